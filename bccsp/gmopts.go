@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+import "io"
+
+const (
+	// GMSM2 is the SM2 elliptic curve signature algorithm defined in
+	// GB/T 32918 (key gen, import, sign, verify)
+	GMSM2 = "GMSM2"
+
+	// GMSM3 is the SM3 cryptographic hash function defined in GB/T 32905
+	GMSM3 = "GMSM3"
+
+	// GMSM4 is the SM4 block cipher defined in GB/T 32907
+	// (key gen, import, encrypt, decrypt)
+	GMSM4 = "GMSM4"
+)
+
+// GMSM2KeyGenOpts contains options for SM2 key generation.
+type GMSM2KeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *GMSM2KeyGenOpts) Algorithm() string {
+	return GMSM2
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *GMSM2KeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// GMSM2PrivateKeyImportOpts contains options for SM2 private key importation
+// in SEC 1, ASN.1 DER format.
+type GMSM2PrivateKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *GMSM2PrivateKeyImportOpts) Algorithm() string {
+	return GMSM2
+}
+
+// Ephemeral returns true if the key generated has to be ephemeral,
+// false otherwise.
+func (opts *GMSM2PrivateKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// GMSM2PublicKeyImportOpts contains options for SM2 public key importation
+// in PKIX, ASN.1 DER format.
+type GMSM2PublicKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *GMSM2PublicKeyImportOpts) Algorithm() string {
+	return GMSM2
+}
+
+// Ephemeral returns true if the key generated has to be ephemeral,
+// false otherwise.
+func (opts *GMSM2PublicKeyImportOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// GMSM3Opts contains options relating to SM3.
+type GMSM3Opts struct {
+}
+
+// Algorithm returns the hash algorithm identifier (to be used).
+func (opts *GMSM3Opts) Algorithm() string {
+	return GMSM3
+}
+
+// GMSM4KeyGenOpts contains options for SM4 key generation.
+type GMSM4KeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *GMSM4KeyGenOpts) Algorithm() string {
+	return GMSM4
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *GMSM4KeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// GMSM4ImportKeyOpts contains options for importing SM4 keys.
+type GMSM4ImportKeyOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (opts *GMSM4ImportKeyOpts) Algorithm() string {
+	return GMSM4
+}
+
+// Ephemeral returns true if the key generated has to be ephemeral,
+// false otherwise.
+func (opts *GMSM4ImportKeyOpts) Ephemeral() bool {
+	return opts.Temporary
+}
+
+// GMSM4CBCPKCS7ModeOpts contains options for SM4 encryption in CBC mode
+// with PKCS7 padding.
+// Notice that both IV and PRNG can be nil. In that case, the BCCSP implementation
+// is supposed to sample the IV using a cryptographic secure PRNG.
+// Notice also that either IV or PRNG can be different from nil.
+type GMSM4CBCPKCS7ModeOpts struct {
+	// IV is the initialization vector to be used by the underlying cipher.
+	// The length of IV must be the same as the Block's block size.
+	// It is used only if different from nil.
+	IV []byte
+	// PRNG is an instance of a PRNG to be used by the underlying cipher.
+	// It is used only if different from nil.
+	PRNG io.Reader
+}
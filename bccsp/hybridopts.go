@@ -0,0 +1,28 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+// HybridKeyGenOpts contains options for hybrid classical+post-quantum key generation.
+type HybridKeyGenOpts struct {
+	Temporary bool
+
+	// PQCScheme names the registered post-quantum signature scheme that
+	// complements the classical key, e.g. "DILITHIUM3". The scheme must
+	// have been registered with the provider before key generation.
+	PQCScheme string
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *HybridKeyGenOpts) Algorithm() string {
+	return HYBRID
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *HybridKeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
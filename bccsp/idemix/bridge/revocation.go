@@ -60,6 +60,10 @@ func (*Revocation) Verify(pk *ecdsa.PublicKey, criRaw []byte, epoch int, alg bcc
 		return err
 	}
 
+	if cri.Epoch != int64(epoch) {
+		return errors.Errorf("CRI is for epoch %d, expected epoch %d", cri.Epoch, epoch)
+	}
+
 	return cryptolib.VerifyEpochPK(
 		pk,
 		cri.EpochPk,
@@ -0,0 +1,208 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("bccsp_kms")
+
+// Client abstracts the subset of a cloud key management service needed by
+// the BCCSP: fetching public material and signing pre-computed digests.
+// Adapters for AWS KMS, GCP Cloud KMS, or Azure Key Vault implement this
+// interface on top of the respective SDK and are supplied at construction
+// time; the BCCSP itself never holds private key material.
+type Client interface {
+	// PublicKey returns the PKIX DER encoding of the public key identified
+	// by keyID.
+	PublicKey(keyID string) ([]byte, error)
+
+	// Sign signs digest with the private key identified by keyID and
+	// returns the signature in the algorithm's conventional encoding
+	// (ASN.1 DER for ECDSA).
+	Sign(keyID string, digest []byte) ([]byte, error)
+}
+
+// New returns a BCCSP that delegates signing to the key management service
+// behind the passed client. All other operations, including verification
+// against the cached public material, are served by a software BCCSP
+// backed by the passed KeyStore.
+func New(client Client, metricsProvider metrics.Provider, keyStore bccsp.KeyStore) (*CSP, error) {
+	if client == nil {
+		return nil, errors.New("Invalid client. It must be different from nil")
+	}
+
+	swCSP, err := sw.NewDefaultSecurityLevelWithKeystore(keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	return &CSP{
+		BCCSP:     swCSP,
+		client:    client,
+		metrics:   NewMetrics(metricsProvider),
+		keysByID:  map[string]*Key{},
+		keysBySKI: map[string]*Key{},
+	}, nil
+}
+
+// CSP is a BCCSP whose private keys live in a cloud key management
+// service. Public material is fetched once per key and cached.
+type CSP struct {
+	bccsp.BCCSP
+
+	client  Client
+	metrics *Metrics
+
+	mutex     sync.RWMutex
+	keysByID  map[string]*Key
+	keysBySKI map[string]*Key
+}
+
+// ResolveKey fetches the public material of the KMS key identified by
+// keyID, caches it, and returns a handle that can be passed to Sign and
+// Verify. Resolving the same keyID again is served from the cache.
+func (csp *CSP) ResolveKey(keyID string) (bccsp.Key, error) {
+	csp.mutex.RLock()
+	key, ok := csp.keysByID[keyID]
+	csp.mutex.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	startTime := time.Now()
+	der, err := csp.client.PublicKey(keyID)
+	csp.metrics.PublicKeyFetchDuration.With(
+		"success", strconv.FormatBool(err == nil),
+	).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed fetching public key for KMS key %s", keyID)
+	}
+
+	lowLevelKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing public key for KMS key %s", keyID)
+	}
+
+	ecdsaPub, ok := lowLevelKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("KMS key %s has an unsupported public key type", keyID)
+	}
+
+	pub, err := csp.BCCSP.KeyImport(ecdsaPub, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed importing public key for KMS key %s", keyID)
+	}
+
+	key = &Key{keyID: keyID, pub: pub, ecdsaPub: ecdsaPub}
+
+	csp.mutex.Lock()
+	if cached, ok := csp.keysByID[keyID]; ok {
+		key = cached
+	} else {
+		csp.keysByID[keyID] = key
+		csp.keysBySKI[hex.EncodeToString(key.SKI())] = key
+	}
+	csp.mutex.Unlock()
+
+	logger.Debugf("resolved KMS key %s with SKI %x", keyID, key.SKI())
+	return key, nil
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *CSP) GetKey(ski []byte) (bccsp.Key, error) {
+	csp.mutex.RLock()
+	key, ok := csp.keysBySKI[hex.EncodeToString(ski)]
+	csp.mutex.RUnlock()
+	if ok {
+		return key, nil
+	}
+	return csp.BCCSP.GetKey(ski)
+}
+
+// Sign signs digest using key k.
+// Signing for KMS-backed keys is delegated to the service; anything else
+// falls through to the software BCCSP.
+func (csp *CSP) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	kmsKey, ok := k.(*Key)
+	if !ok {
+		return csp.BCCSP.Sign(k, digest, opts)
+	}
+
+	startTime := time.Now()
+	signature, err := csp.client.Sign(kmsKey.keyID, digest)
+	csp.metrics.SignDuration.With(
+		"success", strconv.FormatBool(err == nil),
+	).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed signing with KMS key %s", kmsKey.keyID)
+	}
+
+	// Some services return high-S ECDSA signatures; normalize to the
+	// low-S form Fabric requires.
+	return utils.SignatureToLowS(kmsKey.ecdsaPub, signature)
+}
+
+// Verify verifies signature against key k and digest.
+// KMS-backed keys are verified against their cached public material.
+func (csp *CSP) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	if kmsKey, ok := k.(*Key); ok {
+		k = kmsKey.pub
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
+
+// Key is a handle to a private key held by the key management service.
+// Only the public half is available locally.
+type Key struct {
+	keyID    string
+	pub      bccsp.Key
+	ecdsaPub *ecdsa.PublicKey
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *Key) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *Key) SKI() []byte {
+	return k.pub.SKI()
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *Key) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *Key) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *Key) PublicKey() (bccsp.Key, error) {
+	return k.pub, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient serves a single in-memory ECDSA key, standing in for a cloud
+// key management service.
+type fakeClient struct {
+	keyID          string
+	privKey        *ecdsa.PrivateKey
+	publicKeyCalls int
+	signCalls      int
+	publicKeyErr   error
+	signErr        error
+}
+
+func (c *fakeClient) PublicKey(keyID string) ([]byte, error) {
+	c.publicKeyCalls++
+	if c.publicKeyErr != nil {
+		return nil, c.publicKeyErr
+	}
+	if keyID != c.keyID {
+		return nil, errors.New("key not found")
+	}
+	return x509.MarshalPKIXPublicKey(&c.privKey.PublicKey)
+}
+
+func (c *fakeClient) Sign(keyID string, digest []byte) ([]byte, error) {
+	c.signCalls++
+	if c.signErr != nil {
+		return nil, c.signErr
+	}
+	if keyID != c.keyID {
+		return nil, errors.New("key not found")
+	}
+	return c.privKey.Sign(rand.Reader, digest, nil)
+}
+
+func newTestCSP(t *testing.T) (*CSP, *fakeClient, *metricsfakes.Histogram) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	client := &fakeClient{keyID: "projects/test/keys/signer", privKey: privKey}
+
+	histogram := &metricsfakes.Histogram{}
+	histogram.WithReturns(histogram)
+	provider := &metricsfakes.Provider{}
+	provider.NewHistogramReturns(histogram)
+
+	csp, err := New(client, provider, sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	return csp, client, histogram
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(nil, &metricsfakes.Provider{}, sw.NewDummyKeyStore())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid client. It must be different from nil")
+}
+
+func TestResolveKey(t *testing.T) {
+	csp, client, histogram := newTestCSP(t)
+
+	k, err := csp.ResolveKey(client.keyID)
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	assert.NotEmpty(t, k.SKI())
+
+	_, err = k.Bytes()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Not supported.")
+
+	// Public material is cached: resolving again does not hit the service
+	k2, err := csp.ResolveKey(client.keyID)
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+	assert.Equal(t, 1, client.publicKeyCalls)
+	assert.Equal(t, 1, histogram.ObserveCallCount())
+
+	// The resolved key is retrievable by SKI
+	k3, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.Equal(t, k, k3)
+}
+
+func TestResolveKeyErrors(t *testing.T) {
+	csp, client, _ := newTestCSP(t)
+
+	_, err := csp.ResolveKey("no/such/key")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed fetching public key for KMS key no/such/key")
+
+	client.publicKeyErr = errors.New("kaboom")
+	_, err = csp.ResolveKey(client.keyID)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "kaboom")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	csp, client, histogram := newTestCSP(t)
+
+	k, err := csp.ResolveKey(client.keyID)
+	assert.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("Hello World"))
+	signature, err := csp.Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, signature)
+	assert.Equal(t, 1, client.signCalls)
+	assert.Equal(t, 2, histogram.ObserveCallCount())
+
+	// The signature verifies against the KMS key handle as well as its
+	// public half
+	valid, err := csp.Verify(k, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	pub, err := k.PublicKey()
+	assert.NoError(t, err)
+	valid, err = csp.Verify(pub, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(k, signature, []byte("other digest"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestSignError(t *testing.T) {
+	csp, client, _ := newTestCSP(t)
+
+	k, err := csp.ResolveKey(client.keyID)
+	assert.NoError(t, err)
+
+	client.signErr = errors.New("throttled")
+	digest := sha256.Sum256([]byte("Hello World"))
+	_, err = csp.Sign(k, digest[:], nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed signing with KMS key")
+	assert.Contains(t, err.Error(), "throttled")
+}
+
+func TestSoftwareFallback(t *testing.T) {
+	csp, _, _ := newTestCSP(t)
+
+	// Keys that are not KMS handles are served by the software BCCSP
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	digest := sha256.Sum256([]byte("Hello World"))
+	signature, err := csp.Sign(k, digest[:], nil)
+	assert.NoError(t, err)
+
+	valid, err := csp.Verify(k, signature, digest[:], nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var (
+	signDuration = metrics.HistogramOpts{
+		Namespace:    "kms_bccsp",
+		Name:         "sign_duration",
+		Help:         "The time to complete a signing operation against the key management service.",
+		LabelNames:   []string{"success"},
+		StatsdFormat: "%{#fqname}.%{success}",
+	}
+
+	publicKeyFetchDuration = metrics.HistogramOpts{
+		Namespace:    "kms_bccsp",
+		Name:         "public_key_fetch_duration",
+		Help:         "The time to fetch public key material from the key management service.",
+		LabelNames:   []string{"success"},
+		StatsdFormat: "%{#fqname}.%{success}",
+	}
+)
+
+type Metrics struct {
+	SignDuration           metrics.Histogram
+	PublicKeyFetchDuration metrics.Histogram
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		SignDuration:           p.NewHistogram(signDuration),
+		PublicKeyFetchDuration: p.NewHistogram(publicKeyFetchDuration),
+	}
+}
@@ -22,6 +22,13 @@ const (
 	// ECDSAReRand ECDSA key re-randomization
 	ECDSAReRand = "ECDSA_RERAND"
 
+	// ED25519 Edwards-curve Digital Signature Algorithm (key gen, import, sign, verify)
+	ED25519 = "ED25519"
+
+	// HYBRID is a composite signature scheme that pairs a classical ECDSA key
+	// with a post-quantum key; signatures carry both components and both must verify
+	HYBRID = "HYBRID"
+
 	// AES Advanced Encryption Standard at the default security level.
 	// Each BCCSP may or may not support default security level. If not supported than
 	// an error will be returned.
@@ -81,4 +81,7 @@ type PKCS11Opts struct {
 	Pin        string `mapstructure:"pin" json:"pin"`
 	SoftVerify bool   `mapstructure:"softwareverify,omitempty" json:"softwareverify,omitempty"`
 	Immutable  bool   `mapstructure:"immutable,omitempty" json:"immutable,omitempty"`
+	// SessionCacheSize bounds the number of idle sessions kept in the
+	// session pool; it defaults to 10 when unset or non-positive.
+	SessionCacheSize int `mapstructure:"sessioncachesize,omitempty" json:"sessioncachesize,omitempty"`
 }
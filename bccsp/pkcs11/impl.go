@@ -10,22 +10,31 @@ import (
 	"crypto/ecdsa"
 	"crypto/x509"
 	"os"
+	"sync"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/miekg/pkcs11"
 	"github.com/pkg/errors"
 )
 
 var (
-	logger           = flogging.MustGetLogger("bccsp_p11")
-	sessionCacheSize = 10
+	logger                  = flogging.MustGetLogger("bccsp_p11")
+	defaultSessionCacheSize = 10
 )
 
 // New WithParams returns a new instance of the software-based BCCSP
 // set at the passed security level, hash family and KeyStore.
 func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
+	return NewWithMetrics(opts, keyStore, &disabled.Provider{})
+}
+
+// NewWithMetrics behaves as New and additionally emits session pool
+// metrics through the passed metrics provider.
+func NewWithMetrics(opts PKCS11Opts, keyStore bccsp.KeyStore, metricsProvider metrics.Provider) (bccsp.BCCSP, error) {
 	// Init config
 	conf := &config{}
 	err := conf.setSecurityLevel(opts.SecLevel, opts.HashFamily)
@@ -52,8 +61,25 @@ func New(opts PKCS11Opts, keyStore bccsp.KeyStore) (bccsp.BCCSP, error) {
 			lib, label)
 	}
 
-	sessions := make(chan pkcs11.SessionHandle, sessionCacheSize)
-	csp := &impl{swCSP, conf, ctx, sessions, slot, lib, opts.SoftVerify, opts.Immutable}
+	sessionCacheSize := defaultSessionCacheSize
+	if opts.SessionCacheSize > 0 {
+		sessionCacheSize = opts.SessionCacheSize
+	}
+
+	csp := &impl{
+		BCCSP:      swCSP,
+		conf:       conf,
+		ctx:        ctx,
+		sessPool:   make(chan pkcs11.SessionHandle, sessionCacheSize),
+		sessions:   map[pkcs11.SessionHandle]struct{}{},
+		slot:       slot,
+		pin:        pin,
+		lib:        lib,
+		softVerify: opts.SoftVerify,
+		immutable:  opts.Immutable,
+		metrics:    NewMetrics(metricsProvider),
+	}
+	csp.sessions[*session] = struct{}{}
 	csp.returnSession(*session)
 	return csp, nil
 }
@@ -63,9 +89,15 @@ type impl struct {
 
 	conf *config
 
-	ctx      *pkcs11.Ctx
-	sessions chan pkcs11.SessionHandle
-	slot     uint
+	ctx  *pkcs11.Ctx
+	slot uint
+	pin  string
+
+	sessLock sync.Mutex
+	sessPool chan pkcs11.SessionHandle
+	sessions map[pkcs11.SessionHandle]struct{}
+
+	metrics *Metrics
 
 	lib        string
 	softVerify bool
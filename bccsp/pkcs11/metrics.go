@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var sessionErrors = metrics.CounterOpts{
+	Namespace:    "pkcs11_bccsp",
+	Name:         "session_errors",
+	Help:         "The number of PKCS11 sessions discarded by the session pool because of failures.",
+	LabelNames:   []string{"operation"},
+	StatsdFormat: "%{#fqname}.%{operation}",
+}
+
+type Metrics struct {
+	SessionErrors metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		SessionErrors: p.NewCounter(sessionErrors),
+	}
+}
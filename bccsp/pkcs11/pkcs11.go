@@ -14,7 +14,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/miekg/pkcs11"
 	"go.uber.org/zap/zapcore"
@@ -81,47 +83,122 @@ func loadLib(lib, pin, label string) (*pkcs11.Ctx, uint, *pkcs11.SessionHandle,
 	return ctx, slot, &session, nil
 }
 
-func (csp *impl) getSession() (session pkcs11.SessionHandle) {
-	select {
-	case session = <-csp.sessions:
-		logger.Debugf("Reusing existing pkcs11 session %+v on slot %d\n", session, csp.slot)
+func (csp *impl) getSession() (session pkcs11.SessionHandle, err error) {
+	for {
+		select {
+		case session = <-csp.sessPool:
+			logger.Debugf("Reusing existing pkcs11 session %+v on slot %d\n", session, csp.slot)
 
-	default:
-		// cache is empty (or completely in use), create a new session
-		var s pkcs11.SessionHandle
-		var err error
-		for i := 0; i < 10; i++ {
-			s, err = csp.ctx.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
-			if err != nil {
-				logger.Warningf("OpenSession failed, retrying [%s]\n", err)
-			} else {
-				break
-			}
+		default:
+			// pool is empty (or completely in use), create a new session
+			return csp.createSession()
 		}
-		if err != nil {
-			panic(fmt.Errorf("OpenSession failed [%s]", err))
+
+		// Health check the session before handing it out; cached sessions
+		// go stale when the HSM restarts or drops its connections.
+		_, err = csp.ctx.GetSessionInfo(session)
+		if err == nil {
+			return session, nil
 		}
-		logger.Debugf("Created new pkcs11 session %+v on slot %d\n", s, csp.slot)
-		session = s
+
+		logger.Warningf("Cached pkcs11 session %+v is unusable [%s], discarding it\n", session, err)
+		csp.metrics.SessionErrors.With("operation", "health_check").Add(1)
+		csp.closeSession(session)
 	}
-	return session
+}
+
+func (csp *impl) createSession() (pkcs11.SessionHandle, error) {
+	var session pkcs11.SessionHandle
+	var err error
+	// attempt to open a session with a 100ms delay after each attempt
+	for i := 0; i < 10; i++ {
+		session, err = csp.ctx.OpenSession(csp.slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+		if err == nil {
+			logger.Debugf("Created new pkcs11 session %+v on slot %d\n", session, csp.slot)
+			break
+		}
+		logger.Warningf("OpenSession failed, retrying [%s]\n", err)
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
+		csp.metrics.SessionErrors.With("operation", "open").Add(1)
+		return 0, fmt.Errorf("OpenSession failed [%s]", err)
+	}
+
+	csp.sessLock.Lock()
+	csp.sessions[session] = struct{}{}
+	csp.sessLock.Unlock()
+
+	// Login on the fresh session. After an HSM restart every session is
+	// gone along with the login state, so this is what transparently logs
+	// the application back in.
+	err = csp.ctx.Login(session, pkcs11.CKU_USER, csp.pin)
+	if err != nil && err != pkcs11.Error(pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		csp.metrics.SessionErrors.With("operation", "login").Add(1)
+		csp.closeSession(session)
+		return 0, fmt.Errorf("Login failed [%s]", err)
+	}
+
+	return session, nil
+}
+
+func (csp *impl) closeSession(session pkcs11.SessionHandle) {
+	if err := csp.ctx.CloseSession(session); err != nil {
+		logger.Debugf("CloseSession failed [%s]\n", err)
+	}
+
+	csp.sessLock.Lock()
+	delete(csp.sessions, session)
+	csp.sessLock.Unlock()
 }
 
 func (csp *impl) returnSession(session pkcs11.SessionHandle) {
 	select {
-	case csp.sessions <- session:
+	case csp.sessPool <- session:
 		// returned session back to session cache
 	default:
 		// have plenty of sessions in cache, dropping
-		csp.ctx.CloseSession(session)
+		csp.closeSession(session)
+	}
+}
+
+// sessionErrorStrings are the PKCS11 return values that indicate the
+// session itself has become unusable rather than the operation merely
+// failing.
+var sessionErrorStrings = []string{
+	"CKR_SESSION_HANDLE_INVALID",
+	"CKR_SESSION_CLOSED",
+	"CKR_DEVICE_ERROR",
+	"CKR_DEVICE_REMOVED",
+	"CKR_TOKEN_NOT_PRESENT",
+}
+
+// handleSessionReturn returns the session to the pool unless err marks
+// the session as unusable, in which case it is discarded and a fresh
+// session will be created on demand.
+func (csp *impl) handleSessionReturn(err error, session pkcs11.SessionHandle) {
+	if err != nil {
+		for _, sessionError := range sessionErrorStrings {
+			if strings.Contains(err.Error(), sessionError) {
+				logger.Warningf("Detected pkcs11 session error [%s], discarding session %+v\n", err, session)
+				csp.metrics.SessionErrors.With("operation", "use").Add(1)
+				csp.closeSession(session)
+				return
+			}
+		}
 	}
+	csp.returnSession(session)
 }
 
 // Look for an EC key by SKI, stored in CKA_ID
 func (csp *impl) getECKey(ski []byte) (pubKey *ecdsa.PublicKey, isPriv bool, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
+
 	isPriv = true
 	_, err = findKeyPairFromSKI(p11lib, session, ski, privateKeyType)
 	if err != nil {
@@ -196,8 +273,11 @@ func namedCurveFromOID(oid asn1.ObjectIdentifier) elliptic.Curve {
 
 func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski []byte, pubKey *ecdsa.PublicKey, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
 
 	id := nextIDCtr()
 	publabel := fmt.Sprintf("BCPUB%s", id.Text(16))
@@ -312,8 +392,11 @@ func (csp *impl) generateECKey(curve asn1.ObjectIdentifier, ephemeral bool) (ski
 
 func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	session, err := csp.getSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
 
 	privateKey, err := findKeyPairFromSKI(p11lib, session, ski, privateKeyType)
 	if err != nil {
@@ -340,10 +423,13 @@ func (csp *impl) signP11ECDSA(ski []byte, msg []byte) (R, S *big.Int, err error)
 	return R, S, nil
 }
 
-func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize int) (bool, error) {
+func (csp *impl) verifyP11ECDSA(ski []byte, msg []byte, R, S *big.Int, byteSize int) (valid bool, err error) {
 	p11lib := csp.ctx
-	session := csp.getSession()
-	defer csp.returnSession(session)
+	session, err := csp.getSession()
+	if err != nil {
+		return false, err
+	}
+	defer func() { csp.handleSessionReturn(err, session) }()
 
 	logger.Debugf("Verify ECDSA\n")
 
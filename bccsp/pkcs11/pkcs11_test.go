@@ -11,6 +11,8 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"encoding/asn1"
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -89,11 +91,13 @@ func TestPKCS11GetSession(t *testing.T) {
 		t.Skip("Skipping TestPKCS11GetSession")
 	}
 	var sessions []pkcs11.SessionHandle
-	for i := 0; i < 3*sessionCacheSize; i++ {
-		sessions = append(sessions, currentBCCSP.(*impl).getSession())
+	for i := 0; i < 3*defaultSessionCacheSize; i++ {
+		session, err := currentBCCSP.(*impl).getSession()
+		assert.NoError(t, err)
+		sessions = append(sessions, session)
 	}
 
-	// Return all sessions, should leave sessionCacheSize cached
+	// Return all sessions, should leave defaultSessionCacheSize cached
 	for _, session := range sessions {
 		currentBCCSP.(*impl).returnSession(session)
 	}
@@ -103,15 +107,17 @@ func TestPKCS11GetSession(t *testing.T) {
 	oldSlot := currentBCCSP.(*impl).slot
 	currentBCCSP.(*impl).slot = ^uint(0)
 
-	// Should be able to get sessionCacheSize cached sessions
-	for i := 0; i < sessionCacheSize; i++ {
-		sessions = append(sessions, currentBCCSP.(*impl).getSession())
+	// Should be able to get defaultSessionCacheSize cached sessions
+	for i := 0; i < defaultSessionCacheSize; i++ {
+		session, err := currentBCCSP.(*impl).getSession()
+		assert.NoError(t, err)
+		sessions = append(sessions, session)
 	}
 
 	// This one should fail
-	assert.Panics(t, func() {
-		currentBCCSP.(*impl).getSession()
-	}, "Should not been able to create another session")
+	_, err := currentBCCSP.(*impl).getSession()
+	assert.Error(t, err, "Should not been able to create another session")
+	assert.Contains(t, err.Error(), "OpenSession failed")
 
 	// Cleanup
 	for _, session := range sessions {
@@ -120,6 +126,27 @@ func TestPKCS11GetSession(t *testing.T) {
 	currentBCCSP.(*impl).slot = oldSlot
 }
 
+func TestPKCS11HandleSessionReturn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping TestPKCS11HandleSessionReturn")
+	}
+	csp := currentBCCSP.(*impl)
+
+	// An operational error does not invalidate the session
+	session, err := csp.getSession()
+	assert.NoError(t, err)
+	csp.handleSessionReturn(errors.New("some transient failure"), session)
+	_, err = csp.ctx.GetSessionInfo(session)
+	assert.NoError(t, err, "Session should have been returned to the pool")
+
+	// A session error closes the session so a fresh one is created on demand
+	session, err = csp.getSession()
+	assert.NoError(t, err)
+	csp.handleSessionReturn(fmt.Errorf("P11: sign failed [%s]", pkcs11.Error(pkcs11.CKR_SESSION_HANDLE_INVALID)), session)
+	_, err = csp.ctx.GetSessionInfo(session)
+	assert.Error(t, err, "Session should have been closed")
+}
+
 func TestPKCS11ECKeySignVerify(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping TestPKCS11ECKeySignVerify")
@@ -8,6 +8,7 @@ package signer
 
 import (
 	"crypto"
+	"encoding/hex"
 	"io"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -55,6 +56,25 @@ func New(csp bccsp.BCCSP, key bccsp.Key) (crypto.Signer, error) {
 	return &bccspCryptoSigner{csp, key, pk}, nil
 }
 
+// NewFromSKI returns a new BCCSP-based crypto.Signer for the key with the
+// given hex-encoded SKI held by the passed BCCSP instance. It gives access,
+// through the standard crypto.Signer interface, to keys that never leave
+// the BCCSP, such as keys stored in an HSM behind PKCS11.
+func NewFromSKI(csp bccsp.BCCSP, skiHex string) (crypto.Signer, error) {
+	if csp == nil {
+		return nil, errors.New("bccsp instance must be different from nil.")
+	}
+	ski, err := hex.DecodeString(skiHex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SKI [%s]", skiHex)
+	}
+	key, err := csp.GetKey(ski)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting key with SKI [%s]", skiHex)
+	}
+	return New(csp, key)
+}
+
 // Public returns the public key corresponding to the opaque,
 // private key.
 func (s *bccspCryptoSigner) Public() crypto.PublicKey {
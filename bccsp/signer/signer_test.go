@@ -19,10 +19,13 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/mocks"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -119,3 +122,26 @@ func TestSign(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, err.Error(), "invalid opts")
 }
+
+func TestNewFromSKI(t *testing.T) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewInMemoryKeyStore())
+	assert.NoError(t, err)
+	k, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	assert.NoError(t, err)
+
+	signer, err := NewFromSKI(csp, hex.EncodeToString(k.SKI()))
+	assert.NoError(t, err)
+	assert.NotNil(t, signer)
+	assert.NotNil(t, signer.Public())
+
+	_, err = NewFromSKI(nil, hex.EncodeToString(k.SKI()))
+	assert.Error(t, err)
+
+	_, err = NewFromSKI(csp, "not hex")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid SKI [not hex]")
+
+	_, err = NewFromSKI(csp, hex.EncodeToString([]byte("unknown")))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed getting key with SKI")
+}
@@ -13,6 +13,9 @@ import (
 	"fmt"
 	"hash"
 
+	"github.com/hyperledger/fabric/common/fips"
+	"github.com/hyperledger/fabric/gm/sm2"
+	"github.com/hyperledger/fabric/gm/sm3"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -28,6 +31,11 @@ func (conf *config) setSecurityLevel(securityLevel int, hashFamily string) (err
 		err = conf.setSecurityLevelSHA2(securityLevel)
 	case "SHA3":
 		err = conf.setSecurityLevelSHA3(securityLevel)
+	case "GMSM3":
+		if fips.Enabled() {
+			return fmt.Errorf("Hash Family not FIPS-approved [%s]", hashFamily)
+		}
+		err = conf.setSecurityLevelGMSM3(securityLevel)
 	default:
 		err = fmt.Errorf("Hash Family not supported [%s]", hashFamily)
 	}
@@ -50,6 +58,20 @@ func (conf *config) setSecurityLevelSHA2(level int) (err error) {
 	return
 }
 
+// setSecurityLevelGMSM3 configures the provider for the Chinese national
+// (GM) algorithms: keys default to the SM2 curve and hashing to SM3.
+func (conf *config) setSecurityLevelGMSM3(level int) (err error) {
+	switch level {
+	case 256:
+		conf.ellipticCurve = sm2.P256Sm2()
+		conf.hashFunction = sm3.New
+		conf.aesBitLength = 32
+	default:
+		err = fmt.Errorf("Security level not supported [%d]", level)
+	}
+	return
+}
+
 func (conf *config) setSecurityLevelSHA3(level int) (err error) {
 	switch level {
 	case 256:
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// Ed25519 signs the message itself rather than a pre-computed digest of it.
+// Callers are therefore expected to pass the full message as the digest
+// argument; the MSP takes care of this for Ed25519-backed identities.
+
+func signED25519(k ed25519.PrivateKey, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	return ed25519.Sign(k, digest), nil
+}
+
+func verifyED25519(k ed25519.PublicKey, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return ed25519.Verify(k, digest, signature), nil
+}
+
+type ed25519Signer struct{}
+
+func (s *ed25519Signer) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	return signED25519(k.(*ed25519PrivateKey).privKey, digest, opts)
+}
+
+type ed25519PrivateKeyVerifier struct{}
+
+func (v *ed25519PrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return verifyED25519(k.(*ed25519PrivateKey).privKey.Public().(ed25519.PublicKey), signature, digest, opts)
+}
+
+type ed25519PublicKeyKeyVerifier struct{}
+
+func (v *ed25519PublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return verifyED25519(k.(*ed25519PublicKey).pubKey, signature, digest, opts)
+}
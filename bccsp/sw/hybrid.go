@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+)
+
+// PQCScheme abstracts the post-quantum half of a hybrid signature suite.
+// No scheme ships with the library itself: deployments register the
+// candidate algorithm they trust (e.g. Dilithium) at startup, before any
+// hybrid keys are generated or verified.
+type PQCScheme interface {
+	// Name returns the scheme identifier, e.g. "DILITHIUM3".
+	Name() string
+
+	// KeyGen generates a fresh key pair in the scheme's native encoding.
+	KeyGen() (priv, pub []byte, err error)
+
+	// Sign signs digest with the private key priv.
+	Sign(priv, digest []byte) ([]byte, error)
+
+	// Verify verifies signature over digest against the public key pub.
+	Verify(pub, digest, signature []byte) (bool, error)
+}
+
+var (
+	pqcSchemesMutex sync.RWMutex
+	pqcSchemes      = map[string]PQCScheme{}
+)
+
+// RegisterPQCScheme makes the passed post-quantum scheme available for
+// hybrid key generation and verification.
+func RegisterPQCScheme(scheme PQCScheme) error {
+	if scheme == nil || scheme.Name() == "" {
+		return errors.New("invalid post-quantum scheme, it must be non-nil and named")
+	}
+
+	pqcSchemesMutex.Lock()
+	defer pqcSchemesMutex.Unlock()
+
+	if _, ok := pqcSchemes[scheme.Name()]; ok {
+		return errors.Errorf("post-quantum scheme %s already registered", scheme.Name())
+	}
+	pqcSchemes[scheme.Name()] = scheme
+
+	return nil
+}
+
+func getPQCScheme(name string) (PQCScheme, error) {
+	pqcSchemesMutex.RLock()
+	defer pqcSchemesMutex.RUnlock()
+
+	scheme, ok := pqcSchemes[name]
+	if !ok {
+		return nil, errors.Errorf("post-quantum scheme %s is not registered", name)
+	}
+	return scheme, nil
+}
+
+// hybridSignature is the ASN.1 envelope carrying both signature components.
+type hybridSignature struct {
+	Classical []byte
+	PQC       []byte
+}
+
+func marshalHybridSignature(classical, pqc []byte) ([]byte, error) {
+	return asn1.Marshal(hybridSignature{Classical: classical, PQC: pqc})
+}
+
+func unmarshalHybridSignature(raw []byte) (classical, pqc []byte, err error) {
+	sig := new(hybridSignature)
+	_, err = asn1.Unmarshal(raw, sig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed unmarshalling hybrid signature [%s]", err)
+	}
+	return sig.Classical, sig.PQC, nil
+}
+
+func signHybrid(k *hybridPrivateKey, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	classical, err := signECDSA(k.classical, digest, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := getPQCScheme(k.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	pqc, err := scheme.Sign(k.pqcPriv, digest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating %s signature [%s]", k.scheme, err)
+	}
+
+	return marshalHybridSignature(classical, pqc)
+}
+
+func verifyHybrid(classicalPK *ecdsa.PublicKey, schemeName string, pqcPub, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	classical, pqcSig, err := unmarshalHybridSignature(signature)
+	if err != nil {
+		return false, err
+	}
+
+	valid, err := verifyECDSA(classicalPK, classical, digest, opts)
+	if err != nil || !valid {
+		return valid, err
+	}
+
+	scheme, err := getPQCScheme(schemeName)
+	if err != nil {
+		return false, err
+	}
+
+	return scheme.Verify(pqcPub, digest, pqcSig)
+}
+
+type hybridSigner struct{}
+
+func (s *hybridSigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	return signHybrid(k.(*hybridPrivateKey), digest, opts)
+}
+
+type hybridPrivateKeyVerifier struct{}
+
+func (v *hybridPrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	sk := k.(*hybridPrivateKey)
+	return verifyHybrid(&sk.classical.PublicKey, sk.scheme, sk.pqcPub, signature, digest, opts)
+}
+
+type hybridPublicKeyKeyVerifier struct{}
+
+func (v *hybridPublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	pk := k.(*hybridPublicKey)
+	return verifyHybrid(pk.classical, pk.scheme, pk.pqcPub, signature, digest, opts)
+}
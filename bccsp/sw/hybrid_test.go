@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePQCScheme stands in for a real post-quantum implementation: the
+// public key mirrors the private key and signatures are hashes over both
+// the key and the digest.
+type fakePQCScheme struct {
+	name string
+}
+
+func (s *fakePQCScheme) Name() string {
+	return s.name
+}
+
+func (s *fakePQCScheme) KeyGen() ([]byte, []byte, error) {
+	priv := make([]byte, 32)
+	if _, err := rand.Read(priv); err != nil {
+		return nil, nil, err
+	}
+	pub := make([]byte, len(priv))
+	copy(pub, priv)
+	return priv, pub, nil
+}
+
+func (s *fakePQCScheme) Sign(priv, digest []byte) ([]byte, error) {
+	hash := sha256.New()
+	hash.Write(priv)
+	hash.Write(digest)
+	return hash.Sum(nil), nil
+}
+
+func (s *fakePQCScheme) Verify(pub, digest, signature []byte) (bool, error) {
+	expected, err := s.Sign(pub, digest)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(expected, signature), nil
+}
+
+// registerFakePQCScheme registers a fake scheme under the given name unless
+// an earlier run already did: TestMain runs the tests once per hash family
+// and security level, while the scheme registry is package global.
+func registerFakePQCScheme(t *testing.T, name string) {
+	if _, err := getPQCScheme(name); err == nil {
+		return
+	}
+	assert.NoError(t, RegisterPQCScheme(&fakePQCScheme{name: name}))
+}
+
+func TestRegisterPQCScheme(t *testing.T) {
+	err := RegisterPQCScheme(nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "it must be non-nil and named")
+
+	err = RegisterPQCScheme(&fakePQCScheme{name: ""})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "it must be non-nil and named")
+
+	registerFakePQCScheme(t, "FAKE-REGISTER")
+
+	err = RegisterPQCScheme(&fakePQCScheme{name: "FAKE-REGISTER"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "post-quantum scheme FAKE-REGISTER already registered")
+}
+
+func TestHybridKeyGenerator(t *testing.T) {
+	registerFakePQCScheme(t, "FAKE-KEYGEN")
+
+	kg := &hybridKeyGenerator{curve: elliptic.P256()}
+
+	_, err := kg.KeyGen(nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid opts. Expected *bccsp.HybridKeyGenOpts.")
+
+	_, err = kg.KeyGen(&bccsp.HybridKeyGenOpts{PQCScheme: "UNREGISTERED"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "post-quantum scheme UNREGISTERED is not registered")
+
+	k, err := kg.KeyGen(&bccsp.HybridKeyGenOpts{PQCScheme: "FAKE-KEYGEN"})
+	assert.NoError(t, err)
+
+	hybridK, ok := k.(*hybridPrivateKey)
+	assert.True(t, ok)
+	assert.NotNil(t, hybridK.classical)
+	assert.Equal(t, "FAKE-KEYGEN", hybridK.scheme)
+	assert.NotEmpty(t, hybridK.pqcPriv)
+	assert.NotEmpty(t, hybridK.pqcPub)
+}
+
+func TestHybridSignerSign(t *testing.T) {
+	registerFakePQCScheme(t, "FAKE-SIGN")
+
+	signer := &hybridSigner{}
+	verifierPrivateKey := &hybridPrivateKeyVerifier{}
+	verifierPublicKey := &hybridPublicKeyKeyVerifier{}
+
+	kg := &hybridKeyGenerator{curve: elliptic.P256()}
+	key, err := kg.KeyGen(&bccsp.HybridKeyGenOpts{PQCScheme: "FAKE-SIGN"})
+	assert.NoError(t, err)
+	k := key.(*hybridPrivateKey)
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+
+	// Sign
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(k, msg, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, sigma)
+
+	// Verify
+	valid, err := verifierPrivateKey.Verify(k, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = verifierPublicKey.Verify(pk, sigma, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// A hybrid signature is invalid when either component does not verify
+	valid, err = verifierPublicKey.Verify(pk, sigma, []byte("Hello WorlD"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	classical, pqcSig, err := unmarshalHybridSignature(sigma)
+	assert.NoError(t, err)
+	pqcSig[0] ^= 0xff
+	tampered, err := marshalHybridSignature(classical, pqcSig)
+	assert.NoError(t, err)
+	valid, err = verifierPublicKey.Verify(pk, tampered, msg, nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	_, err = verifierPublicKey.Verify(pk, []byte("not a hybrid signature"), msg, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed unmarshalling hybrid signature [")
+}
+
+func TestHybridPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	lowLevelKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := &hybridPrivateKey{lowLevelKey, "FAKE", []byte("pqc-priv"), []byte("pqc-pub")}
+
+	assert.False(t, k.Symmetric())
+	assert.True(t, k.Private())
+
+	_, err = k.Bytes()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Not supported.")
+
+	k.classical = nil
+	ski := k.SKI()
+	assert.Nil(t, ski)
+
+	k.classical = lowLevelKey
+	ski = k.SKI()
+	assert.Equal(t, hybridSKI(&lowLevelKey.PublicKey, "FAKE", []byte("pqc-pub")), ski)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.NotNil(t, pk)
+	hybridPK, ok := pk.(*hybridPublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, &lowLevelKey.PublicKey, hybridPK.classical)
+	assert.Equal(t, k.SKI(), hybridPK.SKI())
+}
+
+func TestHybridPublicKey(t *testing.T) {
+	t.Parallel()
+
+	lowLevelKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k := &hybridPublicKey{&lowLevelKey.PublicKey, "FAKE", []byte("pqc-pub")}
+
+	assert.False(t, k.Symmetric())
+	assert.False(t, k.Private())
+
+	k.classical = nil
+	ski := k.SKI()
+	assert.Nil(t, ski)
+
+	k.classical = &lowLevelKey.PublicKey
+	ski = k.SKI()
+	assert.Equal(t, hybridSKI(&lowLevelKey.PublicKey, "FAKE", []byte("pqc-pub")), ski)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k, pk)
+
+	raw, err := k.Bytes()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, raw)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+type hybridPrivateKey struct {
+	classical *ecdsa.PrivateKey
+	scheme    string
+	pqcPriv   []byte
+	pqcPub    []byte
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *hybridPrivateKey) Bytes() ([]byte, error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *hybridPrivateKey) SKI() []byte {
+	if k.classical == nil {
+		return nil
+	}
+
+	return hybridSKI(&k.classical.PublicKey, k.scheme, k.pqcPub)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *hybridPrivateKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *hybridPrivateKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *hybridPrivateKey) PublicKey() (bccsp.Key, error) {
+	return &hybridPublicKey{&k.classical.PublicKey, k.scheme, k.pqcPub}, nil
+}
+
+type hybridPublicKey struct {
+	classical *ecdsa.PublicKey
+	scheme    string
+	pqcPub    []byte
+}
+
+// hybridPublicKeyASN is the ASN.1 envelope carrying both public key components.
+type hybridPublicKeyASN struct {
+	Classical []byte
+	Scheme    string
+	PQC       []byte
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *hybridPublicKey) Bytes() (raw []byte, err error) {
+	classical, err := x509.MarshalPKIXPublicKey(k.classical)
+	if err != nil {
+		return nil, fmt.Errorf("Failed marshalling key [%s]", err)
+	}
+
+	return asn1.Marshal(hybridPublicKeyASN{Classical: classical, Scheme: k.scheme, PQC: k.pqcPub})
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *hybridPublicKey) SKI() []byte {
+	if k.classical == nil {
+		return nil
+	}
+
+	return hybridSKI(k.classical, k.scheme, k.pqcPub)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *hybridPublicKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *hybridPublicKey) Private() bool {
+	return false
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *hybridPublicKey) PublicKey() (bccsp.Key, error) {
+	return k, nil
+}
+
+func hybridSKI(classical *ecdsa.PublicKey, scheme string, pqcPub []byte) []byte {
+	// Hash the marshaled classical public key together with the
+	// post-quantum component
+	raw := elliptic.Marshal(classical.Curve, classical.X, classical.Y)
+	hash := sha256.New()
+	hash.Write(raw)
+	hash.Write([]byte(scheme))
+	hash.Write(pqcPub)
+	return hash.Sum(nil)
+}
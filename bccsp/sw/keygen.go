@@ -18,8 +18,10 @@ package sw
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/fabric/bccsp"
@@ -38,6 +40,45 @@ func (kg *ecdsaKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
 	return &ecdsaPrivateKey{privKey}, nil
 }
 
+type ed25519KeyGenerator struct{}
+
+func (kg *ed25519KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating ED25519 key: [%s]", err)
+	}
+
+	return &ed25519PrivateKey{privKey}, nil
+}
+
+type hybridKeyGenerator struct {
+	curve elliptic.Curve
+}
+
+func (kg *hybridKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	hybridOpts, ok := opts.(*bccsp.HybridKeyGenOpts)
+	if !ok {
+		return nil, errors.New("Invalid opts. Expected *bccsp.HybridKeyGenOpts.")
+	}
+
+	scheme, err := getPQCScheme(hybridOpts.PQCScheme)
+	if err != nil {
+		return nil, err
+	}
+
+	classical, err := ecdsa.GenerateKey(kg.curve, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating ECDSA key for [%v]: [%s]", kg.curve, err)
+	}
+
+	pqcPriv, pqcPub, err := scheme.KeyGen()
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating %s key: [%s]", scheme.Name(), err)
+	}
+
+	return &hybridPrivateKey{classical, scheme.Name(), pqcPriv, pqcPub}, nil
+}
+
 type aesKeyGenerator struct {
 	length int
 }
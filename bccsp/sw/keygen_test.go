@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package sw
 
 import (
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"errors"
 	"reflect"
@@ -61,6 +62,20 @@ func TestECDSAKeyGenerator(t *testing.T) {
 	assert.Equal(t, ecdsaK.privKey.Curve, elliptic.P256())
 }
 
+func TestED25519KeyGenerator(t *testing.T) {
+	t.Parallel()
+
+	kg := &ed25519KeyGenerator{}
+
+	k, err := kg.KeyGen(nil)
+	assert.NoError(t, err)
+
+	ed25519K, ok := k.(*ed25519PrivateKey)
+	assert.True(t, ok)
+	assert.NotNil(t, ed25519K.privKey)
+	assert.Equal(t, ed25519.PrivateKeySize, len(ed25519K.privKey))
+}
+
 func TestAESKeyGenerator(t *testing.T) {
 	t.Parallel()
 
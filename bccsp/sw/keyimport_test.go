@@ -17,6 +17,7 @@ limitations under the License.
 package sw
 
 import (
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -175,6 +176,28 @@ func TestECDSAGoPublicKeyImportOptsKeyImporter(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid raw material. Expected *ecdsa.PublicKey.")
 }
 
+func TestED25519GoPublicKeyImportOptsKeyImporter(t *testing.T) {
+	t.Parallel()
+
+	ki := ed25519GoPublicKeyImportOptsKeyImporter{}
+
+	_, err := ki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+
+	_, err = ki.KeyImport(nil, &mocks2.KeyImportOpts{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	k, err := ki.KeyImport(pubKey, &mocks2.KeyImportOpts{})
+	assert.NoError(t, err)
+	ed25519PK, ok := k.(*ed25519PublicKey)
+	assert.True(t, ok)
+	assert.Equal(t, pubKey, ed25519PK.pubKey)
+}
+
 func TestX509PublicKeyImportOptsKeyImporter(t *testing.T) {
 	t.Parallel()
 
@@ -192,5 +215,5 @@ func TestX509PublicKeyImportOptsKeyImporter(t *testing.T) {
 	cert.PublicKey = "Hello world"
 	_, err = ki.KeyImport(cert, &mocks2.KeyImportOpts{})
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "Certificate's public key type not recognized. Supported keys: [ECDSA]")
+	assert.Contains(t, err.Error(), "Certificate's public key type not recognized. Supported keys: [ECDSA, ED25519]")
 }
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/gm/sm2"
+	"github.com/pkg/errors"
+)
+
+// The digest an SM2 signature is computed over binds the signer's public
+// key, so it cannot be pre-computed by the caller. Like Ed25519, SM2 keys
+// therefore sign the message itself rather than a hash of it; callers are
+// expected to pass the full message as the digest argument.
+
+type sm2Signer struct{}
+
+func (s *sm2Signer) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	r, ss, err := sm2.Sign(rand.Reader, k.(*sm2PrivateKey).privKey, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.MarshalECDSASignature(r, ss)
+}
+
+func verifySM2(k *ecdsa.PublicKey, signature, digest []byte) (bool, error) {
+	r, s, err := utils.UnmarshalECDSASignature(signature)
+	if err != nil {
+		return false, fmt.Errorf("Failed unmashalling signature [%s]", err)
+	}
+
+	return sm2.Verify(k, digest, r, s), nil
+}
+
+type sm2PrivateKeyVerifier struct{}
+
+func (v *sm2PrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return verifySM2(&k.(*sm2PrivateKey).privKey.PublicKey, signature, digest)
+}
+
+type sm2PublicKeyKeyVerifier struct{}
+
+func (v *sm2PublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	return verifySM2(k.(*sm2PublicKey).pubKey, signature, digest)
+}
+
+type sm2KeyGenerator struct{}
+
+func (kg *sm2KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	privKey, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("Failed generating SM2 key: [%s]", err)
+	}
+
+	return &sm2PrivateKey{privKey}, nil
+}
+
+type sm2PrivateKeyImportOptsKeyImporter struct{}
+
+func (*sm2PrivateKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+	if len(der) == 0 {
+		return nil, errors.New("invalid raw material, it must not be nil")
+	}
+
+	privKey, err := sm2.ParsePrivateKey(der)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed converting to SM2 private key")
+	}
+
+	return &sm2PrivateKey{privKey}, nil
+}
+
+type sm2PublicKeyImportOptsKeyImporter struct{}
+
+func (*sm2PublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+	if len(der) == 0 {
+		return nil, errors.New("invalid raw material, it must not be nil")
+	}
+
+	pubKey, err := sm2.ParsePublicKey(der)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed converting to SM2 public key")
+	}
+
+	return &sm2PublicKey{pubKey}, nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/gm/sm4"
+)
+
+type sm4Key struct {
+	privKey    []byte
+	exportable bool
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *sm4Key) Bytes() (raw []byte, err error) {
+	if k.exportable {
+		return k.privKey, nil
+	}
+
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *sm4Key) SKI() (ski []byte) {
+	hash := sha256.New()
+	hash.Write([]byte{0x02})
+	hash.Write(k.privKey)
+	return hash.Sum(nil)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *sm4Key) Symmetric() bool {
+	return true
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *sm4Key) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *sm4Key) PublicKey() (bccsp.Key, error) {
+	return nil, errors.New("Cannot call this method on a symmetric key.")
+}
+
+type sm4KeyGenerator struct{}
+
+func (kg *sm4KeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (bccsp.Key, error) {
+	lowLevelKey, err := GetRandomBytes(sm4.KeySize)
+	if err != nil {
+		return nil, errors.New("Failed generating SM4 key")
+	}
+
+	return &sm4Key{lowLevelKey, false}, nil
+}
+
+type sm4ImportKeyOptsKeyImporter struct{}
+
+func (*sm4ImportKeyOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	sm4Raw, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("Invalid raw material. Expected byte array.")
+	}
+	if len(sm4Raw) != sm4.KeySize {
+		return nil, errors.New("Invalid Key Length. It must be 16 bytes.")
+	}
+
+	key := make([]byte, sm4.KeySize)
+	copy(key, sm4Raw)
+	return &sm4Key{key, false}, nil
+}
+
+// sm4CBCPKCS7EncryptWithRand combines CBC encryption and PKCS7 padding; the
+// IV is sampled from the passed prng and prepended to the ciphertext.
+func sm4CBCPKCS7EncryptWithRand(prng io.Reader, key, src []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s := pkcs7Padding(src)
+	ciphertext := make([]byte, sm4.BlockSize+len(s))
+	iv := ciphertext[:sm4.BlockSize]
+	if _, err := io.ReadFull(prng, iv); err != nil {
+		return nil, err
+	}
+
+	mode := cipher.NewCBCEncrypter(block, iv)
+	mode.CryptBlocks(ciphertext[sm4.BlockSize:], s)
+
+	return ciphertext, nil
+}
+
+// sm4CBCPKCS7EncryptWithIV combines CBC encryption and PKCS7 padding using
+// the passed IV, which is prepended to the ciphertext.
+func sm4CBCPKCS7EncryptWithIV(IV, key, src []byte) ([]byte, error) {
+	if len(IV) != sm4.BlockSize {
+		return nil, errors.New("Invalid IV. It must have length the block size")
+	}
+
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s := pkcs7Padding(src)
+	ciphertext := make([]byte, sm4.BlockSize+len(s))
+	copy(ciphertext[:sm4.BlockSize], IV)
+
+	mode := cipher.NewCBCEncrypter(block, IV)
+	mode.CryptBlocks(ciphertext[sm4.BlockSize:], s)
+
+	return ciphertext, nil
+}
+
+// sm4CBCPKCS7Decrypt combines CBC decryption and PKCS7 unpadding; the IV is
+// expected at the beginning of the ciphertext.
+func sm4CBCPKCS7Decrypt(key, src []byte) ([]byte, error) {
+	block, err := sm4.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(src) < sm4.BlockSize {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+	iv := src[:sm4.BlockSize]
+	src = src[sm4.BlockSize:]
+
+	if len(src)%sm4.BlockSize != 0 {
+		return nil, errors.New("Invalid ciphertext. It must be a multiple of the block size")
+	}
+
+	pt := make([]byte, len(src))
+	mode := cipher.NewCBCDecrypter(block, iv)
+	mode.CryptBlocks(pt, src)
+
+	return pkcs7UnPadding(pt)
+}
+
+type sm4cbcpkcs7Encryptor struct{}
+
+func (e *sm4cbcpkcs7Encryptor) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) ([]byte, error) {
+	switch o := opts.(type) {
+	case *bccsp.GMSM4CBCPKCS7ModeOpts:
+		// SM4 in CBC mode with PKCS7 padding
+
+		if len(o.IV) != 0 && o.PRNG != nil {
+			return nil, errors.New("Invalid options. Either IV or PRNG should be different from nil, or both nil.")
+		}
+
+		if len(o.IV) != 0 {
+			// Encrypt with the passed IV
+			return sm4CBCPKCS7EncryptWithIV(o.IV, k.(*sm4Key).privKey, plaintext)
+		} else if o.PRNG != nil {
+			// Encrypt with PRNG
+			return sm4CBCPKCS7EncryptWithRand(o.PRNG, k.(*sm4Key).privKey, plaintext)
+		}
+		// SM4 in CBC mode with PKCS7 padding
+		return sm4CBCPKCS7EncryptWithRand(rand.Reader, k.(*sm4Key).privKey, plaintext)
+	case bccsp.GMSM4CBCPKCS7ModeOpts:
+		return e.Encrypt(k, plaintext, &o)
+	default:
+		return nil, errors.New("Mode not recognized")
+	}
+}
+
+type sm4cbcpkcs7Decryptor struct{}
+
+func (*sm4cbcpkcs7Decryptor) Decrypt(k bccsp.Key, ciphertext []byte, opts bccsp.DecrypterOpts) ([]byte, error) {
+	// check for mode
+	switch opts.(type) {
+	case *bccsp.GMSM4CBCPKCS7ModeOpts, bccsp.GMSM4CBCPKCS7ModeOpts:
+		// SM4 in CBC mode with PKCS7 padding
+		return sm4CBCPKCS7Decrypt(k.(*sm4Key).privKey, ciphertext)
+	default:
+		return nil, errors.New("Mode not recognized")
+	}
+}
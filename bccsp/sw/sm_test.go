@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/gm/sm3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSM2SignVerify(t *testing.T) {
+	csp, err := NewWithParams(256, "GMSM3", NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.GMSM2KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.False(t, k.Symmetric())
+	assert.True(t, k.Private())
+
+	msg := []byte("Hello World")
+	signature, err := csp.Sign(k, msg, nil)
+	assert.NoError(t, err)
+
+	valid, err := csp.Verify(k, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// verification with the public key
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	valid, err = csp.Verify(pk, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// verification after a public key DER round trip
+	raw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := csp.KeyImport(raw, &bccsp.GMSM2PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+	valid, err = csp.Verify(pk2, signature, msg, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// a tampered message does not verify
+	valid, err = csp.Verify(pk, signature, []byte("Hello World!"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestGMSM3Hash(t *testing.T) {
+	csp, err := NewWithParams(256, "GMSM3", NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	msg := []byte("Hello World")
+	digest, err := csp.Hash(msg, &bccsp.GMSM3Opts{})
+	assert.NoError(t, err)
+
+	want := sm3.Sum(msg)
+	assert.Equal(t, want[:], digest)
+
+	// the default hasher of a GMSM3 provider is SM3
+	digest, err = csp.Hash(msg, &bccsp.SHAOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, want[:], digest)
+}
+
+func TestSM4EncryptDecrypt(t *testing.T) {
+	csp, err := NewWithParams(256, "GMSM3", NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.GMSM4KeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.True(t, k.Symmetric())
+
+	plaintext := []byte("the quick brown fox jumps over the lazy dog")
+	ciphertext, err := csp.Encrypt(k, plaintext, &bccsp.GMSM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.False(t, bytes.Contains(ciphertext, plaintext))
+
+	decrypted, err := csp.Decrypt(k, ciphertext, &bccsp.GMSM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestSM4KeyImport(t *testing.T) {
+	csp, err := NewWithParams(256, "GMSM3", NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	raw, err := GetRandomBytes(16)
+	assert.NoError(t, err)
+	k, err := csp.KeyImport(raw, &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	ciphertext, err := csp.Encrypt(k, []byte("msg"), &bccsp.GMSM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	decrypted, err := csp.Decrypt(k, ciphertext, &bccsp.GMSM4CBCPKCS7ModeOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("msg"), decrypted)
+
+	_, err = csp.KeyImport(make([]byte, 24), &bccsp.GMSM4ImportKeyOpts{Temporary: true})
+	assert.Error(t, err)
+}
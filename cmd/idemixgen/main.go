@@ -19,6 +19,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/tools/idemixgen/idemixca"
@@ -48,6 +49,11 @@ var (
 	genCredIsAdmin          = genSignerConfig.Flag("admin", "Make the default signer admin").Short('a').Bool()
 	genCredEnrollmentId     = genSignerConfig.Flag("enrollmentId", "The enrollment id of the default signer").Short('e').String()
 	genCredRevocationHandle = genSignerConfig.Flag("revocationHandle", "The handle used to revoke this signer").Short('r').Int()
+	genCredEpoch            = genSignerConfig.Flag("epoch", "The revocation epoch the signer's credential revocation information is issued for").Int()
+
+	genCRI        = app.Command("cri", "Generate credential revocation information for a new epoch and update this Idemix MSP config")
+	genCRICAInput = genCRI.Flag("ca-input", "The folder where CA's secrets are stored").String()
+	genCRIEpoch   = genCRI.Flag("epoch", "The revocation epoch the credential revocation information is issued for").Int()
 
 	version = app.Command("version", "Show version information")
 )
@@ -97,15 +103,16 @@ func main() {
 		if *genCAInput == "" {
 			genCAInput = outputDir
 		}
-		ipk, ipkRaw := readIssuerKey()
-		rsk := readRevocationKey()
-		rpk := readRevocationPublicKey()
+		ipk, ipkRaw := readIssuerKey(*genCAInput)
+		rsk := readRevocationKey(*genCAInput)
+		rpk := readRevocationPublicKey(*genCAInput)
 
 		config, err := idemixca.GenerateSignerConfig(
 			roleMask,
 			*genCredOU,
 			*genCredEnrollmentId,
 			*genCredRevocationHandle,
+			*genCredEpoch,
 			ipk, rsk,
 		)
 		handleError(err)
@@ -124,6 +131,28 @@ func main() {
 			writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipkRaw)
 		}
 
+	case genCRI.FullCommand():
+		if *genCRICAInput == "" {
+			genCRICAInput = outputDir
+		}
+		rsk := readRevocationKey(*genCRICAInput)
+
+		criBytes, err := idemixca.GenerateCRI(*genCRIEpoch, rsk)
+		handleError(err)
+
+		// Record the new epoch so that verifiers building an MSP config
+		// from this directory reject signatures against stale CRI's
+		handleError(os.MkdirAll(filepath.Join(*outputDir, msp.IdemixConfigDirMsp), 0770))
+		writeFile(filepath.Join(*outputDir, msp.IdemixConfigDirMsp, msp.IdemixConfigFileEpoch), []byte(strconv.Itoa(*genCRIEpoch)))
+
+		// Update the CRI of the default signer, if this config has one
+		signerPath := filepath.Join(*outputDir, msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner)
+		if signerBytes, err := ioutil.ReadFile(signerPath); err == nil {
+			config, err := idemixca.UpdateSignerConfigCRI(signerBytes, criBytes)
+			handleError(err)
+			writeFile(signerPath, config)
+		}
+
 	case version.FullCommand():
 		printVersion()
 
@@ -139,14 +168,14 @@ func writeFile(path string, contents []byte) {
 	handleError(ioutil.WriteFile(path, contents, 0640))
 }
 
-// readIssuerKey reads the issuer key from the current directory
-func readIssuerKey() (*idemix.IssuerKey, []byte) {
-	path := filepath.Join(*genCAInput, IdemixDirIssuer, IdemixConfigIssuerSecretKey)
+// readIssuerKey reads the issuer key from the given CA directory
+func readIssuerKey(caInput string) (*idemix.IssuerKey, []byte) {
+	path := filepath.Join(caInput, IdemixDirIssuer, IdemixConfigIssuerSecretKey)
 	isk, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer secret key file: %s", path))
 	}
-	path = filepath.Join(*genCAInput, IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey)
+	path = filepath.Join(caInput, IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey)
 	ipkBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open issuer public key file: %s", path))
@@ -158,8 +187,8 @@ func readIssuerKey() (*idemix.IssuerKey, []byte) {
 	return key, ipkBytes
 }
 
-func readRevocationKey() *ecdsa.PrivateKey {
-	path := filepath.Join(*genCAInput, IdemixDirIssuer, IdemixConfigRevocationKey)
+func readRevocationKey(caInput string) *ecdsa.PrivateKey {
+	path := filepath.Join(caInput, IdemixDirIssuer, IdemixConfigRevocationKey)
 	keyBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open revocation secret key file: %s", path))
@@ -175,8 +204,8 @@ func readRevocationKey() *ecdsa.PrivateKey {
 	return key
 }
 
-func readRevocationPublicKey() []byte {
-	path := filepath.Join(*genCAInput, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey)
+func readRevocationPublicKey(caInput string) []byte {
+	path := filepath.Join(caInput, msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey)
 	keyBytes, err := ioutil.ReadFile(path)
 	if err != nil {
 		handleError(errors.Wrapf(err, "failed to open revocation secret key file: %s", path))
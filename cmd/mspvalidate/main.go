@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+// mspvalidate is a command line tool that checks an MSP folder for the
+// classic assembly mistakes - missing or non-CA root certificates,
+// expired CAs, admin certificates that do not chain to a declared CA,
+// NodeOUs classifications without certifiers, and CAs reused across the
+// signing and TLS roles - and prints one actionable finding per problem.
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/msp"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+// command line flags
+var (
+	app = kingpin.New("mspvalidate", "Utility for checking a Hyperledger Fabric MSP folder for common assembly mistakes")
+
+	mspDir   = app.Arg("mspdir", "The MSP folder to check").Required().String()
+	warnings = app.Flag("warnings-as-errors", "Exit with a non-zero status on warnings as well").Bool()
+)
+
+func main() {
+	app.HelpFlag.Short('h')
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	findings, err := msp.ValidateMSPDir(*mspDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading MSP folder '%s': %s\n", *mspDir, err)
+		os.Exit(1)
+	}
+
+	failed := false
+	for _, finding := range findings {
+		fmt.Println(finding)
+		if finding.Severity == msp.FindingError || *warnings {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	if len(findings) == 0 {
+		fmt.Printf("MSP folder '%s' looks well assembled\n", *mspDir)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accessaudit
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	recorder := NewRecorder(2)
+	recorder.Record(Event{Channel: "mychannel", Policy: "/Channel/Readers", Reason: "denied"})
+	recorder.Record(Event{Channel: "otherchannel", Policy: "/Channel/Writers", Reason: "denied"})
+
+	events := recorder.Events("")
+	assert.Len(t, events, 2)
+	assert.NotEmpty(t, events[0].Timestamp)
+
+	events = recorder.Events("mychannel")
+	assert.Len(t, events, 1)
+	assert.Equal(t, "/Channel/Readers", events[0].Policy)
+
+	// the oldest event is evicted when the buffer is full
+	recorder.Record(Event{Channel: "mychannel", Policy: "/Channel/Admins", Reason: "denied"})
+	events = recorder.Events("")
+	assert.Len(t, events, 2)
+	assert.Equal(t, "/Channel/Writers", events[0].Policy)
+	assert.Equal(t, "/Channel/Admins", events[1].Policy)
+}
+
+func TestDescribeIdentity(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "rejected-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw})
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: certPEM})
+	assert.NoError(t, err)
+	assert.Equal(t, "SampleOrg: CN=rejected-client", DescribeIdentity(creator))
+
+	// identities without an x509 certificate fall back to the MSP identifier
+	creator, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "IdemixOrg", IdBytes: []byte{1, 2, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, "IdemixOrg", DescribeIdentity(creator))
+
+	assert.Equal(t, "(unidentifiable)", DescribeIdentity([]byte("not a serialized identity")))
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler()
+	handler.events = func(channel string) []Event {
+		if channel != "mychannel" {
+			return []Event{}
+		}
+		return []Event{{
+			Timestamp: "2020-01-01T00:00:00Z",
+			Channel:   "mychannel",
+			Policy:    "/Channel/Readers",
+			Reason:    "implicit policy evaluation failed",
+		}}
+	}
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/audit/access?channel=mychannel", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{
+		"timestamp": "2020-01-01T00:00:00Z",
+		"channel": "mychannel",
+		"policy": "/Channel/Readers",
+		"reason": "implicit policy evaluation failed"
+	}]`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/audit/access", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[]`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/audit/access", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
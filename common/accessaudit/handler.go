@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package accessaudit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// NewHandler creates an http.Handler serving the access denial events of the
+// default recorder. A "channel" query parameter restricts the response to a
+// single channel.
+func NewHandler() *Handler {
+	return &Handler{
+		events: Events,
+		logger: flogging.MustGetLogger("accessaudit"),
+	}
+}
+
+// Handler serves the access denial audit trail over HTTP.
+type Handler struct {
+	events func(channel string) []Event
+	logger *flogging.FabricLogger
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(h.events(req.URL.Query().Get("channel"))); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -98,6 +98,18 @@ func TestChannelV20(t *testing.T) {
 	assert.True(t, cp.MSPVersion() == msp.MSPv1_4_3)
 	assert.True(t, cp.ConsensusTypeMigration())
 	assert.True(t, cp.OrgSpecificOrdererEndpoints())
+	assert.False(t, cp.HybridPQCSignatures())
+}
+
+func TestChannelV30(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV3_0: {},
+	})
+	assert.NoError(t, cp.Supported())
+	assert.True(t, cp.MSPVersion() == msp.MSPv3_0)
+	assert.True(t, cp.ConsensusTypeMigration())
+	assert.True(t, cp.OrgSpecificOrdererEndpoints())
+	assert.True(t, cp.HybridPQCSignatures())
 }
 
 func TestChannelNotSupported(t *testing.T) {
@@ -13,6 +13,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	mb "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/msp"
 	"go.uber.org/zap/zapcore"
 )
@@ -28,24 +29,49 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal) (func([]
 
 	switch t := policy.Type.(type) {
 	case *cb.SignaturePolicy_NOutOf_:
+		if t.NOutOf.N == policydsl.NotGateN {
+			if len(t.NOutOf.Rules) != 1 {
+				return nil, fmt.Errorf("NOT gate must wrap exactly one rule, got %d", len(t.NOutOf.Rules))
+			}
+			compiledPolicy, err := compile(t.NOutOf.Rules[0], identities)
+			if err != nil {
+				return nil, err
+			}
+			return func(signedData []msp.Identity, used []bool) bool {
+				// evaluate the negated rule against a scratch copy so that a
+				// satisfied NOT gate does not consume any identities
+				_used := make([]bool, len(used))
+				copy(_used, used)
+				satisfied := !compiledPolicy(signedData, _used)
+				cauthdslLogger.Debugf("%p not gate evaluation yields %t", signedData, satisfied)
+				return satisfied
+			}, nil
+		}
+		if weight, rule := policydsl.WeightOf(policy); weight > 1 {
+			// a weight marker outside an enclosing threshold gate carries no
+			// threshold semantics of its own, evaluate the wrapped rule directly
+			return compile(rule, identities)
+		}
 		policies := make([]func([]msp.Identity, []bool) bool, len(t.NOutOf.Rules))
+		weights := make([]int32, len(t.NOutOf.Rules))
 		for i, policy := range t.NOutOf.Rules {
-			compiledPolicy, err := compile(policy, identities)
+			weight, rule := policydsl.WeightOf(policy)
+			compiledPolicy, err := compile(rule, identities)
 			if err != nil {
 				return nil, err
 			}
 			policies[i] = compiledPolicy
-
+			weights[i] = weight
 		}
 		return func(signedData []msp.Identity, used []bool) bool {
 			grepKey := time.Now().UnixNano()
 			cauthdslLogger.Debugf("%p gate %d evaluation starts", signedData, grepKey)
 			verified := int32(0)
 			_used := make([]bool, len(used))
-			for _, policy := range policies {
+			for i, policy := range policies {
 				copy(_used, used)
 				if policy(signedData, _used) {
-					verified++
+					verified += weights[i]
 					copy(used, _used)
 				}
 			}
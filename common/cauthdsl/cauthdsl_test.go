@@ -220,3 +220,82 @@ func TestReturnNil(t *testing.T) {
 	assert.Nil(t, spe)
 	assert.EqualError(t, err, "identity index out of range, requested -1, but identities length is 2")
 }
+
+func TestNotGateSignature(t *testing.T) {
+	policy := policydsl.Envelope(policydsl.Not(policydsl.SignedBy(0)), signers)
+
+	spe, err := compile(policy.Rule, policy.Identities)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe(toIdentities([][]byte{signers[1]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to succeed because signers[0] did not sign")
+	}
+	if spe(toIdentities([][]byte{signers[0]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to fail because signers[0] signed")
+	}
+}
+
+func TestNotGateDoesNotConsumeIdentities(t *testing.T) {
+	// signers[1] must both fail the NOT gate's wrapped rule and still be
+	// available to satisfy the second rule of the enclosing AND gate
+	policy := policydsl.Envelope(policydsl.And(policydsl.Not(policydsl.SignedBy(0)), policydsl.SignedBy(1)), signers)
+
+	spe, err := compile(policy.Rule, policy.Identities)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe(toIdentities([][]byte{signers[1]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to succeed with a signature from signers[1] only")
+	}
+	if spe(toIdentities(signers, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to fail because signers[0] signed")
+	}
+}
+
+func TestNotGateBadRuleCount(t *testing.T) {
+	policy := policydsl.Envelope(policydsl.NOutOf(policydsl.NotGateN, []*cb.SignaturePolicy{policydsl.SignedBy(0), policydsl.SignedBy(1)}), signers)
+
+	_, err := compile(policy.Rule, policy.Identities)
+	assert.EqualError(t, err, "NOT gate must wrap exactly one rule, got 2")
+}
+
+func TestWeightedSignature(t *testing.T) {
+	weighted, err := policydsl.Weighted(2, policydsl.SignedBy(0))
+	assert.NoError(t, err)
+
+	policy := policydsl.Envelope(policydsl.NOutOf(3, []*cb.SignaturePolicy{weighted, policydsl.SignedBy(1)}), signers)
+
+	spe, err := compile(policy.Rule, policy.Identities)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe(toIdentities(signers, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to succeed, weights sum to the threshold")
+	}
+	if spe(toIdentities([][]byte{signers[0]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to fail, weight 2 is below the threshold")
+	}
+	if spe(toIdentities([][]byte{signers[1]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to fail, weight 1 is below the threshold")
+	}
+}
+
+func TestWeightedSignatureSatisfiedBySingleSigner(t *testing.T) {
+	weighted, err := policydsl.Weighted(2, policydsl.SignedBy(0))
+	assert.NoError(t, err)
+
+	policy := policydsl.Envelope(policydsl.NOutOf(2, []*cb.SignaturePolicy{weighted, policydsl.SignedBy(1)}), signers)
+
+	spe, err := compile(policy.Rule, policy.Identities)
+	if err != nil {
+		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
+	}
+
+	if !spe(toIdentities([][]byte{signers[0]}, &mockDeserializer{})) {
+		t.Errorf("Expected authentication to succeed, signers[0] alone carries enough weight")
+	}
+}
@@ -23,6 +23,11 @@ type InstalledChaincode struct {
 	// definitions that use this installed chaincode package.
 	References map[string][]*Metadata
 
+	// Signer describes the identity which signed the chaincode
+	// package, if the package carried a signature which was
+	// verified at install time. It is empty for unsigned packages.
+	Signer string
+
 	// FIXME: we should remove these two
 	// fields since they are not properties
 	// of the chaincode (FAB-14561)
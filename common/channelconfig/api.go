@@ -135,6 +135,9 @@ type ChannelCapabilities interface {
 
 	// OrgSpecificOrdererEndpoints return true if the channel config processing allows orderer orgs to specify their own endpoints
 	OrgSpecificOrdererEndpoints() bool
+
+	// HybridPQCSignatures return true if the channel supports hybrid classical+post-quantum signatures
+	HybridPQCSignatures() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
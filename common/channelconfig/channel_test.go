@@ -27,6 +27,7 @@ func TestChannelConfig(t *testing.T) {
 	assert.NoError(t, err)
 
 	cc, err := NewChannelConfig(
+		"testchannel",
 		&cb.ConfigGroup{Groups: map[string]*cb.ConfigGroup{"UnknownGroupKey": {}}},
 		cryptoProvider,
 	)
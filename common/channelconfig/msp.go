@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/cache"
 	"github.com/pkg/errors"
@@ -24,16 +25,18 @@ type pendingMSPConfig struct {
 
 // MSPConfigHandler
 type MSPConfigHandler struct {
-	version msp.MSPVersion
-	idMap   map[string]*pendingMSPConfig
-	bccsp   bccsp.BCCSP
+	channelID string
+	version   msp.MSPVersion
+	idMap     map[string]*pendingMSPConfig
+	bccsp     bccsp.BCCSP
 }
 
-func NewMSPConfigHandler(mspVersion msp.MSPVersion, bccsp bccsp.BCCSP) *MSPConfigHandler {
+func NewMSPConfigHandler(channelID string, mspVersion msp.MSPVersion, bccsp bccsp.BCCSP) *MSPConfigHandler {
 	return &MSPConfigHandler{
-		version: mspVersion,
-		idMap:   make(map[string]*pendingMSPConfig),
-		bccsp:   bccsp,
+		channelID: channelID,
+		version:   mspVersion,
+		idMap:     make(map[string]*pendingMSPConfig),
+		bccsp:     bccsp,
 	}
 }
 
@@ -53,12 +56,17 @@ func (bh *MSPConfigHandler) ProposeMSP(mspConfig *mspprotos.MSPConfig) (msp.MSP,
 			return nil, errors.WithMessage(err, "creating the MSP manager failed")
 		}
 
-		// add a cache layer on top
-		theMsp, err = cache.New(mspInst)
+		// add a cache layer on top, shared with the instances the channel's
+		// earlier config updates produced for this MSP
+		theMsp, err = cache.NewWithOptions(mspInst, cache.Options{ChannelID: bh.channelID})
 		if err != nil {
 			return nil, errors.WithMessage(err, "creating the MSP cache failed")
 		}
 	case int32(msp.IDEMIX):
+		// the pairing-based curves of idemix are not FIPS-approved
+		if fips.Enabled() {
+			return nil, errors.New("Setup error: idemix MSPs are not supported in FIPS mode")
+		}
 		// create the idemix msp instance
 		theMsp, err = msp.New(
 			&msp.IdemixNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: bh.version}},
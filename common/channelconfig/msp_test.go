@@ -27,7 +27,7 @@ func TestMSPConfigManager(t *testing.T) {
 	mspVers := []msp.MSPVersion{msp.MSPv1_0, msp.MSPv1_1}
 
 	for _, ver := range mspVers {
-		mspCH := NewMSPConfigHandler(ver, factory.GetDefault())
+		mspCH := NewMSPConfigHandler("testchannel", ver, factory.GetDefault())
 
 		_, err = mspCH.ProposeMSP(conf)
 		assert.NoError(t, err)
@@ -52,7 +52,7 @@ func TestMSPConfigManager(t *testing.T) {
 func TestMSPConfigFailure(t *testing.T) {
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	mspCH := NewMSPConfigHandler(msp.MSPv1_0, cryptoProvider)
+	mspCH := NewMSPConfigHandler("testchannel", msp.MSPv1_0, cryptoProvider)
 
 	// begin/propose/commit
 	t.Run("Bad proto", func(t *testing.T) {
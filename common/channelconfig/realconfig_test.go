@@ -40,7 +40,7 @@ func TestOrgSpecificOrdererEndpoints(t *testing.T) {
 
 		cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 		assert.NoError(t, err)
-		_, err = channelconfig.NewChannelConfig(cg, cryptoProvider)
+		_, err = channelconfig.NewChannelConfig("testchannel", cg, cryptoProvider)
 		assert.EqualError(t, err, "could not create channel Orderer sub-group config: Orderer Org SampleOrg cannot contain endpoints value until V1_4_2+ capabilities have been enabled")
 	})
 
@@ -55,7 +55,7 @@ func TestOrgSpecificOrdererEndpoints(t *testing.T) {
 
 		cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 		assert.NoError(t, err)
-		_, err = channelconfig.NewChannelConfig(cg, cryptoProvider)
+		_, err = channelconfig.NewChannelConfig("testchannel", cg, cryptoProvider)
 		assert.EqualError(t, err, "Must set some OrdererAddresses")
 	})
 
@@ -69,7 +69,7 @@ func TestOrgSpecificOrdererEndpoints(t *testing.T) {
 
 		cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 		assert.NoError(t, err)
-		cc, err := channelconfig.NewChannelConfig(cg, cryptoProvider)
+		cc, err := channelconfig.NewChannelConfig("testchannel", cg, cryptoProvider)
 		assert.NoError(t, err)
 
 		err = cc.Validate(cc.Capabilities())
@@ -270,7 +270,12 @@ func ValidateCapabilities(block *cb.Block, bccsp bccsp.BCCSP) error {
 			"configuration group", ApplicationGroupKey)
 	}
 
-	cc, err := NewChannelConfig(configEnv.Config.ChannelGroup, bccsp)
+	channelID, err := protoutil.ChannelID(envelopeConfig)
+	if err != nil {
+		return errors.Errorf("failed to %s", err)
+	}
+
+	cc, err := NewChannelConfig(channelID, configEnv.Config.ChannelGroup, bccsp)
 	if err != nil {
 		return errors.Errorf("no valid channel configuration found due to %s", err)
 	}
@@ -26,11 +26,15 @@ func ExpiresAt(identityBytes []byte) time.Time {
 	return certExpirationTime(sId.IdBytes)
 }
 
-func certExpirationTime(pemBytes []byte) time.Time {
-	bl, _ := pem.Decode(pemBytes)
+func certExpirationTime(rawCert []byte) time.Time {
+	bl, _ := pem.Decode(rawCert)
 	if bl == nil {
-		// If the identity isn't a PEM block, we make no decisions about the expiration time
-		return time.Time{}
+		// The certificate isn't PEM encoded, try parsing it as DER directly
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return time.Time{}
+		}
+		return cert.NotAfter
 	}
 	cert, err := x509.ParseCertificate(bl.Bytes)
 	if err != nil {
@@ -0,0 +1,217 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/msp"
+)
+
+var certificateExpiration = metrics.GaugeOpts{
+	Namespace:    "certmonitor",
+	Name:         "seconds_until_expiration",
+	Help:         "The time left until the certificate expires, in seconds.",
+	LabelNames:   []string{"role"},
+	StatsdFormat: "%{#fqname}.%{role}",
+}
+
+// NewCertificateExpirationGauge creates the gauge through which the
+// CertificateExpirationMonitor reports time-to-expiry per certificate.
+func NewCertificateExpirationGauge(p metrics.Provider) metrics.Gauge {
+	return p.NewGauge(certificateExpiration)
+}
+
+// MonitoredCertificate couples a PEM encoded certificate with the role
+// under which its expiration is reported.
+type MonitoredCertificate struct {
+	Role    string
+	RawCert []byte
+}
+
+// CertificateSource returns the certificates to monitor. It is invoked on
+// every scan, so sources backed by mutable material such as the channel
+// configuration are re-read each time.
+type CertificateSource func() []MonitoredCertificate
+
+// StaticCertificateSource returns a CertificateSource that serves the
+// given certificates on every scan.
+func StaticCertificateSource(certs ...MonitoredCertificate) CertificateSource {
+	return func() []MonitoredCertificate {
+		return certs
+	}
+}
+
+// MonitoredIdentityCertificate extracts the certificate of the given
+// serialized identity so it can be monitored under the given role.
+func MonitoredIdentityCertificate(role string, sIDBytes []byte) MonitoredCertificate {
+	sID := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(sIDBytes, sID); err != nil {
+		return MonitoredCertificate{Role: role}
+	}
+	return MonitoredCertificate{Role: role, RawCert: sID.IdBytes}
+}
+
+// CertificateExpirationMonitor periodically scans a set of certificates,
+// reports the time left until each of them expires through a gauge labeled
+// by the certificate's role, and logs warnings that escalate as the
+// expiration approaches.
+type CertificateExpirationMonitor struct {
+	sources      []CertificateSource
+	gauge        metrics.Gauge
+	warn         WarnFunc
+	scanInterval time.Duration
+	now          func() time.Time
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+// NewCertificateExpirationMonitor creates a CertificateExpirationMonitor
+// that scans the certificates served by the given sources every
+// scanInterval.
+func NewCertificateExpirationMonitor(sources []CertificateSource, gauge metrics.Gauge, warn WarnFunc, scanInterval time.Duration) *CertificateExpirationMonitor {
+	return &CertificateExpirationMonitor{
+		sources:      sources,
+		gauge:        gauge,
+		warn:         warn,
+		scanInterval: scanInterval,
+		now:          time.Now,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Run scans the monitored certificates until Stop is invoked, and is meant
+// to be run on a dedicated goroutine.
+func (m *CertificateExpirationMonitor) Run() {
+	m.Scan()
+
+	ticker := time.NewTicker(m.scanInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.Scan()
+		case <-m.stopChan:
+			return
+		}
+	}
+}
+
+// Stop halts the monitor.
+func (m *CertificateExpirationMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+	})
+}
+
+// Scan reports the time left until each monitored certificate expires, and
+// warns about certificates that have expired or are about to.
+func (m *CertificateExpirationMonitor) Scan() {
+	now := m.now()
+	for _, source := range m.sources {
+		for _, cert := range source() {
+			expirationTime := certExpirationTime(cert.RawCert)
+			if expirationTime.IsZero() {
+				// If the certificate expiration time cannot be classified, skip it.
+				continue
+			}
+
+			timeLeftUntilExpiration := expirationTime.Sub(now)
+			m.gauge.With("role", cert.Role).Set(timeLeftUntilExpiration.Seconds())
+			m.warnIfCloseToExpiration(cert.Role, timeLeftUntilExpiration)
+		}
+	}
+}
+
+func (m *CertificateExpirationMonitor) warnIfCloseToExpiration(certRole string, timeLeftUntilExpiration time.Duration) {
+	oneDay := time.Hour * 24
+	oneWeek := oneDay * 7
+	fourWeeks := oneWeek * 4
+
+	if timeLeftUntilExpiration < 0 {
+		m.warn("The %s certificate has expired", certRole)
+		return
+	}
+
+	if timeLeftUntilExpiration < oneWeek {
+		days := timeLeftUntilExpiration / oneDay
+		hours := (timeLeftUntilExpiration - (days * oneDay)) / time.Hour
+		m.warn("The %s certificate expires within %d days and %d hours", certRole, days, hours)
+		return
+	}
+
+	if timeLeftUntilExpiration < fourWeeks {
+		m.warn("The %s certificate expires in %d days", certRole, timeLeftUntilExpiration/oneDay)
+	}
+}
+
+// ConfigCertificates extracts the admin and CA certificates of every MSP
+// defined in the given channel configuration, so that certificates rotated
+// through a config update are picked up on the next scan.
+func ConfigCertificates(channelID string, config *cb.Config) []MonitoredCertificate {
+	if config == nil || config.ChannelGroup == nil {
+		return nil
+	}
+	return configGroupCertificates(channelID, config.ChannelGroup)
+}
+
+func configGroupCertificates(channelID string, group *cb.ConfigGroup) []MonitoredCertificate {
+	var certs []MonitoredCertificate
+
+	if mspValue, ok := group.Values["MSP"]; ok {
+		certs = append(certs, mspConfigCertificates(channelID, mspValue.Value)...)
+	}
+
+	for _, subGroup := range group.Groups {
+		certs = append(certs, configGroupCertificates(channelID, subGroup)...)
+	}
+
+	return certs
+}
+
+func mspConfigCertificates(channelID string, serializedMSPConfig []byte) []MonitoredCertificate {
+	mspConfig := &mspprotos.MSPConfig{}
+	if err := proto.Unmarshal(serializedMSPConfig, mspConfig); err != nil {
+		return nil
+	}
+
+	// Only X.509 based MSPs carry certificates with an expiration
+	if mspConfig.Type != int32(msp.FABRIC) {
+		return nil
+	}
+
+	fabricConfig := &mspprotos.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return nil
+	}
+
+	var certs []MonitoredCertificate
+	appendCerts := func(kind string, rawCerts [][]byte) {
+		for i, rawCert := range rawCerts {
+			certs = append(certs, MonitoredCertificate{
+				Role:    fmt.Sprintf("channel %s %s %s #%d", channelID, fabricConfig.Name, kind, i),
+				RawCert: rawCert,
+			})
+		}
+	}
+
+	appendCerts("admin", fabricConfig.Admins)
+	appendCerts("root CA", fabricConfig.RootCerts)
+	appendCerts("intermediate CA", fabricConfig.IntermediateCerts)
+	appendCerts("TLS root CA", fabricConfig.TlsRootCerts)
+	appendCerts("TLS intermediate CA", fabricConfig.TlsIntermediateCerts)
+
+	return certs
+}
@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package crypto
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateExpirationMonitorScan(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+
+	tlsCert, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+
+	expirationTime := certExpirationTime(tlsCert.Cert)
+
+	for _, testCase := range []struct {
+		description  string
+		now          time.Time
+		expectedWarn string
+	}{
+		{
+			description: "expiration is far away",
+			now:         expirationTime.Add(-365 * 24 * time.Hour),
+		},
+		{
+			description:  "expiration is within four weeks",
+			now:          expirationTime.Add(-20 * 24 * time.Hour),
+			expectedWarn: "The server TLS certificate expires in 20 days",
+		},
+		{
+			description:  "expiration is within a week",
+			now:          expirationTime.Add(-2*24*time.Hour - 12*time.Hour),
+			expectedWarn: "The server TLS certificate expires within 2 days and 12 hours",
+		},
+		{
+			description:  "certificate has expired",
+			now:          expirationTime.Add(time.Hour),
+			expectedWarn: "The server TLS certificate has expired",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			gauge := &metricsfakes.Gauge{}
+			gauge.WithReturns(gauge)
+
+			var formattedWarning string
+			warn := func(format string, args ...interface{}) {
+				formattedWarning = fmt.Sprintf(format, args...)
+			}
+
+			monitor := NewCertificateExpirationMonitor(
+				[]CertificateSource{
+					StaticCertificateSource(
+						MonitoredCertificate{Role: "server TLS", RawCert: tlsCert.Cert},
+						// Certificates that cannot be classified are skipped
+						MonitoredCertificate{Role: "bogus", RawCert: []byte{1, 2, 3}},
+					),
+				},
+				gauge,
+				warn,
+				time.Hour,
+			)
+			monitor.now = func() time.Time {
+				return testCase.now
+			}
+
+			monitor.Scan()
+
+			assert.Equal(t, 1, gauge.SetCallCount())
+			assert.Equal(t, []string{"role", "server TLS"}, gauge.WithArgsForCall(0))
+			assert.InDelta(t, expirationTime.Sub(testCase.now).Seconds(), gauge.SetArgsForCall(0), 1)
+			assert.Equal(t, testCase.expectedWarn, formattedWarning)
+		})
+	}
+}
+
+func TestCertificateExpirationMonitorRun(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+
+	gauge := &metricsfakes.Gauge{}
+	gauge.WithReturns(gauge)
+
+	monitor := NewCertificateExpirationMonitor(
+		[]CertificateSource{
+			StaticCertificateSource(MonitoredCertificate{Role: "enrollment", RawCert: ca.CertBytes()}),
+		},
+		gauge,
+		func(format string, args ...interface{}) {},
+		time.Millisecond,
+	)
+
+	go monitor.Run()
+	defer monitor.Stop()
+
+	// The monitor scans once at startup and then on every tick
+	for start := time.Now(); gauge.SetCallCount() < 2; {
+		if time.Since(start) > 10*time.Second {
+			t.Fatal("gauge was not updated by the periodic scan in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestMonitoredIdentityCertificate(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+
+	sIDBytes := protoutil.MarshalOrPanic(&mspprotos.SerializedIdentity{
+		IdBytes: ca.CertBytes(),
+	})
+
+	cert := MonitoredIdentityCertificate("enrollment", sIDBytes)
+	assert.Equal(t, "enrollment", cert.Role)
+	assert.Equal(t, ca.CertBytes(), cert.RawCert)
+
+	cert = MonitoredIdentityCertificate("enrollment", []byte{1, 2, 3})
+	assert.Equal(t, "enrollment", cert.Role)
+	assert.Nil(t, cert.RawCert)
+}
+
+func TestConfigCertificates(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+
+	fabricConfig := protoutil.MarshalOrPanic(&mspprotos.FabricMSPConfig{
+		Name:         "Org1MSP",
+		Admins:       [][]byte{ca.CertBytes()},
+		RootCerts:    [][]byte{ca.CertBytes()},
+		TlsRootCerts: [][]byte{ca.CertBytes()},
+	})
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Application": {
+					Groups: map[string]*cb.ConfigGroup{
+						"Org1MSP": {
+							Values: map[string]*cb.ConfigValue{
+								"MSP": {
+									Value: protoutil.MarshalOrPanic(&mspprotos.MSPConfig{
+										Config: fabricConfig,
+									}),
+								},
+							},
+						},
+						"IdemixOrg": {
+							Values: map[string]*cb.ConfigValue{
+								"MSP": {
+									Value: protoutil.MarshalOrPanic(&mspprotos.MSPConfig{
+										Type:   1, // IDEMIX
+										Config: []byte{1, 2, 3},
+									}),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	certs := ConfigCertificates("mychannel", config)
+	assert.Len(t, certs, 3)
+
+	roles := make(map[string]struct{})
+	for _, cert := range certs {
+		assert.Equal(t, ca.CertBytes(), cert.RawCert)
+		roles[cert.Role] = struct{}{}
+	}
+	assert.Contains(t, roles, "channel mychannel Org1MSP admin #0")
+	assert.Contains(t, roles, "channel mychannel Org1MSP root CA #0")
+	assert.Contains(t, roles, "channel mychannel Org1MSP TLS root CA #0")
+
+	assert.Nil(t, ConfigCertificates("mychannel", nil))
+	assert.Nil(t, ConfigCertificates("mychannel", &cb.Config{}))
+}
@@ -0,0 +1,142 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package denylist
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// testIdentity returns the serialized identity of a self-signed certificate
+// with the given common name, together with its fingerprint and subject.
+func testIdentity(t *testing.T, commonName string) (creator []byte, fingerprint, subject string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certRaw})
+	creator, err = proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: certPEM})
+	assert.NoError(t, err)
+
+	rawFingerprint := sha256.Sum256(certRaw)
+	return creator, hex.EncodeToString(rawFingerprint[:]), cert.Subject.String()
+}
+
+func TestCheckFingerprint(t *testing.T) {
+	creator, fingerprint, _ := testIdentity(t, "denied-client")
+	list := NewDenyList()
+
+	// an empty list denies nobody
+	assert.NoError(t, list.Check(creator))
+
+	err := list.Add(Entry{Fingerprint: fingerprint})
+	assert.NoError(t, err)
+	err = list.Check(creator)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is deny-listed")
+
+	list.Remove(Entry{Fingerprint: fingerprint})
+	assert.NoError(t, list.Check(creator))
+
+	// fingerprints match case-insensitively
+	err = list.Add(Entry{Fingerprint: strings.ToUpper(fingerprint)})
+	assert.NoError(t, err)
+	assert.Error(t, list.Check(creator))
+
+	// a fingerprint must be a hex string
+	err = list.Add(Entry{Fingerprint: "not a fingerprint"})
+	assert.EqualError(t, err, `the fingerprint "not a fingerprint" is not a hex string`)
+}
+
+func TestCheckSubject(t *testing.T) {
+	creator, _, subject := testIdentity(t, "denied-client")
+	otherCreator, _, _ := testIdentity(t, "innocent-client")
+
+	list := NewDenyList()
+	err := list.Add(Entry{Subject: subject})
+	assert.NoError(t, err)
+
+	err = list.Check(creator)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is deny-listed")
+	assert.NoError(t, list.Check(otherCreator))
+
+	list.Remove(Entry{Subject: subject})
+	assert.NoError(t, list.Check(creator))
+}
+
+func TestCheckMalformedCreator(t *testing.T) {
+	list := NewDenyList()
+	err := list.Add(Entry{Subject: "CN=denied-client"})
+	assert.NoError(t, err)
+
+	// creators that cannot be parsed pass and are rejected downstream
+	assert.NoError(t, list.Check([]byte("not a serialized identity")))
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: []byte("not a PEM block")})
+	assert.NoError(t, err)
+	assert.NoError(t, list.Check(creator))
+}
+
+func TestAddRequiresIdentification(t *testing.T) {
+	list := NewDenyList()
+	err := list.Add(Entry{})
+	assert.EqualError(t, err, "a deny-list entry requires a fingerprint or a subject")
+}
+
+func TestEntries(t *testing.T) {
+	list := NewDenyList()
+	assert.NoError(t, list.Add(Entry{Fingerprint: "AB"}))
+	assert.NoError(t, list.Add(Entry{Subject: "CN=denied-client"}))
+
+	assert.Equal(t, []Entry{
+		{Subject: "CN=denied-client"},
+		{Fingerprint: "ab"},
+	}, list.Entries())
+}
+
+func TestCheckEnvelope(t *testing.T) {
+	creator, fingerprint, _ := testIdentity(t, "denied-client")
+	list := NewDenyList()
+	assert.NoError(t, list.Add(Entry{Fingerprint: fingerprint}))
+
+	env := &cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{
+			SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: creator}),
+		},
+	})}
+	err := list.CheckEnvelope(env)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is deny-listed")
+
+	// malformed envelopes pass and are rejected downstream
+	assert.NoError(t, list.CheckEnvelope(&cb.Envelope{Payload: []byte("garbage")}))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package denylist
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// Request is the body of a POST request to the deny-list endpoint of the
+// operations server.
+type Request struct {
+	Action      string `json:"action"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Subject     string `json:"subject,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHandler creates an http.Handler exposing the deny-list (GET) and the
+// add and remove operations (POST with a JSON body naming the action and the
+// fingerprint or subject).
+func NewHandler(list *DenyList) *Handler {
+	return &Handler{
+		list:   list,
+		logger: flogging.MustGetLogger("denylist"),
+	}
+}
+
+// Handler serves the identity deny-list API over HTTP.
+type Handler struct {
+	list   *DenyList
+	logger *flogging.FabricLogger
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		h.sendResponse(resp, http.StatusOK, h.list.Entries())
+
+	case http.MethodPost:
+		var request Request
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&request); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		entry := Entry{Fingerprint: request.Fingerprint, Subject: request.Subject}
+		switch request.Action {
+		case "add":
+			if err := h.list.Add(entry); err != nil {
+				h.sendResponse(resp, http.StatusBadRequest, err)
+				return
+			}
+			h.logger.Warnw("Added identity to the deny-list", "fingerprint", entry.Fingerprint, "subject", entry.Subject)
+		case "remove":
+			h.list.Remove(entry)
+			h.logger.Warnw("Removed identity from the deny-list", "fingerprint", entry.Fingerprint, "subject", entry.Subject)
+		default:
+			h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid action: %s", request.Action))
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *Handler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package denylist
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func handleRequest(t *testing.T, handler *Handler, method, body string) *httptest.ResponseRecorder {
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(method, "/denylist", strings.NewReader(body)))
+	return resp
+}
+
+func TestHandlerAddListRemove(t *testing.T) {
+	handler := NewHandler(NewDenyList())
+
+	resp := handleRequest(t, handler, http.MethodPost, `{"action": "add", "fingerprint": "ab12", "subject": "CN=denied-client"}`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = handleRequest(t, handler, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{"subject": "CN=denied-client"}, {"fingerprint": "ab12"}]`, resp.Body.String())
+
+	resp = handleRequest(t, handler, http.MethodPost, `{"action": "remove", "fingerprint": "ab12", "subject": "CN=denied-client"}`)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+
+	resp = handleRequest(t, handler, http.MethodGet, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[]`, resp.Body.String())
+}
+
+func TestHandlerBadRequests(t *testing.T) {
+	handler := NewHandler(NewDenyList())
+
+	resp := handleRequest(t, handler, http.MethodPost, `{"action": "purge"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"error": "invalid action: purge"}`, resp.Body.String())
+
+	resp = handleRequest(t, handler, http.MethodPost, `{"action": "add"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"error": "a deny-list entry requires a fingerprint or a subject"}`, resp.Body.String())
+
+	resp = handleRequest(t, handler, http.MethodPost, `not json`)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = handleRequest(t, handler, http.MethodDelete, "")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.JSONEq(t, `{"error": "invalid request method: DELETE"}`, resp.Body.String())
+}
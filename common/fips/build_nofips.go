@@ -0,0 +1,13 @@
+// +build !fips
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fips
+
+// BuildCompliant reports whether the binary was built with the fips build
+// tag, which links it against a validated cryptographic module.
+const BuildCompliant = false
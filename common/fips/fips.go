@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package fips implements the FIPS compliance mode of the peer and the
+// orderer. The mode has two halves: the fips build tag selects a binary
+// backed by a validated cryptographic module, and the runtime switch
+// restricts BCCSP, TLS, and hashing to FIPS-approved algorithms and refuses
+// non-compliant channel configurations. The runtime switch can only be
+// turned on in a binary built with the tag.
+package fips
+
+import (
+	"crypto/tls"
+
+	"github.com/pkg/errors"
+)
+
+var enabled bool
+
+// Enable puts the process in FIPS mode. It must be called at startup, before
+// any cryptographic provider is created, and fails when the binary was not
+// built with the fips build tag.
+func Enable() error {
+	if !BuildCompliant {
+		return errors.New("FIPS mode requires a binary built with the fips build tag")
+	}
+	enabled = true
+	return nil
+}
+
+// Enabled reports whether the process is in FIPS mode.
+func Enabled() bool {
+	return enabled
+}
+
+// TLSCipherSuites returns the FIPS-approved TLS cipher suites; ECDHE key
+// establishment with AES-GCM, per SP 800-52.
+func TLSCipherSuites() []uint16 {
+	return []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	}
+}
+
+// CurvePreferences returns the FIPS-approved elliptic curves for TLS key
+// establishment.
+func CurvePreferences() []tls.CurveID {
+	return []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+}
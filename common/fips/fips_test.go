@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fips
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnableRequiresBuildTag(t *testing.T) {
+	if BuildCompliant {
+		t.Skip("running with the fips build tag")
+	}
+
+	assert.False(t, Enabled())
+	err := Enable()
+	assert.EqualError(t, err, "FIPS mode requires a binary built with the fips build tag")
+	assert.False(t, Enabled())
+}
+
+func TestEnabled(t *testing.T) {
+	defer func() { enabled = false }()
+
+	enabled = true
+	assert.True(t, Enabled())
+}
+
+func TestTLSCipherSuites(t *testing.T) {
+	assert.Equal(t, []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	}, TLSCipherSuites())
+
+	assert.Equal(t, []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}, CurvePreferences())
+}
@@ -46,6 +46,10 @@ var (
 
 	// ErrAttrNotIndexed is used to indicate that an attribute is not indexed
 	ErrAttrNotIndexed = errors.New("attribute not indexed")
+
+	// ErrBlockPruned is used to indicate that the requested block or transaction
+	// has been pruned from the local block store
+	ErrBlockPruned = errors.New("block has been pruned from the block store")
 )
 
 // BlockStoreProvider provides an handle to a BlockStore
@@ -72,3 +76,35 @@ type BlockStore interface {
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
 	Shutdown()
 }
+
+// BlockPruner is an optional capability that a BlockStore implementation may
+// expose. It allows blocks that have aged out of a retention window to be
+// removed from local storage, typically after they have been archived
+type BlockPruner interface {
+	// PruneBlocks removes blocks with a number lower than `retainFrom` from local
+	// storage. Config blocks are always kept and continue to be served; retrieval
+	// of any other pruned block or transaction fails with ErrBlockPruned. An
+	// implementation is free to prune less than requested (e.g. because it removes
+	// blocks at a coarser granularity than a single block); PrunedUpTo reports the
+	// actual pruning boundary
+	PruneBlocks(retainFrom uint64) error
+
+	// PrunedUpTo returns the height below which blocks have been pruned from
+	// local storage
+	PrunedUpTo() uint64
+}
+
+// BlockIndexRebuilder is an optional capability that a BlockStore implementation
+// may expose. It allows the block index to be rebuilt from the block files in the
+// background, e.g. for backfilling the index entries of existing blocks after a
+// new index attribute has been enabled, while the store continues to serve
+// queries and commits
+type BlockIndexRebuilder interface {
+	// RebuildIndex starts a background rebuild of the block index. An error is
+	// returned if a rebuild is already in progress
+	RebuildIndex() error
+
+	// IndexRebuildStatus returns the progress of the ongoing rebuild or the
+	// outcome of the last completed one
+	IndexRebuildStatus() *l.BlockIndexRebuildStatus
+}
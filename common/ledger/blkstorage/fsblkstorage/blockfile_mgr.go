@@ -44,6 +44,8 @@ type blockfileMgr struct {
 	cpInfoCond        *sync.Cond
 	currentFileWriter *blockfileWriter
 	bcInfo            atomic.Value
+	pruneMarker       atomic.Value
+	reindexState      indexRebuildState
 }
 
 /*
@@ -137,6 +139,13 @@ func newBlockfileMgr(id string, conf *Conf, indexConfig *blkstorage.IndexConfig,
 		panic(fmt.Sprintf("error in block index: %s", err))
 	}
 
+	// Load the prune marker that records how far the block files have been pruned
+	pruneMarker, err := mgr.loadPruneMarker()
+	if err != nil {
+		panic(fmt.Sprintf("Could not load prune marker from db: %s", err))
+	}
+	mgr.pruneMarker.Store(pruneMarker)
+
 	// Update the manager with the checkpoint info and the file writer
 	mgr.cpInfo = cpInfo
 	mgr.currentFileWriter = currentFileWriter
@@ -455,6 +464,9 @@ func (mgr *blockfileMgr) retrieveBlockByHash(blockHash []byte) (*common.Block, e
 	if err != nil {
 		return nil, err
 	}
+	if loc.fileSuffixNum < mgr.getPruneMarker().fileSuffixNum {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -466,6 +478,10 @@ func (mgr *blockfileMgr) retrieveBlockByNumber(blockNum uint64) (*common.Block,
 		blockNum = mgr.getBlockchainInfo().Height - 1
 	}
 
+	if blockNum < mgr.getPruneMarker().height {
+		return mgr.retrieveRetainedConfigBlock(blockNum)
+	}
+
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -481,6 +497,9 @@ func (mgr *blockfileMgr) retrieveBlockByTxID(txID string) (*common.Block, error)
 	if err != nil {
 		return nil, err
 	}
+	if loc.fileSuffixNum < mgr.getPruneMarker().fileSuffixNum {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	return mgr.fetchBlock(loc)
 }
 
@@ -491,6 +510,13 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
+	if blockNum < mgr.getPruneMarker().height {
+		block, err := mgr.retrieveRetainedConfigBlock(blockNum)
+		if err != nil {
+			return nil, err
+		}
+		return block.Header, nil
+	}
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
 	if err != nil {
 		return nil, err
@@ -507,6 +533,9 @@ func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.B
 }
 
 func (mgr *blockfileMgr) retrieveBlocks(startNum uint64) (*blocksItr, error) {
+	if startNum < mgr.getPruneMarker().height {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	return newBlockItr(mgr, startNum), nil
 }
 
@@ -516,6 +545,9 @@ func (mgr *blockfileMgr) retrieveTransactionByID(txID string) (*common.Envelope,
 	if err != nil {
 		return nil, err
 	}
+	if loc.fileSuffixNum < mgr.getPruneMarker().fileSuffixNum {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
@@ -525,6 +557,9 @@ func (mgr *blockfileMgr) retrieveTransactionByBlockNumTranNum(blockNum uint64, t
 	if err != nil {
 		return nil, err
 	}
+	if loc.fileSuffixNum < mgr.getPruneMarker().fileSuffixNum {
+		return nil, blkstorage.ErrBlockPruned
+	}
 	return mgr.fetchTransactionEnvelope(loc)
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	l "github.com/hyperledger/fabric/core/ledger"
 )
 
 // fsBlockStore - filesystem based implementation for `BlockStore`
@@ -97,6 +98,29 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// PruneBlocks removes blocks below `retainFrom` from the block files, retaining
+// config blocks. It implements the optional blkstorage.BlockPruner capability
+func (store *fsBlockStore) PruneBlocks(retainFrom uint64) error {
+	return store.fileMgr.pruneBlocks(retainFrom)
+}
+
+// PrunedUpTo returns the height below which blocks have been pruned
+func (store *fsBlockStore) PrunedUpTo() uint64 {
+	return store.fileMgr.getPruneMarker().height
+}
+
+// RebuildIndex starts a background rebuild of the block index. It implements the
+// optional blkstorage.BlockIndexRebuilder capability
+func (store *fsBlockStore) RebuildIndex() error {
+	return store.fileMgr.startIndexRebuild()
+}
+
+// IndexRebuildStatus returns the progress of the ongoing index rebuild or the
+// outcome of the last completed one
+func (store *fsBlockStore) IndexRebuildStatus() *l.BlockIndexRebuildStatus {
+	return store.fileMgr.indexRebuildStatus()
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
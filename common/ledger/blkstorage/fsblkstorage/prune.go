@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// retainedConfigBlockKeyPrefix is the prefix of the index db keys under which
+	// config blocks from the pruned range are kept so that they remain retrievable
+	retainedConfigBlockKeyPrefix = 'c'
+)
+
+var pruneMarkerKey = []byte("pruneMarker")
+
+// pruneMarker records how far the block files have been pruned. Blocks below
+// `height` are no longer present in the block files (config blocks excepted,
+// which are copied to the index db before their files are removed) and
+// `fileSuffixNum` is the suffix number of the first block file still on disk
+type pruneMarker struct {
+	height        uint64
+	fileSuffixNum int
+}
+
+func (m *pruneMarker) marshal() ([]byte, error) {
+	buffer := proto.NewBuffer([]byte{})
+	var err error
+	if err = buffer.EncodeVarint(m.height); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the pruned height [%d]", m.height)
+	}
+	if err = buffer.EncodeVarint(uint64(m.fileSuffixNum)); err != nil {
+		return nil, errors.Wrapf(err, "error encoding the fileSuffixNum [%d]", m.fileSuffixNum)
+	}
+	return buffer.Bytes(), nil
+}
+
+func (m *pruneMarker) unmarshal(b []byte) error {
+	buffer := proto.NewBuffer(b)
+	var val uint64
+	var err error
+
+	if val, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	m.height = val
+
+	if val, err = buffer.DecodeVarint(); err != nil {
+		return err
+	}
+	m.fileSuffixNum = int(val)
+	return nil
+}
+
+func (m *pruneMarker) String() string {
+	return fmt.Sprintf("height=[%d], fileSuffixNum=[%d]", m.height, m.fileSuffixNum)
+}
+
+func retainedConfigBlockKey(blockNum uint64) []byte {
+	return append([]byte{retainedConfigBlockKeyPrefix}, encodeBlockNum(blockNum)...)
+}
+
+func (mgr *blockfileMgr) getPruneMarker() *pruneMarker {
+	return mgr.pruneMarker.Load().(*pruneMarker)
+}
+
+func (mgr *blockfileMgr) loadPruneMarker() (*pruneMarker, error) {
+	b, err := mgr.db.Get(pruneMarkerKey)
+	if err != nil {
+		return nil, err
+	}
+	marker := &pruneMarker{}
+	if b == nil {
+		return marker, nil
+	}
+	if err := marker.unmarshal(b); err != nil {
+		return nil, err
+	}
+	return marker, nil
+}
+
+func (mgr *blockfileMgr) savePruneMarker(marker *pruneMarker) error {
+	b, err := marker.marshal()
+	if err != nil {
+		return err
+	}
+	return mgr.db.Put(pruneMarkerKey, b, true)
+}
+
+// pruneBlocks removes blocks below `retainFrom` from the block files. Whole
+// block files are removed at a time, so the actual pruning boundary is the
+// first block of the file that contains `retainFrom` and may be lower than
+// `retainFrom` itself. Config blocks from the pruned range are copied to the
+// index db before their files are removed and continue to be served by
+// retrieveBlockByNumber; other pruned blocks fail retrieval with
+// blkstorage.ErrBlockPruned
+func (mgr *blockfileMgr) pruneBlocks(retainFrom uint64) error {
+	marker := mgr.getPruneMarker()
+	if retainFrom <= marker.height {
+		return nil
+	}
+	bcInfo := mgr.getBlockchainInfo()
+	if retainFrom >= bcInfo.Height {
+		return errors.Errorf("cannot prune blocks below [%d]: blockchain height is only [%d]", retainFrom, bcInfo.Height)
+	}
+	targetLoc, err := mgr.index.getBlockLocByBlockNum(retainFrom)
+	if err != nil {
+		return err
+	}
+	if targetLoc.fileSuffixNum == marker.fileSuffixNum {
+		logger.Debugf("No whole block file below block [%d], nothing to prune", retainFrom)
+		return nil
+	}
+
+	// determine the first block of the file that contains `retainFrom` - the
+	// blocks below it are the ones whose files are about to be removed
+	boundary := marker.height
+	for boundary < retainFrom {
+		loc, err := mgr.index.getBlockLocByBlockNum(boundary)
+		if err != nil {
+			return err
+		}
+		if loc.fileSuffixNum == targetLoc.fileSuffixNum {
+			break
+		}
+		boundary++
+	}
+
+	// copy config blocks out of the range being pruned before their files are removed
+	for num := marker.height; num < boundary; num++ {
+		block, err := mgr.retrieveBlockByNumber(num)
+		if err != nil {
+			return err
+		}
+		if !protoutil.IsConfigBlock(block) {
+			continue
+		}
+		blockBytes, err := proto.Marshal(block)
+		if err != nil {
+			return errors.Wrapf(err, "error marshaling config block [%d]", num)
+		}
+		if err := mgr.db.Put(retainedConfigBlockKey(num), blockBytes, true); err != nil {
+			return err
+		}
+		logger.Debugf("Retained config block [%d] in the index db", num)
+	}
+
+	// persist the new marker before removing the files so that a crash in
+	// between leaves the store reporting the blocks as pruned rather than
+	// failing retrievals on missing files
+	newMarker := &pruneMarker{height: boundary, fileSuffixNum: targetLoc.fileSuffixNum}
+	if err := mgr.savePruneMarker(newMarker); err != nil {
+		return err
+	}
+	mgr.pruneMarker.Store(newMarker)
+
+	for suffixNum := marker.fileSuffixNum; suffixNum < targetLoc.fileSuffixNum; suffixNum++ {
+		filePath := deriveBlockfilePath(mgr.rootDir, suffixNum)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing block file [%s]", filePath)
+		}
+	}
+	logger.Infof("Pruned block files below block [%d], new pruneMarker=[%s]", boundary, newMarker)
+	return nil
+}
+
+// retrieveRetainedConfigBlock returns the copy of a config block that was kept
+// in the index db when its block file was pruned, or blkstorage.ErrBlockPruned
+// if the requested block was not a config block
+func (mgr *blockfileMgr) retrieveRetainedConfigBlock(blockNum uint64) (*common.Block, error) {
+	blockBytes, err := mgr.db.Get(retainedConfigBlockKey(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if blockBytes == nil {
+		return nil, blkstorage.ErrBlockPruned
+	}
+	block := &common.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling retained config block [%d]", blockNum)
+	}
+	return block, nil
+}
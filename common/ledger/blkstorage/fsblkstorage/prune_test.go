@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneBlocks(t *testing.T) {
+	blockStoreRootDir := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 50) // block 0 is a config block
+	maxFileSize := int(0.1 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	env := newTestEnv(t, NewConf(blockStoreRootDir, maxFileSize))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	mgr := blkfileMgrWrapper.blockfileMgr
+	// sanity check - the blocks must span multiple block files for the test to be meaningful
+	require.True(t, mgr.cpInfo.latestFileChunkSuffixNum > 2)
+
+	// pruning at or beyond the chain height is rejected
+	err := mgr.pruneBlocks(50)
+	assert.EqualError(t, err, "cannot prune blocks below [50]: blockchain height is only [50]")
+
+	assert.NoError(t, mgr.pruneBlocks(30))
+	marker := mgr.getPruneMarker()
+	// whole files are pruned, so the boundary is at or below the requested block
+	// but above the start of the chain
+	require.True(t, marker.height > 0)
+	require.True(t, marker.height <= 30)
+
+	// the block files below the one containing the boundary block are removed
+	for suffixNum := 0; suffixNum < marker.fileSuffixNum; suffixNum++ {
+		_, err := os.Stat(deriveBlockfilePath(mgr.rootDir, suffixNum))
+		assert.True(t, os.IsNotExist(err))
+	}
+	_, err = os.Stat(deriveBlockfilePath(mgr.rootDir, marker.fileSuffixNum))
+	assert.NoError(t, err)
+
+	// the config block remains retrievable from its retained copy
+	configBlock, err := mgr.retrieveBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0], configBlock))
+	configBlockHeader, err := mgr.retrieveBlockHeaderByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0].Header, configBlockHeader))
+
+	// all other pruned blocks fail retrieval with ErrBlockPruned
+	for num := uint64(1); num < marker.height; num++ {
+		_, err := mgr.retrieveBlockByNumber(num)
+		assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	}
+	_, err = mgr.retrieveBlockByHash(protoutil.BlockHeaderHash(blocks[1].Header))
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	txID, err := protoutil.GetOrComputeTxIDFromEnvelope(blocks[1].Data.Data[0])
+	assert.NoError(t, err)
+	_, err = mgr.retrieveBlockByTxID(txID)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = mgr.retrieveTransactionByID(txID)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = mgr.retrieveTransactionByBlockNumTranNum(1, 0)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	_, err = mgr.retrieveBlocks(marker.height - 1)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+
+	// the retained blocks are unaffected
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[marker.height:], marker.height, nil)
+
+	// pruning at or below the current boundary is a no-op
+	assert.NoError(t, mgr.pruneBlocks(marker.height))
+	assert.Equal(t, marker, mgr.getPruneMarker())
+}
+
+func TestPruneMarkerSurvivesRestart(t *testing.T) {
+	blockStoreRootDir := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	maxFileSize := int(0.1 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	env := newTestEnv(t, NewConf(blockStoreRootDir, maxFileSize))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	assert.NoError(t, blkfileMgrWrapper.blockfileMgr.pruneBlocks(30))
+	marker := blkfileMgrWrapper.blockfileMgr.getPruneMarker()
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	env = newTestEnv(t, NewConf(blockStoreRootDir, maxFileSize))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.Equal(t, marker, mgr.getPruneMarker())
+
+	// after the restart, the config block is still served and pruned blocks
+	// still fail retrieval
+	configBlock, err := mgr.retrieveBlockByNumber(0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(blocks[0], configBlock))
+	_, err = mgr.retrieveBlockByNumber(1)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[marker.height:], marker.height, nil)
+}
+
+func TestPruneBlocksStoreAPI(t *testing.T) {
+	blockStoreRootDir := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	maxFileSize := int(0.1 * float64(testutilEstimateTotalSizeOnDisk(t, blocks)))
+	env := newTestEnv(t, NewConf(blockStoreRootDir, maxFileSize))
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	defer store.Shutdown()
+	for _, b := range blocks {
+		assert.NoError(t, store.AddBlock(b))
+	}
+
+	// the store exposes the optional BlockPruner capability
+	pruner, ok := store.(blkstorage.BlockPruner)
+	require.True(t, ok)
+	assert.Equal(t, uint64(0), pruner.PrunedUpTo())
+	assert.NoError(t, pruner.PruneBlocks(30))
+	prunedUpTo := pruner.PrunedUpTo()
+	assert.True(t, prunedUpTo > 0)
+	_, err = store.RetrieveBlockByNumber(prunedUpTo - 1)
+	assert.Equal(t, blkstorage.ErrBlockPruned, err)
+}
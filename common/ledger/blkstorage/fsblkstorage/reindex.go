@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"sync"
+
+	l "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// indexRebuildState tracks the progress of a background rebuild of the block index
+type indexRebuildState struct {
+	sync.Mutex
+	inProgress       bool
+	lastBlockIndexed uint64
+	targetBlockNum   uint64
+	lastError        string
+}
+
+// indexRebuildStatus returns a snapshot of the progress of the ongoing index
+// rebuild or the outcome of the last completed one
+func (mgr *blockfileMgr) indexRebuildStatus() *l.BlockIndexRebuildStatus {
+	state := &mgr.reindexState
+	state.Lock()
+	defer state.Unlock()
+	return &l.BlockIndexRebuildStatus{
+		InProgress:       state.inProgress,
+		LastBlockIndexed: state.lastBlockIndexed,
+		TargetBlockNum:   state.targetBlockNum,
+		LastError:        state.lastError,
+	}
+}
+
+// startIndexRebuild kicks off a rebuild of the block index from the block files in
+// a background goroutine. Unlike syncIndex(), which only appends the indexes of the
+// blocks beyond the last indexed one, a rebuild re-indexes every block still present
+// in the block files so that the entries of a newly enabled index attribute get
+// backfilled for the existing blocks. The index writes are idempotent and hence a
+// block that gets indexed concurrently by the commit path causes no harm
+func (mgr *blockfileMgr) startIndexRebuild() error {
+	state := &mgr.reindexState
+	state.Lock()
+	defer state.Unlock()
+	if state.inProgress {
+		return errors.New("a rebuild of the block index is already in progress")
+	}
+	bcInfo := mgr.getBlockchainInfo()
+	if bcInfo.Height == 0 {
+		return errors.New("the block store is empty")
+	}
+	// blocks below the prune marker are no longer present in the block files
+	startFileNum := mgr.getPruneMarker().fileSuffixNum
+	targetBlockNum := bcInfo.Height - 1
+	state.inProgress = true
+	state.lastError = ""
+	state.lastBlockIndexed = 0
+	state.targetBlockNum = targetBlockNum
+
+	go func() {
+		err := mgr.rebuildIndex(startFileNum, targetBlockNum)
+		if err == nil {
+			// pick up the blocks committed while the rebuild was running and
+			// restore the index checkpoint that the rebuild has moved backwards
+			err = mgr.syncIndex()
+		}
+		state.Lock()
+		defer state.Unlock()
+		state.inProgress = false
+		if err != nil {
+			state.lastError = err.Error()
+			logger.Errorf("Rebuild of the block index failed: %s", err)
+			return
+		}
+		logger.Infof("Finished rebuilding the block index. Last block indexed [%d]", state.lastBlockIndexed)
+	}()
+	return nil
+}
+
+// rebuildIndex re-indexes the blocks in the block files, from the first block still
+// on disk up to (and including) targetBlockNum
+func (mgr *blockfileMgr) rebuildIndex(startFileNum int, targetBlockNum uint64) error {
+	logger.Infof("Start rebuilding the block index up to block [%d]", targetBlockNum)
+	stream, err := newBlockStream(mgr.rootDir, startFileNum, 0, mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+
+	for {
+		blockBytes, blockPlacementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if info.blockHeader.Number > targetBlockNum {
+			break
+		}
+
+		// The blockStartOffset will get applied to the txOffsets prior to indexing
+		// within indexBlock(), therefore just shift by the difference between
+		// blockBytesOffset and blockStartOffset
+		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+		for _, offset := range info.txOffsets {
+			offset.loc.offset += numBytesToShift
+		}
+
+		blockIdxInfo := &blockIdxInfo{
+			blockHash: protoutil.BlockHeaderHash(info.blockHeader),
+			blockNum:  info.blockHeader.Number,
+			flp: &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
+				locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}},
+			txOffsets: info.txOffsets,
+			metadata:  info.metadata,
+		}
+		if err := mgr.index.indexBlock(blockIdxInfo); err != nil {
+			return err
+		}
+
+		mgr.reindexState.Lock()
+		mgr.reindexState.lastBlockIndexed = blockIdxInfo.blockNum
+		mgr.reindexState.Unlock()
+		if blockIdxInfo.blockNum%10000 == 0 {
+			logger.Infof("Rebuilt the index of block number [%d]", blockIdxInfo.blockNum)
+		}
+	}
+	return nil
+}
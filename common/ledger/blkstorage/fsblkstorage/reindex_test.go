@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildIndexBackfillsNewAttribute(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 10)
+
+	// create a store that indexes only the block number and add the blocks
+	env := newTestEnvSelectiveIndexing(t, NewConf(path, 0),
+		[]blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum}, &disabled.Provider{})
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	for _, block := range blocks {
+		assert.NoError(t, store.AddBlock(block))
+	}
+	env.provider.Close()
+
+	// reopen the store with the block hash attribute enabled - the entries of
+	// the already committed blocks are missing from the index. The txid
+	// attribute cannot serve as the backfilled attribute here, as enabling it
+	// also changes the expected data format version of the index db
+	env = newTestEnvSelectiveIndexing(t, NewConf(path, 0),
+		[]blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum, blkstorage.IndexableAttrBlockHash}, &disabled.Provider{})
+	defer env.Cleanup()
+	store, err = env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+	blockHash := protoutil.BlockHeaderHash(blocks[1].Header)
+	_, err = store.RetrieveBlockByHash(blockHash)
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// a background rebuild backfills the missing entries while the store keeps
+	// serving commits
+	rebuilder, ok := store.(blkstorage.BlockIndexRebuilder)
+	assert.True(t, ok)
+	assert.NoError(t, rebuilder.RebuildIndex())
+	waitForIndexRebuild(t, rebuilder)
+
+	status := rebuilder.IndexRebuildStatus()
+	assert.False(t, status.InProgress)
+	assert.Equal(t, uint64(9), status.TargetBlockNum)
+	assert.Equal(t, uint64(9), status.LastBlockIndexed)
+	assert.Empty(t, status.LastError)
+
+	retrievedByHash, err := store.RetrieveBlockByHash(blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[1], retrievedByHash)
+
+	// the entries of the attribute that was indexed all along remain intact
+	retrievedBlock, err := store.RetrieveBlockByNumber(5)
+	assert.NoError(t, err)
+	assert.Equal(t, blocks[5], retrievedBlock)
+}
+
+func TestRebuildIndexEmptyStore(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	store, err := env.provider.OpenBlockStore("testLedger")
+	assert.NoError(t, err)
+
+	rebuilder, ok := store.(blkstorage.BlockIndexRebuilder)
+	assert.True(t, ok)
+	assert.EqualError(t, rebuilder.RebuildIndex(), "the block store is empty")
+}
+
+func waitForIndexRebuild(t *testing.T, rebuilder blkstorage.BlockIndexRebuilder) {
+	for i := 0; i < 100; i++ {
+		if !rebuilder.IndexRebuildStatus().InProgress {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the index rebuild to finish")
+}
@@ -47,6 +47,23 @@ func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConf
 		return err
 	}
 
+	logger.Infof("Verifying the block files after the rollback")
+	return r.verifyRollbackResult()
+}
+
+// verifyRollbackResult reconstructs the checkpoint info from the block files
+// and checks that the target block is the last block present - i.e., that the
+// removal and the truncation of the block files have left the store in a
+// consistent state
+func (r *rollbackMgr) verifyRollbackResult() error {
+	cpInfo, err := constructCheckpointInfoFromBlockFiles(r.ledgerDir)
+	if err != nil {
+		return err
+	}
+	if cpInfo.isChainEmpty || cpInfo.lastBlockNumber != r.targetBlockNum {
+		return errors.Errorf("block store is not consistent after rollback: last block number [%d], expected [%d]",
+			cpInfo.lastBlockNumber, r.targetBlockNum)
+	}
 	return nil
 }
 
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// BlockStoreVerificationResult captures the outcome of scanning the block files
+// of a ledger for integrity verification
+type BlockStoreVerificationResult struct {
+	// LedgerID is the ledger that was verified
+	LedgerID string
+	// NumBlocks is the number of blocks present in the block files
+	NumBlocks uint64
+	// LastBlockNumber is the number of the last block present in the block files
+	LastBlockNumber uint64
+	// LastBlockHash is the hash of the header of the last block
+	LastBlockHash []byte
+}
+
+// VerifyBlockStore scans all the block files of the given ledger and verifies
+// (a) that the data hash recorded in each block header matches the recomputed
+// hash of the block data, (b) that each block header carries the hash of the
+// previous block header (i.e., the hash chain is intact), and (c) that the
+// block index points each block to the file location where the block was
+// actually found. The block store is expected not to be in use by a running
+// peer - the caller is expected to hold the ledger file lock
+func VerifyBlockStore(blockStorageDir, ledgerID string, indexConfig *blkstorage.IndexConfig) (*BlockStoreVerificationResult, error) {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	exists, _, err := util.FileExists(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+
+	dbProvider, err := leveldbhelper.NewProvider(
+		&leveldbhelper.Conf{
+			DBPath:                conf.getIndexDir(),
+			ExpectedFormatVersion: dataFormatVersion(indexConfig),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer dbProvider.Close()
+	indexStore, err := newBlockIndex(indexConfig, dbProvider.GetDBHandle(ledgerID))
+	if err != nil {
+		return nil, err
+	}
+
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlockStoreVerificationResult{LedgerID: ledgerID}
+	var prevHeaderHash []byte
+	for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+		stream, err := newBlockfileStream(ledgerDir, fileNum, 0)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			blockBytes, placementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+			if err != nil {
+				stream.close()
+				return nil, err
+			}
+			if blockBytes == nil {
+				break
+			}
+			block, err := deserializeBlock(blockBytes)
+			if err != nil {
+				stream.close()
+				return nil, errors.WithMessagef(err, "error deserializing the block at file [%d] offset [%d]",
+					placementInfo.fileNum, placementInfo.blockStartOffset)
+			}
+			blockNum := block.Header.Number
+			if result.NumBlocks > 0 && blockNum != result.LastBlockNumber+1 {
+				stream.close()
+				return nil, errors.Errorf("block number [%d] found after block number [%d] - missing or out of order blocks",
+					blockNum, result.LastBlockNumber)
+			}
+			if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+				stream.close()
+				return nil, errors.Errorf("data hash mismatch for block [%d] - the block data does not match the hash recorded in the block header", blockNum)
+			}
+			if result.NumBlocks > 0 && !bytes.Equal(block.Header.PreviousHash, prevHeaderHash) {
+				stream.close()
+				return nil, errors.Errorf("hash chain broken at block [%d] - the previous hash recorded in the block header does not match the hash of block [%d]",
+					blockNum, result.LastBlockNumber)
+			}
+			if err := verifyIndexEntry(indexStore, blockNum, placementInfo); err != nil {
+				stream.close()
+				return nil, err
+			}
+			prevHeaderHash = protoutil.BlockHeaderHash(block.Header)
+			result.NumBlocks++
+			result.LastBlockNumber = blockNum
+			result.LastBlockHash = prevHeaderHash
+		}
+		stream.close()
+	}
+	return result, nil
+}
+
+// ScanBlockStore streams the committed blocks of the given ledger, in order,
+// to the handler. The blocks are read directly from the block files and the
+// block index is not consulted. The block store is expected not to be in use
+// by a running peer - the caller is expected to hold the ledger file lock
+func ScanBlockStore(blockStorageDir, ledgerID string, handler func(block *common.Block) error) error {
+	conf := &Conf{blockStorageDir: blockStorageDir}
+	ledgerDir := conf.getLedgerBlockDir(ledgerID)
+	exists, _, err := util.FileExists(ledgerDir)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+
+	lastFileNum, err := retrieveLastFileSuffix(ledgerDir)
+	if err != nil {
+		return err
+	}
+	for fileNum := 0; fileNum <= lastFileNum; fileNum++ {
+		stream, err := newBlockfileStream(ledgerDir, fileNum, 0)
+		if err != nil {
+			return err
+		}
+		for {
+			blockBytes, err := stream.nextBlockBytes()
+			if err != nil {
+				stream.close()
+				return err
+			}
+			if blockBytes == nil {
+				break
+			}
+			block, err := deserializeBlock(blockBytes)
+			if err != nil {
+				stream.close()
+				return errors.WithMessagef(err, "error deserializing a block of file [%d]", fileNum)
+			}
+			if err := handler(block); err != nil {
+				stream.close()
+				return err
+			}
+		}
+		stream.close()
+	}
+	return nil
+}
+
+// verifyIndexEntry cross-checks that the block index points the given block
+// number to the file location where the block was found during the scan
+func verifyIndexEntry(indexStore *blockIndex, blockNum uint64, placementInfo *blockPlacementInfo) error {
+	flp, err := indexStore.getBlockLocByBlockNum(blockNum)
+	if err == blkstorage.ErrAttrNotIndexed {
+		// the blockNum attribute is not configured for indexing - nothing to cross-check
+		return nil
+	}
+	if err != nil {
+		return errors.WithMessagef(err, "error retrieving the index entry for block [%d]", blockNum)
+	}
+	if flp.fileSuffixNum != placementInfo.fileNum || int64(flp.offset) != placementInfo.blockStartOffset {
+		return errors.Errorf("index mismatch for block [%d] - the index points to file [%d] offset [%d] but the block was found at file [%d] offset [%d]",
+			blockNum, flp.fileSuffixNum, flp.offset, placementInfo.fileNum, placementInfo.blockStartOffset)
+	}
+	return nil
+}
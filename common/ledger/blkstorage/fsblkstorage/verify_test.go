@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyBlockStore(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 50) // 50 blocks persisted in ~5 block files
+	blocksPerFile := 50 / 5
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+	for i, b := range blocks {
+		assert.NoError(t, blkfileMgr.addBlock(b))
+		if i != 0 && i%blocksPerFile == 0 {
+			blkfileMgr.moveToNextFile()
+		}
+	}
+	env.provider.Close()
+	blkfileMgrWrapper.close()
+
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+
+	t.Run("green-path", func(t *testing.T) {
+		result, err := VerifyBlockStore(path, "testLedger", indexConfig)
+		assert.NoError(t, err)
+		assert.Equal(t, "testLedger", result.LedgerID)
+		assert.Equal(t, uint64(50), result.NumBlocks)
+		assert.Equal(t, uint64(49), result.LastBlockNumber)
+		assert.Equal(t, protoutil.BlockHeaderHash(blocks[49].Header), result.LastBlockHash)
+	})
+
+	t.Run("non-existing-ledger", func(t *testing.T) {
+		_, err := VerifyBlockStore(path, "non-existing-ledger", indexConfig)
+		assert.EqualError(t, err, "ledgerID [non-existing-ledger] does not exist")
+	})
+
+	t.Run("tampered-block-file", func(t *testing.T) {
+		conf := &Conf{blockStorageDir: path}
+		ledgerDir := conf.getLedgerBlockDir("testLedger")
+		blockfilePath := deriveBlockfilePath(ledgerDir, 2)
+		contents, err := ioutil.ReadFile(blockfilePath)
+		assert.NoError(t, err)
+		// flip a byte in the middle of the file and verify that the scan detects the corruption
+		tampered := make([]byte, len(contents))
+		copy(tampered, contents)
+		tampered[len(tampered)/2] ^= 0xff
+		assert.NoError(t, ioutil.WriteFile(blockfilePath, tampered, 0644))
+		defer func() {
+			assert.NoError(t, ioutil.WriteFile(blockfilePath, contents, 0644))
+		}()
+		_, err = VerifyBlockStore(path, "testLedger", indexConfig)
+		assert.Error(t, err)
+	})
+}
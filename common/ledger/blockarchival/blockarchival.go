@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockarchival provides the archival backends that blocks pruned
+// from a local block store are offloaded to, and read back from on demand.
+// The backends are shared between the orderer's ledger archiver and the
+// peer's block store retention.
+package blockarchival
+
+import (
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// Sink receives blocks that have aged out of a retention window. A Sink must
+// store blocks durably before returning, since a successfully archived block
+// becomes eligible for removal from the local block store.
+type Sink interface {
+	Put(channelID string, block *cb.Block) error
+}
+
+// Source serves previously archived blocks.
+type Source interface {
+	Get(channelID string, number uint64) (*cb.Block, error)
+}
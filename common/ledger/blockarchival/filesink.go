@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockarchival
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+// FileSink archives blocks as individual protobuf encoded files under a base
+// directory, one subdirectory per channel. It may serve as a staging area for
+// object storage uploads driven by external tooling.
+type FileSink struct {
+	baseDir string
+}
+
+// NewFileSink creates a FileSink rooted at baseDir, creating the directory if
+// it does not exist.
+func NewFileSink(baseDir string) (*FileSink, error) {
+	if err := os.MkdirAll(baseDir, 0700); err != nil {
+		return nil, errors.WithMessagef(err, "failed to create archive directory %s", baseDir)
+	}
+	return &FileSink{baseDir: baseDir}, nil
+}
+
+// Put writes the block to <baseDir>/<channelID>/block_<number>.pb. The block
+// is written to a temporary file and renamed into place so that a partially
+// written block is never observed.
+func (s *FileSink) Put(channelID string, block *cb.Block) error {
+	channelDir := filepath.Join(s.baseDir, channelID)
+	if err := os.MkdirAll(channelDir, 0700); err != nil {
+		return errors.WithMessagef(err, "failed to create channel archive directory %s", channelDir)
+	}
+
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to marshal block %d", block.Header.Number)
+	}
+
+	tempFile, err := ioutil.TempFile(channelDir, ".block")
+	if err != nil {
+		return errors.WithMessage(err, "failed to create temporary block file")
+	}
+
+	if _, err := tempFile.Write(blockBytes); err != nil {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+		return errors.WithMessagef(err, "failed to write block %d", block.Header.Number)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempFile.Name())
+		return errors.WithMessagef(err, "failed to close block file for block %d", block.Header.Number)
+	}
+
+	blockPath := filepath.Join(channelDir, fmt.Sprintf("block_%020d.pb", block.Header.Number))
+	if err := os.Rename(tempFile.Name(), blockPath); err != nil {
+		os.Remove(tempFile.Name())
+		return errors.WithMessagef(err, "failed to move block %d into place", block.Header.Number)
+	}
+
+	return nil
+}
+
+// Get retrieves an archived block from the base directory.
+func (s *FileSink) Get(channelID string, number uint64) (*cb.Block, error) {
+	blockPath := filepath.Join(s.baseDir, channelID, fmt.Sprintf("block_%020d.pb", number))
+	blockBytes, err := ioutil.ReadFile(blockPath)
+	if os.IsNotExist(err) {
+		return nil, errors.Errorf("block %d of channel %s is not archived", number, channelID)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read block %d of channel %s", number, channelID)
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.WithMessagef(err, "failed to unmarshal block %d of channel %s", number, channelID)
+	}
+
+	return block, nil
+}
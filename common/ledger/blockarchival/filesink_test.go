@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockarchival
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink(t *testing.T) {
+	baseDir, err := ioutil.TempDir("", "blockarchival")
+	assert.NoError(t, err)
+	defer os.RemoveAll(baseDir)
+
+	sink, err := NewFileSink(filepath.Join(baseDir, "archive"))
+	assert.NoError(t, err)
+
+	block := protoutil.NewBlock(7, nil)
+	assert.NoError(t, sink.Put("testchannel", block))
+
+	blockPath := filepath.Join(baseDir, "archive", "testchannel", fmt.Sprintf("block_%020d.pb", 7))
+	blockBytes, err := ioutil.ReadFile(blockPath)
+	assert.NoError(t, err)
+
+	archivedBlock := &cb.Block{}
+	assert.NoError(t, proto.Unmarshal(blockBytes, archivedBlock))
+	assert.True(t, proto.Equal(block, archivedBlock))
+
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(filepath.Join(baseDir, "archive", "testchannel"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	// Get round-trips the block
+	retrievedBlock, err := sink.Get("testchannel", 7)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(block, retrievedBlock))
+
+	_, err = sink.Get("testchannel", 8)
+	assert.EqualError(t, err, "block 8 of channel testchannel is not archived")
+}
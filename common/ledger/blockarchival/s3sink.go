@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockarchival
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+// S3Config holds the connection parameters of an S3-compatible object store.
+// Endpoint is the base URL of the service, e.g. "https://s3.amazonaws.com" or
+// the address of an S3-compatible gateway. Requests are issued path-style, so
+// the bucket does not need to resolve as a virtual host.
+type S3Config struct {
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// Prefix is prepended to every object key, allowing a bucket to be
+	// shared with other data.
+	Prefix string
+}
+
+// S3Sink archives blocks to an S3-compatible object store and serves them back
+// on demand, providing a read-through path for blocks that have been pruned
+// from the local block store. Objects are keyed as
+// <prefix>/<channelID>/block_<number>.pb, mirroring the FileSink layout.
+type S3Sink struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Sink creates an S3Sink from the given configuration.
+func NewS3Sink(config S3Config) (*S3Sink, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("object store endpoint is required")
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("object store bucket is required")
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return nil, errors.WithMessagef(err, "invalid object store endpoint %s", config.Endpoint)
+	}
+
+	return &S3Sink{
+		config: config,
+		client: &http.Client{},
+		now:    time.Now,
+	}, nil
+}
+
+// Put uploads the block to the object store. It does not return until the
+// store has acknowledged the write, satisfying the durability contract of the
+// Sink interface.
+func (s *S3Sink) Put(channelID string, block *cb.Block) error {
+	blockBytes, err := proto.Marshal(block)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to marshal block %d", block.Header.Number)
+	}
+
+	resp, err := s.do(http.MethodPut, s.objectKey(channelID, block.Header.Number), blockBytes)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to upload block %d of channel %s", block.Header.Number, channelID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("object store returned status %d uploading block %d of channel %s", resp.StatusCode, block.Header.Number, channelID)
+	}
+
+	return nil
+}
+
+// Get retrieves an archived block from the object store.
+func (s *S3Sink) Get(channelID string, number uint64) (*cb.Block, error) {
+	resp, err := s.do(http.MethodGet, s.objectKey(channelID, number), nil)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to retrieve block %d of channel %s", number, channelID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.Errorf("block %d of channel %s is not archived", number, channelID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("object store returned status %d retrieving block %d of channel %s", resp.StatusCode, number, channelID)
+	}
+
+	blockBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read block %d of channel %s", number, channelID)
+	}
+
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		return nil, errors.WithMessagef(err, "failed to unmarshal block %d of channel %s", number, channelID)
+	}
+
+	return block, nil
+}
+
+func (s *S3Sink) objectKey(channelID string, number uint64) string {
+	key := fmt.Sprintf("%s/block_%020d.pb", channelID, number)
+	if s.config.Prefix != "" {
+		key = strings.TrimSuffix(s.config.Prefix, "/") + "/" + key
+	}
+	return key
+}
+
+// do issues a signed request for the given object key. Requests are signed
+// with AWS Signature Version 4, which S3-compatible stores accept; if no
+// access key is configured the request is sent unsigned.
+func (s *S3Sink) do(method, key string, body []byte) (*http.Response, error) {
+	reqURL := strings.TrimSuffix(s.config.Endpoint, "/") + "/" + s.config.Bucket + "/" + key
+	req, err := http.NewRequest(method, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	req.Header.Set("X-Amz-Date", s.now().UTC().Format("20060102T150405Z"))
+
+	if s.config.AccessKeyID != "" {
+		s.sign(req, hex.EncodeToString(payloadHash[:]))
+	}
+
+	return s.client.Do(req)
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request.
+// See https://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
+func (s *S3Sink) sign(req *http.Request, payloadHash string) {
+	amzDate := req.Header.Get("X-Amz-Date")
+	date := amzDate[:8]
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.config.Region, "s3", "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
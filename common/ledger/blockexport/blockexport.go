@@ -0,0 +1,23 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package blockexport contains the sinks that the peer uses for streaming
+// committed ledger data to external systems. A sink receives an opaque payload
+// per block - the peer decides whether the payload is the serialized block or
+// the extracted write-sets. Delivery is driven by the block commit path and is
+// at-least-once: the peer advances its resume token only after a successful
+// delivery, so a sink may receive the same block again after a failure or a
+// restart and must tolerate duplicates.
+package blockexport
+
+// Sink delivers exported ledger data for committed blocks to an external system
+type Sink interface {
+	// Deliver sends the payload for the given block of the given channel.
+	// A non-nil error causes the delivery to be retried after the next commit
+	Deliver(channelID string, blockNum uint64, payload []byte) error
+	// Close releases the resources held by the sink
+	Close() error
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockexport
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+)
+
+// KafkaConfig holds the parameters of a Kafka sink
+type KafkaConfig struct {
+	// Brokers are the addresses of the Kafka brokers.
+	Brokers []string
+	// Topic is the topic the block payloads are produced to. The channel ID is
+	// used as the message key, so the payloads of a channel land in the same
+	// partition and retain the commit order.
+	Topic string
+}
+
+// KafkaSink produces the payload of each block as a message to a Kafka topic
+type KafkaSink struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+// NewKafkaSink constructs a KafkaSink that produces to the given brokers and topic
+func NewKafkaSink(conf KafkaConfig) (*KafkaSink, error) {
+	if len(conf.Brokers) == 0 {
+		return nil, errors.New("the kafka block export sink requires at least one broker")
+	}
+	if conf.Topic == "" {
+		return nil, errors.New("the kafka block export sink requires a topic")
+	}
+	saramaConf := sarama.NewConfig()
+	saramaConf.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConf.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(conf.Brokers, saramaConf)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the kafka producer for block export")
+	}
+	return &KafkaSink{producer: producer, topic: conf.Topic}, nil
+}
+
+// Deliver implements the function from interface Sink
+func (s *KafkaSink) Deliver(channelID string, blockNum uint64, payload []byte) error {
+	_, _, err := s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: s.topic,
+		Key:   sarama.StringEncoder(channelID),
+		Value: sarama.ByteEncoder(payload),
+	})
+	return errors.Wrapf(err, "error producing the payload of block [%d] of channel [%s]", blockNum, channelID)
+}
+
+// Close implements the function from interface Sink
+func (s *KafkaSink) Close() error {
+	return s.producer.Close()
+}
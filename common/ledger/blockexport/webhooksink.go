@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockexport
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookConfig holds the parameters of a webhook sink
+type WebhookConfig struct {
+	// URL is the endpoint the block payloads are POSTed to.
+	URL string
+	// RequestTimeout is the timeout for a single delivery attempt.
+	// A zero value defaults to 30s.
+	RequestTimeout time.Duration
+}
+
+// WebhookSink POSTs the payload of each block to an HTTP(S) endpoint. The
+// channel and the block number are carried in the X-Fabric-Channel and
+// X-Fabric-Block-Number request headers
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink constructs a WebhookSink that delivers to the given URL
+func NewWebhookSink(conf WebhookConfig) (*WebhookSink, error) {
+	if _, err := url.ParseRequestURI(conf.URL); err != nil {
+		return nil, errors.Wrapf(err, "invalid webhook block export URL [%s]", conf.URL)
+	}
+	timeout := conf.RequestTimeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	return &WebhookSink{
+		url:    conf.URL,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Deliver implements the function from interface Sink
+func (s *WebhookSink) Deliver(channelID string, blockNum uint64, payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Fabric-Channel", channelID)
+	req.Header.Set("X-Fabric-Block-Number", strconv.FormatUint(blockNum, 10))
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "error delivering the payload of block [%d] of channel [%s]", blockNum, channelID)
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned status code [%d] for block [%d] of channel [%s]",
+			resp.StatusCode, blockNum, channelID)
+	}
+	return nil
+}
+
+// Close implements the function from interface Sink
+func (s *WebhookSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
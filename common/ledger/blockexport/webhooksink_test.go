@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package blockexport
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var receivedChannel, receivedBlockNum string
+	var receivedPayload []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedChannel = r.Header.Get("X-Fabric-Channel")
+		receivedBlockNum = r.Header.Get("X-Fabric-Block-Number")
+		receivedPayload, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink, err := NewWebhookSink(WebhookConfig{URL: server.URL})
+	assert.NoError(t, err)
+	defer sink.Close()
+
+	assert.NoError(t, sink.Deliver("testchannel", 7, []byte("payload-bytes")))
+	assert.Equal(t, "testchannel", receivedChannel)
+	assert.Equal(t, "7", receivedBlockNum)
+	assert.Equal(t, []byte("payload-bytes"), receivedPayload)
+
+	// a non-2xx response surfaces as a delivery error so that the block is retried
+	status = http.StatusInternalServerError
+	err = sink.Deliver("testchannel", 8, []byte("payload-bytes"))
+	assert.EqualError(t, err, "webhook returned status code [500] for block [8] of channel [testchannel]")
+
+	// an invalid URL is rejected at construction
+	_, err = NewWebhookSink(WebhookConfig{URL: "not-a-url"})
+	assert.Contains(t, err.Error(), "invalid webhook block export URL")
+}
+
+func TestKafkaSinkConfigValidation(t *testing.T) {
+	_, err := NewKafkaSink(KafkaConfig{Topic: "blocks"})
+	assert.EqualError(t, err, "the kafka block export sink requires at least one broker")
+
+	_, err = NewKafkaSink(KafkaConfig{Brokers: []string{"localhost:9092"}})
+	assert.EqualError(t, err, "the kafka block export sink requires a topic")
+}
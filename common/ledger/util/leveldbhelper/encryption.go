@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KeyProvider supplies the ring of keys used for encrypting the values of a db.
+// The keys are ordered from the oldest to the current one - values are always
+// encrypted with the current key while any key of the ring may be used for
+// decryption, so that a db encrypted with a retired key remains readable after
+// a rotation. Implementations may load the keys from a file, an HSM, or a KMS.
+type KeyProvider interface {
+	// RetrieveKeys returns the ring of 256-bit encryption keys, ordered from
+	// the oldest to the current one
+	RetrieveKeys() ([][]byte, error)
+}
+
+// FileKeyProvider loads the encryption key ring from a file that contains one
+// base64 encoded 256-bit key per line, the last line being the current key.
+// Rotating the key amounts to appending a new key to the file and restarting
+// the peer - the values encrypted with the retired keys are re-encrypted with
+// the current key by a background pass over the db.
+type FileKeyProvider struct {
+	path string
+}
+
+// NewFileKeyProvider constructs a FileKeyProvider for the key ring file at the given path
+func NewFileKeyProvider(path string) *FileKeyProvider {
+	return &FileKeyProvider{path: path}
+}
+
+// RetrieveKeys implements the function from interface KeyProvider
+func (p *FileKeyProvider) RetrieveKeys() ([][]byte, error) {
+	content, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading encryption key file [%s]", p.path)
+	}
+	var keys [][]byte
+	for lineNum, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error decoding the key at line [%d] of encryption key file [%s]", lineNum+1, p.path)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ValueEncryptor performs transparent AES-256-GCM encryption of the values of a db.
+// Each encrypted value carries the index of the key ring entry it was sealed with,
+// so that after a key rotation the values encrypted with a retired key can still be
+// decrypted and can be detected for re-encryption
+type ValueEncryptor struct {
+	aeads []cipher.AEAD
+}
+
+// NewValueEncryptor constructs a ValueEncryptor from the keys supplied by the given KeyProvider
+func NewValueEncryptor(keyProvider KeyProvider) (*ValueEncryptor, error) {
+	keys, err := keyProvider.RetrieveKeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("at least one encryption key is required")
+	}
+	encryptor := &ValueEncryptor{}
+	for i, key := range keys {
+		if len(key) != 32 {
+			return nil, errors.Errorf("the encryption key at index [%d] is [%d] bytes, expected a 256-bit key", i, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error constructing cipher from the encryption key at index [%d]", i)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error constructing GCM from the encryption key at index [%d]", i)
+		}
+		encryptor.aeads = append(encryptor.aeads, aead)
+	}
+	return encryptor, nil
+}
+
+// encrypt seals the value with the current key. The encrypted value is laid out as
+// a 4-byte big endian key index, followed by the nonce, followed by the ciphertext
+func (e *ValueEncryptor) encrypt(value []byte) ([]byte, error) {
+	keyIndex := len(e.aeads) - 1
+	aead := e.aeads[keyIndex]
+	encrypted := make([]byte, 4, 4+aead.NonceSize()+len(value)+aead.Overhead())
+	binary.BigEndian.PutUint32(encrypted, uint32(keyIndex))
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "error generating nonce")
+	}
+	encrypted = append(encrypted, nonce...)
+	return aead.Seal(encrypted, nonce, value, nil), nil
+}
+
+// decrypt opens the value with the key ring entry recorded in the value itself
+func (e *ValueEncryptor) decrypt(value []byte) ([]byte, error) {
+	keyIndex, rest, err := e.parseEncryptedValue(value)
+	if err != nil {
+		return nil, err
+	}
+	aead := e.aeads[keyIndex]
+	nonce, ciphertext := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decrypt value - the db may have been written with a key that is not in the key ring")
+	}
+	return plaintext, nil
+}
+
+// encryptedWithRetiredKey returns true if the value is sealed with a key that is
+// older than the current one
+func (e *ValueEncryptor) encryptedWithRetiredKey(value []byte) bool {
+	keyIndex, _, err := e.parseEncryptedValue(value)
+	return err == nil && keyIndex < len(e.aeads)-1
+}
+
+// hasRetiredKeys returns true if the key ring contains keys older than the current one
+func (e *ValueEncryptor) hasRetiredKeys() bool {
+	return len(e.aeads) > 1
+}
+
+func (e *ValueEncryptor) parseEncryptedValue(value []byte) (int, []byte, error) {
+	if len(value) < 4 {
+		return 0, nil, errors.New("encrypted value is too short to carry a key index")
+	}
+	keyIndex := int(binary.BigEndian.Uint32(value))
+	if keyIndex >= len(e.aeads) {
+		return 0, nil, errors.Errorf("value is encrypted with key index [%d] which is not in the key ring of size [%d]", keyIndex, len(e.aeads))
+	}
+	rest := value[4:]
+	if len(rest) < e.aeads[keyIndex].NonceSize() {
+		return 0, nil, errors.New("encrypted value is too short to carry a nonce")
+	}
+	return keyIndex, rest, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileKeyProvider(t *testing.T) {
+	key1 := generateTestEncryptionKey(t)
+	key2 := generateTestEncryptionKey(t)
+
+	t.Run("valid-key-ring", func(t *testing.T) {
+		path := createTestKeyFile(t, key1, key2)
+		defer os.RemoveAll(filepath.Dir(path))
+		keys, err := NewFileKeyProvider(path).RetrieveKeys()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{key1, key2}, keys)
+	})
+
+	t.Run("blank-lines-are-skipped", func(t *testing.T) {
+		path := createTestKeyFile(t, key1)
+		defer os.RemoveAll(filepath.Dir(path))
+		content := "\n" + base64.StdEncoding.EncodeToString(key1) + "\n\n"
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+		keys, err := NewFileKeyProvider(path).RetrieveKeys()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]byte{key1}, keys)
+	})
+
+	t.Run("missing-file", func(t *testing.T) {
+		_, err := NewFileKeyProvider("/non-existing-path/keyfile").RetrieveKeys()
+		assert.Contains(t, err.Error(), "error reading encryption key file")
+	})
+
+	t.Run("invalid-base64", func(t *testing.T) {
+		path := createTestKeyFile(t, key1)
+		defer os.RemoveAll(filepath.Dir(path))
+		assert.NoError(t, ioutil.WriteFile(path, []byte("not-a-base64-key!\n"), 0600))
+		_, err := NewFileKeyProvider(path).RetrieveKeys()
+		assert.Contains(t, err.Error(), "error decoding the key at line [1]")
+	})
+}
+
+func TestValueEncryptorValidation(t *testing.T) {
+	t.Run("empty-key-ring", func(t *testing.T) {
+		path := createTestKeyFile(t)
+		defer os.RemoveAll(filepath.Dir(path))
+		_, err := NewValueEncryptor(NewFileKeyProvider(path))
+		assert.EqualError(t, err, "at least one encryption key is required")
+	})
+
+	t.Run("wrong-key-length", func(t *testing.T) {
+		path := createTestKeyFile(t, []byte("too-short"))
+		defer os.RemoveAll(filepath.Dir(path))
+		_, err := NewValueEncryptor(NewFileKeyProvider(path))
+		assert.EqualError(t, err, "the encryption key at index [0] is [9] bytes, expected a 256-bit key")
+	})
+}
+
+func TestEncryptedDBWriteAndReads(t *testing.T) {
+	env := newTestEncryptedProviderEnv(t, generateTestEncryptionKey(t))
+	defer env.cleanup()
+	p := env.provider
+
+	db := p.GetDBHandle("db")
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), false))
+	assert.NoError(t, db.Put([]byte("key2"), []byte("value2"), false))
+
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	// the value in the underlying db carries the key index and is not the plaintext
+	rawValue, err := p.db.db.Get(constructLevelKey("db", []byte("key1")), p.db.readOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0}, rawValue[:4])
+	assert.NotContains(t, string(rawValue), "value1")
+
+	batch := NewUpdateBatch()
+	batch.Put([]byte("key3"), []byte("value3"))
+	batch.Delete([]byte("key2"))
+	assert.NoError(t, db.WriteBatch(batch, false))
+
+	value, err = db.Get([]byte("key2"))
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	itr := db.GetIterator(nil, nil)
+	checkItrResults(t, itr, []string{"key1", "key3"}, []string{"value1", "value3"})
+}
+
+func TestEncryptedDBOpenWithWrongKey(t *testing.T) {
+	env := newTestEncryptedProviderEnv(t, generateTestEncryptionKey(t))
+	defer env.cleanup()
+	assert.NoError(t, env.provider.GetDBHandle("db").Put([]byte("key1"), []byte("value1"), true))
+	env.provider.Close()
+
+	env.reopen(generateTestEncryptionKey(t))
+	_, err := env.provider.GetDBHandle("db").Get([]byte("key1"))
+	assert.Contains(t, err.Error(), "unable to decrypt value - the db may have been written with a key that is not in the key ring")
+}
+
+func TestKeyRotationAndReEncryption(t *testing.T) {
+	retiredKey := generateTestEncryptionKey(t)
+	env := newTestEncryptedProviderEnv(t, retiredKey)
+	defer env.cleanup()
+	db := env.provider.GetDBHandle("db")
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), false))
+	assert.NoError(t, db.Put([]byte("key2"), []byte("value2"), true))
+	env.provider.Close()
+
+	// reopen with a rotated key ring - the values sealed with the retired key remain readable
+	env.reopen(retiredKey, generateTestEncryptionKey(t))
+	db = env.provider.GetDBHandle("db")
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), value)
+
+	// after the re-encryption pass, no value is sealed with the retired key anymore
+	env.provider.reEncryptStaleValues()
+	itr := env.provider.db.GetIterator(nil, nil)
+	defer itr.Release()
+	for itr.Next() {
+		assert.False(t, env.provider.db.encryptor.encryptedWithRetiredKey(itr.Value()))
+	}
+	assert.NoError(t, itr.Error())
+
+	value, err = db.Get([]byte("key2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value2"), value)
+}
+
+func TestReEncryptValueSkipsConcurrentlyChangedValue(t *testing.T) {
+	env := newTestEncryptedProviderEnv(t, generateTestEncryptionKey(t))
+	defer env.cleanup()
+	db := env.provider.GetDBHandle("db")
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1"), false))
+
+	levelKey := constructLevelKey("db", []byte("key1"))
+	observedValue, err := env.provider.db.db.Get(levelKey, env.provider.db.readOpts)
+	assert.NoError(t, err)
+
+	// a rewrite between observing the value and re-encrypting it must not be undone
+	assert.NoError(t, db.Put([]byte("key1"), []byte("value1-updated"), false))
+	written, err := env.provider.db.reEncryptValue(levelKey, observedValue)
+	assert.NoError(t, err)
+	assert.False(t, written)
+
+	value, err := db.Get([]byte("key1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1-updated"), value)
+
+	// a deleted key is skipped as well
+	assert.NoError(t, db.Delete([]byte("key1"), false))
+	written, err = env.provider.db.reEncryptValue(levelKey, observedValue)
+	assert.NoError(t, err)
+	assert.False(t, written)
+}
+
+type testEncryptedProviderEnv struct {
+	t        *testing.T
+	dbPath   string
+	keyPath  string
+	provider *Provider
+}
+
+func newTestEncryptedProviderEnv(t *testing.T, keys ...[]byte) *testEncryptedProviderEnv {
+	dbPath := filepath.Join(testDBPath, "encrypted")
+	assert.NoError(t, os.RemoveAll(dbPath))
+	env := &testEncryptedProviderEnv{t: t, dbPath: dbPath, keyPath: createTestKeyFile(t, keys...)}
+	env.open(keys...)
+	return env
+}
+
+func (env *testEncryptedProviderEnv) open(keys ...[]byte) {
+	env.keyPath = createTestKeyFile(env.t, keys...)
+	encryptor, err := NewValueEncryptor(NewFileKeyProvider(env.keyPath))
+	assert.NoError(env.t, err)
+	env.provider, err = NewProvider(&Conf{DBPath: env.dbPath, Encryptor: encryptor})
+	assert.NoError(env.t, err)
+}
+
+func (env *testEncryptedProviderEnv) reopen(keys ...[]byte) {
+	os.RemoveAll(filepath.Dir(env.keyPath))
+	env.open(keys...)
+}
+
+func (env *testEncryptedProviderEnv) cleanup() {
+	env.provider.Close()
+	assert.NoError(env.t, os.RemoveAll(env.dbPath))
+	assert.NoError(env.t, os.RemoveAll(filepath.Dir(env.keyPath)))
+}
+
+func generateTestEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.NoError(t, err)
+	return key
+}
+
+func createTestKeyFile(t *testing.T, keys ...[]byte) string {
+	dir, err := ioutil.TempDir("", "leveldbencryption")
+	assert.NoError(t, err)
+	var lines []string
+	for _, key := range keys {
+		lines = append(lines, base64.StdEncoding.EncodeToString(key))
+	}
+	path := filepath.Join(dir, "keyfile")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")), 0600))
+	return path
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package leveldbhelper
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 	"syscall"
@@ -31,10 +32,11 @@ const (
 
 // DB - a wrapper on an actual store
 type DB struct {
-	conf    *Conf
-	db      *leveldb.DB
-	dbState dbState
-	mutex   sync.RWMutex
+	conf      *Conf
+	db        *leveldb.DB
+	dbState   dbState
+	mutex     sync.RWMutex
+	encryptor *ValueEncryptor
 
 	readOpts        *opt.ReadOptions
 	writeOptsNoSync *opt.WriteOptions
@@ -51,6 +53,7 @@ func CreateDB(conf *Conf) *DB {
 	return &DB{
 		conf:            conf,
 		dbState:         closed,
+		encryptor:       conf.Encryptor,
 		readOpts:        readOpts,
 		writeOptsNoSync: writeOptsNoSync,
 		writeOptsSync:   writeOptsSync}
@@ -112,6 +115,11 @@ func (dbInst *DB) Get(key []byte) ([]byte, error) {
 		logger.Errorf("Error retrieving leveldb key [%#v]: %s", key, err)
 		return nil, errors.Wrapf(err, "error retrieving leveldb key [%#v]", key)
 	}
+	if dbInst.encryptor != nil && value != nil {
+		if value, err = dbInst.encryptor.decrypt(value); err != nil {
+			return nil, errors.WithMessagef(err, "error decrypting the value of leveldb key [%#v]", key)
+		}
+	}
 	return value, nil
 }
 
@@ -123,6 +131,12 @@ func (dbInst *DB) Put(key []byte, value []byte, sync bool) error {
 	if sync {
 		wo = dbInst.writeOptsSync
 	}
+	if dbInst.encryptor != nil {
+		var err error
+		if value, err = dbInst.encryptor.encrypt(value); err != nil {
+			return errors.WithMessagef(err, "error encrypting the value of leveldb key [%#v]", key)
+		}
+	}
 	err := dbInst.db.Put(key, value, wo)
 	if err != nil {
 		logger.Errorf("Error writing leveldb key [%#v]", key)
@@ -170,6 +184,37 @@ func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	return nil
 }
 
+// reEncryptValue rewrites the value of the given key with the current encryption key.
+// The write is skipped if the value has been rewritten concurrently since it was
+// observed - the regular writes seal with the current key anyway. The full db lock
+// is taken so that the check and the write behave as a single atomic operation
+func (dbInst *DB) reEncryptValue(key []byte, observedValue []byte) (bool, error) {
+	dbInst.mutex.Lock()
+	defer dbInst.mutex.Unlock()
+	currentValue, err := dbInst.db.Get(key, dbInst.readOpts)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "error retrieving leveldb key [%#v]", key)
+	}
+	if !bytes.Equal(currentValue, observedValue) {
+		return false, nil
+	}
+	plaintext, err := dbInst.encryptor.decrypt(currentValue)
+	if err != nil {
+		return false, errors.WithMessagef(err, "error decrypting the value of leveldb key [%#v]", key)
+	}
+	encrypted, err := dbInst.encryptor.encrypt(plaintext)
+	if err != nil {
+		return false, errors.WithMessagef(err, "error encrypting the value of leveldb key [%#v]", key)
+	}
+	if err := dbInst.db.Put(key, encrypted, dbInst.writeOptsNoSync); err != nil {
+		return false, errors.Wrapf(err, "error writing leveldb key [%#v]", key)
+	}
+	return true, nil
+}
+
 // FileLock encapsulate the DB that holds the file lock.
 // As the FileLock to be used by a single process/goroutine,
 // there is no need for the semaphore to synchronize the
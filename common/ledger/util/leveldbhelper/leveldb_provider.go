@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
@@ -33,9 +34,13 @@ var (
 // either the db is empty (i.e., opening for the first time) or the value
 // of the formatVersionKey is equal to `ExpectedFormatVersion`. Otherwise, an error is returned.
 // A nil value for ExpectedFormatVersion indicates that the format is never set and hence there is no such record
+//
+// `Encryptor`, when set, transparently encrypts the values at rest. A db that was
+// created without an encryptor cannot be opened with one and vice versa
 type Conf struct {
 	DBPath                string
 	ExpectedFormatVersion string
+	Encryptor             *ValueEncryptor
 }
 
 // Provider enables to use a single leveldb as multiple logical leveldbs
@@ -52,10 +57,44 @@ func NewProvider(conf *Conf) (*Provider, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Provider{
+	p := &Provider{
 		db:        db,
 		dbHandles: make(map[string]*DBHandle),
-	}, nil
+	}
+	if conf.Encryptor != nil && conf.Encryptor.hasRetiredKeys() {
+		go p.reEncryptStaleValues()
+	}
+	return p, nil
+}
+
+// reEncryptStaleValues makes a pass over the db and rewrites with the current
+// encryption key every value that is still sealed with a retired key of the ring.
+// It runs in the background after a key rotation while the db is in regular use
+func (p *Provider) reEncryptStaleValues() {
+	logger.Infof("Starting background re-encryption of the db at [%s] with the rotated key", p.db.conf.DBPath)
+	itr := p.db.GetIterator(nil, nil)
+	defer itr.Release()
+	reEncrypted := 0
+	for itr.Next() {
+		if !p.db.encryptor.encryptedWithRetiredKey(itr.Value()) {
+			continue
+		}
+		key := append([]byte{}, itr.Key()...)
+		value := append([]byte{}, itr.Value()...)
+		written, err := p.db.reEncryptValue(key, value)
+		if err != nil {
+			logger.Errorf("Stopping the background re-encryption of the db at [%s]: %s", p.db.conf.DBPath, err)
+			return
+		}
+		if written {
+			reEncrypted++
+		}
+	}
+	if err := itr.Error(); err != nil {
+		logger.Errorf("Error while iterating over the db at [%s] for re-encryption: %s", p.db.conf.DBPath, err)
+		return
+	}
+	logger.Infof("Background re-encryption of the db at [%s] finished, re-encrypted [%d] values", p.db.conf.DBPath, reEncrypted)
 }
 
 func openDBAndCheckFormat(conf *Conf) (d *DB, e error) {
@@ -159,9 +198,15 @@ func (h *DBHandle) WriteBatch(batch *UpdateBatch, sync bool) error {
 		key := constructLevelKey(h.dbName, []byte(k))
 		if v == nil {
 			levelBatch.Delete(key)
-		} else {
-			levelBatch.Put(key, v)
+			continue
+		}
+		if h.db.encryptor != nil {
+			var err error
+			if v, err = h.db.encryptor.encrypt(v); err != nil {
+				return errors.WithMessagef(err, "error encrypting the value of leveldb key [%#v]", key)
+			}
 		}
+		levelBatch.Put(key, v)
 	}
 	if err := h.db.WriteBatch(levelBatch, sync); err != nil {
 		return err
@@ -180,7 +225,7 @@ func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *Iterator {
 		eKey[len(eKey)-1] = lastKeyIndicator
 	}
 	logger.Debugf("Getting iterator for range [%#v] - [%#v]", sKey, eKey)
-	return &Iterator{h.db.GetIterator(sKey, eKey)}
+	return &Iterator{h.db.GetIterator(sKey, eKey), h.db.encryptor}
 }
 
 // UpdateBatch encloses the details of multiple `updates`
@@ -214,6 +259,7 @@ func (batch *UpdateBatch) Len() int {
 // Iterator extends actual leveldb iterator
 type Iterator struct {
 	iterator.Iterator
+	encryptor *ValueEncryptor
 }
 
 // Key wraps actual leveldb iterator method
@@ -221,6 +267,22 @@ func (itr *Iterator) Key() []byte {
 	return retrieveAppKey(itr.Iterator.Key())
 }
 
+// Value wraps actual leveldb iterator method and decrypts the value when the
+// db is encrypted. A decryption failure is logged and surfaced as a nil value -
+// it cannot legitimately happen on a db that was opened with the right key ring
+func (itr *Iterator) Value() []byte {
+	value := itr.Iterator.Value()
+	if itr.encryptor == nil || value == nil {
+		return value
+	}
+	value, err := itr.encryptor.decrypt(value)
+	if err != nil {
+		logger.Errorf("Error decrypting the value of leveldb key [%#v]: %s", itr.Key(), err)
+		return nil
+	}
+	return value
+}
+
 func constructLevelKey(dbName string, key []byte) []byte {
 	return append(append([]byte(dbName), dbNameKeySep...), key...)
 }
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package merkle provides a binary SHA256 merkle tree over a list of leaves
+// along with generation and verification of inclusion proofs. It is used for
+// producing verifiable inclusion proofs for the transactions of a block
+package merkle
+
+import (
+	"bytes"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/pkg/errors"
+)
+
+// ProofNode is one step of an inclusion proof - the hash of the sibling at a
+// level of the tree and whether the sibling is the left operand of the
+// concatenation
+type ProofNode struct {
+	Hash []byte
+	Left bool
+}
+
+// ComputeRoot computes the merkle root over the given leaves. Each level is
+// built by hashing the concatenation of sibling pairs; a node without a
+// sibling is promoted to the next level unchanged. An empty list of leaves
+// yields a nil root
+func ComputeRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+	level := leaves
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// GenerateProof produces the inclusion proof for the leaf at the given index.
+// The proof, together with the leaf, reproduces the root via VerifyProof
+func GenerateProof(leaves [][]byte, index int) ([]*ProofNode, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, errors.Errorf("leaf index [%d] is out of range for [%d] leaves", index, len(leaves))
+	}
+	var proof []*ProofNode
+	level := leaves
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, &ProofNode{
+				Hash: level[sibling],
+				Left: sibling < index,
+			})
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyProof returns true if the given proof reproduces the given root from
+// the given leaf
+func VerifyProof(leaf []byte, proof []*ProofNode, root []byte) bool {
+	hash := leaf
+	for _, node := range proof {
+		if node.Left {
+			hash = util.ComputeSHA256(append(append([]byte{}, node.Hash...), hash...))
+		} else {
+			hash = util.ComputeSHA256(append(append([]byte{}, hash...), node.Hash...))
+		}
+	}
+	return bytes.Equal(hash, root)
+}
+
+func nextLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			// a node without a sibling is promoted unchanged
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, util.ComputeSHA256(append(append([]byte{}, level[i]...), level[i+1]...)))
+	}
+	return next
+}
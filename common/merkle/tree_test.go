@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeRoot(t *testing.T) {
+	assert.Nil(t, ComputeRoot(nil))
+
+	leaf := util.ComputeSHA256([]byte("leaf-0"))
+	assert.Equal(t, leaf, ComputeRoot([][]byte{leaf}))
+
+	leaves := testLeaves(2)
+	expectedRoot := util.ComputeSHA256(append(append([]byte{}, leaves[0]...), leaves[1]...))
+	assert.Equal(t, expectedRoot, ComputeRoot(leaves))
+}
+
+func TestGenerateAndVerifyProof(t *testing.T) {
+	// exercise trees of different shapes, including ones with unpaired nodes
+	for _, numLeaves := range []int{1, 2, 3, 4, 5, 8, 13} {
+		t.Run(fmt.Sprintf("%d-leaves", numLeaves), func(t *testing.T) {
+			leaves := testLeaves(numLeaves)
+			root := ComputeRoot(leaves)
+			for i := 0; i < numLeaves; i++ {
+				proof, err := GenerateProof(leaves, i)
+				assert.NoError(t, err)
+				assert.True(t, VerifyProof(leaves[i], proof, root))
+				// the proof does not verify a different leaf
+				otherLeaf := util.ComputeSHA256([]byte("other-leaf"))
+				assert.False(t, VerifyProof(otherLeaf, proof, root))
+			}
+		})
+	}
+}
+
+func TestGenerateProofOutOfRange(t *testing.T) {
+	leaves := testLeaves(3)
+	_, err := GenerateProof(leaves, 3)
+	assert.EqualError(t, err, "leaf index [3] is out of range for [3] leaves")
+	_, err = GenerateProof(leaves, -1)
+	assert.EqualError(t, err, "leaf index [-1] is out of range for [3] leaves")
+}
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		leaves[i] = util.ComputeSHA256([]byte(fmt.Sprintf("leaf-%d", i)))
+	}
+	return leaves
+}
@@ -0,0 +1,264 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policydsl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// FromExpression parses a signature policy expressed in a CEL-like boolean
+// expression grammar and compiles it to a SignaturePolicyEnvelope. The
+// grammar accepts double or single quoted principals of the form "ORG.ROLE"
+// combined with &&, || and parentheses:
+//
+// "Org1MSP.member" && ("Org2MSP.member" || "Org3MSP.member")
+//
+// It produces the same encoding as the equivalent And/Or policy handed to
+// FromString, and exists for integrations that prefer a standard expression
+// grammar over the bespoke gate DSL.
+func FromExpression(expr string) (*cb.SignaturePolicyEnvelope, error) {
+	return fromExpression(expr, false)
+}
+
+// FromExpressionWithCapability behaves as FromExpression, but additionally
+// supports negation with the ! operator when extendedGatesSupported is true.
+// The capability constraints of FromStringWithCapability apply: negation is
+// encoded with the sentinel thresholds described on NotGateN.
+func FromExpressionWithCapability(expr string, extendedGatesSupported bool) (*cb.SignaturePolicyEnvelope, error) {
+	return fromExpression(expr, extendedGatesSupported)
+}
+
+func fromExpression(expr string, extendedGatesAllowed bool) (*cb.SignaturePolicyEnvelope, error) {
+	if perr := checkParentheses(expr); perr != nil {
+		return nil, perr
+	}
+
+	p := &exprParser{input: expr, ctx: newContext()}
+	p.ctx.extendedGatesAllowed = extendedGatesAllowed
+
+	rule, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, p.errorAt(p.pos, fmt.Sprintf("unexpected token '%s' in policy expression", p.remainderToken()))
+	}
+
+	return &cb.SignaturePolicyEnvelope{
+		Identities: p.ctx.principals,
+		Version:    0,
+		Rule:       rule,
+	}, nil
+}
+
+// exprParser is a recursive descent parser over the boolean expression
+// grammar accepted by FromExpression.
+type exprParser struct {
+	input string
+	pos   int
+	ctx   *context
+}
+
+// parseOr parses a chain of one or more && expressions joined by ||.
+func (p *exprParser) parseOr() (*cb.SignaturePolicy, error) {
+	rules := []*cb.SignaturePolicy{}
+
+	for {
+		rule, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+
+		if !p.consume("||") {
+			break
+		}
+	}
+
+	if len(rules) == 1 {
+		return rules[0], nil
+	}
+
+	return NOutOf(1, rules), nil
+}
+
+// parseAnd parses a chain of one or more unary expressions joined by &&.
+func (p *exprParser) parseAnd() (*cb.SignaturePolicy, error) {
+	rules := []*cb.SignaturePolicy{}
+
+	for {
+		rule, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+
+		if !p.consume("&&") {
+			break
+		}
+	}
+
+	if len(rules) == 1 {
+		return rules[0], nil
+	}
+
+	return NOutOf(int32(len(rules)), rules), nil
+}
+
+// parseUnary parses an optionally negated primary expression.
+func (p *exprParser) parseUnary() (*cb.SignaturePolicy, error) {
+	if start := p.pos; p.consume("!") {
+		if !p.ctx.extendedGatesAllowed {
+			return nil, p.errorAt(start, "! operator is not supported without the required channel capability")
+		}
+
+		rule, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return Not(rule), nil
+	}
+
+	return p.parsePrimary()
+}
+
+// parsePrimary parses a parenthesized expression or a quoted principal.
+func (p *exprParser) parsePrimary() (*cb.SignaturePolicy, error) {
+	if start := p.pos; p.consume("(") {
+		rule, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.consume(")") {
+			return nil, p.errorAt(start, "unclosed '(' in policy expression")
+		}
+
+		return rule, nil
+	}
+
+	return p.parsePrincipal()
+}
+
+// parsePrincipal parses a quoted "ORG.ROLE" principal, registers it in the
+// parse context, and returns a policy requiring its signature.
+func (p *exprParser) parsePrincipal() (*cb.SignaturePolicy, error) {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return nil, p.errorAt(p.pos, "unexpected end of policy expression, expected a principal")
+	}
+
+	quote := p.input[p.pos]
+	if quote != '"' && quote != '\'' {
+		return nil, p.errorAt(p.pos, fmt.Sprintf("unexpected token '%s' in policy expression, expected a quoted principal", p.remainderToken()))
+	}
+
+	end := strings.IndexByte(p.input[p.pos+1:], quote)
+	if end == -1 {
+		return nil, p.errorAt(p.pos, "unterminated principal in policy expression")
+	}
+
+	token := p.input[p.pos+1 : p.pos+1+end]
+	start := p.pos
+	p.pos += end + 2
+
+	principal, err := principalFromString(token)
+	if err != nil {
+		perr := p.errorAt(start+1, fmt.Sprintf("unrecognized principal '%s' in policy expression", token))
+		perr.Token = token
+		perr.Suggestion = suggestPrincipal(token)
+		return nil, perr
+	}
+
+	p.ctx.principals = append(p.ctx.principals, principal)
+	rule := SignedBy(int32(p.ctx.IDNum))
+	p.ctx.IDNum++
+
+	return rule, nil
+}
+
+// principalFromString converts an "ORG.ROLE" token into an MSPPrincipal.
+func principalFromString(token string) (*mb.MSPPrincipal, error) {
+	subm := regex.FindAllStringSubmatch(token, -1)
+	if subm == nil || len(subm) != 1 || len(subm[0]) != 4 {
+		return nil, fmt.Errorf("error parsing principal %s", token)
+	}
+
+	var r mb.MSPRole_MSPRoleType
+	switch subm[0][3] {
+	case RoleMember:
+		r = mb.MSPRole_MEMBER
+	case RoleAdmin:
+		r = mb.MSPRole_ADMIN
+	case RoleClient:
+		r = mb.MSPRole_CLIENT
+	case RolePeer:
+		r = mb.MSPRole_PEER
+	case RoleOrderer:
+		r = mb.MSPRole_ORDERER
+	default:
+		return nil, fmt.Errorf("error parsing role %s", token)
+	}
+
+	mspRole, err := proto.Marshal(&mb.MSPRole{MspIdentifier: subm[0][1], Role: r})
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling msp role: %s", err)
+	}
+
+	return &mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ROLE,
+		Principal:               mspRole,
+	}, nil
+}
+
+// consume advances past the given token when it is next in the input,
+// ignoring leading whitespace.
+func (p *exprParser) consume(token string) bool {
+	p.skipSpace()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+
+	return false
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n' || p.input[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+// remainderToken returns the run of input at the current position up to the
+// next whitespace, for use in error messages.
+func (p *exprParser) remainderToken() string {
+	rest := p.input[p.pos:]
+	if idx := strings.IndexAny(rest, " \t\n\r"); idx != -1 {
+		rest = rest[:idx]
+	}
+
+	return rest
+}
+
+// errorAt builds a ParseError pointing at the given byte offset.
+func (p *exprParser) errorAt(offset int, message string) *ParseError {
+	err := &ParseError{Message: message}
+	if offset > len(p.input) {
+		offset = len(p.input)
+	}
+	err.Line, err.Column = positionOfOffset(p.input, offset)
+
+	return err
+}
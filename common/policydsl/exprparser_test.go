@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policydsl
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/stretchr/testify/assert"
+)
+
+func rolePrincipal(t *testing.T, mspID string, role mb.MSPRole_MSPRoleType) *mb.MSPPrincipal {
+	mspRole, err := proto.Marshal(&mb.MSPRole{MspIdentifier: mspID, Role: role})
+	assert.NoError(t, err)
+
+	return &mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ROLE,
+		Principal:               mspRole,
+	}
+}
+
+func TestExpressionAnd(t *testing.T) {
+	p1, err := FromExpression("'A.member' && 'B.member'")
+	assert.NoError(t, err)
+
+	p2, err := FromString("AND('A.member', 'B.member')")
+	assert.NoError(t, err)
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionOr(t *testing.T) {
+	p1, err := FromExpression("'A.member' || 'B.member'")
+	assert.NoError(t, err)
+
+	p2, err := FromString("OR('A.member', 'B.member')")
+	assert.NoError(t, err)
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionComplex(t *testing.T) {
+	p1, err := FromExpression("'A.member' && ('B.member' || 'C.admin')")
+	assert.NoError(t, err)
+
+	// identities are numbered in lexical order, unlike FromString which
+	// numbers inner gates first
+	p2 := &cb.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule:    And(SignedBy(0), Or(SignedBy(1), SignedBy(2))),
+		Identities: []*mb.MSPPrincipal{
+			rolePrincipal(t, "A", mb.MSPRole_MEMBER),
+			rolePrincipal(t, "B", mb.MSPRole_MEMBER),
+			rolePrincipal(t, "C", mb.MSPRole_ADMIN),
+		},
+	}
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionDoubleQuotes(t *testing.T) {
+	p1, err := FromExpression(`"A.member" && "B.member"`)
+	assert.NoError(t, err)
+
+	p2, err := FromString("AND('A.member', 'B.member')")
+	assert.NoError(t, err)
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionPrecedence(t *testing.T) {
+	// && binds tighter than ||
+	p1, err := FromExpression("'A.member' || 'B.member' && 'C.member'")
+	assert.NoError(t, err)
+
+	p2 := &cb.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule:    Or(SignedBy(0), And(SignedBy(1), SignedBy(2))),
+		Identities: []*mb.MSPPrincipal{
+			rolePrincipal(t, "A", mb.MSPRole_MEMBER),
+			rolePrincipal(t, "B", mb.MSPRole_MEMBER),
+			rolePrincipal(t, "C", mb.MSPRole_MEMBER),
+		},
+	}
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionLongChain(t *testing.T) {
+	p1, err := FromExpression("'A.member' && 'B.member' && 'C.member'")
+	assert.NoError(t, err)
+
+	p2, err := FromString("AND('A.member', 'B.member', 'C.member')")
+	assert.NoError(t, err)
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionNot(t *testing.T) {
+	p1, err := FromExpressionWithCapability("!'A.member'", true)
+	assert.NoError(t, err)
+
+	p2, err := FromStringWithCapability("NOT('A.member')", true)
+	assert.NoError(t, err)
+
+	assert.True(t, proto.Equal(p1, p2))
+}
+
+func TestExpressionNotRequiresCapability(t *testing.T) {
+	p, err := FromExpression("!'A.member'")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "! operator is not supported without the required channel capability at line 1, col 1")
+}
+
+func TestExpressionBadPrincipal(t *testing.T) {
+	p, err := FromExpression("'A.member' && 'B.membr'")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "unrecognized principal 'B.membr' in policy expression at line 1, col 16: did you mean 'B.member'?")
+
+	perr, ok := err.(*ParseError)
+	assert.True(t, ok)
+	assert.Equal(t, "B.membr", perr.Token)
+	assert.Equal(t, "B.member", perr.Suggestion)
+}
+
+func TestExpressionUnterminatedPrincipal(t *testing.T) {
+	p, err := FromExpression("'A.member' && 'B.member")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "unterminated principal in policy expression at line 1, col 15")
+}
+
+func TestExpressionTrailingGarbage(t *testing.T) {
+	p, err := FromExpression("'A.member' 'B.member'")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "unexpected token ''B.member'' in policy expression at line 1, col 12")
+}
+
+func TestExpressionMissingOperand(t *testing.T) {
+	p, err := FromExpression("'A.member' &&")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "unexpected end of policy expression, expected a principal at line 1, col 14")
+}
+
+func TestExpressionUnbalancedParentheses(t *testing.T) {
+	p, err := FromExpression("('A.member' && 'B.member'")
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "unbalanced parentheses in policy string, unclosed '(' at line 1, col 1")
+}
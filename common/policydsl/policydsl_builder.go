@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package policydsl
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/golang/protobuf/proto"
@@ -111,7 +112,7 @@ func SignedByMspAdmin(mspId string) *cb.SignaturePolicyEnvelope {
 	return p
 }
 
-//wrapper for generating "any of a given role" type policies
+// wrapper for generating "any of a given role" type policies
 func signedByAnyOfGivenRole(role mb.MSPRole_MSPRoleType, ids []string) *cb.SignaturePolicyEnvelope {
 	return SignedByNOutOfGivenRole(1, role, ids)
 }
@@ -178,6 +179,73 @@ func Or(lhs, rhs *cb.SignaturePolicy) *cb.SignaturePolicy {
 	return NOutOf(1, []*cb.SignaturePolicy{lhs, rhs})
 }
 
+// NotGateN is the sentinel NOutOf threshold used to encode a NOT gate. An
+// NOutOf gate carrying this threshold and exactly one rule evaluates to true
+// iff the wrapped rule evaluates to false. Thresholds below NotGateN encode
+// weight markers: a gate with threshold NotGateN-w and exactly one rule
+// contributes w, rather than 1, towards the threshold of its enclosing
+// NOutOf gate when the wrapped rule is satisfied.
+//
+// Since the SignaturePolicy message has no native NOT or weight types,
+// evaluators without explicit support for the sentinels treat these gates as
+// always satisfied; policies using them must therefore only be installed on
+// channels whose capabilities guarantee all peers understand the encoding.
+const NotGateN int32 = -1
+
+// Not creates a policy which evaluates to true iff the given policy
+// evaluates to false. See NotGateN for the compatibility constraints of the
+// resulting encoding.
+func Not(policy *cb.SignaturePolicy) *cb.SignaturePolicy {
+	return NOutOf(NotGateN, []*cb.SignaturePolicy{policy})
+}
+
+// Weighted attaches a weight to a policy for use inside an NOutOf gate: when
+// the wrapped policy is satisfied it contributes weight, rather than 1,
+// towards the gate's threshold. See NotGateN for the compatibility
+// constraints of the resulting encoding.
+func Weighted(weight int32, policy *cb.SignaturePolicy) (*cb.SignaturePolicy, error) {
+	if weight < 1 {
+		return nil, fmt.Errorf("invalid weight %d, weights must be positive", weight)
+	}
+
+	if weight == 1 {
+		return policy, nil
+	}
+
+	return NOutOf(NotGateN-weight, []*cb.SignaturePolicy{policy}), nil
+}
+
+// WeightOf returns the weight attached to a policy by Weighted together with
+// the wrapped rule. Policies without a weight marker carry weight 1.
+func WeightOf(policy *cb.SignaturePolicy) (int32, *cb.SignaturePolicy) {
+	nOutOf := policy.GetNOutOf()
+	if nOutOf == nil || nOutOf.N >= NotGateN || len(nOutOf.Rules) != 1 {
+		return 1, policy
+	}
+
+	return NotGateN - nOutOf.N, nOutOf.Rules[0]
+}
+
+// WeightedOutOf creates a policy which requires the weights of the satisfied
+// policies to sum to at least n. See NotGateN for the compatibility
+// constraints of the resulting encoding.
+func WeightedOutOf(n int32, policies []*cb.SignaturePolicy, weights []int32) (*cb.SignaturePolicy, error) {
+	if len(policies) != len(weights) {
+		return nil, fmt.Errorf("expected one weight per policy, got %d policies and %d weights", len(policies), len(weights))
+	}
+
+	weighted := make([]*cb.SignaturePolicy, len(policies))
+	for i, policy := range policies {
+		var err error
+		weighted[i], err = Weighted(weights[i], policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NOutOf(n, weighted), nil
+}
+
 // NOutOf creates a policy which requires N out of the slice of policies to evaluate to true
 func NOutOf(n int32, policies []*cb.SignaturePolicy) *cb.SignaturePolicy {
 	return &cb.SignaturePolicy{
@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policydsl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError describes a problem found while parsing a policy string. It
+// carries the position of the offending token (1-based, zero when the
+// position could not be determined) and, when a likely fix can be inferred,
+// a suggestion.
+type ParseError struct {
+	Line       int
+	Column     int
+	Token      string
+	Message    string
+	Suggestion string
+}
+
+func (e *ParseError) Error() string {
+	msg := e.Message
+	if e.Line > 0 {
+		msg = fmt.Sprintf("%s at line %d, col %d", msg, e.Line, e.Column)
+	}
+	if e.Suggestion != "" {
+		msg = fmt.Sprintf("%s: did you mean '%s'?", msg, e.Suggestion)
+	}
+
+	return msg
+}
+
+var roles = []string{RoleAdmin, RoleMember, RoleClient, RolePeer, RoleOrderer}
+
+var gates = []string{GateAnd, GateOr, GateOutOf, GateNot, GateWeightedOutOf}
+
+// newParseError creates a ParseError for token, locating the token's first
+// occurrence in the policy string.
+func newParseError(policy, token, message string) *ParseError {
+	err := &ParseError{Token: token, Message: message}
+	if idx := strings.Index(policy, token); idx != -1 && token != "" {
+		err.Line, err.Column = positionOfOffset(policy, idx)
+	}
+
+	return err
+}
+
+// positionOfOffset converts a byte offset into 1-based line and column numbers.
+func positionOfOffset(policy string, offset int) (int, int) {
+	line, col := 1, 1
+	for _, r := range policy[:offset] {
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+
+	return line, col
+}
+
+// unrecognizedTokenError builds the error returned when a token in the policy
+// string is not a well-formed principal, suggesting one when the token is
+// close to being well formed.
+func unrecognizedTokenError(policy, token string) *ParseError {
+	err := newParseError(policy, token, fmt.Sprintf("unrecognized token '%s' in policy string", token))
+	err.Suggestion = suggestPrincipal(token)
+
+	return err
+}
+
+// unknownGateError builds the error returned when the policy string calls a
+// gate this package does not define, suggesting the closest known gate.
+func unknownGateError(policy, token string) *ParseError {
+	err := newParseError(policy, token, fmt.Sprintf("unknown gate '%s' in policy string", token))
+	err.Suggestion = suggestGate(token)
+
+	return err
+}
+
+// suggestPrincipal proposes a well-formed principal for a malformed one: a
+// missing role separator or role suffix yields '<org>.member', a misspelled
+// role yields the closest known role.
+func suggestPrincipal(token string) string {
+	dot := strings.LastIndex(token, ".")
+	if dot <= 0 || dot == len(token)-1 {
+		// the role may have been concatenated without the '.' separator
+		for _, role := range roles {
+			if len(token) > len(role) && strings.HasSuffix(strings.ToLower(token), role) {
+				return token[:len(token)-len(role)] + "." + role
+			}
+		}
+
+		return strings.Trim(token, ".") + "." + RoleMember
+	}
+
+	org, role := token[:dot], strings.ToLower(token[dot+1:])
+	closest := closestMatch(role, roles)
+	if closest == "" {
+		closest = RoleMember
+	}
+
+	return org + "." + closest
+}
+
+// suggestGate proposes the closest known gate for an unknown one, or the
+// empty string when nothing is close enough.
+func suggestGate(token string) string {
+	lowered := make([]string, len(gates))
+	for i, gate := range gates {
+		lowered[i] = strings.ToLower(gate)
+	}
+
+	closest := closestMatch(strings.ToLower(token), lowered)
+	for i, gate := range lowered {
+		if gate == closest {
+			return gates[i]
+		}
+	}
+
+	return ""
+}
+
+// closestMatch returns the candidate with the smallest edit distance to
+// token, or the empty string when no candidate is within half its length.
+func closestMatch(token string, candidates []string) string {
+	closest, distance := "", -1
+	for _, candidate := range candidates {
+		if d := levenshtein(token, candidate); distance == -1 || d < distance {
+			closest, distance = candidate, d
+		}
+	}
+
+	if closest == "" || distance > len(closest)/2 {
+		return ""
+	}
+
+	return closest
+}
+
+// levenshtein computes the edit distance between two strings.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := 0; j <= len(b); j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(min(curr[j-1]+1, prev[j]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// checkParentheses reports the position of the first unbalanced parenthesis
+// in the policy string, ignoring parentheses inside quoted principals.
+func checkParentheses(policy string) *ParseError {
+	var open []int
+	inQuote := false
+
+	for i, r := range policy {
+		switch r {
+		case '\'':
+			inQuote = !inQuote
+		case '(':
+			if !inQuote {
+				open = append(open, i)
+			}
+		case ')':
+			if inQuote {
+				continue
+			}
+			if len(open) == 0 {
+				err := &ParseError{Token: ")", Message: "unbalanced parentheses in policy string, unmatched ')'"}
+				err.Line, err.Column = positionOfOffset(policy, i)
+				return err
+			}
+			open = open[:len(open)-1]
+		}
+	}
+
+	if len(open) > 0 {
+		err := &ParseError{Token: "(", Message: "unbalanced parentheses in policy string, unclosed '('"}
+		err.Line, err.Column = positionOfOffset(policy, open[len(open)-1])
+		return err
+	}
+
+	return nil
+}
@@ -21,11 +21,17 @@ import (
 
 // Gate values
 const (
-	GateAnd   = "And"
-	GateOr    = "Or"
-	GateOutOf = "OutOf"
+	GateAnd           = "And"
+	GateOr            = "Or"
+	GateOutOf         = "OutOf"
+	GateNot           = "Not"
+	GateWeightedOutOf = "WeightedOutOf"
 )
 
+// AttrFunction is the name of the DSL function that requires a Fabric CA
+// certificate attribute of the signer
+const AttrFunction = "Attr"
+
 // Role values for principals
 const (
 	RoleAdmin   = "admin"
@@ -40,7 +46,8 @@ var (
 		fmt.Sprintf("^([[:alnum:].-]+)([.])(%s|%s|%s|%s|%s)$",
 			RoleAdmin, RoleMember, RoleClient, RolePeer, RoleOrderer),
 	)
-	regexErr = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
+	regexErr     = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
+	regexGateErr = regexp.MustCompile("^Undefined function (.+)$")
 )
 
 // a stub function - it returns the same string as it's passed.
@@ -82,6 +89,117 @@ func outof(args ...interface{}) (interface{}, error) {
 	return toret + ")", nil
 }
 
+// not rewrites a Not gate into an outof call with the NotGateN sentinel
+// threshold, to be recognized by the second pass.
+func not(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("expected exactly one argument to Not. Given %d", len(args))
+	}
+
+	return outof(append([]interface{}{int(NotGateN)}, args...)...)
+}
+
+// weightedOutOf rewrites a WeightedOutOf gate, consisting of a threshold
+// followed by weight and policy pairs, into an outof call in which each
+// policy of weight greater than one is wrapped in a weight marker gate, to
+// be recognized by the second pass.
+func weightedOutOf(args ...interface{}) (interface{}, error) {
+	if len(args) < 3 || len(args)%2 != 1 {
+		return nil, fmt.Errorf("expected a threshold followed by weight and policy pairs as arguments to WeightedOutOf. Given %d arguments", len(args))
+	}
+
+	expanded := []interface{}{args[0]}
+
+	for i := 1; i < len(args); i += 2 {
+		var w int
+		switch weight := args[i].(type) {
+		case float64:
+			w = int(weight)
+		case int:
+			w = weight
+		default:
+			return nil, fmt.Errorf("unexpected weight type %s", reflect.TypeOf(args[i]))
+		}
+
+		if w < 1 {
+			return nil, fmt.Errorf("invalid weight %d, weights must be positive", w)
+		}
+
+		if w == 1 {
+			expanded = append(expanded, args[i+1])
+			continue
+		}
+
+		marked, err := outof(append([]interface{}{int(NotGateN) - w}, args[i+1])...)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, marked)
+	}
+
+	return outof(expanded...)
+}
+
+// attrMarker validates the arguments of an attribute principal and emits
+// the canonical attr call for the later passes
+func attrMarker(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("expected two or three arguments to %s. Given %d", AttrFunction, len(args))
+	}
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type %s", reflect.TypeOf(arg))
+		}
+		if strings.Contains(s, "'") {
+			return nil, fmt.Errorf("invalid character ' in attribute argument %s", s)
+		}
+		quoted[i] = "'" + s + "'"
+	}
+
+	return "attr(" + strings.Join(quoted, ", ") + ")", nil
+}
+
+// attrDisallowed replaces attrMarker when the caller has not asserted the
+// channel capability that understands attribute principals
+func attrDisallowed(args ...interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("%s principals are not supported without the required channel capability", AttrFunction)
+}
+
+// attrPrincipal builds the attribute principal in the final pass
+func attrPrincipal(args ...interface{}) (interface{}, error) {
+	if _, err := attrMarker(args...); err != nil {
+		return nil, err
+	}
+
+	attribute := &mb.MSPAttribute{}
+	switch len(args) {
+	case 2:
+		attribute.AttributeName = args[0].(string)
+		attribute.AttributeValue = args[1].(string)
+	case 3:
+		attribute.MspIdentifier = args[0].(string)
+		attribute.AttributeName = args[1].(string)
+		attribute.AttributeValue = args[2].(string)
+	}
+
+	if attribute.AttributeName == "" {
+		return nil, fmt.Errorf("the attribute name of an %s principal must not be empty", AttrFunction)
+	}
+
+	raw, err := proto.Marshal(attribute)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling msp attribute: %s", err)
+	}
+
+	return &mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ATTRIBUTE,
+		Principal:               raw,
+	}, nil
+}
+
 func and(args ...interface{}) (interface{}, error) {
 	args = append([]interface{}{len(args)}, args...)
 	return outof(args...)
@@ -143,8 +261,20 @@ func secondPass(args ...interface{}) (interface{}, error) {
 	/* get the n in the t out of n */
 	var n int = len(args) - 2
 
-	/* sanity check - t should be positive, permit equal to n+1, but disallow over n+1 */
-	if t < 0 || t > n+1 {
+	/* negative thresholds are the sentinels of the extended gates (NOT and
+	   weight markers); they wrap a single rule and are only honored when the
+	   caller has asserted the channel capability that understands them */
+	if t < 0 {
+		switch {
+		case ctx.extendedGatesAllowed && n != 1:
+			return nil, fmt.Errorf("expected exactly one policy in an extended gate, got %d", n)
+		case !ctx.extendedGatesAllowed && n == 1:
+			return nil, fmt.Errorf("%s and %s gates are not supported without the required channel capability", GateNot, GateWeightedOutOf)
+		case !ctx.extendedGatesAllowed:
+			return nil, fmt.Errorf("invalid t-out-of-n predicate, t %d, n %d", t, n)
+		}
+	} else if t > n+1 {
+		/* sanity check - t should be positive, permit equal to n+1, but disallow over n+1 */
 		return nil, fmt.Errorf("invalid t-out-of-n predicate, t %d, n %d", t, n)
 	}
 
@@ -205,6 +335,13 @@ func secondPass(args ...interface{}) (interface{}, error) {
 			// TODO: deduplicate principals
 			ctx.IDNum++
 
+		/* attribute principals arrive already built, we only have to
+		   register them with the context */
+		case *mb.MSPPrincipal:
+			ctx.principals = append(ctx.principals, t)
+			policies = append(policies, SignedBy(int32(ctx.IDNum)))
+			ctx.IDNum++
+
 		/* if we've already got a policy we're good, just append it */
 		case *cb.SignaturePolicy:
 			policies = append(policies, t)
@@ -214,12 +351,17 @@ func secondPass(args ...interface{}) (interface{}, error) {
 		}
 	}
 
+	if t == int(NotGateN) {
+		return Not(policies[0]), nil
+	}
+
 	return NOutOf(int32(t), policies), nil
 }
 
 type context struct {
-	IDNum      int
-	principals []*mb.MSPPrincipal
+	IDNum                int
+	principals           []*mb.MSPPrincipal
+	extendedGatesAllowed bool
 }
 
 func newContext() *context {
@@ -233,33 +375,98 @@ func newContext() *context {
 // GATE(P[, P])
 //
 // where:
-//	- GATE is either "and" or "or"
-//	- P is either a principal or another nested call to GATE
+//   - GATE is either "and" or "or"
+//   - P is either a principal or another nested call to GATE
 //
 // A principal is defined as:
 //
-// ORG.ROLE
+// # ORG.ROLE
 //
 // where:
-//	- ORG is a string (representing the MSP identifier)
-//	- ROLE takes the value of any of the RoleXXX constants representing
-//    the required role
+//   - ORG is a string (representing the MSP identifier)
+//   - ROLE takes the value of any of the RoleXXX constants representing
+//     the required role
 func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
+	return fromString(policy, false, false)
+}
+
+// FromStringWithCapability behaves as FromString, but additionally supports
+// the extended gates when extendedGatesSupported is true:
+//
+// Not(P)
+//
+// evaluates to true iff P evaluates to false, and
+//
+// WeightedOutOf(T, W1, P1[, W2, P2])
+//
+// where each policy P contributes its weight W, rather than 1, towards the
+// threshold T when satisfied.
+//
+// Callers must derive extendedGatesSupported from the channel's
+// capabilities: the extended gates use the sentinel thresholds described on
+// NotGateN, which peers without the corresponding evaluator support treat as
+// always-satisfied NOutOf gates.
+func FromStringWithCapability(policy string, extendedGatesSupported bool) (*cb.SignaturePolicyEnvelope, error) {
+	return fromString(policy, extendedGatesSupported, false)
+}
+
+// FromStringWithCapabilities behaves as FromStringWithCapability, but
+// additionally supports attribute principals when
+// attributePrincipalsSupported is true:
+//
+// Attr(NAME, VALUE) or Attr(ORG, NAME, VALUE)
+//
+// is satisfied by the identities whose certificate carries the Fabric CA
+// attribute NAME with the value VALUE (an empty VALUE only requires the
+// attribute to be present), restricted to the members of ORG when given.
+//
+// Callers must derive attributePrincipalsSupported from the channel's
+// capabilities: MSPs below v3.0 reject attribute principals as invalid.
+func FromStringWithCapabilities(policy string, extendedGatesSupported, attributePrincipalsSupported bool) (*cb.SignaturePolicyEnvelope, error) {
+	return fromString(policy, extendedGatesSupported, attributePrincipalsSupported)
+}
+
+func fromString(policy string, extendedGatesAllowed, attributePrincipalsAllowed bool) (*cb.SignaturePolicyEnvelope, error) {
+	// report unbalanced parentheses with their position before govaluate
+	// produces a positionless error for them
+	if perr := checkParentheses(policy); perr != nil {
+		return nil, perr
+	}
+
+	attr := attrMarker
+	if !attributePrincipalsAllowed {
+		attr = attrDisallowed
+	}
+
 	// first we translate the and/or business into outof gates
 	intermediate, err := govaluate.NewEvaluableExpressionWithFunctions(
 		policy, map[string]govaluate.ExpressionFunction{
-			GateAnd:                    and,
-			strings.ToLower(GateAnd):   and,
-			strings.ToUpper(GateAnd):   and,
-			GateOr:                     or,
-			strings.ToLower(GateOr):    or,
-			strings.ToUpper(GateOr):    or,
-			GateOutOf:                  outof,
-			strings.ToLower(GateOutOf): outof,
-			strings.ToUpper(GateOutOf): outof,
+			GateAnd:                            and,
+			strings.ToLower(GateAnd):           and,
+			strings.ToUpper(GateAnd):           and,
+			GateOr:                             or,
+			strings.ToLower(GateOr):            or,
+			strings.ToUpper(GateOr):            or,
+			GateOutOf:                          outof,
+			strings.ToLower(GateOutOf):         outof,
+			strings.ToUpper(GateOutOf):         outof,
+			GateNot:                            not,
+			strings.ToLower(GateNot):           not,
+			strings.ToUpper(GateNot):           not,
+			GateWeightedOutOf:                  weightedOutOf,
+			strings.ToLower(GateWeightedOutOf): weightedOutOf,
+			strings.ToUpper(GateWeightedOutOf): weightedOutOf,
+			AttrFunction:                       attr,
+			strings.ToLower(AttrFunction):      attr,
+			strings.ToUpper(AttrFunction):      attr,
 		},
 	)
 	if err != nil {
+		// attempt to produce a meaningful error
+		if sm := regexGateErr.FindStringSubmatch(err.Error()); len(sm) == 2 {
+			return nil, unknownGateError(policy, sm[1])
+		}
+
 		return nil, err
 	}
 
@@ -269,7 +476,7 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 		if regexErr.MatchString(err.Error()) {
 			sm := regexErr.FindStringSubmatch(err.Error())
 			if len(sm) == 2 {
-				return nil, fmt.Errorf("unrecognized token '%s' in policy string", sm[1])
+				return nil, unrecognizedTokenError(policy, sm[1])
 			}
 		}
 
@@ -289,7 +496,7 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 	// we put the identities that the policy requires
 	exp, err := govaluate.NewEvaluableExpressionWithFunctions(
 		resStr,
-		map[string]govaluate.ExpressionFunction{"outof": firstPass},
+		map[string]govaluate.ExpressionFunction{"outof": firstPass, "attr": attrMarker},
 	)
 	if err != nil {
 		return nil, err
@@ -301,7 +508,7 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 		if regexErr.MatchString(err.Error()) {
 			sm := regexErr.FindStringSubmatch(err.Error())
 			if len(sm) == 2 {
-				return nil, fmt.Errorf("unrecognized token '%s' in policy string", sm[1])
+				return nil, unrecognizedTokenError(policy, sm[1])
 			}
 		}
 
@@ -314,12 +521,13 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 	}
 
 	ctx := newContext()
+	ctx.extendedGatesAllowed = extendedGatesAllowed
 	parameters := make(map[string]interface{}, 1)
 	parameters["ID"] = ctx
 
 	exp, err = govaluate.NewEvaluableExpressionWithFunctions(
 		resStr,
-		map[string]govaluate.ExpressionFunction{"outof": secondPass},
+		map[string]govaluate.ExpressionFunction{"outof": secondPass, "attr": attrPrincipal},
 	)
 	if err != nil {
 		return nil, err
@@ -331,7 +539,7 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 		if regexErr.MatchString(err.Error()) {
 			sm := regexErr.FindStringSubmatch(err.Error())
 			if len(sm) == 2 {
-				return nil, fmt.Errorf("unrecognized token '%s' in policy string", sm[1])
+				return nil, unrecognizedTokenError(policy, sm[1])
 			}
 		}
 
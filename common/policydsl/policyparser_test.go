@@ -219,13 +219,13 @@ func TestMSPIDWIthSpecialChars(t *testing.T) {
 
 func TestBadStringsNoPanic(t *testing.T) {
 	_, err := FromString("OR('A.member', Bmember)") // error after 1st Evaluate()
-	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string")
+	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string at line 1, col 16: did you mean 'B.member'?")
 
 	_, err = FromString("OR('A.member', 'Bmember')") // error after 2nd Evalute()
-	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string")
+	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string at line 1, col 17: did you mean 'B.member'?")
 
 	_, err = FromString(`OR('A.member', '\'Bmember\'')`) // error after 3rd Evalute()
-	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string")
+	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string at line 1, col 19: did you mean 'B.member'?")
 }
 
 func TestNodeOUs(t *testing.T) {
@@ -379,3 +379,221 @@ func TestSecondPassBoundaryCheck(t *testing.T) {
 	assert.Nil(t, p3)
 	assert.EqualError(t, err3, "invalid t-out-of-n predicate, t 4, n 2")
 }
+
+func TestNotGate(t *testing.T) {
+	p1, err := FromStringWithCapability("Not('A.member')", true)
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       Not(SignedBy(0)),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestNotGateNested(t *testing.T) {
+	p1, err := FromStringWithCapability("And(Not('A.member'), 'B.member')", true)
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "B"})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       NOutOf(2, []*common.SignaturePolicy{Not(SignedBy(0)), SignedBy(1)}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestNotGateRequiresCapability(t *testing.T) {
+	p1, err := FromString("Not('A.member')")
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "Not and WeightedOutOf gates are not supported without the required channel capability")
+
+	p1, err = FromStringWithCapability("Not('A.member')", false)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "Not and WeightedOutOf gates are not supported without the required channel capability")
+}
+
+func TestWeightedOutOf(t *testing.T) {
+	p1, err := FromStringWithCapability("WeightedOutOf(2, 2, 'A.member', 1, 'B.member')", true)
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "B"})})
+
+	weightedA, err := Weighted(2, SignedBy(0))
+	assert.NoError(t, err)
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       NOutOf(2, []*common.SignaturePolicy{weightedA, SignedBy(1)}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestWeightedOutOfBadWeight(t *testing.T) {
+	p1, err := FromStringWithCapability("WeightedOutOf(2, 0, 'A.member', 1, 'B.member')", true)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "invalid weight 0, weights must be positive")
+}
+
+func TestWeightedOutOfMissingPair(t *testing.T) {
+	p1, err := FromStringWithCapability("WeightedOutOf(2, 2, 'A.member', 1)", true)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "expected a threshold followed by weight and policy pairs as arguments to WeightedOutOf. Given 4 arguments")
+}
+
+func TestWeightOf(t *testing.T) {
+	weighted, err := Weighted(3, SignedBy(0))
+	assert.NoError(t, err)
+
+	w, rule := WeightOf(weighted)
+	assert.Equal(t, int32(3), w)
+	assert.Equal(t, SignedBy(0), rule)
+
+	w, rule = WeightOf(SignedBy(1))
+	assert.Equal(t, int32(1), w)
+	assert.Equal(t, SignedBy(1), rule)
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := FromString("OR('A.member',\n    'Bmember')")
+	assert.EqualError(t, err, "unrecognized token 'Bmember' in policy string at line 2, col 6: did you mean 'B.member'?")
+
+	parseErr, ok := err.(*ParseError)
+	assert.True(t, ok)
+	assert.Equal(t, 2, parseErr.Line)
+	assert.Equal(t, 6, parseErr.Column)
+	assert.Equal(t, "Bmember", parseErr.Token)
+	assert.Equal(t, "B.member", parseErr.Suggestion)
+}
+
+func TestUnknownGate(t *testing.T) {
+	_, err := FromString("Andd('A.member', 'B.member')")
+	assert.EqualError(t, err, "unknown gate 'Andd' in policy string at line 1, col 1: did you mean 'And'?")
+
+	_, err = FromString("Xyzzy('A.member', 'B.member')")
+	assert.EqualError(t, err, "unknown gate 'Xyzzy' in policy string at line 1, col 1")
+}
+
+func TestUnbalancedParentheses(t *testing.T) {
+	_, err := FromString("OR('A.member', 'B.member'")
+	assert.EqualError(t, err, "unbalanced parentheses in policy string, unclosed '(' at line 1, col 3")
+
+	_, err = FromString("And('A.member', 'B.member'))")
+	assert.EqualError(t, err, "unbalanced parentheses in policy string, unmatched ')' at line 1, col 28")
+}
+
+func TestSuggestPrincipal(t *testing.T) {
+	assert.Equal(t, "Org1MSP.member", suggestPrincipal("Org1MSP"))
+	assert.Equal(t, "Org1MSP.member", suggestPrincipal("Org1MSPmember"))
+	assert.Equal(t, "Org1MSP.member", suggestPrincipal("Org1MSP.membr"))
+	assert.Equal(t, "Org1MSP.admin", suggestPrincipal("Org1MSP.admn"))
+	assert.Equal(t, "Org1MSP.member", suggestPrincipal("Org1MSP.xyz"))
+}
+
+func TestAttr(t *testing.T) {
+	p1, err := FromStringWithCapabilities("And(Attr('role', 'auditor'), 'A.member')", false, true)
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPAttribute{AttributeName: "role", AttributeValue: "auditor"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       NOutOf(2, []*common.SignaturePolicy{SignedBy(0), SignedBy(1)}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestAttrWithOrg(t *testing.T) {
+	p1, err := FromStringWithCapabilities("Or(Attr('Org1MSP', 'role', 'auditor'), 'B.admin')", false, true)
+	assert.NoError(t, err)
+
+	principals := make([]*msp.MSPPrincipal, 0)
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPAttribute{MspIdentifier: "Org1MSP", AttributeName: "role", AttributeValue: "auditor"})})
+
+	principals = append(principals, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_ADMIN, MspIdentifier: "B"})})
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       NOutOf(1, []*common.SignaturePolicy{SignedBy(0), SignedBy(1)}),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+}
+
+func TestAttrPresenceOnly(t *testing.T) {
+	p1, err := FromStringWithCapabilities("And(Attr('role', ''), 'A.member')", false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ATTRIBUTE,
+		Principal:               protoutil.MarshalOrPanic(&msp.MSPAttribute{AttributeName: "role"}),
+	}, p1.Identities[0])
+}
+
+func TestAttrWithoutCapability(t *testing.T) {
+	p1, err := FromString("And(Attr('role', 'auditor'), 'A.member')")
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "Attr principals are not supported without the required channel capability")
+
+	p1, err = FromStringWithCapabilities("And(Attr('role', 'auditor'), 'A.member')", false, false)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "Attr principals are not supported without the required channel capability")
+}
+
+func TestAttrBadArgs(t *testing.T) {
+	p1, err := FromStringWithCapabilities("And(Attr('role'), 'A.member')", false, true)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "expected two or three arguments to Attr. Given 1")
+
+	p1, err = FromStringWithCapabilities("And(Attr('', 'auditor'), 'A.member')", false, true)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "the attribute name of an Attr principal must not be empty")
+
+	p1, err = FromStringWithCapabilities("And(Attr('role', 1), 'A.member')", false, true)
+	assert.Nil(t, p1)
+	assert.EqualError(t, err, "unexpected type float64")
+}
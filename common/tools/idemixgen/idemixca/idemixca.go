@@ -42,7 +42,9 @@ func GenerateIssuerKey() ([]byte, []byte, error) {
 // GenerateSignerConfig creates a new signer config.
 // It generates a fresh user secret and issues a credential
 // with four attributes (described above) using the CA's key pair.
-func GenerateSignerConfig(roleMask int, ouString string, enrollmentId string, revocationHandle int, key *idemix.IssuerKey, revKey *ecdsa.PrivateKey) ([]byte, error) {
+// The signer's credential revocation information is issued for the given
+// revocation epoch.
+func GenerateSignerConfig(roleMask int, ouString string, enrollmentId string, revocationHandle int, epoch int, key *idemix.IssuerKey, revKey *ecdsa.PrivateKey) ([]byte, error) {
 	attrs := make([]*FP256BN.BIG, 4)
 
 	if ouString == "" {
@@ -76,7 +78,7 @@ func GenerateSignerConfig(roleMask int, ouString string, enrollmentId string, re
 	}
 
 	// NOTE currently, idemixca creates CRI's with "ALG_NO_REVOCATION"
-	cri, err := idemix.CreateCRI(revKey, []*FP256BN.BIG{FP256BN.NewBIGint(revocationHandle)}, 0, idemix.ALG_NO_REVOCATION, rng)
+	cri, err := idemix.CreateCRI(revKey, []*FP256BN.BIG{FP256BN.NewBIGint(revocationHandle)}, epoch, idemix.ALG_NO_REVOCATION, rng)
 	if err != nil {
 		return nil, err
 	}
@@ -96,3 +98,38 @@ func GenerateSignerConfig(roleMask int, ouString string, enrollmentId string, re
 
 	return proto.Marshal(signer)
 }
+
+// GenerateCRI creates serialized credential revocation information for the
+// given epoch, signed with the revocation authority key.
+// NOTE since idemixca creates CRI's with "ALG_NO_REVOCATION", the resulting
+// CRI can be used by any signer.
+func GenerateCRI(epoch int, revKey *ecdsa.PrivateKey) ([]byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+
+	cri, err := idemix.CreateCRI(revKey, nil, epoch, idemix.ALG_NO_REVOCATION, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	criBytes, err := proto.Marshal(cri)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal CRI")
+	}
+
+	return criBytes, nil
+}
+
+// UpdateSignerConfigCRI replaces the credential revocation information of a
+// serialized signer config with the given serialized CRI.
+func UpdateSignerConfigCRI(signerBytes, criBytes []byte) ([]byte, error) {
+	signer := &m.IdemixMSPSignerConfig{}
+	if err := proto.Unmarshal(signerBytes, signer); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal signer config")
+	}
+	signer.CredentialRevocationInformation = criBytes
+
+	return proto.Marshal(signer)
+}
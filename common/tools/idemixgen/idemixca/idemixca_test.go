@@ -12,6 +12,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -45,13 +46,13 @@ func TestIdemixCa(t *testing.T) {
 
 	key := &idemix.IssuerKey{Isk: isk, Ipk: ipk}
 
-	conf, err := GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid1", 1, key, revocationkey)
+	conf, err := GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid1", 1, 0, key, revocationkey)
 	assert.NoError(t, err)
 	cleanupSigner()
 	assert.NoError(t, writeSignerToFile(conf))
 	assert.NoError(t, setupMSP())
 
-	conf, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "enrollmentid2", 1234, key, revocationkey)
+	conf, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "enrollmentid2", 1234, 0, key, revocationkey)
 	assert.NoError(t, err)
 	cleanupSigner()
 	assert.NoError(t, writeSignerToFile(conf))
@@ -61,11 +62,41 @@ func TestIdemixCa(t *testing.T) {
 	cleanupVerifier()
 	assert.Error(t, setupMSP())
 
-	_, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "", "enrollmentid", 1, key, revocationkey)
+	_, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "", "enrollmentid", 1, 0, key, revocationkey)
 	assert.EqualError(t, err, "the OU attribute value is empty")
 
-	_, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "", 1, key, revocationkey)
+	_, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.ADMIN), "OU1", "", 1, 0, key, revocationkey)
 	assert.EqualError(t, err, "the enrollment id value is empty")
+
+	// Re-create the verifier dir and advance the revocation epoch
+	assert.NoError(t, writeVerifierToFile(ipkBytes, pemEncodedRevocationPK))
+	assert.NoError(t, writeEpochToFile(1))
+
+	// A signer whose CRI was issued for the current epoch is accepted
+	conf, err = GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid3", 1, 1, key, revocationkey)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
+
+	// A signer with a stale CRI is rejected
+	staleConf, err := GenerateSignerConfig(m.GetRoleMaskFromIdemixRole(m.MEMBER), "OU1", "enrollmentid4", 1, 0, key, revocationkey)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(staleConf))
+	err = setupMSP()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Credential revocation information is not valid")
+
+	// Updating the stale signer config with a CRI for the current
+	// epoch makes it acceptable again
+	criBytes, err := GenerateCRI(1, revocationkey)
+	assert.NoError(t, err)
+	conf, err = UpdateSignerConfigCRI(staleConf, criBytes)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.NoError(t, setupMSP())
 }
 
 func cleanup() error {
@@ -98,6 +129,10 @@ func writeVerifierToFile(ipkBytes []byte, revpkBytes []byte) error {
 	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileRevocationPublicKey), revpkBytes, 0644)
 }
 
+func writeEpochToFile(epoch int) error {
+	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileEpoch), []byte(strconv.Itoa(epoch)), 0644)
+}
+
 func writeSignerToFile(signerBytes []byte) error {
 	err := os.Mkdir(filepath.Join(testDir, m.IdemixConfigDirUser), os.ModePerm)
 	if err != nil {
@@ -6,7 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 
 package aclmgmt
 
-import "github.com/hyperledger/fabric/core/policy"
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/accessaudit"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/protoutil"
+)
 
 //implementation of aclMgmt. CheckACL calls in fabric result in the following flow
 //    if resourceProvider[resourceName]
@@ -24,7 +30,47 @@ type aclMgmtImpl struct {
 //id can be extracted for testing against a policy
 func (am *aclMgmtImpl) CheckACL(resName string, channelID string, idinfo interface{}) error {
 	//use the resource based config provider (which will in turn default to 1.0 provider)
-	return am.rescfgProvider.CheckACL(resName, channelID, idinfo)
+	err := am.rescfgProvider.CheckACL(resName, channelID, idinfo)
+	if err != nil {
+		accessaudit.Record(accessaudit.Event{
+			Channel:    channelID,
+			Policy:     resName,
+			Identities: auditIdentities(idinfo),
+			Reason:     err.Error(),
+		})
+	}
+	return err
+}
+
+// auditIdentities extracts the identities of the request for the audit trail;
+// idinfo carries the same types that the providers accept in CheckACL.
+func auditIdentities(idinfo interface{}) []string {
+	switch typedData := idinfo.(type) {
+	case *pb.SignedProposal:
+		prop, err := protoutil.UnmarshalProposal(typedData.ProposalBytes)
+		if err != nil {
+			return nil
+		}
+		hdr, err := protoutil.UnmarshalHeader(prop.Header)
+		if err != nil {
+			return nil
+		}
+		shdr, err := protoutil.UnmarshalSignatureHeader(hdr.SignatureHeader)
+		if err != nil {
+			return nil
+		}
+		return []string{accessaudit.DescribeIdentity(shdr.Creator)}
+	case *common.Envelope:
+		signedData, err := protoutil.EnvelopeAsSignedData(typedData)
+		if err != nil {
+			return nil
+		}
+		return accessaudit.DescribeSignedData(signedData)
+	case []*protoutil.SignedData:
+		return accessaudit.DescribeSignedData(typedData)
+	default:
+		return nil
+	}
 }
 
 //ACLProvider consists of two providers, supplied one and a default one (1.0 ACL management
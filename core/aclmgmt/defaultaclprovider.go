@@ -28,8 +28,8 @@ type defaultACLProvider interface {
 	IsPtypePolicy(resName string) bool
 }
 
-//defaultACLProvider used if resource-based ACL Provider is not provided or
-//if it does not contain a policy for the named resource
+// defaultACLProvider used if resource-based ACL Provider is not provided or
+// if it does not contain a policy for the named resource
 type defaultACLProviderImpl struct {
 	policyChecker policy.PolicyChecker
 
@@ -58,6 +58,8 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinition] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_QueryChaincodeDefinitions] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Lifecycle_CheckCommitReadiness] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Lifecycle_SimulateCommitChaincodeDefinition] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Lifecycle_UpdateCollectionConfig] = CHANNELWRITERS
 
 	//-------------- LSCC --------------
 	//p resources (implemented by the chaincode currently)
@@ -82,6 +84,10 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetHistoryForKey] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionProof] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateProof] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetConflictStats] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
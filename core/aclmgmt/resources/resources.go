@@ -21,6 +21,8 @@ const (
 	Lifecycle_QueryChaincodeDefinition           = "_lifecycle/QueryChaincodeDefinition"
 	Lifecycle_QueryChaincodeDefinitions          = "_lifecycle/QueryChaincodeDefinitions"
 	Lifecycle_CheckCommitReadiness               = "_lifecycle/CheckCommitReadiness"
+	Lifecycle_SimulateCommitChaincodeDefinition  = "_lifecycle/SimulateCommitChaincodeDefinition"
+	Lifecycle_UpdateCollectionConfig             = "_lifecycle/UpdateCollectionConfig"
 
 	//Lscc resources
 	Lscc_Install                   = "lscc/Install"
@@ -34,11 +36,16 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo        = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber    = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash      = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID  = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID      = "qscc/GetBlockByTxID"
+	Qscc_GetHistoryForKey    = "qscc/GetHistoryForKey"
+	Qscc_GetTransactionProof = "qscc/GetTransactionProof"
+	Qscc_GetStateProof       = "qscc/GetStateProof"
+	Qscc_GetConflictStats    = "qscc/GetConflictStats"
+	Qscc_GetCallTrace        = "qscc/GetCallTrace"
 
 	//Cscc resources
 	Cscc_JoinChain      = "cscc/JoinChain"
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// defaultCallTraceRetention is the number of transactions for which call
+// records are retained before the oldest transaction is evicted.
+const defaultCallTraceRetention = 1000
+
+var callTraceLogger = flogging.MustGetLogger("chaincode.calltrace")
+
+// CallRecord describes a single cross-chaincode invocation made while a
+// transaction was being simulated.
+type CallRecord struct {
+	// Caller is the namespace of the chaincode which issued the invocation.
+	Caller string `json:"caller"`
+	// Callee is the name of the invoked chaincode.
+	Callee string `json:"callee"`
+	// ChannelID is the channel the invoked chaincode executed on.
+	ChannelID string `json:"channel_id"`
+	// Latency is the time spent executing the invoked chaincode, in
+	// nanoseconds.
+	Latency time.Duration `json:"latency_ns"`
+	// Success indicates whether the invocation completed without an error.
+	Success bool `json:"success"`
+}
+
+// CallTracer records the cross-chaincode call chains of recently simulated
+// transactions. Each record is emitted as a span-style debug log line when it
+// is captured and the records of the most recent transactions are retained in
+// memory so the call graph of a transaction can be retrieved afterwards, for
+// example via the qscc GetCallTrace query. Records are kept in invocation
+// order, so nested calls appear in the order in which they were issued.
+type CallTracer struct {
+	retention int
+
+	mutex  sync.Mutex
+	traces map[string][]*CallRecord
+	// order holds the traced transaction IDs from oldest to newest and
+	// drives the eviction of the oldest transaction.
+	order []string
+}
+
+// NewCallTracer creates a CallTracer which retains the call records of the
+// given number of transactions. A retention of zero or less selects
+// defaultCallTraceRetention.
+func NewCallTracer(retainedTransactions int) *CallTracer {
+	if retainedTransactions <= 0 {
+		retainedTransactions = defaultCallTraceRetention
+	}
+	return &CallTracer{
+		retention: retainedTransactions,
+		traces:    map[string][]*CallRecord{},
+	}
+}
+
+// RecordCall appends a call record to the trace of the given transaction. If
+// the transaction has not been seen before and the retention limit has been
+// reached, the trace of the oldest transaction is evicted.
+func (t *CallTracer) RecordCall(txID string, record *CallRecord) {
+	callTraceLogger.Debugf(
+		"[%s] %s -> %s on channel %s took %s (success: %t)",
+		shorttxid(txID), record.Caller, record.Callee, record.ChannelID, record.Latency, record.Success,
+	)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, ok := t.traces[txID]; !ok {
+		for len(t.order) >= t.retention {
+			delete(t.traces, t.order[0])
+			t.order = t.order[1:]
+		}
+		t.order = append(t.order, txID)
+	}
+	t.traces[txID] = append(t.traces[txID], record)
+}
+
+// TransactionTrace returns the call records captured for the given
+// transaction in invocation order, or nil when no trace is retained for it.
+func (t *CallTracer) TransactionTrace(txID string) []*CallRecord {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	trace := t.traces[txID]
+	if trace == nil {
+		return nil
+	}
+	return append([]*CallRecord{}, trace...)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CallTracer", func() {
+	var tracer *chaincode.CallTracer
+
+	BeforeEach(func() {
+		tracer = chaincode.NewCallTracer(2)
+	})
+
+	It("retains the call records of a transaction in invocation order", func() {
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{
+			Caller:    "cc-one",
+			Callee:    "cc-two",
+			ChannelID: "channel-id",
+			Latency:   time.Millisecond,
+			Success:   true,
+		})
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{
+			Caller:    "cc-two",
+			Callee:    "cc-three",
+			ChannelID: "other-channel-id",
+			Latency:   2 * time.Millisecond,
+			Success:   false,
+		})
+
+		trace := tracer.TransactionTrace("tx-1")
+		Expect(trace).To(HaveLen(2))
+		Expect(trace[0]).To(Equal(&chaincode.CallRecord{
+			Caller:    "cc-one",
+			Callee:    "cc-two",
+			ChannelID: "channel-id",
+			Latency:   time.Millisecond,
+			Success:   true,
+		}))
+		Expect(trace[1].Callee).To(Equal("cc-three"))
+	})
+
+	It("returns nil for transactions without a retained trace", func() {
+		Expect(tracer.TransactionTrace("missing-tx")).To(BeNil())
+	})
+
+	It("evicts the oldest transaction when the retention limit is reached", func() {
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{Callee: "cc-one"})
+		tracer.RecordCall("tx-2", &chaincode.CallRecord{Callee: "cc-two"})
+		tracer.RecordCall("tx-3", &chaincode.CallRecord{Callee: "cc-three"})
+
+		Expect(tracer.TransactionTrace("tx-1")).To(BeNil())
+		Expect(tracer.TransactionTrace("tx-2")).To(HaveLen(1))
+		Expect(tracer.TransactionTrace("tx-3")).To(HaveLen(1))
+	})
+
+	It("keeps recording for transactions which are already traced", func() {
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{Callee: "cc-one"})
+		tracer.RecordCall("tx-2", &chaincode.CallRecord{Callee: "cc-two"})
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{Callee: "cc-three"})
+
+		Expect(tracer.TransactionTrace("tx-1")).To(HaveLen(2))
+		Expect(tracer.TransactionTrace("tx-2")).To(HaveLen(1))
+	})
+
+	It("returns a copy of the trace", func() {
+		tracer.RecordCall("tx-1", &chaincode.CallRecord{Callee: "cc-one"})
+
+		trace := tracer.TransactionTrace("tx-1")
+		trace[0] = &chaincode.CallRecord{Callee: "tampered"}
+
+		Expect(tracer.TransactionTrace("tx-1")[0].Callee).To(Equal("cc-one"))
+	})
+})
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/scc"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -142,6 +143,103 @@ var _ = Describe("CheckInvocation", func() {
 		})
 	})
 
+	Context("when the invocation is a migration", func() {
+		BeforeEach(func() {
+			input.Args = [][]byte{[]byte("Migrate")}
+		})
+
+		It("enforces migrate exactly once per version semantics", func() {
+			ccid, cctype, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccid).To(Equal("definition-ccid"))
+			Expect(cctype).To(Equal(pb.ChaincodeMessage_TRANSACTION))
+
+			Expect(fakeSimulator.GetStateCallCount()).To(Equal(1))
+			namespace, key := fakeSimulator.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("test-chaincode-name"))
+			Expect(key).To(Equal("\x00" + string(utf8.MaxRune) + "migrated"))
+
+			Expect(fakeSimulator.SetStateCallCount()).To(Equal(1))
+			namespace, key, value := fakeSimulator.SetStateArgsForCall(0)
+			Expect(namespace).To(Equal("test-chaincode-name"))
+			Expect(key).To(Equal("\x00" + string(utf8.MaxRune) + "migrated"))
+			Expect(value).To(Equal([]byte("definition-version")))
+		})
+
+		It("supplies the from and to versions as arguments", func() {
+			_, _, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(input.Args).To(Equal([][]byte{
+				[]byte("Migrate"),
+				[]byte("old-cc-version"),
+				[]byte("definition-version"),
+			}))
+		})
+
+		Context("when the chaincode has never been migrated", func() {
+			BeforeEach(func() {
+				fakeSimulator.GetStateReturns(nil, nil)
+			})
+
+			It("supplies an empty from version", func() {
+				_, _, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(input.Args).To(Equal([][]byte{
+					[]byte("Migrate"),
+					nil,
+					[]byte("definition-version"),
+				}))
+			})
+		})
+
+		Context("when the chaincode has already been migrated to this version", func() {
+			BeforeEach(func() {
+				fakeSimulator.GetStateReturns([]byte("definition-version"), nil)
+			})
+
+			It("returns an error", func() {
+				_, _, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+				Expect(err).To(MatchError("chaincode 'test-chaincode-name' has already been migrated to version 'definition-version'"))
+			})
+		})
+
+		Context("when the target is a system chaincode", func() {
+			BeforeEach(func() {
+				chaincodeSupport.BuiltinSCCs = scc.BuiltinSCCs{"test-chaincode-name": struct{}{}}
+			})
+
+			It("treats the invocation as a normal transaction", func() {
+				_, cctype, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cctype).To(Equal(pb.ChaincodeMessage_TRANSACTION))
+				Expect(fakeSimulator.GetStateCallCount()).To(Equal(0))
+				Expect(input.Args).To(Equal([][]byte{[]byte("Migrate")}))
+			})
+		})
+
+		Context("when the txsimulator cannot get state", func() {
+			BeforeEach(func() {
+				fakeSimulator.GetStateReturns(nil, fmt.Errorf("get-state-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, _, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+				Expect(err).To(MatchError("could not get 'migrated' key: get-state-error"))
+			})
+		})
+
+		Context("when the txsimulator cannot set state", func() {
+			BeforeEach(func() {
+				fakeSimulator.SetStateReturns(fmt.Errorf("set-state-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, _, err := chaincodeSupport.CheckInvocation(txParams, "test-chaincode-name", input)
+				Expect(err).To(MatchError("could not set 'migrated' key: set-state-error"))
+			})
+		})
+	})
+
 	Context("when lifecycle returns an error", func() {
 		BeforeEach(func() {
 			fakeLifecycle.ChaincodeEndorsementInfoReturns(nil, fmt.Errorf("fake-lifecycle-error"))
@@ -60,6 +60,11 @@ type invoker interface {
 	chaincode.Invoker
 }
 
+//go:generate counterfeiter -o mock/launcher.go --fake-name Launcher . launcher
+type launcher interface {
+	chaincode.Launcher
+}
+
 //go:generate counterfeiter -o mock/lifecycle.go --fake-name Lifecycle . lifecycle_
 type lifecycle_ interface {
 	chaincode.Lifecycle
@@ -14,6 +14,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/eventschema"
 	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -31,6 +32,19 @@ const (
 	// the backing chaincode bytes change (but not be required to re-initialize
 	// the chaincode say, when endorsement policy changes).
 	InitializedKeyName = "\x00" + string(utf8.MaxRune) + "initialized"
+
+	// MigratedKeyName is the reserved key in a chaincode's namespace which
+	// records the version of the chaincode definition the namespace was last
+	// migrated to. In this way, we can enforce Migrate exactly once per
+	// version semantics in the same fashion as Init.
+	MigratedKeyName = "\x00" + string(utf8.MaxRune) + "migrated"
+
+	// MigrateFuncName is the reserved chaincode function name which invokes
+	// the optional data-model migration entry point of a chaincode. The peer
+	// rewrites the arguments of a migration invocation to the versions the
+	// namespace is migrated from and to, so a chaincode implementing the
+	// entry point receives [MigrateFuncName, fromVersion, toVersion].
+	MigrateFuncName = "Migrate"
 )
 
 // Runtime is used to manage chaincode runtime instances.
@@ -56,21 +70,28 @@ type Lifecycle interface {
 
 // ChaincodeSupport responsible for providing interfacing with chaincodes from the Peer.
 type ChaincodeSupport struct {
-	ACLProvider            ACLProvider
-	AppConfig              ApplicationConfigRetriever
-	BuiltinSCCs            scc.BuiltinSCCs
-	DeployedCCInfoProvider ledger.DeployedChaincodeInfoProvider
-	ExecuteTimeout         time.Duration
-	InstallTimeout         time.Duration
-	HandlerMetrics         *HandlerMetrics
-	HandlerRegistry        *HandlerRegistry
-	Keepalive              time.Duration
-	Launcher               Launcher
-	Lifecycle              Lifecycle
-	Peer                   *peer.Peer
-	Runtime                Runtime
-	TotalQueryLimit        int
-	UserRunsCC             bool
+	ACLProvider             ACLProvider
+	AppConfig               ApplicationConfigRetriever
+	BuiltinSCCs             scc.BuiltinSCCs
+	CallTracer              *CallTracer
+	DeployedCCInfoProvider  ledger.DeployedChaincodeInfoProvider
+	EventSchemas            *eventschema.Registry
+	ExecuteTimeout          time.Duration
+	ExecuteTimeoutOverrides map[string]time.Duration
+	ExecutionLimiter        *ExecutionLimiter
+	InstallTimeout          time.Duration
+	HandlerMetrics          *HandlerMetrics
+	HandlerRegistry         *HandlerRegistry
+	IdleReaper              *IdleReaper
+	Keepalive               time.Duration
+	Launcher                Launcher
+	Lifecycle               Lifecycle
+	Peer                    *peer.Peer
+	Runtime                 Runtime
+	TotalQueryLimit         int
+	QueryBatchLimit         int
+	QueryBatchByteLimit     int
+	UserRunsCC              bool
 }
 
 // Launch starts executing chaincode if it is not already running. This method
@@ -105,6 +126,10 @@ func (cs *ChaincodeSupport) LaunchInProc(ccid string) <-chan struct{} {
 
 // HandleChaincodeStream implements ccintf.HandleChaincodeStream for all vms to call with appropriate stream
 func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream) error {
+	queryBatchLimit := cs.QueryBatchLimit
+	if queryBatchLimit <= 0 {
+		queryBatchLimit = defaultQueryBatchLimit
+	}
 	handler := &Handler{
 		Invoker:                cs,
 		Keepalive:              cs.Keepalive,
@@ -113,12 +138,13 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		TXContexts:             NewTransactionContexts(),
 		ActiveTransactions:     NewActiveTransactions(),
 		BuiltinSCCs:            cs.BuiltinSCCs,
-		QueryResponseBuilder:   &QueryResponseGenerator{MaxResultLimit: 100},
+		QueryResponseBuilder:   &QueryResponseGenerator{MaxResultLimit: queryBatchLimit, MaxResultBytes: cs.QueryBatchByteLimit},
 		UUIDGenerator:          UUIDGeneratorFunc(util.GenerateUUID),
 		LedgerGetter:           cs.Peer,
 		DeployedCCInfoProvider: cs.DeployedCCInfoProvider,
 		AppConfig:              cs.AppConfig,
 		Metrics:                cs.HandlerMetrics,
+		CallTracer:             cs.CallTracer,
 		TotalQueryLimit:        cs.TotalQueryLimit,
 	}
 
@@ -194,12 +220,35 @@ func (cs *ChaincodeSupport) Invoke(txParams *ccprovider.TransactionParams, chain
 		return nil, errors.WithMessage(err, "invalid invocation")
 	}
 
+	if cs.IdleReaper != nil && !cs.BuiltinSCCs.IsSysCC(chaincodeName) {
+		cs.IdleReaper.TransactionStarted(ccid)
+		defer cs.IdleReaper.TransactionCompleted(ccid)
+	}
+
 	h, err := cs.Launch(ccid)
 	if err != nil {
 		return nil, err
 	}
 
-	return cs.execute(cctype, txParams, chaincodeName, input, h)
+	if cs.ExecutionLimiter != nil {
+		release, err := cs.ExecutionLimiter.TryAcquire(chaincodeName)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	resp, err := cs.execute(cctype, txParams, chaincodeName, input, h)
+	if err != nil || cs.EventSchemas == nil || resp == nil || resp.ChaincodeEvent == nil {
+		return resp, err
+	}
+
+	event := resp.ChaincodeEvent
+	if err := cs.EventSchemas.Validate(ccid, event.EventName, event.Payload); err != nil {
+		return nil, errors.WithMessagef(err, "payload of event '%s' emitted by chaincode %s does not conform to its registered schema", event.EventName, ccid)
+	}
+
+	return resp, nil
 }
 
 // CheckInvocation inspects the parameters of an invocation and determines if, how, and to where a that invocation should be routed.
@@ -249,6 +298,32 @@ func (cs *ChaincodeSupport) CheckInvocation(txParams *ccprovider.TransactionPara
 		return "", 0, errors.Errorf("chaincode '%s' has not been initialized for this version, must call as init first", chaincodeName)
 	}
 
+	// An invocation of the reserved Migrate function runs the chaincode's
+	// data-model migration entry point. Like init, it executes under the
+	// endorsement policy of the definition and may run exactly once per
+	// version of the definition on each channel; the reserved key records
+	// the version last migrated to and makes concurrent migrations conflict
+	// through its read-write set.
+	if chaincodeOperation(input.Args) == MigrateFuncName && !cs.BuiltinSCCs.IsSysCC(chaincodeName) {
+		value, err := txParams.TXSimulator.GetState(chaincodeName, MigratedKeyName)
+		if err != nil {
+			return "", 0, errors.WithMessage(err, "could not get 'migrated' key")
+		}
+
+		if bytes.Equal(value, []byte(cii.Version)) {
+			return "", 0, errors.Errorf("chaincode '%s' has already been migrated to version '%s'", chaincodeName, cii.Version)
+		}
+
+		err = txParams.TXSimulator.SetState(chaincodeName, MigratedKeyName, []byte(cii.Version))
+		if err != nil {
+			return "", 0, errors.WithMessage(err, "could not set 'migrated' key")
+		}
+
+		// The peer supplies the from and to versions; the from version is
+		// empty when the namespace has never been migrated before.
+		input.Args = [][]byte{[]byte(MigrateFuncName), value, []byte(cii.Version)}
+	}
+
 	return cii.ChaincodeID, pb.ChaincodeMessage_TRANSACTION, nil
 }
 
@@ -278,14 +353,19 @@ func (cs *ChaincodeSupport) execute(cctyp pb.ChaincodeMessage_Type, txParams *cc
 }
 
 func (cs *ChaincodeSupport) executeTimeout(namespace string, input *pb.ChaincodeInput) time.Duration {
+	executeTimeout := cs.ExecuteTimeout
+	if override, ok := cs.ExecuteTimeoutOverrides[namespace]; ok {
+		executeTimeout = override
+	}
+
 	operation := chaincodeOperation(input.Args)
 	switch {
 	case namespace == "lscc" && operation == "install":
-		return maxDuration(cs.InstallTimeout, cs.ExecuteTimeout)
+		return maxDuration(cs.InstallTimeout, executeTimeout)
 	case namespace == lifecycle.LifecycleNamespace && operation == lifecycle.InstallChaincodeFuncName:
-		return maxDuration(cs.InstallTimeout, cs.ExecuteTimeout)
+		return maxDuration(cs.InstallTimeout, executeTimeout)
 	default:
-		return cs.ExecuteTimeout
+		return executeTimeout
 	}
 }
 
@@ -1220,11 +1220,12 @@ func TestExecuteTimeout(t *testing.T) {
 	defer cleanup()
 
 	tests := []struct {
-		executeTimeout  time.Duration
-		installTimeout  time.Duration
-		namespace       string
-		command         string
-		expectedTimeout time.Duration
+		executeTimeout   time.Duration
+		installTimeout   time.Duration
+		timeoutOverrides map[string]time.Duration
+		namespace        string
+		command          string
+		expectedTimeout  time.Duration
 	}{
 		{
 			executeTimeout:  time.Second,
@@ -1275,11 +1276,36 @@ func TestExecuteTimeout(t *testing.T) {
 			command:         "",
 			expectedTimeout: time.Second,
 		},
+		{
+			executeTimeout:   time.Second,
+			installTimeout:   time.Minute,
+			timeoutOverrides: map[string]time.Duration{"slowcc": 5 * time.Minute},
+			namespace:        "slowcc",
+			command:          "anything",
+			expectedTimeout:  5 * time.Minute,
+		},
+		{
+			executeTimeout:   time.Second,
+			installTimeout:   time.Minute,
+			timeoutOverrides: map[string]time.Duration{"slowcc": 5 * time.Minute},
+			namespace:        "anything",
+			command:          "",
+			expectedTimeout:  time.Second,
+		},
+		{
+			executeTimeout:   time.Second,
+			installTimeout:   time.Minute,
+			timeoutOverrides: map[string]time.Duration{"lscc": 2 * time.Minute},
+			namespace:        "lscc",
+			command:          "install",
+			expectedTimeout:  2 * time.Minute,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.namespace+"_"+tt.command, func(t *testing.T) {
 			cs.ExecuteTimeout = tt.executeTimeout
 			cs.InstallTimeout = tt.installTimeout
+			cs.ExecuteTimeoutOverrides = tt.timeoutOverrides
 			input := &pb.ChaincodeInput{Args: util.ToChaincodeArgs(tt.command)}
 
 			result := cs.executeTimeout(tt.namespace, input)
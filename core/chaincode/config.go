@@ -18,19 +18,47 @@ import (
 const (
 	defaultExecutionTimeout = 30 * time.Second
 	minimumStartupTimeout   = 5 * time.Second
+
+	defaultQueryBatchLimit     = 100
+	defaultQueryBatchByteLimit = 10 * 1024 * 1024 // 10 MiB
+
+	defaultLogStreamRetainedBytes = 1024 * 1024 // 1 MiB
+
+	defaultOnDemandIdleTimeout = 5 * time.Minute
 )
 
 type Config struct {
-	TotalQueryLimit int
-	TLSEnabled      bool
-	Keepalive       time.Duration
-	ExecuteTimeout  time.Duration
-	InstallTimeout  time.Duration
-	StartupTimeout  time.Duration
-	LogFormat       string
-	LogLevel        string
-	ShimLogLevel    string
-	SCCWhitelist    map[string]bool
+	TotalQueryLimit         int
+	QueryBatchLimit         int
+	QueryBatchByteLimit     int
+	TLSEnabled              bool
+	Keepalive               time.Duration
+	ExecuteTimeout          time.Duration
+	ExecuteTimeoutOverrides map[string]time.Duration
+	InstallTimeout          time.Duration
+	StartupTimeout          time.Duration
+	LogFormat               string
+	LogLevel                string
+	ShimLogLevel            string
+	LogStreamEnabled        bool
+	LogStreamRetainedBytes  int
+	EventSchemaValidation   bool
+	OnDemandEnabled         bool
+	OnDemandIdleTimeout     time.Duration
+	SCCWhitelist            map[string]bool
+	ResourceLimitsDefault   ResourceLimits
+	ResourceLimitsOverrides map[string]ResourceLimits
+}
+
+// ResourceLimits describes the resource limits that apply to a chaincode. A
+// zero value leaves the corresponding resource unlimited. CPUShares and
+// Memory (in bytes) are applied to the chaincode container by the launcher,
+// while MaxConcurrency caps the number of transactions the peer will execute
+// concurrently on the chaincode regardless of how it is launched.
+type ResourceLimits struct {
+	CPUShares      int64
+	Memory         int64
+	MaxConcurrency int
 }
 
 func GlobalConfig() *Config {
@@ -52,6 +80,15 @@ func (c *Config) load() {
 	if c.ExecuteTimeout < time.Second {
 		c.ExecuteTimeout = defaultExecutionTimeout
 	}
+	c.ExecuteTimeoutOverrides = map[string]time.Duration{}
+	for name := range viper.GetStringMap("chaincode.executeTimeoutOverrides") {
+		timeout := viper.GetDuration("chaincode.executeTimeoutOverrides." + name)
+		if timeout < time.Second {
+			chaincodeLogger.Warningf("executetimeout override for chaincode %s is less than 1s, using the global timeout", name)
+			continue
+		}
+		c.ExecuteTimeoutOverrides[name] = timeout
+	}
 	c.InstallTimeout = viper.GetDuration("chaincode.installTimeout")
 	c.StartupTimeout = viper.GetDuration("chaincode.startuptimeout")
 	if c.StartupTimeout < minimumStartupTimeout {
@@ -67,10 +104,68 @@ func (c *Config) load() {
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
 
+	c.LogStreamEnabled = viper.GetBool("chaincode.logging.stream.enabled")
+	c.LogStreamRetainedBytes = viper.GetInt("chaincode.logging.stream.retainedBytes")
+	if c.LogStreamRetainedBytes <= 0 {
+		c.LogStreamRetainedBytes = defaultLogStreamRetainedBytes
+	}
+
+	c.EventSchemaValidation = viper.GetBool("chaincode.events.schemaValidation")
+
+	c.OnDemandEnabled = viper.GetBool("chaincode.onDemand.enabled")
+	c.OnDemandIdleTimeout = viper.GetDuration("chaincode.onDemand.idleTimeout")
+	if c.OnDemandIdleTimeout <= 0 {
+		c.OnDemandIdleTimeout = defaultOnDemandIdleTimeout
+	}
+
 	c.TotalQueryLimit = 10000 // need a default just in case it's not set
 	if viper.IsSet("ledger.state.totalQueryLimit") {
 		c.TotalQueryLimit = viper.GetInt("ledger.state.totalQueryLimit")
 	}
+
+	c.QueryBatchLimit = defaultQueryBatchLimit
+	if viper.IsSet("chaincode.queryBatchLimit") {
+		c.QueryBatchLimit = viper.GetInt("chaincode.queryBatchLimit")
+	}
+	if c.QueryBatchLimit <= 0 {
+		c.QueryBatchLimit = defaultQueryBatchLimit
+	}
+	c.QueryBatchByteLimit = defaultQueryBatchByteLimit
+	if viper.IsSet("chaincode.queryBatchByteLimit") {
+		c.QueryBatchByteLimit = viper.GetInt("chaincode.queryBatchByteLimit")
+	}
+
+	c.ResourceLimitsDefault = ResourceLimits{
+		CPUShares:      int64(viper.GetInt("chaincode.resourceLimits.default.cpuShares")),
+		Memory:         int64(viper.GetInt("chaincode.resourceLimits.default.memory")),
+		MaxConcurrency: viper.GetInt("chaincode.resourceLimits.default.maxConcurrency"),
+	}
+
+	c.ResourceLimitsOverrides = map[string]ResourceLimits{}
+	for name := range viper.GetStringMap("chaincode.resourceLimits.overrides") {
+		prefix := "chaincode.resourceLimits.overrides." + name
+		limits := c.ResourceLimitsDefault
+		if viper.IsSet(prefix + ".cpuShares") {
+			limits.CPUShares = int64(viper.GetInt(prefix + ".cpuShares"))
+		}
+		if viper.IsSet(prefix + ".memory") {
+			limits.Memory = int64(viper.GetInt(prefix + ".memory"))
+		}
+		if viper.IsSet(prefix + ".maxConcurrency") {
+			limits.MaxConcurrency = viper.GetInt(prefix + ".maxConcurrency")
+		}
+		c.ResourceLimitsOverrides[name] = limits
+	}
+}
+
+// LimitsForChaincode returns the resource limits that apply to the named
+// chaincode: the override for the name when one is configured, and the
+// defaults otherwise. Values an override leaves unset inherit the defaults.
+func (c *Config) LimitsForChaincode(name string) ResourceLimits {
+	if limits, ok := c.ResourceLimitsOverrides[name]; ok {
+		return limits
+	}
+	return c.ResourceLimitsDefault
 }
 
 func parseBool(s string) bool {
@@ -36,6 +36,8 @@ var _ = Describe("Config", func() {
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "warning")
 			viper.Set("chaincode.logging.shim", "warning")
+			viper.Set("chaincode.queryBatchLimit", 200)
+			viper.Set("chaincode.queryBatchByteLimit", 1048576)
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
@@ -46,6 +48,19 @@ var _ = Describe("Config", func() {
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("warn"))
 			Expect(config.ShimLogLevel).To(Equal("warn"))
+			Expect(config.QueryBatchLimit).To(Equal(200))
+			Expect(config.QueryBatchByteLimit).To(Equal(1048576))
+		})
+
+		Context("when an invalid query batch limit is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.queryBatchLimit", 0)
+			})
+
+			It("falls back to the default", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.QueryBatchLimit).To(Equal(100))
+			})
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -93,6 +108,130 @@ var _ = Describe("Config", func() {
 				Expect(config.ShimLogLevel).To(Equal("info"))
 			})
 		})
+
+		Context("when chaincode log streaming is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.logging.stream.enabled", true)
+				viper.Set("chaincode.logging.stream.retainedBytes", 2048)
+			})
+
+			It("captures the configuration", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.LogStreamEnabled).To(BeTrue())
+				Expect(config.LogStreamRetainedBytes).To(Equal(2048))
+			})
+
+			Context("when the retention limit is not positive", func() {
+				BeforeEach(func() {
+					viper.Set("chaincode.logging.stream.retainedBytes", 0)
+				})
+
+				It("falls back to the default", func() {
+					config := chaincode.GlobalConfig()
+					Expect(config.LogStreamRetainedBytes).To(Equal(1024 * 1024))
+				})
+			})
+		})
+
+		Context("when chaincode event schema validation is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.events.schemaValidation", true)
+			})
+
+			It("captures the configuration", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.EventSchemaValidation).To(BeTrue())
+			})
+		})
+
+		Context("when per-chaincode execution timeouts are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.executeTimeoutOverrides", map[string]interface{}{"analytics-cc": nil, "fast-cc": nil, "bad-cc": nil})
+				viper.Set("chaincode.executeTimeoutOverrides.analytics-cc", "10m")
+				viper.Set("chaincode.executeTimeoutOverrides.fast-cc", "2s")
+				viper.Set("chaincode.executeTimeoutOverrides.bad-cc", "100ms")
+			})
+
+			It("captures the overrides and ignores those below the minimum", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ExecuteTimeoutOverrides).To(Equal(map[string]time.Duration{
+					"analytics-cc": 10 * time.Minute,
+					"fast-cc":      2 * time.Second,
+				}))
+			})
+		})
+
+		Context("when on-demand execution is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.onDemand.enabled", true)
+				viper.Set("chaincode.onDemand.idleTimeout", "90s")
+			})
+
+			It("captures the configuration", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.OnDemandEnabled).To(BeTrue())
+				Expect(config.OnDemandIdleTimeout).To(Equal(90 * time.Second))
+			})
+
+			Context("when the idle timeout is not positive", func() {
+				BeforeEach(func() {
+					viper.Set("chaincode.onDemand.idleTimeout", "0s")
+				})
+
+				It("falls back to the default", func() {
+					config := chaincode.GlobalConfig()
+					Expect(config.OnDemandIdleTimeout).To(Equal(5 * time.Minute))
+				})
+			})
+		})
+
+		Context("when per-chaincode resource limits are configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.resourceLimits.default.cpuShares", 512)
+				viper.Set("chaincode.resourceLimits.default.memory", 134217728)
+				viper.Set("chaincode.resourceLimits.default.maxConcurrency", 8)
+				viper.Set("chaincode.resourceLimits.overrides", map[string]interface{}{"mycc": nil})
+				viper.Set("chaincode.resourceLimits.overrides.mycc.memory", 268435456)
+				viper.Set("chaincode.resourceLimits.overrides.mycc.maxConcurrency", 16)
+			})
+
+			It("captures the defaults and merges them into the overrides", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.ResourceLimitsDefault).To(Equal(chaincode.ResourceLimits{
+					CPUShares:      512,
+					Memory:         134217728,
+					MaxConcurrency: 8,
+				}))
+				Expect(config.ResourceLimitsOverrides).To(Equal(map[string]chaincode.ResourceLimits{
+					"mycc": {
+						CPUShares:      512,
+						Memory:         268435456,
+						MaxConcurrency: 16,
+					},
+				}))
+			})
+		})
+	})
+
+	Describe("LimitsForChaincode", func() {
+		var config *chaincode.Config
+
+		BeforeEach(func() {
+			config = &chaincode.Config{
+				ResourceLimitsDefault: chaincode.ResourceLimits{MaxConcurrency: 8},
+				ResourceLimitsOverrides: map[string]chaincode.ResourceLimits{
+					"mycc": {MaxConcurrency: 2},
+				},
+			}
+		})
+
+		It("returns the override for the named chaincode", func() {
+			Expect(config.LimitsForChaincode("mycc")).To(Equal(chaincode.ResourceLimits{MaxConcurrency: 2}))
+		})
+
+		It("returns the defaults when no override is configured", func() {
+			Expect(config.LimitsForChaincode("othercc")).To(Equal(chaincode.ResourceLimits{MaxConcurrency: 8}))
+		})
 	})
 
 	Describe("IsDevMode", func() {
@@ -114,13 +253,25 @@ func capture() (restore func()) {
 	viper.SetEnvPrefix("CORE")
 	viper.AutomaticEnv()
 	config := map[string]string{
-		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
-		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
-		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
-		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
-		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
-		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
-		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"peer.tls.enabled":                                viper.GetString("peer.tls.enabled"),
+		"chaincode.keepalive":                             viper.GetString("chaincode.keepalive"),
+		"chaincode.executetimeout":                        viper.GetString("chaincode.executetimeout"),
+		"chaincode.executeTimeoutOverrides":               "",
+		"chaincode.startuptimeout":                        viper.GetString("chaincode.startuptimeout"),
+		"chaincode.logging.format":                        viper.GetString("chaincode.logging.format"),
+		"chaincode.logging.level":                         viper.GetString("chaincode.logging.level"),
+		"chaincode.logging.shim":                          viper.GetString("chaincode.logging.shim"),
+		"chaincode.queryBatchLimit":                       viper.GetString("chaincode.queryBatchLimit"),
+		"chaincode.queryBatchByteLimit":                   viper.GetString("chaincode.queryBatchByteLimit"),
+		"chaincode.logging.stream.enabled":                viper.GetString("chaincode.logging.stream.enabled"),
+		"chaincode.logging.stream.retainedBytes":          viper.GetString("chaincode.logging.stream.retainedBytes"),
+		"chaincode.events.schemaValidation":               viper.GetString("chaincode.events.schemaValidation"),
+		"chaincode.onDemand.enabled":                      viper.GetString("chaincode.onDemand.enabled"),
+		"chaincode.onDemand.idleTimeout":                  viper.GetString("chaincode.onDemand.idleTimeout"),
+		"chaincode.resourceLimits.default.cpuShares":      viper.GetString("chaincode.resourceLimits.default.cpuShares"),
+		"chaincode.resourceLimits.default.memory":         viper.GetString("chaincode.resourceLimits.default.memory"),
+		"chaincode.resourceLimits.default.maxConcurrency": viper.GetString("chaincode.resourceLimits.default.maxConcurrency"),
+		"chaincode.resourceLimits.overrides":              "",
 	}
 
 	return func() {
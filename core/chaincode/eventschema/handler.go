@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// NewHandler creates an http.Handler serving the event payload schemas
+// registered by the chaincode packages installed on the peer. A GET request
+// without a "packageID" query parameter lists the event names with a
+// registered schema per package, while a request naming a package returns
+// its schemas keyed by event name.
+func NewHandler(registry *Registry) *Handler {
+	return &Handler{
+		registry: registry,
+		logger:   flogging.MustGetLogger("eventschema"),
+	}
+}
+
+// Handler serves registered event payload schemas over HTTP.
+type Handler struct {
+	registry *Registry
+	logger   *flogging.FabricLogger
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendJSON(resp, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+		return
+	}
+
+	packageID := req.URL.Query().Get("packageID")
+	if packageID == "" {
+		installed, err := h.registry.InstalledSchemas()
+		if err != nil {
+			h.sendJSON(resp, http.StatusInternalServerError, &errorResponse{Error: err.Error()})
+			return
+		}
+		h.sendJSON(resp, http.StatusOK, installed)
+		return
+	}
+
+	schemas, err := h.registry.SchemasFor(packageID)
+	if err != nil {
+		h.sendJSON(resp, http.StatusNotFound, &errorResponse{Error: err.Error()})
+		return
+	}
+
+	h.sendJSON(resp, http.StatusOK, schemas)
+}
+
+func (h *Handler) sendJSON(resp http.ResponseWriter, code int, payload interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventschema
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry(t *testing.T) *Registry {
+	return NewRegistry(&fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:         "golang",
+				Label:        "mycc",
+				EventSchemas: map[string]json.RawMessage{"transfer": json.RawMessage(`{"type":"object"}`)},
+			}),
+		},
+		installed: []chaincode.InstalledChaincode{{PackageID: "mycc:hash"}},
+	})
+}
+
+func TestHandlerListsInstalledSchemas(t *testing.T) {
+	resp := httptest.NewRecorder()
+	NewHandler(testRegistry(t)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/eventschemas", nil))
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"mycc:hash": ["transfer"]}`, resp.Body.String())
+}
+
+func TestHandlerPackageSchemas(t *testing.T) {
+	resp := httptest.NewRecorder()
+	NewHandler(testRegistry(t)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/eventschemas?packageID=mycc:hash", nil))
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.JSONEq(t, `{"transfer": {"type": "object"}}`, resp.Body.String())
+}
+
+func TestHandlerUnknownPackage(t *testing.T) {
+	resp := httptest.NewRecorder()
+	NewHandler(testRegistry(t)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/eventschemas?packageID=missing:hash", nil))
+
+	require.Equal(t, http.StatusNotFound, resp.Code)
+	require.JSONEq(t, `{"error": "could not load chaincode package missing:hash: could not find chaincode with package id 'missing:hash'"}`, resp.Body.String())
+}
+
+func TestHandlerBadMethod(t *testing.T) {
+	resp := httptest.NewRecorder()
+	NewHandler(testRegistry(t)).ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/eventschemas", nil))
+
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	require.JSONEq(t, `{"error": "invalid request method: POST"}`, resp.Body.String())
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventschema
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/pkg/errors"
+)
+
+// PackageLoader provides access to the chaincode packages installed on the
+// peer. It is implemented by the persistence.Store.
+type PackageLoader interface {
+	Load(packageID string) ([]byte, error)
+	ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
+}
+
+// registeredSchema pairs the raw schema bytes served to event consumers
+// with the parsed form used for validation.
+type registeredSchema struct {
+	raw    json.RawMessage
+	parsed *Schema
+}
+
+// Registry resolves the event payload schemas registered in the metadata of
+// installed chaincode packages. Schemas are parsed lazily, the first time a
+// package's schemas are requested, and cached; installed packages are
+// immutable, so the cache never needs to be invalidated.
+type Registry struct {
+	loader PackageLoader
+
+	mutex sync.Mutex
+	cache map[string]map[string]*registeredSchema
+}
+
+// NewRegistry creates a Registry which reads schemas from the packages
+// provided by the loader.
+func NewRegistry(loader PackageLoader) *Registry {
+	return &Registry{
+		loader: loader,
+		cache:  map[string]map[string]*registeredSchema{},
+	}
+}
+
+// SchemasFor returns the raw event payload schemas registered by the
+// package, keyed by event name. The map is empty when the package does not
+// register any schemas.
+func (r *Registry) SchemasFor(packageID string) (map[string]json.RawMessage, error) {
+	schemas, err := r.schemasFor(packageID)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]json.RawMessage{}
+	for eventName, schema := range schemas {
+		raw[eventName] = schema.raw
+	}
+	return raw, nil
+}
+
+// Validate checks an event payload against the schema the package registered
+// for the event. Events without a registered schema are always valid.
+func (r *Registry) Validate(packageID, eventName string, payload []byte) error {
+	schemas, err := r.schemasFor(packageID)
+	if err != nil {
+		return err
+	}
+
+	schema, ok := schemas[eventName]
+	if !ok {
+		return nil
+	}
+	return schema.parsed.Validate(payload)
+}
+
+// InstalledSchemas returns the names of the events with a registered schema
+// for each installed package that registers any, keyed by package ID.
+func (r *Registry) InstalledSchemas() (map[string][]string, error) {
+	installed, err := r.loader.ListInstalledChaincodes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not list installed chaincodes")
+	}
+
+	events := map[string][]string{}
+	for _, cc := range installed {
+		schemas, err := r.schemasFor(cc.PackageID)
+		if err != nil {
+			return nil, err
+		}
+		if len(schemas) == 0 {
+			continue
+		}
+
+		eventNames := make([]string, 0, len(schemas))
+		for eventName := range schemas {
+			eventNames = append(eventNames, eventName)
+		}
+		sort.Strings(eventNames)
+		events[cc.PackageID] = eventNames
+	}
+
+	return events, nil
+}
+
+func (r *Registry) schemasFor(packageID string) (map[string]*registeredSchema, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if schemas, ok := r.cache[packageID]; ok {
+		return schemas, nil
+	}
+
+	pkgBytes, err := r.loader.Load(packageID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not load chaincode package %s", packageID)
+	}
+
+	metadata, err := packageMetadata(pkgBytes)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not read metadata of chaincode package %s", packageID)
+	}
+
+	schemas := map[string]*registeredSchema{}
+	for eventName, schemaBytes := range metadata.EventSchemas {
+		parsed, err := Parse(schemaBytes)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid schema for event '%s' in chaincode package %s", eventName, packageID)
+		}
+		schemas[eventName] = &registeredSchema{raw: schemaBytes, parsed: parsed}
+	}
+
+	r.cache[packageID] = schemas
+	return schemas, nil
+}
+
+// packageMetadata extracts the package metadata from the package tar without
+// parsing the embedded code package.
+func packageMetadata(pkgBytes []byte) (*persistence.ChaincodePackageMetadata, error) {
+	gzReader, err := gzip.NewReader(bytes.NewBuffer(pkgBytes))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading as gzip stream")
+	}
+
+	tarReader := tar.NewReader(gzReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error inspecting next tar header")
+		}
+
+		if header.Name != persistence.MetadataFile {
+			continue
+		}
+
+		metadataBytes, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s from tar", persistence.MetadataFile)
+		}
+
+		metadata := &persistence.ChaincodePackageMetadata{}
+		if err := json.Unmarshal(metadataBytes, metadata); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal %s as json", persistence.MetadataFile)
+		}
+		return metadata, nil
+	}
+
+	return nil, errors.Errorf("%s not found in package", persistence.MetadataFile)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventschema
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLoader struct {
+	packages  map[string][]byte
+	installed []chaincode.InstalledChaincode
+	listErr   error
+	loadCount int
+}
+
+func (f *fakeLoader) Load(packageID string) ([]byte, error) {
+	f.loadCount++
+	pkgBytes, ok := f.packages[packageID]
+	if !ok {
+		return nil, errors.Errorf("could not find chaincode with package id '%s'", packageID)
+	}
+	return pkgBytes, nil
+}
+
+func (f *fakeLoader) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error) {
+	return f.installed, f.listErr
+}
+
+func testPackage(t *testing.T, metadata *persistence.ChaincodePackageMetadata) []byte {
+	metadataBytes, err := json.Marshal(metadata)
+	require.NoError(t, err)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, fileBytes := range map[string][]byte{
+		persistence.MetadataFile:    metadataBytes,
+		persistence.CodePackageFile: []byte("code"),
+	} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(fileBytes)), Mode: 0100644}))
+		_, err := tw.Write(fileBytes)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	return payload.Bytes()
+}
+
+func TestRegistrySchemasFor(t *testing.T) {
+	assetSchema := json.RawMessage(`{"type":"object","required":["asset"]}`)
+	loader := &fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:         "golang",
+				Label:        "mycc",
+				EventSchemas: map[string]json.RawMessage{"transfer": assetSchema},
+			}),
+			"plaincc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:  "golang",
+				Label: "plaincc",
+			}),
+		},
+	}
+	registry := NewRegistry(loader)
+
+	schemas, err := registry.SchemasFor("mycc:hash")
+	require.NoError(t, err)
+	require.Equal(t, map[string]json.RawMessage{"transfer": assetSchema}, schemas)
+
+	schemas, err = registry.SchemasFor("plaincc:hash")
+	require.NoError(t, err)
+	require.Empty(t, schemas)
+
+	_, err = registry.SchemasFor("missing:hash")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not load chaincode package missing:hash")
+}
+
+func TestRegistryCachesPackages(t *testing.T) {
+	loader := &fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:         "golang",
+				Label:        "mycc",
+				EventSchemas: map[string]json.RawMessage{"transfer": json.RawMessage(`{"type":"object"}`)},
+			}),
+		},
+	}
+	registry := NewRegistry(loader)
+
+	for i := 0; i < 3; i++ {
+		_, err := registry.SchemasFor("mycc:hash")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, loader.loadCount)
+}
+
+func TestRegistryValidate(t *testing.T) {
+	loader := &fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:         "golang",
+				Label:        "mycc",
+				EventSchemas: map[string]json.RawMessage{"transfer": json.RawMessage(`{"type":"object","required":["asset"]}`)},
+			}),
+		},
+	}
+	registry := NewRegistry(loader)
+
+	require.NoError(t, registry.Validate("mycc:hash", "transfer", []byte(`{"asset":"marble"}`)))
+	require.NoError(t, registry.Validate("mycc:hash", "unregistered-event", []byte(`not even json`)))
+
+	err := registry.Validate("mycc:hash", "transfer", []byte(`{}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "$: missing required property 'asset'")
+}
+
+func TestRegistryRejectsInvalidSchema(t *testing.T) {
+	loader := &fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:         "golang",
+				Label:        "mycc",
+				EventSchemas: map[string]json.RawMessage{"transfer": json.RawMessage(`{"type":"text"}`)},
+			}),
+		},
+	}
+
+	_, err := NewRegistry(loader).SchemasFor("mycc:hash")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid schema for event 'transfer' in chaincode package mycc:hash")
+}
+
+func TestRegistryInstalledSchemas(t *testing.T) {
+	loader := &fakeLoader{
+		packages: map[string][]byte{
+			"mycc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:  "golang",
+				Label: "mycc",
+				EventSchemas: map[string]json.RawMessage{
+					"transfer": json.RawMessage(`{"type":"object"}`),
+					"burn":     json.RawMessage(`{"type":"object"}`),
+				},
+			}),
+			"plaincc:hash": testPackage(t, &persistence.ChaincodePackageMetadata{
+				Type:  "golang",
+				Label: "plaincc",
+			}),
+		},
+		installed: []chaincode.InstalledChaincode{
+			{PackageID: "mycc:hash"},
+			{PackageID: "plaincc:hash"},
+		},
+	}
+
+	installed, err := NewRegistry(loader).InstalledSchemas()
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"mycc:hash": {"burn", "transfer"}}, installed)
+}
+
+func TestRegistryInstalledSchemasListError(t *testing.T) {
+	loader := &fakeLoader{listErr: errors.New("scone")}
+
+	_, err := NewRegistry(loader).InstalledSchemas()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not list installed chaincodes: scone")
+}
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package eventschema implements registration, discovery, and validation of
+// chaincode event payload schemas. Schemas are registered by embedding them
+// in the metadata of the chaincode package at packaging time; once the
+// package is installed, the peer can validate emitted event payloads against
+// them and event consumers can discover them instead of reverse-engineering
+// payload formats.
+package eventschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// Schema is a parsed chaincode event payload schema. It supports the subset
+// of JSON Schema required to describe event payloads: the "type",
+// "properties", "required", and "items" keywords. Keywords outside of the
+// subset are rejected at parse time so that a schema never silently
+// validates less than its author intended.
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+}
+
+// jsonTypes holds the type names permitted by the "type" keyword. An empty
+// type imposes no constraint on the value.
+var jsonTypes = map[string]bool{
+	"":        true,
+	"object":  true,
+	"array":   true,
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"null":    true,
+}
+
+// Parse parses the provided bytes as an event payload schema.
+func Parse(schemaBytes []byte) (*Schema, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(schemaBytes, &raw); err != nil {
+		return nil, errors.Wrap(err, "schema is not a JSON object")
+	}
+	for keyword := range raw {
+		switch keyword {
+		case "type", "properties", "required", "items":
+		default:
+			return nil, errors.Errorf("unsupported schema keyword '%s'", keyword)
+		}
+	}
+
+	schema := &Schema{}
+	if err := json.Unmarshal(schemaBytes, schema); err != nil {
+		return nil, errors.Wrap(err, "could not parse schema")
+	}
+	if err := schema.check("$"); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// check verifies that the schema and its nested schemas only use supported
+// type names.
+func (s *Schema) check(path string) error {
+	if !jsonTypes[s.Type] {
+		return errors.Errorf("%s: unsupported type '%s'", path, s.Type)
+	}
+	for name, property := range s.Properties {
+		if err := property.check(path + "." + name); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := s.Items.check(path + "[]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks the provided event payload against the schema.
+func (s *Schema) Validate(payload []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return errors.Wrap(err, "payload is not valid JSON")
+	}
+	return s.validate(value, "$")
+}
+
+func (s *Schema) validate(value interface{}, path string) error {
+	switch s.Type {
+	case "":
+		// no type constraint
+
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("%s: expected object, got %s", path, jsonTypeOf(value))
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				return errors.Errorf("%s: missing required property '%s'", path, name)
+			}
+		}
+		for name, property := range s.Properties {
+			if propertyValue, ok := object[name]; ok {
+				if err := property.validate(propertyValue, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return errors.Errorf("%s: expected array, got %s", path, jsonTypeOf(value))
+		}
+		if s.Items != nil {
+			for i, item := range array {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+
+	case "string":
+		if _, ok := value.(string); !ok {
+			return errors.Errorf("%s: expected string, got %s", path, jsonTypeOf(value))
+		}
+
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return errors.Errorf("%s: expected number, got %s", path, jsonTypeOf(value))
+		}
+
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			return errors.Errorf("%s: expected integer, got %s", path, jsonTypeOf(value))
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return errors.Errorf("%s: expected boolean, got %s", path, jsonTypeOf(value))
+		}
+
+	case "null":
+		if value != nil {
+			return errors.Errorf("%s: expected null, got %s", path, jsonTypeOf(value))
+		}
+	}
+
+	return nil
+}
+
+// jsonTypeOf names the JSON type of an unmarshaled value for error messages.
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
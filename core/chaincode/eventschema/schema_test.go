@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eventschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["asset"],
+		"properties": {
+			"asset": {"type": "string"},
+			"owners": {"type": "array", "items": {"type": "string"}}
+		}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, "object", schema.Type)
+	require.Equal(t, []string{"asset"}, schema.Required)
+	require.Equal(t, "string", schema.Properties["asset"].Type)
+	require.Equal(t, "string", schema.Properties["owners"].Items.Type)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		error  string
+	}{
+		{"not an object", `["type"]`, "schema is not a JSON object"},
+		{"unsupported keyword", `{"type": "string", "minLength": 3}`, "unsupported schema keyword 'minLength'"},
+		{"unsupported type", `{"type": "text"}`, "$: unsupported type 'text'"},
+		{"unsupported nested type", `{"type": "object", "properties": {"a": {"type": "text"}}}`, "$.a: unsupported type 'text'"},
+		{"unsupported items type", `{"type": "array", "items": {"type": "text"}}`, "$[]: unsupported type 'text'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.schema))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["asset", "quantity"],
+		"properties": {
+			"asset": {"type": "string"},
+			"quantity": {"type": "integer"},
+			"price": {"type": "number"},
+			"settled": {"type": "boolean"},
+			"memo": {"type": "null"},
+			"owners": {"type": "array", "items": {"type": "string"}},
+			"anything": {}
+		}
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.Validate([]byte(`{"asset": "marble", "quantity": 3}`)))
+	require.NoError(t, schema.Validate([]byte(`{
+		"asset": "marble",
+		"quantity": 3,
+		"price": 1.5,
+		"settled": true,
+		"memo": null,
+		"owners": ["alice", "bob"],
+		"anything": [42],
+		"unconstrained": "extra properties are allowed"
+	}`)))
+
+	tests := []struct {
+		name    string
+		payload string
+		error   string
+	}{
+		{"not json", `marble`, "payload is not valid JSON"},
+		{"not an object", `"marble"`, "$: expected object, got string"},
+		{"missing required", `{"asset": "marble"}`, "$: missing required property 'quantity'"},
+		{"wrong string", `{"asset": 7, "quantity": 3}`, "$.asset: expected string, got number"},
+		{"wrong integer", `{"asset": "marble", "quantity": 3.5}`, "$.quantity: expected integer, got number"},
+		{"wrong number", `{"asset": "marble", "quantity": 3, "price": "1.5"}`, "$.price: expected number, got string"},
+		{"wrong boolean", `{"asset": "marble", "quantity": 3, "settled": "yes"}`, "$.settled: expected boolean, got string"},
+		{"wrong null", `{"asset": "marble", "quantity": 3, "memo": "note"}`, "$.memo: expected null, got string"},
+		{"wrong array", `{"asset": "marble", "quantity": 3, "owners": "alice"}`, "$.owners: expected array, got string"},
+		{"wrong array item", `{"asset": "marble", "quantity": 3, "owners": ["alice", 7]}`, "$.owners[1]: expected string, got number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate([]byte(tt.payload))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
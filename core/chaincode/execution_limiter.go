@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/pkg/errors"
+)
+
+// ExecutionLimiter caps the number of transactions the peer will execute
+// concurrently on each chaincode. Invocations over the cap fail immediately
+// rather than queue, so a runaway chaincode cannot tie up every endorsement
+// thread on the peer.
+type ExecutionLimiter struct {
+	defaultLimit int
+	overrides    map[string]int
+
+	mutex      sync.Mutex
+	semaphores map[string]semaphore.Semaphore
+}
+
+// NewExecutionLimiter creates an ExecutionLimiter from the configured
+// resource limits. A limit of 0 leaves the concurrency of the chaincode
+// unlimited.
+func NewExecutionLimiter(config *Config) *ExecutionLimiter {
+	overrides := map[string]int{}
+	for name, limits := range config.ResourceLimitsOverrides {
+		overrides[name] = limits.MaxConcurrency
+	}
+
+	return &ExecutionLimiter{
+		defaultLimit: config.ResourceLimitsDefault.MaxConcurrency,
+		overrides:    overrides,
+		semaphores:   map[string]semaphore.Semaphore{},
+	}
+}
+
+// TryAcquire obtains an execution slot for the named chaincode and returns
+// the function which releases it. An error is returned if the chaincode has
+// already reached its concurrency limit.
+func (el *ExecutionLimiter) TryAcquire(chaincodeName string) (release func(), err error) {
+	limit := el.limitFor(chaincodeName)
+	if limit <= 0 {
+		return func() {}, nil
+	}
+
+	el.mutex.Lock()
+	sem, ok := el.semaphores[chaincodeName]
+	if !ok {
+		sem = semaphore.New(limit)
+		el.semaphores[chaincodeName] = sem
+	}
+	el.mutex.Unlock()
+
+	if !sem.TryAcquire() {
+		return nil, errors.Errorf("chaincode %s has reached its limit of %d concurrently executing transactions", chaincodeName, limit)
+	}
+
+	return sem.Release, nil
+}
+
+func (el *ExecutionLimiter) limitFor(chaincodeName string) int {
+	if limit, ok := el.overrides[chaincodeName]; ok {
+		return limit
+	}
+	return el.defaultLimit
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExecutionLimiter", func() {
+	var limiter *chaincode.ExecutionLimiter
+
+	BeforeEach(func() {
+		limiter = chaincode.NewExecutionLimiter(&chaincode.Config{
+			ResourceLimitsDefault: chaincode.ResourceLimits{MaxConcurrency: 2},
+			ResourceLimitsOverrides: map[string]chaincode.ResourceLimits{
+				"limited-cc":   {MaxConcurrency: 1},
+				"unlimited-cc": {},
+			},
+		})
+	})
+
+	It("caps the number of concurrent executions per chaincode", func() {
+		release, err := limiter.TryAcquire("limited-cc")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = limiter.TryAcquire("limited-cc")
+		Expect(err).To(MatchError("chaincode limited-cc has reached its limit of 1 concurrently executing transactions"))
+
+		release()
+		release, err = limiter.TryAcquire("limited-cc")
+		Expect(err).NotTo(HaveOccurred())
+		release()
+	})
+
+	It("applies the default limit to chaincodes without an override", func() {
+		for i := 0; i < 2; i++ {
+			_, err := limiter.TryAcquire("some-cc")
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := limiter.TryAcquire("some-cc")
+		Expect(err).To(MatchError("chaincode some-cc has reached its limit of 2 concurrently executing transactions"))
+	})
+
+	It("tracks each chaincode independently", func() {
+		_, err := limiter.TryAcquire("limited-cc")
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = limiter.TryAcquire("another-limited-cc")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("does not limit chaincodes with a zero concurrency limit", func() {
+		for i := 0; i < 100; i++ {
+			release, err := limiter.TryAcquire("unlimited-cc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(release).NotTo(BeNil())
+		}
+	})
+})
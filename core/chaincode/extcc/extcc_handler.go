@@ -8,6 +8,7 @@ package extcc
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/comm"
@@ -15,59 +16,112 @@ import (
 	"github.com/pkg/errors"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
-
-	"google.golang.org/grpc"
 )
 
 var extccLogger = flogging.MustGetLogger("extcc")
 
+const (
+	// DefaultRetryAttempts is the number of times a connection to a chaincode
+	// server is attempted before the launch is given up on.
+	DefaultRetryAttempts = 5
+
+	// DefaultRetryWaitTime is the time to wait before the first reconnection
+	// attempt to a chaincode server. The wait time doubles after every failed
+	// attempt.
+	DefaultRetryWaitTime = 2 * time.Second
+)
+
 // StreamHandler handles the `Chaincode` gRPC service with peer as client
 type StreamHandler interface {
 	HandleChaincodeStream(stream ccintf.ChaincodeStream) error
 }
 
 type ExternalChaincodeRuntime struct {
+	// Metrics records failed connection attempts and lost connections to
+	// chaincode servers.
+	Metrics *Metrics
+	// RetryAttempts is the number of connection attempts made before giving
+	// up on an unreachable chaincode server. Zero selects
+	// DefaultRetryAttempts.
+	RetryAttempts int
+	// RetryWaitTime is the wait before the second connection attempt; it
+	// doubles after every failed attempt. Zero selects DefaultRetryWaitTime.
+	RetryWaitTime time.Duration
 }
 
-// createConnection - standard grpc client creating using ClientConfig info (surprised there isn't
-// a helper method for this)
-func (i *ExternalChaincodeRuntime) createConnection(ccid string, ccinfo *ccintf.ChaincodeServerInfo) (*grpc.ClientConn, error) {
-	grpcClient, err := comm.NewGRPCClient(ccinfo.ClientConfig)
-	if err != nil {
-		return nil, errors.WithMessagef(err, "error creating grpc client to %s", ccid)
+// Stream connects to the chaincode server described by ccinfo and hands the
+// resulting stream to sHandler. Connection attempts that fail are retried
+// with an exponentially growing wait time so that a chaincode server that is
+// restarting does not immediately fail the launch. Once a stream has been
+// established its termination is final; the launch registry deregisters the
+// chaincode and the next invocation triggers a fresh launch.
+func (i *ExternalChaincodeRuntime) Stream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler StreamHandler) error {
+	extccLogger.Debugf("Starting external chaincode connection: %s", ccid)
+
+	clientConfig := ccinfo.ClientConfig
+	// keepalive pings let the peer detect a dead chaincode server even when
+	// no requests are in flight
+	if clientConfig.KaOpts == (comm.KeepaliveOptions{}) {
+		clientConfig.KaOpts = comm.DefaultKeepaliveOptions
 	}
 
-	conn, err := grpcClient.NewConnection(ccinfo.Address)
+	grpcClient, err := comm.NewGRPCClient(clientConfig)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "error creating grpc connection to %s", ccinfo.Address)
+		return errors.WithMessagef(err, "error creating grpc client to %s", ccid)
 	}
 
-	extccLogger.Debugf("Created external chaincode connection: %s", ccid)
+	attempts := i.RetryAttempts
+	if attempts <= 0 {
+		attempts = DefaultRetryAttempts
+	}
+	waitTime := i.RetryWaitTime
+	if waitTime <= 0 {
+		waitTime = DefaultRetryWaitTime
+	}
+
+	for attempt := 1; ; attempt++ {
+		connected, err := i.runStream(ccid, ccinfo, grpcClient, sHandler)
+		if connected || err == nil {
+			return err
+		}
 
-	return conn, nil
+		i.Metrics.ConnectionFailures.With("chaincode", ccid).Add(1)
+		if attempt == attempts {
+			return errors.WithMessagef(err, "chaincode server %s at %s is unreachable after %d connection attempts", ccid, ccinfo.Address, attempts)
+		}
+
+		extccLogger.Warningf("Connection attempt %d to chaincode server %s at %s failed, retrying in %s: %s", attempt, ccid, ccinfo.Address, waitTime, err)
+		time.Sleep(waitTime)
+		waitTime *= 2
+	}
 }
 
-func (i *ExternalChaincodeRuntime) Stream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, sHandler StreamHandler) error {
-	extccLogger.Debugf("Starting external chaincode connection: %s", ccid)
-	conn, err := i.createConnection(ccid, ccinfo)
+// runStream makes a single connection attempt and, if it succeeds, handles
+// the stream until the chaincode server goes away. It reports whether a
+// stream was established.
+func (i *ExternalChaincodeRuntime) runStream(ccid string, ccinfo *ccintf.ChaincodeServerInfo, grpcClient *comm.GRPCClient, sHandler StreamHandler) (bool, error) {
+	conn, err := grpcClient.NewConnection(ccinfo.Address)
 	if err != nil {
-		return errors.WithMessagef(err, "error cannot create connection for %s", ccid)
+		return false, errors.WithMessagef(err, "error creating grpc connection to %s", ccinfo.Address)
 	}
 
 	defer conn.Close()
 
+	extccLogger.Debugf("Created external chaincode connection: %s", ccid)
+
 	//create the client and start streaming
 	client := pb.NewChaincodeClient(conn)
 
 	stream, err := client.Connect(context.Background())
 	if err != nil {
-		return errors.WithMessagef(err, "error creating grpc client connection to %s", ccid)
+		return false, errors.WithMessagef(err, "error creating grpc client connection to %s", ccid)
 	}
 
 	//peer as client has to initiate the stream. Rest of the process is unchanged
 	sHandler.HandleChaincodeStream(stream)
 
+	i.Metrics.Disconnects.With("chaincode", ccid).Add(1)
 	extccLogger.Debugf("External chaincode %s client exited", ccid)
 
-	return nil
+	return true, nil
 }
@@ -10,6 +10,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/extcc/mock"
 	"github.com/hyperledger/fabric/core/comm"
@@ -23,13 +24,26 @@ import (
 
 var _ = Describe("Extcc", func() {
 	var (
-		i        *extcc.ExternalChaincodeRuntime
-		shandler *mock.StreamHandler
+		i                      *extcc.ExternalChaincodeRuntime
+		shandler               *mock.StreamHandler
+		fakeConnectionFailures *metricsfakes.Counter
+		fakeDisconnects        *metricsfakes.Counter
 	)
 
 	BeforeEach(func() {
 		shandler = &mock.StreamHandler{}
-		i = &extcc.ExternalChaincodeRuntime{}
+		fakeConnectionFailures = &metricsfakes.Counter{}
+		fakeConnectionFailures.WithReturns(fakeConnectionFailures)
+		fakeDisconnects = &metricsfakes.Counter{}
+		fakeDisconnects.WithReturns(fakeDisconnects)
+		i = &extcc.ExternalChaincodeRuntime{
+			Metrics: &extcc.Metrics{
+				ConnectionFailures: fakeConnectionFailures,
+				Disconnects:        fakeDisconnects,
+			},
+			RetryAttempts: 2,
+			RetryWaitTime: 10 * time.Millisecond,
+		}
 	})
 
 	Context("Run", func() {
@@ -70,6 +84,27 @@ var _ = Describe("Extcc", func() {
 
 				streamArg := shandler.HandleChaincodeStreamArgsForCall(0)
 				Expect(streamArg).To(Not(BeNil()))
+
+				Expect(fakeConnectionFailures.AddCallCount()).To(Equal(0))
+				Expect(fakeDisconnects.AddCallCount()).To(Equal(1))
+				Expect(fakeDisconnects.WithArgsForCall(0)).To(Equal([]string{"chaincode", "ccid"}))
+			})
+		})
+		When("chaincode server is unreachable", func() {
+			It("retries before giving up and records the failed attempts", func() {
+				ccinfo := &ccintf.ChaincodeServerInfo{
+					Address: "127.0.0.1:1",
+					ClientConfig: comm.ClientConfig{
+						Timeout: 100 * time.Millisecond,
+					},
+				}
+				err := i.Stream("ccid", ccinfo, shandler)
+				Expect(err).To(MatchError(ContainSubstring("chaincode server ccid at 127.0.0.1:1 is unreachable after 2 connection attempts")))
+				Expect(shandler.HandleChaincodeStreamCallCount()).To(Equal(0))
+
+				Expect(fakeConnectionFailures.AddCallCount()).To(Equal(2))
+				Expect(fakeConnectionFailures.WithArgsForCall(0)).To(Equal([]string{"chaincode", "ccid"}))
+				Expect(fakeDisconnects.AddCallCount()).To(Equal(0))
 			})
 		})
 		Context("chaincode info incorrect", func() {
@@ -105,9 +140,10 @@ var _ = Describe("Extcc", func() {
 				BeforeEach(func() {
 					ccinfo.ClientConfig.SecOpts.Key = nil
 				})
-				It("returns an error", func() {
+				It("returns an error without retrying", func() {
 					err := i.Stream("ccid", ccinfo, shandler)
 					Expect(err).To(MatchError(ContainSubstring("both Key and Certificate are required when using mutual TLS")))
+					Expect(fakeConnectionFailures.AddCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package extcc
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	serverConnectionFailures = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "server_connection_failures",
+		Help:         "The number of failed connection attempts to chaincode servers.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+	serverDisconnects = metrics.CounterOpts{
+		Namespace:    "chaincode",
+		Name:         "server_disconnects",
+		Help:         "The number of times an established connection to a chaincode server was lost.",
+		LabelNames:   []string{"chaincode"},
+		StatsdFormat: "%{#fqname}.%{chaincode}",
+	}
+)
+
+// Metrics tracks the health of the connections the peer maintains to
+// chaincode servers.
+type Metrics struct {
+	ConnectionFailures metrics.Counter
+	Disconnects        metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		ConnectionFailures: p.NewCounter(serverConnectionFailures),
+		Disconnects:        p.NewCounter(serverDisconnects),
+	}
+}
@@ -123,6 +123,9 @@ type Handler struct {
 	AppConfig ApplicationConfigRetriever
 	// Metrics holds chaincode handler metrics
 	Metrics *HandlerMetrics
+	// CallTracer records cross-chaincode invocations. It may be nil when
+	// call tracing is not wired up, as in tests.
+	CallTracer *CallTracer
 
 	// state holds the current handler state. It will be created, established, or
 	// ready.
@@ -130,11 +133,16 @@ type Handler struct {
 	// chaincodeID holds the ID of the chaincode that registered with the peer.
 	chaincodeID string
 
-	// serialLock is used to serialize sends across the grpc chat stream.
+	// serialLock is used to serialize sends across the grpc chat stream when
+	// no multiplexer is running.
 	serialLock sync.Mutex
 	// chatStream is the bidirectional grpc stream used to communicate with the
 	// chaincode instance.
 	chatStream ccintf.ChaincodeStream
+	// mux multiplexes the sends of concurrent transactions over the chat
+	// stream. It is created by ProcessStream and owns all writes to the
+	// stream while the stream is being processed.
+	mux *StreamMux
 	// errChan is used to communicate errors from the async send to the receive loop
 	errChan chan error
 	// mutex is used to serialze the stream closed chan.
@@ -286,10 +294,7 @@ func ParseName(ccName string) *sysccprovider.ChaincodeInstance {
 
 // serialSend serializes msgs so gRPC will be happy
 func (h *Handler) serialSend(msg *pb.ChaincodeMessage) error {
-	h.serialLock.Lock()
-	defer h.serialLock.Unlock()
-
-	if err := h.chatStream.Send(msg); err != nil {
+	if err := h.sendToStream(msg); err != nil {
 		err = errors.WithMessagef(err, "[%s] error sending %s", shorttxid(msg.Txid), msg.Type)
 		chaincodeLogger.Errorf("%+v", err)
 		return err
@@ -298,6 +303,18 @@ func (h *Handler) serialSend(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
+// sendToStream hands msg to the stream multiplexer when one is running and
+// falls back to a mutex-serialized direct send otherwise.
+func (h *Handler) sendToStream(msg *pb.ChaincodeMessage) error {
+	if h.mux != nil {
+		return h.mux.Send(msg)
+	}
+
+	h.serialLock.Lock()
+	defer h.serialLock.Unlock()
+	return h.chatStream.Send(msg)
+}
+
 // serialSendAsync serves the same purpose as serialSend (serialize msgs so gRPC will
 // be happy). In addition, it is also asynchronous so send-remoterecv--localrecv loop
 // can be nonblocking. Only errors need to be handled and these are handled by
@@ -363,6 +380,10 @@ func (h *Handler) ProcessStream(stream ccintf.ChaincodeStream) error {
 	h.chatStream = stream
 	h.errChan = make(chan error, 1)
 
+	h.mux = NewStreamMux(stream, defaultSendWindow)
+	go h.mux.Run()
+	defer h.mux.Close()
+
 	var keepaliveCh <-chan time.Time
 	if h.Keepalive != 0 {
 		ticker := time.NewTicker(h.Keepalive)
@@ -1171,7 +1192,17 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 	}
 
 	// Execute the chaincode... this CANNOT be an init at least for now
+	startTime := time.Now()
 	responseMessage, err := h.Invoker.Invoke(txParams, targetInstance.ChaincodeName, chaincodeSpec.Input)
+	if h.CallTracer != nil {
+		h.CallTracer.RecordCall(msg.Txid, &CallRecord{
+			Caller:    txContext.NamespaceID,
+			Callee:    targetInstance.ChaincodeName,
+			ChannelID: targetInstance.ChannelID,
+			Latency:   time.Since(startTime),
+			Success:   err == nil && responseMessage != nil && responseMessage.Type == pb.ChaincodeMessage_COMPLETED,
+		})
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "execute failed")
 	}
@@ -27,3 +27,9 @@ func StreamDone(h *Handler) <-chan struct{} {
 func SetStreamDoneChan(h *Handler, ch chan struct{}) {
 	h.streamDoneChan = ch
 }
+
+func MuxQueueDepth(m *StreamMux, txid string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return len(m.queues[txid])
+}
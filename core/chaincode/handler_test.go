@@ -2181,6 +2181,57 @@ var _ = Describe("Handler", func() {
 			Expect(proposal).To(Equal(expectedSignedProp))
 		})
 
+		Context("when a call tracer is set", func() {
+			BeforeEach(func() {
+				handler.CallTracer = chaincode.NewCallTracer(10)
+				responseMessage = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED}
+				fakeInvoker.InvokeReturns(responseMessage, nil)
+			})
+
+			It("records the invocation on the transaction's call trace", func() {
+				_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				trace := handler.CallTracer.TransactionTrace("tx-id")
+				Expect(trace).To(HaveLen(1))
+				Expect(trace[0].Caller).To(Equal("cc-instance-name"))
+				Expect(trace[0].Callee).To(Equal("target-chaincode-name"))
+				Expect(trace[0].ChannelID).To(Equal("channel-id"))
+				Expect(trace[0].Latency).To(BeNumerically(">=", 0))
+				Expect(trace[0].Success).To(BeTrue())
+			})
+
+			Context("when the target chaincode completes with an error", func() {
+				BeforeEach(func() {
+					fakeInvoker.InvokeReturns(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR}, nil)
+				})
+
+				It("records the invocation as unsuccessful", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+
+					trace := handler.CallTracer.TransactionTrace("tx-id")
+					Expect(trace).To(HaveLen(1))
+					Expect(trace[0].Success).To(BeFalse())
+				})
+			})
+
+			Context("when the target chaincode fails to execute", func() {
+				BeforeEach(func() {
+					fakeInvoker.InvokeReturns(nil, errors.New("lemon-curd"))
+				})
+
+				It("records the invocation as unsuccessful", func() {
+					_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+					Expect(err).To(MatchError("execute failed: lemon-curd"))
+
+					trace := handler.CallTracer.TransactionTrace("tx-id")
+					Expect(trace).To(HaveLen(1))
+					Expect(trace[0].Success).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when the target channel is different from the context", func() {
 			BeforeEach(func() {
 				request = &pb.ChaincodeSpec{
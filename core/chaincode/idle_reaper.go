@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultIdleTimeout = 5 * time.Minute
+
+// IdleReaper implements the on-demand chaincode execution mode. Chaincodes
+// are launched when their first invocation arrives, as they always are, and
+// are stopped again once they have been idle for the configured timeout, so
+// a peer hosting many rarely used chaincodes does not keep a warm container
+// for each of them. A stopped chaincode is relaunched by the next
+// invocation; an invocation that arrives while a stop is in flight may fail
+// and needs to be resubmitted.
+type IdleReaper struct {
+	launcher    Launcher
+	idleTimeout time.Duration
+
+	mutex   sync.Mutex
+	halted  bool
+	entries map[string]*idleEntry
+}
+
+// idleEntry tracks the in-flight invocations of a running chaincode. The
+// timer is armed while no invocations are in flight and stops the chaincode
+// when it fires.
+type idleEntry struct {
+	inflight int
+	timer    *time.Timer
+}
+
+// NewIdleReaper creates an IdleReaper that stops chaincodes with the
+// launcher after they have been idle for the provided timeout. A timeout
+// less than or equal to zero falls back to the default of five minutes.
+func NewIdleReaper(launcher Launcher, idleTimeout time.Duration) *IdleReaper {
+	if idleTimeout <= 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+	return &IdleReaper{
+		launcher:    launcher,
+		idleTimeout: idleTimeout,
+		entries:     map[string]*idleEntry{},
+	}
+}
+
+// TransactionStarted records the start of an invocation of the chaincode and
+// cancels its pending stop, if any.
+func (r *IdleReaper) TransactionStarted(ccid string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := r.entries[ccid]
+	if entry == nil {
+		entry = &idleEntry{}
+		r.entries[ccid] = entry
+	}
+	entry.inflight++
+	if entry.timer != nil {
+		entry.timer.Stop()
+		entry.timer = nil
+	}
+}
+
+// TransactionCompleted records the completion of an invocation of the
+// chaincode. When the last in-flight invocation completes, the idle timer
+// is armed.
+func (r *IdleReaper) TransactionCompleted(ccid string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := r.entries[ccid]
+	if entry == nil {
+		return
+	}
+	entry.inflight--
+	if entry.inflight > 0 || r.halted {
+		return
+	}
+	entry.timer = time.AfterFunc(r.idleTimeout, func() { r.reap(ccid) })
+}
+
+func (r *IdleReaper) reap(ccid string) {
+	r.mutex.Lock()
+	entry := r.entries[ccid]
+	if entry == nil || entry.inflight > 0 || r.halted {
+		r.mutex.Unlock()
+		return
+	}
+	delete(r.entries, ccid)
+	r.mutex.Unlock()
+
+	chaincodeLogger.Debugf("stopping chaincode %s after %s of inactivity", ccid, r.idleTimeout)
+	if err := r.launcher.Stop(ccid); err != nil {
+		chaincodeLogger.Warningf("could not stop idle chaincode %s: %s", ccid, err)
+	}
+}
+
+// Halt cancels the pending stops of all idle chaincodes and prevents new
+// ones from being scheduled. It is used at peer shutdown.
+func (r *IdleReaper) Halt() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.halted = true
+	for _, entry := range r.entries {
+		if entry.timer != nil {
+			entry.timer.Stop()
+			entry.timer = nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("IdleReaper", func() {
+	var (
+		fakeLauncher *mock.Launcher
+		reaper       *chaincode.IdleReaper
+	)
+
+	BeforeEach(func() {
+		fakeLauncher = &mock.Launcher{}
+		reaper = chaincode.NewIdleReaper(fakeLauncher, 50*time.Millisecond)
+	})
+
+	AfterEach(func() {
+		reaper.Halt()
+	})
+
+	It("stops a chaincode once it has been idle for the timeout", func() {
+		reaper.TransactionStarted("idle-cc")
+		reaper.TransactionCompleted("idle-cc")
+
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(1))
+		Expect(fakeLauncher.StopArgsForCall(0)).To(Equal("idle-cc"))
+	})
+
+	It("does not stop a chaincode with an in-flight invocation", func() {
+		reaper.TransactionStarted("busy-cc")
+		reaper.TransactionStarted("busy-cc")
+		reaper.TransactionCompleted("busy-cc")
+
+		Consistently(fakeLauncher.StopCallCount, "150ms").Should(Equal(0))
+	})
+
+	It("resets the idle timer when a new invocation arrives", func() {
+		reaper.TransactionStarted("busy-cc")
+		reaper.TransactionCompleted("busy-cc")
+		reaper.TransactionStarted("busy-cc")
+
+		Consistently(fakeLauncher.StopCallCount, "150ms").Should(Equal(0))
+
+		reaper.TransactionCompleted("busy-cc")
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(1))
+	})
+
+	It("tracks each chaincode independently", func() {
+		reaper.TransactionStarted("idle-cc")
+		reaper.TransactionCompleted("idle-cc")
+		reaper.TransactionStarted("busy-cc")
+
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(1))
+		Consistently(fakeLauncher.StopCallCount, "150ms").Should(Equal(1))
+		Expect(fakeLauncher.StopArgsForCall(0)).To(Equal("idle-cc"))
+	})
+
+	It("stops a reaped chaincode again after it is reused", func() {
+		reaper.TransactionStarted("idle-cc")
+		reaper.TransactionCompleted("idle-cc")
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(1))
+
+		reaper.TransactionStarted("idle-cc")
+		reaper.TransactionCompleted("idle-cc")
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(2))
+	})
+
+	It("keeps reaping when stopping a chaincode fails", func() {
+		fakeLauncher.StopReturns(errors.New("pound cake"))
+
+		reaper.TransactionStarted("idle-cc")
+		reaper.TransactionCompleted("idle-cc")
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(1))
+
+		reaper.TransactionStarted("other-cc")
+		reaper.TransactionCompleted("other-cc")
+		Eventually(fakeLauncher.StopCallCount).Should(Equal(2))
+	})
+
+	Context("when the reaper has been halted", func() {
+		It("does not stop idle chaincodes", func() {
+			reaper.TransactionStarted("idle-cc")
+			reaper.TransactionCompleted("idle-cc")
+			reaper.Halt()
+
+			Consistently(fakeLauncher.StopCallCount, "150ms").Should(Equal(0))
+
+			reaper.TransactionStarted("other-cc")
+			reaper.TransactionCompleted("other-cc")
+			Consistently(fakeLauncher.StopCallCount, "150ms").Should(Equal(0))
+		})
+	})
+})
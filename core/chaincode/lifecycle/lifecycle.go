@@ -9,6 +9,7 @@ package lifecycle
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/msp"
@@ -195,6 +196,15 @@ type PackageParser interface {
 	Parse(data []byte) (*persistence.ChaincodePackage, error)
 }
 
+//go:generate counterfeiter -o mock/package_signature_validator.go --fake-name PackageSignatureValidator . PackageSignatureValidator
+
+// PackageSignatureValidator verifies the signature embedded in a signed
+// chaincode install package and returns a human readable description of
+// the signer.
+type PackageSignatureValidator interface {
+	ValidateSignature(sig *persistence.ChaincodePackageSignature) (string, error)
+}
+
 //go:generate counterfeiter -o mock/install_listener.go --fake-name InstallListener . InstallListener
 type InstallListener interface {
 	HandleChaincodeInstalled(md *persistence.ChaincodePackageMetadata, packageID string)
@@ -292,6 +302,7 @@ type ExternalFunctions struct {
 	InstalledChaincodesLister InstalledChaincodesLister
 	ChaincodeBuilder          ChaincodeBuilder
 	BuildRegistry             *container.BuildRegistry
+	PackageSignatureValidator PackageSignatureValidator
 }
 
 // CheckCommitReadiness takes a chaincode definition, checks that
@@ -340,6 +351,149 @@ func (ef *ExternalFunctions) CommitChaincodeDefinition(chname, ccname string, cd
 	return approvals, nil
 }
 
+// CommitSimulation describes the effects that committing a chaincode
+// definition would have, without the definition having been applied.
+type CommitSimulation struct {
+	// RequiredSequence is the sequence number the next committed definition
+	// must carry.
+	RequiredSequence int64
+
+	// Approvals maps the orgs whose states were supplied to whether or not
+	// they have approved the proposed definition.
+	Approvals map[string]bool
+
+	// CollectionsAdded and CollectionsRemoved name the collections which
+	// appear only in the proposed definition, and only in the committed
+	// definition, respectively.
+	CollectionsAdded   []string
+	CollectionsRemoved []string
+
+	// PolicyConflicts describes the ways in which the proposed definition
+	// would be rejected at commit time, or would strand existing state such
+	// as key-level endorsement policies.
+	PolicyConflicts []string
+}
+
+// SimulateCommitChaincodeDefinition takes a proposed chaincode definition and
+// reports what committing it would do -- which orgs have approved it, how its
+// collections differ from the committed definition, and any policy conflicts
+// the commit would cause -- without applying the definition. Unlike
+// CommitChaincodeDefinition, problems with the proposed definition are
+// reported in the simulation rather than returned as errors.
+func (ef *ExternalFunctions) SimulateCommitChaincodeDefinition(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (*CommitSimulation, error) {
+	currentSequence, err := ef.Resources.Serializer.DeserializeFieldAsInt64(NamespacesName, ccname, "Sequence", publicState)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not get current sequence")
+	}
+
+	if err := ef.SetChaincodeDefinitionDefaults(chname, cd); err != nil {
+		return nil, errors.WithMessagef(err, "could not set defaults for chaincode definition in channel %s", chname)
+	}
+
+	sim := &CommitSimulation{
+		RequiredSequence: currentSequence + 1,
+	}
+
+	if cd.Sequence != sim.RequiredSequence {
+		sim.PolicyConflicts = append(sim.PolicyConflicts, fmt.Sprintf("requested sequence is %d, but new definition must be sequence %d", cd.Sequence, sim.RequiredSequence))
+	}
+
+	if sim.Approvals, err = ef.QueryOrgApprovals(ccname, cd, orgStates); err != nil {
+		return nil, err
+	}
+
+	var committed *ChaincodeDefinition
+	if currentSequence != 0 {
+		if committed, err = ef.QueryChaincodeDefinition(ccname, publicState); err != nil {
+			return nil, err
+		}
+	}
+
+	proposedColls := staticCollectionsByName(cd.Collections)
+	committedColls := map[string]*pb.StaticCollectionConfig{}
+	if committed != nil {
+		committedColls = staticCollectionsByName(committed.Collections)
+	}
+
+	for name, proposedColl := range proposedColls {
+		committedColl, ok := committedColls[name]
+		if !ok {
+			sim.CollectionsAdded = append(sim.CollectionsAdded, name)
+			continue
+		}
+		if proposedColl.BlockToLive != committedColl.BlockToLive {
+			sim.PolicyConflicts = append(sim.PolicyConflicts, fmt.Sprintf("collection '%s' changes BlockToLive from %d to %d, which commit would reject", name, committedColl.BlockToLive, proposedColl.BlockToLive))
+		}
+	}
+
+	for name := range committedColls {
+		if _, ok := proposedColls[name]; !ok {
+			sim.CollectionsRemoved = append(sim.CollectionsRemoved, name)
+			sim.PolicyConflicts = append(sim.PolicyConflicts, fmt.Sprintf("collection '%s' is removed; its keys, including any key-level endorsement policies set on them, would become unreachable", name))
+		}
+	}
+
+	if committed != nil && committed.ValidationInfo.ValidationPlugin != cd.ValidationInfo.ValidationPlugin {
+		sim.PolicyConflicts = append(sim.PolicyConflicts, fmt.Sprintf("validation plugin changes from '%s' to '%s'; existing key-level endorsement policies are evaluated by the validation plugin", committed.ValidationInfo.ValidationPlugin, cd.ValidationInfo.ValidationPlugin))
+	}
+
+	// keep the report deterministic so that endorsements of the simulation
+	// match across peers
+	sort.Strings(sim.CollectionsAdded)
+	sort.Strings(sim.CollectionsRemoved)
+	sort.Strings(sim.PolicyConflicts)
+
+	logger.Infof("Successfully simulated commit of chaincode name '%s' on channel '%s' with definition {%s}", ccname, chname, cd)
+
+	return sim, nil
+}
+
+// staticCollectionsByName indexes the static collection configs of a
+// collection config package by collection name. Non-static entries are
+// ignored; they are rejected by validation on the write paths.
+func staticCollectionsByName(collConfigPkg *pb.CollectionConfigPackage) map[string]*pb.StaticCollectionConfig {
+	colls := map[string]*pb.StaticCollectionConfig{}
+	if collConfigPkg == nil {
+		return colls
+	}
+	for _, c := range collConfigPkg.Config {
+		if sc := c.GetStaticCollectionConfig(); sc != nil {
+			colls[sc.Name] = sc
+		}
+	}
+	return colls
+}
+
+// UpdateCollectionConfig replaces the collection configuration of a committed
+// chaincode definition at its current sequence. Routine collection changes --
+// adding collections, or changing the membership or BlockToLive of existing
+// ones -- leave the code package and the rest of the definition untouched, so
+// they require neither a sequence bump nor fresh org approvals. Existing
+// collections cannot be dropped; their private data would otherwise become
+// unreachable. The updated definition is returned.
+func (ef *ExternalFunctions) UpdateCollectionConfig(chname, ccname string, collections *pb.CollectionConfigPackage, publicState ReadWritableState) (*ChaincodeDefinition, error) {
+	definedChaincode, err := ef.QueryChaincodeDefinition(ccname, publicState)
+	if err != nil {
+		return nil, err
+	}
+
+	proposedColls := staticCollectionsByName(collections)
+	for name := range staticCollectionsByName(definedChaincode.Collections) {
+		if _, ok := proposedColls[name]; !ok {
+			return nil, errors.Errorf("existing collection [%s] missing in the updated collection configuration", name)
+		}
+	}
+
+	definedChaincode.Collections = collections
+	if err := ef.Resources.Serializer.Serialize(NamespacesName, ccname, definedChaincode, publicState); err != nil {
+		return nil, errors.WithMessage(err, "could not serialize updated chaincode definition")
+	}
+
+	logger.Infof("Successfully endorsed collection config update for chaincode name '%s' on channel '%s' at sequence %d", ccname, chname, definedChaincode.Sequence)
+
+	return definedChaincode, nil
+}
+
 // DefaultEndorsementPolicyAsBytes returns a marshalled version
 // of the default chaincode endorsement policy in the supplied channel
 func (ef *ExternalFunctions) DefaultEndorsementPolicyAsBytes(channelID string) ([]byte, error) {
@@ -560,6 +714,14 @@ func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*
 		return nil, errors.New("empty metadata for supplied chaincode")
 	}
 
+	var signer string
+	if pkg.Signature != nil && ef.PackageSignatureValidator != nil {
+		signer, err = ef.PackageSignatureValidator.ValidateSignature(pkg.Signature)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not verify chaincode package signature")
+		}
+	}
+
 	packageID, err := ef.Resources.ChaincodeStore.Save(pkg.Metadata.Label, chaincodeInstallPackage)
 	if err != nil {
 		return nil, errors.WithMessage(err, "could not save cc install package")
@@ -580,11 +742,16 @@ func (ef *ExternalFunctions) InstallChaincode(chaincodeInstallPackage []byte) (*
 		ef.InstallListener.HandleChaincodeInstalled(pkg.Metadata, packageID)
 	}
 
-	logger.Infof("Successfully installed chaincode with package ID '%s'", packageID)
+	if signer != "" {
+		logger.Infof("Successfully installed chaincode with package ID '%s' signed by %s", packageID, signer)
+	} else {
+		logger.Infof("Successfully installed chaincode with package ID '%s'", packageID)
+	}
 
 	return &chaincode.InstalledChaincode{
 		PackageID: packageID,
 		Label:     pkg.Metadata.Label,
+		Signer:    signer,
 	}, nil
 }
 
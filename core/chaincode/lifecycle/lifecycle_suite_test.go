@@ -145,6 +145,11 @@ type msp1 interface {
 	msp.MSP
 }
 
+//go:generate counterfeiter -o mock/identity.go --fake-name Identity . identity
+type identity interface {
+	msp.Identity
+}
+
 func TestLifecycle(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Lifecycle Suite")
@@ -275,6 +275,7 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeParser              *mock.PackageParser
 		fakeListener            *mock.InstallListener
 		fakeLister              *mock.InstalledChaincodesLister
+		fakeSignatureValidator  *mock.PackageSignatureValidator
 		fakeChannelConfigSource *mock.ChannelConfigSource
 		fakeChannelConfig       *mock.ChannelConfig
 		fakeApplicationConfig   *mock.ApplicationConfig
@@ -288,6 +289,7 @@ var _ = Describe("ExternalFunctions", func() {
 		fakeParser = &mock.PackageParser{}
 		fakeListener = &mock.InstallListener{}
 		fakeLister = &mock.InstalledChaincodesLister{}
+		fakeSignatureValidator = &mock.PackageSignatureValidator{}
 		fakeChannelConfigSource = &mock.ChannelConfigSource{}
 		fakeChannelConfig = &mock.ChannelConfig{}
 		fakeChannelConfigSource.GetStableChannelConfigReturns(fakeChannelConfig)
@@ -318,6 +320,7 @@ var _ = Describe("ExternalFunctions", func() {
 			InstalledChaincodesLister: fakeLister,
 			ChaincodeBuilder:          fakeChaincodeBuilder,
 			BuildRegistry:             &container.BuildRegistry{},
+			PackageSignatureValidator: fakeSignatureValidator,
 		}
 	})
 
@@ -368,6 +371,67 @@ var _ = Describe("ExternalFunctions", func() {
 			Expect(ccid).To(Equal("fake-hash"))
 		})
 
+		It("does not attempt to verify a signature for an unsigned package", func() {
+			_, err := ef.InstallChaincode([]byte("cc-package"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSignatureValidator.ValidateSignatureCallCount()).To(Equal(0))
+		})
+
+		Context("when the package carries a signature", func() {
+			var signature *persistence.ChaincodePackageSignature
+
+			BeforeEach(func() {
+				signature = &persistence.ChaincodePackageSignature{
+					Identity:  []byte("signer-identity"),
+					Signature: []byte("signature"),
+					Payload:   []byte("signed-payload"),
+				}
+				fakeParser.ParseReturns(&persistence.ChaincodePackage{
+					Metadata: &persistence.ChaincodePackageMetadata{
+						Type:  "cc-type",
+						Path:  "cc-path",
+						Label: "cc-label",
+					},
+					Signature: signature,
+				}, nil)
+				fakeSignatureValidator.ValidateSignatureReturns("'signer' (mspid: first-mspid)", nil)
+			})
+
+			It("verifies the signature and records the signer", func() {
+				cc, err := ef.InstallChaincode([]byte("cc-package"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cc.Signer).To(Equal("'signer' (mspid: first-mspid)"))
+
+				Expect(fakeSignatureValidator.ValidateSignatureCallCount()).To(Equal(1))
+				Expect(fakeSignatureValidator.ValidateSignatureArgsForCall(0)).To(Equal(signature))
+			})
+
+			Context("when signature verification fails", func() {
+				BeforeEach(func() {
+					fakeSignatureValidator.ValidateSignatureReturns("", fmt.Errorf("fake-validation-error"))
+				})
+
+				It("wraps and returns the error without saving the package", func() {
+					_, err := ef.InstallChaincode([]byte("cc-package"))
+					Expect(err).To(MatchError("could not verify chaincode package signature: fake-validation-error"))
+					Expect(fakeCCStore.SaveCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when no signature validator is configured", func() {
+				BeforeEach(func() {
+					ef.PackageSignatureValidator = nil
+				})
+
+				It("installs the package without recording a signer", func() {
+					cc, err := ef.InstallChaincode([]byte("cc-package"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cc.Signer).To(BeEmpty())
+					Expect(fakeSignatureValidator.ValidateSignatureCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		When("building the chaincode fails", func() {
 			BeforeEach(func() {
 				fakeChaincodeBuilder.BuildReturns(fmt.Errorf("fake-build-error"))
@@ -1098,6 +1162,264 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("SimulateCommitChaincodeDefinition", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			fakeOrgStates   []*mock.ReadWritableState
+
+			testDefinition *lifecycle.ChaincodeDefinition
+
+			publicKVS, org0KVS, org1KVS MapLedgerShim
+		)
+
+		staticCollection := func(name string, blockToLive uint64) *pb.CollectionConfig {
+			return &pb.CollectionConfig{
+				Payload: &pb.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &pb.StaticCollectionConfig{
+						Name:        name,
+						BlockToLive: blockToLive,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			testDefinition = &lifecycle.ChaincodeDefinition{
+				Sequence: 5,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           "version",
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				},
+				Collections: &pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						staticCollection("coll1", 100),
+						staticCollection("coll3", 0),
+					},
+				},
+			}
+
+			publicKVS = MapLedgerShim(map[string][]byte{})
+			fakePublicState = &mock.ReadWritableState{}
+			fakePublicState.GetStateStub = publicKVS.GetState
+			fakePublicState.PutStateStub = publicKVS.PutState
+
+			resources.Serializer.Serialize("namespaces", "cc-name", &lifecycle.ChaincodeDefinition{
+				Sequence: 4,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           "version",
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				},
+				Collections: &pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						staticCollection("coll1", 100),
+						staticCollection("coll2", 0),
+					},
+				},
+			}, publicKVS)
+
+			org0KVS = MapLedgerShim(map[string][]byte{})
+			org1KVS = MapLedgerShim(map[string][]byte{})
+			fakeOrg0State := &mock.ReadWritableState{}
+			fakeOrg0State.CollectionNameReturns("_implicit_org_org0")
+			fakeOrg1State := &mock.ReadWritableState{}
+			fakeOrg1State.CollectionNameReturns("_implicit_org_org1")
+			fakeOrgStates = []*mock.ReadWritableState{
+				fakeOrg0State,
+				fakeOrg1State,
+			}
+			for i, kvs := range []MapLedgerShim{org0KVS, org1KVS} {
+				kvs := kvs
+				fakeOrgStates[i].GetStateStub = kvs.GetState
+				fakeOrgStates[i].GetStateHashStub = kvs.GetStateHash
+				fakeOrgStates[i].PutStateStub = kvs.PutState
+			}
+
+			resources.Serializer.Serialize("namespaces", "cc-name#5", testDefinition.Parameters(), fakeOrgStates[0])
+			resources.Serializer.Serialize("namespaces", "cc-name#5", &lifecycle.ChaincodeParameters{}, fakeOrgStates[1])
+		})
+
+		It("reports the approvals, the collection changes, and the conflicts without mutating the public state", func() {
+			sim, err := ef.SimulateCommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sim.RequiredSequence).To(Equal(int64(5)))
+			Expect(sim.Approvals).To(Equal(map[string]bool{
+				"org0": true,
+				"org1": false,
+			}))
+			Expect(sim.CollectionsAdded).To(Equal([]string{"coll3"}))
+			Expect(sim.CollectionsRemoved).To(Equal([]string{"coll2"}))
+			Expect(sim.PolicyConflicts).To(ConsistOf(
+				ContainSubstring("collection 'coll2' is removed"),
+			))
+			Expect(fakePublicState.PutStateCallCount()).To(Equal(0))
+		})
+
+		Context("when the requested sequence is not the next sequence", func() {
+			BeforeEach(func() {
+				testDefinition.Sequence = 7
+			})
+
+			It("reports the conflict instead of returning an error", func() {
+				sim, err := ef.SimulateCommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sim.RequiredSequence).To(Equal(int64(5)))
+				Expect(sim.PolicyConflicts).To(ContainElement("requested sequence is 7, but new definition must be sequence 5"))
+			})
+		})
+
+		Context("when the BlockToLive of an existing collection changes", func() {
+			BeforeEach(func() {
+				testDefinition.Collections.Config[0] = staticCollection("coll1", 50)
+			})
+
+			It("reports the conflict", func() {
+				sim, err := ef.SimulateCommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sim.PolicyConflicts).To(ContainElement("collection 'coll1' changes BlockToLive from 100 to 50, which commit would reject"))
+			})
+		})
+
+		Context("when the validation plugin changes", func() {
+			BeforeEach(func() {
+				testDefinition.ValidationInfo.ValidationPlugin = "other-plugin"
+			})
+
+			It("reports the conflict", func() {
+				sim, err := ef.SimulateCommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sim.PolicyConflicts).To(ContainElement(ContainSubstring("validation plugin changes from 'validation-plugin' to 'other-plugin'")))
+			})
+		})
+
+		Context("when the chaincode is not yet defined", func() {
+			BeforeEach(func() {
+				testDefinition.Sequence = 1
+			})
+
+			It("treats every proposed collection as added", func() {
+				sim, err := ef.SimulateCommitChaincodeDefinition("my-channel", "other-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sim.RequiredSequence).To(Equal(int64(1)))
+				Expect(sim.CollectionsAdded).To(Equal([]string{"coll1", "coll3"}))
+				Expect(sim.CollectionsRemoved).To(BeEmpty())
+				Expect(sim.PolicyConflicts).To(BeEmpty())
+			})
+		})
+
+		Context("when the public state is not readable", func() {
+			BeforeEach(func() {
+				fakePublicState.GetStateReturns(nil, fmt.Errorf("getstate-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := ef.SimulateCommitChaincodeDefinition("my-channel", "cc-name", testDefinition, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).To(MatchError("could not get current sequence: could not get state for key namespaces/fields/cc-name/Sequence: getstate-error"))
+			})
+		})
+	})
+
+	Describe("UpdateCollectionConfig", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			publicKVS       MapLedgerShim
+		)
+
+		staticCollection := func(name string, blockToLive uint64) *pb.CollectionConfig {
+			return &pb.CollectionConfig{
+				Payload: &pb.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &pb.StaticCollectionConfig{
+						Name:        name,
+						BlockToLive: blockToLive,
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			publicKVS = MapLedgerShim(map[string][]byte{})
+			fakePublicState = &mock.ReadWritableState{}
+			fakePublicState.GetStateStub = publicKVS.GetState
+			fakePublicState.PutStateStub = publicKVS.PutState
+
+			resources.Serializer.Serialize("namespaces", "cc-name", &lifecycle.ChaincodeDefinition{
+				Sequence: 4,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           "version",
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				},
+				Collections: &pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						staticCollection("coll1", 100),
+					},
+				},
+			}, publicKVS)
+		})
+
+		It("replaces the collection configuration at the current sequence", func() {
+			cd, err := ef.UpdateCollectionConfig("my-channel", "cc-name", &pb.CollectionConfigPackage{
+				Config: []*pb.CollectionConfig{
+					staticCollection("coll1", 50),
+					staticCollection("coll2", 0),
+				},
+			}, fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cd.Sequence).To(Equal(int64(4)))
+
+			committed, err := ef.QueryChaincodeDefinition("cc-name", fakePublicState)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(committed.Sequence).To(Equal(int64(4)))
+			Expect(committed.Collections.Config).To(HaveLen(2))
+			Expect(committed.Collections.Config[0].GetStaticCollectionConfig().BlockToLive).To(Equal(uint64(50)))
+			Expect(committed.Collections.Config[1].GetStaticCollectionConfig().Name).To(Equal("coll2"))
+		})
+
+		Context("when an existing collection is dropped", func() {
+			It("returns an error", func() {
+				_, err := ef.UpdateCollectionConfig("my-channel", "cc-name", &pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						staticCollection("coll2", 0),
+					},
+				}, fakePublicState)
+				Expect(err).To(MatchError("existing collection [coll1] missing in the updated collection configuration"))
+			})
+		})
+
+		Context("when the chaincode is not defined", func() {
+			It("returns an error", func() {
+				_, err := ef.UpdateCollectionConfig("my-channel", "other-name", &pb.CollectionConfigPackage{}, fakePublicState)
+				Expect(err).To(MatchError("namespace other-name is not defined"))
+			})
+		})
+
+		Context("when the public state is not writable", func() {
+			BeforeEach(func() {
+				fakePublicState.PutStateReturns(fmt.Errorf("putstate-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := ef.UpdateCollectionConfig("my-channel", "cc-name", &pb.CollectionConfigPackage{
+					Config: []*pb.CollectionConfig{
+						staticCollection("coll1", 100),
+						staticCollection("coll2", 0),
+					},
+				}, fakePublicState)
+				Expect(err).To(MatchError(ContainSubstring("could not serialize updated chaincode definition")))
+			})
+		})
+	})
+
 	Describe("CommitChaincodeDefinition", func() {
 		var (
 			fakePublicState *mock.ReadWritableState
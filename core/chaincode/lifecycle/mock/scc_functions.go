@@ -4,6 +4,7 @@ package mock
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 )
@@ -150,6 +151,39 @@ type SCCFunctions struct {
 		result1 map[string]bool
 		result2 error
 	}
+	SimulateCommitChaincodeDefinitionStub        func(string, string, *lifecycle.ChaincodeDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) (*lifecycle.CommitSimulation, error)
+	simulateCommitChaincodeDefinitionMutex       sync.RWMutex
+	simulateCommitChaincodeDefinitionArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *lifecycle.ChaincodeDefinition
+		arg4 lifecycle.ReadWritableState
+		arg5 []lifecycle.OpaqueState
+	}
+	simulateCommitChaincodeDefinitionReturns struct {
+		result1 *lifecycle.CommitSimulation
+		result2 error
+	}
+	simulateCommitChaincodeDefinitionReturnsOnCall map[int]struct {
+		result1 *lifecycle.CommitSimulation
+		result2 error
+	}
+	UpdateCollectionConfigStub        func(string, string, *peer.CollectionConfigPackage, lifecycle.ReadWritableState) (*lifecycle.ChaincodeDefinition, error)
+	updateCollectionConfigMutex       sync.RWMutex
+	updateCollectionConfigArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *peer.CollectionConfigPackage
+		arg4 lifecycle.ReadWritableState
+	}
+	updateCollectionConfigReturns struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
+	updateCollectionConfigReturnsOnCall map[int]struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -806,6 +840,144 @@ func (fake *SCCFunctions) QueryOrgApprovalsReturnsOnCall(i int, result1 map[stri
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinition(arg1 string, arg2 string, arg3 *lifecycle.ChaincodeDefinition, arg4 lifecycle.ReadWritableState, arg5 []lifecycle.OpaqueState) (*lifecycle.CommitSimulation, error) {
+	var arg5Copy []lifecycle.OpaqueState
+	if arg5 != nil {
+		arg5Copy = make([]lifecycle.OpaqueState, len(arg5))
+		copy(arg5Copy, arg5)
+	}
+	fake.simulateCommitChaincodeDefinitionMutex.Lock()
+	ret, specificReturn := fake.simulateCommitChaincodeDefinitionReturnsOnCall[len(fake.simulateCommitChaincodeDefinitionArgsForCall)]
+	fake.simulateCommitChaincodeDefinitionArgsForCall = append(fake.simulateCommitChaincodeDefinitionArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *lifecycle.ChaincodeDefinition
+		arg4 lifecycle.ReadWritableState
+		arg5 []lifecycle.OpaqueState
+	}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.recordInvocation("SimulateCommitChaincodeDefinition", []interface{}{arg1, arg2, arg3, arg4, arg5Copy})
+	fake.simulateCommitChaincodeDefinitionMutex.Unlock()
+	if fake.SimulateCommitChaincodeDefinitionStub != nil {
+		return fake.SimulateCommitChaincodeDefinitionStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.simulateCommitChaincodeDefinitionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinitionCallCount() int {
+	fake.simulateCommitChaincodeDefinitionMutex.RLock()
+	defer fake.simulateCommitChaincodeDefinitionMutex.RUnlock()
+	return len(fake.simulateCommitChaincodeDefinitionArgsForCall)
+}
+
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinitionCalls(stub func(string, string, *lifecycle.ChaincodeDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) (*lifecycle.CommitSimulation, error)) {
+	fake.simulateCommitChaincodeDefinitionMutex.Lock()
+	defer fake.simulateCommitChaincodeDefinitionMutex.Unlock()
+	fake.SimulateCommitChaincodeDefinitionStub = stub
+}
+
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinitionArgsForCall(i int) (string, string, *lifecycle.ChaincodeDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) {
+	fake.simulateCommitChaincodeDefinitionMutex.RLock()
+	defer fake.simulateCommitChaincodeDefinitionMutex.RUnlock()
+	argsForCall := fake.simulateCommitChaincodeDefinitionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinitionReturns(result1 *lifecycle.CommitSimulation, result2 error) {
+	fake.simulateCommitChaincodeDefinitionMutex.Lock()
+	defer fake.simulateCommitChaincodeDefinitionMutex.Unlock()
+	fake.SimulateCommitChaincodeDefinitionStub = nil
+	fake.simulateCommitChaincodeDefinitionReturns = struct {
+		result1 *lifecycle.CommitSimulation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) SimulateCommitChaincodeDefinitionReturnsOnCall(i int, result1 *lifecycle.CommitSimulation, result2 error) {
+	fake.simulateCommitChaincodeDefinitionMutex.Lock()
+	defer fake.simulateCommitChaincodeDefinitionMutex.Unlock()
+	fake.SimulateCommitChaincodeDefinitionStub = nil
+	if fake.simulateCommitChaincodeDefinitionReturnsOnCall == nil {
+		fake.simulateCommitChaincodeDefinitionReturnsOnCall = make(map[int]struct {
+			result1 *lifecycle.CommitSimulation
+			result2 error
+		})
+	}
+	fake.simulateCommitChaincodeDefinitionReturnsOnCall[i] = struct {
+		result1 *lifecycle.CommitSimulation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfig(arg1 string, arg2 string, arg3 *peer.CollectionConfigPackage, arg4 lifecycle.ReadWritableState) (*lifecycle.ChaincodeDefinition, error) {
+	fake.updateCollectionConfigMutex.Lock()
+	ret, specificReturn := fake.updateCollectionConfigReturnsOnCall[len(fake.updateCollectionConfigArgsForCall)]
+	fake.updateCollectionConfigArgsForCall = append(fake.updateCollectionConfigArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *peer.CollectionConfigPackage
+		arg4 lifecycle.ReadWritableState
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("UpdateCollectionConfig", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateCollectionConfigMutex.Unlock()
+	if fake.UpdateCollectionConfigStub != nil {
+		return fake.UpdateCollectionConfigStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.updateCollectionConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfigCallCount() int {
+	fake.updateCollectionConfigMutex.RLock()
+	defer fake.updateCollectionConfigMutex.RUnlock()
+	return len(fake.updateCollectionConfigArgsForCall)
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfigCalls(stub func(string, string, *peer.CollectionConfigPackage, lifecycle.ReadWritableState) (*lifecycle.ChaincodeDefinition, error)) {
+	fake.updateCollectionConfigMutex.Lock()
+	defer fake.updateCollectionConfigMutex.Unlock()
+	fake.UpdateCollectionConfigStub = stub
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfigArgsForCall(i int) (string, string, *peer.CollectionConfigPackage, lifecycle.ReadWritableState) {
+	fake.updateCollectionConfigMutex.RLock()
+	defer fake.updateCollectionConfigMutex.RUnlock()
+	argsForCall := fake.updateCollectionConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfigReturns(result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.updateCollectionConfigMutex.Lock()
+	defer fake.updateCollectionConfigMutex.Unlock()
+	fake.UpdateCollectionConfigStub = nil
+	fake.updateCollectionConfigReturns = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) UpdateCollectionConfigReturnsOnCall(i int, result1 *lifecycle.ChaincodeDefinition, result2 error) {
+	fake.updateCollectionConfigMutex.Lock()
+	defer fake.updateCollectionConfigMutex.Unlock()
+	fake.UpdateCollectionConfigStub = nil
+	if fake.updateCollectionConfigReturnsOnCall == nil {
+		fake.updateCollectionConfigReturnsOnCall = map[int]struct {
+			result1 *lifecycle.ChaincodeDefinition
+			result2 error
+		}{}
+	}
+	fake.updateCollectionConfigReturnsOnCall[i] = struct {
+		result1 *lifecycle.ChaincodeDefinition
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
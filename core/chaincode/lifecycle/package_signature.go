@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+// LocalPackageSignatureValidator validates the signature carried in a signed
+// chaincode install package. Because install is not scoped to a channel, the
+// signer is validated against the peer's local MSP rather than against a
+// channel's MSP configuration.
+type LocalPackageSignatureValidator struct {
+	Deserializer msp.IdentityDeserializer
+}
+
+// ValidateSignature deserializes the signer identity, validates it against
+// the backing MSP, and verifies the signature over the package payload. It
+// returns a human readable description of the signer on success.
+func (v *LocalPackageSignatureValidator) ValidateSignature(sig *persistence.ChaincodePackageSignature) (string, error) {
+	identity, err := v.Deserializer.DeserializeIdentity(sig.Identity)
+	if err != nil {
+		return "", errors.WithMessage(err, "could not deserialize package signer identity")
+	}
+
+	if err := identity.Validate(); err != nil {
+		return "", errors.WithMessage(err, "package signer identity is not valid")
+	}
+
+	if err := identity.Verify(sig.Payload, sig.Signature); err != nil {
+		return "", errors.WithMessage(err, "invalid signature over package contents")
+	}
+
+	identifier := identity.GetIdentifier()
+	return fmt.Sprintf("'%s' (mspid: %s)", identifier.Id, identifier.Mspid), nil
+}
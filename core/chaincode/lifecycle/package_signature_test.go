@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle_test
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/msp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalPackageSignatureValidator", func() {
+	var (
+		fakeMSP      *mock.MSP
+		fakeIdentity *mock.Identity
+		validator    *lifecycle.LocalPackageSignatureValidator
+		signature    *persistence.ChaincodePackageSignature
+	)
+
+	BeforeEach(func() {
+		fakeIdentity = &mock.Identity{}
+		fakeIdentity.GetIdentifierReturns(&msp.IdentityIdentifier{
+			Mspid: "test-mspid",
+			Id:    "signer-id",
+		})
+
+		fakeMSP = &mock.MSP{}
+		fakeMSP.DeserializeIdentityReturns(fakeIdentity, nil)
+
+		validator = &lifecycle.LocalPackageSignatureValidator{
+			Deserializer: fakeMSP,
+		}
+
+		signature = &persistence.ChaincodePackageSignature{
+			Identity:  []byte("serialized-identity"),
+			Signature: []byte("signature"),
+			Payload:   []byte("signed-payload"),
+		}
+	})
+
+	It("validates the signer and verifies the signature over the payload", func() {
+		signer, err := validator.ValidateSignature(signature)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signer).To(Equal("'signer-id' (mspid: test-mspid)"))
+
+		Expect(fakeMSP.DeserializeIdentityCallCount()).To(Equal(1))
+		Expect(fakeMSP.DeserializeIdentityArgsForCall(0)).To(Equal([]byte("serialized-identity")))
+
+		Expect(fakeIdentity.ValidateCallCount()).To(Equal(1))
+
+		Expect(fakeIdentity.VerifyCallCount()).To(Equal(1))
+		msg, sig := fakeIdentity.VerifyArgsForCall(0)
+		Expect(msg).To(Equal([]byte("signed-payload")))
+		Expect(sig).To(Equal([]byte("signature")))
+	})
+
+	Context("when the identity cannot be deserialized", func() {
+		BeforeEach(func() {
+			fakeMSP.DeserializeIdentityReturns(nil, fmt.Errorf("deserialize-error"))
+		})
+
+		It("wraps and returns the error", func() {
+			_, err := validator.ValidateSignature(signature)
+			Expect(err).To(MatchError("could not deserialize package signer identity: deserialize-error"))
+		})
+	})
+
+	Context("when the identity is not valid", func() {
+		BeforeEach(func() {
+			fakeIdentity.ValidateReturns(fmt.Errorf("validate-error"))
+		})
+
+		It("wraps and returns the error", func() {
+			_, err := validator.ValidateSignature(signature)
+			Expect(err).To(MatchError("package signer identity is not valid: validate-error"))
+		})
+	})
+
+	Context("when the signature does not verify", func() {
+		BeforeEach(func() {
+			fakeIdentity.VerifyReturns(fmt.Errorf("verify-error"))
+		})
+
+		It("wraps and returns the error", func() {
+			_, err := validator.ValidateSignature(signature)
+			Expect(err).To(MatchError("invalid signature over package contents: verify-error"))
+		})
+	})
+})
@@ -55,10 +55,21 @@ const (
 	// approval status for a given definition over a given set of orgs
 	CheckCommitReadinessFuncName = "CheckCommitReadiness"
 
+	// SimulateCommitChaincodeDefinitionFuncName is the chaincode function name
+	// used to simulate committing a chaincode definition. It reports the
+	// approval status, collection changes, and policy conflicts the commit
+	// would produce, without mutating anything.
+	SimulateCommitChaincodeDefinitionFuncName = "SimulateCommitChaincodeDefinition"
+
 	// CommitChaincodeDefinitionFuncName is the chaincode function name used to
 	// 'commit' (previously 'instantiate') a chaincode in a channel.
 	CommitChaincodeDefinitionFuncName = "CommitChaincodeDefinition"
 
+	// UpdateCollectionConfigFuncName is the chaincode function name used to
+	// replace the collection configuration of a committed chaincode
+	// definition without bumping its sequence or re-approving the chaincode.
+	UpdateCollectionConfigFuncName = "UpdateCollectionConfig"
+
 	// QueryChaincodeDefinitionFuncName is the chaincode function name used to
 	// query a committed chaincode definition in a channel.
 	QueryChaincodeDefinitionFuncName = "QueryChaincodeDefinition"
@@ -66,6 +77,16 @@ const (
 	// QueryChaincodeDefinitionsFuncName is the chaincode function name used to
 	// query the committed chaincode definitions in a channel.
 	QueryChaincodeDefinitionsFuncName = "QueryChaincodeDefinitions"
+
+	// ApproveChaincodeDefinitionEventName is the name of the chaincode event
+	// set on transactions which approve a chaincode definition for an org.
+	// The event payload is the marshaled arguments of the approval.
+	ApproveChaincodeDefinitionEventName = "ApproveChaincodeDefinitionForMyOrg"
+
+	// CommitChaincodeDefinitionEventName is the name of the chaincode event
+	// set on transactions which commit a chaincode definition to a channel.
+	// The event payload is the marshaled arguments of the commit.
+	CommitChaincodeDefinitionEventName = "CommitChaincodeDefinition"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -92,11 +113,22 @@ type SCCFunctions interface {
 	// the specified definition.
 	CheckCommitReadiness(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (map[string]bool, error)
 
+	// SimulateCommitChaincodeDefinition reports which orgs have approved the
+	// supplied definition, how its collections differ from the committed
+	// definition, and any policy conflicts the commit would cause, without
+	// mutating any state.
+	SimulateCommitChaincodeDefinition(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (*CommitSimulation, error)
+
 	// CommitChaincodeDefinition records a new chaincode definition into the
 	// public state and returns a map containing the orgs whose orgStates
 	// were supplied and whether or not they have approved the definition.
 	CommitChaincodeDefinition(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (map[string]bool, error)
 
+	// UpdateCollectionConfig replaces the collection configuration of a
+	// committed chaincode definition at its current sequence and returns the
+	// updated definition.
+	UpdateCollectionConfig(chname, ccname string, collections *pb.CollectionConfigPackage, publicState ReadWritableState) (*ChaincodeDefinition, error)
+
 	// QueryChaincodeDefinition returns a chaincode definition from the public
 	// state.
 	QueryChaincodeDefinition(name string, publicState ReadableState) (*ChaincodeDefinition, error)
@@ -406,6 +438,11 @@ func (i *Invocation) ApproveChaincodeDefinitionForMyOrg(input *lb.ApproveChainco
 	); err != nil {
 		return nil, err
 	}
+
+	if err := i.setEvent(ApproveChaincodeDefinitionEventName, input); err != nil {
+		return nil, err
+	}
+
 	return &lb.ApproveChaincodeDefinitionForMyOrgResult{}, nil
 }
 
@@ -452,6 +489,72 @@ func (i *Invocation) CheckCommitReadiness(input *lb.CheckCommitReadinessArgs) (p
 	}, nil
 }
 
+// SimulateCommitChaincodeDefinitionResult is the message returned by the
+// SimulateCommitChaincodeDefinition SCC function. It is defined here rather
+// than in the fabric-protos lifecycle package until the message is promoted
+// there.
+type SimulateCommitChaincodeDefinitionResult struct {
+	Approvals          map[string]bool `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	RequiredSequence   int64           `protobuf:"varint,2,opt,name=required_sequence,json=requiredSequence,proto3" json:"required_sequence,omitempty"`
+	CollectionsAdded   []string        `protobuf:"bytes,3,rep,name=collections_added,json=collectionsAdded,proto3" json:"collections_added,omitempty"`
+	CollectionsRemoved []string        `protobuf:"bytes,4,rep,name=collections_removed,json=collectionsRemoved,proto3" json:"collections_removed,omitempty"`
+	PolicyConflicts    []string        `protobuf:"bytes,5,rep,name=policy_conflicts,json=policyConflicts,proto3" json:"policy_conflicts,omitempty"`
+}
+
+func (m *SimulateCommitChaincodeDefinitionResult) Reset() {
+	*m = SimulateCommitChaincodeDefinitionResult{}
+}
+func (m *SimulateCommitChaincodeDefinitionResult) String() string { return proto.CompactTextString(m) }
+func (*SimulateCommitChaincodeDefinitionResult) ProtoMessage()    {}
+
+// SimulateCommitChaincodeDefinition is a SCC function that may be dispatched
+// to the underlying lifecycle implementation. It takes the same proposed
+// definition as CheckCommitReadiness, and so reuses its args message.
+func (i *Invocation) SimulateCommitChaincodeDefinition(input *lb.CheckCommitReadinessArgs) (proto.Message, error) {
+	opaqueStates, err := i.createOpaqueStates()
+	if err != nil {
+		return nil, err
+	}
+
+	cd := &ChaincodeDefinition{
+		Sequence: input.Sequence,
+		EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+			Version:           input.Version,
+			EndorsementPlugin: input.EndorsementPlugin,
+			InitRequired:      input.InitRequired,
+		},
+		ValidationInfo: &lb.ChaincodeValidationInfo{
+			ValidationPlugin:    input.ValidationPlugin,
+			ValidationParameter: input.ValidationParameter,
+		},
+		Collections: input.Collections,
+	}
+
+	logger.Debugf("received invocation of SimulateCommitChaincodeDefinition on channel '%s' for definition '%s'",
+		i.Stub.GetChannelID(),
+		cd,
+	)
+
+	sim, err := i.SCC.Functions.SimulateCommitChaincodeDefinition(
+		i.Stub.GetChannelID(),
+		input.Name,
+		cd,
+		i.Stub,
+		opaqueStates,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SimulateCommitChaincodeDefinitionResult{
+		Approvals:          sim.Approvals,
+		RequiredSequence:   sim.RequiredSequence,
+		CollectionsAdded:   sim.CollectionsAdded,
+		CollectionsRemoved: sim.CollectionsRemoved,
+		PolicyConflicts:    sim.PolicyConflicts,
+	}, nil
+}
+
 // CommitChaincodeDefinition is a SCC function that may be dispatched
 // to which routes to the underlying lifecycle implementation.
 func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefinitionArgs) (proto.Message, error) {
@@ -514,11 +617,83 @@ func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefiniti
 		return nil, errors.Errorf("chaincode definition not agreed to by this org (%s)", i.SCC.OrgMSPID)
 	}
 
+	if err := i.setEvent(CommitChaincodeDefinitionEventName, input); err != nil {
+		return nil, err
+	}
+
 	logger.Infof("Successfully endorsed commit for chaincode name '%s' on channel '%s' with definition {%s}", input.Name, i.Stub.GetChannelID(), cd)
 
 	return &lb.CommitChaincodeDefinitionResult{}, nil
 }
 
+// UpdateCollectionConfigArgs is the message accepted by the
+// UpdateCollectionConfig SCC function. It is defined here rather than in the
+// fabric-protos lifecycle package until the message is promoted there.
+type UpdateCollectionConfigArgs struct {
+	Name        string                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Collections *pb.CollectionConfigPackage `protobuf:"bytes,2,opt,name=collections,proto3" json:"collections,omitempty"`
+}
+
+func (m *UpdateCollectionConfigArgs) Reset()         { *m = UpdateCollectionConfigArgs{} }
+func (m *UpdateCollectionConfigArgs) String() string { return proto.CompactTextString(m) }
+func (*UpdateCollectionConfigArgs) ProtoMessage()    {}
+
+// UpdateCollectionConfigResult is the message returned by the
+// UpdateCollectionConfig SCC function.
+type UpdateCollectionConfigResult struct{}
+
+func (m *UpdateCollectionConfigResult) Reset()         { *m = UpdateCollectionConfigResult{} }
+func (m *UpdateCollectionConfigResult) String() string { return proto.CompactTextString(m) }
+func (*UpdateCollectionConfigResult) ProtoMessage()    {}
+
+// UpdateCollectionConfig is a SCC function that may be dispatched to which
+// routes to the underlying lifecycle implementation. It also binds the
+// collections field of the definition to the chaincode's own endorsement
+// policy via a key-level validation parameter, so that subsequent collection
+// updates are governed by the chaincode endorsement policy rather than the
+// channel's lifecycle endorsement policy.
+func (i *Invocation) UpdateCollectionConfig(input *UpdateCollectionConfigArgs) (proto.Message, error) {
+	collConfigs, err := extractStaticCollectionConfigs(input.Collections)
+	if err != nil {
+		return nil, err
+	}
+
+	channelConfig := i.SCC.ChannelConfigSource.GetStableChannelConfig(i.ChannelID)
+	if channelConfig == nil {
+		return nil, errors.Errorf("could not get channelconfig for channel '%s'", i.ChannelID)
+	}
+	mspMgr := channelConfig.MSPManager()
+	if mspMgr == nil {
+		return nil, errors.Errorf("could not get MSP manager for channel '%s'", i.ChannelID)
+	}
+
+	if err := validateCollectionConfigs(collConfigs, mspMgr); err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("received invocation of UpdateCollectionConfig on channel '%s' for chaincode '%s'",
+		i.Stub.GetChannelID(),
+		input.Name,
+	)
+
+	cd, err := i.SCC.Functions.UpdateCollectionConfig(
+		i.Stub.GetChannelID(),
+		input.Name,
+		input.Collections,
+		i.Stub,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionsKey := FieldKey(NamespacesName, input.Name, "Collections")
+	if err := i.Stub.SetStateValidationParameter(collectionsKey, cd.ValidationInfo.ValidationParameter); err != nil {
+		return nil, errors.WithMessagef(err, "could not set endorsement policy for key %s", collectionsKey)
+	}
+
+	return &UpdateCollectionConfigResult{}, nil
+}
+
 // QueryChaincodeDefinition is a SCC function that may be dispatched
 // to which routes to the underlying lifecycle implementation.
 func (i *Invocation) QueryChaincodeDefinition(input *lb.QueryChaincodeDefinitionArgs) (proto.Message, error) {
@@ -611,6 +786,22 @@ var (
 	}
 )
 
+// setEvent sets a chaincode event carrying the marshaled message on the
+// transaction, so that listeners on the channel event services are notified
+// when the transaction commits. The payload is cloned before marshaling, as
+// marshaling mutates the proto-internal bookkeeping of the message and the
+// caller keeps using it.
+func (i *Invocation) setEvent(name string, payload proto.Message) error {
+	payloadBytes, err := proto.Marshal(proto.Clone(payload))
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal %s event payload", name)
+	}
+	if err := i.Stub.SetEvent(name, payloadBytes); err != nil {
+		return errors.WithMessagef(err, "could not set %s event", name)
+	}
+	return nil
+}
+
 func (i *Invocation) validateInput(name, version string, collections *pb.CollectionConfigPackage) error {
 	if !ChaincodeNameRegExp.MatchString(name) {
 		return errors.Errorf("invalid chaincode name '%s'. Names can only consist of alphanumerics, '_', and '-' and can only begin with alphanumerics", name)
@@ -510,6 +510,31 @@ var _ = Describe("SCC", func() {
 				Expect(privState.(*lifecycle.ChaincodePrivateLedgerShim).Collection).To(Equal("_implicit_org_fake-mspid"))
 			})
 
+			It("sets an event on the transaction carrying the approval arguments", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeStub.SetEventCallCount()).To(Equal(1))
+				eventName, eventPayload := fakeStub.SetEventArgsForCall(0)
+				Expect(eventName).To(Equal(lifecycle.ApproveChaincodeDefinitionEventName))
+				eventArg := &lb.ApproveChaincodeDefinitionForMyOrgArgs{}
+				err = proto.Unmarshal(eventPayload, eventArg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(eventArg, arg)).To(BeTrue())
+			})
+
+			Context("when the event cannot be set on the transaction", func() {
+				BeforeEach(func() {
+					fakeStub.SetEventReturns(fmt.Errorf("tart-failure"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'ApproveChaincodeDefinitionForMyOrg': could not set ApproveChaincodeDefinitionForMyOrg event: tart-failure"))
+				})
+			})
+
 			Context("when the chaincode name contains invalid characters", func() {
 				BeforeEach(func() {
 					arg.Name = "!nvalid"
@@ -1015,6 +1040,31 @@ var _ = Describe("SCC", func() {
 				Expect([]string{collection0, collection1}).To(ConsistOf("_implicit_org_fake-mspid", "_implicit_org_other-mspid"))
 			})
 
+			It("sets an event on the transaction carrying the commit arguments", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeStub.SetEventCallCount()).To(Equal(1))
+				eventName, eventPayload := fakeStub.SetEventArgsForCall(0)
+				Expect(eventName).To(Equal(lifecycle.CommitChaincodeDefinitionEventName))
+				eventArg := &lb.CommitChaincodeDefinitionArgs{}
+				err = proto.Unmarshal(eventPayload, eventArg)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(eventArg, arg)).To(BeTrue())
+			})
+
+			Context("when the event cannot be set on the transaction", func() {
+				BeforeEach(func() {
+					fakeStub.SetEventReturns(fmt.Errorf("guava-failure"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'CommitChaincodeDefinition': could not set CommitChaincodeDefinition event: guava-failure"))
+				})
+			})
+
 			Context("when the chaincode name begins with an invalid character", func() {
 				BeforeEach(func() {
 					arg.Name = "_invalid"
@@ -1308,6 +1358,209 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("SimulateCommitChaincodeDefinition", func() {
+			var (
+				err            error
+				arg            *lb.CheckCommitReadinessArgs
+				marshaledArg   []byte
+				fakeOrgConfigs []*mock.ApplicationOrgConfig
+			)
+
+			BeforeEach(func() {
+				arg = &lb.CheckCommitReadinessArgs{
+					Sequence:            7,
+					Name:                "name",
+					Version:             "version",
+					EndorsementPlugin:   "endorsement-plugin",
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+					Collections:         &pb.CollectionConfigPackage{},
+					InitRequired:        true,
+				}
+
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("SimulateCommitChaincodeDefinition"), marshaledArg})
+
+				fakeOrgConfigs = []*mock.ApplicationOrgConfig{{}, {}}
+				fakeOrgConfigs[0].MSPIDReturns("fake-mspid")
+				fakeOrgConfigs[1].MSPIDReturns("other-mspid")
+
+				fakeApplicationConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org0": fakeOrgConfigs[0],
+					"org1": fakeOrgConfigs[1],
+				})
+
+				fakeSCCFuncs.SimulateCommitChaincodeDefinitionReturns(&lifecycle.CommitSimulation{
+					RequiredSequence: 7,
+					Approvals: map[string]bool{
+						"fake-mspid":  true,
+						"other-mspid": false,
+					},
+					CollectionsAdded:   []string{"new-coll"},
+					CollectionsRemoved: []string{"old-coll"},
+					PolicyConflicts:    []string{"collection 'old-coll' is removed"},
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lifecycle.SimulateCommitChaincodeDefinitionResult{}
+				err = proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(payload.RequiredSequence).To(Equal(int64(7)))
+				Expect(payload.Approvals).To(Equal(map[string]bool{
+					"fake-mspid":  true,
+					"other-mspid": false,
+				}))
+				Expect(payload.CollectionsAdded).To(Equal([]string{"new-coll"}))
+				Expect(payload.CollectionsRemoved).To(Equal([]string{"old-coll"}))
+				Expect(payload.PolicyConflicts).To(Equal([]string{"collection 'old-coll' is removed"}))
+
+				Expect(fakeSCCFuncs.SimulateCommitChaincodeDefinitionCallCount()).To(Equal(1))
+				chname, ccname, cd, pubState, orgStates := fakeSCCFuncs.SimulateCommitChaincodeDefinitionArgsForCall(0)
+				Expect(chname).To(Equal("test-channel"))
+				Expect(ccname).To(Equal("name"))
+				Expect(cd).To(Equal(&lifecycle.ChaincodeDefinition{
+					Sequence: 7,
+					EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+						Version:           "version",
+						EndorsementPlugin: "endorsement-plugin",
+						InitRequired:      true,
+					},
+					ValidationInfo: &lb.ChaincodeValidationInfo{
+						ValidationPlugin:    "validation-plugin",
+						ValidationParameter: []byte("validation-parameter"),
+					},
+					Collections: arg.Collections,
+				}))
+				Expect(pubState).To(Equal(fakeStub))
+				Expect(orgStates).To(HaveLen(2))
+				collection0 := orgStates[0].(*lifecycle.ChaincodePrivateLedgerShim).Collection
+				collection1 := orgStates[1].(*lifecycle.ChaincodePrivateLedgerShim).Collection
+				Expect([]string{collection0, collection1}).To(ConsistOf("_implicit_org_fake-mspid", "_implicit_org_other-mspid"))
+			})
+
+			Context("when there is no application config", func() {
+				BeforeEach(func() {
+					fakeChannelConfig.ApplicationConfigReturns(nil, false)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("could not get application config for channel 'test-channel'"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.SimulateCommitChaincodeDefinitionReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'SimulateCommitChaincodeDefinition': underlying-error"))
+				})
+			})
+		})
+
+		Describe("UpdateCollectionConfig", func() {
+			var (
+				err          error
+				arg          *lifecycle.UpdateCollectionConfigArgs
+				marshaledArg []byte
+			)
+
+			BeforeEach(func() {
+				arg = &lifecycle.UpdateCollectionConfigArgs{
+					Name:        "testcc",
+					Collections: &pb.CollectionConfigPackage{},
+				}
+
+				marshaledArg, err = proto.Marshal(arg)
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("UpdateCollectionConfig"), marshaledArg})
+
+				fakeSCCFuncs.UpdateCollectionConfigReturns(&lifecycle.ChaincodeDefinition{
+					Sequence: 4,
+					EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+						Version: "version",
+					},
+					ValidationInfo: &lb.ChaincodeValidationInfo{
+						ValidationPlugin:    "validation-plugin",
+						ValidationParameter: []byte("validation-parameter"),
+					},
+					Collections: arg.Collections,
+				}, nil)
+			})
+
+			It("passes the arguments to the backing scc function implementation and binds the key-level policy", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+
+				Expect(fakeSCCFuncs.UpdateCollectionConfigCallCount()).To(Equal(1))
+				chname, ccname, collections, pubState := fakeSCCFuncs.UpdateCollectionConfigArgsForCall(0)
+				Expect(chname).To(Equal("test-channel"))
+				Expect(ccname).To(Equal("testcc"))
+				Expect(proto.Equal(collections, arg.Collections)).To(BeTrue())
+				Expect(pubState).To(Equal(fakeStub))
+
+				Expect(fakeStub.SetStateValidationParameterCallCount()).To(Equal(1))
+				key, ep := fakeStub.SetStateValidationParameterArgsForCall(0)
+				Expect(key).To(Equal("namespaces/fields/testcc/Collections"))
+				Expect(ep).To(Equal([]byte("validation-parameter")))
+			})
+
+			Context("when the collection config contains an unexpected payload type", func() {
+				BeforeEach(func() {
+					arg.Collections = &pb.CollectionConfigPackage{
+						Config: []*pb.CollectionConfig{{}},
+					}
+					marshaledArg, err = proto.Marshal(arg)
+					Expect(err).NotTo(HaveOccurred())
+					fakeStub.GetArgsReturns([][]byte{[]byte("UpdateCollectionConfig"), marshaledArg})
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(ContainSubstring("collection config contains unexpected payload type"))
+				})
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.UpdateCollectionConfigReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'UpdateCollectionConfig': underlying-error"))
+				})
+			})
+
+			Context("when setting the key-level policy fails", func() {
+				BeforeEach(func() {
+					fakeStub.SetStateValidationParameterReturns(fmt.Errorf("sbe-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'UpdateCollectionConfig': could not set endorsement policy for key namespaces/fields/testcc/Collections: sbe-error"))
+				})
+			})
+		})
+
 		Describe("QueryChaincodeDefinition", func() {
 			var (
 				arg            *lb.QueryChaincodeDefinitionArgs
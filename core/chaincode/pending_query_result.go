@@ -13,12 +13,14 @@ import (
 )
 
 type PendingQueryResult struct {
-	batch []*pb.QueryResultBytes
+	batch      []*pb.QueryResultBytes
+	batchBytes int
 }
 
 func (p *PendingQueryResult) Cut() []*pb.QueryResultBytes {
 	batch := p.batch
 	p.batch = nil
+	p.batchBytes = 0
 	return batch
 }
 
@@ -29,9 +31,16 @@ func (p *PendingQueryResult) Add(queryResult commonledger.QueryResult) error {
 		return err
 	}
 	p.batch = append(p.batch, &pb.QueryResultBytes{ResultBytes: queryResultBytes})
+	p.batchBytes += len(queryResultBytes)
 	return nil
 }
 
 func (p *PendingQueryResult) Size() int {
 	return len(p.batch)
 }
+
+// Bytes returns the number of marshaled result bytes queued up in the
+// pending batch.
+func (p *PendingQueryResult) Bytes() int {
+	return p.batchBytes
+}
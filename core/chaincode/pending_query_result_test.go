@@ -36,6 +36,27 @@ var _ = Describe("PendingQueryResult", func() {
 			}
 		})
 
+		Describe("Bytes", func() {
+			It("returns the number of marshaled result bytes in the batch", func() {
+				Expect(pqr.Bytes()).To(Equal(0))
+
+				expectedBytes := 0
+				for i := 1; i <= 10; i++ {
+					kv := &queryresult.KV{Key: fmt.Sprintf("key-%d", i)}
+					marshaled, err := proto.Marshal(kv)
+					Expect(err).NotTo(HaveOccurred())
+					expectedBytes += len(marshaled)
+
+					err = pqr.Add(kv)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pqr.Bytes()).To(Equal(expectedBytes))
+				}
+
+				pqr.Cut()
+				Expect(pqr.Bytes()).To(Equal(0))
+			})
+		})
+
 		Describe("Add and Cut", func() {
 			It("tracks the query results", func() {
 				By("adding the results")
@@ -38,6 +38,10 @@ const (
 	// CodePackageFile is the expected location of the code package in the
 	// top level of the chaincode package
 	CodePackageFile = "code.tar.gz"
+
+	// SignatureFile is the optional location of the package signature json
+	// document in the top level of the chaincode package.
+	SignatureFile = "signature.json"
 )
 
 //go:generate counterfeiter -o mock/legacy_cc_package_locator.go --fake-name LegacyCCPackageLocator . LegacyCCPackageLocator
@@ -219,6 +223,20 @@ type ChaincodePackage struct {
 	Metadata    *ChaincodePackageMetadata
 	CodePackage []byte
 	DBArtifacts []byte
+	Signature   *ChaincodePackageSignature
+}
+
+// ChaincodePackageSignature contains the serialized identity of the party
+// which signed the chaincode package at packaging time, and its signature
+// over the metadata and code package entries.
+type ChaincodePackageSignature struct {
+	Identity  []byte `json:"identity"`
+	Signature []byte `json:"signature"`
+
+	// Payload is the content covered by Signature, reconstructed by the
+	// parser as the metadata file bytes followed by the code package bytes.
+	// It is not part of the json encoding of the signature file.
+	Payload []byte `json:"-"`
 }
 
 // ChaincodePackageMetadata contains the information necessary to understand
@@ -227,6 +245,9 @@ type ChaincodePackageMetadata struct {
 	Type  string `json:"type"`
 	Path  string `json:"path"`
 	Label string `json:"label"`
+	// EventSchemas holds the payload schemas the chaincode registers for
+	// the events it emits, keyed by event name.
+	EventSchemas map[string]json.RawMessage `json:"event_schemas,omitempty"`
 }
 
 // MetadataProvider provides the means to retrieve metadata
@@ -265,7 +286,9 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 	tarReader := tar.NewReader(gzReader)
 
 	var codePackage []byte
+	var metadataBytes []byte
 	var ccPackageMetadata *ChaincodePackageMetadata
+	var ccPackageSignature *ChaincodePackageSignature
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
@@ -293,9 +316,17 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 			if err != nil {
 				return nil, errors.Wrapf(err, "could not unmarshal %s as json", MetadataFile)
 			}
+			metadataBytes = fileBytes
 
 		case CodePackageFile:
 			codePackage = fileBytes
+
+		case SignatureFile:
+			ccPackageSignature = &ChaincodePackageSignature{}
+			err := json.Unmarshal(fileBytes, ccPackageSignature)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not unmarshal %s as json", SignatureFile)
+			}
 		default:
 			logger.Warningf("Encountered unexpected file '%s' in top level of chaincode package", header.Name)
 		}
@@ -313,6 +344,13 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 		return nil, err
 	}
 
+	if ccPackageSignature != nil {
+		if len(ccPackageSignature.Identity) == 0 || len(ccPackageSignature.Signature) == 0 {
+			return nil, errors.Errorf("%s must contain both an identity and a signature", SignatureFile)
+		}
+		ccPackageSignature.Payload = append(append([]byte{}, metadataBytes...), codePackage...)
+	}
+
 	dbArtifacts, err := ccpp.MetadataProvider.GetDBArtifacts(codePackage)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error retrieving DB artifacts from code package")
@@ -322,5 +360,6 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 		Metadata:    ccPackageMetadata,
 		CodePackage: codePackage,
 		DBArtifacts: dbArtifacts,
+		Signature:   ccPackageSignature,
 	}, nil
 }
@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package persistence_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"io/ioutil"
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
@@ -252,9 +256,88 @@ var _ = Describe("ChaincodePackageParser", func() {
 				Expect(err).To(MatchError("did not find a code package inside the package"))
 			})
 		})
+
+		Context("when the package carries a signature", func() {
+			var (
+				metadataBytes []byte
+				codeBytes     []byte
+			)
+
+			BeforeEach(func() {
+				metadataBytes = []byte(`{"type": "Fake-Type", "path": "Fake-Path", "label": "Real-Label"}`)
+				codeBytes = []byte("fake-code")
+			})
+
+			It("parses the signature and reconstructs the signed payload", func() {
+				signatureBytes, err := json.Marshal(&persistence.ChaincodePackageSignature{
+					Identity:  []byte("fake-identity"),
+					Signature: []byte("fake-signature"),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				data := packageWithFiles(map[string][]byte{
+					"metadata.json":  metadataBytes,
+					"code.tar.gz":    codeBytes,
+					"signature.json": signatureBytes,
+				})
+
+				ccPackage, err := ccpp.Parse(data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccPackage.Signature).To(Equal(&persistence.ChaincodePackageSignature{
+					Identity:  []byte("fake-identity"),
+					Signature: []byte("fake-signature"),
+					Payload:   append(append([]byte{}, metadataBytes...), codeBytes...),
+				}))
+			})
+
+			Context("when the signature file is corrupt", func() {
+				It("fails", func() {
+					data := packageWithFiles(map[string][]byte{
+						"metadata.json":  metadataBytes,
+						"code.tar.gz":    codeBytes,
+						"signature.json": []byte("bad-signature-json"),
+					})
+
+					_, err := ccpp.Parse(data)
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal signature.json as json")))
+				})
+			})
+
+			Context("when the signature file is missing the signature", func() {
+				It("fails", func() {
+					data := packageWithFiles(map[string][]byte{
+						"metadata.json":  metadataBytes,
+						"code.tar.gz":    codeBytes,
+						"signature.json": []byte(`{"identity": "ZmFrZS1pZGVudGl0eQ=="}`),
+					})
+
+					_, err := ccpp.Parse(data)
+					Expect(err).To(MatchError("signature.json must contain both an identity and a signature"))
+				})
+			})
+		})
 	})
 })
 
+func packageWithFiles(files map[string][]byte) []byte {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for name, fileBytes := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Size: int64(len(fileBytes)),
+			Mode: 0100644,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tw.Write(fileBytes)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return payload.Bytes()
+}
+
 var _ = Describe("ChaincodePackageLocator", func() {
 	var (
 		locator *persistence.ChaincodePackageLocator
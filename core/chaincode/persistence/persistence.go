@@ -145,8 +145,7 @@ func (s *Store) Initialize() {
 // Save persists chaincode install package bytes. It returns
 // the hash of the chaincode install package
 func (s *Store) Save(label string, ccInstallPkg []byte) (string, error) {
-	hash := util.ComputeSHA256(ccInstallPkg)
-	packageID := packageID(label, hash)
+	packageID := PackageID(label, ccInstallPkg)
 
 	ccInstallPkgFileName := CCFileName(packageID)
 	ccInstallPkgFilePath := filepath.Join(s.Path, ccInstallPkgFileName)
@@ -234,6 +233,12 @@ func packageID(label string, hash []byte) string {
 	return fmt.Sprintf("%s:%x", label, hash)
 }
 
+// PackageID returns the package ID of a chaincode install package, which is
+// the label combined with the hash of the package bytes.
+func PackageID(label string, ccInstallPkg []byte) string {
+	return packageID(label, util.ComputeSHA256(ccInstallPkg))
+}
+
 func CCFileName(packageID string) string {
 	return strings.Replace(packageID, ":", ".", 1) + ".tar.gz"
 }
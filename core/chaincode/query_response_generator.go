@@ -14,6 +14,7 @@ import (
 
 type QueryResponseGenerator struct {
 	MaxResultLimit int
+	MaxResultBytes int
 }
 
 // BuildQueryResponse takes an iterator and fetch state to construct QueryResponse
@@ -40,11 +41,11 @@ func (q *QueryResponseGenerator) BuildQueryResponse(txContext *TransactionContex
 
 			return createQueryResponse(txContext, iterID, isPaginated, pendingQueryResults, *totalReturnCount)
 
-		case !isPaginated && pendingQueryResults.Size() == q.MaxResultLimit:
+		case !isPaginated && q.batchFull(pendingQueryResults):
 			// if explicit pagination is not used
-			// if the max number of results is queued up, cut batch, then add current result to pending batch
-			// MaxResultLimit is for batching between chaincode shim and handler
-			// MaxResultLimit does not limit the records returned to the client
+			// if the max number of results or result bytes is queued up, cut batch, then add current result to pending batch
+			// the limits are for batching between chaincode shim and handler
+			// they do not limit the records returned to the client
 			batch := pendingQueryResults.Cut()
 			if err := pendingQueryResults.Add(queryResult); err != nil {
 				txContext.CleanupQueryContext(iterID)
@@ -63,6 +64,19 @@ func (q *QueryResponseGenerator) BuildQueryResponse(txContext *TransactionContex
 	}
 }
 
+// batchFull returns true once the pending batch has reached the record count
+// or the byte size bound of a single QueryResponse. The chaincode shim
+// retrieves the remaining results with QUERY_STATE_NEXT messages, so the peer
+// never buffers more than one batch per open iterator. Because the bounds are
+// checked before the next result is added, a batch may exceed MaxResultBytes
+// by at most one record.
+func (q *QueryResponseGenerator) batchFull(pendingQueryResults *PendingQueryResult) bool {
+	if pendingQueryResults.Size() >= q.MaxResultLimit {
+		return true
+	}
+	return q.MaxResultBytes > 0 && pendingQueryResults.Bytes() >= q.MaxResultBytes
+}
+
 func createQueryResponse(txContext *TransactionContext, iterID string, isPaginated bool, pendingQueryResults *PendingQueryResult, totalReturnCount int32) (*pb.QueryResponse, error) {
 
 	batch := pendingQueryResults.Cut()
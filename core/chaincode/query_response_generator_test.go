@@ -12,6 +12,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/mock"
@@ -118,6 +119,63 @@ func TestBuildQueryResponse(t *testing.T) {
 	}
 }
 
+func TestBuildQueryResponseMaxResultBytes(t *testing.T) {
+	queryResult := &queryresult.KV{
+		Key:       "key",
+		Namespace: "namespace",
+		Value:     []byte("value"),
+	}
+	resultBytes, err := proto.Marshal(queryResult)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		maxResultLimit     int
+		maxResultBytes     int
+		recordCount        int
+		expectedBatchSizes []int
+	}{
+		// the byte bound is checked before the next record is added, so a
+		// batch is cut once the queued results reach three records worth of bytes
+		{"byte bound cuts batches", 100, 3 * len(resultBytes), 10, []int{3, 3, 3, 1}},
+		{"record bound cuts first", 2, 3 * len(resultBytes), 5, []int{2, 2, 1}},
+		{"zero byte bound disables the byte check", 10, 0, 5, []int{5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txSimulator := &mock.TxSimulator{}
+			transactionContext := &chaincode.TransactionContext{
+				TXSimulator: txSimulator,
+			}
+
+			resultsIterator := &mock.QueryResultsIterator{}
+			transactionContext.InitializeQueryContext("query-id", resultsIterator)
+			for i := 0; i < tc.recordCount; i++ {
+				resultsIterator.NextReturnsOnCall(i, queryResult, nil)
+			}
+			resultsIterator.NextReturnsOnCall(tc.recordCount, nil, nil)
+			responseGenerator := &chaincode.QueryResponseGenerator{
+				MaxResultLimit: tc.maxResultLimit,
+				MaxResultBytes: tc.maxResultBytes,
+			}
+
+			var batchSizes []int
+			for {
+				queryResponse, err := responseGenerator.BuildQueryResponse(transactionContext, resultsIterator, "query-id", false, totalQueryLimit)
+				assert.NoError(t, err)
+				batchSizes = append(batchSizes, len(queryResponse.GetResults()))
+				if !queryResponse.GetHasMore() {
+					break
+				}
+			}
+
+			assert.Equal(t, tc.expectedBatchSizes, batchSizes)
+			assert.Equal(t, 1, resultsIterator.CloseCallCount())
+		})
+	}
+}
+
 func TestBuildQueryResponseErrors(t *testing.T) {
 	validResult := &queryresult.KV{Key: "key-name"}
 	invalidResult := brokenProto{}
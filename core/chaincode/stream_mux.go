@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// defaultSendWindow is the number of messages a single transaction may have
+// queued for delivery to the chaincode before further sends on behalf of
+// that transaction block.
+const defaultSendWindow = 8
+
+// StreamMux multiplexes message delivery for concurrent transactions over a
+// chaincode chat stream. The register protocol permits a single stream per
+// chaincode, so instead of opening additional connections the mux pools the
+// messages of all transactions into per-transaction queues and drains them
+// onto the stream from a single sender goroutine. Queues are served round
+// robin, so a transaction with a deep backlog cannot head-of-line block
+// responses that belong to other transactions, and each queue is capped by a
+// window so backpressure from a slow stream lands on the transactions
+// producing the traffic rather than on the stream as a whole.
+type StreamMux struct {
+	stream ccintf.ChaincodeStream
+	window int
+
+	mutex sync.Mutex
+	cond  *sync.Cond
+	// queues holds the undelivered messages of each transaction, keyed by
+	// transaction ID. Control messages, such as keepalives, use the empty
+	// transaction ID and share a queue.
+	queues map[string][]*muxSend
+	// txids holds the transaction IDs with undelivered messages in the
+	// round-robin order in which their queues are served.
+	txids  []string
+	err    error
+	closed bool
+}
+
+// muxSend is a queued message along with the channel used to communicate the
+// result of the delivery attempt back to the sender.
+type muxSend struct {
+	msg  *pb.ChaincodeMessage
+	sent chan error
+}
+
+// NewStreamMux creates a StreamMux for the provided stream. A window of zero
+// or less selects defaultSendWindow.
+func NewStreamMux(stream ccintf.ChaincodeStream, window int) *StreamMux {
+	if window <= 0 {
+		window = defaultSendWindow
+	}
+	m := &StreamMux{
+		stream: stream,
+		window: window,
+		queues: map[string][]*muxSend{},
+	}
+	m.cond = sync.NewCond(&m.mutex)
+	return m
+}
+
+// Send queues msg for delivery to the chaincode and blocks until the message
+// has been written to the stream. If the transaction already has a window's
+// worth of undelivered messages, Send blocks until the queue drains; sends
+// on behalf of other transactions are not affected.
+func (m *StreamMux) Send(msg *pb.ChaincodeMessage) error {
+	send := &muxSend{msg: msg, sent: make(chan error, 1)}
+
+	m.mutex.Lock()
+	for m.err == nil && !m.closed && len(m.queues[msg.Txid]) >= m.window {
+		m.cond.Wait()
+	}
+	if m.err != nil {
+		err := m.err
+		m.mutex.Unlock()
+		return err
+	}
+	if m.closed {
+		m.mutex.Unlock()
+		return errors.New("chaincode stream multiplexer is closed")
+	}
+	if len(m.queues[msg.Txid]) == 0 {
+		m.txids = append(m.txids, msg.Txid)
+	}
+	m.queues[msg.Txid] = append(m.queues[msg.Txid], send)
+	m.cond.Broadcast()
+	m.mutex.Unlock()
+
+	return <-send.sent
+}
+
+// Run drains the transaction queues onto the stream until the mux is closed
+// or a send fails. It is intended to be run on a dedicated goroutine; the
+// goroutine is the only writer to the stream while the mux is in use.
+func (m *StreamMux) Run() {
+	for {
+		send, ok := m.nextSend()
+		if !ok {
+			return
+		}
+
+		err := m.stream.Send(send.msg)
+		send.sent <- err
+		if err != nil {
+			m.fail(err)
+			return
+		}
+	}
+}
+
+// Close shuts the mux down. Undelivered messages and subsequent sends fail
+// with an error.
+func (m *StreamMux) Close() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	m.failQueues(errors.New("chaincode stream multiplexer is closed"))
+	m.cond.Broadcast()
+}
+
+// nextSend blocks until a message is available and removes it from its
+// queue. The queue it was removed from is rotated to the back of the
+// round-robin order. nextSend reports false when the mux has been closed.
+func (m *StreamMux) nextSend() (*muxSend, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for len(m.txids) == 0 && !m.closed {
+		m.cond.Wait()
+	}
+	if m.closed {
+		return nil, false
+	}
+
+	txid := m.txids[0]
+	queue := m.queues[txid]
+	send := queue[0]
+
+	if len(queue) == 1 {
+		delete(m.queues, txid)
+		m.txids = m.txids[1:]
+	} else {
+		m.queues[txid] = queue[1:]
+		m.txids = append(m.txids[1:], txid)
+	}
+	m.cond.Broadcast()
+
+	return send, true
+}
+
+// fail records the terminal stream error, fails the undelivered messages,
+// and releases the senders blocked on full queues.
+func (m *StreamMux) fail(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.err = err
+	m.failQueues(err)
+	m.cond.Broadcast()
+}
+
+// failQueues completes all undelivered messages with err. The caller must
+// hold the mutex.
+func (m *StreamMux) failQueues(err error) {
+	for txid, queue := range m.queues {
+		for _, send := range queue {
+			send.sent <- err
+		}
+		delete(m.queues, txid)
+	}
+	m.txids = nil
+}
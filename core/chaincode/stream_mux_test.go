@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/core/chaincode/mock"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamMux", func() {
+	var (
+		fakeChatStream *mock.ChaincodeStream
+		mux            *chaincode.StreamMux
+	)
+
+	newMessage := func(txid, payload string) *pb.ChaincodeMessage {
+		return &pb.ChaincodeMessage{
+			Type:      pb.ChaincodeMessage_RESPONSE,
+			Txid:      txid,
+			ChannelId: "channel-id",
+			Payload:   []byte(payload),
+		}
+	}
+
+	BeforeEach(func() {
+		fakeChatStream = &mock.ChaincodeStream{}
+	})
+
+	AfterEach(func() {
+		mux.Close()
+	})
+
+	It("delivers messages to the stream", func() {
+		mux = chaincode.NewStreamMux(fakeChatStream, 2)
+		go mux.Run()
+
+		err := mux.Send(newMessage("tx-id", "payload"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeChatStream.SendCallCount()).To(Equal(1))
+		Expect(fakeChatStream.SendArgsForCall(0)).To(Equal(newMessage("tx-id", "payload")))
+	})
+
+	It("serves the transaction queues round robin", func() {
+		release := make(chan struct{})
+		fakeChatStream.SendStub = func(*pb.ChaincodeMessage) error {
+			<-release
+			return nil
+		}
+
+		mux = chaincode.NewStreamMux(fakeChatStream, 2)
+		go mux.Run()
+
+		results := make(chan error, 4)
+		send := func(txid, payload string) {
+			go func() { results <- mux.Send(newMessage(txid, payload)) }()
+		}
+
+		send("tx-a", "a1")
+		Eventually(fakeChatStream.SendCallCount).Should(Equal(1))
+		send("tx-a", "a2")
+		Eventually(func() int { return chaincode.MuxQueueDepth(mux, "tx-a") }).Should(Equal(1))
+		send("tx-a", "a3")
+		Eventually(func() int { return chaincode.MuxQueueDepth(mux, "tx-a") }).Should(Equal(2))
+		send("tx-b", "b1")
+		Eventually(func() int { return chaincode.MuxQueueDepth(mux, "tx-b") }).Should(Equal(1))
+
+		close(release)
+		Eventually(fakeChatStream.SendCallCount).Should(Equal(4))
+		for i := 0; i < 4; i++ {
+			Eventually(results).Should(Receive(BeNil()))
+		}
+
+		var payloads []string
+		for i := 0; i < 4; i++ {
+			payloads = append(payloads, string(fakeChatStream.SendArgsForCall(i).Payload))
+		}
+		Expect(payloads).To(Equal([]string{"a1", "a2", "b1", "a3"}))
+	})
+
+	It("blocks sends for a transaction which has exhausted its window", func() {
+		release := make(chan struct{})
+		fakeChatStream.SendStub = func(*pb.ChaincodeMessage) error {
+			<-release
+			return nil
+		}
+
+		mux = chaincode.NewStreamMux(fakeChatStream, 1)
+		go mux.Run()
+
+		results := make(chan error, 3)
+		send := func(txid, payload string) {
+			go func() { results <- mux.Send(newMessage(txid, payload)) }()
+		}
+
+		send("tx-a", "a1")
+		Eventually(fakeChatStream.SendCallCount).Should(Equal(1))
+		send("tx-a", "a2")
+		Eventually(func() int { return chaincode.MuxQueueDepth(mux, "tx-a") }).Should(Equal(1))
+		send("tx-a", "a3")
+
+		Consistently(func() int { return chaincode.MuxQueueDepth(mux, "tx-a") }).Should(Equal(1))
+		Consistently(results).ShouldNot(Receive())
+
+		close(release)
+		Eventually(fakeChatStream.SendCallCount).Should(Equal(3))
+		for i := 0; i < 3; i++ {
+			Eventually(results).Should(Receive(BeNil()))
+		}
+	})
+
+	Context("when sending on the stream fails", func() {
+		BeforeEach(func() {
+			fakeChatStream.SendReturns(errors.New("flour"))
+		})
+
+		It("returns the error to the sender and fails subsequent sends", func() {
+			mux = chaincode.NewStreamMux(fakeChatStream, 2)
+			go mux.Run()
+
+			err := mux.Send(newMessage("tx-id", "payload"))
+			Expect(err).To(MatchError("flour"))
+
+			err = mux.Send(newMessage("tx-id", "payload"))
+			Expect(err).To(MatchError("flour"))
+			Expect(fakeChatStream.SendCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the mux has been closed", func() {
+		It("fails subsequent sends", func() {
+			mux = chaincode.NewStreamMux(fakeChatStream, 2)
+			go mux.Run()
+
+			mux.Close()
+			err := mux.Send(newMessage("tx-id", "payload"))
+			Expect(err).To(MatchError("chaincode stream multiplexer is closed"))
+			Expect(fakeChatStream.SendCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"crypto/x509"
 	"time"
 
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -56,9 +57,15 @@ func NewGRPCClient(config ClientConfig) (*GRPCClient, error) {
 		client.dialOpts = append(client.dialOpts, grpc.FailOnNonTempDialError(true))
 	}
 	client.timeout = config.Timeout
-	// set send/recv message size to package defaults
+	// set send/recv message size to package defaults, unless overridden
 	client.maxRecvMsgSize = MaxRecvMsgSize
 	client.maxSendMsgSize = MaxSendMsgSize
+	if config.MaxRecvMsgSize > 0 {
+		client.maxRecvMsgSize = config.MaxRecvMsgSize
+	}
+	if config.MaxSendMsgSize > 0 {
+		client.maxSendMsgSize = config.MaxSendMsgSize
+	}
 
 	return client, nil
 }
@@ -72,6 +79,10 @@ func (client *GRPCClient) parseSecureOptions(opts SecureOptions) error {
 	client.tlsConfig = &tls.Config{
 		VerifyPeerCertificate: opts.VerifyCertificate,
 		MinVersion:            tls.VersionTLS12} // TLS 1.2 only
+	if fips.Enabled() {
+		client.tlsConfig.CipherSuites = fips.TLSCipherSuites()
+		client.tlsConfig.CurvePreferences = fips.CurvePreferences()
+	}
 	if len(opts.ServerRootCAs) > 0 {
 		client.tlsConfig.RootCAs = x509.NewCertPool()
 		for _, certBytes := range opts.ServerRootCAs {
@@ -84,21 +95,26 @@ func (client *GRPCClient) parseSecureOptions(opts SecureOptions) error {
 		}
 	}
 	if opts.RequireClientCert {
-		// make sure we have both Key and Certificate
-		if opts.Key != nil &&
-			opts.Certificate != nil {
-			cert, err := tls.X509KeyPair(opts.Certificate,
+		// make sure we have both Key (or Signer) and Certificate
+		var cert tls.Certificate
+		var err error
+		switch {
+		case opts.Key != nil && opts.Certificate != nil:
+			cert, err = tls.X509KeyPair(opts.Certificate,
 				opts.Key)
-			if err != nil {
-				return errors.WithMessage(err, "failed to "+
-					"load client certificate")
-			}
-			client.tlsConfig.Certificates = append(
-				client.tlsConfig.Certificates, cert)
-		} else {
+		case opts.Signer != nil && opts.Certificate != nil:
+			cert, err = TLSCertificateWithSigner(opts.Certificate,
+				opts.Signer)
+		default:
 			return errors.New("both Key and Certificate " +
 				"are required when using mutual TLS")
 		}
+		if err != nil {
+			return errors.WithMessage(err, "failed to "+
+				"load client certificate")
+		}
+		client.tlsConfig.Certificates = append(
+			client.tlsConfig.Certificates, cert)
 	}
 
 	if opts.TimeShift > 0 {
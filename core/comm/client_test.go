@@ -449,6 +449,7 @@ func TestSetMessageSize(t *testing.T) {
 		name        string
 		maxRecvSize int
 		maxSendSize int
+		viaConfig   bool
 		failRecv    bool
 		failSend    bool
 	}{
@@ -476,6 +477,20 @@ func TestSetMessageSize(t *testing.T) {
 			failSend:    true,
 			maxSendSize: 1,
 		},
+		{
+			name:        "recv set via config should fail",
+			failRecv:    true,
+			failSend:    false,
+			maxRecvSize: 1,
+			viaConfig:   true,
+		},
+		{
+			name:        "send set via config should fail",
+			failRecv:    false,
+			failSend:    true,
+			maxSendSize: 1,
+			viaConfig:   true,
+		},
 	}
 
 	// set up test client
@@ -491,11 +506,23 @@ func TestSetMessageSize(t *testing.T) {
 		address := lis.Addr().String()
 		t.Run(test.name, func(t *testing.T) {
 			t.Log(test.name)
-			if test.maxRecvSize > 0 {
-				client.SetMaxRecvMsgSize(test.maxRecvSize)
-			}
-			if test.maxSendSize > 0 {
-				client.SetMaxSendMsgSize(test.maxSendSize)
+			client := client
+			if test.viaConfig {
+				client, err = comm.NewGRPCClient(comm.ClientConfig{
+					Timeout:        testTimeout,
+					MaxRecvMsgSize: test.maxRecvSize,
+					MaxSendMsgSize: test.maxSendSize,
+				})
+				if err != nil {
+					t.Fatalf("error creating test client: %v", err)
+				}
+			} else {
+				if test.maxRecvSize > 0 {
+					client.SetMaxRecvMsgSize(test.maxRecvSize)
+				}
+				if test.maxSendSize > 0 {
+					client.SetMaxSendMsgSize(test.maxSendSize)
+				}
 			}
 			conn, err := client.NewConnection(address)
 			assert.NoError(t, err)
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"time"
@@ -64,6 +65,12 @@ type ServerConfig struct {
 	HealthCheckEnabled bool
 	// ServerStatsHandler should be set if metrics on connections are to be reported.
 	ServerStatsHandler *ServerStatsHandler
+	// MaxRecvMsgSize, if set to a positive value, overrides the package-level
+	// MaxRecvMsgSize default for this server
+	MaxRecvMsgSize int
+	// MaxSendMsgSize, if set to a positive value, overrides the package-level
+	// MaxSendMsgSize default for this server
+	MaxSendMsgSize int
 }
 
 // ClientConfig defines the parameters for configuring a GRPCClient instance
@@ -77,6 +84,12 @@ type ClientConfig struct {
 	Timeout time.Duration
 	// AsyncConnect makes connection creation non blocking
 	AsyncConnect bool
+	// MaxRecvMsgSize, if set to a positive value, overrides the package-level
+	// MaxRecvMsgSize default for connections created from this config
+	MaxRecvMsgSize int
+	// MaxSendMsgSize, if set to a positive value, overrides the package-level
+	// MaxSendMsgSize default for connections created from this config
+	MaxSendMsgSize int
 }
 
 // Clone clones this ClientConfig
@@ -96,6 +109,10 @@ type SecureOptions struct {
 	Certificate []byte
 	// PEM-encoded private key to be used for TLS communication
 	Key []byte
+	// Signer, when set, is used instead of Key to perform TLS handshakes.
+	// It typically fronts a private key that never leaves an HSM and is
+	// only reachable through BCCSP, e.g. via PKCS11.
+	Signer crypto.Signer
 	// Set of PEM-encoded X509 certificate authorities used by clients to
 	// verify server certificates
 	ServerRootCAs [][]byte
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -71,10 +72,16 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 
 	secureConfig := serverConfig.SecOpts
 	if secureConfig.UseTLS {
-		//both key and cert are required
-		if secureConfig.Key != nil && secureConfig.Certificate != nil {
+		//both key (or signer) and cert are required
+		if secureConfig.Certificate != nil && (secureConfig.Key != nil || secureConfig.Signer != nil) {
 			//load server public and private keys
-			cert, err := tls.X509KeyPair(secureConfig.Certificate, secureConfig.Key)
+			var cert tls.Certificate
+			var err error
+			if secureConfig.Key != nil {
+				cert, err = tls.X509KeyPair(secureConfig.Certificate, secureConfig.Key)
+			} else {
+				cert, err = TLSCertificateWithSigner(secureConfig.Certificate, secureConfig.Signer)
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -84,6 +91,9 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 			if len(secureConfig.CipherSuites) == 0 {
 				secureConfig.CipherSuites = DefaultTLSCipherSuites
 			}
+			if fips.Enabled() {
+				secureConfig.CipherSuites = fips.TLSCipherSuites()
+			}
 			getCert := func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
 				cert := grpcServer.serverCertificate.Load().(tls.Certificate)
 				return &cert, nil
@@ -95,6 +105,10 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 				SessionTicketsDisabled: true,
 				CipherSuites:           secureConfig.CipherSuites,
 			}
+			if fips.Enabled() {
+				grpcServer.tlsConfig.MinVersion = tls.VersionTLS12
+				grpcServer.tlsConfig.CurvePreferences = fips.CurvePreferences()
+			}
 
 			if serverConfig.SecOpts.TimeShift > 0 {
 				timeShift := serverConfig.SecOpts.TimeShift
@@ -127,9 +141,17 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 			return nil, errors.New("serverConfig.SecOpts must contain both Key and Certificate when UseTLS is true")
 		}
 	}
-	// set max send and recv msg sizes
-	serverOpts = append(serverOpts, grpc.MaxSendMsgSize(MaxSendMsgSize))
-	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(MaxRecvMsgSize))
+	// set max send and recv msg sizes to package defaults, unless overridden
+	maxSendMsgSize := MaxSendMsgSize
+	if serverConfig.MaxSendMsgSize > 0 {
+		maxSendMsgSize = serverConfig.MaxSendMsgSize
+	}
+	maxRecvMsgSize := MaxRecvMsgSize
+	if serverConfig.MaxRecvMsgSize > 0 {
+		maxRecvMsgSize = serverConfig.MaxRecvMsgSize
+	}
+	serverOpts = append(serverOpts, grpc.MaxSendMsgSize(maxSendMsgSize))
+	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(maxRecvMsgSize))
 	// set the keepalive options
 	serverOpts = append(serverOpts, ServerKeepaliveOptions(serverConfig.KaOpts)...)
 	// set connection timeout
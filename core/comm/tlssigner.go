@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+// TLSCertificateWithSigner builds a tls.Certificate from a PEM-encoded
+// certificate chain and a crypto.Signer holding the leaf's private key.
+// It is the counterpart of tls.X509KeyPair for private keys that cannot
+// be read as PEM material, such as keys held in an HSM behind PKCS11.
+func TLSCertificateWithSigner(certPEMBlock []byte, signer crypto.Signer) (tls.Certificate, error) {
+	if signer == nil {
+		return tls.Certificate{}, errors.New("signer must be different from nil")
+	}
+
+	var cert tls.Certificate
+	for rest := certPEMBlock; len(rest) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			cert.Certificate = append(cert.Certificate, block.Bytes)
+		}
+	}
+	if len(cert.Certificate) == 0 {
+		return tls.Certificate{}, errors.New("no certificates found in the PEM block")
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, errors.WithMessage(err, "failed to parse TLS certificate")
+	}
+	pub, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(signer.Public()) {
+		return tls.Certificate{}, errors.New("the TLS certificate's public key does not match the signer")
+	}
+
+	cert.Leaf = leaf
+	cert.PrivateKey = signer
+	return cert, nil
+}
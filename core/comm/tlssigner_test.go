@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/stretchr/testify/assert"
+)
+
+func selfSignedCertificate(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tlssigner.example.com"},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+}
+
+func TestTLSCertificateWithSigner(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM := selfSignedCertificate(t, key)
+
+	cert, err := comm.TLSCertificateWithSigner(certPEM, key)
+	assert.NoError(t, err)
+	assert.Len(t, cert.Certificate, 1)
+	assert.Equal(t, key, cert.PrivateKey)
+	assert.NotNil(t, cert.Leaf)
+}
+
+func TestTLSCertificateWithSignerFailures(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM := selfSignedCertificate(t, key)
+
+	// nil signer
+	_, err = comm.TLSCertificateWithSigner(certPEM, nil)
+	assert.EqualError(t, err, "signer must be different from nil")
+
+	// no certificates in the PEM block
+	_, err = comm.TLSCertificateWithSigner([]byte("not pem"), key)
+	assert.EqualError(t, err, "no certificates found in the PEM block")
+
+	// certificate does not match the signer
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, err = comm.TLSCertificateWithSigner(certPEM, otherKey)
+	assert.EqualError(t, err, "the TLS certificate's public key does not match the signer")
+}
@@ -37,6 +37,29 @@ func (_m *KeyLevelValidationParameterManager) GetValidationParameterForKey(cc st
 	return r0, r1
 }
 
+// GetRangeValidationParameterCoveringKey provides a mock function with given fields: cc, key, blockNum, txNum
+func (_m *KeyLevelValidationParameterManager) GetRangeValidationParameterCoveringKey(cc string, key string, blockNum uint64, txNum uint64) ([]byte, error) {
+	ret := _m.Called(cc, key, blockNum, txNum)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string, uint64, uint64) []byte); ok {
+		r0 = rf(cc, key, blockNum, txNum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, uint64, uint64) error); ok {
+		r1 = rf(cc, key, blockNum, txNum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetTxValidationResult provides a mock function with given fields: cc, blockNum, txNum, err
 func (_m *KeyLevelValidationParameterManager) SetTxValidationResult(cc string, blockNum uint64, txNum uint64, err error) {
 	_m.Called(cc, blockNum, txNum, err)
@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebased
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation parameters can be set not only for individual keys but also for
+// key ranges and composite key prefixes. A range-level validation parameter is
+// recorded as the validation parameter of a reserved marker key that encodes
+// the range - the chaincode puts the marker key and sets its validation
+// parameter in a single transaction, so one metadata write covers the whole
+// range instead of one write per key. A key that carries no validation
+// parameter of its own is validated against the narrowest range that covers
+// it; a key-level parameter always takes precedence. The marker keys
+// themselves are governed by their own validation parameter, so changing a
+// range policy requires an endorsement that satisfies the current one.
+
+const (
+	// rangeValidationParameterKeyPrefix is the reserved prefix of the marker
+	// keys. It starts with the same U+0000 rune that guards composite keys so
+	// that the marker keys cannot collide with regular chaincode keys
+	rangeValidationParameterKeyPrefix = "\x00sbe-range\x00"
+	// rangeValidationParameterKeyPrefixEnd is the end of the key range that
+	// contains all the marker keys of a namespace
+	rangeValidationParameterKeyPrefixEnd = "\x00sbe-range\x01"
+	// rangeValidationParameterKeySep separates the hex encoded start and end
+	// keys of the range within a marker key
+	rangeValidationParameterKeySep = ":"
+)
+
+const minUnicodeRuneValue = rune(0)
+
+// RangeValidationParameterKey returns the reserved marker key under whose
+// validation parameter an endorsement policy covering all the keys of the
+// range [startKey, endKey) is recorded. An empty endKey denotes the end of
+// the namespace
+func RangeValidationParameterKey(startKey, endKey string) string {
+	return rangeValidationParameterKeyPrefix +
+		hex.EncodeToString([]byte(startKey)) +
+		rangeValidationParameterKeySep +
+		hex.EncodeToString([]byte(endKey))
+}
+
+// CompositeKeyPrefixValidationParameterKey returns the reserved marker key
+// under whose validation parameter an endorsement policy covering all the
+// composite keys with the given object type and leading attributes is recorded
+func CompositeKeyPrefixValidationParameterKey(objectType string, attributes []string) string {
+	prefix := compositeKeyPrefix(objectType, attributes)
+	return RangeValidationParameterKey(prefix, prefix+string(utf8.MaxRune))
+}
+
+// compositeKeyPrefix builds the key prefix shared by all the composite keys
+// with the given object type and leading attributes, following the encoding of
+// the chaincode shim
+func compositeKeyPrefix(objectType string, attributes []string) string {
+	prefix := string(minUnicodeRuneValue) + objectType + string(minUnicodeRuneValue)
+	for _, attribute := range attributes {
+		prefix += attribute + string(minUnicodeRuneValue)
+	}
+	return prefix
+}
+
+// isRangeValidationParameterKey returns true if the key is a reserved range
+// marker key
+func isRangeValidationParameterKey(key string) bool {
+	return strings.HasPrefix(key, rangeValidationParameterKeyPrefix)
+}
+
+// decodeRangeValidationParameterKey extracts the start and end keys of the
+// range encoded in a marker key
+func decodeRangeValidationParameterKey(key string) (string, string, bool) {
+	if !isRangeValidationParameterKey(key) {
+		return "", "", false
+	}
+	encoded := strings.SplitN(strings.TrimPrefix(key, rangeValidationParameterKeyPrefix), rangeValidationParameterKeySep, 2)
+	if len(encoded) != 2 {
+		return "", "", false
+	}
+	startKey, err := hex.DecodeString(encoded[0])
+	if err != nil {
+		return "", "", false
+	}
+	endKey, err := hex.DecodeString(encoded[1])
+	if err != nil {
+		return "", "", false
+	}
+	return string(startKey), string(endKey), true
+}
+
+// rangeCovers returns true if the range [startKey, endKey) contains the key -
+// an empty endKey denotes the end of the namespace
+func rangeCovers(startKey, endKey, key string) bool {
+	return key >= startKey && (endKey == "" || key < endKey)
+}
+
+// narrowerRange returns true if the range [start1, end1) is to be preferred
+// over the range [start2, end2) when both cover a key - the range with the
+// greater start key wins and, among ranges with the same start key, the one
+// with the smaller end key wins. The rule is deterministic, so every peer of
+// the channel selects the same range
+func narrowerRange(start1, end1, start2, end2 string) bool {
+	if start1 != start2 {
+		return start1 > start2
+	}
+	switch {
+	case end1 == end2:
+		return false
+	case end1 == "":
+		return false
+	case end2 == "":
+		return true
+	default:
+		return end1 < end2
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebased
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeValidationParameterKeyCodec(t *testing.T) {
+	t.Parallel()
+
+	// the start and end keys survive a round trip even when they contain
+	// the separator or the reserved U+0000 rune
+	for _, keys := range [][]string{
+		{"startKey", "endKey"},
+		{"start:key", "end\x00key"},
+		{"", ""},
+		{"startKey", ""},
+	} {
+		markerKey := RangeValidationParameterKey(keys[0], keys[1])
+		assert.True(t, isRangeValidationParameterKey(markerKey))
+		startKey, endKey, ok := decodeRangeValidationParameterKey(markerKey)
+		assert.True(t, ok)
+		assert.Equal(t, keys[0], startKey)
+		assert.Equal(t, keys[1], endKey)
+	}
+
+	// regular and malformed keys are not marker keys
+	for _, key := range []string{"key", "\x00composite\x00key\x00", rangeValidationParameterKeyPrefix + "missing-separator"} {
+		_, _, ok := decodeRangeValidationParameterKey(key)
+		assert.False(t, ok)
+	}
+	assert.False(t, isRangeValidationParameterKey("key"))
+
+	// a composite key prefix marker covers exactly the composite keys with
+	// the given object type and leading attributes
+	markerKey := CompositeKeyPrefixValidationParameterKey("objectType", []string{"attr1"})
+	startKey, endKey, ok := decodeRangeValidationParameterKey(markerKey)
+	assert.True(t, ok)
+	assert.True(t, rangeCovers(startKey, endKey, "\x00objectType\x00attr1\x00"))
+	assert.True(t, rangeCovers(startKey, endKey, "\x00objectType\x00attr1\x00attr2\x00"))
+	assert.False(t, rangeCovers(startKey, endKey, "\x00objectType\x00attr2\x00"))
+	assert.False(t, rangeCovers(startKey, endKey, "\x00otherType\x00attr1\x00"))
+	assert.False(t, rangeCovers(startKey, endKey, "regularKey"))
+}
+
+func TestRangeCovers(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, rangeCovers("key1", "key5", "key1"))
+	assert.True(t, rangeCovers("key1", "key5", "key4"))
+	assert.False(t, rangeCovers("key1", "key5", "key5"))
+	assert.False(t, rangeCovers("key1", "key5", "key0"))
+	// an empty end key denotes the end of the namespace
+	assert.True(t, rangeCovers("key1", "", "zzz"))
+	// an empty start key denotes the start of the namespace
+	assert.True(t, rangeCovers("", "key5", "aaa"))
+}
+
+func TestNarrowerRange(t *testing.T) {
+	t.Parallel()
+
+	// the greater start key wins
+	assert.True(t, narrowerRange("key2", "key3", "key1", "key5"))
+	assert.False(t, narrowerRange("key1", "key5", "key2", "key3"))
+	// among equal start keys the smaller end key wins
+	assert.True(t, narrowerRange("key1", "key3", "key1", "key5"))
+	assert.False(t, narrowerRange("key1", "key5", "key1", "key3"))
+	// an empty end key denotes the end of the namespace and never wins
+	assert.True(t, narrowerRange("key1", "key3", "key1", ""))
+	assert.False(t, narrowerRange("key1", "", "key1", "key3"))
+	// equal ranges do not replace each other
+	assert.False(t, narrowerRange("key1", "key5", "key1", "key5"))
+}
+
+func TestGetRangeValidationParameterCoveringKey(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: the namespace carries two range validation parameters, a wide
+	// one and a narrow one. A key covered by both is validated against the
+	// narrow one, a key covered only by the wide one against the wide one,
+	// and a key covered by neither carries no range validation parameter
+
+	vpMetadataKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	wideMarkerKey := RangeValidationParameterKey("key1", "key5")
+	narrowMarkerKey := RangeValidationParameterKey("key2", "key3")
+	mr := &mockState{
+		GetStateRangeScanRv: []*queryresult.KV{
+			{Key: wideMarkerKey},
+			{Key: narrowMarkerKey},
+		},
+		GetStateMetadataByKeyRv: map[string]map[string][]byte{
+			wideMarkerKey:   {vpMetadataKey: []byte("WIDEEP")},
+			narrowMarkerKey: {vpMetadataKey: []byte("NARROWEP")},
+		},
+	}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+
+	vp, err := pm.GetRangeValidationParameterCoveringKey("cc", "key2", 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("NARROWEP"), vp)
+
+	vp, err = pm.GetRangeValidationParameterCoveringKey("cc", "key4", 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("WIDEEP"), vp)
+
+	vp, err = pm.GetRangeValidationParameterCoveringKey("cc", "key9", 1, 0)
+	assert.NoError(t, err)
+	assert.Nil(t, vp)
+	assert.True(t, ms.DoneCalled())
+}
+
+func TestGetRangeValidationParameterUpdatedInBlock(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: a transaction of the block updates a range validation
+	// parameter of the namespace. A subsequent transaction of the same block
+	// that relies on range validation parameters is invalidated, whereas a
+	// metadata update to a regular key introduces no range dependency
+
+	mr := &mockState{}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+
+	markerKey := RangeValidationParameterKey("key1", "key5")
+	pm.ExtractValidationParameterDependency(1, 0, rwsetUpdatingMetadataFor("cc", markerKey))
+	pm.SetTxValidationResult("cc", 1, 0, nil)
+
+	_, err := pm.GetRangeValidationParameterCoveringKey("cc", "key2", 1, 1)
+	assert.Error(t, err)
+	assert.IsType(t, &ValidationParameterUpdatedError{}, err)
+
+	pm = &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+	pm.ExtractValidationParameterDependency(1, 0, rwsetUpdatingMetadataFor("cc", "regularKey"))
+	pm.SetTxValidationResult("cc", 1, 0, nil)
+
+	vp, err := pm.GetRangeValidationParameterCoveringKey("cc", "key2", 1, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, vp)
+}
+
+func TestKeylevelValidationRangePolicy(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: we validate a transaction that writes to a key that carries
+	// no key-level validation parameter but is covered by a range validation
+	// parameter. We simulate policy check success and failure
+
+	vpMetadataKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	markerKey := RangeValidationParameterKey("ka", "kz")
+	mr := &mockState{
+		GetStateRangeScanRv: []*queryresult.KV{{Key: markerKey}},
+		GetStateMetadataByKeyRv: map[string]map[string][]byte{
+			markerKey: {vpMetadataKey: []byte("RANGEEP")},
+		},
+	}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{PolicyTranslator: &mockTranslator{}, StateFetcher: ms}
+	pe := &mockPolicyEvaluator{}
+	validator := NewKeyLevelValidator(NewV13Evaluator(pe, pm), pm)
+
+	rwsb := rwsetBytes(t, "cc")
+	prp := []byte("barf")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsb))
+
+	validator.PreValidate(0, block)
+
+	endorsements := []*pb.Endorsement{
+		{
+			Signature: []byte("signature"),
+			Endorser:  []byte("endorser"),
+		},
+	}
+
+	err := validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), endorsements)
+	assert.NoError(t, err)
+
+	// the range policy is evaluated instead of the cc endorsement policy
+	pe.EvaluateResByPolicy = map[string]error{
+		"RANGEEP": fmt.Errorf("range policy evaluation error"),
+	}
+
+	err = validator.Validate("cc", 1, 0, rwsb, prp, []byte("CCEP"), endorsements)
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+	assert.Contains(t, err.Error(), "range policy evaluation error")
+}
@@ -60,6 +60,7 @@ func Test1(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -91,6 +92,7 @@ func Test1Multiple(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): nil}, EvaluateRV: errors.New("nope")}
 
@@ -122,6 +124,7 @@ func Test1NoErr(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, &ledger.CollConfigNotDefinedError{})
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -153,6 +156,7 @@ func Test1Err1(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, &ValidationParameterUpdatedError{})
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -184,6 +188,7 @@ func Test1Err2(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("heavy metal hamster"))
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -215,6 +220,7 @@ func Test1Meta(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -246,6 +252,7 @@ func Test1MetaMultiple(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): nil}, EvaluateRV: errors.New("nope")}
 
@@ -278,6 +285,7 @@ func Test2(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, "", key, mock.Anything, mock.Anything).Return(sbep, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(sbep): errors.New("nope")}}
 
@@ -311,6 +319,7 @@ func Test3(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -345,6 +354,7 @@ func Test3Meta(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(ccep): errors.New("nope")}}
 
@@ -380,6 +390,7 @@ func Test4(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(collep): errors.New("nope")}}
 
@@ -414,6 +425,7 @@ func Test4Multiple(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(collep): nil}, EvaluateRV: errors.New("nope")}
 
@@ -448,6 +460,7 @@ func Test4Err(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{}}
 
@@ -483,6 +496,7 @@ func Test5(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(sbep, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{string(sbep): errors.New("nope")}}
 
@@ -517,6 +531,7 @@ func Test6(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{}}
 
@@ -551,6 +566,7 @@ func Test7(t *testing.T) {
 
 	pm := &mocks.KeyLevelValidationParameterManager{}
 	pm.On("GetValidationParameterForKey", cc, coll, hashedKey, mock.Anything, mock.Anything).Return(nil, nil)
+	pm.On("GetRangeValidationParameterCoveringKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	pe := &mockPolicyEvaluator{EvaluateResByPolicy: map[string]error{}}
 
@@ -34,41 +34,65 @@ type baseEvaluator struct {
 	policySupport validation.PolicyEvaluator
 }
 
+// translateValidationParameterError maps the errors returned by the
+// KeyLevelValidationParameterManager onto the validation outcome, following
+// this rationale:
+// 1) if there is a conflict because validation params have been updated
+//    by another transaction in this block, we will get ValidationParameterUpdatedError.
+//    This should lead to invalidating the transaction by calling policyErr
+// 2) if the ledger returns "determinstic" errors, that is, errors that
+//    every peer in the channel will also return (such as errors linked to
+//    an attempt to retrieve metadata from a non-defined collection) should be
+//    logged and ignored. The ledger will take the most appropriate action
+//    when performing its side of the validation. A nil return value indicates
+//    this case.
+// 3) any other type of error should return an execution failure which will
+//    lead to halting the processing on this channel. Note that any non-categorized
+//    deterministic error would be caught by the default and would lead to
+//    a processing halt. This would certainly be a bug, but - in the absence of a
+//    single, well-defined deterministic error returned by the ledger, it is
+//    best to err on the side of caution and rather halt processing (because a
+//    deterministic error is treated like an I/O one) rather than risking a fork
+//    (in case an I/O error is treated as a deterministic one).
+func translateValidationParameterError(err error) commonerrors.TxValidationError {
+	switch err := errors.Cause(err).(type) {
+	case *ValidationParameterUpdatedError:
+		return policyErr(err)
+	case *ledger.CollConfigNotDefinedError, *ledger.InvalidCollNameError:
+		logger.Warningf(errors.WithMessage(err, "skipping key-level validation").Error())
+		return nil
+	default:
+		return &commonerrors.VSCCExecutionFailureError{
+			Err: err,
+		}
+	}
+}
+
 func (p *baseEvaluator) checkSBAndCCEP(cc, coll, key string, blockNum, txNum uint64, signatureSet []*protoutil.SignedData) commonerrors.TxValidationError {
 	// see if there is a key-level validation parameter for this key
 	vp, err := p.vpmgr.GetValidationParameterForKey(cc, coll, key, blockNum, txNum)
 	if err != nil {
-		// error handling for GetValidationParameterForKey follows this rationale:
-		switch err := errors.Cause(err).(type) {
-		// 1) if there is a conflict because validation params have been updated
-		//    by another transaction in this block, we will get ValidationParameterUpdatedError.
-		//    This should lead to invalidating the transaction by calling policyErr
-		case *ValidationParameterUpdatedError:
-			return policyErr(err)
-		// 2) if the ledger returns "determinstic" errors, that is, errors that
-		//    every peer in the channel will also return (such as errors linked to
-		//    an attempt to retrieve metadata from a non-defined collection) should be
-		//    logged and ignored. The ledger will take the most appropriate action
-		//    when performing its side of the validation.
-		case *ledger.CollConfigNotDefinedError, *ledger.InvalidCollNameError:
-			logger.Warningf(errors.WithMessage(err, "skipping key-level validation").Error())
-			err = nil
-		// 3) any other type of error should return an execution failure which will
-		//    lead to halting the processing on this channel. Note that any non-categorized
-		//    deterministic error would be caught by the default and would lead to
-		//    a processing halt. This would certainly be a bug, but - in the absence of a
-		//    single, well-defined deterministic error returned by the ledger, it is
-		//    best to err on the side of caution and rather halt processing (because a
-		//    deterministic error is treated like an I/O one) rather than risking a fork
-		//    (in case an I/O error is treated as a deterministic one).
-		default:
-			return &commonerrors.VSCCExecutionFailureError{
-				Err: err,
+		if verr := translateValidationParameterError(err); verr != nil {
+			return verr
+		}
+	}
+
+	// a public key that carries no validation parameter of its own may still
+	// be covered by a range-level validation parameter. The reserved range
+	// marker keys are exempt - they are governed by their own validation
+	// parameter, so that changing a range policy requires an endorsement that
+	// satisfies the current one
+	if len(vp) == 0 && coll == "" && !isRangeValidationParameterKey(key) {
+		vp, err = p.vpmgr.GetRangeValidationParameterCoveringKey(cc, key, blockNum, txNum)
+		if err != nil {
+			if verr := translateValidationParameterError(err); verr != nil {
+				return verr
 			}
 		}
 	}
 
-	// if no key-level validation parameter has been specified, the regular cc endorsement policy needs to hold
+	// if no key-level or range-level validation parameter has been specified,
+	// the regular cc endorsement policy needs to hold
 	if len(vp) == 0 {
 		return p.CheckCCEPIfNotChecked(cc, coll, blockNum, txNum, signatureSet)
 	}
@@ -48,6 +48,15 @@ type KeyLevelValidationParameterManager interface {
 	// all txes with txNum smaller than the one supplied by the caller.
 	GetValidationParameterForKey(cc, coll, key string, blockNum, txNum uint64) ([]byte, error)
 
+	// GetRangeValidationParameterCoveringKey returns the validation parameter
+	// recorded for the narrowest key range that covers the supplied public key
+	// of namespace cc, or nil if no range validation parameter covers the key.
+	// The blocking and error semantics of GetValidationParameterForKey apply -
+	// in particular, a ValidationParameterUpdatedError is returned in case any
+	// range validation parameter of the namespace has been changed by a
+	// transaction with txNum smaller than the one supplied by the caller.
+	GetRangeValidationParameterCoveringKey(cc, key string, blockNum, txNum uint64) ([]byte, error)
+
 	// ExtractValidationParameterDependency is used to determine which validation parameters are
 	// updated by transaction at height `blockNum, txNum`. This is needed
 	// to determine which txes have dependencies for specific validation parameters and will
@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
@@ -27,9 +28,12 @@ import (
 
 type mockState struct {
 	GetStateMetadataRv              map[string][]byte
+	GetStateMetadataByKeyRv         map[string]map[string][]byte
 	GetStateMetadataErr             error
 	GetPrivateDataMetadataByHashRv  map[string][]byte
 	GetPrivateDataMetadataByHashErr error
+	GetStateRangeScanRv             []*queryresult.KV
+	GetStateRangeScanErr            error
 	DoneCalled                      bool
 }
 
@@ -38,13 +42,35 @@ func (ms *mockState) GetStateMultipleKeys(namespace string, keys []string) ([][]
 }
 
 func (ms *mockState) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (validation.ResultsIterator, error) {
-	return nil, nil
+	if ms.GetStateRangeScanErr != nil {
+		return nil, ms.GetStateRangeScanErr
+	}
+	return &mockResultsIterator{results: ms.GetStateRangeScanRv}, nil
 }
 
 func (ms *mockState) GetStateMetadata(namespace, key string) (map[string][]byte, error) {
+	if ms.GetStateMetadataByKeyRv != nil {
+		return ms.GetStateMetadataByKeyRv[key], ms.GetStateMetadataErr
+	}
 	return ms.GetStateMetadataRv, ms.GetStateMetadataErr
 }
 
+type mockResultsIterator struct {
+	results []*queryresult.KV
+	cursor  int
+}
+
+func (mri *mockResultsIterator) Next() (validation.QueryResult, error) {
+	if mri.cursor >= len(mri.results) {
+		return nil, nil
+	}
+	res := mri.results[mri.cursor]
+	mri.cursor++
+	return res, nil
+}
+
+func (mri *mockResultsIterator) Close() {}
+
 func (ms *mockState) GetPrivateDataMetadataByHash(namespace, collection string, keyhash []byte) (map[string][]byte, error) {
 	return ms.GetPrivateDataMetadataByHashRv, ms.GetPrivateDataMetadataByHashErr
 }
@@ -85,6 +111,9 @@ func (ms *mockStateFetcher) FetchState() (validation.State, error) {
 			GetStateMetadataErr:             ms.FetchStateRv.GetStateMetadataErr,
 			GetPrivateDataMetadataByHashRv:  ms.FetchStateRv.GetPrivateDataMetadataByHashRv,
 			GetStateMetadataRv:              ms.FetchStateRv.GetStateMetadataRv,
+			GetStateMetadataByKeyRv:         ms.FetchStateRv.GetStateMetadataByKeyRv,
+			GetStateRangeScanRv:             ms.FetchStateRv.GetStateRangeScanRv,
+			GetStateRangeScanErr:            ms.FetchStateRv.GetStateRangeScanErr,
 		}
 		ms.mutex.Lock()
 		if ms.returnedStates != nil {
@@ -9,6 +9,7 @@ package statebased
 import (
 	"sync"
 
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api/state"
@@ -271,6 +272,12 @@ func (m *KeyLevelValidationParameterManagerImpl) ExtractValidationParameterDepen
 			for _, mw := range rws.KvRwSet.MetadataWrites {
 				// record the fact that this key has a dependency on our tx
 				vCtx.addDependency(newLedgerKeyID(rws.NameSpace, "", mw.Key), txNum, dep)
+
+				if isRangeValidationParameterKey(mw.Key) {
+					// a change to any range validation parameter is a dependency
+					// for every key of the namespace that may be covered by a range
+					vCtx.addDependency(newLedgerKeyID(rws.NameSpace, "", rangeValidationParameterKeyPrefix), txNum, dep)
+				}
 			}
 
 			for _, cw := range rws.CollHashedRwSets {
@@ -347,6 +354,90 @@ func (m *KeyLevelValidationParameterManagerImpl) GetValidationParameterForKey(cc
 	return policy, nil
 }
 
+// GetRangeValidationParameterCoveringKey implements the method of
+// the same name of the KeyLevelValidationParameterManager interface
+func (m *KeyLevelValidationParameterManagerImpl) GetRangeValidationParameterCoveringKey(cc, key string, blockNum, txNum uint64) ([]byte, error) {
+	vCtx := m.validationCtx.forBlock(blockNum)
+
+	// wait until all txes before us have introduced dependencies
+	for i := int64(txNum) - 1; i >= 0; i-- {
+		txdep := vCtx.getOrCreateDependencyByTxnum(uint64(i))
+		txdep.waitForDepInserted()
+	}
+
+	// wait until the validation results for all dependencies in the cc namespace are available
+	// bail, if any range validation parameter of the namespace has been updated in the meantime
+	err := vCtx.waitForValidationResults(newLedgerKeyID(cc, "", rangeValidationParameterKeyPrefix), blockNum, txNum)
+	if err != nil {
+		logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	// if we're here, it means that it is safe to retrieve the range validation
+	// parameters for the requested namespace from the ledger
+
+	state, err := m.StateFetcher.FetchState()
+	if err != nil {
+		err = errors.WithMessage(err, "could not retrieve ledger")
+		logger.Errorf(err.Error())
+		return nil, err
+	}
+	defer state.Done()
+
+	// all the range marker keys of the namespace live under a single reserved
+	// prefix, so one range scan retrieves every range policy of the namespace
+	itr, err := state.GetStateRangeScanIterator(cc, rangeValidationParameterKeyPrefix, rangeValidationParameterKeyPrefixEnd)
+	if err != nil {
+		err = errors.WithMessagef(err, "could not retrieve the range validation parameters of namespace %s", cc)
+		logger.Errorf(err.Error())
+		return nil, err
+	}
+	defer itr.Close()
+
+	markerKey := ""
+	markerStartKey, markerEndKey := "", ""
+	found := false
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			err = errors.WithMessagef(err, "could not retrieve the range validation parameters of namespace %s", cc)
+			logger.Errorf(err.Error())
+			return nil, err
+		}
+		if res == nil {
+			break
+		}
+		kv, ok := res.(*queryresult.KV)
+		if !ok {
+			return nil, errors.Errorf("unexpected query result of type %T", res)
+		}
+		startKey, endKey, ok := decodeRangeValidationParameterKey(kv.Key)
+		if !ok || !rangeCovers(startKey, endKey, key) {
+			continue
+		}
+		if !found || narrowerRange(startKey, endKey, markerStartKey, markerEndKey) {
+			markerKey, markerStartKey, markerEndKey, found = kv.Key, startKey, endKey, true
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	mdMap, err := state.GetStateMetadata(cc, markerKey)
+	if err != nil {
+		err = errors.WithMessagef(err, "could not retrieve metadata for %s:%s", cc, markerKey)
+		logger.Errorf(err.Error())
+		return nil, err
+	}
+
+	policy, err := m.PolicyTranslator.Translate(mdMap[pb.MetaDataKeys_VALIDATION_PARAMETER.String()])
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not translate policy for %s:%s", cc, markerKey)
+	}
+
+	return policy, nil
+}
+
 // SetTxValidationCode implements the method of the same name of
 // the KeyLevelValidationParameterManager interface. Note that
 // this function receives a namespace argument so that it records
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// followerBufferSize is the number of lines buffered for each follower of a
+// chaincode's output. A follower that falls this far behind misses lines
+// rather than slowing down log collection.
+const followerBufferSize = 256
+
+// Buffer retains the most recent output of each chaincode in memory and fans
+// new lines out to followers. It is populated by the container launcher and
+// served by the Handler on the operations endpoint.
+type Buffer struct {
+	retainedBytes int
+
+	mutex      sync.Mutex
+	chaincodes map[string]*chaincodeOutput
+}
+
+type chaincodeOutput struct {
+	lines     []string
+	size      int
+	followers map[chan string]struct{}
+}
+
+// NewBuffer creates a Buffer which retains up to retainedBytes of output per
+// chaincode.
+func NewBuffer(retainedBytes int) *Buffer {
+	return &Buffer{
+		retainedBytes: retainedBytes,
+		chaincodes:    map[string]*chaincodeOutput{},
+	}
+}
+
+// ObserveOutput records a line of output for the named chaincode, evicting
+// the oldest retained lines once the retention limit is exceeded, and
+// delivers the line to the followers of the chaincode. A follower with a
+// full delivery channel misses the line instead of blocking log collection.
+func (b *Buffer) ObserveOutput(chaincodeName, line string) {
+	line = strings.TrimRight(line, "\r\n")
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	output := b.output(chaincodeName)
+	output.lines = append(output.lines, line)
+	output.size += len(line) + 1
+	for output.size > b.retainedBytes && len(output.lines) > 1 {
+		output.size -= len(output.lines[0]) + 1
+		output.lines = output.lines[1:]
+	}
+
+	for follower := range output.followers {
+		select {
+		case follower <- line:
+		default:
+		}
+	}
+}
+
+// Chaincodes returns the names of the chaincodes the buffer is tracking, in
+// lexical order.
+func (b *Buffer) Chaincodes() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	names := make([]string, 0, len(b.chaincodes))
+	for name := range b.chaincodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tail returns up to n of the most recently retained lines of output of the
+// named chaincode, oldest first. All retained lines are returned when n is
+// not positive, and nil is returned when the buffer has never seen output
+// for the chaincode.
+func (b *Buffer) Tail(chaincodeName string, n int) []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	output, ok := b.chaincodes[chaincodeName]
+	if !ok {
+		return nil
+	}
+	return append([]string{}, tail(output.lines, n)...)
+}
+
+// Follow returns up to n of the most recently retained lines of output of
+// the named chaincode along with a channel on which subsequent lines are
+// delivered. The follower must call cancel to stop the delivery.
+func (b *Buffer) Follow(chaincodeName string, n int) (lines []string, updates <-chan string, cancel func()) {
+	follower := make(chan string, followerBufferSize)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	output := b.output(chaincodeName)
+	output.followers[follower] = struct{}{}
+
+	cancel = func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		delete(output.followers, follower)
+	}
+	return append([]string{}, tail(output.lines, n)...), follower, cancel
+}
+
+// output returns the retained output of the named chaincode, creating it on
+// first use. The caller must hold the mutex.
+func (b *Buffer) output(chaincodeName string) *chaincodeOutput {
+	output, ok := b.chaincodes[chaincodeName]
+	if !ok {
+		output = &chaincodeOutput{followers: map[chan string]struct{}{}}
+		b.chaincodes[chaincodeName] = output
+	}
+	return output
+}
+
+func tail(lines []string, n int) []string {
+	if n > 0 && n < len(lines) {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
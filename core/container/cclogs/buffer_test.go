@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferTail(t *testing.T) {
+	buffer := NewBuffer(1024)
+	buffer.ObserveOutput("mycc", "one\n")
+	buffer.ObserveOutput("mycc", "two\r\n")
+	buffer.ObserveOutput("mycc", "three")
+	buffer.ObserveOutput("othercc", "other\n")
+
+	require.Equal(t, []string{"one", "two", "three"}, buffer.Tail("mycc", 0))
+	require.Equal(t, []string{"two", "three"}, buffer.Tail("mycc", 2))
+	require.Equal(t, []string{"one", "two", "three"}, buffer.Tail("mycc", 10))
+	require.Equal(t, []string{"other"}, buffer.Tail("othercc", 0))
+	require.Nil(t, buffer.Tail("unknowncc", 0))
+}
+
+func TestBufferRetention(t *testing.T) {
+	buffer := NewBuffer(12)
+	buffer.ObserveOutput("mycc", "one\n")
+	buffer.ObserveOutput("mycc", "two\n")
+	buffer.ObserveOutput("mycc", "three\n")
+
+	// "one" was evicted to make room for "three"
+	require.Equal(t, []string{"two", "three"}, buffer.Tail("mycc", 0))
+
+	// a line over the retention limit is retained until the next line arrives
+	buffer.ObserveOutput("mycc", "a-line-longer-than-the-limit\n")
+	require.Equal(t, []string{"a-line-longer-than-the-limit"}, buffer.Tail("mycc", 0))
+}
+
+func TestBufferChaincodes(t *testing.T) {
+	buffer := NewBuffer(1024)
+	require.Empty(t, buffer.Chaincodes())
+
+	buffer.ObserveOutput("zebra", "z\n")
+	buffer.ObserveOutput("aardvark", "a\n")
+	require.Equal(t, []string{"aardvark", "zebra"}, buffer.Chaincodes())
+}
+
+func TestBufferFollow(t *testing.T) {
+	buffer := NewBuffer(1024)
+	buffer.ObserveOutput("mycc", "one\n")
+	buffer.ObserveOutput("mycc", "two\n")
+
+	lines, updates, cancel := buffer.Follow("mycc", 1)
+	require.Equal(t, []string{"two"}, lines)
+
+	buffer.ObserveOutput("mycc", "three\n")
+	require.Equal(t, "three", <-updates)
+
+	// output for other chaincodes is not delivered
+	buffer.ObserveOutput("othercc", "other\n")
+	select {
+	case line := <-updates:
+		t.Fatalf("unexpected line delivered: %q", line)
+	default:
+	}
+
+	cancel()
+	buffer.ObserveOutput("mycc", "four\n")
+	select {
+	case line := <-updates:
+		t.Fatalf("line delivered after cancel: %q", line)
+	default:
+	}
+}
+
+func TestBufferFollowUnknownChaincode(t *testing.T) {
+	buffer := NewBuffer(1024)
+
+	lines, updates, cancel := buffer.Follow("mycc", 0)
+	defer cancel()
+	require.Empty(t, lines)
+
+	buffer.ObserveOutput("mycc", "one\n")
+	require.Equal(t, "one", <-updates)
+}
+
+func TestBufferSlowFollower(t *testing.T) {
+	buffer := NewBuffer(64 * 1024)
+
+	_, updates, cancel := buffer.Follow("mycc", 0)
+	defer cancel()
+
+	// a follower that does not drain its channel misses lines but does not
+	// block log collection
+	for i := 0; i < followerBufferSize+10; i++ {
+		buffer.ObserveOutput("mycc", "line\n")
+	}
+	require.Len(t, updates, followerBufferSize)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+// NewHandler creates an http.Handler serving the chaincode output retained
+// by the buffer. A GET request without a "chaincode" query parameter lists
+// the chaincodes with output, while a request naming a chaincode returns its
+// most recent output, optionally limited with "tail=n" and streamed as it
+// arrives with "follow=true".
+func NewHandler(buffer *Buffer) *Handler {
+	return &Handler{
+		buffer: buffer,
+		logger: flogging.MustGetLogger("cclogs"),
+	}
+}
+
+// Handler serves retained chaincode output over HTTP.
+type Handler struct {
+	buffer *Buffer
+	logger *flogging.FabricLogger
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendJSON(resp, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid request method: %s", req.Method)})
+		return
+	}
+
+	query := req.URL.Query()
+	chaincodeName := query.Get("chaincode")
+	if chaincodeName == "" {
+		h.sendJSON(resp, http.StatusOK, h.buffer.Chaincodes())
+		return
+	}
+
+	var tail int
+	if tailParam := query.Get("tail"); tailParam != "" {
+		var err error
+		tail, err = strconv.Atoi(tailParam)
+		if err != nil {
+			h.sendJSON(resp, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid tail parameter: %s", tailParam)})
+			return
+		}
+	}
+
+	var follow bool
+	if followParam := query.Get("follow"); followParam != "" {
+		var err error
+		follow, err = strconv.ParseBool(followParam)
+		if err != nil {
+			h.sendJSON(resp, http.StatusBadRequest, &errorResponse{Error: fmt.Sprintf("invalid follow parameter: %s", followParam)})
+			return
+		}
+	}
+
+	if !follow {
+		lines := h.buffer.Tail(chaincodeName, tail)
+		if lines == nil {
+			h.sendJSON(resp, http.StatusNotFound, &errorResponse{Error: fmt.Sprintf("no output retained for chaincode %s", chaincodeName)})
+			return
+		}
+		resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, line := range lines {
+			fmt.Fprintln(resp, line)
+		}
+		return
+	}
+
+	flusher, ok := resp.(http.Flusher)
+	if !ok {
+		h.sendJSON(resp, http.StatusInternalServerError, &errorResponse{Error: "streaming is not supported"})
+		return
+	}
+
+	lines, updates, cancel := h.buffer.Follow(chaincodeName, tail)
+	defer cancel()
+
+	resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, line := range lines {
+		fmt.Fprintln(resp, line)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case line := <-updates:
+			fmt.Fprintln(resp, line)
+			flusher.Flush()
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+func (h *Handler) sendJSON(resp http.ResponseWriter, code int, payload interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cclogs
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerListsChaincodes(t *testing.T) {
+	buffer := NewBuffer(1024)
+	buffer.ObserveOutput("mycc", "one\n")
+	buffer.ObserveOutput("othercc", "other\n")
+
+	resp := httptest.NewRecorder()
+	NewHandler(buffer).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincodelogs", nil))
+
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	require.JSONEq(t, `["mycc", "othercc"]`, resp.Body.String())
+}
+
+func TestHandlerTail(t *testing.T) {
+	buffer := NewBuffer(1024)
+	buffer.ObserveOutput("mycc", "one\n")
+	buffer.ObserveOutput("mycc", "two\n")
+	buffer.ObserveOutput("mycc", "three\n")
+
+	resp := httptest.NewRecorder()
+	NewHandler(buffer).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincodelogs?chaincode=mycc", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "text/plain; charset=utf-8", resp.Header().Get("Content-Type"))
+	require.Equal(t, "one\ntwo\nthree\n", resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	NewHandler(buffer).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincodelogs?chaincode=mycc&tail=2", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.Equal(t, "two\nthree\n", resp.Body.String())
+}
+
+func TestHandlerUnknownChaincode(t *testing.T) {
+	resp := httptest.NewRecorder()
+	NewHandler(NewBuffer(1024)).ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/chaincodelogs?chaincode=mycc", nil))
+
+	require.Equal(t, http.StatusNotFound, resp.Code)
+	require.JSONEq(t, `{"error": "no output retained for chaincode mycc"}`, resp.Body.String())
+}
+
+func TestHandlerBadRequests(t *testing.T) {
+	handler := NewHandler(NewBuffer(1024))
+
+	tests := []struct {
+		method string
+		target string
+		error  string
+	}{
+		{http.MethodPost, "/chaincodelogs", "invalid request method: POST"},
+		{http.MethodGet, "/chaincodelogs?chaincode=mycc&tail=junk", "invalid tail parameter: junk"},
+		{http.MethodGet, "/chaincodelogs?chaincode=mycc&follow=junk", "invalid follow parameter: junk"},
+	}
+	for _, tt := range tests {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(tt.method, tt.target, nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+		require.JSONEq(t, `{"error": "`+tt.error+`"}`, resp.Body.String())
+	}
+}
+
+func TestHandlerFollow(t *testing.T) {
+	buffer := NewBuffer(1024)
+	buffer.ObserveOutput("mycc", "before\n")
+
+	server := httptest.NewServer(NewHandler(buffer))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/chaincodelogs?chaincode=mycc&follow=true")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "before\n", line)
+
+	buffer.ObserveOutput("mycc", "after\n")
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "after\n", line)
+}
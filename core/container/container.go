@@ -33,6 +33,20 @@ type ExternalBuilder interface {
 	Build(ccid string, metadata []byte, codePackageStream io.Reader) (Instance, error)
 }
 
+//go:generate counterfeiter -o mock/kube_builder.go --fake-name KubeBuilder . KubeBuilder
+
+// KubeBuilder is what is exposed by the kubecontroller
+type KubeBuilder interface {
+	Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (Instance, error)
+}
+
+//go:generate counterfeiter -o mock/wasm_builder.go --fake-name WasmBuilder . WasmBuilder
+
+// WasmBuilder is what is exposed by the wasmruntime
+type WasmBuilder interface {
+	Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (Instance, error)
+}
+
 //go:generate counterfeiter -o mock/instance.go --fake-name Instance . Instance
 
 // Instance represents a built chaincode instance, because of the docker legacy, calling this a
@@ -72,6 +86,8 @@ type PackageProvider interface {
 
 type Router struct {
 	ExternalBuilder ExternalBuilder
+	KubeBuilder     KubeBuilder
+	WasmBuilder     WasmBuilder
 	DockerBuilder   DockerBuilder
 	containers      map[string]Instance
 	PackageProvider PackageProvider
@@ -111,6 +127,32 @@ func (r *Router) Build(ccid string) error {
 		}
 	}
 
+	if instance == nil && r.KubeBuilder != nil {
+		metadata, _, codeStream, err := r.PackageProvider.GetChaincodePackage(ccid)
+		if err != nil {
+			return errors.WithMessage(err, "failed to get chaincode package for kubernetes build")
+		}
+		defer codeStream.Close()
+
+		instance, err = r.KubeBuilder.Build(ccid, metadata, codeStream)
+		if err != nil {
+			return errors.WithMessage(err, "kubernetes build failed")
+		}
+	}
+
+	if instance == nil && r.WasmBuilder != nil {
+		metadata, _, codeStream, err := r.PackageProvider.GetChaincodePackage(ccid)
+		if err != nil {
+			return errors.WithMessage(err, "failed to get chaincode package for wasm build")
+		}
+		defer codeStream.Close()
+
+		instance, err = r.WasmBuilder.Build(ccid, metadata, codeStream)
+		if err != nil {
+			return errors.WithMessage(err, "wasm build failed")
+		}
+	}
+
 	if instance == nil {
 		if r.DockerBuilder == nil {
 			return errors.New("no DockerBuilder, cannot build")
@@ -152,6 +152,114 @@ var _ = Describe("Router", func() {
 			})
 		})
 
+		Context("when a kube builder is provided", func() {
+			var fakeKubeBuilder *mock.KubeBuilder
+
+			BeforeEach(func() {
+				fakeKubeBuilder = &mock.KubeBuilder{}
+				fakeKubeBuilder.BuildReturns(fakeInstance, nil)
+				fakeExternalBuilder.BuildReturns(nil, nil)
+				router.KubeBuilder = fakeKubeBuilder
+			})
+
+			It("calls the kube builder and does not call the docker builder", func() {
+				err := router.Build("package-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeKubeBuilder.BuildCallCount()).To(Equal(1))
+				ccid, _, _ := fakeKubeBuilder.BuildArgsForCall(0)
+				Expect(ccid).To(Equal("package-id"))
+				Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(0))
+			})
+
+			Context("when the kube builder returns a nil instance", func() {
+				BeforeEach(func() {
+					fakeKubeBuilder.BuildReturns(nil, nil)
+					fakeDockerBuilder.BuildReturns(fakeInstance, nil)
+				})
+
+				It("falls back to the docker impl", func() {
+					err := router.Build("package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the kube builder returns an error", func() {
+				BeforeEach(func() {
+					fakeKubeBuilder.BuildReturns(nil, errors.New("fake-kube-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Build("package-id")
+					Expect(err).To(MatchError("kubernetes build failed: fake-kube-error"))
+				})
+			})
+		})
+
+		Context("when a wasm builder is provided", func() {
+			var fakeWasmBuilder *mock.WasmBuilder
+
+			BeforeEach(func() {
+				fakeWasmBuilder = &mock.WasmBuilder{}
+				fakeWasmBuilder.BuildReturns(fakeInstance, nil)
+				fakeExternalBuilder.BuildReturns(nil, nil)
+				router.WasmBuilder = fakeWasmBuilder
+			})
+
+			It("calls the wasm builder with the correct args and does not call the docker builder", func() {
+				err := router.Build("package-id")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeWasmBuilder.BuildCallCount()).To(Equal(1))
+				ccid, md, codeStream := fakeWasmBuilder.BuildArgsForCall(0)
+				Expect(ccid).To(Equal("package-id"))
+				Expect(md).To(Equal(&persistence.ChaincodePackageMetadata{
+					Type: "package-type",
+					Path: "package-path",
+				}))
+				codePackage, err := ioutil.ReadAll(codeStream)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(codePackage).To(Equal([]byte("code-bytes")))
+				Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(0))
+			})
+
+			Context("when the wasm builder returns a nil instance", func() {
+				BeforeEach(func() {
+					fakeWasmBuilder.BuildReturns(nil, nil)
+					fakeDockerBuilder.BuildReturns(fakeInstance, nil)
+				})
+
+				It("falls back to the docker impl", func() {
+					err := router.Build("package-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeDockerBuilder.BuildCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the wasm builder returns an error", func() {
+				BeforeEach(func() {
+					fakeWasmBuilder.BuildReturns(nil, errors.New("fake-wasm-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Build("package-id")
+					Expect(err).To(MatchError("wasm build failed: fake-wasm-error"))
+				})
+			})
+
+			Context("when the package provider returns an error before calling the wasm builder", func() {
+				BeforeEach(func() {
+					fakePackageProvider.GetChaincodePackageReturnsOnCall(1, nil, nil, nil, errors.New("fake-package-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					err := router.Build("package-id")
+					Expect(err).To(MatchError("failed to get chaincode package for wasm build: fake-package-error"))
+				})
+			})
+		})
+
 		Context("when an external builder is not provided", func() {
 			BeforeEach(func() {
 				router.ExternalBuilder = nil
@@ -98,6 +98,20 @@ func (ci *ContainerInstance) Wait() (int, error) {
 	return ci.DockerVM.Wait(ci.CCID)
 }
 
+// ContainerLogObserver receives a copy of each line of output of the
+// containers the DockerVM starts, keyed by chaincode name.
+type ContainerLogObserver interface {
+	ObserveOutput(chaincodeName, line string)
+}
+
+// ContainerResourceLimits describes the cpu and memory limits applied to a
+// chaincode container. A non-zero value takes precedence over the
+// corresponding value in the host configuration.
+type ContainerResourceLimits struct {
+	CPUShares int64
+	Memory    int64
+}
+
 // DockerVM is a vm. It is identified by an image id
 type DockerVM struct {
 	PeerID          string
@@ -111,6 +125,18 @@ type DockerVM struct {
 	PlatformBuilder PlatformBuilder
 	LoggingEnv      []string
 	MSPID           string
+
+	// LogObserver, when set, receives a copy of each line of output of every
+	// container the DockerVM starts, keyed by chaincode name -- the portion
+	// of the chaincode ID before the first ':'.
+	LogObserver ContainerLogObserver
+
+	// DefaultResourceLimits applies to the container of every chaincode,
+	// while ResourceLimitsOverrides is keyed by chaincode name -- the
+	// portion of the chaincode ID before the first ':', which is the
+	// package label for chaincodes built by the peer.
+	DefaultResourceLimits   ContainerResourceLimits
+	ResourceLimitsOverrides map[string]ContainerResourceLimits
 }
 
 // HealthCheck checks if the DockerVM is able to communicate with the Docker
@@ -122,7 +148,7 @@ func (vm *DockerVM) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
-func (vm *DockerVM) createContainer(imageID, containerID string, args, env []string) error {
+func (vm *DockerVM) createContainer(imageID, containerID string, args, env []string, hostConfig *docker.HostConfig) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
 	logger.Debugw("create container")
 	_, err := vm.Client.CreateContainer(docker.CreateContainerOptions{
@@ -134,7 +160,7 @@ func (vm *DockerVM) createContainer(imageID, containerID string, args, env []str
 			AttachStdout: vm.AttachStdOut,
 			AttachStderr: vm.AttachStdOut,
 		},
-		HostConfig: vm.HostConfig,
+		HostConfig: hostConfig,
 	})
 	if err != nil {
 		return err
@@ -143,6 +169,46 @@ func (vm *DockerVM) createContainer(imageID, containerID string, args, env []str
 	return nil
 }
 
+// hostConfigFor returns the host configuration to use for the container of
+// the given chaincode. When resource limits are configured for the
+// chaincode, a copy of HostConfig with the limits applied is returned.
+func (vm *DockerVM) hostConfigFor(ccid string) *docker.HostConfig {
+	limits, ok := vm.resourceLimitsFor(ccid)
+	if !ok {
+		return vm.HostConfig
+	}
+
+	hostConfig := &docker.HostConfig{}
+	if vm.HostConfig != nil {
+		configCopy := *vm.HostConfig
+		hostConfig = &configCopy
+	}
+	if limits.CPUShares != 0 {
+		hostConfig.CPUShares = limits.CPUShares
+	}
+	if limits.Memory != 0 {
+		hostConfig.Memory = limits.Memory
+	}
+	return hostConfig
+}
+
+func (vm *DockerVM) resourceLimitsFor(ccid string) (ContainerResourceLimits, bool) {
+	limits, ok := vm.ResourceLimitsOverrides[chaincodeNameFor(ccid)]
+	if !ok {
+		limits = vm.DefaultResourceLimits
+	}
+	return limits, limits != (ContainerResourceLimits{})
+}
+
+// chaincodeNameFor returns the name portion of a chaincode ID -- the package
+// label for chaincodes built by the peer.
+func chaincodeNameFor(ccid string) string {
+	if idx := strings.Index(ccid, ":"); idx >= 0 {
+		return ccid[:idx]
+	}
+	return ccid
+}
+
 func (vm *DockerVM) buildImage(ccid string, reader io.Reader) error {
 	id, err := vm.GetVMNameForDocker(ccid)
 	if err != nil {
@@ -296,16 +362,24 @@ func (vm *DockerVM) Start(ccid string, ccType string, peerConnection *ccintf.Pee
 	env := vm.GetEnv(ccid, peerConnection.TLSConfig)
 	dockerLogger.Debugf("start container with env:\n\t%s", strings.Join(env, "\n\t"))
 
-	err = vm.createContainer(imageName, containerName, args, env)
+	err = vm.createContainer(imageName, containerName, args, env, vm.hostConfigFor(ccid))
 	if err != nil {
 		logger.Errorf("create container failed: %s", err)
 		return err
 	}
 
-	// stream stdout and stderr to chaincode logger
-	if vm.AttachStdOut {
-		containerLogger := flogging.MustGetLogger("peer.chaincode." + containerName)
-		streamOutput(dockerLogger, vm.Client, containerName, containerLogger)
+	// stream stdout and stderr to the chaincode logger and the log observer
+	if vm.AttachStdOut || vm.LogObserver != nil {
+		var containerLogger *flogging.FabricLogger
+		if vm.AttachStdOut {
+			containerLogger = flogging.MustGetLogger("peer.chaincode." + containerName)
+		}
+		var observe func(string)
+		if vm.LogObserver != nil {
+			chaincodeName := chaincodeNameFor(ccid)
+			observe = func(line string) { vm.LogObserver.ObserveOutput(chaincodeName, line) }
+		}
+		streamOutput(dockerLogger, vm.Client, containerName, containerLogger, observe)
 	}
 
 	// upload TLS files to the container before starting it if needed
@@ -376,8 +450,9 @@ func addFiles(tw *tar.Writer, contents map[string][]byte) error {
 	return nil
 }
 
-// streamOutput mirrors output from the named container to a fabric logger.
-func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, containerLogger *flogging.FabricLogger) {
+// streamOutput mirrors output from the named container to a fabric logger
+// and to an observer, either of which may be nil.
+func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, containerLogger *flogging.FabricLogger, observe func(line string)) {
 	// Launch a few go routines to manage output streams from the container.
 	// They will be automatically destroyed when the container exits
 	attached := make(chan struct{})
@@ -423,7 +498,12 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 			// until the pipe is closed
 			line, err := is.ReadString('\n')
 			if len(line) > 0 {
-				containerLogger.Info(line)
+				if containerLogger != nil {
+					containerLogger.Info(line)
+				}
+				if observe != nil {
+					observe(line)
+				}
 			}
 			switch err {
 			case nil:
@@ -203,6 +203,43 @@ func Test_Start(t *testing.T) {
 	gt.Expect(err).NotTo(HaveOccurred())
 }
 
+func TestHostConfigFor(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	hostConfig := &docker.HostConfig{NetworkMode: "host", Memory: 1024}
+	dvm := DockerVM{HostConfig: hostConfig}
+
+	// without resource limits the shared host config is used as-is
+	gt.Expect(dvm.hostConfigFor("simple:1.0")).To(BeIdenticalTo(hostConfig))
+
+	// default limits apply to every chaincode without mutating the original
+	dvm.DefaultResourceLimits = ContainerResourceLimits{CPUShares: 512}
+	hc := dvm.hostConfigFor("simple:1.0")
+	gt.Expect(hc.CPUShares).To(Equal(int64(512)))
+	gt.Expect(hc.Memory).To(Equal(int64(1024)))
+	gt.Expect(hc.NetworkMode).To(Equal("host"))
+	gt.Expect(hostConfig.CPUShares).To(Equal(int64(0)))
+
+	// overrides are keyed by the name portion of the chaincode ID
+	dvm.ResourceLimitsOverrides = map[string]ContainerResourceLimits{
+		"simple": {Memory: 2048},
+	}
+	hc = dvm.hostConfigFor("simple:1.0")
+	gt.Expect(hc.CPUShares).To(Equal(int64(0)))
+	gt.Expect(hc.Memory).To(Equal(int64(2048)))
+	gt.Expect(dvm.hostConfigFor("other:1.0").CPUShares).To(Equal(int64(512)))
+
+	// an all-zero override leaves the chaincode unlimited
+	dvm.ResourceLimitsOverrides["simple"] = ContainerResourceLimits{}
+	gt.Expect(dvm.hostConfigFor("simple:1.0")).To(BeIdenticalTo(hostConfig))
+
+	// a nil host config is tolerated when limits apply
+	dvm.HostConfig = nil
+	hc = dvm.hostConfigFor("other:1.0")
+	gt.Expect(hc.CPUShares).To(Equal(int64(512)))
+	gt.Expect(hc.Memory).To(Equal(int64(0)))
+}
+
 func Test_streamOutput(t *testing.T) {
 	gt := NewGomegaWithT(t)
 
@@ -217,7 +254,10 @@ func Test_streamOutput(t *testing.T) {
 		return <-errCh
 	}
 
-	streamOutput(logger, client, "container-name", containerLogger)
+	observed := make(chan string, 10)
+	observe := func(line string) { observed <- line }
+
+	streamOutput(logger, client, "container-name", containerLogger, observe)
 
 	var opts docker.AttachToContainerOptions
 	gt.Eventually(optsCh).Should(Receive(&opts))
@@ -228,6 +268,7 @@ func Test_streamOutput(t *testing.T) {
 	fmt.Fprintf(opts.OutputStream, "message-two") // does not get written until after stream closed
 	gt.Eventually(containerRecorder).Should(gbytes.Say("message-one"))
 	gt.Consistently(containerRecorder.Entries).Should(HaveLen(1))
+	gt.Eventually(observed).Should(Receive(Equal("message-one\n")))
 
 	close(errCh)
 
@@ -235,6 +276,7 @@ func Test_streamOutput(t *testing.T) {
 	gt.Consistently(recorder.Entries).Should(HaveLen(1))
 	gt.Eventually(containerRecorder).Should(gbytes.Say("message-two"))
 	gt.Consistently(containerRecorder.Entries).Should(HaveLen(2))
+	gt.Eventually(observed).Should(Receive(Equal("message-two")))
 }
 
 func Test_BuildMetric(t *testing.T) {
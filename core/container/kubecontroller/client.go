@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubecontroller
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// serviceAccountDir is where Kubernetes projects the service account of a
+// pod, including the token and the CA certificate of the API server.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// Client is a minimal Kubernetes API client. It supports exactly the
+// operations the chaincode controller needs - creating and deleting
+// namespaced resources - and authenticates with the service account of the
+// peer pod.
+type Client struct {
+	// BaseURL is the root URL of the Kubernetes API server.
+	BaseURL string
+	// Namespace is the namespace the chaincode resources are managed in.
+	Namespace string
+	// Token is the bearer token presented to the API server.
+	Token string
+	// HTTPClient is the client used to reach the API server.
+	HTTPClient *http.Client
+}
+
+// NewInClusterClient builds a client from the service account projected into
+// the peer pod. It fails when the peer does not run inside a Kubernetes
+// cluster.
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("peer is not running inside a Kubernetes cluster")
+	}
+
+	token, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read service account token")
+	}
+	caCert, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read API server CA certificate")
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("could not parse API server CA certificate")
+	}
+	namespace, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read service account namespace")
+	}
+
+	return &Client{
+		BaseURL:   "https://" + net.JoinHostPort(host, port),
+		Namespace: strings.TrimSpace(string(namespace)),
+		Token:     strings.TrimSpace(string(token)),
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: caCertPool},
+			},
+		},
+	}, nil
+}
+
+// Create creates the resource described by manifest below the collection
+// path, e.g. "/apis/apps/v1/namespaces/ns/deployments".
+func (c *Client) Create(collectionPath string, manifest interface{}) error {
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.WithMessage(err, "could not marshal manifest")
+	}
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+collectionPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, http.StatusOK, http.StatusCreated, http.StatusAccepted)
+}
+
+// Delete removes the named resource. Deleting a resource that does not exist
+// is not an error, so Delete can be used to clean up partially created
+// chaincode deployments.
+func (c *Client) Delete(resourcePath string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.BaseURL+resourcePath, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, http.StatusAccepted, http.StatusNotFound)
+}
+
+func (c *Client) do(req *http.Request, acceptedStatus ...int) error {
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return errors.WithMessage(err, "could not reach the Kubernetes API server")
+	}
+	defer resp.Body.Close()
+
+	for _, status := range acceptedStatus {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	response, _ := ioutil.ReadAll(resp.Body)
+	return errors.Errorf("the Kubernetes API server returned status %d for %s %s: %s",
+		resp.StatusCode, req.Method, req.URL.Path, strings.TrimSpace(string(response)))
+}
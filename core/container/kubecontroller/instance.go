@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubecontroller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// dialTimeout is the timeout for the peer connecting to the chaincode pod.
+const dialTimeout = 3 * time.Second
+
+// tlsMountPath is where the TLS secret is projected into the chaincode pod.
+const tlsMountPath = "/var/hyperledger/tls"
+
+// Instance is a chaincode running as a Kubernetes Deployment. The chaincode
+// acts as a server the peer connects to, so Start and Wait are never used;
+// the resources are created when the chaincode is built and deleted when it
+// is stopped.
+type Instance struct {
+	CCID          string
+	Client        *Client
+	Name          string
+	PeerID        string
+	Image         string
+	ServiceHost   string
+	CACert        []byte
+	ServerKeyPair *tlsgen.CertKeyPair
+	ClientKeyPair *tlsgen.CertKeyPair
+}
+
+func (i *Instance) labels() map[string]string {
+	return map[string]string{
+		"app":                        i.Name,
+		"fabric-chaincode-peer-name": i.PeerID,
+	}
+}
+
+// create brings up the Secret, Deployment and Service of the chaincode.
+// Resources left behind by a previous incarnation of the chaincode are
+// removed first, so the pod always runs with the freshly issued TLS
+// material.
+func (i *Instance) create() error {
+	if err := i.delete(); err != nil {
+		return err
+	}
+
+	err := i.Client.Create(i.secretsPath(), &secret{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Metadata:   objectMeta{Name: i.Name, Labels: i.labels()},
+		StringData: map[string]string{
+			"server.key":    string(i.ServerKeyPair.Key),
+			"server.crt":    string(i.ServerKeyPair.Cert),
+			"client-ca.crt": string(i.CACert),
+		},
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "could not create secret for chaincode %s", i.CCID)
+	}
+
+	err = i.Client.Create(i.deploymentsPath(), &deployment{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   objectMeta{Name: i.Name, Labels: i.labels()},
+		Spec: deploymentSpec{
+			Replicas: 1,
+			Selector: labelSelector{MatchLabels: i.labels()},
+			Template: podTemplate{
+				Metadata: objectMeta{Labels: i.labels()},
+				Spec: podSpec{
+					Containers: []podContainer{{
+						Name:  "chaincode",
+						Image: i.Image,
+						Env: []envVar{
+							{Name: "CHAINCODE_ID", Value: i.CCID},
+							{Name: "CHAINCODE_SERVER_ADDRESS", Value: fmt.Sprintf("0.0.0.0:%d", chaincodePort)},
+							{Name: "CHAINCODE_TLS_KEY_FILE", Value: tlsMountPath + "/server.key"},
+							{Name: "CHAINCODE_TLS_CERT_FILE", Value: tlsMountPath + "/server.crt"},
+							{Name: "CHAINCODE_CLIENT_CA_FILE", Value: tlsMountPath + "/client-ca.crt"},
+						},
+						Ports:        []podPort{{ContainerPort: chaincodePort}},
+						VolumeMounts: []podMount{{Name: "tls", MountPath: tlsMountPath, ReadOnly: true}},
+					}},
+					Volumes: []podVolume{{Name: "tls", Secret: &secretVolume{SecretName: i.Name}}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "could not create deployment for chaincode %s", i.CCID)
+	}
+
+	err = i.Client.Create(i.servicesPath(), &service{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   objectMeta{Name: i.Name, Labels: i.labels()},
+		Spec: serviceSpec{
+			Selector: i.labels(),
+			Ports:    []servicePort{{Name: "chaincode", Port: chaincodePort, TargetPort: chaincodePort}},
+		},
+	})
+	if err != nil {
+		return errors.WithMessagef(err, "could not create service for chaincode %s", i.CCID)
+	}
+
+	logger.Debugf("created deployment %s for chaincode %s", i.Name, i.CCID)
+	return nil
+}
+
+func (i *Instance) delete() error {
+	for _, resourcePath := range []string{
+		i.servicesPath() + "/" + i.Name,
+		i.deploymentsPath() + "/" + i.Name,
+		i.secretsPath() + "/" + i.Name,
+	} {
+		if err := i.Client.Delete(resourcePath); err != nil {
+			return errors.WithMessagef(err, "could not delete resources of chaincode %s", i.CCID)
+		}
+	}
+	return nil
+}
+
+func (i *Instance) secretsPath() string {
+	return "/api/v1/namespaces/" + i.Client.Namespace + "/secrets"
+}
+
+func (i *Instance) servicesPath() string {
+	return "/api/v1/namespaces/" + i.Client.Namespace + "/services"
+}
+
+func (i *Instance) deploymentsPath() string {
+	return "/apis/apps/v1/namespaces/" + i.Client.Namespace + "/deployments"
+}
+
+// ChaincodeServerInfo tells the peer how to connect to the chaincode
+// Service with the bootstrapped mutual TLS material.
+func (i *Instance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
+	connInfo := &ccintf.ChaincodeServerInfo{
+		Address: fmt.Sprintf("%s:%d", i.ServiceHost, chaincodePort),
+	}
+	connInfo.ClientConfig.Timeout = dialTimeout
+	connInfo.ClientConfig.KaOpts = comm.DefaultKeepaliveOptions
+	connInfo.ClientConfig.SecOpts.UseTLS = true
+	connInfo.ClientConfig.SecOpts.ServerRootCAs = [][]byte{i.CACert}
+	connInfo.ClientConfig.SecOpts.RequireClientCert = true
+	connInfo.ClientConfig.SecOpts.Certificate = i.ClientKeyPair.Cert
+	connInfo.ClientConfig.SecOpts.Key = i.ClientKeyPair.Key
+	return connInfo, nil
+}
+
+// Start is not used; the chaincode runs as a server the peer connects to.
+func (i *Instance) Start(peerConnection *ccintf.PeerConnection) error {
+	return errors.Errorf("chaincode %s runs as a server, it cannot be started by the peer", i.CCID)
+}
+
+// Stop removes the Kubernetes resources of the chaincode.
+func (i *Instance) Stop() error {
+	return i.delete()
+}
+
+// Wait is not used; the chaincode runs as a server the peer connects to.
+func (i *Instance) Wait() (int, error) {
+	return -1, errors.Errorf("chaincode %s runs as a server, it cannot be waited on", i.CCID)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kubecontroller launches chaincode as a service on a Kubernetes
+// cluster, so operators no longer need to script their own external builder
+// to run chaincode in pods. A chaincode package of the "k8s" type carries no
+// source; its code package names a prebuilt chaincode server image. For
+// every such chaincode the controller creates a Deployment and a Service in
+// the configured namespace and bootstraps mutual TLS between the peer and
+// the pod with a chaincode specific certificate authority: the server key
+// material is handed to the pod through a Secret and the peer connects to
+// the Service with a client certificate issued by the same CA.
+package kubecontroller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("kubecontroller")
+
+// PackageType is the chaincode package metadata type handled by this
+// controller.
+const PackageType = "k8s"
+
+// chaincodePort is the port the chaincode server pod listens on.
+const chaincodePort = 7052
+
+// imageSpec is the content of the image.json file of a k8s code package.
+type imageSpec struct {
+	// Name is the image reference without a tag or digest.
+	Name string `json:"name"`
+	// Digest pins the image content, so every peer runs the same chaincode
+	// binary.
+	Digest string `json:"digest"`
+}
+
+func (s *imageSpec) reference() string {
+	return s.Name + "@" + s.Digest
+}
+
+// KubeVM builds and runs chaincode as Kubernetes resources. It is registered
+// on the container Router alongside the other builders and claims only
+// packages of the k8s type.
+type KubeVM struct {
+	// Client reaches the Kubernetes API server.
+	Client *Client
+	// PeerID labels the created resources, so several peers can share a
+	// namespace.
+	PeerID string
+}
+
+// Build reads the image reference from the code package and prepares the TLS
+// material of the chaincode. A nil instance is returned for packages of any
+// other type so that the Router can try the remaining builders. The
+// Kubernetes resources are created here as well - the pod must be running
+// before the peer can connect to it - and are removed again when the
+// instance is stopped.
+func (vm *KubeVM) Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (container.Instance, error) {
+	if !strings.EqualFold(metadata.Type, PackageType) {
+		return nil, nil
+	}
+
+	spec, err := readImageSpec(codePackageStream)
+	if err != nil {
+		return nil, err
+	}
+
+	name := objectName(vm.PeerID, ccid)
+	serviceHost := fmt.Sprintf("%s.%s.svc.cluster.local", name, vm.Client.Namespace)
+
+	ca, err := tlsgen.NewCA()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create chaincode CA")
+	}
+	serverKeyPair, err := ca.NewServerCertKeyPair(serviceHost)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create chaincode server key pair")
+	}
+	clientKeyPair, err := ca.NewClientCertKeyPair()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create peer client key pair")
+	}
+
+	instance := &Instance{
+		CCID:          ccid,
+		Client:        vm.Client,
+		Name:          name,
+		PeerID:        vm.PeerID,
+		Image:         spec.reference(),
+		ServiceHost:   serviceHost,
+		CACert:        ca.CertBytes(),
+		ServerKeyPair: serverKeyPair,
+		ClientKeyPair: clientKeyPair,
+	}
+	if err := instance.create(); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// readImageSpec extracts image.json from the gzip-ed tar code package.
+func readImageSpec(codePackageStream io.Reader) (*imageSpec, error) {
+	gzr, err := gzip.NewReader(codePackageStream)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read code package")
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.New("code package contains no image.json")
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not get next tar element")
+		}
+		if header.Typeflag != tar.TypeReg || strings.TrimPrefix(header.Name, "./") != "image.json" {
+			continue
+		}
+
+		specBytes, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not read image.json")
+		}
+		spec := &imageSpec{}
+		if err := json.Unmarshal(specBytes, spec); err != nil {
+			return nil, errors.WithMessage(err, "malformed image.json")
+		}
+		if spec.Name == "" {
+			return nil, errors.New("image.json does not name an image")
+		}
+		if spec.Digest == "" {
+			return nil, errors.New("image.json does not pin the image to a digest")
+		}
+		return spec, nil
+	}
+}
+
+// objectName derives a DNS-1123 compatible resource name from the peer ID
+// and the chaincode package ID. A hash of the original IDs keeps distinct
+// chaincodes distinct after the character mapping.
+func objectName(peerID, ccid string) string {
+	hash := sha256.Sum256([]byte(peerID + "|" + ccid))
+
+	mapped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '-'
+		}
+	}, peerID+"-"+ccid)
+	mapped = strings.Trim(mapped, "-")
+	if len(mapped) > 40 {
+		mapped = mapped[:40]
+	}
+
+	return fmt.Sprintf("cc-%s-%s", mapped, hex.EncodeToString(hash[:])[:8])
+}
@@ -0,0 +1,231 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubecontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIServer records the requests the controller sends to the Kubernetes
+// API server and answers them like an empty cluster would.
+type fakeAPIServer struct {
+	*httptest.Server
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	method string
+	path   string
+	body   []byte
+}
+
+func newFakeAPIServer() *fakeAPIServer {
+	f := &fakeAPIServer{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		f.requests = append(f.requests, recordedRequest{method: r.Method, path: r.URL.Path, body: body})
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			// nothing exists yet in the fake cluster
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	return f
+}
+
+func (f *fakeAPIServer) client() *Client {
+	return &Client{
+		BaseURL:    f.URL,
+		Namespace:  "test-namespace",
+		Token:      "test-token",
+		HTTPClient: f.Server.Client(),
+	}
+}
+
+func TestBuildIgnoresOtherPackageTypes(t *testing.T) {
+	vm := &KubeVM{}
+	instance, err := vm.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "golang"},
+		bytes.NewReader(nil),
+	)
+	assert.NoError(t, err)
+	assert.Nil(t, instance)
+}
+
+func TestBuildCreatesResources(t *testing.T) {
+	server := newFakeAPIServer()
+	defer server.Close()
+
+	vm := &KubeVM{Client: server.client(), PeerID: "peer0"}
+	instance, err := vm.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "K8S"},
+		codePackage(t, map[string][]byte{
+			"image.json": []byte(`{"name":"registry.example.com/testcc","digest":"sha256:abcd"}`),
+		}),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, instance)
+
+	var created []recordedRequest
+	for _, req := range server.requests {
+		if req.method == http.MethodPost {
+			created = append(created, req)
+		}
+	}
+	require.Len(t, created, 3)
+	assert.Equal(t, "/api/v1/namespaces/test-namespace/secrets", created[0].path)
+	assert.Equal(t, "/apis/apps/v1/namespaces/test-namespace/deployments", created[1].path)
+	assert.Equal(t, "/api/v1/namespaces/test-namespace/services", created[2].path)
+
+	var dep deployment
+	require.NoError(t, json.Unmarshal(created[1].body, &dep))
+	container := dep.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "registry.example.com/testcc@sha256:abcd", container.Image)
+	assert.Contains(t, container.Env, envVar{Name: "CHAINCODE_ID", Value: "testcc:0"})
+
+	kubeInstance := instance.(*Instance)
+	assert.Regexp(t, regexp.MustCompile(`^cc-peer0-testcc-0-[0-9a-f]{8}$`), kubeInstance.Name)
+
+	serverInfo, err := instance.ChaincodeServerInfo()
+	require.NoError(t, err)
+	assert.Equal(t, kubeInstance.Name+".test-namespace.svc.cluster.local:7052", serverInfo.Address)
+	assert.True(t, serverInfo.ClientConfig.SecOpts.UseTLS)
+	assert.True(t, serverInfo.ClientConfig.SecOpts.RequireClientCert)
+	assert.Equal(t, [][]byte{kubeInstance.CACert}, serverInfo.ClientConfig.SecOpts.ServerRootCAs)
+}
+
+func TestBuildBadCodePackages(t *testing.T) {
+	server := newFakeAPIServer()
+	defer server.Close()
+
+	vm := &KubeVM{Client: server.client(), PeerID: "peer0"}
+	metadata := &persistence.ChaincodePackageMetadata{Type: "k8s"}
+
+	_, err := vm.Build("testcc:0", metadata, codePackage(t, map[string][]byte{"other.json": []byte("{}")}))
+	assert.EqualError(t, err, "code package contains no image.json")
+
+	_, err = vm.Build("testcc:0", metadata, codePackage(t, map[string][]byte{"image.json": []byte(`{"digest":"sha256:abcd"}`)}))
+	assert.EqualError(t, err, "image.json does not name an image")
+
+	_, err = vm.Build("testcc:0", metadata, codePackage(t, map[string][]byte{"image.json": []byte(`{"name":"img"}`)}))
+	assert.EqualError(t, err, "image.json does not pin the image to a digest")
+
+	_, err = vm.Build("testcc:0", metadata, codePackage(t, map[string][]byte{"image.json": []byte("not-json")}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed image.json")
+}
+
+func TestStopDeletesResources(t *testing.T) {
+	server := newFakeAPIServer()
+	defer server.Close()
+
+	vm := &KubeVM{Client: server.client(), PeerID: "peer0"}
+	instance, err := vm.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "k8s"},
+		codePackage(t, map[string][]byte{
+			"image.json": []byte(`{"name":"registry.example.com/testcc","digest":"sha256:abcd"}`),
+		}),
+	)
+	require.NoError(t, err)
+
+	server.requests = nil
+	require.NoError(t, instance.Stop())
+
+	require.Len(t, server.requests, 3)
+	name := instance.(*Instance).Name
+	assert.Equal(t, "/api/v1/namespaces/test-namespace/services/"+name, server.requests[0].path)
+	assert.Equal(t, "/apis/apps/v1/namespaces/test-namespace/deployments/"+name, server.requests[1].path)
+	assert.Equal(t, "/api/v1/namespaces/test-namespace/secrets/"+name, server.requests[2].path)
+	for _, req := range server.requests {
+		assert.Equal(t, http.MethodDelete, req.method)
+	}
+}
+
+func TestAPIServerErrorsAreReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("secrets is forbidden"))
+	}))
+	defer server.Close()
+
+	vm := &KubeVM{
+		Client: &Client{
+			BaseURL:    server.URL,
+			Namespace:  "test-namespace",
+			HTTPClient: server.Client(),
+		},
+		PeerID: "peer0",
+	}
+	_, err := vm.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "k8s"},
+		codePackage(t, map[string][]byte{
+			"image.json": []byte(`{"name":"registry.example.com/testcc","digest":"sha256:abcd"}`),
+		}),
+	)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "returned status 403")
+	assert.Contains(t, err.Error(), "secrets is forbidden")
+}
+
+func TestStartAndWaitNotSupported(t *testing.T) {
+	instance := &Instance{CCID: "testcc:0"}
+
+	err := instance.Start(nil)
+	assert.EqualError(t, err, "chaincode testcc:0 runs as a server, it cannot be started by the peer")
+
+	_, err = instance.Wait()
+	assert.EqualError(t, err, "chaincode testcc:0 runs as a server, it cannot be waited on")
+}
+
+func TestObjectName(t *testing.T) {
+	name := objectName("Peer0.Org1", "mycc:74f1e5a3")
+	assert.Regexp(t, regexp.MustCompile(`^cc-[a-z0-9-]+-[0-9a-f]{8}$`), name)
+	assert.True(t, len(name) <= 63)
+
+	// distinct inputs that map to the same characters stay distinct
+	assert.NotEqual(t, objectName("peer0", "cc:1"), objectName("peer0", "cc_1"))
+}
+
+func codePackage(t *testing.T, files map[string][]byte) io.Reader {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0600,
+			Size: int64(len(content)),
+		})
+		require.NoError(t, err)
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf
+}
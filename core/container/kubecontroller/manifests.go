@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kubecontroller
+
+// The types below model the small subset of the Kubernetes resource schemas
+// the chaincode controller creates. They marshal to manifests the API server
+// accepts; fields the controller never sets are omitted.
+
+type objectMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type secret struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   objectMeta        `json:"metadata"`
+	StringData map[string]string `json:"stringData"`
+}
+
+type service struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   objectMeta  `json:"metadata"`
+	Spec       serviceSpec `json:"spec"`
+}
+
+type serviceSpec struct {
+	Selector map[string]string `json:"selector"`
+	Ports    []servicePort     `json:"ports"`
+}
+
+type servicePort struct {
+	Name       string `json:"name"`
+	Port       int    `json:"port"`
+	TargetPort int    `json:"targetPort"`
+}
+
+type deployment struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Metadata   objectMeta     `json:"metadata"`
+	Spec       deploymentSpec `json:"spec"`
+}
+
+type deploymentSpec struct {
+	Replicas int           `json:"replicas"`
+	Selector labelSelector `json:"selector"`
+	Template podTemplate   `json:"template"`
+}
+
+type labelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels"`
+}
+
+type podTemplate struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     podSpec    `json:"spec"`
+}
+
+type podSpec struct {
+	Containers []podContainer `json:"containers"`
+	Volumes    []podVolume    `json:"volumes,omitempty"`
+}
+
+type podContainer struct {
+	Name         string     `json:"name"`
+	Image        string     `json:"image"`
+	Env          []envVar   `json:"env,omitempty"`
+	Ports        []podPort  `json:"ports,omitempty"`
+	VolumeMounts []podMount `json:"volumeMounts,omitempty"`
+}
+
+type envVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type podPort struct {
+	ContainerPort int `json:"containerPort"`
+}
+
+type podMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+type podVolume struct {
+	Name   string        `json:"name"`
+	Secret *secretVolume `json:"secret,omitempty"`
+}
+
+type secretVolume struct {
+	SecretName string `json:"secretName"`
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmruntime
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/pkg/errors"
+)
+
+// Names of the TLS client material files written next to the module for the
+// engine to hand to the guest.
+const (
+	tlsClientKeyFile      = "client_pem.key"
+	tlsClientCertFile     = "client_pem.crt"
+	tlsClientRootCertFile = "peer.crt"
+)
+
+// ProcessEngine runs modules with a WebAssembly engine binary installed next
+// to the peer, such as wasmtime or wasmer. The engine is started as
+//
+//	<path> [args...] <module.wasm>
+//
+// and receives the resource limits and the connection properties of the peer
+// in its environment:
+//
+//	WASM_MAX_MEMORY_PAGES   maximum number of 64KiB linear memory pages
+//	WASM_MAX_INSTRUCTIONS   maximum number of instructions
+//	CORE_CHAINCODE_ID_NAME  package ID the guest registers with
+//	CORE_PEER_ADDRESS       chaincode listen address of the peer
+//	CORE_PEER_TLS_ENABLED   whether the guest must present TLS client material
+//
+// When TLS is enabled, the PEM encoded client key, client certificate and
+// peer root certificate are written next to the module and their paths are
+// exposed as CORE_TLS_CLIENT_KEY_FILE, CORE_TLS_CLIENT_CERT_FILE and
+// CORE_PEER_TLS_ROOTCERT_FILE.
+type ProcessEngine struct {
+	// Path is the location of the engine binary.
+	Path string
+	// Args are additional arguments passed to the engine ahead of the module
+	// path.
+	Args []string
+}
+
+// Launch starts the engine process for the module and returns its session.
+func (e *ProcessEngine) Launch(ccid string, modulePath string, limits Limits, peerConnection *ccintf.PeerConnection) (*externalbuilder.Session, error) {
+	args := append([]string{}, e.Args...)
+	args = append(args, modulePath)
+	cmd := exec.Command(e.Path, args...)
+	cmd.Env = append(cmd.Env,
+		"WASM_MAX_MEMORY_PAGES="+strconv.FormatUint(limits.MaxMemoryPages, 10),
+		"WASM_MAX_INSTRUCTIONS="+strconv.FormatUint(limits.MaxInstructions, 10),
+		"CORE_CHAINCODE_ID_NAME="+ccid,
+		"CORE_PEER_ADDRESS="+peerConnection.Address,
+	)
+
+	if peerConnection.TLSConfig != nil {
+		tlsEnv, err := writeTLSClientMaterial(filepath.Dir(modulePath), peerConnection.TLSConfig)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Env = append(cmd.Env, "CORE_PEER_TLS_ENABLED=true")
+		cmd.Env = append(cmd.Env, tlsEnv...)
+	} else {
+		cmd.Env = append(cmd.Env, "CORE_PEER_TLS_ENABLED=false")
+	}
+
+	sess, err := externalbuilder.Start(logger, cmd)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not start wasm engine '%s'", e.Path)
+	}
+	return sess, nil
+}
+
+func writeTLSClientMaterial(dir string, tlsConfig *ccintf.TLSConfig) ([]string, error) {
+	material := []struct {
+		file   string
+		data   []byte
+		envVar string
+	}{
+		{tlsClientKeyFile, tlsConfig.ClientKey, "CORE_TLS_CLIENT_KEY_FILE"},
+		{tlsClientCertFile, tlsConfig.ClientCert, "CORE_TLS_CLIENT_CERT_FILE"},
+		{tlsClientRootCertFile, tlsConfig.RootCert, "CORE_PEER_TLS_ROOTCERT_FILE"},
+	}
+
+	var env []string
+	for _, m := range material {
+		path := filepath.Join(dir, m.file)
+		if err := ioutil.WriteFile(path, m.data, 0600); err != nil {
+			return nil, errors.WithMessagef(err, "could not write '%s'", path)
+		}
+		env = append(env, m.envVar+"="+path)
+	}
+	return env, nil
+}
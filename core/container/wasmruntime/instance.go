@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmruntime
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/pkg/errors"
+)
+
+// Instance is a built wasm chaincode. The module runs as a guest of the
+// configured engine and connects back to the peer over the regular chaincode
+// endpoint.
+type Instance struct {
+	CCID        string
+	ModulePath  string
+	Engine      Engine
+	Limits      Limits
+	TermTimeout time.Duration
+	Session     *externalbuilder.Session
+}
+
+// Start hands the module to the engine for execution.
+func (i *Instance) Start(peerConnection *ccintf.PeerConnection) error {
+	sess, err := i.Engine.Launch(i.CCID, i.ModulePath, i.Limits, peerConnection)
+	if err != nil {
+		return errors.WithMessage(err, "could not launch wasm module")
+	}
+	i.Session = sess
+	return nil
+}
+
+// ChaincodeServerInfo returns nil; a wasm module always connects back to the
+// peer as a client.
+func (i *Instance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
+	return nil, nil
+}
+
+// Stop signals the engine to terminate with SIGTERM. If the engine doesn't
+// terminate within TermTimeout, the process is killed with SIGKILL.
+func (i *Instance) Stop() error {
+	if i.Session == nil {
+		return errors.Errorf("instance has not been started")
+	}
+
+	done := make(chan struct{})
+	go func() { i.Wait(); close(done) }()
+
+	i.Session.Signal(syscall.SIGTERM)
+	select {
+	case <-time.After(i.TermTimeout):
+		i.Session.Signal(syscall.SIGKILL)
+	case <-done:
+		return nil
+	}
+
+	select {
+	case <-time.After(5 * time.Second):
+		return errors.Errorf("failed to stop instance '%s'", i.CCID)
+	case <-done:
+		return nil
+	}
+}
+
+// Wait waits for the engine to terminate and returns its exit code.
+func (i *Instance) Wait() (int, error) {
+	if i.Session == nil {
+		return -1, errors.Errorf("instance was not successfully started")
+	}
+
+	err := i.Session.Wait()
+	err = errors.Wrapf(err, "wasm module '%s' run failed", i.CCID)
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		return exitErr.ExitCode(), err
+	}
+	return 0, err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package wasmruntime executes WebAssembly chaincode directly on the peer,
+// without a Docker daemon. A chaincode package whose metadata declares the
+// "wasm" type carries a compiled module in its code package; the module is
+// extracted to a durable location on the peer file system and is run by a
+// sandboxed WebAssembly engine that enforces deterministic limits on the
+// linear memory and on the number of instructions the module may consume.
+package wasmruntime
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("wasmruntime")
+
+// PackageType is the chaincode package metadata type handled by this runtime.
+const PackageType = "wasm"
+
+// moduleFileName is the name under which the extracted module is persisted.
+const moduleFileName = "chaincode.wasm"
+
+// wasmModuleHeader is the magic number and version that open every binary
+// WebAssembly module of the supported version.
+var wasmModuleHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// Limits bounds the resources a module may consume while it executes. The
+// engine aborts execution deterministically when a limit is exceeded, so
+// peers configured with the same limits reach the same verdict for the same
+// transaction.
+type Limits struct {
+	// MaxMemoryPages is the maximum number of 64KiB linear memory pages the
+	// module may allocate.
+	MaxMemoryPages uint64
+	// MaxInstructions is the maximum number of instructions the module may
+	// execute.
+	MaxInstructions uint64
+}
+
+// Engine runs a WebAssembly module in a sandbox with the provided resource
+// limits and returns a session for the running module.
+type Engine interface {
+	Launch(ccid string, modulePath string, limits Limits, peerConnection *ccintf.PeerConnection) (*externalbuilder.Session, error)
+}
+
+// Runtime builds and runs WebAssembly chaincode packages. It is registered
+// on the container Router alongside the external and Docker builders and
+// claims only packages of the wasm type.
+type Runtime struct {
+	Engine Engine
+	Limits Limits
+	// DurablePath is the file system location where extracted modules are
+	// persisted.
+	DurablePath string
+}
+
+// Build extracts the compiled module from the code package and validates it.
+// A nil instance is returned for packages of any other type so that the
+// Router can fall back to the Docker build.
+func (r *Runtime) Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackageStream io.Reader) (container.Instance, error) {
+	if !strings.EqualFold(metadata.Type, PackageType) {
+		return nil, nil
+	}
+
+	module, err := readModuleFromPackage(codePackageStream)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(module, wasmModuleHeader) {
+		return nil, errors.Errorf("code package of %s does not contain a binary wasm module of the supported version", ccid)
+	}
+
+	moduleDir := filepath.Join(r.DurablePath, externalbuilder.SanitizeCCIDPath(ccid))
+	if err := os.MkdirAll(moduleDir, 0700); err != nil {
+		return nil, errors.WithMessagef(err, "could not create directory '%s'", moduleDir)
+	}
+	modulePath := filepath.Join(moduleDir, moduleFileName)
+	if err := ioutil.WriteFile(modulePath, module, 0600); err != nil {
+		return nil, errors.WithMessagef(err, "could not write module '%s'", modulePath)
+	}
+	logger.Debugf("extracted wasm module for %s to %s", ccid, modulePath)
+
+	return &Instance{
+		CCID:        ccid,
+		ModulePath:  modulePath,
+		Engine:      r.Engine,
+		Limits:      r.Limits,
+		TermTimeout: 5 * time.Second,
+	}, nil
+}
+
+// readModuleFromPackage extracts the compiled module from the gzip-ed tar
+// code package. The package must contain exactly one .wasm file.
+func readModuleFromPackage(codePackageStream io.Reader) ([]byte, error) {
+	gzr, err := gzip.NewReader(codePackageStream)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not read code package")
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	var module []byte
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not get next tar element")
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".wasm") {
+			continue
+		}
+		if module != nil {
+			return nil, errors.New("code package contains more than one wasm module")
+		}
+		module, err = ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "could not read module '%s'", header.Name)
+		}
+	}
+	if module == nil {
+		return nil, errors.New("code package contains no wasm module")
+	}
+	return module, nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wasmruntime
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildIgnoresOtherPackageTypes(t *testing.T) {
+	rt := &Runtime{}
+	instance, err := rt.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "golang"},
+		bytes.NewReader(nil),
+	)
+	assert.NoError(t, err)
+	assert.Nil(t, instance)
+}
+
+func TestBuildExtractsModule(t *testing.T) {
+	durablePath, cleanup := testDir(t)
+	defer cleanup()
+
+	module := append(append([]byte{}, wasmModuleHeader...), []byte("module-body")...)
+	rt := &Runtime{DurablePath: durablePath}
+	instance, err := rt.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "WASM"},
+		codePackage(t, map[string][]byte{
+			"src/chaincode.wasm": module,
+			"src/metadata.json":  []byte("{}"),
+		}),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, instance)
+
+	wasmInstance, ok := instance.(*Instance)
+	require.True(t, ok)
+	assert.Equal(t, "testcc:0", wasmInstance.CCID)
+
+	persisted, err := ioutil.ReadFile(wasmInstance.ModulePath)
+	require.NoError(t, err)
+	assert.Equal(t, module, persisted)
+}
+
+func TestBuildRejectsInvalidModule(t *testing.T) {
+	durablePath, cleanup := testDir(t)
+	defer cleanup()
+
+	rt := &Runtime{DurablePath: durablePath}
+	_, err := rt.Build(
+		"testcc:0",
+		&persistence.ChaincodePackageMetadata{Type: "wasm"},
+		codePackage(t, map[string][]byte{"chaincode.wasm": []byte("not-a-wasm-module")}),
+	)
+	assert.EqualError(t, err, "code package of testcc:0 does not contain a binary wasm module of the supported version")
+}
+
+func TestBuildBadCodePackages(t *testing.T) {
+	durablePath, cleanup := testDir(t)
+	defer cleanup()
+
+	rt := &Runtime{DurablePath: durablePath}
+	metadata := &persistence.ChaincodePackageMetadata{Type: "wasm"}
+
+	_, err := rt.Build("testcc:0", metadata, codePackage(t, map[string][]byte{"metadata.json": []byte("{}")}))
+	assert.EqualError(t, err, "code package contains no wasm module")
+
+	_, err = rt.Build("testcc:0", metadata, codePackage(t, map[string][]byte{
+		"one.wasm": wasmModuleHeader,
+		"two.wasm": wasmModuleHeader,
+	}))
+	assert.EqualError(t, err, "code package contains more than one wasm module")
+
+	_, err = rt.Build("testcc:0", metadata, bytes.NewReader([]byte("not-a-gzip-stream")))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read code package")
+}
+
+func TestProcessEngineLaunch(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	outputFile := filepath.Join(dir, "engine-output")
+	engineScript := filepath.Join(dir, "engine.sh")
+	err := ioutil.WriteFile(engineScript, []byte(
+		"#!/bin/sh\n"+
+			"echo \"module=$2\" > "+outputFile+"\n"+
+			"echo \"flag=$1\" >> "+outputFile+"\n"+
+			"echo \"WASM_MAX_MEMORY_PAGES=$WASM_MAX_MEMORY_PAGES\" >> "+outputFile+"\n"+
+			"echo \"WASM_MAX_INSTRUCTIONS=$WASM_MAX_INSTRUCTIONS\" >> "+outputFile+"\n"+
+			"echo \"CORE_CHAINCODE_ID_NAME=$CORE_CHAINCODE_ID_NAME\" >> "+outputFile+"\n"+
+			"echo \"CORE_PEER_ADDRESS=$CORE_PEER_ADDRESS\" >> "+outputFile+"\n"+
+			"echo \"CORE_PEER_TLS_ENABLED=$CORE_PEER_TLS_ENABLED\" >> "+outputFile+"\n",
+	), 0700)
+	require.NoError(t, err)
+
+	modulePath := filepath.Join(dir, moduleFileName)
+	require.NoError(t, ioutil.WriteFile(modulePath, wasmModuleHeader, 0600))
+
+	engine := &ProcessEngine{Path: engineScript, Args: []string{"--deterministic"}}
+	limits := Limits{MaxMemoryPages: 16, MaxInstructions: 1000}
+	peerConnection := &ccintf.PeerConnection{
+		Address: "peer-address:7052",
+		TLSConfig: &ccintf.TLSConfig{
+			ClientKey:  []byte("client-key"),
+			ClientCert: []byte("client-cert"),
+			RootCert:   []byte("root-cert"),
+		},
+	}
+
+	sess, err := engine.Launch("testcc:0", modulePath, limits, peerConnection)
+	require.NoError(t, err)
+	require.NoError(t, sess.Wait())
+
+	output, err := ioutil.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "module="+modulePath)
+	assert.Contains(t, string(output), "flag=--deterministic")
+	assert.Contains(t, string(output), "WASM_MAX_MEMORY_PAGES=16")
+	assert.Contains(t, string(output), "WASM_MAX_INSTRUCTIONS=1000")
+	assert.Contains(t, string(output), "CORE_CHAINCODE_ID_NAME=testcc:0")
+	assert.Contains(t, string(output), "CORE_PEER_ADDRESS=peer-address:7052")
+	assert.Contains(t, string(output), "CORE_PEER_TLS_ENABLED=true")
+
+	clientKey, err := ioutil.ReadFile(filepath.Join(dir, tlsClientKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("client-key"), clientKey)
+}
+
+func TestInstanceLifecycle(t *testing.T) {
+	dir, cleanup := testDir(t)
+	defer cleanup()
+
+	engineScript := filepath.Join(dir, "engine.sh")
+	err := ioutil.WriteFile(engineScript, []byte("#!/bin/sh\nexit 7\n"), 0700)
+	require.NoError(t, err)
+
+	instance := &Instance{
+		CCID:   "testcc:0",
+		Engine: &ProcessEngine{Path: engineScript},
+	}
+	err = instance.Start(&ccintf.PeerConnection{Address: "peer-address:7052"})
+	require.NoError(t, err)
+
+	exitCode, err := instance.Wait()
+	assert.Equal(t, 7, exitCode)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "wasm module 'testcc:0' run failed")
+
+	serverInfo, err := instance.ChaincodeServerInfo()
+	assert.NoError(t, err)
+	assert.Nil(t, serverInfo)
+}
+
+func TestInstanceNotStarted(t *testing.T) {
+	instance := &Instance{CCID: "testcc:0"}
+
+	err := instance.Stop()
+	assert.EqualError(t, err, "instance has not been started")
+
+	_, err = instance.Wait()
+	assert.EqualError(t, err, "instance was not successfully started")
+}
+
+func codePackage(t *testing.T, files map[string][]byte) io.Reader {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Mode: 0600,
+			Size: int64(len(content)),
+		})
+		require.NoError(t, err)
+		_, err = tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf
+}
+
+func testDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "wasmruntime")
+	require.NoError(t, err)
+	return dir, func() { os.RemoveAll(dir) }
+}
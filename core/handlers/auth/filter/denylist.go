@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/denylist"
+	"github.com/hyperledger/fabric/core/handlers/auth"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// NewDenyListFilter creates a new Filter that rejects proposals whose creator
+// is on the runtime identity deny-list
+func NewDenyListFilter(list *denylist.DenyList) auth.Filter {
+	return &denyListFilter{list: list}
+}
+
+type denyListFilter struct {
+	next peer.EndorserServer
+	list *denylist.DenyList
+}
+
+// Init initializes the Filter with the next EndorserServer
+func (f *denyListFilter) Init(next peer.EndorserServer) {
+	f.next = next
+}
+
+func (f *denyListFilter) checkProposal(signedProp *peer.SignedProposal) error {
+	prop, err := protoutil.UnmarshalProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing proposal")
+	}
+
+	hdr, err := protoutil.UnmarshalHeader(prop.Header)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing header")
+	}
+
+	sh, err := protoutil.UnmarshalSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return errors.Wrap(err, "failed parsing signature header")
+	}
+	return f.list.Check(sh.Creator)
+}
+
+// ProcessProposal processes a signed proposal
+func (f *denyListFilter) ProcessProposal(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	if err := f.checkProposal(signedProp); err != nil {
+		return nil, err
+	}
+	return f.next.ProcessProposal(ctx, signedProp)
+}
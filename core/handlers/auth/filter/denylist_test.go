@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package filter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/denylist"
+	"github.com/stretchr/testify/assert"
+)
+
+func certFingerprint(t *testing.T, certFileName string) string {
+	certBytes, err := ioutil.ReadFile(filepath.Join("testdata", certFileName))
+	assert.NoError(t, err)
+	block, _ := pem.Decode(certBytes)
+	assert.NotNil(t, block)
+	fingerprint := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+func TestDenyListFilter(t *testing.T) {
+	nextEndorser := &mockEndorserServer{}
+	list := denylist.NewDenyList()
+	auth := NewDenyListFilter(list)
+	auth.Init(nextEndorser)
+
+	// Scenario I: identity not on the deny-list
+	sp := createValidSignedProposal(t, createX509Identity(t, "notExpiredCert.pem"))
+	_, err := auth.ProcessProposal(context.Background(), sp)
+	assert.NoError(t, err)
+	assert.True(t, nextEndorser.invoked)
+	nextEndorser.invoked = false
+
+	// Scenario II: deny-listed identity
+	err = list.Add(denylist.Entry{Fingerprint: certFingerprint(t, "notExpiredCert.pem")})
+	assert.NoError(t, err)
+	_, err = auth.ProcessProposal(context.Background(), sp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is deny-listed")
+	assert.False(t, nextEndorser.invoked)
+
+	// Scenario III: Idemix identities are not subject to the deny-list
+	sp = createValidSignedProposal(t, createIdemixIdentity(t))
+	_, err = auth.ProcessProposal(context.Background(), sp)
+	assert.NoError(t, err)
+	assert.True(t, nextEndorser.invoked)
+	nextEndorser.invoked = false
+
+	// Scenario IV: Malformed proposal
+	sp = createValidSignedProposal(t, createX509Identity(t, "notExpiredCert.pem"))
+	sp.ProposalBytes = append(sp.ProposalBytes, 0)
+	_, err = auth.ProcessProposal(context.Background(), sp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing proposal")
+	assert.False(t, nextEndorser.invoked)
+}
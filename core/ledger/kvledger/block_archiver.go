@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/blockarchival"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// archivedHeightKey is the bookkeeping key under which a blockArchiver records
+// the number of the next block to archive
+var archivedHeightKey = []byte("archivedHeight")
+
+// archivalStore is the combination of the archival backend interfaces that a
+// blockArchiver needs - it must be able to both offload blocks and serve them
+// back for queries
+type archivalStore interface {
+	blockarchival.Sink
+	blockarchival.Source
+}
+
+// blockArchiver offloads blocks that have aged out of the configured retention
+// window to an archival store and prunes them from the local block store.
+// Archived blocks remain retrievable via retrieveArchivedBlock. The archiver is
+// driven by the block commit path and is not safe for concurrent use
+type blockArchiver struct {
+	ledgerID     string
+	blockStore   *ledgerstorage.Store
+	pruner       blkstorage.BlockPruner
+	archive      archivalStore
+	retainBlocks uint64
+	bookkeeper   *leveldbhelper.DBHandle
+
+	nextBlock uint64
+}
+
+// newBlockArchiverFor constructs a blockArchiver for the given ledger if block
+// archival is enabled in the provider's configuration, and nil otherwise
+func (p *Provider) newBlockArchiverFor(ledgerID string, blockStore *ledgerstorage.Store) (*blockArchiver, error) {
+	conf := p.initializer.Config.BlockArchivalConfig
+	if conf == nil || conf.RetainBlocks == 0 {
+		return nil, nil
+	}
+
+	var archive archivalStore
+	var err error
+	switch conf.Archiver {
+	case "file", "":
+		archiveDir := conf.FileDir
+		if archiveDir == "" {
+			archiveDir = filepath.Join(p.initializer.Config.RootFSPath, "blockArchive")
+		}
+		archive, err = blockarchival.NewFileSink(archiveDir)
+	case "s3":
+		if conf.S3 == nil {
+			return nil, errors.New("the s3 block archiver requires an s3 configuration")
+		}
+		archive, err = blockarchival.NewS3Sink(*conf.S3)
+	default:
+		err = errors.Errorf("unsupported block archiver type: %s", conf.Archiver)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bookkeeper := p.bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.BlockArchival)
+	return newBlockArchiver(ledgerID, blockStore, archive, conf.RetainBlocks, bookkeeper)
+}
+
+func newBlockArchiver(
+	ledgerID string,
+	blockStore *ledgerstorage.Store,
+	archive archivalStore,
+	retainBlocks uint64,
+	bookkeeper *leveldbhelper.DBHandle,
+) (*blockArchiver, error) {
+	pruner, ok := blockStore.BlockStore.(blkstorage.BlockPruner)
+	if !ok {
+		return nil, errors.New("the block store does not support pruning")
+	}
+
+	a := &blockArchiver{
+		ledgerID:     ledgerID,
+		blockStore:   blockStore,
+		pruner:       pruner,
+		archive:      archive,
+		retainBlocks: retainBlocks,
+		bookkeeper:   bookkeeper,
+	}
+
+	heightBytes, err := bookkeeper.Get(archivedHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	if heightBytes != nil {
+		height, n := proto.DecodeVarint(heightBytes)
+		if n == 0 {
+			return nil, errors.Errorf("error decoding the archived height bytes [%#v]", heightBytes)
+		}
+		a.nextBlock = height
+	}
+	// the bookkeeping can never trail the prune boundary in a healthy store,
+	// but guard against it so that archival does not get stuck on pruned blocks
+	if prunedUpTo := pruner.PrunedUpTo(); prunedUpTo > a.nextBlock {
+		a.nextBlock = prunedUpTo
+	}
+	return a, nil
+}
+
+// archiveAndPrune offloads every block that has aged out of the retention
+// window and has not yet been archived, in order, and then prunes the archived
+// prefix from the local block store. It is invoked after each block commit
+func (a *blockArchiver) archiveAndPrune() error {
+	bcInfo, err := a.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	if bcInfo.Height <= a.retainBlocks {
+		return nil
+	}
+
+	horizon := bcInfo.Height - a.retainBlocks
+	for a.nextBlock < horizon {
+		block, err := a.blockStore.RetrieveBlockByNumber(a.nextBlock)
+		if err != nil {
+			return err
+		}
+		if err := a.archive.Put(a.ledgerID, block); err != nil {
+			return errors.WithMessagef(err, "failed to archive block [%d]", a.nextBlock)
+		}
+		a.nextBlock++
+		if err := a.bookkeeper.Put(archivedHeightKey, proto.EncodeVarint(a.nextBlock), true); err != nil {
+			return err
+		}
+	}
+	return a.pruner.PruneBlocks(a.nextBlock)
+}
+
+// retrieveArchivedBlock serves a block that has been pruned from the local
+// block store by reading it back from the archival store
+func (a *blockArchiver) retrieveArchivedBlock(blockNum uint64) (*common.Block, error) {
+	return a.archive.Get(a.ledgerID, blockNum)
+}
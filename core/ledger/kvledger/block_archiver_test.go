@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockArchival(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	conf.BlockArchivalConfig = &lgr.BlockArchivalConfig{
+		RetainBlocks: 2,
+	}
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+
+	committedBlocks := []*common.Block{gb}
+	for i := 0; i < 5; i++ {
+		txid := util.GenerateUUID()
+		simulator, _ := ledger.NewTxSimulator(txid)
+		simulator.SetState("ns1", "key1", []byte(fmt.Sprintf("value%d", i)))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		require.NoError(t, ledger.CommitLegacy(&lgr.BlockAndPvtData{Block: block}, &lgr.CommitOptions{}))
+		committedBlocks = append(committedBlocks, block)
+	}
+
+	// with a height of 6 and a retention of 2 blocks, blocks 0-3 must have been
+	// offloaded to the default file archiver under the ledger root dir
+	archiveDir := filepath.Join(conf.RootFSPath, "blockArchive", "testLedger")
+	for num := 0; num < 4; num++ {
+		_, err := os.Stat(filepath.Join(archiveDir, fmt.Sprintf("block_%020d.pb", num)))
+		assert.NoError(t, err, "block [%d] should have been archived", num)
+	}
+	_, err = os.Stat(filepath.Join(archiveDir, fmt.Sprintf("block_%020d.pb", 4)))
+	assert.True(t, os.IsNotExist(err), "block [4] is within the retention window and should not have been archived")
+
+	archiver := ledger.(*kvLedger).blockArchiver
+	require.NotNil(t, archiver)
+	assert.Equal(t, uint64(4), archiver.nextBlock)
+
+	// archived blocks round-trip through the archival store
+	for num, expectedBlock := range committedBlocks[0:4] {
+		archivedBlock, err := archiver.retrieveArchivedBlock(uint64(num))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedBlock, archivedBlock))
+	}
+
+	// all blocks remain retrievable through the ledger APIs
+	for num, expectedBlock := range committedBlocks {
+		retrievedBlock, err := ledger.GetBlockByNumber(uint64(num))
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(expectedBlock, retrievedBlock))
+	}
+
+	// the archival progress survives a reopen of the ledger
+	ledger.Close()
+	provider.Close()
+	provider = testutilNewProvider(conf, t)
+	reopenedLedger, err := provider.Open("testLedger")
+	require.NoError(t, err)
+	defer reopenedLedger.Close()
+	archiver = reopenedLedger.(*kvLedger).blockArchiver
+	require.NotNil(t, archiver)
+	assert.Equal(t, uint64(4), archiver.nextBlock)
+}
+
+func TestBlockArchivalDisabled(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t)
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer ledger.Close()
+	assert.Nil(t, ledger.(*kvLedger).blockArchiver)
+}
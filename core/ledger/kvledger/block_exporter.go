@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"encoding/json"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/blockexport"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// exportedHeightKey is the bookkeeping key under which a blockExporter records
+// the number of the next block to export - i.e., the resume token
+var exportedHeightKey = []byte("exportedHeight")
+
+// blockExporter streams the committed blocks of a ledger to an external sink.
+// Delivery is at-least-once: the resume token is advanced only after a
+// successful delivery, so a failed delivery is retried after the next commit
+// and the blocks may reach the sink more than once. The exporter is driven by
+// the block commit path and is not safe for concurrent use
+type blockExporter struct {
+	ledgerID   string
+	blockStore *ledgerstorage.Store
+	sink       blockexport.Sink
+	content    string
+	bookkeeper *leveldbhelper.DBHandle
+
+	nextBlock uint64
+}
+
+// newBlockExporterFor constructs a blockExporter for the given ledger if block
+// export is enabled in the provider's configuration, and nil otherwise
+func (p *Provider) newBlockExporterFor(ledgerID string, blockStore *ledgerstorage.Store) (*blockExporter, error) {
+	conf := p.initializer.Config.BlockExportConfig
+	if conf == nil || conf.Sink == "" {
+		return nil, nil
+	}
+
+	var sink blockexport.Sink
+	var err error
+	switch conf.Sink {
+	case "kafka":
+		if conf.Kafka == nil {
+			return nil, errors.New("the kafka block export sink requires a kafka configuration")
+		}
+		sink, err = blockexport.NewKafkaSink(*conf.Kafka)
+	case "webhook":
+		if conf.Webhook == nil {
+			return nil, errors.New("the webhook block export sink requires a webhook configuration")
+		}
+		sink, err = blockexport.NewWebhookSink(*conf.Webhook)
+	default:
+		err = errors.Errorf("unsupported block export sink type: %s", conf.Sink)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := conf.Content
+	if content == "" {
+		content = "block"
+	}
+	if content != "block" && content != "writesets" {
+		return nil, errors.Errorf("unsupported block export content type: %s", content)
+	}
+
+	bookkeeper := p.bookkeepingProvider.GetDBHandle(ledgerID, bookkeeping.BlockExport)
+	return newBlockExporter(ledgerID, blockStore, sink, content, bookkeeper)
+}
+
+func newBlockExporter(
+	ledgerID string,
+	blockStore *ledgerstorage.Store,
+	sink blockexport.Sink,
+	content string,
+	bookkeeper *leveldbhelper.DBHandle,
+) (*blockExporter, error) {
+	e := &blockExporter{
+		ledgerID:   ledgerID,
+		blockStore: blockStore,
+		sink:       sink,
+		content:    content,
+		bookkeeper: bookkeeper,
+	}
+
+	heightBytes, err := bookkeeper.Get(exportedHeightKey)
+	if err != nil {
+		return nil, err
+	}
+	if heightBytes != nil {
+		height, n := proto.DecodeVarint(heightBytes)
+		if n == 0 {
+			return nil, errors.Errorf("error decoding the exported height bytes [%#v]", heightBytes)
+		}
+		e.nextBlock = height
+	}
+	return e, nil
+}
+
+// exportPending delivers to the sink every committed block that has not yet
+// been exported, in order, advancing the resume token after each successful
+// delivery. It is invoked after each block commit
+func (e *blockExporter) exportPending() error {
+	bcInfo, err := e.blockStore.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	for e.nextBlock < bcInfo.Height {
+		block, err := e.blockStore.RetrieveBlockByNumber(e.nextBlock)
+		if err != nil {
+			return err
+		}
+		payload, err := e.buildPayload(block)
+		if err != nil {
+			return err
+		}
+		if err := e.sink.Deliver(e.ledgerID, e.nextBlock, payload); err != nil {
+			return errors.WithMessagef(err, "failed to export block [%d]", e.nextBlock)
+		}
+		e.nextBlock++
+		if err := e.bookkeeper.Put(exportedHeightKey, proto.EncodeVarint(e.nextBlock), true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildPayload constructs the sink payload for a block - either the serialized
+// block itself or a JSON document with the write-sets of the valid endorser
+// transactions, depending on the configured content type
+func (e *blockExporter) buildPayload(block *common.Block) ([]byte, error) {
+	if e.content == "block" {
+		return proto.Marshal(block)
+	}
+	return extractWriteSets(e.ledgerID, block)
+}
+
+// exportedWriteSets is the JSON document delivered per block when the export
+// content is configured to "writesets"
+type exportedWriteSets struct {
+	ChannelID    string                 `json:"channelID"`
+	BlockNumber  uint64                 `json:"blockNumber"`
+	Transactions []*exportedTransaction `json:"transactions"`
+}
+
+type exportedTransaction struct {
+	TxID   string           `json:"txID"`
+	Writes []*exportedWrite `json:"writes"`
+}
+
+type exportedWrite struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Value     []byte `json:"value,omitempty"`
+	IsDelete  bool   `json:"isDelete,omitempty"`
+}
+
+// extractWriteSets extracts the public write-sets of the valid endorser
+// transactions of a block into a JSON document
+func extractWriteSets(channelID string, block *common.Block) ([]byte, error) {
+	exported := &exportedWriteSets{
+		ChannelID:   channelID,
+		BlockNumber: block.Header.Number,
+	}
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for tranNo, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(tranNo) {
+			continue
+		}
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		respPayload, err := protoutil.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			return nil, err
+		}
+		tx := &exportedTransaction{TxID: chdr.TxId}
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				tx.Writes = append(tx.Writes, &exportedWrite{
+					Namespace: nsRWSet.NameSpace,
+					Key:       kvWrite.Key,
+					Value:     kvWrite.Value,
+					IsDelete:  kvWrite.IsDelete,
+				})
+			}
+		}
+		exported.Transactions = append(exported.Transactions, tx)
+	}
+	return json.Marshal(exported)
+}
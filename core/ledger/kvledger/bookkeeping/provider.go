@@ -20,6 +20,10 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// BlockArchival maintains the bookkeeping about the progress of block archival
+	BlockArchival
+	// BlockExport maintains the bookkeeping about the progress of block export
+	BlockExport
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
@@ -197,6 +197,106 @@ func TestHistory(t *testing.T) {
 	assert.Equal(t, 4, count)
 }
 
+func TestHistoryWithOptions(t *testing.T) {
+	env := newTestHistoryEnv(t)
+	defer env.cleanup()
+	provider := env.testBlockStorageEnv.provider
+	ledger1id := "ledger1"
+	store1, err := provider.OpenBlockStore(ledger1id)
+	assert.NoError(t, err, "Error upon provider.OpenBlockStore()")
+	defer store1.Shutdown()
+
+	bg, gb := testutil.NewBlockGenerator(t, ledger1id, false)
+	assert.NoError(t, store1.AddBlock(gb))
+	assert.NoError(t, env.testHistoryDB.Commit(gb))
+
+	// block1 sets value1, block2 has two trans setting value2 and value3,
+	// block3 deletes the key and block4 sets value4
+	addBlock := func(simResults [][]byte) {
+		block := bg.NextBlock(simResults)
+		assert.NoError(t, store1.AddBlock(block))
+		assert.NoError(t, env.testHistoryDB.Commit(block))
+	}
+	simulate := func(value []byte) []byte {
+		txid := util2.GenerateUUID()
+		simulator, _ := env.txmgr.NewTxSimulator(txid)
+		if value != nil {
+			simulator.SetState("ns1", "key7", value)
+		} else {
+			simulator.DeleteState("ns1", "key7")
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimResBytes, _ := simRes.GetPubSimulationBytes()
+		return pubSimResBytes
+	}
+	addBlock([][]byte{simulate([]byte("value1"))})
+	addBlock([][]byte{simulate([]byte("value2")), simulate([]byte("value3"))})
+	addBlock([][]byte{simulate(nil)})
+	addBlock([][]byte{simulate([]byte("value4"))})
+
+	qhistory, err := env.testHistoryDB.NewQueryExecutor(store1)
+	assert.NoError(t, err, "Error upon NewQueryExecutor")
+	pagedQE := qhistory.(ledger.PagedHistoryQueryExecutor)
+
+	// nil options return the complete history, newest to oldest
+	vals, bookmark := testutilQueryWithOptions(t, pagedQE, nil)
+	assert.Equal(t, []string{"value4", "", "value3", "value2", "value1"}, vals)
+	assert.Equal(t, "", bookmark)
+
+	// a block range restricts the results to the modifications within the range
+	vals, bookmark = testutilQueryWithOptions(t, pagedQE,
+		&ledger.HistoryQueryOptions{StartBlockNum: 2, EndBlockNum: 3})
+	assert.Equal(t, []string{"", "value3", "value2"}, vals)
+	assert.Equal(t, "", bookmark)
+
+	// excluding deletes drops the entry for the delete in block3
+	vals, bookmark = testutilQueryWithOptions(t, pagedQE,
+		&ledger.HistoryQueryOptions{ExcludeDeletes: true})
+	assert.Equal(t, []string{"value4", "value3", "value2", "value1"}, vals)
+	assert.Equal(t, "", bookmark)
+
+	// paginate over the complete history in pages of two results, resuming
+	// each page from the bookmark of the previous one
+	retrievedVals := []string{}
+	options := &ledger.HistoryQueryOptions{PageSize: 2}
+	numPages := 0
+	for {
+		vals, bookmark = testutilQueryWithOptions(t, pagedQE, options)
+		assert.True(t, len(vals) <= 2)
+		retrievedVals = append(retrievedVals, vals...)
+		numPages++
+		if bookmark == "" {
+			break
+		}
+		options.Bookmark = bookmark
+	}
+	assert.Equal(t, []string{"value4", "", "value3", "value2", "value1"}, retrievedVals)
+	assert.Equal(t, 3, numPages)
+
+	// an invalid bookmark is rejected
+	_, err = pagedQE.GetHistoryForKeyWithOptions("ns1", "key7",
+		&ledger.HistoryQueryOptions{Bookmark: "bad-bookmark"})
+	assert.EqualError(t, err, "invalid bookmark [bad-bookmark]")
+}
+
+// testutilQueryWithOptions returns the values (empty string for a delete) retrieved
+// by a history query with the given options, along with the bookmark
+func testutilQueryWithOptions(t *testing.T, hqe ledger.PagedHistoryQueryExecutor, options *ledger.HistoryQueryOptions) ([]string, string) {
+	itr, err := hqe.GetHistoryForKeyWithOptions("ns1", "key7", options)
+	assert.NoError(t, err, "Error upon GetHistoryForKeyWithOptions()")
+	retrievedVals := []string{}
+	for {
+		kmod, err := itr.Next()
+		assert.NoError(t, err)
+		if kmod == nil {
+			break
+		}
+		retrievedVals = append(retrievedVals, string(kmod.(*queryresult.KeyModification).Value))
+	}
+	return retrievedVals, itr.GetBookmarkAndClose()
+}
+
 func TestHistoryForInvalidTran(t *testing.T) {
 	env := newTestHistoryEnv(t)
 	defer env.cleanup()
@@ -8,6 +8,9 @@ package history
 
 import (
 	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/pkg/errors"
@@ -55,6 +58,29 @@ func constructRangeScan(ns string, key string) *rangeScan {
 	}
 }
 
+// encodeBookmark encodes the position of a history entry into the opaque bookmark
+// that a paged history query returns to the client for resuming the scan
+func encodeBookmark(blockNum uint64, tranNum uint64) string {
+	return fmt.Sprintf("%d:%d", blockNum, tranNum)
+}
+
+// decodeBookmark decodes a bookmark produced by encodeBookmark
+func decodeBookmark(bookmark string) (uint64, uint64, error) {
+	components := strings.Split(bookmark, ":")
+	if len(components) != 2 {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	blockNum, err := strconv.ParseUint(components[0], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	tranNum, err := strconv.ParseUint(components[1], 10, 64)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid bookmark [%s]", bookmark)
+	}
+	return blockNum, tranNum, nil
+}
+
 func (r *rangeScan) decodeBlockNumTranNum(dataKey dataKey) (uint64, uint64, error) {
 	blockNumTranNumBytes := bytes.TrimPrefix(dataKey, r.startKey)
 	blockNum, blockBytesConsumed, err := util.DecodeOrderPreservingVarUint64(blockNumTranNumBytes)
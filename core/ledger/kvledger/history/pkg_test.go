@@ -62,6 +62,8 @@ func newTestHistoryEnv(t *testing.T) *levelDBLockBasedHistoryEnv {
 		&mock.DeployedChaincodeInfoProvider{},
 		nil,
 		cryptoProvider,
+		0,
+		nil,
 	)
 
 	assert.NoError(t, err)
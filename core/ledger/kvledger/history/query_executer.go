@@ -11,7 +11,9 @@ import (
 	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	protoutil "github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -38,6 +40,52 @@ func (q *QueryExecutor) GetHistoryForKey(namespace string, key string) (commonle
 	return &historyScanner{rangeScan, namespace, key, dbItr, q.blockStore}, nil
 }
 
+// GetHistoryForKeyWithOptions implements method in interface `ledger.PagedHistoryQueryExecutor`
+func (q *QueryExecutor) GetHistoryForKeyWithOptions(namespace string, key string, options *ledger.HistoryQueryOptions) (commonledger.QueryResultsIterator, error) {
+	if options == nil {
+		options = &ledger.HistoryQueryOptions{}
+	}
+	// the unrestricted rangeScan is retained in the scanner for decoding the
+	// block and transaction numbers from the keys visited by the iterator
+	rangeScan := constructRangeScan(namespace, key)
+	startKey := rangeScan.startKey
+	if options.StartBlockNum > 0 {
+		startKey = append(append([]byte{}, rangeScan.startKey...),
+			util.EncodeOrderPreservingVarUint64(options.StartBlockNum)...)
+	}
+	// the endKey of the underlying iterator is exclusive and hence is placed one
+	// block (or one height, when resuming from a bookmark) beyond the last entry
+	// that the scan is allowed to visit
+	endKey := rangeScan.endKey
+	if options.EndBlockNum > 0 {
+		endKey = append(append([]byte{}, rangeScan.startKey...),
+			util.EncodeOrderPreservingVarUint64(options.EndBlockNum+1)...)
+	}
+	if options.Bookmark != "" {
+		// a bookmark marks the oldest entry returned in the previous page and the
+		// query resumes from the entry that precedes it in the commit order. A
+		// bookmark produced under an EndBlockNum restriction always points below
+		// the restricted range and hence simply replaces the end of the range
+		blockNum, tranNum, err := decodeBookmark(options.Bookmark)
+		if err != nil {
+			return nil, err
+		}
+		endKey = append(append([]byte{}, rangeScan.startKey...),
+			util.EncodeOrderPreservingVarUint64(blockNum)...)
+		endKey = append(endKey, util.EncodeOrderPreservingVarUint64(tranNum)...)
+	}
+	dbItr := q.levelDB.GetIterator(startKey, endKey)
+	if dbItr.Last() {
+		dbItr.Next()
+	}
+	scanner := &historyScanner{rangeScan, namespace, key, dbItr, q.blockStore}
+	return &pagedHistoryScanner{
+		historyScanner: scanner,
+		pageSize:       options.PageSize,
+		excludeDeletes: options.ExcludeDeletes,
+	}, nil
+}
+
 //historyScanner implements ResultsIterator for iterating through history results
 type historyScanner struct {
 	rangeScan  *rangeScan
@@ -89,6 +137,51 @@ func (scanner *historyScanner) Close() {
 	scanner.dbItr.Release()
 }
 
+// pagedHistoryScanner wraps a historyScanner in order to enforce a page size and
+// to optionally filter out delete markers. When the page fills up, the position
+// of the last returned entry is recorded as a bookmark from which a subsequent
+// query can resume the scan
+type pagedHistoryScanner struct {
+	*historyScanner
+	pageSize       int32
+	excludeDeletes bool
+	numReturned    int32
+	bookmark       string
+}
+
+// Next returns the next history result within the page and returns nil once the
+// page is full or the underlying scan is exhausted
+func (scanner *pagedHistoryScanner) Next() (commonledger.QueryResult, error) {
+	if scanner.pageSize > 0 && scanner.numReturned >= scanner.pageSize {
+		return nil, nil
+	}
+	for {
+		queryResult, err := scanner.historyScanner.Next()
+		if queryResult == nil || err != nil {
+			return queryResult, err
+		}
+		if scanner.excludeDeletes && queryResult.(*queryresult.KeyModification).IsDelete {
+			continue
+		}
+		scanner.numReturned++
+		if scanner.pageSize > 0 && scanner.numReturned == scanner.pageSize {
+			blockNum, tranNum, err := scanner.rangeScan.decodeBlockNumTranNum(scanner.dbItr.Key())
+			if err != nil {
+				return nil, err
+			}
+			scanner.bookmark = encodeBookmark(blockNum, tranNum)
+		}
+		return queryResult, nil
+	}
+}
+
+// GetBookmarkAndClose implements method in interface `commonledger.QueryResultsIterator`.
+// The returned bookmark is empty if the scan got exhausted before filling the page
+func (scanner *pagedHistoryScanner) GetBookmarkAndClose() string {
+	defer scanner.Close()
+	return scanner.bookmark
+}
+
 // getTxIDandKeyWriteValueFromTran inspects a transaction for writes to a given key
 func getKeyModificationFromTran(tranEnvelope *common.Envelope, namespace string, key string) (commonledger.QueryResult, error) {
 	logger.Debugf("Entering getKeyModificationFromTran()\n", namespace, key)
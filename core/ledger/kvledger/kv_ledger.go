@@ -22,11 +22,14 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/confighistory"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/conflicttracker"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/statebasedval"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -45,6 +48,9 @@ type kvLedger struct {
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
 	commitHash             []byte
+	blockArchiver          *blockArchiver
+	blockExporter          *blockExporter
+	conflictTracker        *conflicttracker.Tracker
 }
 
 // newKVLedger constructs new `KVLedger`
@@ -61,14 +67,24 @@ func newKVLedger(
 	stats *ledgerStats,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hasher ledger.Hasher,
+	blockArchiver *blockArchiver,
+	blockExporter *blockExporter,
+	validationWorkerPoolSize int,
+	conflictTracker *conflicttracker.Tracker,
 ) (*kvLedger, error) {
 	logger.Debugf("Creating KVLedger ledgerID=%s: ", ledgerID)
 	// Create a kvLedger for this chain/ledger, which encapsulates the underlying
 	// id store, blockstore, txmgr (state database), history database
-	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}, blockArchiver: blockArchiver, blockExporter: blockExporter, conflictTracker: conflictTracker}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, ccInfoProvider})
 
+	// the tracker is assigned to an interface variable only if present so that the
+	// validator can rely on a plain nil check
+	var conflictRecorder statebasedval.ConflictRecorder
+	if conflictTracker != nil {
+		conflictRecorder = conflictTracker
+	}
 	if err := l.initTxMgr(
 		versionedDB,
 		stateListeners,
@@ -77,6 +93,8 @@ func newKVLedger(
 		ccInfoProvider,
 		customTxProcessors,
 		hasher,
+		validationWorkerPoolSize,
+		conflictRecorder,
 	); err != nil {
 		return nil, err
 	}
@@ -118,6 +136,8 @@ func (l *kvLedger) initTxMgr(
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider,
 	customtxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hasher ledger.Hasher,
+	validationWorkerPoolSize int,
+	conflictRecorder statebasedval.ConflictRecorder,
 ) error {
 	var err error
 	txmgr, err := lockbasedtxmgr.NewLockBasedTxMgr(
@@ -129,6 +149,8 @@ func (l *kvLedger) initTxMgr(
 		ccInfoProvider,
 		customtxProcessors,
 		hasher,
+		validationWorkerPoolSize,
+		conflictRecorder,
 	)
 	if err != nil {
 		return err
@@ -349,6 +371,9 @@ func (l *kvLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
 // blockNumber of  math.MaxUint64 will return last block
 func (l *kvLedger) GetBlockByNumber(blockNumber uint64) (*common.Block, error) {
 	block, err := l.blockStore.RetrieveBlockByNumber(blockNumber)
+	if err == blkstorage.ErrBlockPruned && l.blockArchiver != nil {
+		block, err = l.blockArchiver.retrieveArchivedBlock(blockNumber)
+	}
 	l.blockAPIsRWLock.RLock()
 	l.blockAPIsRWLock.RUnlock()
 	return block, err
@@ -462,6 +487,20 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 	}
 	elapsedBlockstorageAndPvtdataCommit := time.Since(startBlockstorageAndPvtdataCommit)
 
+	// The history database is written in parallel with the state database. Both are
+	// recoverable from the block storage (which is already committed at this point)
+	// and hence the relative order of the two commits is immaterial
+	var historyCommitDone sync.WaitGroup
+	var historyCommitErr error
+	if l.historyDB != nil {
+		historyCommitDone.Add(1)
+		go func() {
+			defer historyCommitDone.Done()
+			logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
+			historyCommitErr = l.historyDB.Commit(block)
+		}()
+	}
+
 	startCommitState := time.Now()
 	logger.Debugf("[%s] Committing block [%d] transactions to state database", l.ledgerID, blockNo)
 	if err = l.txtmgmt.Commit(); err != nil {
@@ -469,13 +508,9 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 	}
 	elapsedCommitState := time.Since(startCommitState)
 
-	// History database could be written in parallel with state and/or async as a future optimization,
-	// although it has not been a bottleneck...no need to clutter the log with elapsed duration.
-	if l.historyDB != nil {
-		logger.Debugf("[%s] Committing block [%d] transactions to history database", l.ledgerID, blockNo)
-		if err := l.historyDB.Commit(block); err != nil {
-			panic(errors.WithMessage(err, "Error during commit to history db"))
-		}
+	historyCommitDone.Wait()
+	if historyCommitErr != nil {
+		panic(errors.WithMessage(historyCommitErr, "Error during commit to history db"))
 	}
 
 	logger.Infof("[%s] Committed block [%d] with %d transaction(s) in %dms (state_validation=%dms block_and_pvtdata_commit=%dms state_commit=%dms)"+
@@ -493,6 +528,22 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 		elapsedCommitState,
 		txstatsInfo,
 	)
+
+	// a failure to archive must not fail the commit - the blocks stay in the
+	// local block store and archival is retried after the next commit
+	if l.blockArchiver != nil {
+		if err := l.blockArchiver.archiveAndPrune(); err != nil {
+			logger.Warningf("[%s] Block archival after commit of block [%d] failed: %s", l.ledgerID, blockNo, err)
+		}
+	}
+
+	// a failure to export must not fail the commit either - the resume token is
+	// not advanced and the delivery is retried after the next commit
+	if l.blockExporter != nil {
+		if err := l.blockExporter.exportPending(); err != nil {
+			logger.Warningf("[%s] Block export after commit of block [%d] failed: %s", l.ledgerID, blockNo, err)
+		}
+	}
 	return nil
 }
 
@@ -514,6 +565,35 @@ func (l *kvLedger) updateBlockStats(
 	l.stats.updateBlockstorageAndPvtdataCommitTime(blockstorageAndPvtdataCommitTime)
 	l.stats.updateStatedbCommitTime(statedbCommitTime)
 	l.stats.updateTransactionsStats(txstatsInfo)
+	if l.conflictTracker != nil {
+		l.conflictTracker.ObserveTransactions(txstatsInfo)
+	}
+}
+
+// ConflictStats implements the optional interface `ledger.ConflictStatsProvider`
+func (l *kvLedger) ConflictStats() (*ledger.ConflictStats, error) {
+	if l.conflictTracker == nil {
+		return nil, errors.New("conflict statistics are not enabled on this ledger")
+	}
+	return l.conflictTracker.Stats(), nil
+}
+
+// RebuildBlockIndex implements the optional interface `ledger.BlockIndexRebuilder`
+func (l *kvLedger) RebuildBlockIndex() error {
+	rebuilder, ok := l.blockStore.BlockStore.(blkstorage.BlockIndexRebuilder)
+	if !ok {
+		return errors.New("the block store does not support rebuilding the block index")
+	}
+	return rebuilder.RebuildIndex()
+}
+
+// BlockIndexRebuildStatus implements the optional interface `ledger.BlockIndexRebuilder`
+func (l *kvLedger) BlockIndexRebuildStatus() (*ledger.BlockIndexRebuildStatus, error) {
+	rebuilder, ok := l.blockStore.BlockStore.(blkstorage.BlockIndexRebuilder)
+	if !ok {
+		return nil, errors.New("the block store does not support rebuilding the block index")
+	}
+	return rebuilder.IndexRebuildStatus(), nil
 }
 
 // GetMissingPvtDataInfoForMostRecentBlocks returns the missing private data information for the
@@ -582,6 +662,14 @@ func (l *kvLedger) CommitPvtDataOfOldBlocks(reconciledPvtdata []*ledger.Reconcil
 		return nil, err
 	}
 
+	// drop the writes to keys that have been purged via PurgePrivateData so that
+	// the purged data cannot re-enter the stateDB or the pvtdata store through
+	// reconciliation
+	hashVerifiedPvtData, err = l.blockStore.RemovePurgedKeys(hashVerifiedPvtData)
+	if err != nil {
+		return nil, err
+	}
+
 	err = l.applyValidTxPvtDataOfOldBlocks(hashVerifiedPvtData)
 	if err != nil {
 		return nil, err
@@ -620,6 +708,24 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 	return l, nil
 }
 
+// PurgePrivateData implements method in the interface `ledger.PvtDataPurger`.
+// It erases the given private data keys from this peer - both the historical
+// versions maintained in the pvtdata store and the current values in the state
+// database. Purge markers recorded in the pvtdata store prevent the purged data
+// from being reintroduced through the reconciliation of the pvtdata of old
+// blocks. The hashes of the keys, being part of the blocks, are retained so that
+// the validation results remain identical across the peers that purged the data
+// and the ones that never possessed it
+func (l *kvLedger) PurgePrivateData(namespace, collection string, keys []string) error {
+	// the pvtdata store is purged first so that the purge markers are in place
+	// before the current values disappear from the state database - a crash in
+	// between is repaired by simply re-invoking the purge
+	if err := l.blockStore.PurgePrivateDataKeys(namespace, collection, keys); err != nil {
+		return err
+	}
+	return l.txtmgmt.PurgePrivateData(namespace, collection, keys)
+}
+
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
 	l.blockStore.Shutdown()
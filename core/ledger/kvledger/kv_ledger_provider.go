@@ -19,7 +19,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/history"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/msgs"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/conflicttracker"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/protoutil"
@@ -65,6 +67,7 @@ type Provider struct {
 	stats               *stats
 	fileLock            *leveldbhelper.FileLock
 	hasher              ledger.Hasher
+	encryptor           *leveldbhelper.ValueEncryptor
 }
 
 // NewProvider instantiates a new Provider.
@@ -101,6 +104,10 @@ func NewProvider(initializer *ledger.Initializer) (pr *Provider, e error) {
 		return nil, err
 	}
 
+	if err := p.initEncryptor(); err != nil {
+		return nil, err
+	}
+
 	if err := p.initLedgerStorageProvider(); err != nil {
 		return nil, err
 	}
@@ -137,11 +144,26 @@ func (p *Provider) initLedgerIDInventory() error {
 	return nil
 }
 
+func (p *Provider) initEncryptor() error {
+	encryptionConf := p.initializer.Config.EncryptionConfig
+	if encryptionConf == nil || !encryptionConf.Enabled {
+		return nil
+	}
+	encryptor, err := leveldbhelper.NewValueEncryptor(leveldbhelper.NewFileKeyProvider(encryptionConf.KeyPath))
+	if err != nil {
+		return errors.WithMessage(err, "error initializing the encryption of the ledger data")
+	}
+	p.encryptor = encryptor
+	logger.Info("Encryption at rest of the state database and the private data store is enabled")
+	return nil
+}
+
 func (p *Provider) initLedgerStorageProvider() error {
 	// initialize ledger storage
 	privateData := &pvtdatastorage.PrivateDataConfig{
 		PrivateDataConfig: p.initializer.Config.PrivateDataConfig,
 		StorePath:         PvtDataStorePath(p.initializer.Config.RootFSPath),
+		Encryptor:         p.encryptor,
 	}
 
 	ledgerStoreProvider, err := ledgerstorage.NewProvider(
@@ -211,6 +233,7 @@ func (p *Provider) initStateDBProvider() error {
 	stateDB := &privacyenabledstate.StateDBConfig{
 		StateDBConfig: p.initializer.Config.StateDBConfig,
 		LevelDBPath:   StateDBPath(p.initializer.Config.RootFSPath),
+		Encryptor:     p.encryptor,
 	}
 	sysNamespaces := p.initializer.DeployedChaincodeInfoProvider.Namespaces()
 	p.vdbProvider, err = privacyenabledstate.NewCommonStorageDBProvider(
@@ -302,6 +325,18 @@ func (p *Provider) openInternal(ledgerID string) (ledger.PeerLedger, error) {
 		}
 	}
 
+	// Create a block archiver if block retention is configured
+	blockArchiver, err := p.newBlockArchiverFor(ledgerID, blockStore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a block exporter if block export is configured
+	blockExporter, err := p.newBlockExporterFor(ledgerID, blockStore)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create a kvLedger for this chain/ledger, which encapsulates the underlying data stores
 	// (id store, blockstore, state database, history database)
 	l, err := newKVLedger(
@@ -317,6 +352,10 @@ func (p *Provider) openInternal(ledgerID string) (ledger.PeerLedger, error) {
 		p.stats.ledgerStats(ledgerID),
 		p.initializer.CustomTxProcessors,
 		p.hasher,
+		blockArchiver,
+		blockExporter,
+		p.initializer.Config.ValidationWorkerPoolSize,
+		conflicttracker.New(ledgerID, p.stats.mvccConflictsCount),
 	)
 	if err != nil {
 		return nil, err
@@ -334,6 +373,16 @@ func (p *Provider) List() ([]string, error) {
 	return p.idStore.getActiveLedgerIDs()
 }
 
+// StateCache returns the state cache maintained by the state database
+// provider, or nil if the state database maintains no cache
+func (p *Provider) StateCache() *statedb.Cache {
+	cacheHolder, ok := p.vdbProvider.(interface{ StateCache() *statedb.Cache })
+	if !ok {
+		return nil
+	}
+	return cacheHolder.StateCache()
+}
+
 // Close implements the corresponding method from interface ledger.PeerLedgerProvider
 func (p *Provider) Close() {
 	if p.idStore != nil {
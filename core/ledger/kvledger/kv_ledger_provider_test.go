@@ -531,10 +531,11 @@ func testutilNewProvider(conf *lgr.Config, t *testing.T) *Provider {
 
 	provider, err := NewProvider(
 		&lgr.Initializer{
-			DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
-			MetricsProvider:               &disabled.Provider{},
-			Config:                        conf,
-			Hasher:                        cryptoProvider,
+			DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+			ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+			MetricsProvider:                 &disabled.Provider{},
+			Config:                          conf,
+			Hasher:                          cryptoProvider,
 		},
 	)
 	require.NoError(t, err, "Failed to create new Provider")
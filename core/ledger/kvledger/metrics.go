@@ -18,6 +18,7 @@ type stats struct {
 	blockAndPvtdataStoreCommitTime metrics.Histogram
 	statedbCommitTime              metrics.Histogram
 	transactionsCount              metrics.Counter
+	mvccConflictsCount             metrics.Counter
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -26,6 +27,7 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockAndPvtdataStoreCommitTime = metricsProvider.NewHistogram(blockAndPvtdataStoreCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.mvccConflictsCount = metricsProvider.NewCounter(mvccConflictCountOpts)
 	return stats
 }
 
@@ -114,4 +116,13 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	mvccConflictCountOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "mvcc_conflict_count",
+		Help:         "Number of transactions invalidated by MVCC read conflicts or phantom reads.",
+		LabelNames:   []string{"channel", "chaincode", "conflict_type"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{conflict_type}",
+	}
 )
@@ -31,10 +31,11 @@ func TestStatsBlockCommit(t *testing.T) {
 	assert.NoError(t, err)
 	provider, err := NewProvider(
 		&lgr.Initializer{
-			DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
-			MetricsProvider:               testMetricProvider.fakeProvider,
-			Config:                        conf,
-			Hasher:                        cryptoProvider,
+			DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+			ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+			MetricsProvider:                 testMetricProvider.fakeProvider,
+			Config:                          conf,
+			Hasher:                          cryptoProvider,
 		},
 	)
 	if err != nil {
@@ -33,9 +33,22 @@ func RollbackKVLedger(rootFSPath, ledgerID string, blockNum uint64) error {
 	}
 
 	logger.Info("Rolling back ledger store")
-	if err := ledgerstorage.Rollback(blockstorePath, ledgerID, blockNum); err != nil {
+	pvtdataStorePath := PvtDataStorePath(rootFSPath)
+	if err := ledgerstorage.Rollback(blockstorePath, pvtdataStorePath, ledgerID, blockNum); err != nil {
 		return err
 	}
 	logger.Infof("The channel [%s] has been successfully rolled back to the block number [%d]", ledgerID, blockNum)
+
+	// report the range of blocks that the peer has to refetch, from an orderer or
+	// via gossip state transfer from another peer, to get back to the pre-rollback height
+	preResetHt, err := ledgerstorage.LoadPreResetHeight(blockstorePath, []string{ledgerID})
+	if err != nil {
+		return err
+	}
+	if height, ok := preResetHt[ledgerID]; ok && height > blockNum+1 {
+		logger.Infof("When the peer starts, the blocks [%d] to [%d] for the channel [%s] will be refetched"+
+			" from an orderer or another peer to rebuild the dropped databases and reach the pre-rollback height [%d]",
+			blockNum+1, height-1, ledgerID, height)
+	}
 	return nil
 }
@@ -0,0 +1,268 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/pkg/errors"
+)
+
+// stateSnapshotFormatV1 identifies the layout of a state range snapshot file -
+// a JSON header document followed by one JSON document per exported key
+const stateSnapshotFormatV1 = "v1"
+
+// importBatchSize caps the number of keys that are applied to the state
+// database in a single write batch during an import
+const importBatchSize = 1000
+
+// stateRangeSnapshotHeader is the first JSON document of a state range
+// snapshot file and records the source of the exported keys
+type stateRangeSnapshotHeader struct {
+	Format      string `json:"format"`
+	LedgerID    string `json:"ledgerID"`
+	Namespace   string `json:"namespace"`
+	StartKey    string `json:"startKey"`
+	EndKey      string `json:"endKey"`
+	BlockHeight uint64 `json:"blockHeight"`
+}
+
+// stateRangeSnapshotRecord carries one exported key along with the height at
+// which the key was last written on the source channel
+type stateRangeSnapshotRecord struct {
+	Key      string `json:"key"`
+	Value    []byte `json:"value"`
+	Metadata []byte `json:"metadata,omitempty"`
+	BlockNum uint64 `json:"blockNum"`
+	TxNum    uint64 `json:"txNum"`
+}
+
+// StateRangeSnapshotInfo summarizes a state range export or import
+type StateRangeSnapshotInfo struct {
+	LedgerID    string
+	Namespace   string
+	NumKeys     uint64
+	BlockHeight uint64
+}
+
+// ExportStateRange exports a consistent snapshot of the keys of a namespace
+// within the range [startKey, endKey) to a portable file. An empty endKey
+// denotes the end of the namespace. The snapshot reflects the state as of the
+// current block height of the channel, which is recorded in the file header.
+// The peer must be offline when the command is executed - the ledger file lock
+// is held for the duration of the export. The export is supported only with
+// the goleveldb state database
+func ExportStateRange(config *ledger.Config, ledgerID, namespace, startKey, endKey, outputFile string) (*StateRangeSnapshotInfo, error) {
+	if namespace == "" {
+		return nil, errors.New("namespace must not be empty")
+	}
+	fileLockPath := fileLockPath(config.RootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	db, cleanup, err := openStateDBForSnapshot(config, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.Errorf("no state found for the channel [%s], the channel may not exist on this peer", ledgerID)
+	}
+
+	file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating the snapshot file [%s]", outputFile)
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+
+	header := &stateRangeSnapshotHeader{
+		Format:      stateSnapshotFormatV1,
+		LedgerID:    ledgerID,
+		Namespace:   namespace,
+		StartKey:    startKey,
+		EndKey:      endKey,
+		BlockHeight: savepoint.BlockNum + 1,
+	}
+	if err := encoder.Encode(header); err != nil {
+		return nil, err
+	}
+
+	itr, err := db.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	numKeys := uint64(0)
+	for {
+		queryResult, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			break
+		}
+		kv := queryResult.(*statedb.VersionedKV)
+		record := &stateRangeSnapshotRecord{
+			Key:      kv.Key,
+			Value:    kv.Value,
+			Metadata: kv.Metadata,
+			BlockNum: kv.Version.BlockNum,
+			TxNum:    kv.Version.TxNum,
+		}
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+		numKeys++
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+	logger.Infof("Exported [%d] keys of namespace [%s] from the channel [%s] as of block height [%d]",
+		numKeys, namespace, ledgerID, header.BlockHeight)
+	return &StateRangeSnapshotInfo{
+		LedgerID:    ledgerID,
+		Namespace:   namespace,
+		NumKeys:     numKeys,
+		BlockHeight: header.BlockHeight,
+	}, nil
+}
+
+// ImportStateRange imports the keys of a previously exported state range
+// snapshot into the state database of another channel. The imported keys are
+// recorded at the current block height of the target channel so that they
+// behave like any other committed key in the MVCC validation of subsequent
+// transactions. The history database and, where applicable, the block store of
+// the target channel are not updated - the imported keys carry no history.
+// The peer must be offline when the command is executed. The import is
+// supported only with the goleveldb state database
+func ImportStateRange(config *ledger.Config, ledgerID, inputFile string) (*StateRangeSnapshotInfo, error) {
+	fileLockPath := fileLockPath(config.RootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	file, err := os.Open(inputFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening the snapshot file [%s]", inputFile)
+	}
+	defer file.Close()
+	decoder := json.NewDecoder(bufio.NewReader(file))
+
+	header := &stateRangeSnapshotHeader{}
+	if err := decoder.Decode(header); err != nil {
+		return nil, errors.Wrapf(err, "error reading the header of the snapshot file [%s]", inputFile)
+	}
+	if header.Format != stateSnapshotFormatV1 {
+		return nil, errors.Errorf("unsupported snapshot file format [%s], expected [%s]", header.Format, stateSnapshotFormatV1)
+	}
+
+	db, cleanup, err := openStateDBForSnapshot(config, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.Errorf("no state found for the channel [%s], the channel must be created on this peer before importing", ledgerID)
+	}
+	importHeight := version.NewHeight(savepoint.BlockNum, savepoint.TxNum)
+
+	numKeys := uint64(0)
+	batch := statedb.NewUpdateBatch()
+	numInBatch := 0
+	for {
+		record := &stateRangeSnapshotRecord{}
+		if err := decoder.Decode(record); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "error reading a record of the snapshot file [%s]", inputFile)
+		}
+		if record.Key == "" || record.Value == nil {
+			return nil, errors.Errorf("malformed record in the snapshot file [%s]", inputFile)
+		}
+		batch.PutValAndMetadata(header.Namespace, record.Key, record.Value, record.Metadata, importHeight)
+		numKeys++
+		numInBatch++
+		if numInBatch == importBatchSize {
+			if err := db.ApplyUpdates(batch, nil); err != nil {
+				return nil, err
+			}
+			batch = statedb.NewUpdateBatch()
+			numInBatch = 0
+		}
+	}
+	if numInBatch > 0 {
+		if err := db.ApplyUpdates(batch, nil); err != nil {
+			return nil, err
+		}
+	}
+	logger.Infof("Imported [%d] keys of namespace [%s], exported from the channel [%s] at block height [%d], into the channel [%s]",
+		numKeys, header.Namespace, header.LedgerID, header.BlockHeight, ledgerID)
+	return &StateRangeSnapshotInfo{
+		LedgerID:    ledgerID,
+		Namespace:   header.Namespace,
+		NumKeys:     numKeys,
+		BlockHeight: header.BlockHeight,
+	}, nil
+}
+
+// openStateDBForSnapshot opens the goleveldb state database of a channel for
+// an offline export or import. The caller must hold the ledger file lock
+func openStateDBForSnapshot(config *ledger.Config, ledgerID string) (statedb.VersionedDB, func(), error) {
+	if ledgerID == "" {
+		return nil, nil, errors.New("ledgerID must not be empty")
+	}
+	if config.StateDBConfig != nil && config.StateDBConfig.StateDatabase == "CouchDB" {
+		return nil, nil, errors.New("state range export and import are supported only with the goleveldb state database")
+	}
+	var encryptor *leveldbhelper.ValueEncryptor
+	encryptionConf := config.EncryptionConfig
+	if encryptionConf != nil && encryptionConf.Enabled {
+		var err error
+		encryptor, err = leveldbhelper.NewValueEncryptor(leveldbhelper.NewFileKeyProvider(encryptionConf.KeyPath))
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "error initializing the encryption of the ledger data")
+		}
+	}
+	provider, err := stateleveldb.NewVersionedDBProvider(StateDBPath(config.RootFSPath), encryptor)
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := provider.GetDBHandle(ledgerID)
+	if err != nil {
+		provider.Close()
+		return nil, nil, err
+	}
+	return db, provider.Close, nil
+}
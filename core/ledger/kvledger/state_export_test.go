@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAndImportStateRange(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	conf.HistoryDBConfig.Enabled = false
+	defer cleanup()
+	snapshotDir, err := ioutil.TempDir("", "staterangesnapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(snapshotDir)
+
+	provider := testutilNewProvider(conf, t)
+
+	// populate the source channel with keys in two namespaces
+	bg, gb := testutil.NewBlockGenerator(t, "sourceledger", false)
+	sourceLedger, err := provider.Create(gb)
+	require.NoError(t, err)
+	txid := util.GenerateUUID()
+	simulator, _ := sourceLedger.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.SetState("ns1", "key2", []byte("value2"))
+	simulator.SetState("ns1", "key3", []byte("value3"))
+	simulator.SetState("ns2", "key4", []byte("value4"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, sourceLedger.CommitLegacy(&lgr.BlockAndPvtData{Block: block1}, &lgr.CommitOptions{}))
+	sourceLedger.Close()
+
+	// the target channel exists but carries none of the keys
+	_, targetGB := testutil.NewBlockGenerator(t, "targetledger", false)
+	targetLedger, err := provider.Create(targetGB)
+	require.NoError(t, err)
+	targetLedger.Close()
+	provider.Close()
+
+	// a sub range exports only the keys within the range
+	subRangeFile := filepath.Join(snapshotDir, "subrange")
+	info, err := ExportStateRange(conf, "sourceledger", "ns1", "key1", "key3", subRangeFile)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), info.NumKeys)
+	require.Equal(t, uint64(2), info.BlockHeight)
+
+	// an empty endKey exports the namespace to its end
+	fullRangeFile := filepath.Join(snapshotDir, "fullrange")
+	info, err = ExportStateRange(conf, "sourceledger", "ns1", "", "", fullRangeFile)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), info.NumKeys)
+
+	// exporting to an existing file is rejected
+	_, err = ExportStateRange(conf, "sourceledger", "ns1", "", "", fullRangeFile)
+	require.Error(t, err)
+
+	// exporting from a non existing channel is rejected
+	_, err = ExportStateRange(conf, "nonexistingledger", "ns1", "", "", filepath.Join(snapshotDir, "unused"))
+	require.Error(t, err)
+
+	info, err = ImportStateRange(conf, "targetledger", fullRangeFile)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), info.NumKeys)
+	require.Equal(t, "ns1", info.Namespace)
+
+	// importing into a non existing channel is rejected
+	_, err = ImportStateRange(conf, "nonexistingledger", fullRangeFile)
+	require.Error(t, err)
+
+	// a file without a valid header is rejected
+	garbageFile := filepath.Join(snapshotDir, "garbage")
+	require.NoError(t, ioutil.WriteFile(garbageFile, []byte("garbage"), 0600))
+	_, err = ImportStateRange(conf, "targetledger", garbageFile)
+	require.Error(t, err)
+
+	// the imported keys are visible on the target channel
+	provider = testutilNewProvider(conf, t)
+	defer provider.Close()
+	targetLedger, err = provider.Open("targetledger")
+	require.NoError(t, err)
+	defer targetLedger.Close()
+	qe, err := targetLedger.NewQueryExecutor()
+	require.NoError(t, err)
+	defer qe.Done()
+	for _, key := range []string{"key1", "key2", "key3"} {
+		value, err := qe.GetState("ns1", key)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"+key[len(key)-1:]), value)
+	}
+	// the namespace that was not exported remains absent
+	value, err := qe.GetState("ns2", "key4")
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestExportStateRangeCouchDBNotSupported(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	conf.StateDBConfig.StateDatabase = "CouchDB"
+
+	_, err := ExportStateRange(conf, "testledger", "ns1", "", "", filepath.Join(conf.RootFSPath, "unused"))
+	require.EqualError(t, err, "state range export and import are supported only with the goleveldb state database")
+}
@@ -32,11 +32,12 @@ func TestStateListener(t *testing.T) {
 	assert.NoError(t, err)
 	provider, err := NewProvider(
 		&ledger.Initializer{
-			DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
-			StateListeners:                []ledger.StateListener{mockListener},
-			MetricsProvider:               &disabled.Provider{},
-			Config:                        conf,
-			Hasher:                        cryptoProvider,
+			DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+			ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+			StateListeners:                  []ledger.StateListener{mockListener},
+			MetricsProvider:                 &disabled.Provider{},
+			Config:                          conf,
+			Hasher:                          cryptoProvider,
 		},
 	)
 	if err != nil {
@@ -103,11 +104,12 @@ func TestStateListener(t *testing.T) {
 
 	provider, err = NewProvider(
 		&ledger.Initializer{
-			DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
-			StateListeners:                []ledger.StateListener{mockListener},
-			MetricsProvider:               &disabled.Provider{},
-			Config:                        conf,
-			Hasher:                        cryptoProvider,
+			DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+			ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+			StateListeners:                  []ledger.StateListener{mockListener},
+			MetricsProvider:                 &disabled.Provider{},
+			Config:                          conf,
+			Hasher:                          cryptoProvider,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,389 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// maxReportedDivergentKeys caps the number of divergent keys that are carried
+// in a StateVerificationResult - the total count is reported in any case
+const maxReportedDivergentKeys = 100
+
+// DivergentKey describes one key for which the state rebuilt from the blocks
+// disagrees with the live state database
+type DivergentKey struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Reason    string `json:"reason"`
+}
+
+// StateVerificationResult summarizes the comparison of the state rebuilt from
+// the blocks of a channel against the live state database of the channel
+type StateVerificationResult struct {
+	// LedgerID is the ledger that was verified
+	LedgerID string
+	// BlockHeight is the block height as of which the state was rebuilt and compared
+	BlockHeight uint64
+	// NumKeysChecked is the number of distinct keys seen across the live and the rebuilt state
+	NumKeysChecked uint64
+	// LiveStateHash is the hash of the compared portion of the live state database
+	LiveStateHash []byte
+	// RebuiltStateHash is the hash of the corresponding portion of the rebuilt state
+	RebuiltStateHash []byte
+	// InSync is true if the live state database matches the rebuilt state
+	InSync bool
+	// NumDivergentKeys is the total number of keys on which the two disagree
+	NumDivergentKeys uint64
+	// DivergentKeys carries up to maxReportedDivergentKeys of the divergent keys
+	DivergentKeys []*DivergentKey
+}
+
+// VerifyState rebuilds the public state of a channel from its committed blocks
+// into a throwaway state database and compares the outcome against the live
+// state database, reporting the keys on which the two disagree. A divergence
+// indicates corruption of the state database or nondeterminism in a past
+// commit. The rebuild re-applies the public write-sets of the valid endorser
+// transactions - the namespaces that cannot be rebuilt deterministically from
+// the blocks alone are excluded from the comparison, namely the private data
+// namespaces (which are subject to purging) and the internal namespace
+// maintained by the custom transaction processors. The peer must be offline
+// when the command is executed - the ledger file lock is held for the duration
+// of the verification. The command is supported only with the goleveldb state
+// database
+func VerifyState(config *ledger.Config, ledgerID string) (*StateVerificationResult, error) {
+	if config.StateDBConfig != nil && config.StateDBConfig.StateDatabase == "CouchDB" {
+		return nil, errors.New("state verification is supported only with the goleveldb state database")
+	}
+	fileLockPath := fileLockPath(config.RootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	liveDB, cleanup, err := openStateDBForSnapshot(config, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	savepoint, err := liveDB.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.Errorf("no state found for the channel [%s], the channel may not exist on this peer", ledgerID)
+	}
+
+	sandboxDir, err := ioutil.TempDir("", "fabric-stateverify")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(sandboxDir)
+	sandboxProvider, err := stateleveldb.NewVersionedDBProvider(sandboxDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer sandboxProvider.Close()
+	sandboxDB, err := sandboxProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("Rebuilding the state of the channel [%s] from the blocks up to block number [%d]",
+		ledgerID, savepoint.BlockNum)
+	err = ledgerstorage.ScanBlockStore(BlockStorePath(config.RootFSPath), ledgerID,
+		func(block *common.Block) error {
+			if block.Header.Number > savepoint.BlockNum {
+				// the state database has not caught up with this block yet -
+				// the comparison is performed as of the savepoint
+				return nil
+			}
+			return applyBlockToStateDB(block, sandboxDB)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := compareStateDBs(ledgerID, savepoint, liveDB, sandboxDB)
+	if err != nil {
+		return nil, err
+	}
+	if result.InSync {
+		logger.Infof("The state database of the channel [%s] matches the state rebuilt from the blocks - [%d] keys verified",
+			ledgerID, result.NumKeysChecked)
+	} else {
+		logger.Warnf("The state database of the channel [%s] diverges from the state rebuilt from the blocks on [%d] keys",
+			ledgerID, result.NumDivergentKeys)
+	}
+	return result, nil
+}
+
+// applyBlockToStateDB re-applies the public write-sets of the valid endorser
+// transactions of a block to the given state database
+func applyBlockToStateDB(block *common.Block, db statedb.VersionedDB) error {
+	if block.Data == nil || len(block.Data.Data) == 0 {
+		return nil
+	}
+	batch := statedb.NewUpdateBatch()
+	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFilter) < len(block.Data.Data) {
+		// the block carries no validation flags - this happens only for the
+		// genesis block, whose single config transaction is valid by definition
+		txsFilter = util.NewTxValidationFlags(len(block.Data.Data))
+	}
+	for tranNo, envBytes := range block.Data.Data {
+		if txsFilter.IsInvalid(tranNo) {
+			continue
+		}
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return err
+		}
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return err
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		respPayload, err := protoutil.GetActionFromEnvelope(envBytes)
+		if err != nil {
+			return err
+		}
+		txRWSet := &rwsetutil.TxRwSet{}
+		if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+			return err
+		}
+		height := version.NewHeight(block.Header.Number, uint64(tranNo))
+		for _, nsRWSet := range txRWSet.NsRwSets {
+			ns := nsRWSet.NameSpace
+			for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+				if kvWrite.IsDelete {
+					batch.Delete(ns, kvWrite.Key, height)
+				} else {
+					batch.Put(ns, kvWrite.Key, kvWrite.Value, height)
+				}
+			}
+			for _, metadataWrite := range nsRWSet.KvRwSet.MetadataWrites {
+				// a metadata write updates the metadata and the version of the
+				// key but preserves its value - it is a no-op on an absent key
+				value := latestValue(batch, db, ns, metadataWrite.Key)
+				if value == nil {
+					continue
+				}
+				var metadata []byte
+				if metadataWrite.Entries != nil {
+					var err error
+					metadata, err = storageutil.SerializeMetadata(metadataWrite.Entries)
+					if err != nil {
+						return err
+					}
+				}
+				batch.PutValAndMetadata(ns, metadataWrite.Key, value, metadata, height)
+			}
+		}
+	}
+	return db.ApplyUpdates(batch, version.NewHeight(block.Header.Number, uint64(len(block.Data.Data)-1)))
+}
+
+// latestValue returns the value of a key as of the writes applied so far - the
+// writes of the preceding transactions of the current block take precedence
+// over the content of the state database
+func latestValue(batch *statedb.UpdateBatch, db statedb.VersionedDB, ns, key string) []byte {
+	if vv := batch.Get(ns, key); vv != nil {
+		return vv.Value
+	}
+	vv, err := db.GetState(ns, key)
+	if err != nil || vv == nil {
+		return nil
+	}
+	return vv.Value
+}
+
+// compareStateDBs compares the rebuildable namespaces of the live state
+// database against the rebuilt state and collects the divergent keys
+func compareStateDBs(ledgerID string, savepoint *version.Height, liveDB, rebuiltDB statedb.VersionedDB) (*StateVerificationResult, error) {
+	namespaces, err := unionOfNamespaces(liveDB, rebuiltDB)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StateVerificationResult{
+		LedgerID:    ledgerID,
+		BlockHeight: savepoint.BlockNum + 1,
+	}
+	liveHasher := sha256.New()
+	rebuiltHasher := sha256.New()
+	for _, ns := range namespaces {
+		if !rebuildableNamespace(ns) {
+			continue
+		}
+		if err := compareNamespace(ns, liveDB, rebuiltDB, liveHasher, rebuiltHasher, result); err != nil {
+			return nil, err
+		}
+	}
+	result.LiveStateHash = liveHasher.Sum(nil)
+	result.RebuiltStateHash = rebuiltHasher.Sum(nil)
+	result.InSync = result.NumDivergentKeys == 0 && bytes.Equal(result.LiveStateHash, result.RebuiltStateHash)
+	return result, nil
+}
+
+// compareNamespace walks the sorted keys of a namespace in the two state
+// databases in lockstep and records the keys on which they disagree
+func compareNamespace(ns string, liveDB, rebuiltDB statedb.VersionedDB, liveHasher, rebuiltHasher hash.Hash, result *StateVerificationResult) error {
+	liveItr, err := liveDB.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return err
+	}
+	defer liveItr.Close()
+	rebuiltItr, err := rebuiltDB.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return err
+	}
+	defer rebuiltItr.Close()
+
+	liveKV, err := nextKV(liveItr)
+	if err != nil {
+		return err
+	}
+	rebuiltKV, err := nextKV(rebuiltItr)
+	if err != nil {
+		return err
+	}
+	for liveKV != nil || rebuiltKV != nil {
+		switch {
+		case rebuiltKV == nil || (liveKV != nil && liveKV.Key < rebuiltKV.Key):
+			hashStateKV(liveHasher, liveKV)
+			result.addDivergentKey(ns, liveKV.Key, "the key is missing from the rebuilt state")
+			if liveKV, err = nextKV(liveItr); err != nil {
+				return err
+			}
+		case liveKV == nil || rebuiltKV.Key < liveKV.Key:
+			hashStateKV(rebuiltHasher, rebuiltKV)
+			result.addDivergentKey(ns, rebuiltKV.Key, "the key is missing from the live state")
+			if rebuiltKV, err = nextKV(rebuiltItr); err != nil {
+				return err
+			}
+		default:
+			hashStateKV(liveHasher, liveKV)
+			hashStateKV(rebuiltHasher, rebuiltKV)
+			switch {
+			case !bytes.Equal(liveKV.Value, rebuiltKV.Value):
+				result.addDivergentKey(ns, liveKV.Key, "value mismatch")
+			case liveKV.Version.Compare(rebuiltKV.Version) != 0:
+				result.addDivergentKey(ns, liveKV.Key, "version mismatch")
+			case !bytes.Equal(liveKV.Metadata, rebuiltKV.Metadata):
+				result.addDivergentKey(ns, liveKV.Key, "metadata mismatch")
+			}
+			if liveKV, err = nextKV(liveItr); err != nil {
+				return err
+			}
+			if rebuiltKV, err = nextKV(rebuiltItr); err != nil {
+				return err
+			}
+		}
+		result.NumKeysChecked++
+	}
+	return nil
+}
+
+func (r *StateVerificationResult) addDivergentKey(ns, key, reason string) {
+	r.NumDivergentKeys++
+	if len(r.DivergentKeys) < maxReportedDivergentKeys {
+		r.DivergentKeys = append(r.DivergentKeys, &DivergentKey{Namespace: ns, Key: key, Reason: reason})
+	}
+}
+
+// rebuildableNamespace returns false for the namespaces that cannot be rebuilt
+// deterministically from the blocks alone - the private data namespaces
+// (identified by the namespace-collection joiner) and the internal namespace
+// written by the custom transaction processors
+func rebuildableNamespace(ns string) bool {
+	return ns != "" && !strings.Contains(ns, "$$")
+}
+
+// unionOfNamespaces returns the sorted union of the namespaces present in the
+// two state databases
+func unionOfNamespaces(liveDB, rebuiltDB statedb.VersionedDB) ([]string, error) {
+	type namespaceLister interface {
+		GetAllNamespaces() ([]string, error)
+	}
+	seen := map[string]struct{}{}
+	for _, db := range []statedb.VersionedDB{liveDB, rebuiltDB} {
+		lister, ok := db.(namespaceLister)
+		if !ok {
+			return nil, errors.New("the state database does not support listing its namespaces")
+		}
+		namespaces, err := lister.GetAllNamespaces()
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces {
+			seen[ns] = struct{}{}
+		}
+	}
+	union := make([]string, 0, len(seen))
+	for ns := range seen {
+		union = append(union, ns)
+	}
+	sort.Strings(union)
+	return union, nil
+}
+
+// stateKVHashSep separates the namespace and the key in the running hash of a
+// state database
+var stateKVHashSep = []byte{0x00}
+
+// hashStateKV adds a key along with its value, metadata, and version to the
+// running hash of a state database
+func hashStateKV(hasher hash.Hash, kv *statedb.VersionedKV) {
+	hasher.Write([]byte(kv.Namespace))
+	hasher.Write(stateKVHashSep)
+	hasher.Write([]byte(kv.Key))
+	hasher.Write(stateKVHashSep)
+	hasher.Write(kv.Version.ToBytes())
+	hasher.Write(kv.Value)
+	hasher.Write(kv.Metadata)
+}
+
+func nextKV(itr statedb.ResultsIterator) (*statedb.VersionedKV, error) {
+	queryResult, err := itr.Next()
+	if err != nil {
+		return nil, err
+	}
+	if queryResult == nil {
+		return nil, nil
+	}
+	return queryResult.(*statedb.VersionedKV), nil
+}
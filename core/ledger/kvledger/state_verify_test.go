@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyState(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	conf.HistoryDBConfig.Enabled = false
+	defer cleanup()
+
+	provider := testutilNewProvider(conf, t)
+	bg, gb := testutil.NewBlockGenerator(t, "testledger", false)
+	ledger, err := provider.Create(gb)
+	require.NoError(t, err)
+
+	// populate two namespaces, update one key, and delete another
+	txid := util.GenerateUUID()
+	simulator, _ := ledger.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key1", []byte("value1"))
+	simulator.SetState("ns1", "key2", []byte("value2"))
+	simulator.SetState("ns1", "key3", []byte("value3"))
+	simulator.SetState("ns2", "key4", []byte("value4"))
+	simulator.Done()
+	simRes, _ := simulator.GetTxSimulationResults()
+	pubSimBytes, _ := simRes.GetPubSimulationBytes()
+	block1 := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, ledger.CommitLegacy(&lgr.BlockAndPvtData{Block: block1}, &lgr.CommitOptions{}))
+
+	txid = util.GenerateUUID()
+	simulator, _ = ledger.NewTxSimulator(txid)
+	simulator.SetState("ns1", "key1", []byte("value1-updated"))
+	simulator.DeleteState("ns1", "key2")
+	simulator.Done()
+	simRes, _ = simulator.GetTxSimulationResults()
+	pubSimBytes, _ = simRes.GetPubSimulationBytes()
+	block2 := bg.NextBlock([][]byte{pubSimBytes})
+	require.NoError(t, ledger.CommitLegacy(&lgr.BlockAndPvtData{Block: block2}, &lgr.CommitOptions{}))
+	ledger.Close()
+	provider.Close()
+
+	// an untampered state database matches the rebuilt state
+	result, err := VerifyState(conf, "testledger")
+	require.NoError(t, err)
+	require.True(t, result.InSync)
+	require.Equal(t, uint64(3), result.BlockHeight)
+	require.Equal(t, uint64(3), result.NumKeysChecked)
+	require.Equal(t, uint64(0), result.NumDivergentKeys)
+	require.Empty(t, result.DivergentKeys)
+	require.Equal(t, result.LiveStateHash, result.RebuiltStateHash)
+
+	// tamper with the live state database behind the ledger's back
+	dbProvider, err := stateleveldb.NewVersionedDBProvider(StateDBPath(conf.RootFSPath), nil)
+	require.NoError(t, err)
+	db, err := dbProvider.GetDBHandle("testledger")
+	require.NoError(t, err)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key3", []byte("tampered"), version.NewHeight(1, 0))
+	batch.Put("ns1", "rogue", []byte("rogue-value"), version.NewHeight(1, 0))
+	batch.Delete("ns2", "key4", version.NewHeight(1, 0))
+	require.NoError(t, db.ApplyUpdates(batch, nil))
+	dbProvider.Close()
+
+	result, err = VerifyState(conf, "testledger")
+	require.NoError(t, err)
+	require.False(t, result.InSync)
+	require.NotEqual(t, result.LiveStateHash, result.RebuiltStateHash)
+	require.Equal(t, uint64(4), result.NumKeysChecked)
+	require.Equal(t, uint64(3), result.NumDivergentKeys)
+	require.ElementsMatch(t,
+		[]*DivergentKey{
+			{Namespace: "ns1", Key: "key3", Reason: "value mismatch"},
+			{Namespace: "ns1", Key: "rogue", Reason: "the key is missing from the rebuilt state"},
+			{Namespace: "ns2", Key: "key4", Reason: "the key is missing from the live state"},
+		},
+		result.DivergentKeys,
+	)
+
+	// verifying a non existing channel is rejected
+	_, err = VerifyState(conf, "nonexistingledger")
+	require.Error(t, err)
+}
+
+func TestVerifyStateCouchDBNotSupported(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	conf.StateDBConfig.StateDatabase = "CouchDB"
+
+	_, err := VerifyState(conf, "testledger")
+	require.EqualError(t, err, "state verification is supported only with the goleveldb state database")
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/ledger/mock"
 	corepeer "github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/msp"
@@ -227,6 +228,10 @@ func populateMissingsWithTestDefaults(t *testing.T, initializer *ledgermgmt.Init
 		initializer.MetricsProvider = &disabled.Provider{}
 	}
 
+	if initializer.ChaincodeLifecycleEventProvider == nil {
+		initializer.ChaincodeLifecycleEventProvider = &mock.ChaincodeLifecycleEventProvider{}
+	}
+
 	if initializer.Config == nil {
 		rootPath, err := ioutil.TempDir("/tmp", "ledgersData")
 		if err != nil {
@@ -118,6 +118,49 @@ func TestTxWithWrongPvtdata(t *testing.T) {
 	h.verifyPvtState("cc1", "coll1", "key2", "")
 }
 
+func TestPvtdataKeyLevelPurge(t *testing.T) {
+	env := newEnv(t)
+	defer env.cleanup()
+	env.initLedgerMgmt()
+	h := env.newTestHelperCreateLgr("ledger1", t)
+	collConf := []*collConf{{name: "coll1", btl: 0}}
+
+	// deploy cc1 with 'collConf'
+	h.simulateDeployTx("cc1", collConf)
+	h.cutBlockAndCommitLegacy()
+
+	// commit key1 and key2 in block 2
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key1", "value1")
+		s.setPvtdata("cc1", "coll1", "key2", "value2")
+	})
+	h.cutBlockAndCommitLegacy()
+
+	// purge key1 from the peer
+	purger := h.lgr.(ledger.PvtDataPurger)
+	h.assertNoError(purger.PurgePrivateData("cc1", "coll1", []string{"key1"}))
+
+	// as the hashed version of key1 is retained, the purged key behaves like missing pvtdata
+	h.simulateDataTx("", func(s *simulator) {
+		h.assertError(s.GetPrivateData("cc1", "coll1", "key1"))
+	})
+	h.verifyPvtState("cc1", "coll1", "key2", "value2")
+	h.verifyBlockAndPvtData(2, nil, func(r *retrievedBlockAndPvtdata) {
+		r.pvtdataShouldNotContainKey("cc1", "coll1", "key1") // key1 should have been purged from the pvtdata storage
+		r.pvtdataShouldContain(0, "cc1", "coll1", "key2", "value2")
+	})
+
+	// a purge does not prevent a future transaction from writing the key again
+	h.simulateDataTx("", func(s *simulator) {
+		s.setPvtdata("cc1", "coll1", "key1", "newvalue1")
+	})
+	h.cutBlockAndCommitLegacy()
+	h.verifyPvtState("cc1", "coll1", "key1", "newvalue1")
+	h.verifyBlockAndPvtData(3, nil, func(r *retrievedBlockAndPvtdata) {
+		r.pvtdataShouldContain(1, "cc1", "coll1", "key1", "newvalue1")
+	})
+}
+
 func TestBTL(t *testing.T) {
 	env := newEnv(t)
 	defer env.cleanup()
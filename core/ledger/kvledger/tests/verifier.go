@@ -188,6 +188,26 @@ func (r *retrievedBlockAndPvtdata) pvtdataShouldNotContain(ns, coll string) {
 	}
 }
 
+func (r *retrievedBlockAndPvtdata) pvtdataShouldNotContainKey(ns, coll, key string) {
+	for _, txPvtData := range r.BlockAndPvtData.PvtData {
+		for _, nsdata := range txPvtData.WriteSet.NsPvtRwset {
+			if nsdata.Namespace != ns {
+				continue
+			}
+			for _, colldata := range nsdata.CollectionPvtRwset {
+				if colldata.CollectionName != coll {
+					continue
+				}
+				rwset := &kvrwset.KVRWSet{}
+				r.assert.NoError(proto.Unmarshal(colldata.Rwset, rwset))
+				for _, w := range rwset.Writes {
+					r.assert.NotEqual(key, w.Key)
+				}
+			}
+		}
+	}
+}
+
 func (r *retrievedBlockAndPvtdata) sameBlockHeaderAndData(expectedBlock *common.Block) {
 	r.assert.True(proto.Equal(expectedBlock.Data, r.BlockAndPvtData.Block.Data))
 	r.assert.True(proto.Equal(expectedBlock.Header, r.BlockAndPvtData.Block.Header))
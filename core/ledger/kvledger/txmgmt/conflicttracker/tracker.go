@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package conflicttracker
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+)
+
+const (
+	// maxTrackedKeys limits the number of conflicting keys remembered per chaincode.
+	// When the limit is hit, the key with the fewest conflicts makes room for a new one
+	maxTrackedKeys = 1024
+	// numTopKeys is the number of most conflicting keys reported per chaincode
+	numTopKeys = 10
+)
+
+// Tracker accumulates, in memory, the statistics about the transactions of a channel
+// that were invalidated by MVCC read conflicts or phantom reads - i.e., the number of
+// conflicts per chaincode and per key, and the conflict rates relative to the total
+// number of transactions processed. The validator records the offending keys via the
+// function `RecordConflict` (potentially from multiple goroutines) and the committer
+// reports the per-transaction outcomes via the function `ObserveTransactions`
+type Tracker struct {
+	ledgerid         string
+	conflictsCounter metrics.Counter
+
+	lock       sync.Mutex
+	chaincodes map[string]*chaincodeConflicts
+}
+
+type chaincodeConflicts struct {
+	totalTxs         uint64
+	mvccConflicts    uint64
+	phantomConflicts uint64
+	keys             map[string]*keyConflicts
+}
+
+type keyConflicts struct {
+	mvccConflicts    uint64
+	phantomConflicts uint64
+}
+
+// New constructs a `Tracker` for the given ledger. The supplied counter is expected to
+// carry the labels channel, chaincode, and conflict_type
+func New(ledgerid string, conflictsCounter metrics.Counter) *Tracker {
+	return &Tracker{
+		ledgerid:         ledgerid,
+		conflictsCounter: conflictsCounter,
+		chaincodes:       make(map[string]*chaincodeConflicts),
+	}
+}
+
+// RecordConflict implements the interface `statebasedval.ConflictRecorder`. The
+// namespace corresponds to the chaincode whose read could not be validated
+func (t *Tracker) RecordConflict(namespace, key string, validationCode peer.TxValidationCode) {
+	t.conflictsCounter.With(
+		"channel", t.ledgerid,
+		"chaincode", namespace,
+		"conflict_type", validationCode.String(),
+	).Add(1)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	kc := t.keyConflictsEntry(namespace, key)
+	switch validationCode {
+	case peer.TxValidationCode_PHANTOM_READ_CONFLICT:
+		kc.phantomConflicts++
+	default:
+		kc.mvccConflicts++
+	}
+}
+
+// ObserveTransactions accounts the outcomes of the transactions of a committed block
+func (t *Tracker) ObserveTransactions(txstatsInfo []*txmgr.TxStatInfo) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, txstat := range txstatsInfo {
+		if txstat.ChaincodeID == nil {
+			continue
+		}
+		cc := t.chaincodeEntry(txstat.ChaincodeID.Name)
+		cc.totalTxs++
+		switch txstat.ValidationCode {
+		case peer.TxValidationCode_MVCC_READ_CONFLICT:
+			cc.mvccConflicts++
+		case peer.TxValidationCode_PHANTOM_READ_CONFLICT:
+			cc.phantomConflicts++
+		}
+	}
+}
+
+// Stats returns a point-in-time snapshot of the accumulated statistics. The chaincodes
+// are sorted by name and the keys of a chaincode by decreasing number of conflicts
+func (t *Tracker) Stats() *ledger.ConflictStats {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	stats := &ledger.ConflictStats{Channel: t.ledgerid}
+	for name, cc := range t.chaincodes {
+		ccStats := &ledger.ChaincodeConflictStats{
+			Chaincode:            name,
+			TotalTransactions:    cc.totalTxs,
+			MVCCReadConflicts:    cc.mvccConflicts,
+			PhantomReadConflicts: cc.phantomConflicts,
+			TopKeys:              cc.topKeys(),
+		}
+		if cc.totalTxs > 0 {
+			ccStats.ConflictRate = float64(cc.mvccConflicts+cc.phantomConflicts) / float64(cc.totalTxs)
+		}
+		stats.Chaincodes = append(stats.Chaincodes, ccStats)
+	}
+	sort.Slice(stats.Chaincodes, func(i, j int) bool {
+		return stats.Chaincodes[i].Chaincode < stats.Chaincodes[j].Chaincode
+	})
+	return stats
+}
+
+func (t *Tracker) chaincodeEntry(name string) *chaincodeConflicts {
+	cc, ok := t.chaincodes[name]
+	if !ok {
+		cc = &chaincodeConflicts{keys: make(map[string]*keyConflicts)}
+		t.chaincodes[name] = cc
+	}
+	return cc
+}
+
+func (t *Tracker) keyConflictsEntry(namespace, key string) *keyConflicts {
+	cc := t.chaincodeEntry(namespace)
+	kc, ok := cc.keys[key]
+	if !ok {
+		if len(cc.keys) >= maxTrackedKeys {
+			cc.evictLeastConflictingKey()
+		}
+		kc = &keyConflicts{}
+		cc.keys[key] = kc
+	}
+	return kc
+}
+
+func (cc *chaincodeConflicts) evictLeastConflictingKey() {
+	var evictKey string
+	var evictConflicts uint64
+	for key, kc := range cc.keys {
+		conflicts := kc.mvccConflicts + kc.phantomConflicts
+		if evictKey == "" || conflicts < evictConflicts {
+			evictKey = key
+			evictConflicts = conflicts
+		}
+	}
+	delete(cc.keys, evictKey)
+}
+
+func (cc *chaincodeConflicts) topKeys() []*ledger.KeyConflicts {
+	var keys []*ledger.KeyConflicts
+	for key, kc := range cc.keys {
+		keys = append(keys, &ledger.KeyConflicts{
+			Key:                  key,
+			MVCCReadConflicts:    kc.mvccConflicts,
+			PhantomReadConflicts: kc.phantomConflicts,
+		})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ci := keys[i].MVCCReadConflicts + keys[i].PhantomReadConflicts
+		cj := keys[j].MVCCReadConflicts + keys[j].PhantomReadConflicts
+		if ci != cj {
+			return ci > cj
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if len(keys) > numTopKeys {
+		keys = keys[:numTopKeys]
+	}
+	return keys
+}
@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package conflicttracker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTracker() (*Tracker, *metricsfakes.Counter) {
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	return New("testchannel", fakeCounter), fakeCounter
+}
+
+func TestTrackerStats(t *testing.T) {
+	tracker, fakeCounter := newTestTracker()
+
+	tracker.RecordConflict("cc1", "key1", peer.TxValidationCode_MVCC_READ_CONFLICT)
+	tracker.RecordConflict("cc1", "key1", peer.TxValidationCode_MVCC_READ_CONFLICT)
+	tracker.RecordConflict("cc1", "range[a,b]", peer.TxValidationCode_PHANTOM_READ_CONFLICT)
+	tracker.RecordConflict("cc2", "key2", peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	tracker.ObserveTransactions([]*txmgr.TxStatInfo{
+		{ChaincodeID: &peer.ChaincodeID{Name: "cc1"}, ValidationCode: peer.TxValidationCode_VALID},
+		{ChaincodeID: &peer.ChaincodeID{Name: "cc1"}, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+		{ChaincodeID: &peer.ChaincodeID{Name: "cc1"}, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+		{ChaincodeID: &peer.ChaincodeID{Name: "cc1"}, ValidationCode: peer.TxValidationCode_PHANTOM_READ_CONFLICT},
+		{ChaincodeID: &peer.ChaincodeID{Name: "cc2"}, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+		// a transaction without a chaincode id, such as a config transaction, is ignored
+		{ValidationCode: peer.TxValidationCode_VALID},
+	})
+
+	stats := tracker.Stats()
+	require.Equal(t, &ledger.ConflictStats{
+		Channel: "testchannel",
+		Chaincodes: []*ledger.ChaincodeConflictStats{
+			{
+				Chaincode:            "cc1",
+				TotalTransactions:    4,
+				MVCCReadConflicts:    2,
+				PhantomReadConflicts: 1,
+				ConflictRate:         0.75,
+				TopKeys: []*ledger.KeyConflicts{
+					{Key: "key1", MVCCReadConflicts: 2},
+					{Key: "range[a,b]", PhantomReadConflicts: 1},
+				},
+			},
+			{
+				Chaincode:         "cc2",
+				TotalTransactions: 1,
+				MVCCReadConflicts: 1,
+				ConflictRate:      1,
+				TopKeys: []*ledger.KeyConflicts{
+					{Key: "key2", MVCCReadConflicts: 1},
+				},
+			},
+		},
+	}, stats)
+
+	require.Equal(t, 4, fakeCounter.AddCallCount())
+	require.Equal(t,
+		[]string{"channel", "testchannel", "chaincode", "cc1", "conflict_type", "MVCC_READ_CONFLICT"},
+		fakeCounter.WithArgsForCall(0),
+	)
+	require.Equal(t,
+		[]string{"channel", "testchannel", "chaincode", "cc1", "conflict_type", "PHANTOM_READ_CONFLICT"},
+		fakeCounter.WithArgsForCall(2),
+	)
+}
+
+func TestTrackerTopKeysLimit(t *testing.T) {
+	tracker, _ := newTestTracker()
+
+	// key-i causes i+1 conflicts so that the top keys are the last numTopKeys
+	// in decreasing order
+	for i := 0; i < numTopKeys+5; i++ {
+		for j := 0; j <= i; j++ {
+			tracker.RecordConflict("cc1", fmt.Sprintf("key-%d", i), peer.TxValidationCode_MVCC_READ_CONFLICT)
+		}
+	}
+
+	topKeys := tracker.Stats().Chaincodes[0].TopKeys
+	require.Len(t, topKeys, numTopKeys)
+	for i, kc := range topKeys {
+		require.Equal(t, fmt.Sprintf("key-%d", numTopKeys+4-i), kc.Key)
+		require.Equal(t, uint64(numTopKeys+5-i), kc.MVCCReadConflicts)
+	}
+}
+
+func TestTrackerKeyCapEviction(t *testing.T) {
+	tracker, _ := newTestTracker()
+
+	// fill the tracker to its cap with keys that caused two conflicts each and
+	// a single key that caused only one
+	for i := 0; i < maxTrackedKeys-1; i++ {
+		tracker.RecordConflict("cc1", fmt.Sprintf("key-%d", i), peer.TxValidationCode_MVCC_READ_CONFLICT)
+		tracker.RecordConflict("cc1", fmt.Sprintf("key-%d", i), peer.TxValidationCode_MVCC_READ_CONFLICT)
+	}
+	tracker.RecordConflict("cc1", "rare-key", peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	// a new key makes the tracker evict the key with the fewest conflicts
+	tracker.RecordConflict("cc1", "new-key", peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	keys := map[string]bool{}
+	for key := range tracker.chaincodes["cc1"].keys {
+		keys[key] = true
+	}
+	require.Len(t, keys, maxTrackedKeys)
+	require.False(t, keys["rare-key"])
+	require.True(t, keys["new-key"])
+}
@@ -9,9 +9,11 @@ package privacyenabledstate
 import (
 	"encoding/base64"
 	"strings"
+	"sync"
 
 	"github.com/hyperledger/fabric-lib-go/healthz"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -20,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statepostgres"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/pkg/errors"
@@ -31,7 +34,9 @@ const (
 	nsJoiner       = "$$"
 	pvtDataPrefix  = "p"
 	hashDataPrefix = "h"
+	goLevelDB      = "goleveldb"
 	couchDB        = "CouchDB"
+	postgresDB     = "Postgres"
 )
 
 // StateDBConfig encapsulates the configuration for stateDB on the ledger.
@@ -42,12 +47,64 @@ type StateDBConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.StateDBConfig and not exposed to other components.
 	LevelDBPath string
+	// Encryptor, when set, transparently encrypts the state database at rest.
+	// It applies only when statedb type is "goleveldb" and, like LevelDBPath,
+	// it is internally computed by the ledger component.
+	Encryptor *leveldbhelper.ValueEncryptor
+}
+
+// VersionedDBProviderFactory constructs the VersionedDBProvider of a state
+// database implementation from the state database configuration.
+type VersionedDBProviderFactory func(
+	stateDBConf *StateDBConfig,
+	metricsProvider metrics.Provider,
+	sysNamespaces []string,
+) (statedb.VersionedDBProvider, error)
+
+var (
+	vdbFactoriesLock sync.RWMutex
+	vdbFactories     = map[string]VersionedDBProviderFactory{
+		goLevelDB:  levelDBProviderFactory,
+		couchDB:    couchDBProviderFactory,
+		postgresDB: postgresProviderFactory,
+	}
+)
+
+// RegisterStateDBProvider registers factory as the constructor of the state
+// database selected by setting StateDatabase to name, so that alternative
+// state database implementations can be plugged into the peer. It must be
+// called before the ledger component is initialized. The built-in
+// implementations "goleveldb", "CouchDB" and "Postgres" are pre-registered.
+func RegisterStateDBProvider(name string, factory VersionedDBProviderFactory) {
+	vdbFactoriesLock.Lock()
+	defer vdbFactoriesLock.Unlock()
+	vdbFactories[name] = factory
+}
+
+func lookupStateDBProviderFactory(name string) VersionedDBProviderFactory {
+	vdbFactoriesLock.RLock()
+	defer vdbFactoriesLock.RUnlock()
+	return vdbFactories[name]
+}
+
+func levelDBProviderFactory(stateDBConf *StateDBConfig, _ metrics.Provider, _ []string) (statedb.VersionedDBProvider, error) {
+	return stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath, stateDBConf.Encryptor)
+}
+
+func couchDBProviderFactory(stateDBConf *StateDBConfig, metricsProvider metrics.Provider, sysNamespaces []string) (statedb.VersionedDBProvider, error) {
+	cache := statedb.NewCache(stateDBConf.CouchDB.UserCacheSizeMBs, sysNamespaces, metricsProvider)
+	return statecouchdb.NewVersionedDBProvider(stateDBConf.CouchDB, metricsProvider, cache)
+}
+
+func postgresProviderFactory(stateDBConf *StateDBConfig, _ metrics.Provider, _ []string) (statedb.VersionedDBProvider, error) {
+	return statepostgres.NewVersionedDBProvider(stateDBConf.Postgres)
 }
 
 // CommonStorageDBProvider implements interface DBProvider
 type CommonStorageDBProvider struct {
 	statedb.VersionedDBProvider
 	HealthCheckRegistry ledger.HealthCheckRegistry
+	HealthCheckerName   string
 	bookkeepingProvider bookkeeping.Provider
 }
 
@@ -60,21 +117,20 @@ func NewCommonStorageDBProvider(
 	sysNamespaces []string,
 ) (DBProvider, error) {
 
-	var vdbProvider statedb.VersionedDBProvider
-	var err error
-
-	if stateDBConf != nil && stateDBConf.StateDatabase == couchDB {
-		cache := statedb.NewCache(stateDBConf.CouchDB.UserCacheSizeMBs, sysNamespaces)
-		if vdbProvider, err = statecouchdb.NewVersionedDBProvider(stateDBConf.CouchDB, metricsProvider, cache); err != nil {
-			return nil, err
-		}
-	} else {
-		if vdbProvider, err = stateleveldb.NewVersionedDBProvider(stateDBConf.LevelDBPath); err != nil {
-			return nil, err
-		}
+	stateDatabase := goLevelDB
+	if stateDBConf != nil && stateDBConf.StateDatabase != "" {
+		stateDatabase = stateDBConf.StateDatabase
+	}
+	factory := lookupStateDBProviderFactory(stateDatabase)
+	if factory == nil {
+		return nil, errors.Errorf("unsupported state database: %s", stateDatabase)
+	}
+	vdbProvider, err := factory(stateDBConf, metricsProvider, sysNamespaces)
+	if err != nil {
+		return nil, err
 	}
 
-	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
+	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, strings.ToLower(stateDatabase), bookkeeperProvider}
 
 	err = dbProvider.RegisterHealthChecker()
 	if err != nil {
@@ -84,10 +140,20 @@ func NewCommonStorageDBProvider(
 	return dbProvider, nil
 }
 
+// StateCache returns the state cache maintained by the underlying versioned
+// database provider, or nil if the provider maintains no cache
+func (p *CommonStorageDBProvider) StateCache() *statedb.Cache {
+	cacheHolder, ok := p.VersionedDBProvider.(interface{ StateCache() *statedb.Cache })
+	if !ok {
+		return nil
+	}
+	return cacheHolder.StateCache()
+}
+
 // RegisterHealthChecker implements function from interface DBProvider
 func (p *CommonStorageDBProvider) RegisterHealthChecker() error {
 	if healthChecker, ok := p.VersionedDBProvider.(healthz.HealthChecker); ok {
-		return p.HealthCheckRegistry.RegisterChecker("couchdb", healthChecker)
+		return p.HealthCheckRegistry.RegisterChecker(p.HealthCheckerName, healthChecker)
 	}
 	return nil
 }
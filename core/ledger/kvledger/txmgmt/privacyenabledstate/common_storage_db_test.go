@@ -9,7 +9,10 @@ package privacyenabledstate_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/stateleveldb"
 	"github.com/hyperledger/fabric/core/ledger/mock"
@@ -23,6 +26,7 @@ func TestHealthCheckRegister(t *testing.T) {
 	dbProvider := &privacyenabledstate.CommonStorageDBProvider{
 		VersionedDBProvider: &stateleveldb.VersionedDBProvider{},
 		HealthCheckRegistry: fakeHealthCheckRegistry,
+		HealthCheckerName:   "couchdb",
 	}
 
 	err := dbProvider.RegisterHealthChecker()
@@ -38,3 +42,29 @@ func TestHealthCheckRegister(t *testing.T) {
 	gt.Expect(arg1).To(Equal("couchdb"))
 	gt.Expect(arg2).NotTo(Equal(nil))
 }
+
+func TestStateDBProviderRegistry(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	_, err := privacyenabledstate.NewCommonStorageDBProvider(
+		nil, nil, nil,
+		&privacyenabledstate.StateDBConfig{StateDBConfig: &ledger.StateDBConfig{StateDatabase: "bogusdb"}},
+		nil,
+	)
+	gt.Expect(err).To(MatchError("unsupported state database: bogusdb"))
+
+	fakeProvider := &stateleveldb.VersionedDBProvider{}
+	privacyenabledstate.RegisterStateDBProvider(
+		"bogusdb",
+		func(_ *privacyenabledstate.StateDBConfig, _ metrics.Provider, _ []string) (statedb.VersionedDBProvider, error) {
+			return fakeProvider, nil
+		},
+	)
+	dbProvider, err := privacyenabledstate.NewCommonStorageDBProvider(
+		nil, nil, nil,
+		&privacyenabledstate.StateDBConfig{StateDBConfig: &ledger.StateDBConfig{StateDatabase: "bogusdb"}},
+		nil,
+	)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(dbProvider.(*privacyenabledstate.CommonStorageDBProvider).VersionedDBProvider).To(Equal(fakeProvider))
+}
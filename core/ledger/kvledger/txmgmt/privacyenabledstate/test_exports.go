@@ -61,6 +61,7 @@ func (env *LevelDBCommonStorageTestEnv) Init(t testing.TB) {
 		&StateDBConfig{
 			&ledger.StateDBConfig{},
 			dbPath,
+			nil,
 		},
 		[]string{"lscc", "_lifecycle"},
 	)
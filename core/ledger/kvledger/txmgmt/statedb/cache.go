@@ -7,39 +7,56 @@ SPDX-License-Identifier: Apache-2.0
 package statedb
 
 import (
+	"sync"
+
 	"github.com/VictoriaMetrics/fastcache"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
 )
 
 var (
 	keySep = []byte{0x00}
 )
 
-// Cache holds both the system and user cache
+// Cache holds both the system and user cache. The system cache is shared
+// across channels while the user cache is maintained per channel - every
+// channel gets its own bytes-bounded LRU so that a hot channel cannot evict
+// the entries of a cold one
 type Cache struct {
 	sysCache      *fastcache.Cache
-	usrCache      *fastcache.Cache
 	sysNamespaces []string
+	stats         *cacheStats
+
+	// usrCacheLock guards usrCaches and usrCacheLimits
+	usrCacheLock   sync.RWMutex
+	usrCaches      map[string]*lruCache
+	usrCacheLimits map[string]uint64
+	// defaultUsrCacheBytes is the limit applied to the user cache of a
+	// channel for which no runtime override has been set - zero denotes
+	// that the user cache is disabled
+	defaultUsrCacheBytes uint64
 }
 
 // NewCache creates a Cache. The cache consists of both system state cache (for lscc, _lifecycle)
 // and user state cache (for all user deployed chaincodes). The size of the
-// system state cache is 64 MB, by default. The size of the user state cache, in terms of MB, is
-// specified via usrCacheSize parameter. Note that the maximum memory consumption of fastcache
-// would be in the multiples of 32 MB (due to 512 buckets & an equal number of 64 KB chunks per bucket).
-// If the usrCacheSizeMBs is not a multiple of 32 MB, the fastcache would round the size
-// to the next multiple of 32 MB.
-func NewCache(usrCacheSizeMBs int, sysNamespaces []string) *Cache {
-	cache := &Cache{}
-	// By default, 64 MB is allocated for the system cache
-	cache.sysCache = fastcache.New(64 * 1024 * 1024)
-	cache.sysNamespaces = sysNamespaces
-
-	// User passed size is used to allocate memory for the user cache
-	if usrCacheSizeMBs <= 0 {
-		return cache
-	}
-	cache.usrCache = fastcache.New(usrCacheSizeMBs * 1024 * 1024)
+// system state cache is 64 MB, by default. The size of the user state cache of each
+// channel, in terms of MB, is specified via the usrCacheSizeMBs parameter - the user
+// cache of a channel is a bytes-bounded LRU and can be resized at runtime via
+// ResizeUserCache. Cache hits, misses, and evictions of the user caches are reported
+// via the supplied metrics provider.
+func NewCache(usrCacheSizeMBs int, sysNamespaces []string, metricsProvider metrics.Provider) *Cache {
+	cache := &Cache{
+		// By default, 64 MB is allocated for the system cache
+		sysCache:       fastcache.New(64 * 1024 * 1024),
+		sysNamespaces:  sysNamespaces,
+		stats:          newCacheStats(metricsProvider),
+		usrCaches:      map[string]*lruCache{},
+		usrCacheLimits: map[string]uint64{},
+	}
+	if usrCacheSizeMBs > 0 {
+		cache.defaultUsrCacheBytes = uint64(usrCacheSizeMBs) * 1024 * 1024
+	}
 	return cache
 }
 
@@ -47,24 +64,28 @@ func NewCache(usrCacheSizeMBs int, sysNamespaces []string) *Cache {
 // Namespace can be of two types: system namespace (such as lscc) and user
 // namespace (all user's chaincode states).
 func (c *Cache) Enabled(namespace string) bool {
-	for _, ns := range c.sysNamespaces {
-		if namespace == ns {
-			return true
-		}
+	if c.isSysNamespace(namespace) {
+		return true
 	}
-	return c.usrCache != nil
+	return c.defaultUsrCacheBytes > 0
 }
 
 // GetState returns the value for a given namespace and key from
 // a cache associated with the chainID.
 func (c *Cache) GetState(chainID, namespace, key string) (*CacheValue, error) {
-	cache := c.getCache(namespace)
-	if cache == nil {
-		return nil, nil
-	}
-
 	cacheKey := constructCacheKey(chainID, namespace, key)
-	valBytes := cache.Get(nil, cacheKey)
+	var valBytes []byte
+	if c.isSysNamespace(namespace) {
+		valBytes = c.sysCache.Get(nil, cacheKey)
+	} else {
+		usrCache := c.getOrCreateUsrCache(chainID)
+		if usrCache == nil {
+			return nil, nil
+		}
+		cachedBytes, hit := usrCache.get(string(cacheKey))
+		c.stats.observeLookup(chainID, hit)
+		valBytes = cachedBytes
+	}
 	if valBytes == nil {
 		return nil, nil
 	}
@@ -78,17 +99,20 @@ func (c *Cache) GetState(chainID, namespace, key string) (*CacheValue, error) {
 
 // PutState stores a given value in a cache associated with the chainID.
 func (c *Cache) PutState(chainID, namespace, key string, cacheValue *CacheValue) error {
-	cache := c.getCache(namespace)
-	if cache == nil {
-		return nil
-	}
-
-	cacheKey := constructCacheKey(chainID, namespace, key)
 	valBytes, err := proto.Marshal(cacheValue)
 	if err != nil {
 		return err
 	}
-	cache.Set(cacheKey, valBytes)
+	cacheKey := constructCacheKey(chainID, namespace, key)
+	if c.isSysNamespace(namespace) {
+		c.sysCache.Set(cacheKey, valBytes)
+		return nil
+	}
+	usrCache := c.getOrCreateUsrCache(chainID)
+	if usrCache == nil {
+		return nil
+	}
+	c.stats.observeEvictions(chainID, usrCache.put(string(cacheKey), valBytes))
 	return nil
 }
 
@@ -115,24 +139,45 @@ func (u CacheUpdates) Add(namespace string, ckvs CacheKVs) {
 // the chainID.
 func (c *Cache) UpdateStates(chainID string, updates CacheUpdates) error {
 	for ns, kvs := range updates {
-		cache := c.getCache(ns)
-		if cache == nil {
+		if c.isSysNamespace(ns) {
+			if err := c.updateSysStates(chainID, ns, kvs); err != nil {
+				return err
+			}
+			continue
+		}
+		usrCache := c.getOrCreateUsrCache(chainID)
+		if usrCache == nil {
 			continue
 		}
-
 		for key, newVal := range kvs {
-			cacheKey := constructCacheKey(chainID, ns, key)
+			cacheKey := string(constructCacheKey(chainID, ns, key))
 			if newVal == nil {
-				cache.Del(cacheKey)
+				usrCache.del(cacheKey)
 				continue
 			}
-			if oldVal := cache.Get(nil, cacheKey); oldVal != nil {
-				newValBytes, err := proto.Marshal(newVal)
-				if err != nil {
-					return err
-				}
-				cache.Set(cacheKey, newValBytes)
+			newValBytes, err := proto.Marshal(newVal)
+			if err != nil {
+				return err
+			}
+			c.stats.observeEvictions(chainID, usrCache.update(cacheKey, newValBytes))
+		}
+	}
+	return nil
+}
+
+func (c *Cache) updateSysStates(chainID, namespace string, kvs CacheKVs) error {
+	for key, newVal := range kvs {
+		cacheKey := constructCacheKey(chainID, namespace, key)
+		if newVal == nil {
+			c.sysCache.Del(cacheKey)
+			continue
+		}
+		if oldVal := c.sysCache.Get(nil, cacheKey); oldVal != nil {
+			newValBytes, err := proto.Marshal(newVal)
+			if err != nil {
+				return err
 			}
+			c.sysCache.Set(cacheKey, newValBytes)
 		}
 	}
 	return nil
@@ -141,18 +186,98 @@ func (c *Cache) UpdateStates(chainID string, updates CacheUpdates) error {
 // Reset removes all the items from the cache.
 func (c *Cache) Reset() {
 	c.sysCache.Reset()
-	if c.usrCache != nil {
-		c.usrCache.Reset()
+	c.usrCacheLock.Lock()
+	defer c.usrCacheLock.Unlock()
+	for chainID := range c.usrCaches {
+		limitBytes := c.defaultUsrCacheBytes
+		if override, ok := c.usrCacheLimits[chainID]; ok {
+			limitBytes = override
+		}
+		c.usrCaches[chainID] = newLRUCache(limitBytes)
+	}
+}
+
+// ResizeUserCache changes, at runtime, the size limit of the user state cache
+// of the given channel. Entries are evicted immediately if the cache of the
+// channel exceeds the new limit
+func (c *Cache) ResizeUserCache(chainID string, sizeMBs int) error {
+	if c.defaultUsrCacheBytes == 0 {
+		return errors.New("the user state cache is disabled on this peer")
+	}
+	if sizeMBs <= 0 {
+		return errors.New("the cache size must be greater than zero")
+	}
+	limitBytes := uint64(sizeMBs) * 1024 * 1024
+	c.usrCacheLock.Lock()
+	defer c.usrCacheLock.Unlock()
+	c.usrCacheLimits[chainID] = limitBytes
+	if usrCache, ok := c.usrCaches[chainID]; ok {
+		c.stats.observeEvictions(chainID, usrCache.resize(limitBytes))
+	}
+	return nil
+}
+
+// UserCacheStatus describes the user state cache of one channel
+type UserCacheStatus struct {
+	Channel    string `json:"channel"`
+	LimitBytes uint64 `json:"limitBytes"`
+	UsedBytes  uint64 `json:"usedBytes"`
+	Entries    int    `json:"entries"`
+}
+
+// UserCacheStatus returns the current limit and usage of the user state cache
+// of the given channel
+func (c *Cache) UserCacheStatus(chainID string) (*UserCacheStatus, error) {
+	if c.defaultUsrCacheBytes == 0 {
+		return nil, errors.New("the user state cache is disabled on this peer")
 	}
+	status := &UserCacheStatus{Channel: chainID}
+	c.usrCacheLock.RLock()
+	defer c.usrCacheLock.RUnlock()
+	if usrCache, ok := c.usrCaches[chainID]; ok {
+		status.LimitBytes, status.UsedBytes, status.Entries = usrCache.status()
+		return status, nil
+	}
+	status.LimitBytes = c.defaultUsrCacheBytes
+	if limitBytes, ok := c.usrCacheLimits[chainID]; ok {
+		status.LimitBytes = limitBytes
+	}
+	return status, nil
 }
 
-func (c *Cache) getCache(namespace string) *fastcache.Cache {
+func (c *Cache) isSysNamespace(namespace string) bool {
 	for _, ns := range c.sysNamespaces {
 		if namespace == ns {
-			return c.sysCache
+			return true
 		}
 	}
-	return c.usrCache
+	return false
+}
+
+// getOrCreateUsrCache returns the user cache of the given channel, creating it
+// on first use. A nil cache is returned if the user cache is disabled
+func (c *Cache) getOrCreateUsrCache(chainID string) *lruCache {
+	if c.defaultUsrCacheBytes == 0 {
+		return nil
+	}
+	c.usrCacheLock.RLock()
+	usrCache, ok := c.usrCaches[chainID]
+	c.usrCacheLock.RUnlock()
+	if ok {
+		return usrCache
+	}
+	c.usrCacheLock.Lock()
+	defer c.usrCacheLock.Unlock()
+	if usrCache, ok := c.usrCaches[chainID]; ok {
+		return usrCache
+	}
+	limitBytes := c.defaultUsrCacheBytes
+	if override, ok := c.usrCacheLimits[chainID]; ok {
+		limitBytes = override
+	}
+	usrCache = newLRUCache(limitBytes)
+	c.usrCaches[chainID] = usrCache
+	return usrCache
 }
 
 func constructCacheKey(chainID, namespace, key string) []byte {
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// CacheResizeRequest is the body of a POST request to the state cache
+// endpoint of the operations server
+type CacheResizeRequest struct {
+	Channel      string `json:"channel"`
+	CacheSizeMBs int    `json:"cacheSizeMBs"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewCacheHandler creates an http.Handler exposing the status of the user
+// state cache of a channel (GET with a "channel" query parameter) and a knob
+// to resize it at runtime (POST with a JSON body naming the channel and the
+// new size in MB). A nil cache denotes that the state database of the peer
+// maintains no cache, in which case all the requests are rejected
+func NewCacheHandler(cache *Cache) *CacheHandler {
+	return &CacheHandler{
+		cache:  cache,
+		logger: flogging.MustGetLogger("statedb.cache.handler"),
+	}
+}
+
+// CacheHandler serves the state cache status and resize API over HTTP
+type CacheHandler struct {
+	cache  *Cache
+	logger *flogging.FabricLogger
+}
+
+func (h *CacheHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if h.cache == nil {
+		h.sendResponse(resp, http.StatusNotFound, errors.New("the state database of this peer maintains no cache"))
+		return
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		channelID := req.URL.Query().Get("channel")
+		if channelID == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel query parameter is required"))
+			return
+		}
+		status, err := h.cache.UserCacheStatus(channelID)
+		if err != nil {
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		h.sendResponse(resp, http.StatusOK, status)
+
+	case http.MethodPost:
+		var request CacheResizeRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&request); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		if request.Channel == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel field is required"))
+			return
+		}
+		if err := h.cache.ResizeUserCache(request.Channel, request.CacheSizeMBs); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *CacheHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.WriteHeader(code)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
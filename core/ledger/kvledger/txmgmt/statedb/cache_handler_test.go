@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheHandlerStatus(t *testing.T) {
+	cache := NewCache(1, sysNamespaces, &disabled.Provider{})
+	require.NoError(t, cache.PutState("ch1", "ns1", "k1", &CacheValue{Value: []byte("value1")}))
+	handler := NewCacheHandler(cache)
+
+	req := httptest.NewRequest(http.MethodGet, "/state-cache?channel=ch1", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	status := &UserCacheStatus{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), status))
+	require.Equal(t, "ch1", status.Channel)
+	require.Equal(t, uint64(1024*1024), status.LimitBytes)
+	require.Equal(t, 1, status.Entries)
+	require.True(t, status.UsedBytes > 0)
+
+	// the channel query parameter is required
+	req = httptest.NewRequest(http.MethodGet, "/state-cache", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestCacheHandlerResize(t *testing.T) {
+	cache := NewCache(1, sysNamespaces, &disabled.Provider{})
+	handler := NewCacheHandler(cache)
+
+	req := httptest.NewRequest(http.MethodPost, "/state-cache", strings.NewReader(`{"channel":"ch1","cacheSizeMBs":4}`))
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNoContent, resp.Code)
+
+	status, err := cache.UserCacheStatus("ch1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(4*1024*1024), status.LimitBytes)
+
+	// an invalid size is rejected
+	req = httptest.NewRequest(http.MethodPost, "/state-cache", strings.NewReader(`{"channel":"ch1","cacheSizeMBs":0}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// the channel field is required
+	req = httptest.NewRequest(http.MethodPost, "/state-cache", strings.NewReader(`{"cacheSizeMBs":4}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// a malformed body is rejected
+	req = httptest.NewRequest(http.MethodPost, "/state-cache", strings.NewReader("garbage"))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestCacheHandlerNoCache(t *testing.T) {
+	handler := NewCacheHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/state-cache?channel=ch1", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestCacheHandlerInvalidMethod(t *testing.T) {
+	handler := NewCacheHandler(NewCache(1, sysNamespaces, &disabled.Provider{}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/state-cache", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var cacheHitsOpts = metrics.CounterOpts{
+	Namespace:    "statedb",
+	Subsystem:    "cache",
+	Name:         "hits",
+	Help:         "Number of user state cache lookups that were served from the cache.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
+var cacheMissesOpts = metrics.CounterOpts{
+	Namespace:    "statedb",
+	Subsystem:    "cache",
+	Name:         "misses",
+	Help:         "Number of user state cache lookups that were not served from the cache.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
+var cacheEvictionsOpts = metrics.CounterOpts{
+	Namespace:    "statedb",
+	Subsystem:    "cache",
+	Name:         "evictions",
+	Help:         "Number of entries evicted from the user state cache to keep the cache within its size limit.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+}
+
+type cacheStats struct {
+	hits      metrics.Counter
+	misses    metrics.Counter
+	evictions metrics.Counter
+}
+
+func newCacheStats(metricsProvider metrics.Provider) *cacheStats {
+	return &cacheStats{
+		hits:      metricsProvider.NewCounter(cacheHitsOpts),
+		misses:    metricsProvider.NewCounter(cacheMissesOpts),
+		evictions: metricsProvider.NewCounter(cacheEvictionsOpts),
+	}
+}
+
+func (s *cacheStats) observeLookup(channel string, hit bool) {
+	if hit {
+		s.hits.With("channel", channel).Add(1)
+		return
+	}
+	s.misses.With("channel", channel).Add(1)
+}
+
+func (s *cacheStats) observeEvictions(channel string, evicted int) {
+	if evicted > 0 {
+		s.evictions.With("channel", channel).Add(float64(evicted))
+	}
+}
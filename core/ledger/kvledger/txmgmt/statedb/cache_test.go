@@ -9,39 +9,32 @@ package statedb
 import (
 	"testing"
 
-	"github.com/VictoriaMetrics/fastcache"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/stretchr/testify/require"
 )
 
 var sysNamespaces = []string{"lscc", "_lifecycle"}
 
 func TestNewCache(t *testing.T) {
-	cache := NewCache(32, sysNamespaces)
-	expectedCache := &Cache{
-		sysCache:      fastcache.New(64 * 1024 * 1024),
-		usrCache:      fastcache.New(32 * 1024 * 1024),
-		sysNamespaces: sysNamespaces,
-	}
-	require.Equal(t, expectedCache, cache)
+	cache := NewCache(32, sysNamespaces, &disabled.Provider{})
+	require.Equal(t, uint64(32*1024*1024), cache.defaultUsrCacheBytes)
+	require.Equal(t, sysNamespaces, cache.sysNamespaces)
 	require.True(t, cache.Enabled("lscc"))
 	require.True(t, cache.Enabled("_lifecycle"))
 	require.True(t, cache.Enabled("xyz"))
 
-	cache = NewCache(0, sysNamespaces)
-	expectedCache = &Cache{
-		sysCache:      fastcache.New(64 * 1024 * 1024),
-		usrCache:      nil,
-		sysNamespaces: sysNamespaces,
-	}
-	require.Equal(t, expectedCache, cache)
+	cache = NewCache(0, sysNamespaces, &disabled.Provider{})
+	require.Equal(t, uint64(0), cache.defaultUsrCacheBytes)
 	require.True(t, cache.Enabled("lscc"))
 	require.True(t, cache.Enabled("_lifecycle"))
 	require.False(t, cache.Enabled("xyz"))
 }
 
 func TestGetPutState(t *testing.T) {
-	cache := NewCache(32, sysNamespaces)
+	cache := NewCache(32, sysNamespaces, &disabled.Provider{})
 
 	// test GetState
 	v, err := cache.GetState("ch1", "ns1", "k1")
@@ -58,7 +51,7 @@ func TestGetPutState(t *testing.T) {
 }
 
 func TestUpdateStates(t *testing.T) {
-	cache := NewCache(32, sysNamespaces)
+	cache := NewCache(32, sysNamespaces, &disabled.Provider{})
 
 	// create states for three namespaces (ns1, ns2, ns3)
 	// each with two keys (k1, k2)
@@ -147,7 +140,7 @@ func TestUpdateStates(t *testing.T) {
 }
 
 func TestCacheReset(t *testing.T) {
-	cache := NewCache(32, sysNamespaces)
+	cache := NewCache(32, sysNamespaces, &disabled.Provider{})
 
 	// create states for three namespaces (ns1, ns2, ns3)
 	// each with two keys (k1, k2)
@@ -220,3 +213,97 @@ func TestCacheUpdates(t *testing.T) {
 
 	require.Equal(t, expectedCacheUpdates, u)
 }
+
+func newTestCacheWithFakeMetrics(usrCacheSizeMBs int) (*Cache, map[string]*metricsfakes.Counter) {
+	counters := map[string]*metricsfakes.Counter{}
+	provider := &metricsfakes.Provider{}
+	provider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		fakeCounter := &metricsfakes.Counter{}
+		fakeCounter.WithReturns(fakeCounter)
+		counters[opts.Name] = fakeCounter
+		return fakeCounter
+	}
+	return NewCache(usrCacheSizeMBs, sysNamespaces, provider), counters
+}
+
+func TestCachePerChannelIsolation(t *testing.T) {
+	cache := NewCache(32, sysNamespaces, &disabled.Provider{})
+
+	value1 := &CacheValue{Value: []byte("value1")}
+	require.NoError(t, cache.PutState("ch1", "ns1", "k1", value1))
+	value2 := &CacheValue{Value: []byte("value2")}
+	require.NoError(t, cache.PutState("ch2", "ns1", "k1", value2))
+
+	v, err := cache.GetState("ch1", "ns1", "k1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(value1, v))
+	v, err = cache.GetState("ch2", "ns1", "k1")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(value2, v))
+}
+
+func TestCacheHitMissAndEvictionMetrics(t *testing.T) {
+	cache, counters := newTestCacheWithFakeMetrics(1)
+
+	_, err := cache.GetState("ch1", "ns1", "k1")
+	require.NoError(t, err)
+	require.Equal(t, 1, counters["misses"].AddCallCount())
+	require.Equal(t, 0, counters["hits"].AddCallCount())
+
+	require.NoError(t, cache.PutState("ch1", "ns1", "k1", &CacheValue{Value: []byte("value1")}))
+	_, err = cache.GetState("ch1", "ns1", "k1")
+	require.NoError(t, err)
+	require.Equal(t, 1, counters["hits"].AddCallCount())
+
+	// two values of 700 KB each exceed the 1 MB limit of the channel cache,
+	// evicting the first value
+	bigValue := &CacheValue{Value: make([]byte, 700*1024)}
+	require.NoError(t, cache.PutState("ch1", "ns1", "big1", bigValue))
+	require.NoError(t, cache.PutState("ch1", "ns1", "big2", bigValue))
+	require.Equal(t, 1, counters["evictions"].AddCallCount())
+
+	// lookups of system namespaces are not observed
+	require.NoError(t, cache.PutState("ch1", "lscc", "k1", &CacheValue{Value: []byte("value1")}))
+	_, err = cache.GetState("ch1", "lscc", "k1")
+	require.NoError(t, err)
+	require.Equal(t, 1, counters["hits"].AddCallCount())
+	require.Equal(t, 1, counters["misses"].AddCallCount())
+}
+
+func TestResizeUserCache(t *testing.T) {
+	cache := NewCache(1, sysNamespaces, &disabled.Provider{})
+
+	bigValue := &CacheValue{Value: make([]byte, 700*1024)}
+	require.NoError(t, cache.PutState("ch1", "ns1", "k1", bigValue))
+	require.NoError(t, cache.PutState("ch1", "ns1", "k2", bigValue))
+	v, err := cache.GetState("ch1", "ns1", "k1")
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	// after growing the cache of the channel, both values fit
+	require.NoError(t, cache.ResizeUserCache("ch1", 2))
+	require.NoError(t, cache.PutState("ch1", "ns1", "k1", bigValue))
+	v, err = cache.GetState("ch1", "ns1", "k2")
+	require.NoError(t, err)
+	require.NotNil(t, v)
+
+	// shrinking the cache of the channel evicts entries immediately
+	require.NoError(t, cache.ResizeUserCache("ch1", 1))
+	status, err := cache.UserCacheStatus("ch1")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1024*1024), status.LimitBytes)
+	require.Equal(t, 1, status.Entries)
+
+	// the size of the other channels is not affected
+	status, err = cache.UserCacheStatus("ch2")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1024*1024), status.LimitBytes)
+	require.Equal(t, 0, status.Entries)
+
+	require.EqualError(t, cache.ResizeUserCache("ch1", 0), "the cache size must be greater than zero")
+
+	disabledCache := NewCache(0, sysNamespaces, &disabled.Provider{})
+	require.EqualError(t, disabledCache.ResizeUserCache("ch1", 1), "the user state cache is disabled on this peer")
+	_, err = disabledCache.UserCacheStatus("ch1")
+	require.EqualError(t, err, "the user state cache is disabled on this peer")
+}
@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruCache is a bytes-bounded LRU cache that backs the user state cache of a
+// single channel. The size of an entry is accounted as the length of its key
+// plus the length of its value. When an insert takes the cache beyond its
+// limit, the least recently used entries are evicted until the cache fits
+// again. All the methods are safe for concurrent use
+type lruCache struct {
+	mutex      sync.Mutex
+	limitBytes uint64
+	usedBytes  uint64
+	elements   map[string]*list.Element
+	// evictionList keeps the entries ordered by recency of use - the entry at
+	// the front is the most recently used and the entry at the back is the
+	// next candidate for eviction
+	evictionList *list.List
+}
+
+type lruCacheEntry struct {
+	key   string
+	value []byte
+}
+
+func newLRUCache(limitBytes uint64) *lruCache {
+	return &lruCache{
+		limitBytes:   limitBytes,
+		elements:     map[string]*list.Element{},
+		evictionList: list.New(),
+	}
+}
+
+// get returns the value associated with the key and marks the entry as the
+// most recently used
+func (c *lruCache) get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.elements[key]
+	if !ok {
+		return nil, false
+	}
+	c.evictionList.MoveToFront(element)
+	return element.Value.(*lruCacheEntry).value, true
+}
+
+// put adds or replaces the value associated with the key and returns the
+// number of entries that were evicted to keep the cache within its limit
+func (c *lruCache) put(key string, value []byte) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.elements[key]; ok {
+		entry := element.Value.(*lruCacheEntry)
+		c.usedBytes += uint64(len(value)) - uint64(len(entry.value))
+		entry.value = value
+		c.evictionList.MoveToFront(element)
+		return c.evictToLimitLocked()
+	}
+	entry := &lruCacheEntry{key: key, value: value}
+	c.elements[key] = c.evictionList.PushFront(entry)
+	c.usedBytes += uint64(len(key)) + uint64(len(value))
+	return c.evictToLimitLocked()
+}
+
+// update replaces the value associated with the key only if the key is
+// already present and returns the number of entries that were evicted
+func (c *lruCache) update(key string, value []byte) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.elements[key]
+	if !ok {
+		return 0
+	}
+	entry := element.Value.(*lruCacheEntry)
+	c.usedBytes += uint64(len(value)) - uint64(len(entry.value))
+	entry.value = value
+	c.evictionList.MoveToFront(element)
+	return c.evictToLimitLocked()
+}
+
+// del removes the entry associated with the key, if present
+func (c *lruCache) del(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.elements[key]
+	if !ok {
+		return
+	}
+	c.removeElementLocked(element)
+}
+
+// resize changes the limit of the cache and returns the number of entries
+// that were evicted to fit the new limit
+func (c *lruCache) resize(limitBytes uint64) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.limitBytes = limitBytes
+	return c.evictToLimitLocked()
+}
+
+// status returns the limit, the accounted size, and the number of entries
+func (c *lruCache) status() (limitBytes, usedBytes uint64, entries int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.limitBytes, c.usedBytes, len(c.elements)
+}
+
+func (c *lruCache) evictToLimitLocked() int {
+	evicted := 0
+	for c.usedBytes > c.limitBytes {
+		element := c.evictionList.Back()
+		if element == nil {
+			break
+		}
+		c.removeElementLocked(element)
+		evicted++
+	}
+	return evicted
+}
+
+func (c *lruCache) removeElementLocked(element *list.Element) {
+	entry := element.Value.(*lruCacheEntry)
+	c.evictionList.Remove(element)
+	delete(c.elements, entry.key)
+	c.usedBytes -= uint64(len(entry.key)) + uint64(len(entry.value))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCachePutGetEvict(t *testing.T) {
+	// each entry occupies 8 bytes (2 bytes key + 6 bytes value) so that
+	// the cache holds at most three entries
+	c := newLRUCache(24)
+
+	require.Equal(t, 0, c.put("k1", []byte("value1")))
+	require.Equal(t, 0, c.put("k2", []byte("value2")))
+	require.Equal(t, 0, c.put("k3", []byte("value3")))
+	limit, used, entries := c.status()
+	require.Equal(t, uint64(24), limit)
+	require.Equal(t, uint64(24), used)
+	require.Equal(t, 3, entries)
+
+	// k1 becomes the most recently used entry so that k2 is evicted next
+	v, ok := c.get("k1")
+	require.True(t, ok)
+	require.Equal(t, []byte("value1"), v)
+
+	require.Equal(t, 1, c.put("k4", []byte("value4")))
+	_, ok = c.get("k2")
+	require.False(t, ok)
+	_, ok = c.get("k1")
+	require.True(t, ok)
+	_, ok = c.get("k3")
+	require.True(t, ok)
+	_, ok = c.get("k4")
+	require.True(t, ok)
+}
+
+func TestLRUCacheUpdateAndDelete(t *testing.T) {
+	c := newLRUCache(100)
+
+	// an update of a non existing key is a noop
+	require.Equal(t, 0, c.update("k1", []byte("value1")))
+	_, ok := c.get("k1")
+	require.False(t, ok)
+
+	require.Equal(t, 0, c.put("k1", []byte("value1")))
+	require.Equal(t, 0, c.update("k1", []byte("another-value")))
+	v, ok := c.get("k1")
+	require.True(t, ok)
+	require.Equal(t, []byte("another-value"), v)
+	_, used, _ := c.status()
+	require.Equal(t, uint64(2+13), used)
+
+	c.del("k1")
+	_, ok = c.get("k1")
+	require.False(t, ok)
+	_, used, entries := c.status()
+	require.Equal(t, uint64(0), used)
+	require.Equal(t, 0, entries)
+
+	// deleting a non existing key is a noop
+	c.del("k1")
+}
+
+func TestLRUCacheResize(t *testing.T) {
+	c := newLRUCache(24)
+	require.Equal(t, 0, c.put("k1", []byte("value1")))
+	require.Equal(t, 0, c.put("k2", []byte("value2")))
+	require.Equal(t, 0, c.put("k3", []byte("value3")))
+
+	// shrinking the cache evicts the least recently used entries
+	require.Equal(t, 2, c.resize(8))
+	_, ok := c.get("k1")
+	require.False(t, ok)
+	_, ok = c.get("k2")
+	require.False(t, ok)
+	_, ok = c.get("k3")
+	require.True(t, ok)
+
+	// growing the cache makes room for more entries
+	require.Equal(t, 0, c.resize(16))
+	require.Equal(t, 0, c.put("k4", []byte("value4")))
+	limit, used, entries := c.status()
+	require.Equal(t, uint64(16), limit)
+	require.Equal(t, uint64(16), used)
+	require.Equal(t, 2, entries)
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
@@ -148,6 +149,9 @@ func (vdb *VersionedDB) buildCommittersForNs(ns string, nsUpdates map[string]*st
 }
 
 func (vdb *VersionedDB) executeCommitter(committers []*committer) error {
+	// each committer pushes its batch with a single bulk update round trip
+	// (barring the per-document retries on partial failures)
+	atomic.AddUint64(&vdb.commitRoundTrips, uint64(len(committers)))
 	errsChan := make(chan error, len(committers))
 	defer close(errsChan)
 	var wg sync.WaitGroup
@@ -294,6 +298,13 @@ func (vdb *VersionedDB) addMissingRevisionsFromDB(ns string, missingKeys []strin
 	}
 
 	logger.Debugf("Pulling revisions for the [%d] keys for namsespace [%s] that were not part of the readset", len(missingKeys), db.DBName)
+	// the keys are fetched in batches of maxBatchSize - account for the round trips
+	// that retrieveNsMetadata is about to make
+	numBatches := 1
+	if maxBatchSize := db.CouchInstance.MaxBatchUpdateSize(); maxBatchSize > 0 {
+		numBatches = int(math.Ceil(float64(len(missingKeys)) / float64(maxBatchSize)))
+	}
+	atomic.AddUint64(&vdb.commitRoundTrips, uint64(numBatches))
 	retrievedMetadata, err := retrieveNsMetadata(db, missingKeys)
 	if err != nil {
 		return err
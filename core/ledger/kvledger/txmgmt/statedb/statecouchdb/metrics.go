@@ -0,0 +1,35 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var commitRoundTripsOpts = metrics.HistogramOpts{
+	Namespace:    "couchdb",
+	Subsystem:    "",
+	Name:         "commit_roundtrips",
+	Help:         "Number of CouchDB round trips (revision lookups and bulk updates) performed per block commit.",
+	LabelNames:   []string{"channel"},
+	StatsdFormat: "%{#fqname}.%{channel}",
+	Buckets:      []float64{1, 2, 5, 10, 20, 50, 100, 500},
+}
+
+type stats struct {
+	commitRoundTrips metrics.Histogram
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		commitRoundTrips: metricsProvider.NewHistogram(commitRoundTripsOpts),
+	}
+}
+
+func (s *stats) observeCommitRoundTrips(channel string, roundTrips uint64) {
+	s.commitRoundTrips.With("channel", channel).Observe(float64(roundTrips))
+}
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	. "github.com/onsi/gomega"
+)
+
+func TestCommitRoundTripsMetric(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+
+	stats := &stats{commitRoundTrips: fakeHistogram}
+	stats.observeCommitRoundTrips("testchannel", 7)
+
+	gt.Expect(fakeHistogram.WithArgsForCall(0)).To(Equal([]string{
+		"channel", "testchannel",
+	}))
+	gt.Expect(fakeHistogram.ObserveCallCount()).To(Equal(1))
+	gt.Expect(fakeHistogram.ObserveArgsForCall(0)).To(Equal(float64(7)))
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
@@ -43,6 +44,7 @@ type VersionedDBProvider struct {
 	openCounts         uint64
 	redoLoggerProvider *redoLoggerProvider
 	cache              *statedb.Cache
+	stats              *stats
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -66,10 +68,17 @@ func NewVersionedDBProvider(config *couchdb.Config, metricsProvider metrics.Prov
 			openCounts:         0,
 			redoLoggerProvider: p,
 			cache:              cache,
+			stats:              newStats(metricsProvider),
 		},
 		nil
 }
 
+// StateCache returns the state cache maintained by this provider so that its
+// status and size can be exposed over the operations API
+func (provider *VersionedDBProvider) StateCache() *statedb.Cache {
+	return provider.cache
+}
+
 func checkExpectedDataformatVersion(couchInstance *couchdb.CouchInstance) error {
 	databasesToIgnore := []string{fabricInternalDBName}
 	isEmpty, err := couchInstance.IsEmpty(databasesToIgnore)
@@ -143,6 +152,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 			provider.redoLoggerProvider.newRedoLogger(dbName),
 			dbName,
 			provider.cache,
+			provider.stats,
 		)
 		if err != nil {
 			return nil, err
@@ -174,10 +184,12 @@ type VersionedDB struct {
 	mux                sync.RWMutex
 	redoLogger         *redoLogger
 	cache              *statedb.Cache
+	stats              *stats
+	commitRoundTrips   uint64 // number of CouchDB round trips made by the commit in progress
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, redoLogger *redoLogger, dbName string, cache *statedb.Cache) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, redoLogger *redoLogger, dbName string, cache *statedb.Cache, stats *stats) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -195,6 +207,7 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, redoLogger *redoLogger
 		committedDataCache: newVersionCache(),
 		redoLogger:         redoLogger,
 		cache:              cache,
+		stats:              stats,
 	}
 	logger.Debugf("chain [%s]: checking for redolog record", chainName)
 	redologRecord, err := redoLogger.load()
@@ -642,6 +655,7 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 func (vdb *VersionedDB) applyUpdates(updates *statedb.UpdateBatch, height *version.Height) error {
 	// TODO a note about https://jira.hyperledger.org/browse/FAB-8622
 	// The write lock is needed only for the stage 2.
+	atomic.StoreUint64(&vdb.commitRoundTrips, 0)
 
 	// stage 1 - buildCommitters builds committers per namespace (per DB). Each committer transforms the
 	// given batch in the form of underlying db and keep it in memory.
@@ -654,6 +668,7 @@ func (vdb *VersionedDB) applyUpdates(updates *statedb.UpdateBatch, height *versi
 	if err = vdb.executeCommitter(committers); err != nil {
 		return err
 	}
+	vdb.stats.observeCommitRoundTrips(vdb.chainName, atomic.LoadUint64(&vdb.commitRoundTrips))
 
 	// Stgae 3 - postCommitProcessing - flush and record savepoint.
 	namespaces := updates.GetUpdatedNamespaces()
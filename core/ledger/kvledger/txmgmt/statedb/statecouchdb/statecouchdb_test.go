@@ -50,7 +50,7 @@ func TestBasicRW(t *testing.T) {
 // TestGetStateFromCache checks cache hits, cache misses, and cache
 // updates during GetState call.
 func TestGetStateFromCache(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
@@ -114,7 +114,7 @@ func TestGetStateFromCache(t *testing.T) {
 // TestGetVersionFromCache checks cache hits, cache misses, and
 // updates during GetVersion call.
 func TestGetVersionFromCache(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
@@ -178,7 +178,7 @@ func TestGetVersionFromCache(t *testing.T) {
 // TestGetMultipleStatesFromCache checks cache hits, cache misses,
 // and updates during GetStateMultipleKeys call.
 func TestGetMultipleStatesFromCache(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
@@ -240,7 +240,7 @@ func TestGetMultipleStatesFromCache(t *testing.T) {
 // TestCacheUpdatesAfterCommit checks whether the cache is updated
 // after a commit of a update batch.
 func TestCacheUpdatesAfterCommit(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
@@ -1162,7 +1162,7 @@ func testExistInCache(t *testing.T, db *couchdb.CouchDatabase, cache *statedb.Ca
 }
 
 func TestLoadCommittedVersion(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
@@ -1302,7 +1302,7 @@ func TestMissingRevisionRetrievalFromDB(t *testing.T) {
 }
 
 func TestMissingRevisionRetrievalFromCache(t *testing.T) {
-	cache := statedb.NewCache(32, []string{"lscc"})
+	cache := statedb.NewCache(32, []string{"lscc"}, &disabled.Provider{})
 	env := testEnv
 	env.init(t, cache)
 	defer env.cleanup()
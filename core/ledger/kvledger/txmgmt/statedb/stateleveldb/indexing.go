@@ -0,0 +1,403 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+var (
+	indexDefKeyPrefix   = []byte{'i'}
+	indexEntryKeyPrefix = []byte{'x'}
+)
+
+// indexDefinition describes an index over the JSON values of a namespace. An
+// index entry is maintained for every key whose value is a JSON object
+// containing all of the indexed fields.
+type indexDefinition struct {
+	name   string
+	fields []string
+}
+
+// parseIndexDefinition parses a CouchDB-style index definition file. Only the
+// field names are retained; sort directions have no meaning for the embedded
+// index and are ignored.
+func parseIndexDefinition(fileName string, content []byte) (*indexDefinition, error) {
+	definition := &struct {
+		Index struct {
+			Fields []interface{} `json:"fields"`
+		} `json:"index"`
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal(content, definition); err != nil {
+		return nil, errors.Wrap(err, "error parsing index definition")
+	}
+	def := &indexDefinition{name: definition.Name}
+	if def.name == "" {
+		def.name = strings.TrimSuffix(filepath.Base(fileName), ".json")
+	}
+	for _, field := range definition.Index.Fields {
+		switch fieldVal := field.(type) {
+		case string:
+			def.fields = append(def.fields, fieldVal)
+		case map[string]interface{}:
+			// a field with a sort direction, e.g. {"size": "asc"}
+			for fieldName := range fieldVal {
+				def.fields = append(def.fields, fieldName)
+			}
+		default:
+			return nil, errors.New("index fields must be field names or {\"field\": \"sort\"} objects")
+		}
+	}
+	if len(def.fields) == 0 {
+		return nil, errors.New("index definition must include at least one field")
+	}
+	return def, nil
+}
+
+// GetDBType returns the hosted stateDB
+func (vdb *versionedDB) GetDBType() string {
+	return "leveldb"
+}
+
+// ProcessIndexesForChaincodeDeploy stores the index definitions packaged in
+// the META-INF of a chaincode and builds the index entries for the existing
+// state of the specified namespace.
+func (vdb *versionedDB) ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error {
+	for _, fileEntry := range fileEntries {
+		fileName := fileEntry.FileHeader.Name
+		def, err := parseIndexDefinition(fileName, fileEntry.FileContent)
+		if err != nil {
+			return errors.WithMessagef(err, "error creating index from file [%s] for namespace [%s]", fileName, namespace)
+		}
+		if err := vdb.deployIndex(namespace, def); err != nil {
+			return errors.WithMessagef(err, "error creating index from file [%s] for namespace [%s]", fileName, namespace)
+		}
+	}
+	return nil
+}
+
+// deployIndex stores the index definition and (re)builds its entries from the
+// current state of the namespace.
+func (vdb *versionedDB) deployIndex(namespace string, def *indexDefinition) error {
+	logger.Infof("Channel [%s]: Building index [%s] on fields %v for namespace [%s]",
+		vdb.dbName, def.name, def.fields, namespace)
+	fieldsBytes, err := json.Marshal(def.fields)
+	if err != nil {
+		return err
+	}
+	dbBatch := leveldbhelper.NewUpdateBatch()
+	dbBatch.Put(encodeIndexDefKey(namespace, def.name), fieldsBytes)
+
+	// drop the entries of a previous incarnation of the index
+	entryPrefix := encodeIndexEntryPrefix(namespace, def.name)
+	itr := vdb.db.GetIterator(entryPrefix, prefixScanEnd(entryPrefix))
+	for itr.Next() {
+		dbBatch.Delete(append([]byte{}, itr.Key()...))
+	}
+	itr.Release()
+	if err := itr.Error(); err != nil {
+		return err
+	}
+
+	resultsItr, err := vdb.GetStateRangeScanIterator(namespace, "", "")
+	if err != nil {
+		return err
+	}
+	defer resultsItr.Close()
+	for {
+		result, err := resultsItr.Next()
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			break
+		}
+		kv := result.(*statedb.VersionedKV)
+		entryKey, ok := encodeIndexEntryKey(namespace, def, kv.Key, kv.Value)
+		if ok {
+			dbBatch.Put(entryKey, []byte{})
+		}
+	}
+	return vdb.db.WriteBatch(dbBatch, true)
+}
+
+// indexDefinitions returns the indexes defined for a namespace.
+func (vdb *versionedDB) indexDefinitions(namespace string) ([]*indexDefinition, error) {
+	defPrefix := encodeIndexDefKey(namespace, "")
+	itr := vdb.db.GetIterator(defPrefix, prefixScanEnd(defPrefix))
+	defer itr.Release()
+	var defs []*indexDefinition
+	for itr.Next() {
+		def := &indexDefinition{name: string(itr.Key()[len(defPrefix):])}
+		if err := json.Unmarshal(itr.Value(), &def.fields); err != nil {
+			return nil, errors.Wrapf(err, "error parsing stored index definition [%s]", def.name)
+		}
+		defs = append(defs, def)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+	return defs, nil
+}
+
+// updateIndexEntries adds the index entry updates implied by writing the given
+// value to the given key to the commit batch.
+func (vdb *versionedDB) updateIndexEntries(dbBatch *leveldbhelper.UpdateBatch, indexes []*indexDefinition, namespace, key string, value []byte) error {
+	existing, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return err
+	}
+	for _, def := range indexes {
+		if existing != nil {
+			if oldEntryKey, ok := encodeIndexEntryKey(namespace, def, key, existing.Value); ok {
+				dbBatch.Delete(oldEntryKey)
+			}
+		}
+		if value != nil {
+			if newEntryKey, ok := encodeIndexEntryKey(namespace, def, key, value); ok {
+				dbBatch.Put(newEntryKey, []byte{})
+			}
+		}
+	}
+	return nil
+}
+
+func encodeIndexDefKey(namespace, indexName string) []byte {
+	k := append(indexDefKeyPrefix, []byte(namespace)...)
+	k = append(k, nsKeySep...)
+	return append(k, []byte(indexName)...)
+}
+
+func encodeIndexEntryPrefix(namespace, indexName string) []byte {
+	k := append(indexEntryKeyPrefix, []byte(namespace)...)
+	k = append(k, nsKeySep...)
+	k = append(k, []byte(indexName)...)
+	return append(k, nsKeySep...)
+}
+
+// encodeIndexEntryKey derives the index entry key for a state key and value.
+// The indexed field values are kept in their JSON encoding, which contains no
+// raw 0x00 bytes, so the separator remains unambiguous and the state key can
+// be recovered from the entry. The second return value is false if the value
+// is not a JSON object containing all of the indexed fields, in which case no
+// entry is maintained - mirroring the CouchDB behavior of indexing only the
+// documents that contain all indexed fields.
+func encodeIndexEntryKey(namespace string, def *indexDefinition, key string, value []byte) ([]byte, bool) {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(value, &doc); err != nil {
+		return nil, false
+	}
+	k := encodeIndexEntryPrefix(namespace, def.name)
+	for _, field := range def.fields {
+		fieldValue, exists := lookupField(doc, field)
+		if !exists {
+			return nil, false
+		}
+		encodedValue, err := json.Marshal(fieldValue)
+		if err != nil {
+			return nil, false
+		}
+		k = append(k, encodedValue...)
+		k = append(k, nsKeySep...)
+	}
+	return append(k, []byte(key)...), true
+}
+
+// prefixScanEnd returns the exclusive end key that covers all keys beginning
+// with the given prefix. All prefixes scanned in this package end with the
+// 0x00 namespace separator, so incrementing the final byte never overflows.
+func prefixScanEnd(prefix []byte) []byte {
+	end := append([]byte{}, prefix...)
+	end[len(end)-1] = end[len(end)-1] + 1
+	return end
+}
+
+// executeQueryExpression runs a parsed query against a namespace, using an
+// index when one pins all of its fields with equality conditions, and a full
+// scan of the namespace otherwise.
+func (vdb *versionedDB) executeQueryExpression(namespace string, expr *queryExpression, limit int32, bookmark string) (statedb.QueryResultsIterator, error) {
+	indexes, err := vdb.indexDefinitions(namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range indexes {
+		source, usable, err := vdb.indexedDocSource(namespace, def, expr, bookmark)
+		if err != nil {
+			return nil, err
+		}
+		if usable {
+			logger.Debugf("Channel [%s]: Using index [%s] for query on namespace [%s]", vdb.dbName, def.name, namespace)
+			return newQueryScanner(namespace, source, expr, limit), nil
+		}
+	}
+	logger.Debugf("Channel [%s]: No usable index for query on namespace [%s], executing full scan", vdb.dbName, namespace)
+	startKey := ""
+	if bookmark != "" {
+		// the smallest key strictly greater than the bookmark
+		startKey = bookmark + string([]byte{0x00})
+	}
+	dbItr := vdb.db.GetIterator(encodeDataKey(namespace, startKey), prefixScanEnd(encodeDataKey(namespace, "")))
+	return newQueryScanner(namespace, &scanDocSource{dbItr: dbItr}, expr, limit), nil
+}
+
+// indexedDocSource builds a document source backed by the given index, if the
+// query pins every indexed field with an equality condition.
+func (vdb *versionedDB) indexedDocSource(namespace string, def *indexDefinition, expr *queryExpression, bookmark string) (*keyListDocSource, bool, error) {
+	prefix := encodeIndexEntryPrefix(namespace, def.name)
+	for _, field := range def.fields {
+		value, ok := equalityValue(expr.selector, field)
+		if !ok {
+			return nil, false, nil
+		}
+		encodedValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, false, err
+		}
+		prefix = append(prefix, encodedValue...)
+		prefix = append(prefix, nsKeySep...)
+	}
+
+	itr := vdb.db.GetIterator(prefix, prefixScanEnd(prefix))
+	defer itr.Release()
+	var keys []string
+	for itr.Next() {
+		key := string(itr.Key()[len(prefix):])
+		if key > bookmark {
+			keys = append(keys, key)
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return nil, false, err
+	}
+	// results are returned in key order, like a full scan, so that the
+	// bookmark semantics do not depend on the chosen execution plan
+	sort.Strings(keys)
+	return &keyListDocSource{vdb: vdb, namespace: namespace, keys: keys}, true, nil
+}
+
+// docSource supplies the candidate documents of a query in key order.
+type docSource interface {
+	next() (string, *statedb.VersionedValue, error)
+	close()
+}
+
+// keyListDocSource fetches the documents of a pre-computed list of keys.
+type keyListDocSource struct {
+	vdb       *versionedDB
+	namespace string
+	keys      []string
+}
+
+func (source *keyListDocSource) next() (string, *statedb.VersionedValue, error) {
+	for len(source.keys) > 0 {
+		key := source.keys[0]
+		source.keys = source.keys[1:]
+		vv, err := source.vdb.GetState(source.namespace, key)
+		if err != nil {
+			return "", nil, err
+		}
+		if vv != nil {
+			return key, vv, nil
+		}
+	}
+	return "", nil, nil
+}
+
+func (source *keyListDocSource) close() {}
+
+// scanDocSource supplies all documents of a namespace from a db iterator.
+type scanDocSource struct {
+	dbItr *leveldbhelper.Iterator
+}
+
+func (source *scanDocSource) next() (string, *statedb.VersionedValue, error) {
+	if !source.dbItr.Next() {
+		return "", nil, source.dbItr.Error()
+	}
+	dbValCopy := append([]byte{}, source.dbItr.Value()...)
+	_, key := decodeDataKey(source.dbItr.Key())
+	vv, err := decodeValue(dbValCopy)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, vv, nil
+}
+
+func (source *scanDocSource) close() {
+	source.dbItr.Release()
+}
+
+// queryScanner filters the documents of a source against the query selector
+// and applies the projection and result limit.
+type queryScanner struct {
+	namespace            string
+	source               docSource
+	expr                 *queryExpression
+	limit                int32
+	totalRecordsReturned int32
+	lastKey              string
+}
+
+func newQueryScanner(namespace string, source docSource, expr *queryExpression, limit int32) *queryScanner {
+	return &queryScanner{namespace: namespace, source: source, expr: expr, limit: limit}
+}
+
+func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
+	if scanner.limit > 0 && scanner.totalRecordsReturned >= scanner.limit {
+		return nil, nil
+	}
+	for {
+		key, vv, err := scanner.source.next()
+		if err != nil {
+			return nil, err
+		}
+		if vv == nil {
+			return nil, nil
+		}
+		doc := map[string]interface{}{}
+		if err := json.Unmarshal(vv.Value, &doc); err != nil {
+			// non-JSON values can never match a selector
+			continue
+		}
+		if !matchSelector(doc, scanner.expr.selector) {
+			continue
+		}
+		if scanner.expr.fields != nil {
+			projectedValue, err := json.Marshal(applyProjection(doc, scanner.expr.fields))
+			if err != nil {
+				return nil, err
+			}
+			vv = &statedb.VersionedValue{Value: projectedValue, Metadata: vv.Metadata, Version: vv.Version}
+		}
+		scanner.lastKey = key
+		scanner.totalRecordsReturned++
+		return &statedb.VersionedKV{
+			CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+			VersionedValue: *vv,
+		}, nil
+	}
+}
+
+func (scanner *queryScanner) Close() {
+	scanner.source.close()
+}
+
+// GetBookmarkAndClose returns the key of the last record returned, which may
+// be passed back as the "bookmark" query option to resume the query after it.
+func (scanner *queryScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	return scanner.lastKey
+}
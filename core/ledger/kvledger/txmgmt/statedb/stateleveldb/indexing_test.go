@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"archive/tar"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIndexDefinition(t *testing.T) {
+	def, err := parseIndexDefinition(
+		"META-INF/statedb/leveldb/indexes/indexOwner.json",
+		[]byte(`{"index":{"fields":["owner",{"size":"asc"}]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "indexOwner", def.name)
+	assert.Equal(t, []string{"owner", "size"}, def.fields)
+
+	// the name defaults to the file name
+	def, err = parseIndexDefinition(
+		"META-INF/statedb/leveldb/indexes/indexColor.json",
+		[]byte(`{"index":{"fields":["color"]}}`),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "indexColor", def.name)
+
+	_, err = parseIndexDefinition("index.json", []byte(`{"index":{"fields":[]}}`))
+	assert.EqualError(t, err, "index definition must include at least one field")
+
+	_, err = parseIndexDefinition("index.json", []byte(`{"index":{"fields":[1]}}`))
+	assert.EqualError(t, err, "index fields must be field names or {\"field\": \"sort\"} objects")
+
+	_, err = parseIndexDefinition("index.json", []byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestIndexedQuery(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testindexedquery")
+	assert.NoError(t, err)
+	vdb := db.(*versionedDB)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"blue","size":1,"owner":"tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"color":"red","size":2,"owner":"jerry"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"red","size":3,"owner":"jerry"}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte("not json"), version.NewHeight(1, 4))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 4)))
+
+	// deploying an index over existing state builds its entries
+	err = vdb.ProcessIndexesForChaincodeDeploy("ns1", []*ccprovider.TarFileEntry{{
+		FileHeader:  &tar.Header{Name: "META-INF/statedb/leveldb/indexes/indexColor.json"},
+		FileContent: []byte(`{"index":{"fields":["color"]},"name":"indexColor","type":"json"}`),
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2", "key3"}, indexedKeys(t, vdb, "ns1", "indexColor", `"red"`))
+
+	// the query planner uses the index and returns the same results as a scan
+	itr, err := db.ExecuteQuery("ns1", `{"selector":{"color":"red","owner":"jerry"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2", "key3"}, retrieveKeys(t, itr))
+
+	// index entries follow state updates and deletes
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte(`{"color":"red","size":1,"owner":"tom"}`), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte(`{"color":"green","size":3,"owner":"jerry"}`), version.NewHeight(2, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+	assert.Equal(t, []string{"key1"}, indexedKeys(t, vdb, "ns1", "indexColor", `"red"`))
+	assert.Equal(t, []string{"key3"}, indexedKeys(t, vdb, "ns1", "indexColor", `"green"`))
+
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"color":"red"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key1"}, retrieveKeys(t, itr))
+
+	// a query that does not pin all indexed fields falls back to a full scan
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"size":{"$gte":3}}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key3"}, retrieveKeys(t, itr))
+}
+
+// indexedKeys returns the state keys recorded in the index for the given
+// encoded field value.
+func indexedKeys(t *testing.T, vdb *versionedDB, namespace, indexName, encodedValue string) []string {
+	prefix := append(encodeIndexEntryPrefix(namespace, indexName), []byte(encodedValue)...)
+	prefix = append(prefix, nsKeySep...)
+	itr := vdb.db.GetIterator(prefix, prefixScanEnd(prefix))
+	defer itr.Release()
+	keys := []string{}
+	for itr.Next() {
+		keys = append(keys, string(itr.Key()[len(prefix):]))
+	}
+	assert.NoError(t, itr.Error())
+	return keys
+}
@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// queryExpression is the parsed form of a CouchDB-style selector query. The
+// embedded query engine supports the selector syntax for equality, comparison
+// and combination operators, an optional limit, and an optional projection of
+// the fields to return. Sorting is not supported.
+type queryExpression struct {
+	selector map[string]interface{}
+	fields   []string
+	limit    int32
+}
+
+// parseQuery parses and validates a selector query, so that malformed queries
+// are rejected before any data is scanned.
+func parseQuery(query string) (*queryExpression, error) {
+	rawQuery := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(query), &rawQuery); err != nil {
+		return nil, errors.Wrap(err, "error parsing query")
+	}
+	expr := &queryExpression{}
+	for key, value := range rawQuery {
+		switch key {
+		case "selector":
+			selector, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("\"selector\" must be a JSON object")
+			}
+			expr.selector = selector
+		case "limit":
+			num, ok := value.(float64)
+			if !ok || num != math.Trunc(num) || num < 0 {
+				return nil, errors.New("\"limit\" must be a non-negative integer")
+			}
+			expr.limit = int32(num)
+		case "fields":
+			fields, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.New("\"fields\" must be a JSON array")
+			}
+			for _, field := range fields {
+				fieldName, ok := field.(string)
+				if !ok {
+					return nil, errors.New("\"fields\" entries must be strings")
+				}
+				expr.fields = append(expr.fields, fieldName)
+			}
+		case "use_index":
+			// accepted for compatibility with CouchDB queries; the embedded
+			// query engine selects a usable index automatically
+		default:
+			return nil, errors.Errorf("query keyword %q is not supported by the leveldb query engine", key)
+		}
+	}
+	if len(expr.selector) == 0 {
+		return nil, errors.New("query must contain a non-empty selector")
+	}
+	if err := validateSelector(expr.selector); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+var supportedOperators = map[string]bool{
+	"$eq": true, "$ne": true,
+	"$gt": true, "$gte": true, "$lt": true, "$lte": true,
+	"$in": true, "$nin": true,
+	"$exists": true,
+}
+
+// validateSelector rejects selectors that use operators the embedded query
+// engine does not implement.
+func validateSelector(selector map[string]interface{}) error {
+	for key, condition := range selector {
+		switch key {
+		case "$and", "$or", "$nor":
+			subSelectors, ok := condition.([]interface{})
+			if !ok {
+				return errors.Errorf("operator %s requires a JSON array of selectors", key)
+			}
+			for _, sub := range subSelectors {
+				subSelector, ok := sub.(map[string]interface{})
+				if !ok {
+					return errors.Errorf("operator %s requires a JSON array of selectors", key)
+				}
+				if err := validateSelector(subSelector); err != nil {
+					return err
+				}
+			}
+		case "$not":
+			subSelector, ok := condition.(map[string]interface{})
+			if !ok {
+				return errors.New("operator $not requires a selector")
+			}
+			if err := validateSelector(subSelector); err != nil {
+				return err
+			}
+		default:
+			if strings.HasPrefix(key, "$") {
+				return errors.Errorf("query operator %s is not supported by the leveldb query engine", key)
+			}
+			if err := validateCondition(condition); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateCondition(condition interface{}) error {
+	conditionMap, ok := condition.(map[string]interface{})
+	if !ok || !isOperatorMap(conditionMap) {
+		return nil
+	}
+	for operator, operand := range conditionMap {
+		if !supportedOperators[operator] {
+			return errors.Errorf("query operator %s is not supported by the leveldb query engine", operator)
+		}
+		switch operator {
+		case "$in", "$nin":
+			if _, ok := operand.([]interface{}); !ok {
+				return errors.Errorf("operator %s requires a JSON array", operator)
+			}
+		case "$exists":
+			if _, ok := operand.(bool); !ok {
+				return errors.New("operator $exists requires a boolean")
+			}
+		}
+	}
+	return nil
+}
+
+// isOperatorMap returns true if the map expresses operator conditions rather
+// than an object literal to compare against for equality. Mixing operators
+// and literal fields in one map is not meaningful and is treated as a literal.
+func isOperatorMap(conditionMap map[string]interface{}) bool {
+	if len(conditionMap) == 0 {
+		return false
+	}
+	for key := range conditionMap {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSelector evaluates the selector against a document. The fields of a
+// selector are combined with an implicit AND.
+func matchSelector(doc map[string]interface{}, selector map[string]interface{}) bool {
+	for key, condition := range selector {
+		switch key {
+		case "$and":
+			for _, sub := range condition.([]interface{}) {
+				if !matchSelector(doc, sub.(map[string]interface{})) {
+					return false
+				}
+			}
+		case "$or":
+			matched := false
+			for _, sub := range condition.([]interface{}) {
+				if matchSelector(doc, sub.(map[string]interface{})) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		case "$nor":
+			for _, sub := range condition.([]interface{}) {
+				if matchSelector(doc, sub.(map[string]interface{})) {
+					return false
+				}
+			}
+		case "$not":
+			if matchSelector(doc, condition.(map[string]interface{})) {
+				return false
+			}
+		default:
+			value, exists := lookupField(doc, key)
+			if !matchCondition(value, exists, condition) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func matchCondition(value interface{}, exists bool, condition interface{}) bool {
+	conditionMap, ok := condition.(map[string]interface{})
+	if !ok || !isOperatorMap(conditionMap) {
+		return exists && reflect.DeepEqual(value, condition)
+	}
+	for operator, operand := range conditionMap {
+		switch operator {
+		case "$eq":
+			if !exists || !reflect.DeepEqual(value, operand) {
+				return false
+			}
+		case "$ne":
+			if exists && reflect.DeepEqual(value, operand) {
+				return false
+			}
+		case "$gt", "$gte", "$lt", "$lte":
+			if !exists {
+				return false
+			}
+			result, comparable := compareValues(value, operand)
+			if !comparable {
+				return false
+			}
+			switch operator {
+			case "$gt":
+				if result <= 0 {
+					return false
+				}
+			case "$gte":
+				if result < 0 {
+					return false
+				}
+			case "$lt":
+				if result >= 0 {
+					return false
+				}
+			case "$lte":
+				if result > 0 {
+					return false
+				}
+			}
+		case "$in":
+			if !exists || !containsValue(operand.([]interface{}), value) {
+				return false
+			}
+		case "$nin":
+			if exists && containsValue(operand.([]interface{}), value) {
+				return false
+			}
+		case "$exists":
+			if operand.(bool) != exists {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compareValues compares two JSON values of the same type. Values of different
+// or non-ordered types are reported as not comparable, which causes the
+// comparison predicate to evaluate to false.
+func compareValues(a, b interface{}) (int, bool) {
+	switch aVal := a.(type) {
+	case float64:
+		bVal, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case aVal < bVal:
+			return -1, true
+		case aVal > bVal:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case string:
+		bVal, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(aVal, bVal), true
+	default:
+		return 0, false
+	}
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupField resolves a dotted field path against a document.
+func lookupField(doc map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	var value interface{} = doc
+	for _, part := range parts {
+		nested, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = nested[part]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// applyProjection builds the document returned for a query with a "fields"
+// clause, retaining only the requested fields.
+func applyProjection(doc map[string]interface{}, fields []string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, field := range fields {
+		value, exists := lookupField(doc, field)
+		if !exists {
+			continue
+		}
+		target := projected
+		parts := strings.Split(field, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := target[part].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				target[part] = next
+			}
+			target = next
+		}
+		target[parts[len(parts)-1]] = value
+	}
+	return projected
+}
+
+// equalityValue returns the value that the selector requires the given field
+// to be equal to, if the selector pins the field with an equality condition at
+// its top level. This drives index selection.
+func equalityValue(selector map[string]interface{}, field string) (interface{}, bool) {
+	condition, ok := selector[field]
+	if !ok {
+		return nil, false
+	}
+	conditionMap, ok := condition.(map[string]interface{})
+	if ok && isOperatorMap(conditionMap) {
+		value, ok := conditionMap["$eq"]
+		return value, ok
+	}
+	return condition, true
+}
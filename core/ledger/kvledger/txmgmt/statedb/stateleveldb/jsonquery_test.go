@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package stateleveldb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	expr, err := parseQuery(`{"selector":{"owner":"tom"},"limit":5,"fields":["owner","color"],"use_index":"indexOwner"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": "tom"}, expr.selector)
+	assert.Equal(t, int32(5), expr.limit)
+	assert.Equal(t, []string{"owner", "color"}, expr.fields)
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	testCases := []struct {
+		query       string
+		expectedErr string
+	}{
+		{`not json`, "error parsing query"},
+		{`{"selector":"owner"}`, "\"selector\" must be a JSON object"},
+		{`{"selector":{}}`, "query must contain a non-empty selector"},
+		{`{"limit":1}`, "query must contain a non-empty selector"},
+		{`{"selector":{"owner":"tom"},"limit":-1}`, "\"limit\" must be a non-negative integer"},
+		{`{"selector":{"owner":"tom"},"limit":1.5}`, "\"limit\" must be a non-negative integer"},
+		{`{"selector":{"owner":"tom"},"fields":"owner"}`, "\"fields\" must be a JSON array"},
+		{`{"selector":{"owner":"tom"},"fields":[1]}`, "\"fields\" entries must be strings"},
+		{`{"selector":{"owner":"tom"},"sort":[{"owner":"asc"}]}`, `query keyword "sort" is not supported by the leveldb query engine`},
+		{`{"selector":{"owner":{"$regex":"t.*"}}}`, "query operator $regex is not supported by the leveldb query engine"},
+		{`{"selector":{"$elemMatch":{"owner":"tom"}}}`, "query operator $elemMatch is not supported by the leveldb query engine"},
+		{`{"selector":{"$and":{"owner":"tom"}}}`, "operator $and requires a JSON array of selectors"},
+		{`{"selector":{"owner":{"$in":"tom"}}}`, "operator $in requires a JSON array"},
+		{`{"selector":{"owner":{"$exists":"yes"}}}`, "operator $exists requires a boolean"},
+	}
+	for _, testCase := range testCases {
+		_, err := parseQuery(testCase.query)
+		assert.Error(t, err, testCase.query)
+		assert.Contains(t, err.Error(), testCase.expectedErr)
+	}
+}
+
+func TestMatchSelector(t *testing.T) {
+	doc := map[string]interface{}{}
+	err := json.Unmarshal(
+		[]byte(`{"owner":"tom","size":5,"approved":true,"details":{"origin":"FR","weight":2}}`),
+		&doc,
+	)
+	assert.NoError(t, err)
+
+	matchingSelectors := []string{
+		`{"owner":"tom"}`,
+		`{"owner":{"$eq":"tom"},"size":{"$gt":1,"$lte":5}}`,
+		`{"size":{"$in":[3,5]}}`,
+		`{"owner":{"$nin":["jerry"]}}`,
+		`{"owner":{"$ne":"jerry"}}`,
+		`{"approved":true}`,
+		`{"details.origin":"FR"}`,
+		`{"details":{"origin":"FR","weight":2}}`,
+		`{"$or":[{"owner":"jerry"},{"size":5}]}`,
+		`{"$and":[{"owner":"tom"},{"size":5}]}`,
+		`{"$not":{"owner":"jerry"}}`,
+		`{"$nor":[{"owner":"jerry"},{"size":1}]}`,
+		`{"color":{"$exists":false}}`,
+		`{"owner":{"$exists":true}}`,
+	}
+	for _, selectorJSON := range matchingSelectors {
+		assert.True(t, matchSelector(doc, unmarshalSelector(t, selectorJSON)), selectorJSON)
+	}
+
+	nonMatchingSelectors := []string{
+		`{"owner":"jerry"}`,
+		`{"size":{"$gt":5}}`,
+		`{"size":{"$lt":5}}`,
+		`{"owner":{"$gt":5}}`, // not comparable across types
+		`{"color":"blue"}`,
+		`{"details.origin":"DE"}`,
+		`{"details":{"origin":"FR"}}`, // object equality is exact
+		`{"$or":[{"owner":"jerry"},{"size":1}]}`,
+		`{"$not":{"owner":"tom"}}`,
+		`{"owner":{"$exists":false}}`,
+	}
+	for _, selectorJSON := range nonMatchingSelectors {
+		assert.False(t, matchSelector(doc, unmarshalSelector(t, selectorJSON)), selectorJSON)
+	}
+}
+
+func TestApplyProjection(t *testing.T) {
+	doc := map[string]interface{}{}
+	err := json.Unmarshal([]byte(`{"owner":"tom","size":5,"details":{"origin":"FR","weight":2}}`), &doc)
+	assert.NoError(t, err)
+
+	projected := applyProjection(doc, []string{"owner", "details.origin", "color"})
+	projectedJSON, err := json.Marshal(projected)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"owner":"tom","details":{"origin":"FR"}}`, string(projectedJSON))
+}
+
+func unmarshalSelector(t *testing.T, selectorJSON string) map[string]interface{} {
+	selector := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal([]byte(selectorJSON), &selector))
+	return selector
+}
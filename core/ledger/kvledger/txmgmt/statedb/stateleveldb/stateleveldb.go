@@ -8,13 +8,13 @@ package stateleveldb
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/dataformat"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
-	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 )
 
@@ -32,13 +32,15 @@ type VersionedDBProvider struct {
 	dbProvider *leveldbhelper.Provider
 }
 
-// NewVersionedDBProvider instantiates VersionedDBProvider
-func NewVersionedDBProvider(dbPath string) (*VersionedDBProvider, error) {
+// NewVersionedDBProvider instantiates VersionedDBProvider. A non-nil encryptor
+// causes the values of the db to be transparently encrypted at rest
+func NewVersionedDBProvider(dbPath string, encryptor *leveldbhelper.ValueEncryptor) (*VersionedDBProvider, error) {
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
 	dbProvider, err := leveldbhelper.NewProvider(
 		&leveldbhelper.Conf{
 			DBPath:                dbPath,
 			ExpectedFormatVersion: dataformat.Version20,
+			Encryptor:             encryptor,
 		})
 	if err != nil {
 		return nil, err
@@ -133,7 +135,10 @@ func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey str
 	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
 }
 
-const optionLimit = "limit"
+const (
+	optionLimit    = "limit"
+	optionBookmark = "bookmark"
+)
 
 // GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
 func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
@@ -163,12 +168,50 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
-	return nil, errors.New("ExecuteQuery not supported for leveldb")
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
 }
 
 // ExecuteQueryWithMetadata implements method in VersionedDB interface
 func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
-	return nil, errors.New("ExecuteQueryWithMetadata not supported for leveldb")
+	logger.Debugf("ExecuteQueryWithMetadata(). ns=%s, query=%s", namespace, query)
+	expr, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	queryLimit := expr.limit
+	bookmark := ""
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			queryLimit = limitOption.(int32)
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	return vdb.executeQueryExpression(namespace, expr, queryLimit, bookmark)
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"bookmark\" must be a string")
+		case optionLimit:
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"limit\" must be a int32")
+		default:
+			return fmt.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
 }
 
 // ApplyUpdates implements method in VersionedDB interface
@@ -176,6 +219,10 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	dbBatch := leveldbhelper.NewUpdateBatch()
 	namespaces := batch.GetUpdatedNamespaces()
 	for _, ns := range namespaces {
+		indexes, err := vdb.indexDefinitions(ns)
+		if err != nil {
+			return err
+		}
 		updates := batch.GetUpdates(ns)
 		for k, vv := range updates {
 			dataKey := encodeDataKey(ns, k)
@@ -190,6 +237,11 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 				}
 				dbBatch.Put(dataKey, encodedVal)
 			}
+			if len(indexes) > 0 {
+				if err := vdb.updateIndexEntries(dbBatch, indexes, ns, k, vv.Value); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	// Record a savepoint at a given height
@@ -206,6 +258,28 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 	return nil
 }
 
+// GetAllNamespaces returns the namespaces that have at least one key in the
+// db, in lexical order. This is not part of the VersionedDB interface - it is
+// used by offline commands that scan the entire state database
+func (vdb *versionedDB) GetAllNamespaces() ([]string, error) {
+	dbItr := vdb.db.GetIterator(dataKeyPrefix, nil)
+	defer dbItr.Release()
+	var namespaces []string
+	for dbItr.Next() {
+		dataKey := dbItr.Key()
+		if !bytes.HasPrefix(dataKey, dataKeyPrefix) {
+			// the data keys carry the smallest prefix - the remaining keys are
+			// index and savepoint keys
+			break
+		}
+		ns, _ := decodeDataKey(dataKey)
+		if len(namespaces) == 0 || namespaces[len(namespaces)-1] != ns {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, dbItr.Error()
+}
+
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	versionBytes, err := vdb.db.Get(savePointKey)
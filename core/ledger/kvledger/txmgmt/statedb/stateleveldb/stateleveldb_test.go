@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package stateleveldb
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
@@ -61,20 +62,97 @@ func TestQueryOnLevelDB(t *testing.T) {
 	db.Open()
 	defer db.Close()
 	batch := statedb.NewUpdateBatch()
-	jsonValue1 := `{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`
-	batch.Put("ns1", "key1", []byte(jsonValue1), version.NewHeight(1, 1))
+	batch.Put("ns1", "key1", []byte(`{"asset_name": "marble1","color": "blue","size": 1,"owner": "tom"}`), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte(`{"asset_name": "marble2","color": "red","size": 2,"owner": "jerry"}`), version.NewHeight(1, 2))
+	batch.Put("ns1", "key3", []byte(`{"asset_name": "marble3","color": "red","size": 3,"owner": "jerry"}`), version.NewHeight(1, 3))
+	batch.Put("ns1", "key4", []byte("not json"), version.NewHeight(1, 4))
+	batch.Put("ns2", "key5", []byte(`{"asset_name": "marble5","color": "red","size": 5,"owner": "jerry"}`), version.NewHeight(1, 5))
 
 	savePoint := version.NewHeight(2, 22)
-	db.ApplyUpdates(batch, savePoint)
+	assert.NoError(t, db.ApplyUpdates(batch, savePoint))
 
-	// query for owner=jerry, use namespace "ns1"
-	// As queries are not supported in levelDB, call to ExecuteQuery()
-	// should return a error message
+	// query for owner=jerry returns the two matching documents of "ns1" in key order
 	itr, err := db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"}}`)
-	assert.Error(t, err, "ExecuteQuery not supported for leveldb")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2", "key3"}, retrieveKeys(t, itr))
+
+	// comparison and combination operators
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"size":{"$gt":1},"color":{"$in":["blue","red"]}}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2", "key3"}, retrieveKeys(t, itr))
+
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"$or":[{"owner":"tom"},{"size":3}]}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key1", "key3"}, retrieveKeys(t, itr))
+
+	// a query limit restricts the number of results
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"jerry"},"limit":1}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key2"}, retrieveKeys(t, itr))
+
+	// a projection restricts the returned fields
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":"tom"},"fields":["asset_name"]}`)
+	assert.NoError(t, err)
+	result, err := itr.Next()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"asset_name":"marble1"}`, string(result.(*statedb.VersionedKV).Value))
+	itr.Close()
+
+	// an unsupported operator is rejected
+	itr, err = db.ExecuteQuery("ns1", `{"selector":{"owner":{"$regex":"je.*"}}}`)
+	assert.EqualError(t, err, "query operator $regex is not supported by the leveldb query engine")
 	assert.Nil(t, itr)
 }
 
+// TestPaginatedQueryOnLevelDB tests paginated queries on levelDB.
+func TestPaginatedQueryOnLevelDB(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testpaginatedquery")
+	assert.NoError(t, err)
+	db.Open()
+	defer db.Close()
+	batch := statedb.NewUpdateBatch()
+	for i := 1; i <= 9; i++ {
+		jsonValue := fmt.Sprintf(`{"asset_name": "marble%d","color": "red"}`, i)
+		batch.Put("ns1", fmt.Sprintf("key%d", i), []byte(jsonValue), version.NewHeight(1, uint64(i)))
+	}
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 22)))
+
+	query := `{"selector":{"color":"red"}}`
+	returnedKeys := []string{}
+	bookmark := ""
+	for {
+		itr, err := db.ExecuteQueryWithMetadata("ns1", query,
+			map[string]interface{}{"limit": int32(4), "bookmark": bookmark})
+		assert.NoError(t, err)
+		keys := retrieveKeysWithoutClose(t, itr)
+		returnedKeys = append(returnedKeys, keys...)
+		bookmark = itr.GetBookmarkAndClose()
+		if len(keys) < 4 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"key1", "key2", "key3", "key4", "key5", "key6", "key7", "key8", "key9"}, returnedKeys)
+}
+
+func retrieveKeys(t *testing.T, itr statedb.ResultsIterator) []string {
+	defer itr.Close()
+	return retrieveKeysWithoutClose(t, itr)
+}
+
+func retrieveKeysWithoutClose(t *testing.T, itr statedb.ResultsIterator) []string {
+	keys := []string{}
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			return keys
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+}
+
 func TestGetStateMultipleKeys(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -0,0 +1,64 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statepostgres
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// rawQuery is the JSON document accepted by ExecuteQuery. It deliberately
+// mirrors the CouchDB query syntax for the subset that can be translated to
+// a JSONB containment predicate.
+type rawQuery struct {
+	Selector map[string]interface{} `json:"selector"`
+	Limit    int                    `json:"limit"`
+}
+
+// translateQuery converts a CouchDB-style selector query into the JSONB
+// document used as the containment predicate of the rich query, along with
+// the limit requested by the query itself. Only (possibly nested) equality
+// selectors are supported; queries using combination or condition operators
+// are rejected.
+func translateQuery(query string) (selector string, limit int, err error) {
+	parsed := &rawQuery{}
+	if err := json.Unmarshal([]byte(query), parsed); err != nil {
+		return "", 0, errors.Wrap(err, "error parsing query")
+	}
+	if len(parsed.Selector) == 0 {
+		return "", 0, errors.New("query must contain a non-empty selector")
+	}
+	if parsed.Limit < 0 {
+		return "", 0, errors.New("query limit must not be negative")
+	}
+	if err := validateSelector(parsed.Selector); err != nil {
+		return "", 0, err
+	}
+	raw, err := json.Marshal(parsed.Selector)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "error marshaling selector")
+	}
+	return string(raw), parsed.Limit, nil
+}
+
+// validateSelector rejects selectors that use query operators, since those
+// cannot be expressed as a JSONB containment predicate.
+func validateSelector(selector map[string]interface{}) error {
+	for field, value := range selector {
+		if strings.HasPrefix(field, "$") {
+			return errors.Errorf("query operator %s is not supported by the PostgreSQL state database, only equality selectors are", field)
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			if err := validateSelector(nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statepostgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateQuery(t *testing.T) {
+	selector, limit, err := translateQuery(`{"selector":{"color":"blue"}}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"color":"blue"}`, selector)
+	assert.Equal(t, 0, limit)
+
+	selector, limit, err = translateQuery(`{"selector":{"asset":{"size":5}},"limit":10}`)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"asset":{"size":5}}`, selector)
+	assert.Equal(t, 10, limit)
+}
+
+func TestTranslateQueryErrors(t *testing.T) {
+	_, _, err := translateQuery(`not json`)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing query")
+
+	_, _, err = translateQuery(`{"selector":{}}`)
+	assert.EqualError(t, err, "query must contain a non-empty selector")
+
+	_, _, err = translateQuery(`{"limit":10}`)
+	assert.EqualError(t, err, "query must contain a non-empty selector")
+
+	_, _, err = translateQuery(`{"selector":{"color":"blue"},"limit":-1}`)
+	assert.EqualError(t, err, "query limit must not be negative")
+}
+
+func TestTranslateQueryRejectsOperators(t *testing.T) {
+	_, _, err := translateQuery(`{"selector":{"$or":[{"color":"blue"},{"color":"red"}]}}`)
+	assert.EqualError(t, err, "query operator $or is not supported by the PostgreSQL state database, only equality selectors are")
+
+	_, _, err = translateQuery(`{"selector":{"size":{"$gt":5}}}`)
+	assert.EqualError(t, err, "query operator $gt is not supported by the PostgreSQL state database, only equality selectors are")
+
+	_, _, err = translateQuery(`{"selector":{"asset":{"details":{"$regex":"^a"}}}}`)
+	assert.EqualError(t, err, "query operator $regex is not supported by the PostgreSQL state database, only equality selectors are")
+}
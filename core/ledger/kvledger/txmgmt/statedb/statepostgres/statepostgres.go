@@ -0,0 +1,451 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package statepostgres implements a PostgreSQL-backed state database. Each
+// channel is stored in its own table, with the raw value kept in a BYTEA
+// column and, when the value is a JSON object, a shadow JSONB column that
+// carries a GIN index so that rich queries translate to indexed containment
+// predicates.
+package statepostgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util/postgres"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("statepostgres")
+
+// maxIdentifierLength is the PostgreSQL limit on identifier names.
+const maxIdentifierLength = 63
+
+// VersionedDBProvider implements interface VersionedDBProvider
+type VersionedDBProvider struct {
+	db        *sql.DB
+	config    *postgres.Config
+	mux       sync.Mutex
+	databases map[string]*versionedDB
+}
+
+// NewVersionedDBProvider instantiates VersionedDBProvider, opening a
+// connection pool to the configured PostgreSQL server.
+func NewVersionedDBProvider(config *postgres.Config) (*VersionedDBProvider, error) {
+	logger.Debugf("constructing VersionedDBProvider for PostgreSQL state database")
+	db, err := postgres.OpenPool(config)
+	if err != nil {
+		return nil, err
+	}
+	return &VersionedDBProvider{
+		db:        db,
+		config:    config,
+		databases: make(map[string]*versionedDB),
+	}, nil
+}
+
+// GetDBHandle gets the handle to a named database
+func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.VersionedDB, error) {
+	provider.mux.Lock()
+	defer provider.mux.Unlock()
+
+	vdb := provider.databases[dbName]
+	if vdb == nil {
+		var err error
+		vdb, err = newVersionedDB(provider.db, dbName, provider.config.InternalQueryLimit)
+		if err != nil {
+			return nil, err
+		}
+		provider.databases[dbName] = vdb
+	}
+	return vdb, nil
+}
+
+// Close closes the underlying connection pool
+func (provider *VersionedDBProvider) Close() {
+	if err := provider.db.Close(); err != nil {
+		logger.Warningf("Failed to close PostgreSQL connection pool: %s", err)
+	}
+}
+
+// HealthCheck verifies that the PostgreSQL server is reachable.
+func (provider *VersionedDBProvider) HealthCheck(ctx context.Context) error {
+	return provider.db.PingContext(ctx)
+}
+
+// versionedDB implements VersionedDB interface
+type versionedDB struct {
+	db         *sql.DB
+	dbName     string
+	table      string
+	queryLimit int
+}
+
+// newVersionedDB constructs an instance of versionedDB and creates the state
+// table, its indexes and the savepoint table if they do not exist yet.
+func newVersionedDB(db *sql.DB, dbName string, queryLimit int) (*versionedDB, error) {
+	table := tableName(dbName)
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			namespace TEXT NOT NULL,
+			key TEXT NOT NULL,
+			value BYTEA NOT NULL,
+			metadata BYTEA,
+			json JSONB,
+			block BIGINT NOT NULL,
+			txnum BIGINT NOT NULL,
+			PRIMARY KEY (namespace, key))`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_json_idx ON %s USING GIN (json)`, table, table),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s_svpt (
+			id SMALLINT PRIMARY KEY CHECK (id = 0),
+			block BIGINT NOT NULL,
+			txnum BIGINT NOT NULL)`, table),
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize state table for database %s", dbName)
+		}
+	}
+	return &versionedDB{db: db, dbName: dbName, table: table, queryLimit: queryLimit}, nil
+}
+
+// tableName derives the name of the state table for the given database. The
+// name is sanitized to a valid PostgreSQL identifier; a hash suffix keeps it
+// unique when characters had to be replaced or the name had to be truncated.
+func tableName(dbName string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return '_'
+		}
+	}, dbName)
+	name := "state_" + sanitized
+	// Leave room for the "_svpt" and "_json_idx" suffixes of the auxiliary objects.
+	maxLength := maxIdentifierLength - len("_json_idx")
+	if sanitized == dbName && len(name) <= maxLength {
+		return name
+	}
+	suffix := "_" + hex.EncodeToString(util.ComputeSHA256([]byte(dbName)))[:8]
+	if len(name)+len(suffix) > maxLength {
+		name = name[:maxLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// Open implements method in VersionedDB interface
+func (vdb *versionedDB) Open() error {
+	// do nothing because shared connection pool is used
+	return nil
+}
+
+// Close implements method in VersionedDB interface
+func (vdb *versionedDB) Close() {
+	// do nothing because shared connection pool is used
+}
+
+// ValidateKeyValue implements method in VersionedDB interface
+func (vdb *versionedDB) ValidateKeyValue(key string, value []byte) error {
+	if !utf8.ValidString(key) {
+		return errors.Errorf("invalid key [%x], must be a UTF-8 string", key)
+	}
+	return nil
+}
+
+// BytesKeySupported implements method in VersionedDB interface
+func (vdb *versionedDB) BytesKeySupported() bool {
+	return false
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
+	row := vdb.db.QueryRow(
+		fmt.Sprintf(`SELECT value, metadata, block, txnum FROM %s WHERE namespace = $1 AND key = $2`, vdb.table),
+		namespace, key,
+	)
+	var value, metadata []byte
+	var blockNum, txNum int64
+	if err := row.Scan(&value, &metadata, &blockNum, &txNum); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read state [%s, %s]", namespace, key)
+	}
+	return &statedb.VersionedValue{
+		Value:    value,
+		Metadata: metadata,
+		Version:  version.NewHeight(uint64(blockNum), uint64(txNum)),
+	}, nil
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *versionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	versionedValue, err := vdb.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if versionedValue == nil {
+		return nil, nil
+	}
+	return versionedValue.Version, nil
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	vals := make([]*statedb.VersionedValue, len(keys))
+	for i, key := range keys {
+		val, err := vdb.GetState(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+// startKey is inclusive
+// endKey is exclusive
+func (vdb *versionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.GetStateRangeScanIteratorWithMetadata(namespace, startKey, endKey, nil)
+}
+
+const (
+	optionLimit    = "limit"
+	optionBookmark = "bookmark"
+)
+
+// GetStateRangeScanIteratorWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	requestedLimit := int32(0)
+	if metadata != nil {
+		if err := statedb.ValidateRangeMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			requestedLimit = limitOption.(int32)
+		}
+	}
+
+	query := fmt.Sprintf(`SELECT key, value, metadata, block, txnum FROM %s WHERE namespace = $1 AND key >= $2`, vdb.table)
+	args := []interface{}{namespace, startKey}
+	if endKey != "" {
+		query += fmt.Sprintf(` AND key < $%d`, len(args)+1)
+		args = append(args, endKey)
+	}
+	query += ` ORDER BY key`
+	if requestedLimit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, requestedLimit)
+	}
+
+	rows, err := vdb.db.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute range scan on namespace %s", namespace)
+	}
+	return newKVScanner(namespace, rows), nil
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.ExecuteQueryWithMetadata(namespace, query, nil)
+}
+
+// ExecuteQueryWithMetadata implements method in VersionedDB interface
+func (vdb *versionedDB) ExecuteQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (statedb.QueryResultsIterator, error) {
+	selector, queryLimit, err := translateQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark := ""
+	if metadata != nil {
+		if err := validateQueryMetadata(metadata); err != nil {
+			return nil, err
+		}
+		if limitOption, ok := metadata[optionLimit]; ok {
+			queryLimit = int(limitOption.(int32))
+		}
+		if bookmarkOption, ok := metadata[optionBookmark]; ok {
+			bookmark = bookmarkOption.(string)
+		}
+	}
+	if queryLimit == 0 {
+		queryLimit = vdb.queryLimit
+	}
+
+	sqlQuery := fmt.Sprintf(`SELECT key, value, metadata, block, txnum FROM %s WHERE namespace = $1 AND json @> $2::jsonb`, vdb.table)
+	args := []interface{}{namespace, selector}
+	if bookmark != "" {
+		sqlQuery += fmt.Sprintf(` AND key > $%d`, len(args)+1)
+		args = append(args, bookmark)
+	}
+	sqlQuery += ` ORDER BY key`
+	if queryLimit > 0 {
+		sqlQuery += fmt.Sprintf(` LIMIT %d`, queryLimit)
+	}
+
+	rows, err := vdb.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to execute rich query on namespace %s", namespace)
+	}
+	return newKVScanner(namespace, rows), nil
+}
+
+func validateQueryMetadata(metadata map[string]interface{}) error {
+	for key, keyVal := range metadata {
+		switch key {
+		case optionBookmark:
+			if _, ok := keyVal.(string); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"bookmark\" must be a string")
+		case optionLimit:
+			if _, ok := keyVal.(int32); ok {
+				continue
+			}
+			return fmt.Errorf("Invalid entry, \"limit\" must be a int32")
+		default:
+			return fmt.Errorf("Invalid entry, option %s not recognized", key)
+		}
+	}
+	return nil
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	tx, err := vdb.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin state update transaction")
+	}
+	defer tx.Rollback()
+
+	deleteStmt := fmt.Sprintf(`DELETE FROM %s WHERE namespace = $1 AND key = $2`, vdb.table)
+	upsertStmt := fmt.Sprintf(`INSERT INTO %s (namespace, key, value, metadata, json, block, txnum)
+		VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7)
+		ON CONFLICT (namespace, key) DO UPDATE SET
+			value = EXCLUDED.value, metadata = EXCLUDED.metadata, json = EXCLUDED.json,
+			block = EXCLUDED.block, txnum = EXCLUDED.txnum`, vdb.table)
+
+	namespaces := batch.GetUpdatedNamespaces()
+	for _, ns := range namespaces {
+		updates := batch.GetUpdates(ns)
+		for k, vv := range updates {
+			logger.Debugf("Channel [%s]: Applying key=[%s]", vdb.dbName, k)
+			if vv.Value == nil {
+				_, err = tx.Exec(deleteStmt, ns, k)
+			} else {
+				_, err = tx.Exec(upsertStmt, ns, k, vv.Value, vv.Metadata, jsonValue(vv.Value),
+					int64(vv.Version.BlockNum), int64(vv.Version.TxNum))
+			}
+			if err != nil {
+				return errors.Wrapf(err, "failed to apply update for key [%s, %s]", ns, k)
+			}
+		}
+	}
+
+	// Record a savepoint at a given height
+	// If a given height is nil, it denotes that we are committing pvt data of old blocks.
+	// In this case, we should not store a savepoint for recovery. The lastUpdatedOldBlockList
+	// in the pvtstore acts as a savepoint for pvt data.
+	if height != nil {
+		savepointStmt := fmt.Sprintf(`INSERT INTO %s_svpt (id, block, txnum) VALUES (0, $1, $2)
+			ON CONFLICT (id) DO UPDATE SET block = EXCLUDED.block, txnum = EXCLUDED.txnum`, vdb.table)
+		if _, err := tx.Exec(savepointStmt, int64(height.BlockNum), int64(height.TxNum)); err != nil {
+			return errors.Wrap(err, "failed to record savepoint")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit state update transaction")
+	}
+	return nil
+}
+
+// GetLatestSavePoint implements method in VersionedDB interface
+func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
+	row := vdb.db.QueryRow(fmt.Sprintf(`SELECT block, txnum FROM %s_svpt WHERE id = 0`, vdb.table))
+	var blockNum, txNum int64
+	if err := row.Scan(&blockNum, &txNum); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to read savepoint")
+	}
+	return version.NewHeight(uint64(blockNum), uint64(txNum)), nil
+}
+
+// jsonValue returns the value as a string suitable for the JSONB shadow
+// column when the value is a JSON object, and nil (SQL NULL) otherwise.
+func jsonValue(value []byte) interface{} {
+	if json.Valid(value) && bytes.HasPrefix(bytes.TrimSpace(value), []byte("{")) {
+		return string(value)
+	}
+	return nil
+}
+
+type kvScanner struct {
+	namespace string
+	rows      *sql.Rows
+	lastKey   string
+}
+
+func newKVScanner(namespace string, rows *sql.Rows) *kvScanner {
+	return &kvScanner{namespace: namespace, rows: rows}
+}
+
+func (scanner *kvScanner) Next() (statedb.QueryResult, error) {
+	if !scanner.rows.Next() {
+		if err := scanner.rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "failed to advance query results")
+		}
+		return nil, nil
+	}
+
+	var key string
+	var value, metadata []byte
+	var blockNum, txNum int64
+	if err := scanner.rows.Scan(&key, &value, &metadata, &blockNum, &txNum); err != nil {
+		return nil, errors.Wrap(err, "failed to read query result")
+	}
+
+	scanner.lastKey = key
+	return &statedb.VersionedKV{
+		CompositeKey: statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
+		VersionedValue: statedb.VersionedValue{
+			Value:    value,
+			Metadata: metadata,
+			Version:  version.NewHeight(uint64(blockNum), uint64(txNum)),
+		},
+	}, nil
+}
+
+func (scanner *kvScanner) Close() {
+	if err := scanner.rows.Close(); err != nil {
+		logger.Warningf("Failed to close query results: %s", err)
+	}
+}
+
+// GetBookmarkAndClose returns the key of the last record returned, which may
+// be passed back as the "bookmark" query option to resume the query after it.
+func (scanner *kvScanner) GetBookmarkAndClose() string {
+	scanner.Close()
+	return scanner.lastKey
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statepostgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableName(t *testing.T) {
+	// a clean name is used as-is
+	assert.Equal(t, "state_mychannel", tableName("mychannel"))
+	assert.Equal(t, "state_my_channel_01", tableName("my_channel_01"))
+
+	// a name requiring sanitization carries a hash suffix for uniqueness
+	upper := tableName("MyChannel")
+	assert.True(t, strings.HasPrefix(upper, "state_mychannel_"))
+	assert.NotEqual(t, upper, tableName("mychannel"))
+	assert.NotEqual(t, upper, tableName("My-Channel"))
+
+	// sanitized names of distinct databases remain distinct
+	assert.NotEqual(t, tableName("my-channel"), tableName("my.channel"))
+
+	// a long name is truncated, leaving room for the auxiliary suffixes
+	long := tableName(strings.Repeat("a", 100))
+	assert.True(t, len(long)+len("_json_idx") <= maxIdentifierLength)
+	assert.NotEqual(t, long, tableName(strings.Repeat("a", 101)))
+}
+
+func TestJSONValue(t *testing.T) {
+	assert.Equal(t, `{"color":"blue"}`, jsonValue([]byte(`{"color":"blue"}`)))
+	assert.Equal(t, ` {"color":"blue"} `, jsonValue([]byte(` {"color":"blue"} `)))
+	assert.Nil(t, jsonValue([]byte(`"a plain json string"`)))
+	assert.Nil(t, jsonValue([]byte(`[1, 2, 3]`)))
+	assert.Nil(t, jsonValue([]byte(`{"truncated":`)))
+	assert.Nil(t, jsonValue([]byte{0x00, 0x01}))
+}
+
+func TestValidateQueryMetadata(t *testing.T) {
+	assert.NoError(t, validateQueryMetadata(map[string]interface{}{
+		"limit":    int32(10),
+		"bookmark": "key5",
+	}))
+
+	err := validateQueryMetadata(map[string]interface{}{"limit": 10})
+	assert.EqualError(t, err, "Invalid entry, \"limit\" must be a int32")
+
+	err = validateQueryMetadata(map[string]interface{}{"bookmark": int32(10)})
+	assert.EqualError(t, err, "Invalid entry, \"bookmark\" must be a string")
+
+	err = validateQueryMetadata(map[string]interface{}{"limit1": int32(10)})
+	assert.EqualError(t, err, "Invalid entry, option limit1 not recognized")
+}
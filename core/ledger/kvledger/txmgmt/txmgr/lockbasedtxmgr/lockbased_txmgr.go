@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/queryutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/statebasedval"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valimpl"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -70,6 +71,8 @@ func NewLockBasedTxMgr(
 	ccInfoProvider ledger.DeployedChaincodeInfoProvider,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hasher ledger.Hasher,
+	validationWorkerPoolSize int,
+	conflictRecorder statebasedval.ConflictRecorder,
 ) (*LockBasedTxMgr, error) {
 
 	if hasher == nil {
@@ -89,7 +92,7 @@ func NewLockBasedTxMgr(
 		return nil, err
 	}
 	txmgr.pvtdataPurgeMgr = &pvtdataPurgeMgr{pvtstatePurgeMgr, false}
-	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db, customTxProcessors, hasher)
+	txmgr.validator = valimpl.NewStatebasedValidator(txmgr, db, customTxProcessors, hasher, validationWorkerPoolSize, conflictRecorder)
 	return txmgr, nil
 }
 
@@ -239,6 +242,34 @@ func (txmgr *LockBasedTxMgr) RemoveStaleAndCommitPvtDataOfOldBlocks(reconciledPv
 	return nil
 }
 
+// PurgePrivateData deletes the given keys from the private state. The hashed
+// versions of the keys are deliberately retained - the key hashes are present
+// in the blocks anyway and removing them only on the peers that perform the
+// purge would diverge the validation results across peers. A deleted private
+// key is indistinguishable from pvtdata that the peer never possessed, which
+// keeps the queries and the reconciliation behavior consistent
+func (txmgr *LockBasedTxMgr) PurgePrivateData(namespace, collection string, keys []string) error {
+	// a lock on oldBlockCommit serializes this function with the regular block
+	// commits and with the commits of the reconciled pvtdata of old blocks
+	txmgr.oldBlockCommit.Lock()
+	defer txmgr.oldBlockCommit.Unlock()
+	logger.Debug("lock acquired on oldBlockCommit for purging private data from state database")
+
+	height, err := txmgr.db.GetLatestSavePoint()
+	if err != nil {
+		return err
+	}
+	if height == nil {
+		// an empty state holds no private data to purge
+		return nil
+	}
+	batch := privacyenabledstate.NewUpdateBatch()
+	for _, key := range keys {
+		batch.PvtUpdates.Delete(namespace, collection, key, height)
+	}
+	return txmgr.db.ApplyPrivacyAwareUpdates(batch, nil)
+}
+
 type uniquePvtDataMap map[privacyenabledstate.HashedCompositeKey]*privacyenabledstate.PvtKVWrite
 
 func constructUniquePvtData(reconciledPvtdata map[uint64][]*ledger.TxPvtData) (uniquePvtDataMap, error) {
@@ -108,6 +108,8 @@ func (env *lockBasedEnv) init(t *testing.T, testLedgerID string, btlPolicy pvtda
 		&mock.DeployedChaincodeInfoProvider{},
 		nil,
 		cryptoProvider,
+		0,
+		nil,
 	)
 	assert.NoError(t, err)
 
@@ -7,6 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package statebasedval
 
 import (
+	"fmt"
+	"runtime"
+	"sync"
+
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -20,11 +24,44 @@ import (
 
 var logger = flogging.MustGetLogger("statebasedval")
 
+// ConflictRecorder receives the details of the keys that caused MVCC read conflicts
+// or phantom reads during validation. The validator invokes RecordConflict, possibly
+// from multiple goroutines concurrently, once per invalidated transaction with the
+// first offending key. For a phantom read, the key denotes the range scanned by the
+// offending query and for a hashed read, the collection name and the key hash
+type ConflictRecorder interface {
+	RecordConflict(namespace, key string, validationCode peer.TxValidationCode)
+}
+
 // Validator validates a tx against the latest committed state
 // and preceding valid transactions with in the same block
 type Validator struct {
 	DB     privacyenabledstate.DB
 	Hasher ledger.Hasher
+	// WorkerPoolSize is the number of goroutines used for validating the
+	// transactions of a block against the committed state in parallel. A
+	// value of zero defaults to the number of CPUs; a value of one disables
+	// the parallel validation phase
+	WorkerPoolSize int
+	// ConflictRecorder, if set, is notified of the keys that caused transactions
+	// to be invalidated
+	ConflictRecorder ConflictRecorder
+}
+
+func (v *Validator) recordConflict(ns, key string, validationCode peer.TxValidationCode) {
+	if v.ConflictRecorder != nil {
+		v.ConflictRecorder.RecordConflict(ns, key, validationCode)
+	}
+}
+
+// rangeKey renders the range covered by a range query for conflict reporting
+func rangeKey(rangeQueryInfo *kvrwset.RangeQueryInfo) string {
+	return fmt.Sprintf("range[%s,%s]", rangeQueryInfo.StartKey, rangeQueryInfo.EndKey)
+}
+
+// hashedReadKey renders a hashed read of a private data collection for conflict reporting
+func hashedReadKey(coll string, keyHash []byte) string {
+	return fmt.Sprintf("%s:%x", coll, keyHash)
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -95,11 +132,26 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 		}
 	}
 
+	// validate the transactions against the committed state in parallel; the
+	// intra-block dependencies are resolved in the sequential pass below
+	var preValidationCodes []peer.TxValidationCode
+	if doMVCCValidation && v.workerPoolSize() > 1 && len(block.Txs) > 1 {
+		var err error
+		if preValidationCodes, err = v.preValidateAgainstCommittedState(block); err != nil {
+			return nil, err
+		}
+	}
+
 	updates := internal.NewPubAndHashUpdates()
-	for _, tx := range block.Txs {
+	for i, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if preValidationCodes != nil {
+			validationCode, err = v.validateAgainstBlockUpdates(tx.RWSet, preValidationCodes[i], updates)
+		} else {
+			validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates)
+		}
+		if err != nil {
 			return nil, err
 		}
 
@@ -116,6 +168,120 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 	return updates, nil
 }
 
+func (v *Validator) workerPoolSize() int {
+	if v.WorkerPoolSize <= 0 {
+		return runtime.NumCPU()
+	}
+	return v.WorkerPoolSize
+}
+
+// preValidateAgainstCommittedState validates the transactions of a block against
+// the committed state only, using a pool of worker goroutines. The returned codes
+// ignore the writes of preceding transactions in the same block and hence a
+// transaction marked valid here may still be invalidated by the sequential pass
+// in ValidateAndPrepareBatch. A transaction marked invalid here stays invalid
+// because the writes of preceding transactions can only add conflicts, never
+// remove them
+func (v *Validator) preValidateAgainstCommittedState(block *internal.Block) ([]peer.TxValidationCode, error) {
+	numWorkers := v.workerPoolSize()
+	if numWorkers > len(block.Txs) {
+		numWorkers = len(block.Txs)
+	}
+	logger.Debugf("Block [%d] pre-validating [%d] transactions using [%d] workers", block.Num, len(block.Txs), numWorkers)
+
+	// an empty updates batch - the workers only read from it and hence can share it
+	emptyUpdates := internal.NewPubAndHashUpdates()
+	validationCodes := make([]peer.TxValidationCode, len(block.Txs))
+	errs := make([]error, len(block.Txs))
+	txIndexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range txIndexes {
+				validationCodes[i], errs[i] = v.validateTx(block.Txs[i].RWSet, emptyUpdates)
+			}
+		}()
+	}
+	for i := range block.Txs {
+		txIndexes <- i
+	}
+	close(txIndexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return validationCodes, nil
+}
+
+// validateAgainstBlockUpdates combines the outcome of the parallel validation
+// against the committed state with a check of the transaction's read set against
+// the writes accumulated from the preceding valid transactions in the same block
+func (v *Validator) validateAgainstBlockUpdates(
+	txRWSet *rwsetutil.TxRwSet,
+	committedStateValidationCode peer.TxValidationCode,
+	updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+
+	if committedStateValidationCode != peer.TxValidationCode_VALID {
+		return committedStateValidationCode, nil
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvRead := range nsRWSet.KvRwSet.Reads {
+			if updates.PubUpdates.Exists(ns, kvRead.Key) {
+				v.recordConflict(ns, kvRead.Key, peer.TxValidationCode_MVCC_READ_CONFLICT)
+				return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			}
+		}
+		for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+			if overlaps, err := v.blockUpdatesOverlapRange(ns, rqi, updates.PubUpdates); overlaps || err != nil {
+				if err != nil {
+					return peer.TxValidationCode(-1), err
+				}
+				v.recordConflict(ns, rangeKey(rqi), peer.TxValidationCode_PHANTOM_READ_CONFLICT)
+				return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			}
+		}
+		for _, colHashedRwSet := range nsRWSet.CollHashedRwSets {
+			for _, kvReadHash := range colHashedRwSet.HashedRwSet.HashedReads {
+				if updates.HashUpdates.Contains(ns, colHashedRwSet.CollectionName, kvReadHash.KeyHash) {
+					v.recordConflict(ns, hashedReadKey(colHashedRwSet.CollectionName, kvReadHash.KeyHash), peer.TxValidationCode_MVCC_READ_CONFLICT)
+					return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+				}
+			}
+		}
+	}
+	return peer.TxValidationCode_VALID, nil
+}
+
+// blockUpdatesOverlapRange checks whether any of the writes accumulated from the
+// preceding valid transactions in the block falls into the range covered by the
+// given range query. Any such write changes the version of a key in the range and
+// hence would cause a phantom read conflict
+func (v *Validator) blockUpdatesOverlapRange(ns string, rangeQueryInfo *kvrwset.RangeQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+	itr := updates.UpdateBatch.GetRangeScanIterator(ns, rangeQueryInfo.StartKey, rangeQueryInfo.EndKey)
+	defer itr.Close()
+	res, err := itr.Next()
+	if err != nil {
+		return false, err
+	}
+	if res != nil {
+		return true, nil
+	}
+	// the updates iterator treats the endKey as exclusive but, if during simulation the
+	// caller had not exhausted the iterator, the endKey is the last key seen by the caller
+	// and is part of the query results (see validateRangeQuery)
+	if !rangeQueryInfo.ItrExhausted && rangeQueryInfo.EndKey != "" && updates.Exists(ns, rangeQueryInfo.EndKey) {
+		return true, nil
+	}
+	return false, nil
+}
+
 // validateEndorserTX validates endorser transaction
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
@@ -178,6 +344,7 @@ func (v *Validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, update
 // or in the updates (by a preceding valid transaction in the current block)
 func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
 	if updates.Exists(ns, kvRead.Key) {
+		v.recordConflict(ns, kvRead.Key, peer.TxValidationCode_MVCC_READ_CONFLICT)
 		return false, nil
 	}
 	committedVersion, err := v.DB.GetVersion(ns, kvRead.Key)
@@ -190,6 +357,7 @@ func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *p
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvRead.Version)) {
 		logger.Debugf("Version mismatch for key [%s:%s]. Committed version = [%#v], Version in readSet [%#v]",
 			ns, kvRead.Key, committedVersion, kvRead.Version)
+		v.recordConflict(ns, kvRead.Key, peer.TxValidationCode_MVCC_READ_CONFLICT)
 		return false, nil
 	}
 	return true, nil
@@ -234,7 +402,11 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 		validator = &rangeQueryResultsValidator{}
 	}
 	validator.init(rangeQueryInfo, combinedItr)
-	return validator.validate()
+	valid, err := validator.validate()
+	if !valid && err == nil {
+		v.recordConflict(ns, rangeKey(rangeQueryInfo), peer.TxValidationCode_PHANTOM_READ_CONFLICT)
+	}
+	return valid, err
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -266,6 +438,7 @@ func (v *Validator) validateCollHashedReadSet(ns, coll string, kvReadHashes []*k
 func (v *Validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVReadHash,
 	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
 	if updates.Contains(ns, coll, kvReadHash.KeyHash) {
+		v.recordConflict(ns, hashedReadKey(coll, kvReadHash.KeyHash), peer.TxValidationCode_MVCC_READ_CONFLICT)
 		return false, nil
 	}
 	committedVersion, err := v.DB.GetKeyHashVersion(ns, coll, kvReadHash.KeyHash)
@@ -276,6 +449,7 @@ func (v *Validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVRe
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvReadHash.Version)) {
 		logger.Debugf("Version mismatch for key hash [%s:%s:%#v]. Committed version = [%s], Version in hashedReadSet [%s]",
 			ns, coll, kvReadHash.KeyHash, committedVersion, kvReadHash.Version)
+		v.recordConflict(ns, hashedReadKey(coll, kvReadHash.KeyHash), peer.TxValidationCode_MVCC_READ_CONFLICT)
 		return false, nil
 	}
 	return true, nil
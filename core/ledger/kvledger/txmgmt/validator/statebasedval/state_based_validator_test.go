@@ -19,6 +19,7 @@ package statebasedval
 import (
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
@@ -368,6 +369,84 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{0})
 }
 
+func TestParallelValidation(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 2))
+	batch.PubUpdates.Put("ns1", "key4", []byte("value4"), version.NewHeight(1, 3))
+	batch.PubUpdates.Put("ns1", "key5", []byte("value5"), version.NewHeight(1, 4))
+	batch.HashUpdates.Put("ns1", "col1", util.ComputeStringHash("pvtkey1"), []byte("pvtvalue1"), version.NewHeight(1, 5))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 5))
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	validator := &Validator{DB: db, Hasher: cryptoProvider, WorkerPoolSize: 2}
+
+	// rwset1 is valid and its writes should invalidate the conflicting transactions that follow
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder1.AddToWriteSet("ns1", "key5", []byte("value5_new"))
+	rwsetBuilder1.AddToWriteSet("ns1", "key2_1", []byte("value2_1"))
+	rwsetBuilder1.AddToPvtAndHashedWriteSet("ns1", "col1", "pvtkey1", []byte("pvtvalue1_new"))
+
+	// rwset2 conflicts with the committed state - version of key1 does not match
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key1", version.NewHeight(1, 1))
+
+	// rwset3 conflicts with the write of key5 by rwset1
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key5", version.NewHeight(1, 4))
+
+	// rwset4 performs a range query and the write of key2_1 by rwset1 falls into the range
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rqi4 := &kvrwset.RangeQueryInfo{StartKey: "key2", EndKey: "key4", ItrExhausted: true}
+	rwsetutil.SetRawReads(rqi4, []*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key2", version.NewHeight(1, 1)),
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2))})
+	rwsetBuilder4.AddToRangeQuerySet("ns1", rqi4)
+
+	// rwset5 performs a range query that is not touched by any of the writes of rwset1
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rqi5 := &kvrwset.RangeQueryInfo{StartKey: "key3", EndKey: "key5", ItrExhausted: true}
+	rwsetutil.SetRawReads(rqi5, []*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2)),
+		rwsetutil.NewKVRead("key4", version.NewHeight(1, 3))})
+	rwsetBuilder5.AddToRangeQuerySet("ns1", rqi5)
+
+	// rwset6 conflicts with the hashed write of pvtkey1 by rwset1
+	rwsetBuilder6 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder6.AddToHashedReadSet("ns1", "col1", "pvtkey1", version.NewHeight(1, 5))
+
+	// rwset7 performs a range query that was not exhausted during simulation so that
+	// the last key seen (key5, overwritten by rwset1) is part of the query results
+	rwsetBuilder7 := rwsetutil.NewRWSetBuilder()
+	rqi7 := &kvrwset.RangeQueryInfo{StartKey: "key3", EndKey: "key5", ItrExhausted: false}
+	rwsetutil.SetRawReads(rqi7, []*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key3", version.NewHeight(1, 2)),
+		rwsetutil.NewKVRead("key4", version.NewHeight(1, 3)),
+		rwsetutil.NewKVRead("key5", version.NewHeight(1, 4))})
+	rwsetBuilder7.AddToRangeQuerySet("ns1", rqi7)
+
+	// rwset8 is independent of all of the above and should stay valid
+	rwsetBuilder8 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder8.AddToReadSet("ns1", "key3", version.NewHeight(1, 2))
+
+	transRWSets := getTestPubSimulationRWSet(t, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3,
+		rwsetBuilder4, rwsetBuilder5, rwsetBuilder6, rwsetBuilder7, rwsetBuilder8)
+	checkValidation(t, validator, transRWSets, []int{1, 2, 3, 5, 6})
+
+	// a single worker takes the sequential path and should produce the same outcome
+	sequentialValidator := &Validator{DB: db, Hasher: cryptoProvider, WorkerPoolSize: 1}
+	checkValidation(t, sequentialValidator, transRWSets, []int{1, 2, 3, 5, 6})
+}
+
 func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
 	var trans []*internal.Transaction
 	for i, tranRWSet := range transRWSets {
@@ -422,3 +501,74 @@ func getTestPubSimulationRWSet(t *testing.T, builders ...*rwsetutil.RWSetBuilder
 	}
 	return pubRWSets
 }
+
+type recordedConflict struct {
+	namespace      string
+	key            string
+	validationCode peer.TxValidationCode
+}
+
+type testConflictRecorder struct {
+	mutex     sync.Mutex
+	conflicts []*recordedConflict
+}
+
+func (r *testConflictRecorder) RecordConflict(namespace, key string, validationCode peer.TxValidationCode) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.conflicts = append(r.conflicts, &recordedConflict{namespace, key, validationCode})
+}
+
+func TestConflictRecorder(t *testing.T) {
+	testDBEnv := testEnvs[levelDBtestEnvName]
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 2))
+	keyHash := util.ComputeStringHash("hashedPvtKey1")
+	batch.HashUpdates.Put("ns2", "col1", keyHash, []byte("value1"), version.NewHeight(1, 3))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 3))
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	recorder := &testConflictRecorder{}
+	validator := &Validator{DB: db, Hasher: cryptoProvider, WorkerPoolSize: 1, ConflictRecorder: recorder}
+
+	// a stale public read is recorded with the offending key
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", version.NewHeight(1, 1))
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder1), []int{0})
+
+	// a conflict with the write of a preceding transaction in the same block is recorded
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	rwsetBuilder2.AddToWriteSet("ns1", "key2", []byte("value2_new"))
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2, rwsetBuilder3), []int{1})
+
+	// a phantom read is recorded with the range of the offending query
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rqi := &kvrwset.RangeQueryInfo{StartKey: "key1", EndKey: "key3", ItrExhausted: true}
+	rwsetutil.SetRawReads(rqi, []*kvrwset.KVRead{
+		rwsetutil.NewKVRead("key1", version.NewHeight(1, 0))})
+	rwsetBuilder4.AddToRangeQuerySet("ns1", rqi)
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder4), []int{0})
+
+	// a stale hashed read is recorded with the collection name and the key hash
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToHashedReadSet("ns2", "col1", "hashedPvtKey1", version.NewHeight(1, 0))
+	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder5), []int{0})
+
+	assert.Equal(t, []*recordedConflict{
+		{"ns1", "key1", peer.TxValidationCode_MVCC_READ_CONFLICT},
+		{"ns1", "key2", peer.TxValidationCode_MVCC_READ_CONFLICT},
+		{"ns1", "range[key1,key3]", peer.TxValidationCode_PHANTOM_READ_CONFLICT},
+		{"ns2", fmt.Sprintf("col1:%x", keyHash), peer.TxValidationCode_MVCC_READ_CONFLICT},
+	}, recorder.conflicts)
+}
@@ -38,13 +38,17 @@ func NewStatebasedValidator(
 	db privacyenabledstate.DB,
 	customTxProcessors map[common.HeaderType]ledger.CustomTxProcessor,
 	hasher ledger.Hasher,
+	validationWorkerPoolSize int,
+	conflictRecorder statebasedval.ConflictRecorder,
 ) validator.Validator {
 	return &DefaultImpl{
 		txmgr,
 		db,
 		&statebasedval.Validator{
-			DB:     db,
-			Hasher: hasher,
+			DB:               db,
+			Hasher:           hasher,
+			WorkerPoolSize:   validationWorkerPoolSize,
+			ConflictRecorder: conflictRecorder,
 		},
 		customTxProcessors,
 	}
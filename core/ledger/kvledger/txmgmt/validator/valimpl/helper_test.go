@@ -309,7 +309,7 @@ func TestTxStatsInfoWithConfigTx(t *testing.T) {
 
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	v := NewStatebasedValidator(nil, testDB, nil, cryptoProvider)
+	v := NewStatebasedValidator(nil, testDB, nil, cryptoProvider, 0, nil)
 
 	gb := testutil.ConstructTestBlocks(t, 1)[0]
 	_, txStatsInfo, err := v.ValidateAndPrepareBatch(&ledger.BlockAndPvtData{Block: gb}, true)
@@ -340,7 +340,7 @@ func TestContainsPostOrderWrites(t *testing.T) {
 
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	v := NewStatebasedValidator(mockTxmgr, testDB, customTxProcessors, cryptoProvider)
+	v := NewStatebasedValidator(mockTxmgr, testDB, customTxProcessors, cryptoProvider, 0, nil)
 	blocks := testutil.ConstructTestBlocks(t, 2)
 
 	// block with config tx that produces post order writes
@@ -381,7 +381,7 @@ func TestTxStatsInfo(t *testing.T) {
 
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	assert.NoError(t, err)
-	v := NewStatebasedValidator(nil, testDB, nil, cryptoProvider)
+	v := NewStatebasedValidator(nil, testDB, nil, cryptoProvider, 0, nil)
 
 	// create a block with 4 endorser transactions
 	tx1SimulationResults, _ := testutilGenerateTxSimulationResultsAsBytes(t,
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// VerifyKVLedger verifies the integrity of the block store of a ledger - it
+// recomputes the data hash of every block, validates the hash chain across the
+// block headers, and cross-checks the block index against the actual location
+// of the blocks in the block files. The peer must be offline when the command
+// is executed - the ledger file lock is held for the duration of the scan
+func VerifyKVLedger(rootFSPath, ledgerID string) (*fsblkstorage.BlockStoreVerificationResult, error) {
+	fileLockPath := fileLockPath(rootFSPath)
+	fileLock := leveldbhelper.NewFileLock(fileLockPath)
+	if err := fileLock.Lock(); err != nil {
+		return nil, errors.Wrap(err, "as another peer node command is executing,"+
+			" wait for that command to complete its execution or terminate it before retrying")
+	}
+	defer fileLock.Unlock()
+
+	logger.Infof("Verifying the block store for the channel [%s]", ledgerID)
+	result, err := ledgerstorage.VerifyBlockStore(BlockStorePath(rootFSPath), ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("The block store for the channel [%s] has been successfully verified up to block number [%d]",
+		ledgerID, result.LastBlockNumber)
+	return result, nil
+}
@@ -17,8 +17,11 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/ledger/blockarchival"
+	"github.com/hyperledger/fabric/common/ledger/blockexport"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/hyperledger/fabric/core/ledger/util/postgres"
 )
 
 // Initializer encapsulates dependencies for PeerLedgerProvider
@@ -44,16 +47,38 @@ type Config struct {
 	PrivateDataConfig *PrivateDataConfig
 	// HistoryDBConfig holds the configuration parameters for the transaction history database.
 	HistoryDBConfig *HistoryDBConfig
+	// EncryptionConfig holds the configuration parameters for encryption at rest
+	// of the leveldb based state database and the private data store. If nil, or
+	// if Enabled is false, the data is stored in the clear.
+	EncryptionConfig *EncryptionConfig
+	// BlockArchivalConfig holds the configuration parameters for block retention
+	// and archival. If nil, or if RetainBlocks is zero, blocks are retained
+	// locally forever.
+	BlockArchivalConfig *BlockArchivalConfig
+	// BlockExportConfig holds the configuration parameters for streaming
+	// committed blocks to an external sink. If nil, or if Sink is empty, no
+	// blocks are exported.
+	BlockExportConfig *BlockExportConfig
+	// ValidationWorkerPoolSize is the number of goroutines used for validating
+	// the transactions of a block against the committed state in parallel.
+	// A value of zero defaults to the number of CPUs available; a value of
+	// one disables parallel validation.
+	ValidationWorkerPoolSize int
 }
 
 // StateDBConfig is a structure used to configure the state parameters for the ledger.
 type StateDBConfig struct {
 	// StateDatabase is the database to use for storing last known state.  The
-	// two supported options are "goleveldb" and "CouchDB".
+	// built-in options are "goleveldb", "CouchDB" and "Postgres"; further
+	// implementations may be plugged in via
+	// privacyenabledstate.RegisterStateDBProvider.
 	StateDatabase string
 	// CouchDB is the configuration for CouchDB.  It is used when StateDatabase
 	// is set to "CouchDB".
 	CouchDB *couchdb.Config
+	// Postgres is the configuration for PostgreSQL.  It is used when
+	// StateDatabase is set to "Postgres".
+	Postgres *postgres.Config
 }
 
 // PrivateDataConfig is a structure used to configure a private data storage provider.
@@ -74,6 +99,59 @@ type HistoryDBConfig struct {
 	Enabled bool
 }
 
+// EncryptionConfig is a structure used to configure transparent encryption at rest
+// of the leveldb based state database and the private data store. It applies only
+// when the state database is "goleveldb". The encryption must be enabled when the
+// stores are created - a store created in the clear cannot be opened encrypted and
+// vice versa.
+type EncryptionConfig struct {
+	// Enabled turns on encryption at rest.
+	Enabled bool
+	// KeyPath is the path to the encryption key ring file. The file contains one
+	// base64 encoded 256-bit key per line, the last line being the current key.
+	// The key is rotated by appending a new key to the file and restarting the
+	// peer - the values encrypted with the retired keys are re-encrypted in the
+	// background.
+	KeyPath string
+}
+
+// BlockArchivalConfig is a structure used to configure block retention on the
+// peer. Blocks that age out of the retention window are offloaded to the
+// configured archival store and pruned from the local block store; config
+// blocks are always kept locally. Archived blocks remain retrievable through
+// the ledger's block APIs.
+type BlockArchivalConfig struct {
+	// RetainBlocks is the number of most recent blocks kept in the local block
+	// store. A value of zero disables archival and pruning.
+	RetainBlocks uint64
+	// Archiver selects the archival backend, one of "file" or "s3". It
+	// defaults to "file".
+	Archiver string
+	// FileDir is the directory the file archiver writes blocks to. It defaults
+	// to a directory under RootFSPath.
+	FileDir string
+	// S3 holds the object store parameters for the s3 archiver.
+	S3 *blockarchival.S3Config
+}
+
+// BlockExportConfig is a structure used to configure the streaming of committed
+// blocks to an external sink. Delivery is at-least-once - the peer advances its
+// per-channel resume token only after a successful delivery, so a sink must
+// tolerate duplicates.
+type BlockExportConfig struct {
+	// Sink selects the export backend, one of "kafka" or "webhook". An empty
+	// value disables block export.
+	Sink string
+	// Content selects what is delivered per block, either "block" (the
+	// serialized block) or "writesets" (a JSON document with the write-sets of
+	// the valid endorser transactions). It defaults to "block".
+	Content string
+	// Kafka holds the parameters for the kafka sink.
+	Kafka *blockexport.KafkaConfig
+	// Webhook holds the parameters for the webhook sink.
+	Webhook *blockexport.WebhookConfig
+}
+
 // PeerLedgerProvider provides handle to ledger instances
 type PeerLedgerProvider interface {
 	// Create creates a new ledger with the given genesis block.
@@ -218,6 +296,110 @@ type HistoryQueryExecutor interface {
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
 }
 
+// HistoryQueryOptions restricts the results of a history query. The zero value
+// of a field means that the corresponding restriction is not applied
+type HistoryQueryOptions struct {
+	// PageSize limits the number of results returned. When a page gets filled, a
+	// bookmark for fetching the next page is made available via the function
+	// `GetBookmarkAndClose` on the returned iterator
+	PageSize int32
+	// Bookmark, when set to a value obtained from a previous page of results,
+	// resumes the query from where the previous page ended. A bookmark is opaque
+	// and is valid only for the same query that produced it
+	Bookmark string
+	// StartBlockNum and EndBlockNum restrict the results to the key modifications
+	// committed in the blocks within the range [StartBlockNum, EndBlockNum] (both inclusive)
+	StartBlockNum uint64
+	EndBlockNum   uint64
+	// ExcludeDeletes drops the key modifications that deleted the key
+	ExcludeDeletes bool
+}
+
+// PvtDataPurger is an optional interface that a PeerLedger may implement for erasing
+// specific private data keys, as opposed to the expiry of whole collections via the
+// blockToLive policy. A purge erases the key versions committed up to the point of the
+// purge from the peer and prevents them from being refetched via reconciliation; it does
+// not prevent future transactions from writing the keys again. The key hashes, being
+// part of the blocks, are not erased. Each peer that is authorized to hold the data is
+// expected to perform the purge for a complete erasure across the channel
+type PvtDataPurger interface {
+	// PurgePrivateData erases the given private data keys of a collection from the peer
+	PurgePrivateData(namespace, collection string, keys []string) error
+}
+
+// ConflictStatsProvider is an optional interface that a PeerLedger may implement for
+// exposing the statistics about the transactions that were invalidated by MVCC read
+// conflicts or phantom reads. The statistics are accumulated in memory since the peer
+// started and are not persisted across restarts
+type ConflictStatsProvider interface {
+	// ConflictStats returns the accumulated conflict statistics of the channel
+	ConflictStats() (*ConflictStats, error)
+}
+
+// ConflictStats holds the statistics about the transactions of a channel that were
+// invalidated by MVCC read conflicts or phantom reads
+type ConflictStats struct {
+	Channel    string                    `json:"channel"`
+	Chaincodes []*ChaincodeConflictStats `json:"chaincodes"`
+}
+
+// ChaincodeConflictStats holds the conflict statistics of a single chaincode
+type ChaincodeConflictStats struct {
+	Chaincode string `json:"chaincode"`
+	// TotalTransactions is the number of transactions of the chaincode processed by
+	// the committer, valid or not
+	TotalTransactions uint64 `json:"totalTransactions"`
+	// MVCCReadConflicts and PhantomReadConflicts are the numbers of transactions
+	// invalidated with the validation codes MVCC_READ_CONFLICT and PHANTOM_READ_CONFLICT
+	MVCCReadConflicts    uint64 `json:"mvccReadConflicts"`
+	PhantomReadConflicts uint64 `json:"phantomReadConflicts"`
+	// ConflictRate is the fraction of the total transactions that were invalidated
+	// by either of the two conflict types
+	ConflictRate float64 `json:"conflictRate"`
+	// TopKeys lists the keys of the chaincode that caused the most conflicts, in
+	// decreasing order of the number of conflicts
+	TopKeys []*KeyConflicts `json:"topKeys,omitempty"`
+}
+
+// KeyConflicts holds the number of conflicts caused by a single key. For a phantom
+// read conflict, the key denotes the range that the offending query had scanned and
+// for a conflict on a private data read, the key denotes the collection name and the
+// hash of the offending key
+type KeyConflicts struct {
+	Key                  string `json:"key"`
+	MVCCReadConflicts    uint64 `json:"mvccReadConflicts"`
+	PhantomReadConflicts uint64 `json:"phantomReadConflicts"`
+}
+
+// BlockIndexRebuilder is an optional interface that a PeerLedger may implement for
+// rebuilding the block storage index in the background while the peer continues to
+// serve traffic - e.g., for backfilling the index entries of existing blocks after
+// a new index attribute has been enabled
+type BlockIndexRebuilder interface {
+	// RebuildBlockIndex starts a background rebuild of the block index. An error
+	// is returned if a rebuild is already in progress
+	RebuildBlockIndex() error
+	// BlockIndexRebuildStatus returns the progress of the ongoing rebuild or the
+	// outcome of the last completed one
+	BlockIndexRebuildStatus() (*BlockIndexRebuildStatus, error)
+}
+
+// BlockIndexRebuildStatus conveys the progress of a rebuild of the block index
+type BlockIndexRebuildStatus struct {
+	InProgress       bool   `json:"inProgress"`
+	LastBlockIndexed uint64 `json:"lastBlockIndexed"`
+	TargetBlockNum   uint64 `json:"targetBlockNum"`
+	LastError        string `json:"lastError,omitempty"`
+}
+
+// PagedHistoryQueryExecutor is an optional interface that a HistoryQueryExecutor
+// may implement for serving history queries with pagination and filters
+type PagedHistoryQueryExecutor interface {
+	// GetHistoryForKeyWithOptions retrieves the history of values for a key, restricted by the given options.
+	// The returned QueryResultsIterator contains results of type *KeyModification which is defined in fabric-protos/ledger/queryresult.
+	GetHistoryForKeyWithOptions(namespace string, key string, options *HistoryQueryOptions) (commonledger.QueryResultsIterator, error)
+}
+
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
 // Set* methods are for supporting KV-based data model. ExecuteUpdate method is for supporting a rich datamodel and query support
 type TxSimulator interface {
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/pkg/errors"
 )
 
@@ -141,6 +142,16 @@ func (m *LedgerMgr) GetLedgerIDs() ([]string, error) {
 	return m.ledgerProvider.List()
 }
 
+// StateCache returns the state cache maintained by the state database of the
+// peer, or nil if the state database maintains no cache
+func (m *LedgerMgr) StateCache() *statedb.Cache {
+	cacheHolder, ok := m.ledgerProvider.(interface{ StateCache() *statedb.Cache })
+	if !ok {
+		return nil
+	}
+	return cacheHolder.StateCache()
+}
+
 // Close closes all the opened ledgers and any resources held for ledger management
 func (m *LedgerMgr) Close() {
 	logger.Infof("Closing ledger mgmt")
@@ -186,6 +197,47 @@ func (l *closableLedger) Close() {
 	l.ledgerMgr.closeLedger(l.id)
 }
 
+// PurgePrivateData passes the purge request to the actual ledger, if the underlying
+// implementation supports the optional interface `ledger.PvtDataPurger`
+func (l *closableLedger) PurgePrivateData(namespace, collection string, keys []string) error {
+	purger, ok := l.PeerLedger.(ledger.PvtDataPurger)
+	if !ok {
+		return errors.New("the ledger implementation does not support purging of private data")
+	}
+	return purger.PurgePrivateData(namespace, collection, keys)
+}
+
+// ConflictStats returns the conflict statistics of the actual ledger, if the underlying
+// implementation supports the optional interface `ledger.ConflictStatsProvider`
+func (l *closableLedger) ConflictStats() (*ledger.ConflictStats, error) {
+	provider, ok := l.PeerLedger.(ledger.ConflictStatsProvider)
+	if !ok {
+		return nil, errors.New("the ledger implementation does not support conflict statistics")
+	}
+	return provider.ConflictStats()
+}
+
+// RebuildBlockIndex passes the rebuild request to the actual ledger, if the underlying
+// implementation supports the optional interface `ledger.BlockIndexRebuilder`
+func (l *closableLedger) RebuildBlockIndex() error {
+	rebuilder, ok := l.PeerLedger.(ledger.BlockIndexRebuilder)
+	if !ok {
+		return errors.New("the ledger implementation does not support rebuilding the block index")
+	}
+	return rebuilder.RebuildBlockIndex()
+}
+
+// BlockIndexRebuildStatus returns the progress of the block index rebuild of the actual
+// ledger, if the underlying implementation supports the optional interface
+// `ledger.BlockIndexRebuilder`
+func (l *closableLedger) BlockIndexRebuildStatus() (*ledger.BlockIndexRebuildStatus, error) {
+	rebuilder, ok := l.PeerLedger.(ledger.BlockIndexRebuilder)
+	if !ok {
+		return nil, errors.New("the ledger implementation does not support rebuilding the block index")
+	}
+	return rebuilder.BlockIndexRebuildStatus()
+}
+
 // lscc namespace listener for chaincode instantiate transactions (which manipulates data in 'lscc' namespace)
 // this code should be later moved to peer and passed via `Initialize` function of ledgermgmt
 func addListenerForCCEventsHandler(
@@ -141,9 +141,10 @@ func constructDefaultInitializer(testDir string) (*Initializer, error) {
 			},
 		},
 
-		MetricsProvider:               &disabled.Provider{},
-		DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
-		Hasher:                        cryptoProvider,
+		MetricsProvider:                 &disabled.Provider{},
+		DeployedChaincodeInfoProvider:   &mock.DeployedChaincodeInfoProvider{},
+		ChaincodeLifecycleEventProvider: &mock.ChaincodeLifecycleEventProvider{},
+		Hasher:                          cryptoProvider,
 	}, nil
 }
 
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgermgmt
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+// BlockIndexRequest is the body of a POST request to the block index endpoint
+// of the operations server
+type BlockIndexRequest struct {
+	Channel string `json:"channel"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewBlockIndexHandler creates an http.Handler exposing the progress of the
+// background block index rebuild of a channel (GET with a "channel" query
+// parameter) and the triggering of a rebuild (POST with a JSON body naming the
+// channel).
+func NewBlockIndexHandler(ledgerMgr *LedgerMgr) *BlockIndexHandler {
+	return &BlockIndexHandler{
+		ledgerMgr: ledgerMgr,
+		logger:    flogging.MustGetLogger("ledgermgmt.blockindex"),
+	}
+}
+
+// BlockIndexHandler serves the block index rebuild API over HTTP
+type BlockIndexHandler struct {
+	ledgerMgr *LedgerMgr
+	logger    *flogging.FabricLogger
+}
+
+func (h *BlockIndexHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		channelID := req.URL.Query().Get("channel")
+		if channelID == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel query parameter is required"))
+			return
+		}
+		rebuilder, err := h.rebuilder(channelID)
+		if err != nil {
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		status, err := rebuilder.BlockIndexRebuildStatus()
+		if err != nil {
+			h.sendResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		h.sendResponse(resp, http.StatusOK, status)
+
+	case http.MethodPost:
+		var request BlockIndexRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&request); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+		if request.Channel == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel field is required"))
+			return
+		}
+		rebuilder, err := h.rebuilder(request.Channel)
+		if err != nil {
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		if err := rebuilder.RebuildBlockIndex(); err != nil {
+			h.sendResponse(resp, http.StatusConflict, err)
+			return
+		}
+		// the rebuild continues in the background - the progress can be followed
+		// via GET requests
+		resp.WriteHeader(http.StatusAccepted)
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *BlockIndexHandler) rebuilder(channelID string) (ledger.BlockIndexRebuilder, error) {
+	l, err := h.ledgerMgr.getOpenedLedger(channelID)
+	if err != nil {
+		return nil, err
+	}
+	rebuilder, ok := l.(ledger.BlockIndexRebuilder)
+	if !ok {
+		return nil, errors.Errorf("the ledger of channel %s does not support rebuilding the block index", channelID)
+	}
+	return rebuilder, nil
+}
+
+func (h *BlockIndexHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.WriteHeader(code)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
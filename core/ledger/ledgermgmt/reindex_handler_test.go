@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgermgmt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockIndexHandler(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "reindexhandler")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+	initializer, err := constructDefaultInitializer(testDir)
+	require.NoError(t, err)
+
+	ledgerMgr := NewLedgerMgr(initializer)
+	defer ledgerMgr.Close()
+	gb, _ := test.MakeGenesisBlock("testchannel")
+	_, err = ledgerMgr.CreateLedger("testchannel", gb)
+	require.NoError(t, err)
+
+	handler := NewBlockIndexHandler(ledgerMgr)
+
+	// the status of a fresh ledger reports no rebuild in progress
+	req := httptest.NewRequest(http.MethodGet, "/block-index?channel=testchannel", nil)
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	status := &ledger.BlockIndexRebuildStatus{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), status))
+	assert.False(t, status.InProgress)
+
+	// a POST triggers a background rebuild
+	req = httptest.NewRequest(http.MethodPost, "/block-index", strings.NewReader(`{"channel":"testchannel"}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusAccepted, resp.Code)
+
+	for i := 0; i < 100; i++ {
+		req = httptest.NewRequest(http.MethodGet, "/block-index?channel=testchannel", nil)
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+		status = &ledger.BlockIndexRebuildStatus{}
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), status))
+		if !status.InProgress {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.False(t, status.InProgress)
+	assert.Empty(t, status.LastError)
+	assert.Equal(t, uint64(0), status.TargetBlockNum)
+
+	// the channel query parameter is required
+	req = httptest.NewRequest(http.MethodGet, "/block-index", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// an unknown channel is rejected
+	req = httptest.NewRequest(http.MethodGet, "/block-index?channel=unknown", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/block-index", strings.NewReader(`{"channel":"unknown"}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusNotFound, resp.Code)
+
+	// the channel field is required in a POST body
+	req = httptest.NewRequest(http.MethodPost, "/block-index", strings.NewReader(`{}`))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// a malformed body is rejected
+	req = httptest.NewRequest(http.MethodPost, "/block-index", strings.NewReader("garbage"))
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// an unsupported method is rejected
+	req = httptest.NewRequest(http.MethodDelete, "/block-index", nil)
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+}
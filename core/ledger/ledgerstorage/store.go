@@ -191,6 +191,19 @@ func (s *Store) CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPv
 	return nil
 }
 
+// PurgePrivateDataKeys purges the given private data keys of a collection from
+// the pvtdata store and records the corresponding purge markers
+func (s *Store) PurgePrivateDataKeys(ns, coll string, keys []string) error {
+	s.rwlock.Lock()
+	defer s.rwlock.Unlock()
+	return s.pvtdataStore.PurgePrivateDataKeys(ns, coll, keys)
+}
+
+// RemovePurgedKeys drops the writes to purged keys from the given pvtdata of old blocks
+func (s *Store) RemovePurgedKeys(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error) {
+	return s.pvtdataStore.RemovePurgedKeys(blocksPvtData)
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (s *Store) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
@@ -300,8 +313,25 @@ func ValidateRollbackParams(blockstorePath, ledgerID string, blockNum uint64) er
 	return fsblkstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum)
 }
 
-// Rollback reverts changes made to the block store beyond a given block number.
-func Rollback(blockstorePath, ledgerID string, blockNum uint64) error {
+// VerifyBlockStore scans the block files of the given ledger and verifies the
+// recorded hashes, the hash chain across blocks, and the block index.
+func VerifyBlockStore(blockstorePath, ledgerID string) (*fsblkstorage.BlockStoreVerificationResult, error) {
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
-	return fsblkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig)
+	return fsblkstorage.VerifyBlockStore(blockstorePath, ledgerID, indexConfig)
+}
+
+// ScanBlockStore streams the committed blocks of the given ledger, in order,
+// to the handler.
+func ScanBlockStore(blockstorePath, ledgerID string, handler func(block *common.Block) error) error {
+	return fsblkstorage.ScanBlockStore(blockstorePath, ledgerID, handler)
+}
+
+// Rollback reverts changes made to the block store and the pvtdata store
+// beyond a given block number.
+func Rollback(blockstorePath, pvtdataStorePath, ledgerID string, blockNum uint64) error {
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	if err := fsblkstorage.Rollback(blockstorePath, ledgerID, blockNum, indexConfig); err != nil {
+		return err
+	}
+	return pvtdatastorage.Rollback(pvtdataStorePath, ledgerID, blockNum)
 }
@@ -27,6 +27,7 @@ var (
 	ineligibleMissingDataKeyPrefix = []byte{5}
 	collElgKeyPrefix               = []byte{6}
 	lastUpdatedOldBlocksKey        = []byte{7}
+	purgeMarkerKeyPrefix           = []byte{8}
 
 	nilByte    = byte(0)
 	emptyValue = []byte{}
@@ -176,6 +177,33 @@ func decodeCollElgVal(b []byte) (*CollElgInfo, error) {
 	return m, nil
 }
 
+func encodePurgeMarkerKey(ns, coll, key string) []byte {
+	// the key is placed at the end as it is the only component that may contain the nil byte
+	keyBytes := append(purgeMarkerKeyPrefix, []byte(ns)...)
+	keyBytes = append(keyBytes, nilByte)
+	keyBytes = append(keyBytes, []byte(coll)...)
+	keyBytes = append(keyBytes, nilByte)
+	return append(keyBytes, []byte(key)...)
+}
+
+func decodePurgeMarkerKey(keyBytes []byte) (ns, coll, key string) {
+	splittedKey := bytes.SplitN(keyBytes[1:], []byte{nilByte}, 3)
+	return string(splittedKey[0]), string(splittedKey[1]), string(splittedKey[2])
+}
+
+func encodePurgeMarkerVal(blkNum uint64) []byte {
+	return proto.EncodeVarint(blkNum)
+}
+
+func decodePurgeMarkerVal(b []byte) uint64 {
+	blkNum, _ := proto.DecodeVarint(b)
+	return blkNum
+}
+
+func createRangeScanKeysForPurgeMarkers() (startKey, endKey []byte) {
+	return purgeMarkerKeyPrefix, []byte{purgeMarkerKeyPrefix[0] + 1}
+}
+
 func createRangeScanKeysForEligibleMissingDataEntries(blkNum uint64) (startKey, endKey []byte) {
 	startKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(blkNum)...)
 	endKey = append(eligibleMissingDataKeyPrefix, util.EncodeReverseOrderVarUint64(0)...)
@@ -0,0 +1,229 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"sync/atomic"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/pkg/errors"
+)
+
+// nsCollKey identifies a private data key within a collection and is used in
+// the in-memory index of the purge markers
+type nsCollKey struct {
+	ns, coll, key string
+}
+
+// PurgePrivateDataKeys implements the function in the interface `Store`.
+// It removes the writes to the given keys from the private write sets of all
+// the committed blocks and records a purge marker for each key. The purge
+// markers prevent the purged data from being reintroduced into the store when
+// the pvtdata of old blocks is committed via reconciliation (see the function
+// `RemovePurgedKeys`). A purge covers only the versions committed up to the
+// block at which the purge is performed - the keys can still be written by
+// future transactions
+func (s *store) PurgePrivateDataKeys(ns, coll string, keys []string) error {
+	// the purgerLock serializes this function with the BTL based expiry of the collections
+	s.purgerLock.Lock()
+	defer s.purgerLock.Unlock()
+
+	purgeBlkNum := atomic.LoadUint64(&s.lastCommittedBlock)
+	toPurge := make(map[string]bool)
+	for _, key := range keys {
+		toPurge[key] = true
+	}
+
+	batch := leveldbhelper.NewUpdateBatch()
+	itr := s.db.GetIterator(pvtDataKeyPrefix, []byte{pvtDataKeyPrefix[0] + 1})
+	defer itr.Release()
+	for itr.Next() {
+		keyBytes := append([]byte{}, itr.Key()...)
+		v11Fmt, err := v11Format(keyBytes)
+		if err != nil {
+			return err
+		}
+		if v11Fmt {
+			if err := addPurgedV11EntryToBatch(batch, keyBytes, itr.Value(), ns, coll, toPurge); err != nil {
+				return err
+			}
+			continue
+		}
+		dataKey, err := decodeDatakey(keyBytes)
+		if err != nil {
+			return err
+		}
+		if dataKey.ns != ns || dataKey.coll != coll {
+			continue
+		}
+		collPvtdata, err := decodeDataValue(itr.Value())
+		if err != nil {
+			return err
+		}
+		changed, remaining, err := removeKeysFromCollPvtRwset(collPvtdata, toPurge)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		if remaining == 0 {
+			batch.Delete(keyBytes)
+			continue
+		}
+		valBytes, err := encodeDataValue(collPvtdata)
+		if err != nil {
+			return err
+		}
+		batch.Put(keyBytes, valBytes)
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over the pvtdata entries")
+	}
+
+	for _, key := range keys {
+		batch.Put(encodePurgeMarkerKey(ns, coll, key), encodePurgeMarkerVal(purgeBlkNum))
+	}
+	if err := s.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+
+	s.purgeMarkersLock.Lock()
+	for _, key := range keys {
+		s.purgeMarkers[nsCollKey{ns: ns, coll: coll, key: key}] = purgeBlkNum
+	}
+	s.purgeMarkersLock.Unlock()
+	logger.Infof("Purged private data of [%d] keys in [%s:%s] up to block [%d]", len(keys), ns, coll, purgeBlkNum)
+	return nil
+}
+
+// RemovePurgedKeys implements the function in the interface `Store`.
+// It drops the writes to purged keys from the given pvtdata of old blocks so
+// that purged data cannot be reintroduced into the store or the state database
+// through reconciliation. Only the versions committed up to the block recorded
+// in the purge marker are dropped - writes from the later blocks are retained.
+// The (possibly emptied) collection write sets are kept in place so that the
+// missing data bookkeeping for the reconciled collections still gets cleared
+func (s *store) RemovePurgedKeys(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error) {
+	s.purgeMarkersLock.RLock()
+	defer s.purgeMarkersLock.RUnlock()
+	if len(s.purgeMarkers) == 0 {
+		return blocksPvtData, nil
+	}
+	for blkNum, blockPvtdata := range blocksPvtData {
+		for _, txPvtdata := range blockPvtdata {
+			for _, nsPvtRwset := range txPvtdata.WriteSet.NsPvtRwset {
+				for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+					toPurge := s.purgedKeysUpToBlock(nsPvtRwset.Namespace, collPvtRwset.CollectionName, blkNum)
+					if len(toPurge) == 0 {
+						continue
+					}
+					if _, _, err := removeKeysFromCollPvtRwset(collPvtRwset, toPurge); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+	return blocksPvtData, nil
+}
+
+// purgedKeysUpToBlock returns the purged keys of a collection whose purge
+// marker covers the given block number. The number of purge markers is
+// expected to be small and hence they are scanned rather than indexed by
+// collection
+func (s *store) purgedKeysUpToBlock(ns, coll string, blkNum uint64) map[string]bool {
+	var keys map[string]bool
+	for markerKey, markerBlkNum := range s.purgeMarkers {
+		if markerKey.ns == ns && markerKey.coll == coll && blkNum <= markerBlkNum {
+			if keys == nil {
+				keys = make(map[string]bool)
+			}
+			keys[markerKey.key] = true
+		}
+	}
+	return keys
+}
+
+// loadPurgeMarkers loads the purge markers in the in-memory index maintained
+// for filtering the pvtdata committed via reconciliation
+func (s *store) loadPurgeMarkers() error {
+	s.purgeMarkers = make(map[nsCollKey]uint64)
+	startKey, endKey := createRangeScanKeysForPurgeMarkers()
+	itr := s.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+	for itr.Next() {
+		ns, coll, key := decodePurgeMarkerKey(itr.Key())
+		s.purgeMarkers[nsCollKey{ns: ns, coll: coll, key: key}] = decodePurgeMarkerVal(itr.Value())
+	}
+	return itr.Error()
+}
+
+// addPurgedV11EntryToBatch handles the purge of a pvtdata entry that is stored
+// in the v11 format, where a single entry carries the write sets of all the
+// collections of a transaction
+func addPurgedV11EntryToBatch(batch *leveldbhelper.UpdateBatch, keyBytes, valBytes []byte, ns, coll string, toPurge map[string]bool) error {
+	txPvtRwset, err := v11DecodePvtRwSet(valBytes)
+	if err != nil {
+		return err
+	}
+	changed := false
+	for _, nsPvtRwset := range txPvtRwset.NsPvtRwset {
+		if nsPvtRwset.Namespace != ns {
+			continue
+		}
+		for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+			if collPvtRwset.CollectionName != coll {
+				continue
+			}
+			collChanged, _, err := removeKeysFromCollPvtRwset(collPvtRwset, toPurge)
+			if err != nil {
+				return err
+			}
+			changed = changed || collChanged
+		}
+	}
+	if !changed {
+		return nil
+	}
+	newValBytes, err := proto.Marshal(txPvtRwset)
+	if err != nil {
+		return err
+	}
+	batch.Put(keyBytes, newValBytes)
+	return nil
+}
+
+// removeKeysFromCollPvtRwset drops the writes to the given keys from the write
+// set of a collection and returns whether the write set was modified, along
+// with the number of the remaining writes
+func removeKeysFromCollPvtRwset(collPvtdata *rwset.CollectionPvtReadWriteSet, toPurge map[string]bool) (bool, int, error) {
+	kvRwset := &kvrwset.KVRWSet{}
+	if err := proto.Unmarshal(collPvtdata.Rwset, kvRwset); err != nil {
+		return false, 0, err
+	}
+	var remainingWrites []*kvrwset.KVWrite
+	for _, kvWrite := range kvRwset.Writes {
+		if !toPurge[kvWrite.Key] {
+			remainingWrites = append(remainingWrites, kvWrite)
+		}
+	}
+	if len(remainingWrites) == len(kvRwset.Writes) {
+		return false, len(remainingWrites), nil
+	}
+	kvRwset.Writes = remainingWrites
+	rwsetBytes, err := proto.Marshal(kvRwset)
+	if err != nil {
+		return false, 0, err
+	}
+	collPvtdata.Rwset = rwsetBytes
+	return true, len(remainingWrites), nil
+}
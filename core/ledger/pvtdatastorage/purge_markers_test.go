@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgePrivateDataKeys(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgePrivateDataKeys", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	assert.NoError(store.Commit(0, nil, nil))
+	// block 1 writes key1 and key2 to coll-1 and key1 to coll-2
+	assert.NoError(store.Commit(1, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 0, "ns-1", map[string][]string{
+			"coll-1": {"key1", "key2"},
+			"coll-2": {"key1"},
+		}),
+	}, nil))
+	// block 2 writes a newer version of key1 along with key3 to coll-1
+	assert.NoError(store.Commit(2, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 1, "ns-1", map[string][]string{
+			"coll-1": {"key1", "key3"},
+		}),
+	}, nil))
+
+	assert.NoError(store.PurgePrivateDataKeys("ns-1", "coll-1", []string{"key1"}))
+
+	// all the committed versions of key1 in coll-1 should have been removed
+	// while key2, key3, and the coll-2 data remain untouched
+	assert.Equal(map[string][]string{"coll-1": {"key2"}, "coll-2": {"key1"}},
+		retrieveWrittenKeys(t, store, 1, 0))
+	assert.Equal(map[string][]string{"coll-1": {"key3"}},
+		retrieveWrittenKeys(t, store, 2, 1))
+
+	// the purge markers should survive a store restart
+	env.CloseAndReopen()
+	store = env.TestStore
+	assert.Equal(map[string][]string{"coll-1": {"key2"}, "coll-2": {"key1"}},
+		retrieveWrittenKeys(t, store, 1, 0))
+
+	// pvtdata of old blocks arriving via reconciliation should get the writes
+	// to the purged key dropped for the blocks covered by the purge marker
+	reconciledPvtdata := map[uint64][]*ledger.TxPvtData{
+		1: {
+			producePvtdataWithKeys(t, 0, "ns-1", map[string][]string{
+				"coll-1": {"key1", "key2"},
+			}),
+		},
+		3: {
+			producePvtdataWithKeys(t, 0, "ns-1", map[string][]string{
+				"coll-1": {"key1"},
+			}),
+		},
+	}
+	filteredPvtdata, err := store.RemovePurgedKeys(reconciledPvtdata)
+	assert.NoError(err)
+	assert.Equal([]string{"key2"},
+		writtenKeysInTxPvtdata(t, filteredPvtdata[1][0], "ns-1", "coll-1"))
+	// block 3 is beyond the purge marker and hence its write to key1 is retained
+	assert.Equal([]string{"key1"},
+		writtenKeysInTxPvtdata(t, filteredPvtdata[3][0], "ns-1", "coll-1"))
+}
+
+func TestPurgeAllKeysOfEntry(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestPurgeAllKeysOfEntry", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	assert.NoError(store.Commit(0, nil, nil))
+	assert.NoError(store.Commit(1, []*ledger.TxPvtData{
+		producePvtdataWithKeys(t, 0, "ns-1", map[string][]string{
+			"coll-1": {"key1"},
+		}),
+	}, nil))
+
+	// purging the only key of a collection should remove the data entry altogether
+	assert.NoError(store.PurgePrivateDataKeys("ns-1", "coll-1", []string{"key1"}))
+	retrievedData, err := store.GetPvtDataByBlockNum(1, nil)
+	assert.NoError(err)
+	assert.Len(retrievedData, 0)
+}
+
+func producePvtdataWithKeys(t *testing.T, txNum uint64, ns string, collKeys map[string][]string) *ledger.TxPvtData {
+	builder := rwsetutil.NewRWSetBuilder()
+	for coll, keys := range collKeys {
+		for _, key := range keys {
+			builder.AddToPvtAndHashedWriteSet(ns, coll, key, []byte("value-"+key))
+		}
+	}
+	simRes, err := builder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	return &ledger.TxPvtData{SeqInBlock: txNum, WriteSet: simRes.PvtSimulationResults}
+}
+
+func retrieveWrittenKeys(t *testing.T, store Store, blkNum, txNum uint64) map[string][]string {
+	retrievedData, err := store.GetPvtDataByBlockNum(blkNum, nil)
+	assert.NoError(t, err)
+	writtenKeys := make(map[string][]string)
+	for _, txPvtdata := range retrievedData {
+		if txPvtdata.SeqInBlock != txNum {
+			continue
+		}
+		for _, nsPvtRwset := range txPvtdata.WriteSet.NsPvtRwset {
+			for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+				kvRwset := &kvrwset.KVRWSet{}
+				assert.NoError(t, proto.Unmarshal(collPvtRwset.Rwset, kvRwset))
+				for _, kvWrite := range kvRwset.Writes {
+					writtenKeys[collPvtRwset.CollectionName] = append(writtenKeys[collPvtRwset.CollectionName], kvWrite.Key)
+				}
+			}
+		}
+	}
+	return writtenKeys
+}
+
+func writtenKeysInTxPvtdata(t *testing.T, txPvtdata *ledger.TxPvtData, ns, coll string) []string {
+	var keys []string
+	for _, nsPvtRwset := range txPvtdata.WriteSet.NsPvtRwset {
+		if nsPvtRwset.Namespace != ns {
+			continue
+		}
+		for _, collPvtRwset := range nsPvtRwset.CollectionPvtRwset {
+			if collPvtRwset.CollectionName != coll {
+				continue
+			}
+			kvRwset := &kvrwset.KVRWSet{}
+			assert.NoError(t, proto.Unmarshal(collPvtRwset.Rwset, kvRwset))
+			for _, kvWrite := range kvRwset.Writes {
+				keys = append(keys, kvWrite.Key)
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// Rollback brings the pvtdata store in line with a rollback of the block store
+// to the given block number. This is expected to be invoked only when the peer
+// is offline, alongside the rollback of the block store.
+//
+// The pvtdata itself of the rolled back blocks is deliberately retained and the
+// store is left ahead of the block store - the pvtdata is not present in the
+// blocks and hence cannot be refetched from an orderer when the rolled back
+// blocks are committed again. Instead, the regular commit path reuses the
+// retained pvtdata for the blocks that are recommitted (see the handling of a
+// pvtdata store that is ahead of the block store in the ledgerstorage package).
+// Only the bookkeeping entries that would be reconstructed during the recommits
+// - the missing data entries and the collection eligibility tasks beyond the
+// target block - are removed
+func Rollback(pvtdataStorePath, ledgerID string, targetBlockNum uint64) error {
+	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: pvtdataStorePath})
+	if err != nil {
+		return err
+	}
+	defer dbProvider.Close()
+
+	db := dbProvider.GetDBHandle(ledgerID)
+	batch, err := constructRollbackBatch(db, targetBlockNum)
+	if err != nil {
+		return err
+	}
+	if err := db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+	return verifyRollbackResult(db, targetBlockNum)
+}
+
+// constructRollbackBatch scans the missing data entries and the collection
+// eligibility entries maintained for the given ledger and collects deletions
+// for the entries that are associated with a block number beyond the target
+// block number. As the rollback is a rare offline operation, a full scan of
+// these entries is preferred over maintaining additional reverse indexes for
+// the regular commit path
+func constructRollbackBatch(db *leveldbhelper.DBHandle, targetBlockNum uint64) (*leveldbhelper.UpdateBatch, error) {
+	batch := leveldbhelper.NewUpdateBatch()
+	for _, prefix := range [][]byte{eligibleMissingDataKeyPrefix, ineligibleMissingDataKeyPrefix, collElgKeyPrefix} {
+		itr := db.GetIterator(prefix, []byte{prefix[0] + 1})
+		for itr.Next() {
+			keyBytes := append([]byte{}, itr.Key()...)
+			var blkNum uint64
+			if keyBytes[0] == collElgKeyPrefix[0] {
+				blkNum = decodeCollElgKey(keyBytes)
+			} else {
+				blkNum = decodeMissingDataKey(keyBytes).blkNum
+			}
+			if blkNum > targetBlockNum {
+				batch.Delete(keyBytes)
+			}
+		}
+		err := itr.Error()
+		itr.Release()
+		if err != nil {
+			return nil, errors.Wrap(err, "error while iterating over the pvtdata store")
+		}
+	}
+	// the last updated old blocks list refers to blocks that may no longer exist
+	// in the block store after the rollback and hence is dropped
+	batch.Delete(lastUpdatedOldBlocksKey)
+	return batch, nil
+}
+
+// verifyRollbackResult checks that the store does not report a last committed
+// block behind the target block - i.e., that the pvtdata of the blocks up to
+// the target block is guaranteed to be present
+func verifyRollbackResult(db *leveldbhelper.DBHandle, targetBlockNum uint64) error {
+	v, err := db.Get(lastCommittedBlkkey)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		// an empty store has no entries to verify
+		return nil
+	}
+	if lastCommittedBlock := decodeLastCommittedBlockVal(v); lastCommittedBlock < targetBlockNum {
+		return errors.Errorf("pvtdata store is not consistent after rollback: last committed block [%d] is behind the target block [%d]",
+			lastCommittedBlock, targetBlockNum)
+	}
+	return nil
+}
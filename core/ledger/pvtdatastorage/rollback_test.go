@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	btltestutil "github.com/hyperledger/fabric/core/ledger/pvtdatapolicy/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+			{"ns-2", "coll-1"}: 0,
+			{"ns-2", "coll-2"}: 0,
+		},
+	)
+
+	env := NewTestStoreEnv(t, "TestRollback", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	assert := assert.New(t)
+	store := env.TestStore
+
+	// eligible and ineligible missing data for block 3 - should be removed by the rollback
+	blk3MissingData := make(ledger.TxMissingPvtDataMap)
+	blk3MissingData.Add(1, "ns-1", "coll-1", true)
+	blk3MissingData.Add(2, "ns-2", "coll-1", false)
+
+	assert.NoError(store.Commit(0, nil, nil))
+	assert.NoError(store.Commit(1, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-2:coll-2"}),
+	}, nil))
+	assert.NoError(store.Commit(2, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 1, []string{"ns-1:coll-2"}),
+	}, nil))
+	assert.NoError(store.Commit(3, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 3, []string{"ns-2:coll-1", "ns-2:coll-2"}),
+	}, blk3MissingData))
+	assert.NoError(store.Commit(4, []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1"}),
+	}, nil))
+
+	// the store must be closed while the offline rollback is performed
+	env.TestStoreProvider.Close()
+	assert.NoError(Rollback(env.conf.StorePath, env.ledgerid, 2))
+
+	var err error
+	env.TestStoreProvider, err = NewProvider(env.conf)
+	assert.NoError(err)
+	env.TestStore, err = env.TestStoreProvider.OpenStore(env.ledgerid)
+	assert.NoError(err)
+	env.TestStore.Init(btlPolicy)
+	store = env.TestStore
+
+	// the pvtdata of the rolled back blocks should have been retained so that it
+	// can be reused when the blocks are committed again
+	ht, err := store.LastCommittedBlockHeight()
+	assert.NoError(err)
+	assert.Equal(uint64(5), ht)
+	for blkNum, expectedNumTx := range map[uint64]int{1: 1, 2: 1, 3: 1, 4: 1} {
+		retrievedData, err := store.GetPvtDataByBlockNum(blkNum, nil)
+		assert.NoError(err)
+		assert.Len(retrievedData, expectedNumTx)
+	}
+
+	// the missing data entries of the rolled back blocks should have been removed
+	missingPvtDataInfo, err := store.GetMissingPvtDataInfoForMostRecentBlocks(10)
+	assert.NoError(err)
+	assert.Len(missingPvtDataInfo, 0)
+}
+
+func TestRollbackEmptyStore(t *testing.T) {
+	env := NewTestStoreEnv(t, "TestRollbackEmptyStore", nil, pvtDataConf())
+	defer env.Cleanup()
+	env.TestStoreProvider.Close()
+	// a rollback on a store that has never committed pvtdata should succeed
+	assert.NoError(t, Rollback(env.conf.StorePath, env.ledgerid, 2))
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package pvtdatastorage
 
 import (
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 )
@@ -57,6 +58,16 @@ type Store interface {
 	CommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error
 	// GetLastUpdatedOldBlocksPvtData returns the pvtdata of blocks listed in `lastUpdatedOldBlocksList`
 	GetLastUpdatedOldBlocksPvtData() (map[uint64][]*ledger.TxPvtData, error)
+	// PurgePrivateDataKeys removes the writes to the given keys of a collection from the
+	// private write sets of all the committed blocks and records purge markers for the keys.
+	// The purge markers prevent the purged data from being reintroduced into the store via
+	// `CommitPvtDataOfOldBlocks` - the consumer is expected to pass the pvtdata of old blocks
+	// through `RemovePurgedKeys` before committing it to this store or to the state database
+	PurgePrivateDataKeys(ns, coll string, keys []string) error
+	// RemovePurgedKeys drops the writes to purged keys from the given pvtdata of old blocks.
+	// Only the key versions committed up to the block at which the corresponding purge was
+	// performed are dropped - writes from the later blocks are retained
+	RemovePurgedKeys(blocksPvtData map[uint64][]*ledger.TxPvtData) (map[uint64][]*ledger.TxPvtData, error)
 	// ResetLastUpdatedOldBlocksList removes the `lastUpdatedOldBlocksList` entry from the store
 	ResetLastUpdatedOldBlocksList() error
 	// LastCommittedBlockHeight returns the height of the last committed block
@@ -73,6 +84,9 @@ type PrivateDataConfig struct {
 	// It is internally computed by the ledger component,
 	// so it is not in ledger.PrivateDataConfig and not exposed to other components.
 	StorePath string
+	// Encryptor, when set, transparently encrypts the private data store at rest.
+	// Like StorePath, it is internally computed by the ledger component.
+	Encryptor *leveldbhelper.ValueEncryptor
 }
 
 // ErrIllegalCall is to be thrown by a store impl if the store does not expect a call to Prepare/Commit/Rollback/InitLastCommittedBlock
@@ -40,6 +40,11 @@ type store struct {
 	lastCommittedBlock uint64
 	purgerLock         sync.Mutex
 	collElgProcSync    *collElgProcSync
+	// purgeMarkers is an in-memory index of the purge markers recorded for the
+	// keys purged via PurgePrivateDataKeys. The markers are consulted for
+	// filtering the pvtdata of old blocks that is committed via reconciliation
+	purgeMarkers     map[nsCollKey]uint64
+	purgeMarkersLock sync.RWMutex
 	// After committing the pvtdata of old blocks,
 	// the `isLastUpdatedOldBlocksSet` is set to true.
 	// Once the stateDB is updated with these pvtdata,
@@ -108,7 +113,7 @@ type entriesForPvtDataOfOldBlocks struct {
 
 // NewProvider instantiates a StoreProvider
 func NewProvider(conf *PrivateDataConfig) (Provider, error) {
-	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.StorePath})
+	dbProvider, err := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.StorePath, Encryptor: conf.Encryptor})
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +188,7 @@ func (s *store) initState() error {
 		s.isLastUpdatedOldBlocksSet = true
 	} // false if not set
 
-	return nil
+	return s.loadPurgeMarkers()
 }
 
 func (s *store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
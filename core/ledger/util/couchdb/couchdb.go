@@ -10,6 +10,8 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -146,10 +148,65 @@ type Config struct {
 	// RedoLogPath is the directory where the CouchDB redo log files are stored.
 	RedoLogPath string
 	// UserCacheSizeMBs denotes the user specified maximum mega bytes (MB) to be allocated
-	// for the user state cache (i.e., all chaincodes deployed by the user). Note that
-	// UserCacheSizeMBs needs to be a multiple of 32 MB. If it is not a multiple of 32 MB,
-	// the peer would round the size to the next multiple of 32 MB.
+	// for the user state cache (i.e., all chaincodes deployed by the user) of each
+	// channel. The cache of an individual channel can be resized at runtime via the
+	// operations server.
 	UserCacheSizeMBs int
+	// TLS holds the settings for connecting to CouchDB over HTTPS,
+	// optionally authenticating with a client certificate.
+	TLS TLSConfig
+}
+
+// TLSConfig is a structure used to configure the TLS connection to CouchDB.
+// When a client certificate and key are supplied, CouchDB may authenticate
+// the peer by certificate instead of (or in addition to) username/password.
+type TLSConfig struct {
+	// Enabled determines whether to connect to CouchDB over HTTPS.
+	Enabled bool
+	// ClientCertFile is the path to the PEM-encoded certificate presented to
+	// CouchDB for client authentication. Optional.
+	ClientCertFile string
+	// ClientKeyFile is the path to the PEM-encoded private key for ClientCertFile.
+	ClientKeyFile string
+	// RootCACertFiles are the paths to the PEM-encoded CA certificates trusted
+	// for verifying the CouchDB server certificate. If empty, the host's root
+	// CA set is used.
+	RootCACertFiles []string
+}
+
+// tlsClientConfig builds the tls.Config used by the http client from the
+// certificate and CA files referenced by the TLSConfig
+func (config *TLSConfig) tlsClientConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if config.ClientCertFile != "" || config.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCertFile, config.ClientKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "error loading CouchDB client TLS certificate and key")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if len(config.RootCACertFiles) > 0 {
+		certPool := x509.NewCertPool()
+		for _, caFile := range config.RootCACertFiles {
+			caPEM, err := ioutil.ReadFile(caFile)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error reading CouchDB root CA certificate file [%s]", caFile)
+			}
+			if !certPool.AppendCertsFromPEM(caPEM) {
+				return nil, errors.Errorf("no certificates found in CouchDB root CA certificate file [%s]", caFile)
+			}
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	return tlsConfig, nil
+}
+
+// scheme returns the URL scheme to be used for connecting to CouchDB
+func (config *Config) scheme() string {
+	if config.TLS.Enabled {
+		return "https"
+	}
+	return "http"
 }
 
 //CouchInstance represents a CouchDB instance
@@ -530,7 +587,7 @@ func (couchInstance *CouchInstance) MaxBatchUpdateSize() int {
 func (couchInstance *CouchInstance) URL() string {
 	URL := &url.URL{
 		Host:   couchInstance.conf.Address,
-		Scheme: "http",
+		Scheme: couchInstance.conf.scheme(),
 	}
 	return URL.String()
 }
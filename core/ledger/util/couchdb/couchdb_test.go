@@ -10,14 +10,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 	"unicode/utf8"
 
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdbtest"
@@ -1680,3 +1683,61 @@ func TestURLWithSpecialCharacters(t *testing.T) {
 	assert.Equal(t, database, dbInfo.DbName)
 
 }
+
+func TestTLSClientConfig(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	clientPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "couchdbtls")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	caFile := filepath.Join(tempDir, "ca.pem")
+	certFile := filepath.Join(tempDir, "client.pem")
+	keyFile := filepath.Join(tempDir, "client.key")
+	require.NoError(t, ioutil.WriteFile(caFile, ca.CertBytes(), 0644))
+	require.NoError(t, ioutil.WriteFile(certFile, clientPair.Cert, 0644))
+	require.NoError(t, ioutil.WriteFile(keyFile, clientPair.Key, 0600))
+
+	t.Run("client-cert-and-root-cas", func(t *testing.T) {
+		tlsConf := &TLSConfig{
+			Enabled:         true,
+			ClientCertFile:  certFile,
+			ClientKeyFile:   keyFile,
+			RootCACertFiles: []string{caFile},
+		}
+		clientConfig, err := tlsConf.tlsClientConfig()
+		assert.NoError(t, err)
+		assert.Len(t, clientConfig.Certificates, 1)
+		assert.NotNil(t, clientConfig.RootCAs)
+	})
+
+	t.Run("no-client-cert", func(t *testing.T) {
+		tlsConf := &TLSConfig{Enabled: true}
+		clientConfig, err := tlsConf.tlsClientConfig()
+		assert.NoError(t, err)
+		assert.Empty(t, clientConfig.Certificates)
+		assert.Nil(t, clientConfig.RootCAs)
+	})
+
+	t.Run("missing-client-key", func(t *testing.T) {
+		tlsConf := &TLSConfig{Enabled: true, ClientCertFile: certFile}
+		_, err := tlsConf.tlsClientConfig()
+		assert.Contains(t, err.Error(), "error loading CouchDB client TLS certificate and key")
+	})
+
+	t.Run("bad-root-ca-file", func(t *testing.T) {
+		tlsConf := &TLSConfig{Enabled: true, RootCACertFiles: []string{keyFile}}
+		_, err := tlsConf.tlsClientConfig()
+		assert.Contains(t, err.Error(), "no certificates found in CouchDB root CA certificate file")
+	})
+}
+
+func TestConfigScheme(t *testing.T) {
+	conf := &Config{Address: "127.0.0.1:5984"}
+	assert.Equal(t, "http", conf.scheme())
+	conf.TLS.Enabled = true
+	assert.Equal(t, "https", conf.scheme())
+}
@@ -38,7 +38,7 @@ func CreateCouchInstance(config *Config, metricsProvider metrics.Provider) (*Cou
 	// make sure the address is valid
 	connectURL := &url.URL{
 		Host:   config.Address,
-		Scheme: "http",
+		Scheme: config.scheme(),
 	}
 	_, err := url.Parse(connectURL.String())
 	if err != nil {
@@ -69,6 +69,14 @@ func CreateCouchInstance(config *Config, metricsProvider metrics.Provider) (*Cou
 		ExpectContinueTimeout: 1 * time.Second,
 	}
 
+	if config.TLS.Enabled {
+		tlsClientConfig, err := config.TLS.tlsClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsClientConfig
+	}
+
 	client.Transport = transport
 
 	//Create the CouchDB instance
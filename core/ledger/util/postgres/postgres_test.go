@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDriver registers under a test-local name so that ensureDriver can be
+// exercised without a real PostgreSQL driver linked in.
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	return nil, nil
+}
+
+func TestEnsureDriver(t *testing.T) {
+	sql.Register("stub-postgres", stubDriver{})
+
+	// a driver linked into the binary needs no plugin
+	assert.NoError(t, ensureDriver("stub-postgres", ""))
+	assert.NoError(t, ensureDriver("stub-postgres", "ignored.so"))
+
+	// an unknown driver without a plugin yields a descriptive error
+	err := ensureDriver("no-such-driver", "")
+	assert.EqualError(t, err, "database/sql driver no-such-driver is not linked into the binary and no driver plugin is configured")
+
+	// a plugin that cannot be loaded surfaces the loading failure
+	err = ensureDriver("no-such-driver", "testdata/no-such-plugin.so")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load driver plugin testdata/no-such-plugin.so")
+}
@@ -17,6 +17,7 @@ import (
 
 	kitstatsd "github.com/go-kit/kit/metrics/statsd"
 	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/flogging/httpadmin"
 	"github.com/hyperledger/fabric/common/metadata"
@@ -144,6 +145,13 @@ func (s *System) RegisterChecker(component string, checker healthz.HealthChecker
 	return s.healthHandler.RegisterChecker(component, checker)
 }
 
+// RegisterHandler registers the handler to serve the pattern. When secure is
+// true, the handler requires a client certificate, like the built-in
+// endpoints that mutate state.
+func (s *System) RegisterHandler(pattern string, handler http.Handler, secure bool) {
+	s.mux.Handle(pattern, s.handlerChain(handler, secure))
+}
+
 func (s *System) initializeServer() {
 	s.mux = http.NewServeMux()
 	s.httpServer = &http.Server{
@@ -207,6 +215,7 @@ func (s *System) initializeVersionInfoHandler() {
 	versionInfo := &VersionInfoHandler{
 		CommitSHA: metadata.CommitSHA,
 		Version:   metadata.Version,
+		FIPS:      fips.Enabled(),
 	}
 	s.mux.Handle("/version", s.handlerChain(versionInfo, false))
 }
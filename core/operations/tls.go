@@ -11,6 +11,7 @@ import (
 	"crypto/x509"
 	"io/ioutil"
 
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/hyperledger/fabric/core/comm"
 )
 
@@ -43,6 +44,11 @@ func (t TLS) Config() (*tls.Config, error) {
 			CipherSuites: comm.DefaultTLSCipherSuites,
 			ClientCAs:    caCertPool,
 		}
+		if fips.Enabled() {
+			tlsConfig.MinVersion = tls.VersionTLS12
+			tlsConfig.CipherSuites = fips.TLSCipherSuites()
+			tlsConfig.CurvePreferences = fips.CurvePreferences()
+		}
 		if t.ClientCertRequired {
 			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
 		} else {
@@ -17,6 +17,7 @@ import (
 type VersionInfoHandler struct {
 	CommitSHA string `json:"CommitSHA,omitempty"`
 	Version   string `json:"Version,omitempty"`
+	FIPS      bool   `json:"FIPS,omitempty"`
 }
 
 func (m *VersionInfoHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
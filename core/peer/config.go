@@ -20,6 +20,7 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"crypto"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
@@ -28,6 +29,8 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/signer"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/pkg/errors"
@@ -157,6 +160,32 @@ type Config struct {
 	// builders in the order specified below.
 	ExternalBuilders []ExternalBuilder
 
+	// ----- chaincode.wasm -----
+
+	// WasmRuntimeEnabled enables the WebAssembly chaincode runtime. When
+	// enabled, chaincode packages of type "wasm" are executed directly on the
+	// peer by the configured WebAssembly engine instead of a Docker container.
+	WasmRuntimeEnabled bool
+	// WasmEnginePath is the path of the WebAssembly engine binary used to run
+	// wasm chaincode.
+	WasmEnginePath string
+	// WasmMaxMemoryPages is the maximum number of 64KiB linear memory pages a
+	// wasm chaincode module may allocate.
+	WasmMaxMemoryPages uint64
+	// WasmMaxInstructions is the maximum number of instructions a wasm
+	// chaincode module may execute.
+	WasmMaxInstructions uint64
+
+	// ----- chaincode.kubernetes -----
+
+	// KubeChaincodeEnabled enables launching chaincode as a service on the
+	// Kubernetes cluster the peer runs in. Chaincode packages of type "k8s"
+	// are deployed as pods instead of Docker containers.
+	KubeChaincodeEnabled bool
+	// KubeChaincodeNamespace overrides the namespace the chaincode resources
+	// are created in. When empty, the namespace of the peer pod is used.
+	KubeChaincodeNamespace string
+
 	// ----- Operations config -----
 	// TODO: create separate sub-struct for Operations config.
 
@@ -202,6 +231,29 @@ type Config struct {
 	DockerKey string
 	// DockerCA is the path to the PEM encoded CA certificate for the docker daemon.
 	DockerCA string
+
+	// ----- Renewal config -----
+
+	// RenewalEnabled enables the agent that re-enrolls the peer's
+	// certificates against a Fabric CA before they expire.
+	RenewalEnabled bool
+	// RenewalCAURL is the base URL of the Fabric CA used for re-enrollment.
+	RenewalCAURL string
+	// RenewalCAName is the name of the CA within the Fabric CA server.
+	RenewalCAName string
+	// RenewalCATLSRootCertFile is the path to the PEM encoded TLS root
+	// certificate of the Fabric CA.
+	RenewalCATLSRootCertFile string
+	// RenewalEnrollmentID is the enrollment ID used to authenticate against
+	// the Fabric CA.
+	RenewalEnrollmentID string
+	// RenewalEnrollmentSecret is the enrollment secret used to authenticate
+	// against the Fabric CA.
+	RenewalEnrollmentSecret string
+	// RenewalWindow is how long before expiration a certificate is renewed.
+	RenewalWindow time.Duration
+	// RenewalCheckInterval is how often certificates are checked for renewal.
+	RenewalCheckInterval time.Duration
 }
 
 // GlobalConfig obtains a set of configuration from viper, build and returns
@@ -286,6 +338,25 @@ func (c *Config) load() error {
 	}
 	c.ExternalBuilders = externalBuilders
 
+	c.WasmRuntimeEnabled = viper.GetBool("chaincode.wasm.enabled")
+	if c.WasmRuntimeEnabled {
+		c.WasmEnginePath = config.GetPath("chaincode.wasm.enginePath")
+		if c.WasmEnginePath == "" {
+			return fmt.Errorf("wasm runtime is enabled but chaincode.wasm.enginePath is not set")
+		}
+		c.WasmMaxMemoryPages = uint64(viper.GetInt("chaincode.wasm.maxMemoryPages"))
+		if c.WasmMaxMemoryPages == 0 {
+			c.WasmMaxMemoryPages = 1024 // 64MiB
+		}
+		c.WasmMaxInstructions = uint64(viper.GetInt("chaincode.wasm.maxInstructions"))
+		if c.WasmMaxInstructions == 0 {
+			c.WasmMaxInstructions = 100000000
+		}
+	}
+
+	c.KubeChaincodeEnabled = viper.GetBool("chaincode.kubernetes.enabled")
+	c.KubeChaincodeNamespace = viper.GetString("chaincode.kubernetes.namespace")
+
 	c.OperationsListenAddress = viper.GetString("operations.listenAddress")
 	c.OperationsTLSEnabled = viper.GetBool("operations.tls.enabled")
 	c.OperationsTLSCertFile = config.GetPath("operations.tls.cert.file")
@@ -306,6 +377,15 @@ func (c *Config) load() error {
 	c.DockerKey = config.GetPath("vm.docker.tls.key.file")
 	c.DockerCA = config.GetPath("vm.docker.tls.ca.file")
 
+	c.RenewalEnabled = viper.GetBool("peer.renewal.enabled")
+	c.RenewalCAURL = viper.GetString("peer.renewal.caURL")
+	c.RenewalCAName = viper.GetString("peer.renewal.caName")
+	c.RenewalCATLSRootCertFile = config.GetPath("peer.renewal.caTLSRootCert.file")
+	c.RenewalEnrollmentID = viper.GetString("peer.renewal.enrollmentID")
+	c.RenewalEnrollmentSecret = viper.GetString("peer.renewal.enrollmentSecret")
+	c.RenewalWindow = viper.GetDuration("peer.renewal.window")
+	c.RenewalCheckInterval = viper.GetDuration("peer.renewal.checkInterval")
+
 	return nil
 }
 
@@ -352,17 +432,27 @@ func GetServerConfig() (comm.ServerConfig, error) {
 		},
 	}
 	if serverConfig.SecOpts.UseTLS {
-		// get the certs from the file system
-		serverKey, err := ioutil.ReadFile(config.GetPath("peer.tls.key.file"))
-		if err != nil {
-			return serverConfig, fmt.Errorf("error loading TLS key (%s)", err)
+		if ski := viper.GetString("peer.tls.key.ski"); ski != "" {
+			// the key lives in the default BCCSP (e.g. an HSM) and is
+			// referenced by SKI rather than read from the file system
+			signer, err := tlsSignerForSKI(ski)
+			if err != nil {
+				return serverConfig, err
+			}
+			serverConfig.SecOpts.Signer = signer
+		} else {
+			serverKey, err := ioutil.ReadFile(config.GetPath("peer.tls.key.file"))
+			if err != nil {
+				return serverConfig, fmt.Errorf("error loading TLS key (%s)", err)
+			}
+			serverConfig.SecOpts.Key = serverKey
 		}
+		// get the cert from the file system
 		serverCert, err := ioutil.ReadFile(config.GetPath("peer.tls.cert.file"))
 		if err != nil {
 			return serverConfig, fmt.Errorf("error loading TLS certificate (%s)", err)
 		}
 		serverConfig.SecOpts.Certificate = serverCert
-		serverConfig.SecOpts.Key = serverKey
 		serverConfig.SecOpts.RequireClientCert = viper.GetBool("peer.tls.clientAuthRequired")
 		if serverConfig.SecOpts.RequireClientCert {
 			var clientRoots [][]byte
@@ -408,6 +498,21 @@ func GetServerConfig() (comm.ServerConfig, error) {
 func GetClientCertificate() (tls.Certificate, error) {
 	cert := tls.Certificate{}
 
+	// a client key held by the default BCCSP (e.g. an HSM) is referenced
+	// by SKI rather than read from the file system
+	if ski := viper.GetString("peer.tls.clientKey.ski"); ski != "" {
+		clientCert, err := ioutil.ReadFile(config.GetPath("peer.tls.clientCert.file"))
+		if err != nil {
+			return cert, errors.WithMessage(err,
+				"error loading client TLS certificate")
+		}
+		signer, err := tlsSignerForSKI(ski)
+		if err != nil {
+			return cert, err
+		}
+		return comm.TLSCertificateWithSigner(clientCert, signer)
+	}
+
 	keyPath := viper.GetString("peer.tls.clientKey.file")
 	certPath := viper.GetString("peer.tls.clientCert.file")
 
@@ -458,3 +563,14 @@ func GetClientCertificate() (tls.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// tlsSignerForSKI resolves a TLS private key held by the default BCCSP,
+// typically an HSM reached through PKCS11, into a crypto.Signer usable
+// for TLS handshakes
+func tlsSignerForSKI(ski string) (crypto.Signer, error) {
+	tlsSigner, err := signer.NewFromSKI(factory.GetDefault(), ski)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error resolving TLS key with SKI [%s]", ski)
+	}
+	return tlsSigner, nil
+}
@@ -280,6 +280,12 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("metrics.statsd.prefix", "testPrefix")
 
 	viper.Set("chaincode.pull", false)
+	viper.Set("chaincode.wasm.enabled", true)
+	viper.Set("chaincode.wasm.enginePath", "/usr/local/bin/wasm-engine")
+	viper.Set("chaincode.wasm.maxMemoryPages", 16)
+	viper.Set("chaincode.wasm.maxInstructions", 1000)
+	viper.Set("chaincode.kubernetes.enabled", true)
+	viper.Set("chaincode.kubernetes.namespace", "fabric-chaincode")
 	viper.Set("chaincode.externalBuilders", &[]ExternalBuilder{
 		{
 			Path: "relative/plugin_dir",
@@ -332,6 +338,14 @@ func TestGlobalConfig(t *testing.T) {
 				Name: "absolute",
 			},
 		},
+		WasmRuntimeEnabled:  true,
+		WasmEnginePath:      "/usr/local/bin/wasm-engine",
+		WasmMaxMemoryPages:  16,
+		WasmMaxInstructions: 1000,
+
+		KubeChaincodeEnabled:   true,
+		KubeChaincodeNamespace: "fabric-chaincode",
+
 		OperationsListenAddress:         "127.0.0.1:9443",
 		OperationsTLSEnabled:            false,
 		OperationsTLSCertFile:           filepath.Join(cwd, "test/tls/cert/file"),
@@ -374,6 +388,14 @@ func TestGlobalConfigDefault(t *testing.T) {
 	assert.Equal(t, expectedConfig, coreConfig)
 }
 
+func TestMissingWasmEnginePath(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("chaincode.wasm.enabled", true)
+	_, err := GlobalConfig()
+	assert.EqualError(t, err, "wasm runtime is enabled but chaincode.wasm.enginePath is not set")
+}
+
 func TestMissingExternalBuilderPath(t *testing.T) {
 	defer viper.Reset()
 	viper.Set("peer.address", "localhost:8080")
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/merkle"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// merkleProofNode is one step of an inclusion proof - the hex encoded hash of
+// the sibling and whether the sibling is the left operand of the concatenation
+type merkleProofNode struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"`
+}
+
+// transactionProof is the JSON document returned by GetTransactionProof. The
+// proof demonstrates the inclusion of a transaction in a block: the leaves of
+// the merkle tree are the SHA256 hashes of the serialized transaction
+// envelopes of the block, in order, and the path reproduces the merkle root
+// from the transaction's hash. The root is a commitment over the block's
+// transactions computed by the peer and vouched for by the peer's endorsement
+// of this query response - clients may cross-check it against other peers
+type transactionProof struct {
+	ChannelID   string             `json:"channelID"`
+	TxID        string             `json:"txID"`
+	BlockNumber uint64             `json:"blockNumber"`
+	TxIndex     int                `json:"txIndex"`
+	TxHash      string             `json:"txHash"`
+	MerkleRoot  string             `json:"merkleRoot"`
+	Path        []*merkleProofNode `json:"path"`
+}
+
+// stateProof is the JSON document returned by GetStateProof. It carries the
+// current committed value of a key together with the inclusion proof of the
+// transaction that last wrote the key
+type stateProof struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Value     []byte `json:"value,omitempty"`
+	IsDelete  bool   `json:"isDelete,omitempty"`
+	transactionProof
+}
+
+// getTransactionProof produces the merkle inclusion proof for the transaction
+// with the given ID within its block
+func getTransactionProof(vledger ledger.PeerLedger, cid string, rawTxID []byte) pb.Response {
+	txID := string(rawTxID)
+	if txID == "" {
+		return shim.Error("Transaction ID must not be empty.")
+	}
+	block, err := vledger.GetBlockByTxID(txID)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block for txID %s, error %s", txID, err))
+	}
+	proof, err := buildTransactionProof(cid, block, txID)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to build the proof for txID %s, error %s", txID, err))
+	}
+	bytes, err := json.Marshal(proof)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
+
+// getStateProof produces the current committed value of a key along with the
+// merkle inclusion proof of the transaction that last wrote the key. The
+// history database must be enabled on the peer for locating that transaction
+func getStateProof(vledger ledger.PeerLedger, cid string, namespace []byte, key []byte) pb.Response {
+	if len(namespace) == 0 || len(key) == 0 {
+		return shim.Error("Namespace and key must not be empty.")
+	}
+	qe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+	if qe == nil {
+		return shim.Error("State proofs are not supported because the history database is disabled on this peer.")
+	}
+	itr, err := qe.GetHistoryForKey(string(namespace), string(key))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for key %s, error %s", string(key), err))
+	}
+	defer itr.Close()
+
+	// the modification of the key with the greatest height is the committed state
+	var lastModification *queryresult.KeyModification
+	for {
+		queryResult, err := itr.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to iterate over the history of key %s, error %s", string(key), err))
+		}
+		if queryResult == nil {
+			break
+		}
+		lastModification = queryResult.(*queryresult.KeyModification)
+	}
+	if lastModification == nil {
+		return shim.Error(fmt.Sprintf("No committed value found for key %s in namespace %s.", string(key), string(namespace)))
+	}
+
+	block, err := vledger.GetBlockByTxID(lastModification.TxId)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block for txID %s, error %s", lastModification.TxId, err))
+	}
+	txProof, err := buildTransactionProof(cid, block, lastModification.TxId)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to build the proof for txID %s, error %s", lastModification.TxId, err))
+	}
+	proof := &stateProof{
+		Namespace:        string(namespace),
+		Key:              string(key),
+		Value:            lastModification.Value,
+		IsDelete:         lastModification.IsDelete,
+		transactionProof: *txProof,
+	}
+	bytes, err := json.Marshal(proof)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
+
+// buildTransactionProof computes the merkle tree over the hashes of the
+// transactions of the block and generates the inclusion path for the
+// transaction with the given ID
+func buildTransactionProof(cid string, block *common.Block, txID string) (*transactionProof, error) {
+	leaves := make([][]byte, len(block.Data.Data))
+	txIndex := -1
+	for i, envBytes := range block.Data.Data {
+		leaves[i] = util.ComputeSHA256(envBytes)
+		env, err := protoutil.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, err
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if chdr.TxId == txID {
+			txIndex = i
+		}
+	}
+	if txIndex == -1 {
+		return nil, errors.Errorf("transaction [%s] not found in block [%d]", txID, block.Header.Number)
+	}
+	path, err := merkle.GenerateProof(leaves, txIndex)
+	if err != nil {
+		return nil, err
+	}
+	proof := &transactionProof{
+		ChannelID:   cid,
+		TxID:        txID,
+		BlockNumber: block.Header.Number,
+		TxIndex:     txIndex,
+		TxHash:      hex.EncodeToString(leaves[txIndex]),
+		MerkleRoot:  hex.EncodeToString(merkle.ComputeRoot(leaves)),
+	}
+	for _, node := range path {
+		proof.Path = append(proof.Path, &merkleProofNode{
+			Hash: hex.EncodeToString(node.Hash),
+			Left: node.Left,
+		})
+	}
+	return proof, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/merkle"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// txIDAtIndex extracts the transaction ID of the envelope at the given index
+func txIDAtIndex(t *testing.T, block *common.Block, index int) string {
+	env, err := protoutil.GetEnvelopeFromBlock(block.Data.Data[index])
+	require.NoError(t, err)
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	return chdr.TxId
+}
+
+// verifyProofPath checks that the path of the proof reproduces its merkle root
+func verifyProofPath(t *testing.T, proof *transactionProof) {
+	leaf, err := hex.DecodeString(proof.TxHash)
+	require.NoError(t, err)
+	root, err := hex.DecodeString(proof.MerkleRoot)
+	require.NoError(t, err)
+	var path []*merkle.ProofNode
+	for _, node := range proof.Path {
+		hash, err := hex.DecodeString(node.Hash)
+		require.NoError(t, err)
+		path = append(path, &merkle.ProofNode{Hash: hash, Left: node.Left})
+	}
+	assert.True(t, merkle.VerifyProof(leaf, path, root))
+}
+
+func TestQueryGetTransactionProof(t *testing.T) {
+	chainid := "mytestchainid11"
+	path := tempDir(t, "test11")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+
+	// a proof is produced for each of the two transactions of block 1
+	for idx := 0; idx < len(block1.Data.Data); idx++ {
+		txid := txIDAtIndex(t, block1, idx)
+		args := [][]byte{[]byte(GetTransactionProof), []byte(chainid), []byte(txid)}
+		prop := resetProvider(resources.Qscc_GetTransactionProof, chainid, nil, nil)
+		res := stub.MockInvokeWithSignedProposal("1", args, prop)
+		assert.Equal(t, int32(shim.OK), res.Status, "GetTransactionProof failed with err: %s", res.Message)
+
+		proof := &transactionProof{}
+		assert.NoError(t, json.Unmarshal(res.Payload, proof))
+		assert.Equal(t, chainid, proof.ChannelID)
+		assert.Equal(t, txid, proof.TxID)
+		assert.Equal(t, uint64(1), proof.BlockNumber)
+		assert.Equal(t, idx, proof.TxIndex)
+		verifyProofPath(t, proof)
+	}
+
+	// an unknown transaction ID is rejected
+	args := [][]byte{[]byte(GetTransactionProof), []byte(chainid), []byte("nonexisting-txid")}
+	prop := resetProvider(resources.Qscc_GetTransactionProof, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionProof should have failed for an unknown txID")
+
+	// an empty transaction ID is rejected
+	args = [][]byte{[]byte(GetTransactionProof), []byte(chainid), []byte("")}
+	prop = resetProvider(resources.Qscc_GetTransactionProof, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetTransactionProof should have failed for an empty txID")
+}
+
+func TestQueryGetStateProof(t *testing.T) {
+	chainid := "mytestchainid12"
+	path := tempDir(t, "test12")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedgerWithHistoryDB(chainid, path, true)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	block1 := addBlockForTesting(t, chainid, p)
+
+	// ns1/key1 was last written by the first transaction of block 1
+	args := [][]byte{[]byte(GetStateProof), []byte(chainid), []byte("ns1"), []byte("key1")}
+	prop := resetProvider(resources.Qscc_GetStateProof, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetStateProof failed with err: %s", res.Message)
+
+	proof := &stateProof{}
+	assert.NoError(t, json.Unmarshal(res.Payload, proof))
+	assert.Equal(t, "ns1", proof.Namespace)
+	assert.Equal(t, "key1", proof.Key)
+	assert.Equal(t, []byte("value1"), proof.Value)
+	assert.False(t, proof.IsDelete)
+	assert.Equal(t, txIDAtIndex(t, block1, 0), proof.TxID)
+	assert.Equal(t, uint64(1), proof.BlockNumber)
+	verifyProofPath(t, &proof.transactionProof)
+
+	// a key that was never written is rejected
+	args = [][]byte{[]byte(GetStateProof), []byte(chainid), []byte("ns1"), []byte("nonexisting-key")}
+	prop = resetProvider(resources.Qscc_GetStateProof, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateProof should have failed for an unknown key")
+
+	// the key argument is required
+	args = [][]byte{[]byte(GetStateProof), []byte(chainid), []byte("ns1")}
+	prop = resetProvider(resources.Qscc_GetStateProof, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateProof should have failed due to missing key argument")
+}
+
+func TestQueryGetStateProofHistoryDBDisabled(t *testing.T) {
+	chainid := "mytestchainid13"
+	path := tempDir(t, "test13")
+	defer os.RemoveAll(path)
+
+	stub, _, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	args := [][]byte{[]byte(GetStateProof), []byte(chainid), []byte("ns1"), []byte("key1")}
+	prop := resetProvider(resources.Qscc_GetStateProof, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetStateProof should have failed with a disabled history database")
+}
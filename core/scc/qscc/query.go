@@ -7,13 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protoutil"
 )
@@ -23,12 +26,19 @@ type LedgerGetter interface {
 	GetLedger(cid string) ledger.PeerLedger
 }
 
+// CallTraceProvider supplies the cross-chaincode invocation records captured
+// for recently simulated transactions.
+type CallTraceProvider interface {
+	TransactionTrace(txID string) []*chaincode.CallRecord
+}
+
 // New returns an instance of QSCC.
 // Typically this is called once per peer.
-func New(aclProvider aclmgmt.ACLProvider, ledgers LedgerGetter) *LedgerQuerier {
+func New(aclProvider aclmgmt.ACLProvider, ledgers LedgerGetter, callTracer CallTraceProvider) *LedgerQuerier {
 	return &LedgerQuerier{
 		aclProvider: aclProvider,
 		ledgers:     ledgers,
+		callTracer:  callTracer,
 	}
 }
 
@@ -43,17 +53,23 @@ func (e *LedgerQuerier) Chaincode() shim.Chaincode { return e }
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 	ledgers     LedgerGetter
+	callTracer  CallTraceProvider
 }
 
 var qscclogger = flogging.MustGetLogger("qscc")
 
 // These are function names from Invoke first parameter
 const (
-	GetChainInfo       string = "GetChainInfo"
-	GetBlockByNumber   string = "GetBlockByNumber"
-	GetBlockByHash     string = "GetBlockByHash"
-	GetTransactionByID string = "GetTransactionByID"
-	GetBlockByTxID     string = "GetBlockByTxID"
+	GetChainInfo        string = "GetChainInfo"
+	GetBlockByNumber    string = "GetBlockByNumber"
+	GetBlockByHash      string = "GetBlockByHash"
+	GetTransactionByID  string = "GetTransactionByID"
+	GetBlockByTxID      string = "GetBlockByTxID"
+	GetHistoryForKey    string = "GetHistoryForKey"
+	GetTransactionProof string = "GetTransactionProof"
+	GetStateProof       string = "GetStateProof"
+	GetConflictStats    string = "GetConflictStats"
+	GetCallTrace        string = "GetCallTrace"
 )
 
 // Init is called once per chain when the chain is created.
@@ -72,6 +88,27 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetHistoryForKey: Return the history of the key in args[3] within the namespace
+//
+//	in args[2], optionally restricted by the JSON query options in args[4]
+//
+// # GetTransactionProof: Return the merkle inclusion proof for the transaction
+//
+//	specified by ID in args[2] within its block
+//
+// # GetStateProof: Return the committed value of the key in args[3] within the
+//
+//	namespace in args[2] along with the inclusion proof of the transaction that
+//	last wrote the key
+//
+// # GetConflictStats: Return the statistics about the transactions invalidated by
+//
+//	MVCC read conflicts or phantom reads, restricted to the chaincode named in
+//	args[2] if non-empty
+//
+// # GetCallTrace: Return the cross-chaincode invocations recorded while the
+//
+//	transaction specified by ID in args[2] was simulated on this peer
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -124,6 +161,26 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetHistoryForKey:
+		if len(args) < 4 {
+			return shim.Error(fmt.Sprintf("missing key argument for %s", fname))
+		}
+		var rawOptions []byte
+		if len(args) > 4 {
+			rawOptions = args[4]
+		}
+		return getHistoryForKey(targetLedger, args[2], args[3], rawOptions)
+	case GetTransactionProof:
+		return getTransactionProof(targetLedger, cid, args[2])
+	case GetStateProof:
+		if len(args) < 4 {
+			return shim.Error(fmt.Sprintf("missing key argument for %s", fname))
+		}
+		return getStateProof(targetLedger, cid, args[2], args[3])
+	case GetConflictStats:
+		return getConflictStats(targetLedger, args[2])
+	case GetCallTrace:
+		return e.getCallTrace(args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -223,6 +280,134 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+// getHistoryForKey returns the history of the key in namespace as a QueryResponse
+// object marshalled in bytes. Each result in the response is a marshalled
+// KeyModification and the response metadata carries the bookmark for fetching
+// the next page when a page size was requested. The optional rawOptions is a
+// JSON document with the fields of ledger.HistoryQueryOptions.
+func getHistoryForKey(vledger ledger.PeerLedger, namespace []byte, key []byte, rawOptions []byte) pb.Response {
+	if len(namespace) == 0 || len(key) == 0 {
+		return shim.Error("Namespace and key must not be empty.")
+	}
+
+	options := &ledger.HistoryQueryOptions{}
+	if len(rawOptions) > 0 {
+		if err := json.Unmarshal(rawOptions, options); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to parse history query options with error %s", err))
+		}
+	}
+
+	qe, err := vledger.NewHistoryQueryExecutor()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history query executor, error %s", err))
+	}
+	if qe == nil {
+		return shim.Error("History queries are not supported because the history database is disabled on this peer.")
+	}
+	pagedQE, ok := qe.(ledger.PagedHistoryQueryExecutor)
+	if !ok {
+		return shim.Error("History queries with options are not supported by the ledger on this peer.")
+	}
+
+	itr, err := pagedQE.GetHistoryForKeyWithOptions(string(namespace), string(key), options)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get history for key %s, error %s", string(key), err))
+	}
+
+	var results []*pb.QueryResultBytes
+	for {
+		queryResult, err := itr.Next()
+		if err != nil {
+			itr.Close()
+			return shim.Error(fmt.Sprintf("Failed to iterate over the history of key %s, error %s", string(key), err))
+		}
+		if queryResult == nil {
+			break
+		}
+		resultBytes, err := protoutil.Marshal(queryResult.(*queryresult.KeyModification))
+		if err != nil {
+			itr.Close()
+			return shim.Error(err.Error())
+		}
+		results = append(results, &pb.QueryResultBytes{ResultBytes: resultBytes})
+	}
+	bookmark := itr.GetBookmarkAndClose()
+
+	metadata, err := protoutil.Marshal(&pb.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(results)),
+		Bookmark:            bookmark,
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	bytes, err := protoutil.Marshal(&pb.QueryResponse{
+		Results:  results,
+		HasMore:  bookmark != "",
+		Metadata: metadata,
+	})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+// getConflictStats returns the statistics about the transactions invalidated by MVCC
+// read conflicts or phantom reads as a ledger.ConflictStats object marshalled in JSON.
+// A non-empty chaincodeName restricts the statistics to the named chaincode.
+func getConflictStats(vledger ledger.PeerLedger, chaincodeName []byte) pb.Response {
+	provider, ok := vledger.(ledger.ConflictStatsProvider)
+	if !ok {
+		return shim.Error("Conflict statistics are not supported by the ledger on this peer.")
+	}
+
+	stats, err := provider.ConflictStats()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get conflict statistics, error %s", err))
+	}
+
+	if name := string(chaincodeName); name != "" {
+		var filtered []*ledger.ChaincodeConflictStats
+		for _, ccStats := range stats.Chaincodes {
+			if ccStats.Chaincode == name {
+				filtered = append(filtered, ccStats)
+			}
+		}
+		stats.Chaincodes = filtered
+	}
+
+	bytes, err := json.Marshal(stats)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func (e *LedgerQuerier) getCallTrace(rawTxID []byte) pb.Response {
+	if e.callTracer == nil {
+		return shim.Error("Call tracing is not enabled on this peer.")
+	}
+
+	txID := string(rawTxID)
+	if txID == "" {
+		return shim.Error("Transaction ID must not be empty.")
+	}
+
+	trace := e.callTracer.TransactionTrace(txID)
+	if trace == nil {
+		return shim.Error(fmt.Sprintf("No call trace retained for transaction ID %s", txID))
+	}
+
+	bytes, err := json.Marshal(trace)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
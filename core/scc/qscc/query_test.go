@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-chaincode-go/shimtest"
 	"github.com/hyperledger/fabric-protos-go/common"
@@ -21,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt/ledgermgmttest"
@@ -33,6 +36,10 @@ import (
 )
 
 func setupTestLedger(chainid string, path string) (*shimtest.MockStub, *peer.Peer, func(), error) {
+	return setupTestLedgerWithHistoryDB(chainid, path, false)
+}
+
+func setupTestLedgerWithHistoryDB(chainid string, path string, enableHistoryDB bool) (*shimtest.MockStub, *peer.Peer, func(), error) {
 	mockAclProvider.Reset()
 
 	viper.Set("peer.fileSystemPath", path)
@@ -47,6 +54,7 @@ func setupTestLedger(chainid string, path string) (*shimtest.MockStub, *peer.Pee
 	}
 
 	initializer := ledgermgmttest.NewInitializer(testDir)
+	initializer.Config.HistoryDBConfig.Enabled = enableHistoryDB
 
 	ledgerMgr := ledgermgmt.NewLedgerMgr(initializer)
 
@@ -446,6 +454,76 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+func TestQueryGetHistoryForKey(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, p, cleanup, err := setupTestLedgerWithHistoryDB(chainid, path, true)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	addBlockForTesting(t, chainid, p)
+
+	// ns1/key1 was written once in block 1
+	args := [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1")}
+	prop := resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetHistoryForKey failed with err: %s", res.Message)
+	response := &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, response))
+	assert.Len(t, response.Results, 1)
+	assert.False(t, response.HasMore)
+
+	// a page size of one still fetches the single modification but reports a bookmark
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1"), []byte(`{"pageSize":1}`)}
+	prop = resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetHistoryForKey with pageSize failed with err: %s", res.Message)
+	response = &peer2.QueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, response))
+	assert.Len(t, response.Results, 1)
+	assert.True(t, response.HasMore)
+
+	// malformed query options are rejected
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1"), []byte("garbage")}
+	prop = resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed with malformed options")
+
+	// an invalid bookmark is rejected
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1"), []byte(`{"bookmark":"xyz"}`)}
+	prop = resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("4", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed with an invalid bookmark")
+
+	// the key argument is required
+	args = [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1")}
+	prop = resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("5", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed due to missing key argument")
+}
+
+func TestQueryGetHistoryForKeyHistoryDBDisabled(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, _, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	args := [][]byte{[]byte(GetHistoryForKey), []byte(chainid), []byte("ns1"), []byte("key1")}
+	prop := resetProvider(resources.Qscc_GetHistoryForKey, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetHistoryForKey should have failed because the history database is disabled")
+	assert.Contains(t, res.Message, "history database is disabled")
+}
+
 func addBlockForTesting(t *testing.T, chainid string, p *peer.Peer) *common.Block {
 	ledger := p.GetLedger(chainid)
 	defer ledger.Close()
@@ -483,3 +561,101 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+func TestQueryConflictStats(t *testing.T) {
+	chainid := "mytestchainid14"
+	path := tempDir(t, "test14")
+	defer os.RemoveAll(path)
+
+	stub, _, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	sProp, _ := protoutil.MockSignedEndorserProposalOrPanic(chainid,
+		&peer2.ChaincodeSpec{
+			ChaincodeId: &peer2.ChaincodeID{
+				Name: "qscc",
+			},
+		},
+		[]byte("Alice"),
+		[]byte("msg1"),
+	)
+	sProp.Signature = sProp.ProposalBytes
+
+	args := [][]byte{[]byte(GetConflictStats), []byte(chainid), []byte("")}
+	prop := resetProvider(resources.Qscc_GetConflictStats, chainid, sProp, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetConflictStats failed with err: %s", res.Message)
+	stats := &ledger2.ConflictStats{}
+	require.NoError(t, json.Unmarshal(res.Payload, stats))
+	assert.Equal(t, chainid, stats.Channel)
+	assert.Empty(t, stats.Chaincodes, "expected no conflict statistics on a fresh ledger")
+
+	// restricting the statistics to an unknown chaincode yields an empty result
+	args = [][]byte{[]byte(GetConflictStats), []byte(chainid), []byte("unknowncc")}
+	prop = resetProvider(resources.Qscc_GetConflictStats, chainid, sProp, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetConflictStats failed with err: %s", res.Message)
+	stats = &ledger2.ConflictStats{}
+	require.NoError(t, json.Unmarshal(res.Payload, stats))
+	assert.Empty(t, stats.Chaincodes)
+}
+
+func TestQueryCallTrace(t *testing.T) {
+	chainid := "mytestchainid15"
+	path := tempDir(t, "test15")
+	defer os.RemoveAll(path)
+
+	_, peerInstance, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	tracer := chaincode.NewCallTracer(10)
+	tracer.RecordCall("tx1", &chaincode.CallRecord{
+		Caller:    "caller-cc",
+		Callee:    "callee-cc",
+		ChannelID: chainid,
+		Latency:   42,
+		Success:   true,
+	})
+
+	lq := &LedgerQuerier{
+		aclProvider: mockAclProvider,
+		ledgers:     peerInstance,
+		callTracer:  tracer,
+	}
+	stub := shimtest.NewMockStub("LedgerQuerier", lq)
+
+	args := [][]byte{[]byte(GetCallTrace), []byte(chainid), []byte("tx1")}
+	prop := resetProvider(resources.Qscc_GetCallTrace, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.OK), res.Status, "GetCallTrace failed with err: %s", res.Message)
+	var trace []*chaincode.CallRecord
+	require.NoError(t, json.Unmarshal(res.Payload, &trace))
+	require.Len(t, trace, 1)
+	assert.Equal(t, "caller-cc", trace[0].Caller)
+	assert.Equal(t, "callee-cc", trace[0].Callee)
+	assert.Equal(t, chainid, trace[0].ChannelID)
+	assert.True(t, trace[0].Success)
+
+	// no trace is retained for an unknown transaction
+	args = [][]byte{[]byte(GetCallTrace), []byte(chainid), []byte("unknown-tx")}
+	prop = resetProvider(resources.Qscc_GetCallTrace, chainid, nil, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetCallTrace should have failed for an untraced transaction")
+
+	// call tracing not wired up on this peer
+	lqNoTracer := &LedgerQuerier{
+		aclProvider: mockAclProvider,
+		ledgers:     peerInstance,
+	}
+	stubNoTracer := shimtest.NewMockStub("LedgerQuerier", lqNoTracer)
+	args = [][]byte{[]byte(GetCallTrace), []byte(chainid), []byte("tx1")}
+	prop = resetProvider(resources.Qscc_GetCallTrace, chainid, nil, nil)
+	res = stubNoTracer.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status, "GetCallTrace should have failed when tracing is not enabled")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// The standard library refuses to marshal keys on curves it does not know
+// about, so DER encoding and decoding of SM2 keys is implemented here.
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveSm2  = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+type publicKeyInfo struct {
+	Algorithm asn1.RawValue
+	PublicKey asn1.BitString
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.ObjectIdentifier
+	NamedCurve asn1.ObjectIdentifier
+}
+
+// ecPrivateKey is the SEC 1 / RFC 5915 elliptic curve private key structure.
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// MarshalPublicKey converts an SM2 public key to PKIX, ASN.1 DER form.
+func MarshalPublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
+	if !IsSM2PublicKey(pub) {
+		return nil, errors.New("sm2: public key is not on the SM2 curve")
+	}
+
+	algo, err := asn1.Marshal(algorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		NamedCurve: oidNamedCurveSm2,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	point := elliptic.Marshal(pub.Curve, pub.X, pub.Y)
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: asn1.RawValue{FullBytes: algo},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+// ParsePublicKey parses an SM2 public key in PKIX, ASN.1 DER form.
+func ParsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info publicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, errors.Wrap(err, "sm2: failed to parse public key")
+	} else if len(rest) != 0 {
+		return nil, errors.New("sm2: trailing data after public key")
+	}
+
+	var algo algorithmIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.FullBytes, &algo); err != nil {
+		return nil, errors.Wrap(err, "sm2: failed to parse public key algorithm")
+	}
+	if !algo.Algorithm.Equal(oidPublicKeyECDSA) || !algo.NamedCurve.Equal(oidNamedCurveSm2) {
+		return nil, errors.New("sm2: public key is not an SM2 key")
+	}
+
+	curve := P256Sm2()
+	x, y := elliptic.Unmarshal(curve, info.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.New("sm2: invalid public key point")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+// MarshalPrivateKey converts an SM2 private key to SEC 1, ASN.1 DER form.
+func MarshalPrivateKey(priv *ecdsa.PrivateKey) ([]byte, error) {
+	if !IsSM2PublicKey(&priv.PublicKey) {
+		return nil, errors.New("sm2: private key is not on the SM2 curve")
+	}
+
+	point := elliptic.Marshal(priv.Curve, priv.X, priv.Y)
+	return asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    fieldElement(priv.D),
+		NamedCurveOID: oidNamedCurveSm2,
+		PublicKey:     asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+}
+
+// ParsePrivateKey parses an SM2 private key in SEC 1, ASN.1 DER form.
+func ParsePrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	var ec ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &ec); err != nil {
+		return nil, errors.Wrap(err, "sm2: failed to parse private key")
+	}
+	if len(ec.NamedCurveOID) > 0 && !ec.NamedCurveOID.Equal(oidNamedCurveSm2) {
+		return nil, errors.New("sm2: private key is not an SM2 key")
+	}
+
+	curve := P256Sm2()
+	d := new(big.Int).SetBytes(ec.PrivateKey)
+	if d.Sign() <= 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("sm2: invalid private key scalar")
+	}
+
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d.Bytes())
+	return priv, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sm2 implements the SM2 elliptic curve public key signature
+// algorithm defined in GB/T 32918-2016 (Chinese national standard).
+//
+// SM2 keys are represented as ECDSA keys over the sm2p256v1 curve; only
+// the signature algorithm differs from ECDSA. Because the digest an SM2
+// signature is computed over binds the signer's public key and identity,
+// Sign and Verify take the full message rather than a pre-computed hash.
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/hyperledger/fabric/gm/sm3"
+	"github.com/pkg/errors"
+)
+
+// DefaultID is the default signer identity defined in GB/T 32918.2-2016.
+var DefaultID = []byte("1234567812345678")
+
+var (
+	initonce sync.Once
+	p256Sm2  *elliptic.CurveParams
+)
+
+// P256Sm2 returns the sm2p256v1 curve. Note that the curve has a = p - 3,
+// which is the assumption the standard library curve arithmetic relies on.
+func P256Sm2() elliptic.Curve {
+	initonce.Do(func() {
+		p256Sm2 = &elliptic.CurveParams{Name: "SM2-P-256"}
+		p256Sm2.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+		p256Sm2.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+		p256Sm2.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+		p256Sm2.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+		p256Sm2.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+		p256Sm2.BitSize = 256
+	})
+	return p256Sm2
+}
+
+// a returns the curve coefficient a = p - 3.
+func a() *big.Int {
+	return new(big.Int).Sub(P256Sm2().Params().P, big.NewInt(3))
+}
+
+// GenerateKey generates a new SM2 key pair.
+func GenerateKey(random io.Reader) (*ecdsa.PrivateKey, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	return ecdsa.GenerateKey(P256Sm2(), random)
+}
+
+// IsSM2PublicKey reports whether the given public key is on the SM2 curve.
+func IsSM2PublicKey(pub *ecdsa.PublicKey) bool {
+	return pub != nil && pub.Curve == P256Sm2()
+}
+
+// za computes the signer's distinguishing identifier hash
+// ZA = SM3(ENTL || ID || a || b || Gx || Gy || Px || Py) per GB/T 32918.2.
+func za(pub *ecdsa.PublicKey, id []byte) ([]byte, error) {
+	entl := len(id) * 8
+	if entl >= 1<<16 {
+		return nil, errors.New("sm2: signer ID is too long")
+	}
+
+	params := P256Sm2().Params()
+	d := sm3.New()
+	var entlBytes [2]byte
+	binary.BigEndian.PutUint16(entlBytes[:], uint16(entl))
+	d.Write(entlBytes[:])
+	d.Write(id)
+	d.Write(fieldElement(a()))
+	d.Write(fieldElement(params.B))
+	d.Write(fieldElement(params.Gx))
+	d.Write(fieldElement(params.Gy))
+	d.Write(fieldElement(pub.X))
+	d.Write(fieldElement(pub.Y))
+	return d.Sum(nil), nil
+}
+
+// fieldElement left-pads the big-endian representation of v to the size of
+// a curve field element.
+func fieldElement(v *big.Int) []byte {
+	out := make([]byte, 32)
+	b := v.Bytes()
+	copy(out[32-len(b):], b)
+	return out
+}
+
+// digest computes e = SM3(ZA || msg), the value the signature is produced over.
+func digest(pub *ecdsa.PublicKey, id, msg []byte) (*big.Int, error) {
+	zaBytes, err := za(pub, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d := sm3.New()
+	d.Write(zaBytes)
+	d.Write(msg)
+	return new(big.Int).SetBytes(d.Sum(nil)), nil
+}
+
+// Sign signs the given message with the private key using the default
+// signer identity and returns the signature values (r, s).
+func Sign(random io.Reader, priv *ecdsa.PrivateKey, msg []byte) (r, s *big.Int, err error) {
+	return SignWithID(random, priv, msg, DefaultID)
+}
+
+// SignWithID signs the given message with the private key binding the given
+// signer identity.
+func SignWithID(random io.Reader, priv *ecdsa.PrivateKey, msg, id []byte) (r, s *big.Int, err error) {
+	if !IsSM2PublicKey(&priv.PublicKey) {
+		return nil, nil, errors.New("sm2: private key is not on the SM2 curve")
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+
+	e, err := digest(&priv.PublicKey, id, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	curve := P256Sm2()
+	n := curve.Params().N
+	one := big.NewInt(1)
+	// (1 + d)^-1 is independent of k and can be computed up front.
+	dPlus1Inv := new(big.Int).Add(priv.D, one)
+	dPlus1Inv.ModInverse(dPlus1Inv, n)
+
+	for {
+		k, err := randFieldElement(curve, random)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		x1, _ := curve.ScalarBaseMult(k.Bytes())
+
+		r = new(big.Int).Add(e, x1)
+		r.Mod(r, n)
+		if r.Sign() == 0 {
+			continue
+		}
+		if t := new(big.Int).Add(r, k); t.Cmp(n) == 0 {
+			continue
+		}
+
+		// s = (1 + d)^-1 * (k - r*d) mod n
+		s = new(big.Int).Mul(r, priv.D)
+		s.Sub(k, s)
+		s.Mul(s, dPlus1Inv)
+		s.Mod(s, n)
+		if s.Sign() != 0 {
+			return r, s, nil
+		}
+	}
+}
+
+// Verify verifies the signature (r, s) over the given message with the
+// public key using the default signer identity.
+func Verify(pub *ecdsa.PublicKey, msg []byte, r, s *big.Int) bool {
+	return VerifyWithID(pub, msg, r, s, DefaultID)
+}
+
+// VerifyWithID verifies the signature (r, s) over the given message with
+// the public key binding the given signer identity.
+func VerifyWithID(pub *ecdsa.PublicKey, msg []byte, r, s *big.Int, id []byte) bool {
+	if !IsSM2PublicKey(pub) {
+		return false
+	}
+
+	curve := P256Sm2()
+	n := curve.Params().N
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+
+	e, err := digest(pub, id, msg)
+	if err != nil {
+		return false
+	}
+
+	// (x1, y1) = [s]G + [t]P
+	x1a, y1a := curve.ScalarBaseMult(s.Bytes())
+	x1b, y1b := curve.ScalarMult(pub.X, pub.Y, t.Bytes())
+	x1, _ := curve.Add(x1a, y1a, x1b, y1b)
+
+	expected := new(big.Int).Add(e, x1)
+	expected.Mod(expected, n)
+	return expected.Cmp(r) == 0
+}
+
+// randFieldElement returns a random element of [1, n-1].
+func randFieldElement(curve elliptic.Curve, random io.Reader) (*big.Int, error) {
+	params := curve.Params()
+	b := make([]byte, params.BitSize/8+8)
+	if _, err := io.ReadFull(random, b); err != nil {
+		return nil, err
+	}
+
+	k := new(big.Int).SetBytes(b)
+	nMinus1 := new(big.Int).Sub(params.N, big.NewInt(1))
+	k.Mod(k, nMinus1)
+	k.Add(k, big.NewInt(1))
+	return k, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm2
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignVerify(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	msg := []byte("message digest")
+	r, s, err := Sign(rand.Reader, priv, msg)
+	assert.NoError(t, err)
+	assert.True(t, Verify(&priv.PublicKey, msg, r, s))
+
+	// a different message does not verify
+	assert.False(t, Verify(&priv.PublicKey, []byte("another message"), r, s))
+
+	// a different key does not verify
+	other, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.False(t, Verify(&other.PublicKey, msg, r, s))
+
+	// a different signer identity does not verify
+	assert.False(t, VerifyWithID(&priv.PublicKey, msg, r, s, []byte("someoneelse01234")))
+}
+
+// fixedReader feeds a fixed prefix and then zeros, used to drive signing
+// with a known ephemeral scalar.
+type fixedReader struct{ b []byte }
+
+func (r *fixedReader) Read(p []byte) (int, error) {
+	n := copy(p, r.b)
+	for i := n; i < len(p); i++ {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// Known-answer test with the sm2p256v1 example of GM/T 0003.5:
+// ID is the default identifier and M is "message digest".
+func TestSignKnownAnswer(t *testing.T) {
+	d, _ := new(big.Int).SetString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8", 16)
+	k, _ := new(big.Int).SetString("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21", 16)
+	wantR, _ := new(big.Int).SetString("F5A03B0648D2C4630EEAC513E1BB81A15944DA3827D5B74143AC7EACEEE720B3", 16)
+	wantS, _ := new(big.Int).SetString("B1B6AA29DF212FD8763182BC0D421CA1BB9038FD1F7F42D4840B69C485BBC1AA", 16)
+
+	curve := P256Sm2()
+	priv := &ecdsa.PrivateKey{D: d}
+	priv.Curve = curve
+	priv.X, priv.Y = curve.ScalarBaseMult(d.Bytes())
+
+	// randFieldElement derives the scalar as b mod (n-1) + 1 from the 40
+	// bytes b it reads, so feed it k-1 left-padded to 40 bytes.
+	kb := make([]byte, 40)
+	km1 := new(big.Int).Sub(k, big.NewInt(1))
+	copy(kb[40-len(km1.Bytes()):], km1.Bytes())
+
+	r, s, err := Sign(&fixedReader{kb}, priv, []byte("message digest"))
+	assert.NoError(t, err)
+	assert.Zero(t, r.Cmp(wantR))
+	assert.Zero(t, s.Cmp(wantS))
+	assert.True(t, Verify(&priv.PublicKey, []byte("message digest"), r, s))
+}
+
+func TestKeySerialization(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := MarshalPublicKey(&priv.PublicKey)
+	assert.NoError(t, err)
+	pub, err := ParsePublicKey(der)
+	assert.NoError(t, err)
+	assert.Zero(t, pub.X.Cmp(priv.X))
+	assert.Zero(t, pub.Y.Cmp(priv.Y))
+
+	der, err = MarshalPrivateKey(priv)
+	assert.NoError(t, err)
+	priv2, err := ParsePrivateKey(der)
+	assert.NoError(t, err)
+	assert.Zero(t, priv2.D.Cmp(priv.D))
+	assert.Zero(t, priv2.X.Cmp(priv.X))
+
+	// non-SM2 keys are rejected
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, err = MarshalPublicKey(&p256.PublicKey)
+	assert.EqualError(t, err, "sm2: public key is not on the SM2 curve")
+	_, err = MarshalPrivateKey(p256)
+	assert.EqualError(t, err, "sm2: private key is not on the SM2 curve")
+
+	_, err = ParsePublicKey([]byte{0, 1, 2, 3})
+	assert.Error(t, err)
+	_, err = ParsePrivateKey([]byte{0, 1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestRejectsForeignCurve(t *testing.T) {
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	_, _, err = Sign(rand.Reader, p256, []byte("msg"))
+	assert.EqualError(t, err, "sm2: private key is not on the SM2 curve")
+	assert.False(t, Verify(&p256.PublicKey, []byte("msg"), big.NewInt(1), big.NewInt(1)))
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from GB/T 32905-2016, appendix A.
+func TestSum(t *testing.T) {
+	want, _ := hex.DecodeString("66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0")
+	got := Sum([]byte("abc"))
+	assert.Equal(t, want, got[:])
+
+	want, _ = hex.DecodeString("debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732")
+	got = Sum(bytes.Repeat([]byte("abcd"), 16))
+	assert.Equal(t, want, got[:])
+}
+
+func TestIncrementalWrite(t *testing.T) {
+	msg := bytes.Repeat([]byte("abcd"), 16)
+
+	d := New()
+	d.Write(msg[:5])
+	d.Write(msg[5:63])
+	d.Write(msg[63:])
+
+	want := Sum(msg)
+	assert.Equal(t, want[:], d.Sum(nil))
+
+	// Sum must not disturb the running state
+	assert.Equal(t, want[:], d.Sum(nil))
+}
+
+func TestInterfaceProperties(t *testing.T) {
+	d := New()
+	assert.Equal(t, Size, d.Size())
+	assert.Equal(t, BlockSize, d.BlockSize())
+
+	d.Write([]byte("abc"))
+	d.Reset()
+	d.Write([]byte("abc"))
+	want, _ := hex.DecodeString("66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0")
+	assert.Equal(t, want, d.Sum(nil))
+}
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sm4
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from GB/T 32907-2016, appendix A.
+func TestEncryptDecrypt(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	plaintext, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	want, _ := hex.DecodeString("681edf34d206965e86b3e94f536e4246")
+
+	c, err := NewCipher(key)
+	assert.NoError(t, err)
+	assert.Equal(t, BlockSize, c.BlockSize())
+
+	ciphertext := make([]byte, BlockSize)
+	c.Encrypt(ciphertext, plaintext)
+	assert.Equal(t, want, ciphertext)
+
+	decrypted := make([]byte, BlockSize)
+	c.Decrypt(decrypted, ciphertext)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestMillionIterations(t *testing.T) {
+	key, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	want, _ := hex.DecodeString("595298c7c6fd271f0402f804c33d3f66")
+
+	c, err := NewCipher(key)
+	assert.NoError(t, err)
+
+	x, _ := hex.DecodeString("0123456789abcdeffedcba9876543210")
+	for i := 0; i < 1000000; i++ {
+		c.Encrypt(x, x)
+	}
+	assert.Equal(t, want, x)
+}
+
+func TestInvalidKeySize(t *testing.T) {
+	_, err := NewCipher(make([]byte, 24))
+	assert.EqualError(t, err, "sm4: invalid key size 24")
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
@@ -79,6 +80,7 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 		connTimeout:     config.ConnTimeout,
 		recvBuffSize:    config.RecvBuffSize,
 		sendBuffSize:    config.SendBuffSize,
+		compression:     config.CompressionEnabled,
 	}
 
 	connConfig := ConnConfig{
@@ -95,10 +97,11 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 
 // CommConfig is the configuration required to initialize a new comm
 type CommConfig struct {
-	DialTimeout  time.Duration // Dial timeout
-	ConnTimeout  time.Duration // Connection timeout
-	RecvBuffSize int           // Buffer size of received messages
-	SendBuffSize int           // Buffer size of sending messages
+	DialTimeout        time.Duration // Dial timeout
+	ConnTimeout        time.Duration // Connection timeout
+	RecvBuffSize       int           // Buffer size of received messages
+	SendBuffSize       int           // Buffer size of sending messages
+	CompressionEnabled bool          // Compress message payloads when the remote peer supports it
 }
 
 type commImpl struct {
@@ -125,6 +128,7 @@ type commImpl struct {
 	connTimeout     time.Duration
 	recvBuffSize    int
 	sendBuffSize    int
+	compression     bool
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -162,6 +166,9 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 	}
 
 	ctx, cancel = context.WithCancel(context.Background())
+	if c.compression {
+		ctx = advertiseCompression(ctx)
+	}
 	if stream, err = cl.GossipStream(ctx); err == nil {
 		connInfo, err = c.authenticateRemotePeer(stream, true)
 		if err == nil {
@@ -192,6 +199,13 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 			conn.info = connInfo
 			conn.logger = c.logger
 			conn.cancel = cancel
+			if c.compression {
+				// the remote peer echoes the advertisement in its header
+				// metadata when it supports compression too
+				if md, err := stream.Header(); err == nil && compressionAdvertised(md) {
+					conn.compressPayloads = true
+				}
+			}
 
 			h := func(m *protoext.SignedGossipMessage) {
 				c.logger.Debug("Got message:", m)
@@ -556,6 +570,16 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	if c.isStopping() {
 		return fmt.Errorf("Shutting down")
 	}
+	compress := false
+	if c.compression {
+		if md, ok := metadata.FromIncomingContext(stream.Context()); ok && compressionAdvertised(md) {
+			// echo the advertisement back so the remote peer knows it may
+			// send us compressed payloads as well
+			if err := grpc.SetHeader(stream.Context(), metadata.Pairs(compressionMetadataKey, compressionGzip)); err == nil {
+				compress = true
+			}
+		}
+	}
 	connInfo, err := c.authenticateRemotePeer(stream, false)
 	if err != nil {
 		c.logger.Errorf("Authentication failed: %v", err)
@@ -564,6 +588,7 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	c.logger.Debug("Servicing", extractRemoteAddress(stream))
 
 	conn := c.connStore.onConnected(stream, connInfo, c.metrics)
+	conn.compressPayloads = compress
 
 	h := func(m *protoext.SignedGossipMessage) {
 		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
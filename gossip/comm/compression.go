@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+// compressionMetadataKey is the gRPC metadata key under which peers
+// advertise support for payload compression when a gossip stream is
+// established. A peer only sends compressed payloads to peers that
+// advertised support, so mixed networks keep working.
+const compressionMetadataKey = "gossip-payload-compression"
+
+// compressionGzip is the only compression scheme currently negotiated.
+const compressionGzip = "gzip"
+
+// compressedPayloadMagic prefixes a compressed envelope payload so the
+// receiving side can tell it apart from a plain protobuf payload.
+var compressedPayloadMagic = []byte{0x1f, 0x8b, 0xc9, 0x2e}
+
+// compressEnvelopePayload returns a copy of the envelope with its payload
+// gzip compressed, along with the number of bytes saved. The original
+// envelope is returned untouched when compression does not pay off, since
+// small or high-entropy payloads can grow instead of shrink.
+func compressEnvelopePayload(envelope *proto.Envelope) (*proto.Envelope, int) {
+	var buff bytes.Buffer
+	buff.Write(compressedPayloadMagic)
+	w := gzip.NewWriter(&buff)
+	if _, err := w.Write(envelope.Payload); err != nil {
+		return envelope, 0
+	}
+	if err := w.Close(); err != nil {
+		return envelope, 0
+	}
+
+	saved := len(envelope.Payload) - buff.Len()
+	if saved <= 0 {
+		return envelope, 0
+	}
+
+	return &proto.Envelope{
+		Payload:        buff.Bytes(),
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}, saved
+}
+
+// maybeDecompressPayload restores the payload of an envelope that was
+// compressed by the sending side, detected by the magic prefix. Envelopes
+// with plain payloads pass through untouched.
+func maybeDecompressPayload(envelope *proto.Envelope) error {
+	if !bytes.HasPrefix(envelope.Payload, compressedPayloadMagic) {
+		return nil
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(envelope.Payload[len(compressedPayloadMagic):]))
+	if err != nil {
+		return errors.Wrap(err, "malformed compressed payload")
+	}
+	payload, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "failed decompressing payload")
+	}
+
+	envelope.Payload = payload
+	return nil
+}
+
+// advertiseCompression attaches the compression advertisement to the
+// context a gossip stream is established with.
+func advertiseCompression(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, compressionMetadataKey, compressionGzip)
+}
+
+// compressionAdvertised reports whether the given gRPC metadata contains
+// a gzip compression advertisement.
+func compressionAdvertised(md metadata.MD) bool {
+	for _, value := range md.Get(compressionMetadataKey) {
+		if value == compressionGzip {
+			return true
+		}
+	}
+	return false
+}
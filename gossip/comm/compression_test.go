@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestCompressEnvelopePayload(t *testing.T) {
+	payload := bytes.Repeat([]byte("gossip payload "), 100)
+	envelope := &proto.Envelope{
+		Payload:   payload,
+		Signature: []byte("signature"),
+	}
+
+	compressed, saved := compressEnvelopePayload(envelope)
+	assert.True(t, saved > 0)
+	assert.NotEqual(t, payload, compressed.Payload)
+	assert.Equal(t, envelope.Signature, compressed.Signature)
+	// the original envelope is shared between connections and stays untouched
+	assert.Equal(t, payload, envelope.Payload)
+
+	err := maybeDecompressPayload(compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, compressed.Payload)
+}
+
+func TestCompressEnvelopePayloadNotWorthIt(t *testing.T) {
+	// a tiny payload grows when compressed, so it is sent as is
+	envelope := &proto.Envelope{Payload: []byte("tiny")}
+	compressed, saved := compressEnvelopePayload(envelope)
+	assert.Equal(t, 0, saved)
+	assert.Equal(t, envelope, compressed)
+}
+
+func TestMaybeDecompressPayload(t *testing.T) {
+	// plain payloads pass through untouched
+	envelope := &proto.Envelope{Payload: []byte("plain payload")}
+	err := maybeDecompressPayload(envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plain payload"), envelope.Payload)
+
+	// a magic prefix without a valid gzip stream behind it is rejected
+	envelope = &proto.Envelope{Payload: append(compressedPayloadMagic, []byte("garbage")...)}
+	err = maybeDecompressPayload(envelope)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed compressed payload")
+}
+
+func TestCompressionAdvertised(t *testing.T) {
+	assert.False(t, compressionAdvertised(metadata.MD{}))
+	assert.False(t, compressionAdvertised(metadata.Pairs(compressionMetadataKey, "snappy")))
+	assert.True(t, compressionAdvertised(metadata.Pairs(compressionMetadataKey, compressionGzip)))
+
+	ctx := advertiseCompression(context.Background())
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+	assert.True(t, compressionAdvertised(md))
+}
@@ -207,18 +207,21 @@ type ConnConfig struct {
 
 type connection struct {
 	recvBuffSize int
-	metrics      *metrics.CommMetrics
-	cancel       context.CancelFunc
-	info         *protoext.ConnectionInfo
-	outBuff      chan *msgSending
-	logger       util.Logger        // logger
-	pkiID        common.PKIidType   // pkiID of the remote endpoint
-	handler      handler            // function to invoke upon a message reception
-	conn         *grpc.ClientConn   // gRPC connection to remote endpoint
-	cl           proto.GossipClient // gRPC stub of remote endpoint
-	gossipStream stream             // there can only be one
-	stopChan     chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
-	stopOnce     sync.Once          // once to ensure close is called only once
+	// compressPayloads is set when the remote peer advertised support for
+	// payload compression during the stream establishment
+	compressPayloads bool
+	metrics          *metrics.CommMetrics
+	cancel           context.CancelFunc
+	info             *protoext.ConnectionInfo
+	outBuff          chan *msgSending
+	logger           util.Logger        // logger
+	pkiID            common.PKIidType   // pkiID of the remote endpoint
+	handler          handler            // function to invoke upon a message reception
+	conn             *grpc.ClientConn   // gRPC connection to remote endpoint
+	cl               proto.GossipClient // gRPC stub of remote endpoint
+	gossipStream     stream             // there can only be one
+	stopChan         chan struct{}      // a method to stop the server-side gRPC call from a different go-routine
+	stopOnce         sync.Once          // once to ensure close is called only once
 }
 
 func (conn *connection) close() {
@@ -289,7 +292,15 @@ func (conn *connection) writeToStream() {
 	for {
 		select {
 		case m := <-conn.outBuff:
-			err := stream.Send(m.envelope)
+			envelope := m.envelope
+			if conn.compressPayloads {
+				var saved int
+				envelope, saved = compressEnvelopePayload(envelope)
+				if saved > 0 {
+					conn.metrics.CompressionSavedBytes.Add(float64(saved))
+				}
+			}
+			err := stream.Send(envelope)
 			if err != nil {
 				go m.onErr(err)
 				return
@@ -316,6 +327,11 @@ func (conn *connection) readFromStream(errChan chan error, msgChan chan *protoex
 				return
 			}
 			conn.metrics.ReceivedMessages.Add(1)
+			if err := maybeDecompressPayload(envelope); err != nil {
+				errChan <- err
+				conn.logger.Warningf("Got error, aborting: %v", err)
+				return
+			}
 			msg, err := protoext.EnvelopeToGossipMessage(envelope)
 			if err != nil {
 				errChan <- err
@@ -8,6 +8,7 @@ package discovery
 
 import (
 	"fmt"
+	"time"
 
 	protolib "github.com/golang/protobuf/proto"
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
@@ -37,11 +38,11 @@ type Sieve func(message *protoext.SignedGossipMessage) bool
 // is eligible of knowing about, and also what is it eligible
 // to know about out of a given SignedGossipMessage.
 // Returns:
-// 1) A Sieve for a given remote peer.
-//    The Sieve is applied for each peer in question and outputs
-//    whether the message should be disclosed to the remote peer.
-// 2) A EnvelopeFilter for a given SignedGossipMessage, which may remove
-//    part of the Envelope the SignedGossipMessage originates from
+//  1. A Sieve for a given remote peer.
+//     The Sieve is applied for each peer in question and outputs
+//     whether the message should be disclosed to the remote peer.
+//  2. A EnvelopeFilter for a given SignedGossipMessage, which may remove
+//     part of the Envelope the SignedGossipMessage originates from
 type DisclosurePolicy func(remotePeer *NetworkMember) (Sieve, EnvelopeFilter)
 
 // CommService is an interface that the discovery expects to be implemented and passed on creation
@@ -150,6 +151,10 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// AliveSince returns the last time an alive message was received from
+	// the given member, or the zero time if the member isn't considered alive
+	AliveSince(PKIID common.PKIidType) time.Time
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
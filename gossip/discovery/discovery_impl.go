@@ -288,6 +288,18 @@ func (d *gossipDiscoveryImpl) handleEvents() {
 	}
 }
 
+// AliveSince returns the last time an alive message was received from
+// the given member, or the zero time if the member isn't considered alive
+func (d *gossipDiscoveryImpl) AliveSince(pkiID common.PKIidType) time.Time {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	ts, alive := d.aliveLastTS[string(pkiID)]
+	if !alive {
+		return time.Time{}
+	}
+	return ts.lastSeen
+}
+
 func (d *gossipDiscoveryImpl) isAlive(pkiID common.PKIidType) bool {
 	d.lock.RLock()
 	defer d.lock.RUnlock()
@@ -926,6 +926,14 @@ func TestGetFullMembership(t *testing.T) {
 		}
 	}
 
+	// Check that AliveSince() reports when alive members were last seen
+	for _, inst := range instances {
+		for _, member := range inst.GetMembership() {
+			assert.False(t, inst.AliveSince(member.PKIid).IsZero())
+		}
+		assert.True(t, inst.AliveSince(common.PKIidType("unknown")).IsZero())
+	}
+
 	stopInstances(t, instances)
 }
 
@@ -8,6 +8,7 @@ package election
 
 import (
 	"bytes"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,6 +45,9 @@ func (pi *peerImpl) ID() peerID {
 }
 
 type gossip interface {
+	// SelfMembershipInfo returns the peer's membership information
+	SelfMembershipInfo() discovery.NetworkMember
+
 	// PeersOfChannel returns the NetworkMembers considered alive in a channel
 	PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember
 
@@ -71,14 +75,15 @@ type adapterImpl struct {
 
 	logger util.Logger
 
-	doneCh   chan struct{}
-	stopOnce *sync.Once
-	metrics  *metrics.ElectionMetrics
+	doneCh           chan struct{}
+	stopOnce         *sync.Once
+	metrics          *metrics.ElectionMetrics
+	leaderPriorities map[string]int
 }
 
 // NewAdapter creates new leader election adapter
 func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChannelID,
-	metrics *metrics.ElectionMetrics) LeaderElectionAdapter {
+	metrics *metrics.ElectionMetrics, leaderPriorities map[string]int) LeaderElectionAdapter {
 	return &adapterImpl{
 		gossip:    gossip,
 		selfPKIid: pkiid,
@@ -90,9 +95,10 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChannelID,
 
 		logger: util.GetLogger(util.ElectionLogger, ""),
 
-		doneCh:   make(chan struct{}),
-		stopOnce: &sync.Once{},
-		metrics:  metrics,
+		doneCh:           make(chan struct{}),
+		stopOnce:         &sync.Once{},
+		metrics:          metrics,
+		leaderPriorities: leaderPriorities,
 	}
 }
 
@@ -162,6 +168,35 @@ func (ai *adapterImpl) Peers() []Peer {
 	return res
 }
 
+// PeerPriority returns the election priority configured for the endpoint of
+// the given peer. Peers whose endpoints aren't mapped to a priority have a
+// priority of zero.
+func (ai *adapterImpl) PeerPriority(id peerID) int {
+	if len(ai.leaderPriorities) == 0 {
+		return 0
+	}
+	var member *discovery.NetworkMember
+	if bytes.Equal(ai.selfPKIid, common.PKIidType(id)) {
+		self := ai.gossip.SelfMembershipInfo()
+		member = &self
+	} else {
+		for _, peer := range ai.gossip.PeersOfChannel(ai.channel) {
+			if bytes.Equal(peer.PKIid, common.PKIidType(id)) {
+				peer := peer
+				member = &peer
+				break
+			}
+		}
+	}
+	if member == nil {
+		return 0
+	}
+	if priority, exists := ai.leaderPriorities[strings.ToLower(member.Endpoint)]; exists {
+		return priority
+	}
+	return ai.leaderPriorities[strings.ToLower(member.InternalEndpoint)]
+}
+
 func (ai *adapterImpl) ReportMetrics(isLeader bool) {
 	var leadershipBit float64
 	if isLeader {
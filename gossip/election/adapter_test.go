@@ -41,7 +41,7 @@ func TestNewAdapter(t *testing.T) {
 	peersCluster.addPeer("peer0", mockGossip)
 
 	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
-		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, nil)
 }
 
 func TestAdapterImpl_CreateMessage(t *testing.T) {
@@ -53,7 +53,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	mockGossip := newGossip("peer0", selfNetworkMember, nil)
 
 	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
-		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, nil)
 	msg := adapter.CreateMessage(true)
 
 	if !protoext.IsLeadershipMsg(msg.(*msgImpl).msg) {
@@ -121,6 +121,35 @@ func TestAdapterImpl_Peers(t *testing.T) {
 
 }
 
+func TestAdapterImpl_PeerPriority(t *testing.T) {
+	selfNetworkMember := &discovery.NetworkMember{
+		Endpoint: "p0",
+		Metadata: []byte{},
+		PKIid:    []byte{byte(0)},
+	}
+	mockGossip := newGossip("peer0", selfNetworkMember, nil)
+
+	otherNetworkMember := &discovery.NetworkMember{
+		Endpoint: "p1",
+		Metadata: []byte{},
+		PKIid:    []byte{byte(1)},
+	}
+	otherGossip := newGossip("peer1", otherNetworkMember, nil)
+
+	cluster := newClusterOfPeers("0")
+	cluster.addPeer("peer0", mockGossip)
+	cluster.addPeer("peer1", otherGossip)
+
+	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, map[string]int{"p1": 5})
+
+	assert.Equal(t, 5, adapter.PeerPriority(peerID(otherNetworkMember.PKIid)))
+	// The endpoint of this peer isn't mapped to a priority
+	assert.Equal(t, 0, adapter.PeerPriority(peerID(selfNetworkMember.PKIid)))
+	// Unknown peers have the zero priority
+	assert.Equal(t, 0, adapter.PeerPriority(peerID([]byte{byte(9)})))
+}
+
 func TestAdapterImpl_Stop(t *testing.T) {
 	_, adapters := createCluster(nil, 0, 1, 2, 3, 4, 5)
 
@@ -196,6 +225,10 @@ type peerMockGossip struct {
 	pki2org      map[string]string
 }
 
+func (g *peerMockGossip) SelfMembershipInfo() discovery.NetworkMember {
+	return *g.member
+}
+
 func (g *peerMockGossip) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember {
 	g.clusterLock.RLock()
 	if g.cluster == nil {
@@ -323,7 +356,7 @@ func createCluster(pki2org map[string]string, peers ...int) (*clusterOfPeers, ma
 
 		mockGossip := newGossip(peerEndpoint, peerMember, pki2org)
 		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"),
-			metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+			metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, nil)
 		adapters[peerEndpoint] = adapter.(*adapterImpl)
 		cluster.addPeer(peerEndpoint, mockGossip)
 	}
@@ -337,7 +370,7 @@ func TestReportMetrics(t *testing.T) {
 	electionMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).ElectionMetrics
 
 	mockGossip := newGossip("", &discovery.NetworkMember{}, nil)
-	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), electionMetrics)
+	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), electionMetrics, nil)
 
 	adapter.ReportMetrics(true)
 
@@ -82,6 +82,12 @@ type LeaderElectionAdapter interface {
 	// Peers returns a list of peers considered alive
 	Peers() []Peer
 
+	// PeerPriority returns the election priority configured for the given peer.
+	// Peers with a higher priority win the election over peers with a lower one,
+	// and ties are broken by comparing IDs. Peers without a configured priority
+	// have a priority of zero.
+	PeerPriority(id peerID) int
+
 	// ReportMetrics sends a report to the metrics server about a leadership status
 	ReportMetrics(isLeader bool)
 }
@@ -228,7 +234,7 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.beatsUs(msg.SenderID()) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -310,7 +316,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.beatsUs(peerID(id)) {
 			return
 		}
 	}
@@ -373,6 +379,18 @@ func (le *leaderElectionSvcImpl) drainInterruptChannel() {
 	}
 }
 
+// beatsUs returns whether the peer with the given ID is a better leader
+// candidate than this peer: it has a higher election priority, or the same
+// priority and a lower ID
+func (le *leaderElectionSvcImpl) beatsUs(id peerID) bool {
+	theirPriority := le.adapter.PeerPriority(id)
+	ourPriority := le.adapter.PeerPriority(le.id)
+	if theirPriority != ourPriority {
+		return theirPriority > ourPriority
+	}
+	return bytes.Compare(id, le.id) < 0
+}
+
 // isAlive returns whether peer of given id is considered alive
 func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	for _, p := range le.adapter.Peers() {
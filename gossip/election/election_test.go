@@ -58,6 +58,7 @@ type peer struct {
 	msgChan            chan Msg
 	leaderFromCallback bool
 	callbackInvoked    bool
+	priorities         map[string]int
 	lock               sync.RWMutex
 	LeaderElectionService
 }
@@ -121,6 +122,17 @@ func (p *peer) Peers() []Peer {
 	return peers
 }
 
+func (p *peer) PeerPriority(id peerID) int {
+	p.sharedLock.RLock()
+	defer p.sharedLock.RUnlock()
+
+	if _, isMocked := p.mockedMethods["PeerPriority"]; isMocked {
+		args := p.Called(id)
+		return args.Int(0)
+	}
+	return p.priorities[string(id)]
+}
+
 func (p *peer) ReportMetrics(isLeader bool) {
 	p.Mock.Called(isLeader)
 }
@@ -181,6 +193,30 @@ func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
 	return createPeerWithCostumeMetrics(id, peerMap, l, func(mock.Arguments) {})
 }
 
+func createPeersWithPriorities(priorities map[string]int, ids ...int) []*peer {
+	peers := make([]*peer, len(ids))
+	peerMap := make(map[string]*peer)
+	l := &sync.RWMutex{}
+	for i, id := range ids {
+		idStr := fmt.Sprintf("p%d", id)
+		c := make(chan Msg, 100)
+		p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), priorities: priorities}
+		p.On("ReportMetrics", mock.Anything).Run(func(mock.Arguments) {})
+		config := ElectionConfig{
+			StartupGracePeriod:       testStartupGracePeriod,
+			MembershipSampleInterval: testMembershipSampleInterval,
+			LeaderAliveThreshold:     testLeaderAliveThreshold,
+			LeaderElectionDuration:   testLeaderElectionDuration,
+		}
+		p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback, config)
+		l.Lock()
+		peerMap[idStr] = p
+		l.Unlock()
+		peers[i] = p
+	}
+	return peers
+}
+
 func waitForMultipleLeadersElection(t *testing.T, peers []*peer, leadersNum int) []string {
 	end := time.Now().Add(testTimeout)
 	for time.Now().Before(end) {
@@ -264,6 +300,19 @@ func TestInitPeersStartAtIntervals(t *testing.T) {
 	assert.True(t, peers[0].IsLeader())
 }
 
+func TestInitPeersWithPriorities(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, all configured with the
+	// same priority mapping which favors p3
+	// expected outcome: p3 is the leader although its ID isn't the lowest
+	priorities := map[string]int{"p3": 10}
+	peers := createPeersWithPriorities(priorities, 0, 1, 2, 3, 4)
+	time.Sleep(testStartupGracePeriod + testLeaderElectionDuration)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Len(t, leaders, 1, "More than 1 leader elected")
+	assert.Equal(t, "p3", leaders[0])
+}
+
 func TestStop(t *testing.T) {
 	t.Parallel()
 	// Scenario: peers are spawned at the same time
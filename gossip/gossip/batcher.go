@@ -57,15 +57,74 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 	return p
 }
 
+// newAdaptiveBatchingEmitter behaves like newBatchingEmitter, but adapts the
+// delay between periodic forwards to the observed load: whenever the burst
+// threshold triggers a forwarding the delay is halved, down to a quarter of
+// the given latency, and whenever the buffer drains it backs off again, up
+// to the given latency. Busy peers thus ship fuller batches sooner while
+// idle peers keep the configured cadence.
+func newAdaptiveBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
+	if iterations < 0 {
+		panic(errors.Errorf("Got a negative iterations number"))
+	}
+
+	p := &batchingEmitterImpl{
+		cb:         cb,
+		delay:      latency,
+		iterations: iterations,
+		burstSize:  burstSize,
+		lock:       &sync.Mutex{},
+		buff:       make([]*batchedMessage, 0),
+		stopFlag:   int32(0),
+		adaptive:   true,
+		minDelay:   latency / 4,
+		maxDelay:   latency,
+	}
+
+	if iterations != 0 {
+		go p.periodicEmit()
+	}
+
+	return p
+}
+
 func (p *batchingEmitterImpl) periodicEmit() {
 	for !p.toDie() {
-		time.Sleep(p.delay)
+		time.Sleep(p.currentDelay())
 		p.lock.Lock()
 		p.emit()
+		p.adaptDelay()
 		p.lock.Unlock()
 	}
 }
 
+func (p *batchingEmitterImpl) currentDelay() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.delay
+}
+
+// adaptDelay adjusts the delay until the next periodic emission based on
+// whether the burst threshold was reached since the previous one.
+// It is assumed that the lock is held while this is called.
+func (p *batchingEmitterImpl) adaptDelay() {
+	if !p.adaptive {
+		return
+	}
+	if p.burstTriggered {
+		p.delay /= 2
+		if p.delay < p.minDelay {
+			p.delay = p.minDelay
+		}
+	} else if len(p.buff) == 0 {
+		p.delay *= 2
+		if p.delay > p.maxDelay {
+			p.delay = p.maxDelay
+		}
+	}
+	p.burstTriggered = false
+}
+
 func (p *batchingEmitterImpl) emit() {
 	if p.toDie() {
 		return
@@ -107,6 +166,12 @@ type batchingEmitterImpl struct {
 	lock       *sync.Mutex
 	buff       []*batchedMessage
 	stopFlag   int32
+
+	// adaptive batching state
+	adaptive       bool
+	minDelay       time.Duration
+	maxDelay       time.Duration
+	burstTriggered bool
 }
 
 type batchedMessage struct {
@@ -134,6 +199,7 @@ func (p *batchingEmitterImpl) Add(message interface{}) {
 	p.buff = append(p.buff, &batchedMessage{data: message, iterationsLeft: p.iterations})
 
 	if len(p.buff) >= p.burstSize {
+		p.burstTriggered = true
 		p.emit()
 	}
 }
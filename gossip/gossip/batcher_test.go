@@ -112,3 +112,38 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	}
 	assert.Equal(t, int32(5), atomic.LoadInt32(&disseminationAttempts))
 }
+
+func TestBatchingEmitterAdaptiveDelay(t *testing.T) {
+	latency := 100 * time.Millisecond
+	p := newAdaptiveBatchingEmitter(1, 2, latency, func(a []interface{}) {}).(*batchingEmitterImpl)
+	defer p.Stop()
+
+	assert.Equal(t, latency, p.currentDelay())
+
+	// reaching the burst threshold shrinks the delay, down to a quarter
+	// of the configured latency
+	for i := 0; i < 10; i++ {
+		p.Add(1)
+		p.Add(2)
+	}
+	p.lock.Lock()
+	p.adaptDelay()
+	p.lock.Unlock()
+	assert.Equal(t, latency/2, p.currentDelay())
+
+	for i := 0; i < 10; i++ {
+		p.lock.Lock()
+		p.burstTriggered = true
+		p.adaptDelay()
+		p.lock.Unlock()
+	}
+	assert.Equal(t, latency/4, p.currentDelay())
+
+	// once the buffer drains, the delay backs off towards the latency again
+	for i := 0; i < 10; i++ {
+		p.lock.Lock()
+		p.adaptDelay()
+		p.lock.Unlock()
+	}
+	assert.Equal(t, latency, p.currentDelay())
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/gossip/util"
+)
+
+// bootstrapDNSPrefix marks a bootstrap peer entry as the name of a DNS SRV
+// record rather than a static endpoint. Such entries are resolved whenever
+// the bootstrap peers are connected to, so deployments with ephemeral peer
+// addresses can publish them behind a stable DNS name.
+const bootstrapDNSPrefix = "dns+srv:"
+
+// bootstrapResolver turns the configured bootstrap peer list into the
+// endpoints to connect to. The list is treated as prioritized: endpoints are
+// returned in the order their seeds were configured, and endpoints resolved
+// from a single SRV record are ordered by the record's priority and weight.
+type bootstrapResolver struct {
+	seeds     []string
+	logger    util.Logger
+	lookupSRV func(service, proto, name string) (string, []*net.SRV, error)
+}
+
+func newBootstrapResolver(seeds []string, logger util.Logger) *bootstrapResolver {
+	return &bootstrapResolver{
+		seeds:     seeds,
+		logger:    logger,
+		lookupSRV: net.LookupSRV,
+	}
+}
+
+// resolve returns the current bootstrap endpoints, without duplicates.
+// Static seeds are returned as is, and DNS seeds are re-resolved on every
+// call. Seeds that fail to resolve are skipped rather than failing the
+// whole resolution, since the remaining seeds may still be reachable.
+func (r *bootstrapResolver) resolve() []string {
+	var endpoints []string
+	seen := make(map[string]struct{})
+	add := func(endpoint string) {
+		if _, exists := seen[endpoint]; exists {
+			return
+		}
+		seen[endpoint] = struct{}{}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	for _, seed := range r.seeds {
+		if !strings.HasPrefix(seed, bootstrapDNSPrefix) {
+			add(seed)
+			continue
+		}
+		name := strings.TrimPrefix(seed, bootstrapDNSPrefix)
+		_, records, err := r.lookupSRV("", "", name)
+		if err != nil {
+			r.logger.Warningf("Failed resolving bootstrap DNS record %s: %v", name, err)
+			continue
+		}
+		sort.SliceStable(records, func(i, j int) bool {
+			if records[i].Priority != records[j].Priority {
+				return records[i].Priority < records[j].Priority
+			}
+			return records[i].Weight > records[j].Weight
+		})
+		for _, record := range records {
+			add(net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port))))
+		}
+	}
+	return endpoints
+}
+
+// staticEndpoints returns only the seeds that are plain endpoints and need
+// no DNS resolution.
+func (r *bootstrapResolver) staticEndpoints() []string {
+	var endpoints []string
+	for _, seed := range r.seeds {
+		if !strings.HasPrefix(seed, bootstrapDNSPrefix) {
+			endpoints = append(endpoints, seed)
+		}
+	}
+	return endpoints
+}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapResolverStaticSeeds(t *testing.T) {
+	r := newBootstrapResolver([]string{"p0:7051", "p1:7051", "p0:7051"}, util.GetLogger(util.GossipLogger, "test"))
+	// static seeds resolve to themselves, in order and without duplicates
+	assert.Equal(t, []string{"p0:7051", "p1:7051"}, r.resolve())
+	assert.Equal(t, []string{"p0:7051", "p1:7051", "p0:7051"}, r.staticEndpoints())
+}
+
+func TestBootstrapResolverDNSSeeds(t *testing.T) {
+	r := newBootstrapResolver([]string{"p0:7051", "dns+srv:_gossip._tcp.example.com"}, util.GetLogger(util.GossipLogger, "test"))
+	r.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		assert.Equal(t, "_gossip._tcp.example.com", name)
+		return name, []*net.SRV{
+			{Target: "p2.example.com.", Port: 7051, Priority: 1, Weight: 10},
+			{Target: "p1.example.com.", Port: 7051, Priority: 0, Weight: 10},
+			{Target: "p0", Port: 7051, Priority: 0, Weight: 20},
+		}, nil
+	}
+	// static seeds come first, then SRV targets ordered by priority and weight,
+	// and the "p0:7051" target is dropped as a duplicate of the static seed
+	assert.Equal(t, []string{"p0:7051", "p1.example.com:7051", "p2.example.com:7051"}, r.resolve())
+	assert.Equal(t, []string{"p0:7051"}, r.staticEndpoints())
+}
+
+func TestBootstrapResolverDNSFailure(t *testing.T) {
+	r := newBootstrapResolver([]string{"dns+srv:_gossip._tcp.example.com", "p0:7051"}, util.GetLogger(util.GossipLogger, "test"))
+	r.lookupSRV = func(service, proto, name string) (string, []*net.SRV, error) {
+		return "", nil, errors.New("no such host")
+	}
+	// a seed that fails to resolve is skipped, the remaining seeds are kept
+	assert.Equal(t, []string{"p0:7051"}, r.resolve())
+}
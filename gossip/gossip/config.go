@@ -9,6 +9,7 @@ package gossip
 import (
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -26,8 +27,14 @@ type Config struct {
 	BindPort int
 	// Id of the specific gossip instance.
 	ID string
-	// BootstrapPeers are peers we connect to at startup.
+	// BootstrapPeers are peers we connect to at startup. An entry prefixed
+	// with "dns+srv:" is the name of a DNS SRV record that is resolved into
+	// endpoints, the remaining entries are static endpoints.
 	BootstrapPeers []string
+	// BootstrapReresolveInterval determines how often the bootstrap peers are
+	// re-resolved and reconnected to while no live peers are known.
+	// A zero value disables the periodic re-resolution.
+	BootstrapReresolveInterval time.Duration
 	// PropagateIterations is the number of times a message is pushed to remote peers.
 	PropagateIterations int
 	// PropagatePeerNum is the number of peers selected to push messages to.
@@ -81,10 +88,26 @@ type Config struct {
 	RecvBuffSize int
 	// SendBuffSize is the buffer size of sending message.
 	SendBuffSize int
+	// CompressionEnabled makes the comm layer compress message payloads
+	// towards peers that support it.
+	CompressionEnabled bool
+	// AdaptiveBatching makes the push phase adapt its batching delay to the
+	// observed load instead of using a fixed one.
+	AdaptiveBatching bool
 
 	// MsgExpirationTimeout indicate leadership message expiration timeout.
 	MsgExpirationTimeout time.Duration
 
+	// TopologyZone is the zone this peer is deployed in, used to prefer
+	// nearby peers when forwarding blocks. Empty disables the preference.
+	TopologyZone string
+	// TopologyRegion is the region this peer is deployed in.
+	TopologyRegion string
+	// TopologyZones maps peer endpoints to the zones they are deployed in.
+	TopologyZones map[string]string
+	// TopologyRegions maps peer endpoints to the regions they are deployed in.
+	TopologyRegions map[string]string
+
 	// AliveTimeInterval is the alive check interval.
 	AliveTimeInterval time.Duration
 	// AliveExpirationTimeout is the alive expiration timeout.
@@ -114,6 +137,7 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 
 	c.BindPort = int(port)
 	c.BootstrapPeers = bootPeers
+	c.BootstrapReresolveInterval = util.GetDurationOrDefault("peer.gossip.bootstrapReresolveInterval", 0)
 	c.ID = endpoint
 	c.MaxBlockCountToStore = util.GetIntOrDefault("peer.gossip.maxBlockCountToStore", 100)
 	c.MaxPropagationBurstLatency = util.GetDurationOrDefault("peer.gossip.maxPropagationBurstLatency", 10*time.Millisecond)
@@ -137,7 +161,13 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ConnTimeout = util.GetDurationOrDefault("peer.gossip.connTimeout", comm.DefConnTimeout)
 	c.RecvBuffSize = util.GetIntOrDefault("peer.gossip.recvBuffSize", comm.DefRecvBuffSize)
 	c.SendBuffSize = util.GetIntOrDefault("peer.gossip.sendBuffSize", comm.DefSendBuffSize)
+	c.CompressionEnabled = viper.GetBool("peer.gossip.compressionEnabled")
+	c.AdaptiveBatching = viper.GetBool("peer.gossip.adaptiveBatching")
 	c.MsgExpirationTimeout = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold) * 10
+	c.TopologyZone = viper.GetString("peer.gossip.topology.zone")
+	c.TopologyRegion = viper.GetString("peer.gossip.topology.region")
+	c.TopologyZones = loadTopologyLabels("peer.gossip.topology.zones")
+	c.TopologyRegions = loadTopologyLabels("peer.gossip.topology.regions")
 	c.AliveTimeInterval = util.GetDurationOrDefault("peer.gossip.aliveTimeInterval", discovery.DefAliveTimeInterval)
 	c.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*c.AliveTimeInterval)
 	c.AliveExpirationCheckInterval = c.AliveExpirationTimeout / 10
@@ -145,3 +175,16 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 
 	return nil
 }
+
+// loadTopologyLabels reads a map of peer endpoints to topology labels from
+// the given configuration key, normalizing the endpoints to lower case
+func loadTopologyLabels(key string) map[string]string {
+	var labels map[string]string
+	for endpoint, label := range viper.GetStringMapString(key) {
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[strings.ToLower(endpoint)] = label
+	}
+	return labels
+}
@@ -55,6 +55,10 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.aliveTimeInterval", "20s")
 	viper.Set("peer.gossip.aliveExpirationTimeout", "21s")
 	viper.Set("peer.gossip.reconnectInterval", "22s")
+	viper.Set("peer.gossip.topology.zone", "zone-a")
+	viper.Set("peer.gossip.topology.region", "region-1")
+	viper.Set("peer.gossip.topology.zones", map[string]interface{}{"Peer1:7051": "zone-b"})
+	viper.Set("peer.gossip.topology.regions", map[string]interface{}{"Peer1:7051": "region-2"})
 
 	coreConfig, err := gossip.GlobalConfig(endpoint, nil, bootstrap...)
 	assert.NoError(t, err)
@@ -91,6 +95,10 @@ func TestGlobalConfig(t *testing.T) {
 		RecvBuffSize:                 17,
 		SendBuffSize:                 18,
 		MsgExpirationTimeout:         19 * time.Second * 10, // LeaderAliveThreshold * 10
+		TopologyZone:                 "zone-a",
+		TopologyRegion:               "region-1",
+		TopologyZones:                map[string]string{"peer1:7051": "zone-b"},
+		TopologyRegions:              map[string]string{"peer1:7051": "region-2"},
 		AliveTimeInterval:            20 * time.Second,
 		AliveExpirationTimeout:       21 * time.Second,
 		AliveExpirationCheckInterval: 21 * time.Second / 10, // AliveExpirationTimeout / 10
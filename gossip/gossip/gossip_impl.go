@@ -66,6 +66,8 @@ type Node struct {
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
 	gossipMetrics     *metrics.GossipMetrics
+	bootResolver      *bootstrapResolver
+	topology          *topology
 }
 
 // New creates a gossip instance attached to a gRPC server
@@ -91,6 +93,8 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
 		gossipMetrics:         gossipMetrics,
+		bootResolver:          newBootstrapResolver(conf.BootstrapPeers, lgr),
+		topology:              newTopology(conf),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -100,10 +104,11 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}, sa)
 
 	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
+		DialTimeout:        conf.DialTimeout,
+		ConnTimeout:        conf.ConnTimeout,
+		RecvBuffSize:       conf.RecvBuffSize,
+		SendBuffSize:       conf.SendBuffSize,
+		CompressionEnabled: conf.CompressionEnabled,
 	}
 	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
 		gossipMetrics.CommMetrics, commConfig)
@@ -114,7 +119,11 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	}
 
 	g.chanState = newChannelState(g)
-	g.emitter = newBatchingEmitter(conf.PropagateIterations,
+	newEmitter := newBatchingEmitter
+	if conf.AdaptiveBatching {
+		newEmitter = newAdaptiveBatchingEmitter
+	}
+	g.emitter = newEmitter(conf.PropagateIterations,
 		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
 		g.sendGossipBatch)
 
@@ -126,7 +135,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		AliveExpirationTimeout:       conf.AliveExpirationTimeout,
 		AliveExpirationCheckInterval: conf.AliveExpirationCheckInterval,
 		ReconnectInterval:            conf.ReconnectInterval,
-		BootstrapPeers:               conf.BootstrapPeers,
+		BootstrapPeers:               g.bootResolver.staticEndpoints(),
 	}
 	g.disc = discovery.NewDiscoveryService(g.selfNetworkMember(), g.discAdapter, g.disSecAdap, g.disclosurePolicy,
 		discoveryConfig)
@@ -143,6 +152,10 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	g.stopSignal.Add(2)
 	go g.start()
 	go g.connect2BootstrapPeers()
+	if conf.BootstrapReresolveInterval > 0 {
+		g.stopSignal.Add(1)
+		go g.periodicBootstrapResolution()
+	}
 
 	return g
 }
@@ -570,16 +583,37 @@ func (g *Node) gossipInChan(messages []*emittedGossipMessage, chanRoutingFactory
 		// For leadership messages we will select all peers that pass routing factory - e.g. all peers in channel and org
 		membership := g.disc.GetMembership()
 		var peers2Send []*comm.RemotePeer
-		if protoext.IsLeadershipMsg(messagesOfChannel[0].GossipMessage) {
+		isLeadershipMsg := protoext.IsLeadershipMsg(messagesOfChannel[0].GossipMessage)
+		if isLeadershipMsg {
 			peers2Send = filter.SelectPeers(len(membership), membership, chanRoutingFactory(gc))
 		} else {
-			peers2Send = filter.SelectPeers(g.conf.PropagatePeerNum, membership, chanRoutingFactory(gc))
+			// Prefer peers in our zone and region, if topology labels are configured
+			peers2Send = g.topology.selectPeers(g.conf.PropagatePeerNum, membership, chanRoutingFactory(gc))
 		}
 
 		// Send the messages to the remote peers
 		for _, msg := range messagesOfChannel {
 			filteredPeers := g.removeSelfLoop(msg, peers2Send)
 			g.comm.Send(msg.SignedGossipMessage, filteredPeers...)
+			if !isLeadershipMsg {
+				g.reportCrossZoneTraffic(channel, filteredPeers)
+			}
+		}
+	}
+}
+
+// reportCrossZoneTraffic updates the cross zone and cross region traffic
+// counters for a block that is about to be forwarded to the given peers
+func (g *Node) reportCrossZoneTraffic(channel common.ChannelID, peers []*comm.RemotePeer) {
+	if !g.topology.enabled() {
+		return
+	}
+	for _, peer := range peers {
+		if g.topology.crossZone(peer.Endpoint) {
+			g.gossipMetrics.CommMetrics.CrossZoneSentMessages.With("channel", string(channel)).Add(1)
+		}
+		if g.topology.crossRegion(peer.Endpoint) {
+			g.gossipMetrics.CommMetrics.CrossRegionSentMessages.With("channel", string(channel)).Add(1)
 		}
 	}
 }
@@ -706,6 +740,12 @@ func (g *Node) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// AliveSince returns the last time an alive message was received from
+// the given peer, or the zero time if the peer isn't considered alive
+func (g *Node) AliveSince(pkiID common.PKIidType) time.Time {
+	return g.disc.AliveSince(pkiID)
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember {
@@ -1150,29 +1190,51 @@ func (g *Node) sameOrgOrOurOrgPullFilter(msg protoext.ReceivedMessage) func(stri
 }
 
 func (g *Node) connect2BootstrapPeers() {
-	for _, endpoint := range g.conf.BootstrapPeers {
-		endpoint := endpoint
-		identifier := func() (*discovery.PeerIdentification, error) {
-			remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
-			if err != nil {
-				return nil, errors.WithStack(err)
-			}
-			sameOrg := bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity))
-			if !sameOrg {
-				return nil, errors.Errorf("%s isn't in our organization, cannot be a bootstrap peer", endpoint)
-			}
-			pkiID := g.mcs.GetPKIidOfCert(remotePeerIdentity)
-			if len(pkiID) == 0 {
-				return nil, errors.Errorf("Wasn't able to extract PKI-ID of remote peer with identity of %v", remotePeerIdentity)
-			}
-			return &discovery.PeerIdentification{ID: pkiID, SelfOrg: sameOrg}, nil
+	for _, endpoint := range g.bootResolver.resolve() {
+		g.connect2BootstrapPeer(endpoint)
+	}
+}
+
+func (g *Node) connect2BootstrapPeer(endpoint string) {
+	identifier := func() (*discovery.PeerIdentification, error) {
+		remotePeerIdentity, err := g.comm.Handshake(&comm.RemotePeer{Endpoint: endpoint})
+		if err != nil {
+			return nil, errors.WithStack(err)
 		}
-		g.disc.Connect(discovery.NetworkMember{
-			InternalEndpoint: endpoint,
-			Endpoint:         endpoint,
-		}, identifier)
+		sameOrg := bytes.Equal(g.selfOrg, g.secAdvisor.OrgByPeerIdentity(remotePeerIdentity))
+		if !sameOrg {
+			return nil, errors.Errorf("%s isn't in our organization, cannot be a bootstrap peer", endpoint)
+		}
+		pkiID := g.mcs.GetPKIidOfCert(remotePeerIdentity)
+		if len(pkiID) == 0 {
+			return nil, errors.Errorf("Wasn't able to extract PKI-ID of remote peer with identity of %v", remotePeerIdentity)
+		}
+		return &discovery.PeerIdentification{ID: pkiID, SelfOrg: sameOrg}, nil
 	}
+	g.disc.Connect(discovery.NetworkMember{
+		InternalEndpoint: endpoint,
+		Endpoint:         endpoint,
+	}, identifier)
+}
 
+// periodicBootstrapResolution re-resolves the bootstrap seeds and reconnects
+// to them whenever this peer has no live members in its membership view,
+// so a peer whose bootstrap or anchor peers changed their addresses
+// eventually rejoins the network.
+func (g *Node) periodicBootstrapResolution() {
+	defer g.stopSignal.Done()
+	for {
+		select {
+		case <-g.toDieChan:
+			return
+		case <-time.After(g.conf.BootstrapReresolveInterval):
+			if len(g.disc.GetMembership()) > 0 {
+				continue
+			}
+			g.logger.Info("No live members in membership view, re-resolving bootstrap peers")
+			g.connect2BootstrapPeers()
+		}
+	}
 }
 
 func (g *Node) hasExternalEndpoint(PKIID common.PKIidType) bool {
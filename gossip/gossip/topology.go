@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/filter"
+)
+
+// topology holds the zone and region labels of the peers of the network,
+// keyed by their (lowercased) endpoints. When labels are configured, block
+// forwarding prefers peers in the local zone over peers in the local region,
+// and peers in the local region over the rest of the network, which keeps
+// most of the dissemination traffic off the expensive cross-zone links.
+type topology struct {
+	selfZone   string
+	selfRegion string
+	zones      map[string]string
+	regions    map[string]string
+}
+
+func newTopology(conf *Config) *topology {
+	return &topology{
+		selfZone:   conf.TopologyZone,
+		selfRegion: conf.TopologyRegion,
+		zones:      conf.TopologyZones,
+		regions:    conf.TopologyRegions,
+	}
+}
+
+// enabled returns whether topology aware peer selection is configured
+func (t *topology) enabled() bool {
+	return t.selfZone != "" || t.selfRegion != ""
+}
+
+// zoneOf returns the zone label of the given member, or an empty string if
+// none of its endpoints is labeled
+func (t *topology) zoneOf(member discovery.NetworkMember) string {
+	if zone, exists := t.zones[strings.ToLower(member.Endpoint)]; exists {
+		return zone
+	}
+	return t.zones[strings.ToLower(member.InternalEndpoint)]
+}
+
+// regionOf returns the region label of the given member, or an empty string
+// if none of its endpoints is labeled
+func (t *topology) regionOf(member discovery.NetworkMember) string {
+	if region, exists := t.regions[strings.ToLower(member.Endpoint)]; exists {
+		return region
+	}
+	return t.regions[strings.ToLower(member.InternalEndpoint)]
+}
+
+// crossZone returns whether sending to the given endpoint crosses a zone
+// boundary. An unlabeled endpoint is assumed to be outside our zone.
+func (t *topology) crossZone(endpoint string) bool {
+	return t.selfZone != "" && t.zones[strings.ToLower(endpoint)] != t.selfZone
+}
+
+// crossRegion returns whether sending to the given endpoint crosses a region
+// boundary. An unlabeled endpoint is assumed to be outside our region.
+func (t *topology) crossRegion(endpoint string) bool {
+	return t.selfRegion != "" && t.regions[strings.ToLower(endpoint)] != t.selfRegion
+}
+
+// selectPeers returns a slice of at most k peers from peerPool that match the
+// given routing filter, preferring peers in our zone over peers in our region,
+// and peers in our region over the rest of the network. Without topology
+// labels it is equivalent to filter.SelectPeers.
+func (t *topology) selectPeers(k int, peerPool []discovery.NetworkMember, routingFilter filter.RoutingFilter) []*comm.RemotePeer {
+	if !t.enabled() {
+		return filter.SelectPeers(k, peerPool, routingFilter)
+	}
+
+	var sameZone, sameRegion, remote []discovery.NetworkMember
+	for _, member := range peerPool {
+		switch {
+		case t.selfZone != "" && t.zoneOf(member) == t.selfZone:
+			sameZone = append(sameZone, member)
+		case t.selfRegion != "" && t.regionOf(member) == t.selfRegion:
+			sameRegion = append(sameRegion, member)
+		default:
+			remote = append(remote, member)
+		}
+	}
+
+	res := filter.SelectPeers(k, sameZone, routingFilter)
+	for _, pool := range [][]discovery.NetworkMember{sameRegion, remote} {
+		if len(res) >= k {
+			break
+		}
+		res = append(res, filter.SelectPeers(k-len(res), pool, routingFilter)...)
+	}
+	return res
+}
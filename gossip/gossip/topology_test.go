@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func topologyTestMembership() []discovery.NetworkMember {
+	var membership []discovery.NetworkMember
+	for _, endpoint := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		membership = append(membership, discovery.NetworkMember{
+			Endpoint: endpoint,
+			PKIid:    common.PKIidType(endpoint),
+		})
+	}
+	return membership
+}
+
+func endpointsOf(peers []*comm.RemotePeer) map[string]bool {
+	endpoints := make(map[string]bool)
+	for _, peer := range peers {
+		endpoints[peer.Endpoint] = true
+	}
+	return endpoints
+}
+
+func TestTopologySelectPeersDisabled(t *testing.T) {
+	membership := topologyTestMembership()
+	topology := newTopology(&Config{})
+	assert.False(t, topology.enabled())
+	peers := topology.selectPeers(len(membership), membership, filter.SelectAllPolicy)
+	assert.Len(t, peers, len(membership))
+}
+
+func TestTopologySelectPeersPrefersLocality(t *testing.T) {
+	membership := topologyTestMembership()
+	topology := newTopology(&Config{
+		TopologyZone:   "zone-a",
+		TopologyRegion: "region-1",
+		TopologyZones:  map[string]string{"p1": "zone-a", "p2": "zone-a", "p3": "zone-b"},
+		TopologyRegions: map[string]string{
+			"p1": "region-1", "p2": "region-1", "p3": "region-1", "p4": "region-1", "p5": "region-2",
+		},
+	})
+	assert.True(t, topology.enabled())
+
+	// With enough peers in our zone, no cross-zone peer is selected
+	peers := topology.selectPeers(2, membership, filter.SelectAllPolicy)
+	assert.Equal(t, map[string]bool{"p1": true, "p2": true}, endpointsOf(peers))
+
+	// Once the zone is exhausted, peers of our region fill up the selection
+	peers = topology.selectPeers(4, membership, filter.SelectAllPolicy)
+	assert.Equal(t, map[string]bool{"p1": true, "p2": true, "p3": true, "p4": true}, endpointsOf(peers))
+
+	// A selection bigger than the region spills over to remote peers
+	peers = topology.selectPeers(6, membership, filter.SelectAllPolicy)
+	assert.Len(t, peers, 6)
+
+	// The routing filter is still applied to nearby peers: with p1 filtered
+	// out, the open slot is filled from the region tier, never from a remote
+	// peer
+	peers = topology.selectPeers(2, membership, func(member discovery.NetworkMember) bool {
+		return member.Endpoint != "p1"
+	})
+	selected := endpointsOf(peers)
+	assert.Len(t, selected, 2)
+	assert.Contains(t, selected, "p2")
+	assert.NotContains(t, selected, "p1")
+	assert.NotContains(t, selected, "p5")
+	assert.NotContains(t, selected, "p6")
+}
+
+func TestTopologyInternalEndpointLabels(t *testing.T) {
+	membership := []discovery.NetworkMember{
+		{InternalEndpoint: "p1", PKIid: common.PKIidType("p1")},
+		{InternalEndpoint: "p2", PKIid: common.PKIidType("p2")},
+	}
+	topology := newTopology(&Config{
+		TopologyZone:  "zone-a",
+		TopologyZones: map[string]string{"p1": "zone-a"},
+	})
+
+	peers := topology.selectPeers(1, membership, filter.SelectAllPolicy)
+	assert.Equal(t, map[string]bool{"p1": true}, endpointsOf(peers))
+}
+
+func TestTopologyCrossZoneAndRegion(t *testing.T) {
+	topology := newTopology(&Config{
+		TopologyZone:    "zone-a",
+		TopologyRegion:  "region-1",
+		TopologyZones:   map[string]string{"p1": "zone-a", "p2": "zone-b"},
+		TopologyRegions: map[string]string{"p1": "region-1", "p2": "region-1"},
+	})
+
+	assert.False(t, topology.crossZone("p1"))
+	assert.False(t, topology.crossRegion("p1"))
+	// A peer of another zone in our region only crosses the zone boundary
+	assert.True(t, topology.crossZone("p2"))
+	assert.False(t, topology.crossRegion("p2"))
+	// An unlabeled peer is assumed to be remote
+	assert.True(t, topology.crossZone("p3"))
+	assert.True(t, topology.crossRegion("p3"))
+	// Endpoints are matched regardless of case
+	assert.False(t, topology.crossZone("P1"))
+}
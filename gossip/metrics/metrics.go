@@ -32,6 +32,7 @@ type StateMetrics struct {
 	Height            metrics.Gauge
 	CommitDuration    metrics.Histogram
 	PayloadBufferSize metrics.Gauge
+	FetchedBlocks     metrics.Counter
 }
 
 func newStateMetrics(p metrics.Provider) *StateMetrics {
@@ -39,6 +40,7 @@ func newStateMetrics(p metrics.Provider) *StateMetrics {
 		Height:            p.NewGauge(HeightOpts),
 		CommitDuration:    p.NewHistogram(CommitDurationOpts),
 		PayloadBufferSize: p.NewGauge(PayloadBufferSizeOpts),
+		FetchedBlocks:     p.NewCounter(FetchedBlocksOpts),
 	}
 }
 
@@ -69,6 +71,15 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	FetchedBlocksOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "fetched_blocks",
+		Help:         "Number of blocks fetched from remote peers by state transfer",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 // ElectionMetrics encapsulates gossip leader election related metrics
@@ -95,16 +106,22 @@ var (
 
 // CommMetrics encapsulates gossip communication related metrics
 type CommMetrics struct {
-	SentMessages     metrics.Counter
-	BufferOverflow   metrics.Counter
-	ReceivedMessages metrics.Counter
+	SentMessages            metrics.Counter
+	BufferOverflow          metrics.Counter
+	ReceivedMessages        metrics.Counter
+	CompressionSavedBytes   metrics.Counter
+	CrossZoneSentMessages   metrics.Counter
+	CrossRegionSentMessages metrics.Counter
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
 	return &CommMetrics{
-		SentMessages:     p.NewCounter(SentMessagesOpts),
-		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
-		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+		SentMessages:            p.NewCounter(SentMessagesOpts),
+		BufferOverflow:          p.NewCounter(BufferOverflowOpts),
+		ReceivedMessages:        p.NewCounter(ReceivedMessagesOpts),
+		CompressionSavedBytes:   p.NewCounter(CompressionSavedBytesOpts),
+		CrossZoneSentMessages:   p.NewCounter(CrossZoneSentMessagesOpts),
+		CrossRegionSentMessages: p.NewCounter(CrossRegionSentMessagesOpts),
 	}
 }
 
@@ -132,6 +149,32 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	CompressionSavedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "compression_saved_bytes",
+		Help:         "Number of bytes saved by compressing message payloads",
+		StatsdFormat: "%{#fqname}",
+	}
+
+	CrossZoneSentMessagesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "cross_zone_messages_sent",
+		Help:         "Number of blocks forwarded to peers outside of the local zone",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	CrossRegionSentMessagesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "cross_region_messages_sent",
+		Help:         "Number of blocks forwarded to peers outside of the local region",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
@@ -164,6 +207,7 @@ type PrivdataMetrics struct {
 	CommitPrivateDataDuration      metrics.Histogram
 	PurgeDuration                  metrics.Histogram
 	SendDuration                   metrics.Histogram
+	SendFailures                   metrics.Counter
 	ReconciliationDuration         metrics.Histogram
 	PullDuration                   metrics.Histogram
 	RetrieveDuration               metrics.Histogram
@@ -177,6 +221,7 @@ func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
 		CommitPrivateDataDuration:      p.NewHistogram(CommitPrivateDataDurationOpts),
 		PurgeDuration:                  p.NewHistogram(PurgeDurationOpts),
 		SendDuration:                   p.NewHistogram(SendDurationOpts),
+		SendFailures:                   p.NewCounter(SendFailuresOpts),
 		ReconciliationDuration:         p.NewHistogram(ReconciliationDurationOpts),
 		PullDuration:                   p.NewHistogram(PullDurationOpts),
 		RetrieveDuration:               p.NewHistogram(RetrieveDurationOpts),
@@ -238,6 +283,15 @@ var (
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
 
+	SendFailuresOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "send_failures",
+		Help:         "Number of private data dissemination plans that could not be sent",
+		LabelNames:   []string{"channel", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{collection}",
+	}
+
 	ReconciliationDurationOpts = metrics.HistogramOpts{
 		Namespace:    "gossip",
 		Subsystem:    "privdata",
@@ -34,6 +34,7 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.StateMetrics.Height)
 	assert.NotNil(t, gossipMetrics.StateMetrics.CommitDuration)
 	assert.NotNil(t, gossipMetrics.StateMetrics.PayloadBufferSize)
+	assert.NotNil(t, gossipMetrics.StateMetrics.FetchedBlocks)
 
 	assert.NotNil(t, gossipMetrics.ElectionMetrics)
 	assert.NotNil(t, gossipMetrics.ElectionMetrics.Declaration)
@@ -42,6 +43,8 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	assert.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	assert.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	assert.NotNil(t, gossipMetrics.CommMetrics.CrossZoneSentMessages)
+	assert.NotNil(t, gossipMetrics.CommMetrics.CrossRegionSentMessages)
 
 	assert.NotNil(t, gossipMetrics.MembershipMetrics)
 	assert.NotNil(t, gossipMetrics.MembershipMetrics.Total)
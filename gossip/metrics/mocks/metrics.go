@@ -18,12 +18,15 @@ type TestMetricProvider struct {
 	FakeHeightGauge            *metricsfakes.Gauge
 	FakeCommitDurationHist     *metricsfakes.Histogram
 	FakePayloadBufferSizeGauge *metricsfakes.Gauge
+	FakeFetchedBlocks          *metricsfakes.Counter
 
 	FakeDeclarationGauge *metricsfakes.Gauge
 
-	FakeSentMessages     *metricsfakes.Counter
-	FakeBufferOverflow   *metricsfakes.Counter
-	FakeReceivedMessages *metricsfakes.Counter
+	FakeSentMessages            *metricsfakes.Counter
+	FakeBufferOverflow          *metricsfakes.Counter
+	FakeReceivedMessages        *metricsfakes.Counter
+	FakeCrossZoneSentMessages   *metricsfakes.Counter
+	FakeCrossRegionSentMessages *metricsfakes.Counter
 
 	FakeTotalGauge *metricsfakes.Gauge
 
@@ -33,6 +36,7 @@ type TestMetricProvider struct {
 	FakeCommitPrivateDataDuration      *metricsfakes.Histogram
 	FakePurgeDuration                  *metricsfakes.Histogram
 	FakeSendDuration                   *metricsfakes.Histogram
+	FakeSendFailures                   *metricsfakes.Counter
 	FakeReconciliationDuration         *metricsfakes.Histogram
 	FakePullDuration                   *metricsfakes.Histogram
 	FakeRetrieveDuration               *metricsfakes.Histogram
@@ -44,12 +48,15 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeHeightGauge := testUtilConstructGauge()
 	fakeCommitDurationHist := testUtilConstructHist()
 	fakePayloadBufferSizeGauge := testUtilConstructGauge()
+	fakeFetchedBlocks := testUtilConstructCounter()
 
 	fakeDeclarationGauge := testUtilConstructGauge()
 
 	fakeSentMessages := testUtilConstructCounter()
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
+	fakeCrossZoneSentMessages := testUtilConstructCounter()
+	fakeCrossRegionSentMessages := testUtilConstructCounter()
 
 	fakeTotalGauge := testUtilConstructGauge()
 
@@ -59,6 +66,7 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeCommitPrivateDataDuration := testUtilConstructHist()
 	fakePurgeDuration := testUtilConstructHist()
 	fakeSendDuration := testUtilConstructHist()
+	fakeSendFailures := testUtilConstructCounter()
 	fakeReconciliationDuration := testUtilConstructHist()
 	fakePullDuration := testUtilConstructHist()
 	fakeRetrieveDuration := testUtilConstructHist()
@@ -71,6 +79,14 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.SendFailuresOpts.Name:
+			return fakeSendFailures
+		case gmetrics.FetchedBlocksOpts.Name:
+			return fakeFetchedBlocks
+		case gmetrics.CrossZoneSentMessagesOpts.Name:
+			return fakeCrossZoneSentMessages
+		case gmetrics.CrossRegionSentMessagesOpts.Name:
+			return fakeCrossRegionSentMessages
 		}
 		return nil
 	}
@@ -118,10 +134,13 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeHeightGauge,
 		fakeCommitDurationHist,
 		fakePayloadBufferSizeGauge,
+		fakeFetchedBlocks,
 		fakeDeclarationGauge,
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeCrossZoneSentMessages,
+		fakeCrossRegionSentMessages,
 		fakeTotalGauge,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
@@ -129,6 +148,7 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeCommitPrivateDataDuration,
 		fakePurgeDuration,
 		fakeSendDuration,
+		fakeSendFailures,
 		fakeReconciliationDuration,
 		fakePullDuration,
 		fakeRetrieveDuration,
@@ -8,8 +8,10 @@ package privdata
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -17,6 +19,8 @@ const (
 	reconcileSleepIntervalDefault         = time.Minute
 	reconcileBatchSizeDefault             = 10
 	implicitCollectionMaxPeerCountDefault = 1
+	pushRetryAttemptsDefault              = 1
+	pushRetryBackoffDefault               = 100 * time.Millisecond
 )
 
 // PrivdataConfig is the struct that defines the Gossip Privdata configurations.
@@ -30,6 +34,20 @@ type PrivdataConfig struct {
 	ReconciliationEnabled bool
 	// ImplicitCollectionDisseminationPolicy specifies the dissemination  policy for the peer's own implicit collection.
 	ImplicitCollDisseminationPolicy ImplicitCollectionDisseminationPolicy
+	// PushRetryPolicy is the retry policy applied when pushing private data to a peer fails during dissemination.
+	PushRetryPolicy DisseminationRetryPolicy
+	// PushRetryOverrides maps a collection to a retry policy that overrides PushRetryPolicy for that collection.
+	// Keys are either a plain collection name or "<chaincode>/<collection>".
+	PushRetryOverrides map[string]DisseminationRetryPolicy
+}
+
+// DisseminationRetryPolicy controls how a failed push of private data to a peer
+// is retried during dissemination.
+type DisseminationRetryPolicy struct {
+	// Attempts is the total number of times a single push is attempted. Default is 1, meaning no retries.
+	Attempts int
+	// Backoff is the time waited before the first retry; it is doubled on every subsequent retry.
+	Backoff time.Duration
 }
 
 // ImplicitCollectionDisseminationPolicy specifies the dissemination  policy for the peer's own implicit collection.
@@ -85,4 +103,53 @@ func (c *PrivdataConfig) loadPrivDataConfig() {
 
 	c.ImplicitCollDisseminationPolicy.RequiredPeerCount = requiredPeerCount
 	c.ImplicitCollDisseminationPolicy.MaxPeerCount = maxPeerCount
+
+	attempts := pushRetryAttemptsDefault
+	if viper.Get("peer.gossip.pvtData.pushRetryAttempts") != nil {
+		attempts = viper.GetInt("peer.gossip.pvtData.pushRetryAttempts")
+	}
+	backoff := pushRetryBackoffDefault
+	if viper.Get("peer.gossip.pvtData.pushRetryBackoff") != nil {
+		backoff = viper.GetDuration("peer.gossip.pvtData.pushRetryBackoff")
+	}
+	if attempts < 1 {
+		panic(fmt.Sprintf("peer.gossip.pvtData.pushRetryAttempts (%d) cannot be less than one", attempts))
+	}
+	c.PushRetryPolicy = DisseminationRetryPolicy{
+		Attempts: attempts,
+		Backoff:  backoff,
+	}
+
+	for collection, value := range viper.GetStringMap("peer.gossip.pvtData.pushRetryOverrides") {
+		override := cast.ToStringMap(value)
+		policy := c.PushRetryPolicy
+		if attempts, exists := override["attempts"]; exists {
+			policy.Attempts = cast.ToInt(attempts)
+		}
+		if backoff, exists := override["backoff"]; exists {
+			policy.Backoff = cast.ToDuration(backoff)
+		}
+		if policy.Attempts < 1 {
+			panic(fmt.Sprintf("peer.gossip.pvtData.pushRetryOverrides.%s.attempts (%d) cannot be less than one",
+				collection, policy.Attempts))
+		}
+		if c.PushRetryOverrides == nil {
+			c.PushRetryOverrides = make(map[string]DisseminationRetryPolicy)
+		}
+		c.PushRetryOverrides[collection] = policy
+	}
+}
+
+// RetryPolicyForCollection returns the retry policy to apply when disseminating private data
+// of the given chaincode's collection. A policy configured for "<chaincode>/<collection>"
+// takes precedence over one configured for the plain collection name. The lookup is case
+// insensitive since viper lowercases configuration keys.
+func (c *PrivdataConfig) RetryPolicyForCollection(namespace, collection string) DisseminationRetryPolicy {
+	if policy, exists := c.PushRetryOverrides[strings.ToLower(namespace+"/"+collection)]; exists {
+		return policy
+	}
+	if policy, exists := c.PushRetryOverrides[strings.ToLower(collection)]; exists {
+		return policy
+	}
+	return c.PushRetryPolicy
 }
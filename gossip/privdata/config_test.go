@@ -23,6 +23,12 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.pvtData.reconciliationEnabled", true)
 	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.requiredPeerCount", 2)
 	viper.Set("peer.gossip.pvtData.implicitCollectionDisseminationPolicy.maxPeerCount", 3)
+	viper.Set("peer.gossip.pvtData.pushRetryAttempts", 2)
+	viper.Set("peer.gossip.pvtData.pushRetryBackoff", "50ms")
+	viper.Set("peer.gossip.pvtData.pushRetryOverrides", map[string]interface{}{
+		"mycc/col1": map[string]interface{}{"attempts": 4},
+		"col2":      map[string]interface{}{"backoff": "1s"},
+	})
 
 	coreConfig := privdata.GlobalConfig()
 
@@ -34,6 +40,14 @@ func TestGlobalConfig(t *testing.T) {
 			RequiredPeerCount: 2,
 			MaxPeerCount:      3,
 		},
+		PushRetryPolicy: privdata.DisseminationRetryPolicy{
+			Attempts: 2,
+			Backoff:  50 * time.Millisecond,
+		},
+		PushRetryOverrides: map[string]privdata.DisseminationRetryPolicy{
+			"mycc/col1": {Attempts: 4, Backoff: 50 * time.Millisecond},
+			"col2":      {Attempts: 2, Backoff: time.Second},
+		},
 	}
 
 	assert.Equal(t, coreConfig, expectedConfig)
@@ -52,6 +66,10 @@ func TestGlobalConfigDefaults(t *testing.T) {
 			RequiredPeerCount: 0,
 			MaxPeerCount:      1,
 		},
+		PushRetryPolicy: privdata.DisseminationRetryPolicy{
+			Attempts: 1,
+			Backoff:  100 * time.Millisecond,
+		},
 	}
 
 	assert.Equal(t, coreConfig, expectedConfig)
@@ -80,3 +98,21 @@ func TestGlobalConfigPanic(t *testing.T) {
 		"A panic should occur because requiredPeerCount is less than zero",
 	)
 }
+
+func TestRetryPolicyForCollection(t *testing.T) {
+	config := &privdata.PrivdataConfig{
+		PushRetryPolicy: privdata.DisseminationRetryPolicy{Attempts: 1},
+		PushRetryOverrides: map[string]privdata.DisseminationRetryPolicy{
+			"mycc/col1": {Attempts: 4},
+			"col1":      {Attempts: 3},
+		},
+	}
+
+	// the chaincode qualified override takes precedence over the plain collection name
+	assert.Equal(t, 4, config.RetryPolicyForCollection("mycc", "col1").Attempts)
+	assert.Equal(t, 3, config.RetryPolicyForCollection("othercc", "col1").Attempts)
+	// the lookup is case insensitive since viper lowercases configuration keys
+	assert.Equal(t, 4, config.RetryPolicyForCollection("MyCC", "Col1").Attempts)
+	// collections without an override fall back to the default policy
+	assert.Equal(t, 1, config.RetryPolicyForCollection("mycc", "col2").Attempts)
+}
@@ -79,6 +79,7 @@ type distributorImpl struct {
 	CollectionAccessFactory
 	pushAckTimeout time.Duration
 	metrics        *metrics.PrivdataMetrics
+	privdataConfig *PrivdataConfig
 }
 
 //go:generate mockery -dir . -name CollectionAccessFactory -case underscore -output ./mocks/
@@ -124,13 +125,14 @@ func NewCollectionAccessFactory(factory IdentityDeserializerFactory) CollectionA
 // NewDistributor a constructor for private data distributor capable to send
 // private read write sets for underlying collection
 func NewDistributor(chainID string, gossip gossipAdapter, factory CollectionAccessFactory,
-	metrics *metrics.PrivdataMetrics, pushAckTimeout time.Duration) PvtDataDistributor {
+	metrics *metrics.PrivdataMetrics, pushAckTimeout time.Duration, privdataConfig *PrivdataConfig) PvtDataDistributor {
 	return &distributorImpl{
 		chainID:                 chainID,
 		gossipAdapter:           gossip,
 		CollectionAccessFactory: factory,
 		pushAckTimeout:          pushAckTimeout,
 		metrics:                 metrics,
+		privdataConfig:          privdataConfig,
 	}
 }
 
@@ -144,8 +146,9 @@ func (d *distributorImpl) Distribute(txID string, privData *transientstore.TxPvt
 }
 
 type dissemination struct {
-	msg      *protoext.SignedGossipMessage
-	criteria gossipgossip.SendCriteria
+	msg         *protoext.SignedGossipMessage
+	criteria    gossipgossip.SendCriteria
+	retryPolicy DisseminationRetryPolicy
 }
 
 func (d *distributorImpl) computeDisseminationPlan(txID string,
@@ -225,12 +228,14 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	}
 
 	m := pvtDataMsg.GetPrivateData().Payload
+	retryPolicy := d.retryPolicyForCollection(m.Namespace, m.CollectionName)
 
 	eligiblePeers := d.eligiblePeersOfChannel(routingFilter)
 
 	// With the shift to per peer dissemination in FAB-15389, we must first check
 	// that there are enough eligible peers to satisfy RequiredPeerCount.
 	if (len(eligiblePeers)) < colAP.RequiredPeerCount() {
+		d.reportSendFailure(m.CollectionName)
 		return nil, errors.Errorf("required to disseminate to at least %d peers, but know of only %d eligible peers", colAP.RequiredPeerCount(), len(eligiblePeers))
 	}
 
@@ -286,6 +291,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 					Envelope:      proto.Clone(pvtDataMsg.Envelope).(*protosgossip.Envelope),
 					GossipMessage: proto.Clone(pvtDataMsg.GossipMessage).(*protosgossip.GossipMessage),
 				},
+				retryPolicy: retryPolicy,
 			})
 
 			// Add unselected peers to remainingPeersAcrossOrgs
@@ -339,6 +345,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 				Envelope:      proto.Clone(pvtDataMsg.Envelope).(*protosgossip.Envelope),
 				GossipMessage: proto.Clone(pvtDataMsg.GossipMessage).(*protosgossip.GossipMessage),
 			},
+			retryPolicy: retryPolicy,
 		})
 		if requiredPeerRemainingCount > 0 {
 			requiredPeerRemainingCount--
@@ -393,10 +400,11 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 		go func(dis *dissemination) {
 			defer wg.Done()
 			defer d.reportSendDuration(start)
-			err := d.SendByCriteria(dis.msg, dis.criteria)
+			err := d.sendWithRetries(dis)
 			if err != nil {
 				atomic.AddUint32(&failures, 1)
 				m := dis.msg.GetPrivateData().Payload
+				d.reportSendFailure(m.CollectionName)
 				logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
 			}
 		}(dis)
@@ -409,10 +417,41 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 	return nil
 }
 
+// sendWithRetries sends a single dissemination, retrying failed sends with an
+// exponential backoff according to the dissemination's retry policy.
+func (d *distributorImpl) sendWithRetries(dis *dissemination) error {
+	backoff := dis.retryPolicy.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = d.SendByCriteria(dis.msg, dis.criteria); err == nil {
+			return nil
+		}
+		if attempt >= dis.retryPolicy.Attempts {
+			return err
+		}
+		m := dis.msg.GetPrivateData().Payload
+		logger.Warningf("Attempt %d out of %d to disseminate private RWSet for TxID [%s] collection [%s] failed: %s, retrying in %v",
+			attempt, dis.retryPolicy.Attempts, m.TxId, m.CollectionName, err, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (d *distributorImpl) retryPolicyForCollection(namespace, collection string) DisseminationRetryPolicy {
+	if d.privdataConfig == nil {
+		return DisseminationRetryPolicy{Attempts: 1}
+	}
+	return d.privdataConfig.RetryPolicyForCollection(namespace, collection)
+}
+
 func (d *distributorImpl) reportSendDuration(startTime time.Time) {
 	d.metrics.SendDuration.With("channel", d.chainID).Observe(time.Since(startTime).Seconds())
 }
 
+func (d *distributorImpl) reportSendFailure(collection string) {
+	d.metrics.SendFailures.With("channel", d.chainID, "collection", collection).Add(1)
+}
+
 func (d *distributorImpl) createPrivateDataMessage(txID, namespace string,
 	collection *rwset.CollectionPvtReadWriteSet,
 	ccp *peer.CollectionConfigPackage,
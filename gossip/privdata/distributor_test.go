@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -147,7 +148,7 @@ func TestDistributor(t *testing.T) {
 	testMetricProvider := mocks.TestUtilConstructMetricProvider()
 	metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
 
-	d := NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	d := NewDistributor(channelID, g, accessFactoryMock, metrics, 0, nil)
 	pdFactory := &pvtDataFactory{}
 	pvtData := pdFactory.addRWSet().addNSRWSet("ns1", "c1", "c2").addRWSet().addNSRWSet("ns2", "c1", "c2").create()
 	err := d.Distribute("tx1", &transientstore.TxPvtReadWriteSetWithConfigInfo{
@@ -239,3 +240,98 @@ func TestDistributor(t *testing.T) {
 	)
 	assert.True(t, testMetricProvider.FakeSendDuration.ObserveArgsForCall(0) > 0)
 }
+
+func TestDistributorSendRetries(t *testing.T) {
+	channelID := "test"
+
+	g := &gossipMock{
+		Mock: mock.Mock{},
+		PeerSignature: api.PeerSignature{
+			Signature:    []byte{3, 4, 5},
+			Message:      []byte{6, 7, 8},
+			PeerIdentity: []byte{0, 1, 2},
+		},
+	}
+
+	g.On("PeersOfChannel", gcommon.ChannelID(channelID)).Return([]discovery.NetworkMember{
+		{PKIid: gcommon.PKIidType{1}},
+	})
+
+	g.On("IdentityInfo").Return(api.PeerIdentitySet{
+		{
+			PKIId:        gcommon.PKIidType{1},
+			Organization: api.OrgIdentityType("org1"),
+		},
+	})
+
+	// the first two sends fail, the third succeeds
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(errors.New("failed sending")).Twice()
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(nil)
+
+	c1ColConfig := &peer.CollectionConfig{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &peer.StaticCollectionConfig{
+				Name:              "c1",
+				RequiredPeerCount: 1,
+				MaximumPeerCount:  1,
+			},
+		},
+	}
+
+	policyMock := &mocks2.CollectionAccessPolicy{}
+	Setup(policyMock, 1, 1, func(_ protoutil.SignedData) bool {
+		return true
+	}, map[string]struct{}{
+		"org1": {},
+	}, false)
+
+	accessFactoryMock := &mocks2.CollectionAccessFactory{}
+	accessFactoryMock.On("AccessPolicy", c1ColConfig, channelID).Return(policyMock, nil)
+
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
+
+	privdataConfig := &PrivdataConfig{
+		PushRetryPolicy: DisseminationRetryPolicy{Attempts: 1},
+		PushRetryOverrides: map[string]DisseminationRetryPolicy{
+			"c1": {Attempts: 3, Backoff: time.Millisecond},
+		},
+	}
+
+	d := NewDistributor(channelID, g, accessFactoryMock, metrics, 0, privdataConfig)
+	pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+	privDataWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: pvtData[0].WriteSet,
+		CollectionConfigs: map[string]*peer.CollectionConfigPackage{
+			"ns1": {
+				Config: []*peer.CollectionConfig{c1ColConfig},
+			},
+		},
+	}
+	err := d.Distribute("tx1", privDataWithConfig, 0)
+	assert.NoError(t, err)
+	g.AssertNumberOfCalls(t, "SendByCriteria", 3)
+
+	// when all attempts fail, the dissemination fails and is reported
+	g.Mock = mock.Mock{}
+	g.On("PeersOfChannel", gcommon.ChannelID(channelID)).Return([]discovery.NetworkMember{
+		{PKIid: gcommon.PKIidType{1}},
+	})
+	g.On("IdentityInfo").Return(api.PeerIdentitySet{
+		{
+			PKIId:        gcommon.PKIidType{1},
+			Organization: api.OrgIdentityType("org1"),
+		},
+	})
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(errors.New("failed sending"))
+
+	err = d.Distribute("tx2", privDataWithConfig, 0)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed disseminating 1 out of 1 private dissemination plans")
+	g.AssertNumberOfCalls(t, "SendByCriteria", 3)
+	assert.Equal(t,
+		[]string{"channel", channelID, "collection", "c1"},
+		testMetricProvider.FakeSendFailures.WithArgsForCall(0),
+	)
+	assert.Equal(t, float64(1), testMetricProvider.FakeSendFailures.AddArgsForCall(0))
+}
@@ -10,7 +10,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	protosgossip "github.com/hyperledger/fabric-protos-go/gossip"
@@ -54,6 +56,38 @@ type PvtDataReconciler interface {
 	Stop()
 }
 
+// ReconciliationController is an optional interface, implemented by a reconciler that
+// can report its status and be paused or triggered on demand by an operator.
+// The NoOpReconciler, used when reconciliation is disabled, does not implement it.
+type ReconciliationController interface {
+	// ReconciliationStatus returns a summary of the missing private data per collection
+	ReconciliationStatus() (*ReconciliationStatus, error)
+	// PauseReconciliation suspends the scheduled reconciliation cycles until resumed
+	PauseReconciliation()
+	// ResumeReconciliation resumes the scheduled reconciliation cycles
+	ResumeReconciliation()
+	// TriggerReconciliation runs a reconciliation cycle immediately, regardless of the
+	// scheduler and of whether the scheduled cycles are paused
+	TriggerReconciliation() error
+}
+
+// MissingPvtDataSummary aggregates the missing private data of a single collection
+// across the most recently scanned blocks
+type MissingPvtDataSummary struct {
+	Namespace  string `json:"namespace"`
+	Collection string `json:"collection"`
+	Count      int    `json:"count"`
+	MinBlock   uint64 `json:"min_block"`
+	MaxBlock   uint64 `json:"max_block"`
+}
+
+// ReconciliationStatus describes the state of the reconciler of a channel along with
+// a per collection summary of the missing private data it has yet to fetch
+type ReconciliationStatus struct {
+	Paused         bool                     `json:"paused"`
+	MissingPvtData []*MissingPvtDataSummary `json:"missing_pvt_data"`
+}
+
 type Reconciler struct {
 	channel                string
 	metrics                *metrics.PrivdataMetrics
@@ -62,6 +96,8 @@ type Reconciler struct {
 	stopChan               chan struct{}
 	startOnce              sync.Once
 	stopOnce               sync.Once
+	paused                 int32
+	reconcileLock          sync.Mutex
 	ReconciliationFetcher
 	committer.Committer
 }
@@ -113,6 +149,10 @@ func (r *Reconciler) run() {
 		case <-r.stopChan:
 			return
 		case <-time.After(r.ReconcileSleepInterval):
+			if r.isPaused() {
+				logger.Debug("Reconciliation is paused, skipping the scheduled cycle")
+				continue
+			}
 			logger.Debug("Start reconcile missing private info")
 			if err := r.reconcile(); err != nil {
 				logger.Error("Failed to reconcile missing private info, error: ", err.Error())
@@ -122,8 +162,96 @@ func (r *Reconciler) run() {
 	}
 }
 
+// PauseReconciliation suspends the scheduled reconciliation cycles until resumed
+func (r *Reconciler) PauseReconciliation() {
+	atomic.StoreInt32(&r.paused, 1)
+	logger.Info("Reconciliation has been paused for channel", r.channel)
+}
+
+// ResumeReconciliation resumes the scheduled reconciliation cycles
+func (r *Reconciler) ResumeReconciliation() {
+	atomic.StoreInt32(&r.paused, 0)
+	logger.Info("Reconciliation has been resumed for channel", r.channel)
+}
+
+func (r *Reconciler) isPaused() bool {
+	return atomic.LoadInt32(&r.paused) == 1
+}
+
+// TriggerReconciliation runs a reconciliation cycle immediately, regardless of the
+// scheduler and of whether the scheduled cycles are paused
+func (r *Reconciler) TriggerReconciliation() error {
+	logger.Info("Reconciliation cycle triggered on demand for channel", r.channel)
+	return r.reconcile()
+}
+
+// ReconciliationStatus returns a summary of the missing private data per collection,
+// gathered from the most recent blocks considered by a reconciliation cycle
+func (r *Reconciler) ReconciliationStatus() (*ReconciliationStatus, error) {
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get missingPvtDataTracker")
+	}
+	if missingPvtDataTracker == nil {
+		return nil, errors.New("got nil as MissingPvtDataTracker")
+	}
+	missingPvtDataInfo, err := missingPvtDataTracker.GetMissingPvtDataInfoForMostRecentBlocks(r.ReconcileBatchSize)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get missing pvt data info for most recent blocks")
+	}
+
+	summaries := make(map[collectionSummaryKey]*MissingPvtDataSummary)
+	for blockNum, blockPvtDataInfo := range missingPvtDataInfo {
+		for _, collectionPvtDataInfo := range blockPvtDataInfo {
+			for _, pvtDataInfo := range collectionPvtDataInfo {
+				key := collectionSummaryKey{chaincodeName: pvtDataInfo.Namespace, collectionName: pvtDataInfo.Collection}
+				summary, exists := summaries[key]
+				if !exists {
+					summary = &MissingPvtDataSummary{
+						Namespace:  pvtDataInfo.Namespace,
+						Collection: pvtDataInfo.Collection,
+						MinBlock:   blockNum,
+						MaxBlock:   blockNum,
+					}
+					summaries[key] = summary
+				}
+				summary.Count++
+				if blockNum < summary.MinBlock {
+					summary.MinBlock = blockNum
+				}
+				if blockNum > summary.MaxBlock {
+					summary.MaxBlock = blockNum
+				}
+			}
+		}
+	}
+
+	status := &ReconciliationStatus{
+		Paused:         r.isPaused(),
+		MissingPvtData: []*MissingPvtDataSummary{},
+	}
+	for _, summary := range summaries {
+		status.MissingPvtData = append(status.MissingPvtData, summary)
+	}
+	sort.Slice(status.MissingPvtData, func(i, j int) bool {
+		a, b := status.MissingPvtData[i], status.MissingPvtData[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Collection < b.Collection
+	})
+	return status, nil
+}
+
+type collectionSummaryKey struct {
+	chaincodeName, collectionName string
+}
+
 // returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
 func (r *Reconciler) reconcile() error {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
 	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
 	if err != nil {
 		logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
@@ -517,3 +517,86 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
+
+func TestReconciliationStatus(t *testing.T) {
+	// Scenario: the reconciler reports the missing private data aggregated per collection,
+	// along with whether the scheduled cycles are paused.
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	missingInfo := ledger.MissingPvtDataInfo{
+		3: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			1: {{Collection: "col1", Namespace: "ns1"}},
+		},
+		5: map[uint64][]*ledger.MissingCollectionPvtDataInfo{
+			0: {{Collection: "col1", Namespace: "ns1"}, {Collection: "col2", Namespace: "ns1"}},
+		},
+	}
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Return(missingInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+
+	r := NewReconciler("", metrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Minute, ReconcileBatchSize: 10, ReconciliationEnabled: true})
+
+	status, err := r.ReconciliationStatus()
+	assert.NoError(t, err)
+	assert.False(t, status.Paused)
+	assert.Equal(t, []*MissingPvtDataSummary{
+		{Namespace: "ns1", Collection: "col1", Count: 2, MinBlock: 3, MaxBlock: 5},
+		{Namespace: "ns1", Collection: "col2", Count: 1, MinBlock: 5, MaxBlock: 5},
+	}, status.MissingPvtData)
+
+	r.PauseReconciliation()
+	status, err = r.ReconciliationStatus()
+	assert.NoError(t, err)
+	assert.True(t, status.Paused)
+}
+
+func TestPauseAndTriggerReconciliation(t *testing.T) {
+	// Scenario: the reconciler is paused, so the scheduled cycles don't scan the ledger.
+	// an explicit trigger still runs a cycle, and resuming re-enables the scheduler.
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	var lock sync.Mutex
+	trackerCalls := 0
+	missingPvtDataTracker.On("GetMissingPvtDataInfoForMostRecentBlocks", mock.Anything).Run(func(_ mock.Arguments) {
+		lock.Lock()
+		trackerCalls++
+		lock.Unlock()
+	}).Return(ledger.MissingPvtDataInfo{}, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+
+	r := NewReconciler("", metrics, committer, fetcher,
+		&PrivdataConfig{ReconcileSleepInterval: time.Millisecond, ReconcileBatchSize: 1, ReconciliationEnabled: true})
+	r.PauseReconciliation()
+	r.Start()
+	defer r.Stop()
+
+	time.Sleep(time.Millisecond * 50)
+	lock.Lock()
+	assert.Equal(t, 0, trackerCalls)
+	lock.Unlock()
+
+	assert.NoError(t, r.TriggerReconciliation())
+	lock.Lock()
+	assert.Equal(t, 1, trackerCalls)
+	lock.Unlock()
+
+	r.ResumeReconciliation()
+	deadline := time.Now().Add(time.Second * 10)
+	for time.Now().Before(deadline) {
+		lock.Lock()
+		calls := trackerCalls
+		lock.Unlock()
+		if calls > 1 {
+			return
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	t.Fatal("expected the scheduled reconciliation cycles to resume")
+}
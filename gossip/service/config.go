@@ -6,10 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package service
 
 import (
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 )
 
@@ -37,6 +39,11 @@ type ServiceConfig struct {
 	// ElectionLeaderAliveThreshold is the time passes since last declaration message before peer decides to
 	// perform leader election (unit: second).
 	ElectionLeaderAliveThreshold time.Duration
+	// ElectionLeaderPriorities maps peer endpoints to an election priority. Peers with a higher
+	// priority win the leader election over peers with a lower one, and ties are broken by
+	// comparing PKI-IDs. Peers whose endpoints aren't mapped have a priority of zero. The map
+	// should be identical on all peers of the organization for the election to stay deterministic.
+	ElectionLeaderPriorities map[string]int
 	// ElectionLeaderElectionDuration is the time passes since last declaration message before peer decides to perform
 	// leader election (unit: second).
 	ElectionLeaderElectionDuration time.Duration
@@ -77,6 +84,13 @@ func (c *ServiceConfig) loadGossipConfig() {
 	c.ElectionLeaderAliveThreshold = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold)
 	c.ElectionLeaderElectionDuration = util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration)
 
+	for endpoint, value := range viper.GetStringMap("peer.gossip.election.leaderPriorities") {
+		if c.ElectionLeaderPriorities == nil {
+			c.ElectionLeaderPriorities = make(map[string]int)
+		}
+		c.ElectionLeaderPriorities[strings.ToLower(endpoint)] = cast.ToInt(value)
+	}
+
 	c.PvtDataPushAckTimeout = viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	c.PvtDataPullRetryThreshold = viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold")
 	c.SkipPullingInvalidTransactionsDuringCommit = viper.GetBool("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit")
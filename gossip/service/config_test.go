@@ -28,6 +28,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.orgLeader", true)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "10m")
 	viper.Set("peer.gossip.election.leaderElectionDuration", "5s")
+	viper.Set("peer.gossip.election.leaderPriorities", map[string]interface{}{"Peer0.Org1.Example.Com:7051": 100})
 	viper.Set("peer.gossip.pvtData.btlPullMargin", 15)
 	viper.Set("peer.gossip.pvtData.transientstoreMaxBlockRetention", 1000)
 	viper.Set("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit", false)
@@ -44,6 +45,7 @@ func TestGlobalConfig(t *testing.T) {
 		OrgLeader:                                  true,
 		ElectionLeaderAliveThreshold:               10 * time.Minute,
 		ElectionLeaderElectionDuration:             5 * time.Second,
+		ElectionLeaderPriorities:                   map[string]int{"peer0.org1.example.com:7051": 100},
 		ElectionStartupGracePeriod:                 election.DefStartupGracePeriod,
 		ElectionMembershipSampleInterval:           election.DefMembershipSampleInterval,
 		BtlPullMargin:                              15,
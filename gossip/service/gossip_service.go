@@ -8,6 +8,7 @@ package service
 
 import (
 	"sync"
+	"time"
 
 	gproto "github.com/hyperledger/fabric-protos-go/gossip"
 	tspb "github.com/hyperledger/fabric-protos-go/transientstore"
@@ -56,6 +57,10 @@ type gossipSvc interface {
 	// GetPeers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
 
+	// AliveSince returns the last time an alive message was received from
+	// the given peer, or the zero time if the peer isn't considered alive
+	AliveSince(pkiID common.PKIidType) time.Time
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
@@ -337,7 +342,7 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 	g.privateHandlers[channelID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
-		distributor: gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
+		distributor: gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout, g.privdataConfig),
 		reconciler:  reconciler,
 	}
 	g.privateHandlers[channelID].reconciler.Start()
@@ -458,7 +463,8 @@ func (g *GossipService) Stop() {
 func (g *GossipService) newLeaderElectionComponent(channelID string, callback func(bool),
 	electionMetrics *gossipmetrics.ElectionMetrics) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
-	adapter := election.NewAdapter(g, PKIid, gossipcommon.ChannelID(channelID), electionMetrics)
+	adapter := election.NewAdapter(g, PKIid, gossipcommon.ChannelID(channelID), electionMetrics,
+		g.serviceConfig.ElectionLeaderPriorities)
 	config := election.ElectionConfig{
 		StartupGracePeriod:       g.serviceConfig.ElectionStartupGracePeriod,
 		MembershipSampleInterval: g.serviceConfig.ElectionMembershipSampleInterval,
@@ -50,6 +50,10 @@ func (g *gossipMock) SelfMembershipInfo() discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (g *gossipMock) AliveSince(pkiID common.PKIidType) time.Time {
+	panic("implement me")
+}
+
 func (*gossipMock) PeerFilter(channel common.ChannelID, messagePredicate api.SubChannelSelectionCriteria) (filter.RoutingFilter, error) {
 	panic("implement me")
 }
@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+// YieldLeadership makes this peer relinquish its leadership of block
+// dissemination on the given channel, forcing a new leader election round
+// among the peers of the organization. An error is returned if the peer has
+// not joined the channel or if it uses static leadership.
+func (g *GossipService) YieldLeadership(channelID string) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	le, exists := g.leaderElection[channelID]
+	if !exists {
+		return errors.Errorf("no leader election is running for channel %s", channelID)
+	}
+	le.Yield()
+	return nil
+}
+
+// LeadershipAdmin provides control over the per channel leader election for
+// the operations server handler. It is implemented by the GossipService.
+type LeadershipAdmin interface {
+	YieldLeadership(channelID string) error
+}
+
+// LeadershipRequest is the body of a POST request to the leadership endpoint
+// of the operations server
+type LeadershipRequest struct {
+	Channel string `json:"channel"`
+}
+
+// NewLeadershipHandler creates an http.Handler that forces a leader
+// re-election on a channel (POST with a JSON body naming the channel)
+func NewLeadershipHandler(admin LeadershipAdmin) *LeadershipHandler {
+	return &LeadershipHandler{
+		admin:  admin,
+		logger: flogging.MustGetLogger("gossip.service.leadership"),
+	}
+}
+
+// LeadershipHandler serves the leader election control API over HTTP
+type LeadershipHandler struct {
+	admin  LeadershipAdmin
+	logger *flogging.FabricLogger
+}
+
+func (h *LeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+		return
+	}
+
+	var request LeadershipRequest
+	decoder := json.NewDecoder(req.Body)
+	if err := decoder.Decode(&request); err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+	req.Body.Close()
+
+	if err := h.admin.YieldLeadership(request.Channel); err != nil {
+		h.sendResponse(resp, http.StatusNotFound, err)
+		return
+	}
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (h *LeadershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.WriteHeader(code)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
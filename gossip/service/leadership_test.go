@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLeadershipAdmin struct {
+	channels map[string]bool
+	yielded  []string
+}
+
+func (f *fakeLeadershipAdmin) YieldLeadership(channelID string) error {
+	if !f.channels[channelID] {
+		return errors.Errorf("no leader election is running for channel %s", channelID)
+	}
+	f.yielded = append(f.yielded, channelID)
+	return nil
+}
+
+func TestLeadershipHandler(t *testing.T) {
+	admin := &fakeLeadershipAdmin{channels: map[string]bool{"testchannel": true}}
+	handler := NewLeadershipHandler(admin)
+
+	postYield := func(body string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/leadership", strings.NewReader(body)))
+		return resp
+	}
+
+	// yielding leadership on a joined channel
+	assert.Equal(t, http.StatusNoContent, postYield(`{"channel":"testchannel"}`).Code)
+	assert.Equal(t, []string{"testchannel"}, admin.yielded)
+
+	// an unknown channel is not found
+	assert.Equal(t, http.StatusNotFound, postYield(`{"channel":"unknown"}`).Code)
+
+	// invalid requests
+	assert.Equal(t, http.StatusBadRequest, postYield(`not json`).Code)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/leadership", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
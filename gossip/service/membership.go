@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/common"
+)
+
+// PeerInfo describes a single alive peer in the membership view
+type PeerInfo struct {
+	PKIID            string     `json:"pki_id"`
+	Endpoint         string     `json:"endpoint,omitempty"`
+	InternalEndpoint string     `json:"internal_endpoint,omitempty"`
+	Channels         []string   `json:"channels,omitempty"`
+	LastSeen         *time.Time `json:"last_seen,omitempty"`
+}
+
+// ChannelPeerInfo describes a peer subscribed to a channel
+type ChannelPeerInfo struct {
+	PKIID        string `json:"pki_id"`
+	Endpoint     string `json:"endpoint,omitempty"`
+	LedgerHeight uint64 `json:"ledger_height"`
+	LeftChannel  bool   `json:"left_channel,omitempty"`
+}
+
+// ChannelInfo describes the peer's view of a channel it has joined
+type ChannelInfo struct {
+	IsLeader bool              `json:"is_leader"`
+	Peers    []ChannelPeerInfo `json:"peers"`
+}
+
+// MembershipInfo is the peer's current view of the gossip network
+type MembershipInfo struct {
+	Self       PeerInfo                `json:"self"`
+	AlivePeers []PeerInfo              `json:"alive_peers"`
+	Channels   map[string]*ChannelInfo `json:"channels"`
+}
+
+// MembershipInfo returns this peer's current view of the gossip network: the
+// alive peers, the channels they are subscribed to, the last time each peer
+// was seen, and whether this peer leads block dissemination on each joined
+// channel. It is served by the membership endpoint of the operations server.
+func (g *GossipService) MembershipInfo() *MembershipInfo {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	info := &MembershipInfo{
+		Channels: map[string]*ChannelInfo{},
+	}
+
+	// Channels each alive peer is subscribed to, keyed by PKI-ID
+	peerChannels := map[string][]string{}
+
+	for channelID := range g.chains {
+		isLeader := g.serviceConfig.OrgLeader
+		if le, exists := g.leaderElection[channelID]; exists {
+			isLeader = le.IsLeader()
+		}
+		channelInfo := &ChannelInfo{
+			IsLeader: isLeader,
+			Peers:    []ChannelPeerInfo{},
+		}
+		for _, member := range g.PeersOfChannel(common.ChannelID(channelID)) {
+			peerInfo := ChannelPeerInfo{
+				PKIID:    member.PKIid.String(),
+				Endpoint: member.PreferredEndpoint(),
+			}
+			if member.Properties != nil {
+				peerInfo.LedgerHeight = member.Properties.LedgerHeight
+				peerInfo.LeftChannel = member.Properties.LeftChannel
+			}
+			channelInfo.Peers = append(channelInfo.Peers, peerInfo)
+			peerChannels[string(member.PKIid)] = append(peerChannels[string(member.PKIid)], channelID)
+		}
+		sortChannelPeers(channelInfo.Peers)
+		info.Channels[channelID] = channelInfo
+	}
+
+	self := g.SelfMembershipInfo()
+	info.Self = PeerInfo{
+		PKIID:            self.PKIid.String(),
+		Endpoint:         self.Endpoint,
+		InternalEndpoint: self.InternalEndpoint,
+		Channels:         sortedChannels(peerChannels[string(self.PKIid)]),
+	}
+
+	for _, member := range g.Peers() {
+		peerInfo := PeerInfo{
+			PKIID:            member.PKIid.String(),
+			Endpoint:         member.Endpoint,
+			InternalEndpoint: member.InternalEndpoint,
+			Channels:         sortedChannels(peerChannels[string(member.PKIid)]),
+		}
+		if lastSeen := g.AliveSince(member.PKIid); !lastSeen.IsZero() {
+			peerInfo.LastSeen = &lastSeen
+		}
+		info.AlivePeers = append(info.AlivePeers, peerInfo)
+	}
+	sortPeers(info.AlivePeers)
+
+	return info
+}
+
+func sortChannelPeers(peers []ChannelPeerInfo) {
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].PKIID < peers[j].PKIID
+	})
+}
+
+func sortPeers(peers []PeerInfo) {
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].PKIID < peers[j].PKIID
+	})
+}
+
+func sortedChannels(channels []string) []string {
+	sort.Strings(channels)
+	return channels
+}
+
+// MembershipInfoProvider provides the peer's view of the gossip network for
+// the operations server handler. It is implemented by the GossipService.
+type MembershipInfoProvider interface {
+	MembershipInfo() *MembershipInfo
+}
+
+// NewMembershipHandler creates an http.Handler exposing the peer's current
+// gossip membership view for debugging connectivity issues
+func NewMembershipHandler(provider MembershipInfoProvider) *MembershipHandler {
+	return &MembershipHandler{
+		provider: provider,
+		logger:   flogging.MustGetLogger("gossip.service.membership"),
+	}
+}
+
+// MembershipHandler serves the gossip membership view over HTTP
+type MembershipHandler struct {
+	provider MembershipInfoProvider
+	logger   *flogging.FabricLogger
+}
+
+func (h *MembershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		h.sendResponse(resp, http.StatusBadRequest, &errorResponse{Error: "invalid request method: " + req.Method})
+		return
+	}
+	h.sendResponse(resp, http.StatusOK, h.provider.MembershipInfo())
+}
+
+func (h *MembershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	resp.WriteHeader(code)
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
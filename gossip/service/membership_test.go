@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMembershipInfoProvider struct {
+	info *MembershipInfo
+}
+
+func (f *fakeMembershipInfoProvider) MembershipInfo() *MembershipInfo {
+	return f.info
+}
+
+func TestMembershipHandler(t *testing.T) {
+	lastSeen := time.Now().UTC()
+	info := &MembershipInfo{
+		Self: PeerInfo{
+			PKIID:    "aabb",
+			Endpoint: "peer0.org1:7051",
+			Channels: []string{"testchannel"},
+		},
+		AlivePeers: []PeerInfo{
+			{
+				PKIID:            "ccdd",
+				Endpoint:         "peer1.org1:7051",
+				InternalEndpoint: "peer1.org1.internal:7051",
+				Channels:         []string{"testchannel"},
+				LastSeen:         &lastSeen,
+			},
+		},
+		Channels: map[string]*ChannelInfo{
+			"testchannel": {
+				IsLeader: true,
+				Peers: []ChannelPeerInfo{
+					{PKIID: "ccdd", Endpoint: "peer1.org1:7051", LedgerHeight: 42},
+				},
+			},
+		},
+	}
+	handler := NewMembershipHandler(&fakeMembershipInfoProvider{info: info})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/gossip/membership", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	returned := &MembershipInfo{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), returned))
+	assert.Equal(t, info, returned)
+
+	// only GET requests are served
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/gossip/membership", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	errResp := &errorResponse{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), errResp))
+	assert.Equal(t, "invalid request method: POST", errResp.Error)
+}
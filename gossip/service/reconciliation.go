@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/pkg/errors"
+)
+
+// ReconciliationController returns the controller of the private data reconciler
+// of the given channel. An error is returned if the peer has not joined the channel
+// or if reconciliation has been disabled on this peer.
+func (g *GossipService) ReconciliationController(channelID string) (gossipprivdata.ReconciliationController, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	handler, exists := g.privateHandlers[channelID]
+	if !exists {
+		return nil, errors.Errorf("channel %s does not exist", channelID)
+	}
+	controller, isControllable := handler.reconciler.(gossipprivdata.ReconciliationController)
+	if !isControllable {
+		return nil, errors.Errorf("reconciliation is disabled on channel %s", channelID)
+	}
+	return controller, nil
+}
+
+// ReconciliationAdmin provides access to the per channel reconciliation controllers
+// for the operations server handler. It is implemented by the GossipService.
+type ReconciliationAdmin interface {
+	ReconciliationController(channelID string) (gossipprivdata.ReconciliationController, error)
+}
+
+// ReconciliationRequest is the body of a POST request to the reconciliation
+// endpoint of the operations server
+type ReconciliationRequest struct {
+	Channel string `json:"channel"`
+	Action  string `json:"action"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewReconciliationHandler creates an http.Handler exposing the reconciliation
+// status of a channel (GET with a "channel" query parameter) and the pause,
+// resume, and trigger operations (POST with a JSON body naming the channel and
+// the action).
+func NewReconciliationHandler(admin ReconciliationAdmin) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		admin:  admin,
+		logger: flogging.MustGetLogger("gossip.service.reconciliation"),
+	}
+}
+
+// ReconciliationHandler serves the reconciliation status and control API over HTTP
+type ReconciliationHandler struct {
+	admin  ReconciliationAdmin
+	logger *flogging.FabricLogger
+}
+
+func (h *ReconciliationHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		channelID := req.URL.Query().Get("channel")
+		if channelID == "" {
+			h.sendResponse(resp, http.StatusBadRequest, errors.New("the channel query parameter is required"))
+			return
+		}
+		controller, err := h.admin.ReconciliationController(channelID)
+		if err != nil {
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		status, err := controller.ReconciliationStatus()
+		if err != nil {
+			h.sendResponse(resp, http.StatusInternalServerError, err)
+			return
+		}
+		h.sendResponse(resp, http.StatusOK, status)
+
+	case http.MethodPost:
+		var request ReconciliationRequest
+		decoder := json.NewDecoder(req.Body)
+		if err := decoder.Decode(&request); err != nil {
+			h.sendResponse(resp, http.StatusBadRequest, err)
+			return
+		}
+		req.Body.Close()
+
+		controller, err := h.admin.ReconciliationController(request.Channel)
+		if err != nil {
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		switch request.Action {
+		case "pause":
+			controller.PauseReconciliation()
+		case "resume":
+			controller.ResumeReconciliation()
+		case "trigger":
+			if err := controller.TriggerReconciliation(); err != nil {
+				h.sendResponse(resp, http.StatusInternalServerError, err)
+				return
+			}
+		default:
+			h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid action: %s", request.Action))
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+
+	default:
+		h.sendResponse(resp, http.StatusBadRequest, errors.Errorf("invalid request method: %s", req.Method))
+	}
+}
+
+func (h *ReconciliationHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &errorResponse{Error: err.Error()}
+	}
+
+	resp.WriteHeader(code)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := encoder.Encode(payload); err != nil {
+		h.logger.Errorw("failed to encode payload", "error", err)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReconciliationController struct {
+	status        *gossipprivdata.ReconciliationStatus
+	statusErr     error
+	triggerErr    error
+	pauseCalled   bool
+	resumeCalled  bool
+	triggerCalled bool
+}
+
+func (f *fakeReconciliationController) ReconciliationStatus() (*gossipprivdata.ReconciliationStatus, error) {
+	return f.status, f.statusErr
+}
+
+func (f *fakeReconciliationController) PauseReconciliation() {
+	f.pauseCalled = true
+}
+
+func (f *fakeReconciliationController) ResumeReconciliation() {
+	f.resumeCalled = true
+}
+
+func (f *fakeReconciliationController) TriggerReconciliation() error {
+	f.triggerCalled = true
+	return f.triggerErr
+}
+
+type fakeReconciliationAdmin struct {
+	controllers map[string]gossipprivdata.ReconciliationController
+}
+
+func (f *fakeReconciliationAdmin) ReconciliationController(channelID string) (gossipprivdata.ReconciliationController, error) {
+	controller, exists := f.controllers[channelID]
+	if !exists {
+		return nil, errors.Errorf("channel %s does not exist", channelID)
+	}
+	return controller, nil
+}
+
+func TestReconciliationHandlerStatus(t *testing.T) {
+	controller := &fakeReconciliationController{
+		status: &gossipprivdata.ReconciliationStatus{
+			Paused: true,
+			MissingPvtData: []*gossipprivdata.MissingPvtDataSummary{
+				{Namespace: "ns1", Collection: "col1", Count: 3, MinBlock: 2, MaxBlock: 7},
+			},
+		},
+	}
+	handler := NewReconciliationHandler(&fakeReconciliationAdmin{
+		controllers: map[string]gossipprivdata.ReconciliationController{"testchannel": controller},
+	})
+
+	// status of a joined channel
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/reconciliation?channel=testchannel", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	status := &gossipprivdata.ReconciliationStatus{}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), status))
+	assert.Equal(t, controller.status, status)
+
+	// the channel query parameter is required
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/reconciliation", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	// an unknown channel is not found
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/reconciliation?channel=unknown", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	// a failure to gather the status is a server error
+	controller.statusErr = errors.New("no tracker")
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/reconciliation?channel=testchannel", nil))
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestReconciliationHandlerActions(t *testing.T) {
+	controller := &fakeReconciliationController{}
+	handler := NewReconciliationHandler(&fakeReconciliationAdmin{
+		controllers: map[string]gossipprivdata.ReconciliationController{"testchannel": controller},
+	})
+
+	postAction := func(body string) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/reconciliation", strings.NewReader(body)))
+		return resp
+	}
+
+	assert.Equal(t, http.StatusNoContent, postAction(`{"channel":"testchannel","action":"pause"}`).Code)
+	assert.True(t, controller.pauseCalled)
+
+	assert.Equal(t, http.StatusNoContent, postAction(`{"channel":"testchannel","action":"resume"}`).Code)
+	assert.True(t, controller.resumeCalled)
+
+	assert.Equal(t, http.StatusNoContent, postAction(`{"channel":"testchannel","action":"trigger"}`).Code)
+	assert.True(t, controller.triggerCalled)
+
+	// a failed trigger is a server error
+	controller.triggerErr = errors.New("fetch failed")
+	assert.Equal(t, http.StatusInternalServerError, postAction(`{"channel":"testchannel","action":"trigger"}`).Code)
+
+	// invalid requests
+	assert.Equal(t, http.StatusBadRequest, postAction(`{"channel":"testchannel","action":"restart"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postAction(`not json`).Code)
+	assert.Equal(t, http.StatusNotFound, postAction(`{"channel":"unknown","action":"pause"}`).Code)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPut, "/reconciliation", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -13,13 +13,15 @@ import (
 )
 
 const (
-	DefStateCheckInterval   = 10 * time.Second
-	DefStateResponseTimeout = 3 * time.Second
-	DefStateBatchSize       = 10
-	DefStateMaxRetries      = 3
-	DefStateBlockBufferSize = 100
-	DefStateChannelSize     = 100
-	DefStateEnabled         = true
+	DefStateCheckInterval          = 10 * time.Second
+	DefStateResponseTimeout        = 3 * time.Second
+	DefStateBatchSize              = 10
+	DefStateMaxRetries             = 3
+	DefStateBlockBufferSize        = 100
+	DefStateChannelSize            = 100
+	DefStateEnabled                = true
+	DefStateTransferParallelism    = 1
+	DefStateTransferMaxBytesPerSec = 0
 )
 
 type StateConfig struct {
@@ -30,6 +32,10 @@ type StateConfig struct {
 	StateBlockBufferSize int
 	StateChannelSize     int
 	StateEnabled         bool
+	// StateTransferParallelism is the number of block ranges anti-entropy fetches concurrently.
+	StateTransferParallelism int
+	// StateTransferMaxBytesPerSec caps the rate at which anti-entropy fetches block bytes, zero means unlimited.
+	StateTransferMaxBytesPerSec int
 }
 
 func GlobalConfig() *StateConfig {
@@ -68,4 +74,12 @@ func (c *StateConfig) loadStateConfig() {
 	if viper.IsSet("peer.gossip.state.enabled") {
 		c.StateEnabled = viper.GetBool("peer.gossip.state.enabled")
 	}
+	c.StateTransferParallelism = DefStateTransferParallelism
+	if viper.IsSet("peer.gossip.state.transferParallelism") {
+		c.StateTransferParallelism = viper.GetInt("peer.gossip.state.transferParallelism")
+	}
+	c.StateTransferMaxBytesPerSec = DefStateTransferMaxBytesPerSec
+	if viper.IsSet("peer.gossip.state.transferMaxBytesPerSec") {
+		c.StateTransferMaxBytesPerSec = viper.GetInt("peer.gossip.state.transferMaxBytesPerSec")
+	}
 }
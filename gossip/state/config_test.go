@@ -25,17 +25,21 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.state.blockBufferSize", 5)
 	viper.Set("peer.gossip.state.channelSize", 6)
 	viper.Set("peer.gossip.state.enabled", false)
+	viper.Set("peer.gossip.state.transferParallelism", 7)
+	viper.Set("peer.gossip.state.transferMaxBytesPerSec", 8)
 
 	coreConfig := state.GlobalConfig()
 
 	expectedConfig := &state.StateConfig{
-		StateCheckInterval:   time.Second,
-		StateResponseTimeout: 2 * time.Second,
-		StateBatchSize:       uint64(3),
-		StateMaxRetries:      4,
-		StateBlockBufferSize: 5,
-		StateChannelSize:     6,
-		StateEnabled:         false,
+		StateCheckInterval:          time.Second,
+		StateResponseTimeout:        2 * time.Second,
+		StateBatchSize:              uint64(3),
+		StateMaxRetries:             4,
+		StateBlockBufferSize:        5,
+		StateChannelSize:            6,
+		StateEnabled:                false,
+		StateTransferParallelism:    7,
+		StateTransferMaxBytesPerSec: 8,
 	}
 
 	assert.Equal(t, expectedConfig, coreConfig)
@@ -47,13 +51,15 @@ func TestGlobalConfigDefaults(t *testing.T) {
 	coreConfig := state.GlobalConfig()
 
 	expectedConfig := &state.StateConfig{
-		StateCheckInterval:   10 * time.Second,
-		StateResponseTimeout: 3 * time.Second,
-		StateBatchSize:       uint64(10),
-		StateMaxRetries:      3,
-		StateBlockBufferSize: 100,
-		StateChannelSize:     100,
-		StateEnabled:         true,
+		StateCheckInterval:          10 * time.Second,
+		StateResponseTimeout:        3 * time.Second,
+		StateBatchSize:              uint64(10),
+		StateMaxRetries:             3,
+		StateBlockBufferSize:        100,
+		StateChannelSize:            100,
+		StateEnabled:                true,
+		StateTransferParallelism:    1,
+		StateTransferMaxBytesPerSec: 0,
 	}
 
 	assert.Equal(t, expectedConfig, coreConfig)
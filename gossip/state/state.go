@@ -158,6 +158,13 @@ type GossipStateProviderImpl struct {
 
 	stateTransferActive int32
 
+	// Sequence number of the highest block fetched by state transfer so far,
+	// used to resume anti entropy after a partial failure without re-fetching
+	// blocks which already wait in the payloads buffer
+	fetchedUpTo uint64
+
+	transferLimiter *transferRateLimiter
+
 	stateMetrics *metrics.StateMetrics
 
 	requestValidator *stateRequestValidator
@@ -254,6 +261,7 @@ func NewGossipStateProvider(
 		stateRequestCh:      make(chan protoext.ReceivedMessage, config.StateChannelSize),
 		stopCh:              make(chan struct{}),
 		stateTransferActive: 0,
+		transferLimiter:     newTransferRateLimiter(config.StateTransferMaxBytesPerSec),
 		once:                sync.Once{},
 		stateMetrics:        stateMetrics,
 		requestValidator:    &stateRequestValidator{},
@@ -506,6 +514,7 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg protoext.ReceivedMessa
 	if len(response.GetPayloads()) == 0 {
 		return uint64(0), errors.New("Received state transfer response without payload")
 	}
+	transferredBytes := 0
 	for _, payload := range response.GetPayloads() {
 		s.logger.Debugf("Received payload with sequence number %d.", payload.SeqNum)
 		block, err := protoutil.UnmarshalBlock(payload.Data)
@@ -527,7 +536,11 @@ func (s *GossipStateProviderImpl) handleStateResponse(msg protoext.ReceivedMessa
 		if err != nil {
 			s.logger.Warningf("Block [%d] received from block transfer wasn't added to payload buffer: %v", payload.SeqNum, err)
 		}
+		transferredBytes += len(payload.Data) + len(payload.PrivateData)
 	}
+	s.stateMetrics.FetchedBlocks.With("channel", s.chainID).Add(float64(len(response.GetPayloads())))
+	// Stay within the configured bandwidth budget before fetching further blocks
+	s.transferLimiter.throttle(transferredBytes, s.stopCh)
 	return max, nil
 }
 
@@ -611,7 +624,17 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 				continue
 			}
 
-			s.requestBlocksInRange(uint64(ourHeight), uint64(maxHeight)-1)
+			start := ourHeight
+			if fetched := atomic.LoadUint64(&s.fetchedUpTo); fetched >= start {
+				// Resume after the blocks already fetched into the payloads
+				// buffer by a previous, partially failed round
+				start = fetched + 1
+			}
+			if start >= maxHeight {
+				continue
+			}
+
+			s.requestBlocksInRange(start, maxHeight-1)
 		}
 	}
 }
@@ -634,15 +657,68 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 }
 
 // requestBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end).
+// numbers in the range [start...end], fetching up to StateTransferParallelism
+// consecutive sub-ranges of the range concurrently.
 func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
 	atomic.StoreInt32(&s.stateTransferActive, 1)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
-	for prev := start; prev <= end; {
-		next := min(end, prev+s.config.StateBatchSize)
+	workers := s.config.StateTransferParallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if blocks := end - start + 1; uint64(workers) > blocks {
+		workers = int(blocks)
+	}
+
+	// Route state responses to the fetcher which owns their nonce, so several
+	// block ranges can be in flight at once
+	router := newStateResponseRouter(s.stateResponseCh)
+	defer router.stop()
+
+	// Divide [start...end] into consecutive windows of the same size and
+	// fetch each window by a dedicated fetcher
+	windowSize := (end - start + uint64(workers)) / uint64(workers)
+	windowEnds := make([]uint64, workers)
+	results := make([]uint64, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		from := start + uint64(i)*windowSize
+		windowEnds[i] = min(end, from+windowSize-1)
+		wg.Add(1)
+		go func(i int, from uint64, to uint64) {
+			defer wg.Done()
+			results[i] = s.fetchBlockRange(from, to, router)
+		}(i, from, windowEnds[i])
+	}
+	wg.Wait()
+
+	// Advance the resume point over the contiguous prefix of fetched blocks,
+	// so the next anti entropy round won't re-fetch them
+	progress := start
+	for i := 0; i < workers; i++ {
+		progress = results[i]
+		if results[i] <= windowEnds[i] {
+			// The window wasn't fetched completely, blocks of the
+			// subsequent windows aren't contiguous with the progress
+			break
+		}
+	}
+	if progress > start {
+		s.updateFetchProgress(progress - 1)
+	}
+}
+
+// fetchBlockRange acquires blocks with sequence numbers in the range
+// [from...to], batch by batch, and returns the first sequence number
+// it didn't manage to fetch.
+func (s *GossipStateProviderImpl) fetchBlockRange(from uint64, to uint64, router *stateResponseRouter) uint64 {
+	for prev := from; prev <= to; {
+		next := min(to, prev+s.config.StateBatchSize)
 
 		gossipMsg := s.stateRequestMessage(prev, next)
+		responseCh := router.register(gossipMsg.Nonce)
 
 		responseReceived := false
 		tryCounts := 0
@@ -651,14 +727,16 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 			if tryCounts > s.config.StateMaxRetries {
 				s.logger.Warningf("Wasn't  able to get blocks in range [%d...%d), after %d retries",
 					prev, next, tryCounts)
-				return
+				router.unregister(gossipMsg.Nonce)
+				return prev
 			}
 			// Select peers to ask for blocks
 			peer, err := s.selectPeerToRequestFrom(next)
 			if err != nil {
 				s.logger.Warningf("Cannot send state request for blocks in range [%d...%d), due to %+v",
 					prev, next, errors.WithStack(err))
-				return
+				router.unregister(gossipMsg.Nonce)
+				return prev
 			}
 
 			s.logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d), "+
@@ -669,14 +747,7 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 
 			// Wait until timeout or response arrival
 			select {
-			case msg, stillOpen := <-s.stateResponseCh:
-				if !stillOpen {
-					return
-				}
-				if msg.GetGossipMessage().Nonce !=
-					gossipMsg.Nonce {
-					continue
-				}
+			case msg := <-responseCh:
 				// Got corresponding response for state request, can continue
 				index, err := s.handleStateResponse(msg)
 				if err != nil {
@@ -686,9 +757,25 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 				}
 				prev = index + 1
 				responseReceived = true
+			case <-s.stopCh:
+				router.unregister(gossipMsg.Nonce)
+				return prev
 			case <-time.After(s.config.StateResponseTimeout):
 			}
 		}
+		router.unregister(gossipMsg.Nonce)
+	}
+	return to + 1
+}
+
+// updateFetchProgress advances the sequence number of the highest block
+// fetched by state transfer, it is never moved backwards.
+func (s *GossipStateProviderImpl) updateFetchProgress(seq uint64) {
+	for {
+		current := atomic.LoadUint64(&s.fetchedUpTo)
+		if seq <= current || atomic.CompareAndSwapUint64(&s.fetchedUpTo, current, seq) {
+			return
+		}
 	}
 }
 
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/protoext"
+)
+
+// stateResponseRouter dispatches state response messages to the fetcher which
+// owns the nonce of the response, allowing several block ranges to be
+// requested concurrently over the single state response channel.
+type stateResponseRouter struct {
+	lock     sync.Mutex
+	routes   map[uint64]chan protoext.ReceivedMessage
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// newStateResponseRouter creates a router which reads responses from the
+// given channel until the channel is closed or the router is stopped.
+func newStateResponseRouter(responses <-chan protoext.ReceivedMessage) *stateResponseRouter {
+	r := &stateResponseRouter{
+		routes: make(map[uint64]chan protoext.ReceivedMessage),
+		stopCh: make(chan struct{}),
+	}
+	go r.route(responses)
+	return r
+}
+
+func (r *stateResponseRouter) route(responses <-chan protoext.ReceivedMessage) {
+	for {
+		select {
+		case msg, stillOpen := <-responses:
+			if !stillOpen {
+				return
+			}
+			r.lock.Lock()
+			ch, exists := r.routes[msg.GetGossipMessage().Nonce]
+			r.lock.Unlock()
+			if !exists {
+				// A response for a request which has been given up on
+				continue
+			}
+			select {
+			case ch <- msg:
+			default:
+			}
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// register allocates a channel which will receive the responses carrying the given nonce
+func (r *stateResponseRouter) register(nonce uint64) chan protoext.ReceivedMessage {
+	ch := make(chan protoext.ReceivedMessage, 1)
+	r.lock.Lock()
+	r.routes[nonce] = ch
+	r.lock.Unlock()
+	return ch
+}
+
+// unregister drops further responses carrying the given nonce
+func (r *stateResponseRouter) unregister(nonce uint64) {
+	r.lock.Lock()
+	delete(r.routes, nonce)
+	r.lock.Unlock()
+}
+
+// stop terminates the routing goroutine
+func (r *stateResponseRouter) stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopCh)
+	})
+}
+
+// transferRateLimiter caps the aggregate rate at which state transfer
+// processes fetched block bytes, shared across concurrent fetchers.
+// A zero bytesPerSec disables the limiter.
+type transferRateLimiter struct {
+	lock        sync.Mutex
+	bytesPerSec int
+	next        time.Time
+}
+
+func newTransferRateLimiter(bytesPerSec int) *transferRateLimiter {
+	return &transferRateLimiter{
+		bytesPerSec: bytesPerSec,
+	}
+}
+
+// throttle blocks until processing numBytes additional bytes stays within the
+// configured rate, or until the given stop channel is closed.
+func (r *transferRateLimiter) throttle(numBytes int, stopCh <-chan struct{}) {
+	if r.bytesPerSec <= 0 || numBytes <= 0 {
+		return
+	}
+	r.lock.Lock()
+	now := time.Now()
+	if r.next.Before(now) {
+		r.next = now
+	}
+	wait := r.next.Sub(now)
+	r.next = r.next.Add(time.Duration(float64(numBytes) / float64(r.bytesPerSec) * float64(time.Second)))
+	r.lock.Unlock()
+
+	if wait == 0 {
+		return
+	}
+	select {
+	case <-time.After(wait):
+	case <-stopCh:
+	}
+}
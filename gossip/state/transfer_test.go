@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/stretchr/testify/assert"
+)
+
+func stateResponseWithNonce(t *testing.T, nonce uint64) protoext.ReceivedMessage {
+	sMsg, err := protoext.NoopSign(&proto.GossipMessage{
+		Nonce: nonce,
+		Content: &proto.GossipMessage_StateResponse{
+			StateResponse: &proto.RemoteStateResponse{},
+		},
+	})
+	assert.NoError(t, err)
+	return &comm.ReceivedMessageImpl{
+		SignedGossipMessage: sMsg,
+	}
+}
+
+func TestStateResponseRouter(t *testing.T) {
+	t.Parallel()
+	responses := make(chan protoext.ReceivedMessage, 10)
+	router := newStateResponseRouter(responses)
+	defer router.stop()
+
+	firstCh := router.register(uint64(1))
+	secondCh := router.register(uint64(2))
+
+	// A response carrying a nonce nobody registered for is dropped
+	responses <- stateResponseWithNonce(t, uint64(3))
+	responses <- stateResponseWithNonce(t, uint64(2))
+	responses <- stateResponseWithNonce(t, uint64(1))
+
+	select {
+	case msg := <-firstCh:
+		assert.Equal(t, uint64(1), msg.GetGossipMessage().Nonce)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Didn't receive a response for nonce 1 within a timely manner")
+	}
+	select {
+	case msg := <-secondCh:
+		assert.Equal(t, uint64(2), msg.GetGossipMessage().Nonce)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Didn't receive a response for nonce 2 within a timely manner")
+	}
+
+	// Once unregistered, further responses for the nonce are dropped
+	router.unregister(uint64(1))
+	responses <- stateResponseWithNonce(t, uint64(1))
+	select {
+	case <-firstCh:
+		assert.Fail(t, "Received a response for an unregistered nonce")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Stopping the router twice shouldn't panic
+	router.stop()
+	router.stop()
+}
+
+func TestTransferRateLimiterDisabled(t *testing.T) {
+	t.Parallel()
+	limiter := newTransferRateLimiter(0)
+
+	start := time.Now()
+	limiter.throttle(1024*1024, make(chan struct{}))
+	assert.True(t, time.Since(start) < time.Second, "Disabled limiter shouldn't block")
+}
+
+func TestTransferRateLimiter(t *testing.T) {
+	t.Parallel()
+	// 10KB per second, processing 5KB twice should delay the
+	// second call by about half a second
+	limiter := newTransferRateLimiter(10240)
+	stopCh := make(chan struct{})
+
+	start := time.Now()
+	limiter.throttle(5120, stopCh)
+	limiter.throttle(5120, stopCh)
+	elapsed := time.Since(start)
+	assert.True(t, elapsed >= 400*time.Millisecond, "Expected to be throttled for about half a second, waited only %v", elapsed)
+}
+
+func TestTransferRateLimiterStop(t *testing.T) {
+	t.Parallel()
+	limiter := newTransferRateLimiter(1)
+	stopCh := make(chan struct{})
+	limiter.throttle(1024, stopCh)
+
+	// Closing the stop channel releases a throttled caller early
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		close(stopCh)
+	}()
+	start := time.Now()
+	limiter.throttle(1024, stopCh)
+	assert.True(t, time.Since(start) < 10*time.Second, "Expected the stop channel to release the limiter")
+}
@@ -25,17 +25,21 @@ type fileValidator func(fileName string, fileBytes []byte) error
 // AllowedCharsCollectionName captures the regex pattern for a valid collection name
 const AllowedCharsCollectionName = "[A-Za-z0-9_-]+"
 
-// Currently, the only metadata expected and allowed is for META-INF/statedb/couchdb/indexes.
+// The metadata expected and allowed is index definitions for
+// META-INF/statedb/couchdb/indexes and META-INF/statedb/leveldb/indexes.
+// The leveldb indexes share the couchdb index definition format.
 var fileValidators = map[*regexp.Regexp]fileValidator{
 	regexp.MustCompile("^META-INF/statedb/couchdb/indexes/.*[.]json"):                                                couchdbIndexFileValidator,
 	regexp.MustCompile("^META-INF/statedb/couchdb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): couchdbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/leveldb/indexes/.*[.]json"):                                                couchdbIndexFileValidator,
+	regexp.MustCompile("^META-INF/statedb/leveldb/collections/" + AllowedCharsCollectionName + "/indexes/.*[.]json"): couchdbIndexFileValidator,
 }
 
 var collectionNameValid = regexp.MustCompile("^" + AllowedCharsCollectionName)
 
 var fileNameValid = regexp.MustCompile("^.*[.]json")
 
-var validDatabases = []string{"couchdb"}
+var validDatabases = []string{"couchdb", "leveldb"}
 
 // UnhandledDirectoryError is returned for metadata files in unhandled directories
 type UnhandledDirectoryError struct {
@@ -228,8 +232,8 @@ func validateIndexJSON(indexDefinition map[string]interface{}) error {
 
 }
 
-//processIndexMap processes an interface map and wraps field names or traverses
-//the next level of the json query
+// processIndexMap processes an interface map and wraps field names or traverses
+// the next level of the json query
 func processIndexMap(jsonFragment map[string]interface{}) error {
 
 	//iterate the item in the map
@@ -285,7 +289,7 @@ func processIndexMap(jsonFragment map[string]interface{}) error {
 
 }
 
-//validateFieldMap validates the list of field objects
+// validateFieldMap validates the list of field objects
 func validateFieldMap(jsonFragment map[string]interface{}) error {
 
 	//iterate the fields to validate the sort criteria
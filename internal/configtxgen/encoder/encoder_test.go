@@ -199,7 +199,7 @@ var _ = Describe("Encoder", func() {
 
 			It("wraps and returns the error", func() {
 				err := encoder.AddPolicies(cg, policies, "Readers")
-				Expect(err).To(MatchError("invalid signature policy rule 'garbage': unrecognized token 'garbage' in policy string"))
+				Expect(err).To(MatchError("invalid signature policy rule 'garbage': unrecognized token 'garbage' in policy string at line 1, col 1: did you mean 'garbage.member'?"))
 			})
 		})
 
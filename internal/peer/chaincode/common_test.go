@@ -360,7 +360,7 @@ func TestCollectionParsing(t *testing.T) {
 		{
 			name:             "Invalid member orgs policy",
 			collectionConfig: sampleCollectionConfigBad,
-			expectedErr:      "invalid policy barf: unrecognized token 'barf' in policy string",
+			expectedErr:      "invalid policy barf: unrecognized token 'barf' in policy string at line 1, col 1: did you mean 'barf.member'?",
 		},
 		{
 			name:             "Invalid collection config",
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/config"
@@ -119,6 +120,13 @@ func InitConfig(cmdRoot string) error {
 
 // InitCrypto initializes crypto for this peer
 func InitCrypto(mspMgrConfigDir, localMSPID, localMSPType string) error {
+	// Enable FIPS mode before any cryptographic provider is created
+	if viper.GetBool("peer.fips") {
+		if err := fips.Enable(); err != nil {
+			return errors.WithMessage(err, "cannot enable FIPS mode")
+		}
+	}
+
 	// Check whether msp folder exists
 	fi, err := os.Stat(mspMgrConfigDir)
 	if err != nil {
@@ -42,6 +42,7 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 	addFlags(chaincodeCmd)
 
 	chaincodeCmd.AddCommand(PackageCmd(nil))
+	chaincodeCmd.AddCommand(VerifyBuildCmd(nil))
 	chaincodeCmd.AddCommand(InstallCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryInstalledCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(GetInstalledPackageCmd(nil, cryptoProvider))
@@ -49,6 +50,7 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 	chaincodeCmd.AddCommand(CheckCommitReadinessCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(CommitCmd(nil, cryptoProvider))
 	chaincodeCmd.AddCommand(QueryCommittedCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(DeployCmd(nil, cryptoProvider))
 
 	return chaincodeCmd
 }
@@ -76,12 +78,14 @@ var (
 	initRequired          bool
 	output                string
 	outputDirectory       string
+	signPackage           bool
+	eventSchemasFile      string
 )
 
 var chaincodeCmd = &cobra.Command{
 	Use:   "chaincode",
-	Short: "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|approveformyorg|checkcommitreadiness|commit|querycommitted",
-	Long:  "Perform chaincode operations: package|install|queryinstalled|getinstalledpackage|approveformyorg|checkcommitreadiness|commit|querycommitted",
+	Short: "Perform chaincode operations: package|verifybuild|install|queryinstalled|getinstalledpackage|approveformyorg|checkcommitreadiness|commit|querycommitted|deploy",
+	Long:  "Perform chaincode operations: package|verifybuild|install|queryinstalled|getinstalledpackage|approveformyorg|checkcommitreadiness|commit|querycommitted|deploy",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -127,6 +131,8 @@ func ResetFlags() {
 	flags.BoolVarP(&initRequired, "init-required", "", false, "Whether the chaincode requires invoking 'init'")
 	flags.StringVarP(&output, "output", "O", "", "The output format for query results. Default is human-readable plain-text. json is currently the only supported format.")
 	flags.StringVarP(&outputDirectory, "output-directory", "", "", "The output directory to use when writing a chaincode install package to disk. Default is the current working directory.")
+	flags.BoolVarP(&signPackage, "sign", "", false, "Whether to sign the chaincode package with the default signer's identity, embedding the identity and signature in the package")
+	flags.StringVarP(&eventSchemasFile, "event-schemas", "", "", "The fully qualified path to a JSON file mapping chaincode event names to the JSON schemas of their payloads, embedded in the package for event consumers to discover")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -44,6 +44,26 @@ type signer interface {
 	chaincode.Signer
 }
 
+//go:generate counterfeiter -o mock/package_installer.go -fake-name PackageInstaller . packageInstaller
+type packageInstaller interface {
+	chaincode.PackageInstaller
+}
+
+//go:generate counterfeiter -o mock/definition_approver.go -fake-name DefinitionApprover . definitionApprover
+type definitionApprover interface {
+	chaincode.DefinitionApprover
+}
+
+//go:generate counterfeiter -o mock/approvals_checker.go -fake-name ApprovalsChecker . approvalsChecker
+type approvalsChecker interface {
+	chaincode.ApprovalsChecker
+}
+
+//go:generate counterfeiter -o mock/definition_committer.go -fake-name DefinitionCommitter . definitionCommitter
+type definitionCommitter interface {
+	chaincode.DefinitionCommitter
+}
+
 //go:generate counterfeiter -o mock/broadcast_client.go -fake-name BroadcastClient . broadcastClient
 type broadcastClient interface {
 	common.BroadcastClient
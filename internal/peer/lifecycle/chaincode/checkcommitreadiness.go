@@ -156,38 +156,69 @@ func (c *CommitReadinessChecker) ReadinessCheck() error {
 		c.Command.SilenceUsage = true
 	}
 
+	proposalResponse, err := c.submitReadinessCheck()
+	if err != nil {
+		return err
+	}
+
+	if strings.ToLower(c.Input.OutputFormat) == "json" {
+		return printResponseAsJSON(proposalResponse, &lb.CheckCommitReadinessResult{}, c.Writer)
+	}
+	return c.printResponse(proposalResponse)
+}
+
+// Approvals submits the readiness check and returns the orgs' approvals of
+// the chaincode definition instead of printing them. It is used by the
+// deploy orchestration to decide which approvals are still outstanding.
+func (c *CommitReadinessChecker) Approvals() (map[string]bool, error) {
+	if err := c.Input.Validate(); err != nil {
+		return nil, err
+	}
+
+	proposalResponse, err := c.submitReadinessCheck()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &lb.CheckCommitReadinessResult{}
+	if err := proto.Unmarshal(proposalResponse.Response.Payload, result); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+	return result.Approvals, nil
+}
+
+// submitReadinessCheck signs and submits the readiness check proposal and
+// validates the proposal response.
+func (c *CommitReadinessChecker) submitReadinessCheck() (*pb.ProposalResponse, error) {
 	proposal, err := c.createProposal(c.Input.TxID)
 	if err != nil {
-		return errors.WithMessage(err, "failed to create proposal")
+		return nil, errors.WithMessage(err, "failed to create proposal")
 	}
 
 	signedProposal, err := signProposal(proposal, c.Signer)
 	if err != nil {
-		return errors.WithMessage(err, "failed to create signed proposal")
+		return nil, errors.WithMessage(err, "failed to create signed proposal")
 	}
 
 	// checkcommitreadiness currently only supports a single peer
 	proposalResponse, err := c.EndorserClient.ProcessProposal(context.Background(), signedProposal)
 	if err != nil {
-		return errors.WithMessage(err, "failed to endorse proposal")
+		return nil, errors.WithMessage(err, "failed to endorse proposal")
 	}
 
 	if proposalResponse == nil {
-		return errors.New("received nil proposal response")
+		return nil, errors.New("received nil proposal response")
 	}
 
 	if proposalResponse.Response == nil {
-		return errors.New("received proposal response with nil response")
+		return nil, errors.New("received proposal response with nil response")
 	}
 
 	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
-		return errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+		return nil, errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
 	}
 
-	if strings.ToLower(c.Input.OutputFormat) == "json" {
-		return printResponseAsJSON(proposalResponse, &lb.CheckCommitReadinessResult{}, c.Writer)
-	}
-	return c.printResponse(proposalResponse)
+	return proposalResponse, nil
 }
 
 // printResponse prints the information included in the response
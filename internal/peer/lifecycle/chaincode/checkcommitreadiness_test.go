@@ -232,6 +232,30 @@ var _ = Describe("CheckCommitReadiness", func() {
 				Expect(err).To(MatchError(ContainSubstring("failed to unmarshal proposal response's response payload")))
 			})
 		})
+
+		Describe("Approvals", func() {
+			It("returns the approvals from the readiness check instead of printing them", func() {
+				approvals, err := commitReadinessChecker.Approvals()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal(map[string]bool{
+					"seemsfinetome":  true,
+					"well...ok":      true,
+					"absolutely-not": false,
+				}))
+				Consistently(commitReadinessChecker.Writer).ShouldNot(gbytes.Say("approval status by org"))
+			})
+
+			Context("when the endorser fails to endorse the proposal", func() {
+				BeforeEach(func() {
+					mockEndorserClient.ProcessProposalReturns(nil, errors.New("cortado"))
+				})
+
+				It("returns an error", func() {
+					_, err := commitReadinessChecker.Approvals()
+					Expect(err).To(MatchError("failed to endorse proposal: cortado"))
+				})
+			})
+		})
 	})
 
 	Describe("CheckCommitReadinessCmd", func() {
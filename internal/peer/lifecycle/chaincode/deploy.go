@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// PackageInstaller installs a chaincode package on the peers of an org. It
+// is implemented by the Installer.
+type PackageInstaller interface {
+	Install() error
+}
+
+// DefinitionApprover approves a chaincode definition for an org. It is
+// implemented by the ApproverForMyOrg.
+type DefinitionApprover interface {
+	Approve() error
+}
+
+// ApprovalsChecker returns the orgs' approvals of a chaincode definition.
+// It is implemented by the CommitReadinessChecker.
+type ApprovalsChecker interface {
+	Approvals() (map[string]bool, error)
+}
+
+// DefinitionCommitter commits a chaincode definition to a channel. It is
+// implemented by the Committer.
+type DefinitionCommitter interface {
+	Commit() error
+}
+
+// DeployOrg holds the actors which perform the deployment steps on behalf
+// of a single org. Installer may be nil when the org's peers already have
+// the chaincode package.
+type DeployOrg struct {
+	MSPID     string
+	Installer PackageInstaller
+	Approver  DefinitionApprover
+}
+
+// Deployer drives the chaincode deployment flow -- package installation,
+// approval for each org, readiness checking, and commit -- as one
+// coordinated run. Orgs whose approval is already recorded on the channel
+// are skipped and installing an already installed package is a no-op on the
+// peer, so an interrupted deployment can be resumed by running it again.
+// The deploy command populates a single org from the CLI flags; callers
+// holding admin signing identities for several orgs can populate one
+// DeployOrg per org to deploy on behalf of all of them.
+type Deployer struct {
+	Command          *cobra.Command
+	Orgs             []*DeployOrg
+	ApprovalsChecker ApprovalsChecker
+	Committer        DefinitionCommitter
+	Writer           io.Writer
+}
+
+// DeployCmd returns the cobra command for deploying a chaincode. The
+// command drives the deployment flow on behalf of the invoking admin's org
+// and commits the chaincode definition once every org has approved it;
+// until then it fails without committing and can be rerun.
+func DeployCmd(d *Deployer, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeDeployCmd := &cobra.Command{
+		Use:       "deploy [packagefile]",
+		Short:     "Deploy a chaincode on a channel.",
+		Long:      "Deploy a chaincode on a channel: install the supplied chaincode package, approve the chaincode definition for this org unless it has already been approved, and commit the definition once every org has approved it.",
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("invalid number of args. expected at most the chaincode package file")
+			}
+			if d == nil {
+				var err error
+				d, err = newDeployer(cmd, args, cryptoProvider)
+				if err != nil {
+					return err
+				}
+			}
+			return d.Deploy()
+		},
+	}
+
+	flagList := []string{
+		"channelID",
+		"name",
+		"version",
+		"package-id",
+		"sequence",
+		"endorsement-plugin",
+		"validation-plugin",
+		"signature-policy",
+		"channel-config-policy",
+		"init-required",
+		"collections-config",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(chaincodeDeployCmd, flagList)
+
+	return chaincodeDeployCmd
+}
+
+// newDeployer creates a Deployer for the invoking admin's org based on the
+// CLI flags.
+func newDeployer(cmd *cobra.Command, args []string, cryptoProvider bccsp.BCCSP) (*Deployer, error) {
+	ccInput := &ClientConnectionsInput{
+		CommandName:           cmd.Name(),
+		EndorserRequired:      true,
+		OrdererRequired:       true,
+		ChannelID:             channelID,
+		PeerAddresses:         peerAddresses,
+		TLSRootCertFiles:      tlsRootCertFiles,
+		ConnectionProfilePath: connectionProfilePath,
+		TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+	}
+
+	cc, err := NewClientConnections(ccInput, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+	for i, e := range cc.EndorserClients {
+		endorserClients[i] = e
+	}
+
+	org := &DeployOrg{
+		MSPID: viper.GetString("peer.localMspId"),
+	}
+
+	if len(args) == 1 {
+		org.Installer = &Installer{
+			Command:        cmd,
+			EndorserClient: endorserClients[0],
+			Input:          &InstallInput{PackageFile: args[0]},
+			Reader:         &persistence.FilesystemIO{},
+			Signer:         cc.Signer,
+		}
+	}
+
+	approver := &ApproverForMyOrg{
+		Command:         cmd,
+		Certificate:     cc.Certificate,
+		BroadcastClient: cc.BroadcastClient,
+		DeliverClients:  cc.DeliverClients,
+		EndorserClients: endorserClients,
+		Signer:          cc.Signer,
+	}
+	if approver.Input, err = approver.createInput(); err != nil {
+		return nil, err
+	}
+	org.Approver = approver
+
+	checker := &CommitReadinessChecker{
+		Command:        cmd,
+		EndorserClient: cc.EndorserClients[0],
+		Signer:         cc.Signer,
+		Writer:         os.Stdout,
+	}
+	if checker.Input, err = checker.createInput(); err != nil {
+		return nil, err
+	}
+
+	committer := &Committer{
+		Command:         cmd,
+		Certificate:     cc.Certificate,
+		BroadcastClient: cc.BroadcastClient,
+		DeliverClients:  cc.DeliverClients,
+		EndorserClients: endorserClients,
+		Signer:          cc.Signer,
+	}
+	if committer.Input, err = committer.createInput(); err != nil {
+		return nil, err
+	}
+
+	return &Deployer{
+		Command:          cmd,
+		Orgs:             []*DeployOrg{org},
+		ApprovalsChecker: checker,
+		Committer:        committer,
+		Writer:           os.Stdout,
+	}, nil
+}
+
+// Deploy installs the chaincode package for the orgs with an installer,
+// approves the chaincode definition for the orgs which have not yet
+// approved it, and commits the definition once every org has approved.
+func (d *Deployer) Deploy() error {
+	if d.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		d.Command.SilenceUsage = true
+	}
+
+	for _, org := range d.Orgs {
+		if org.Installer == nil {
+			continue
+		}
+		if err := org.Installer.Install(); err != nil {
+			return errors.WithMessagef(err, "failed to install chaincode package for org %s", org.MSPID)
+		}
+	}
+
+	approvals, err := d.ApprovalsChecker.Approvals()
+	if err != nil {
+		return errors.WithMessage(err, "failed to check commit readiness")
+	}
+
+	for _, org := range d.Orgs {
+		if approvals[org.MSPID] {
+			fmt.Fprintf(d.Writer, "Chaincode definition has already been approved by org %s\n", org.MSPID)
+			continue
+		}
+		if err := org.Approver.Approve(); err != nil {
+			return errors.WithMessagef(err, "failed to approve chaincode definition for org %s", org.MSPID)
+		}
+		fmt.Fprintf(d.Writer, "Approved chaincode definition for org %s\n", org.MSPID)
+	}
+
+	if approvals, err = d.ApprovalsChecker.Approvals(); err != nil {
+		return errors.WithMessage(err, "failed to check commit readiness")
+	}
+
+	var missing []string
+	for org, approved := range approvals {
+		if !approved {
+			missing = append(missing, org)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return errors.Errorf("chaincode definition has not yet been approved by: %s. Rerun the deployment once the remaining orgs have approved it to commit the definition", strings.Join(missing, ", "))
+	}
+
+	if err := d.Committer.Commit(); err != nil {
+		return errors.WithMessage(err, "failed to commit chaincode definition")
+	}
+	fmt.Fprintf(d.Writer, "Committed chaincode definition on channel\n")
+
+	return nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Deploy", func() {
+	Describe("Deployer", func() {
+		var (
+			mockInstaller        *mock.PackageInstaller
+			mockApprover         *mock.DefinitionApprover
+			mockApprovalsChecker *mock.ApprovalsChecker
+			mockCommitter        *mock.DefinitionCommitter
+			deployer             *chaincode.Deployer
+		)
+
+		BeforeEach(func() {
+			mockInstaller = &mock.PackageInstaller{}
+			mockApprover = &mock.DefinitionApprover{}
+			mockApprovalsChecker = &mock.ApprovalsChecker{}
+			mockApprovalsChecker.ApprovalsReturnsOnCall(0, map[string]bool{"Org1MSP": false}, nil)
+			mockApprovalsChecker.ApprovalsReturnsOnCall(1, map[string]bool{"Org1MSP": true}, nil)
+			mockCommitter = &mock.DefinitionCommitter{}
+
+			deployer = &chaincode.Deployer{
+				Orgs: []*chaincode.DeployOrg{
+					{
+						MSPID:     "Org1MSP",
+						Installer: mockInstaller,
+						Approver:  mockApprover,
+					},
+				},
+				ApprovalsChecker: mockApprovalsChecker,
+				Committer:        mockCommitter,
+				Writer:           gbytes.NewBuffer(),
+			}
+		})
+
+		It("installs the package, approves the definition for the org, and commits it", func() {
+			err := deployer.Deploy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockInstaller.InstallCallCount()).To(Equal(1))
+			Expect(mockApprover.ApproveCallCount()).To(Equal(1))
+			Expect(mockApprovalsChecker.ApprovalsCallCount()).To(Equal(2))
+			Expect(mockCommitter.CommitCallCount()).To(Equal(1))
+			Eventually(deployer.Writer).Should(gbytes.Say("Approved chaincode definition for org Org1MSP"))
+			Eventually(deployer.Writer).Should(gbytes.Say("Committed chaincode definition on channel"))
+		})
+
+		Context("when the org does not have an installer", func() {
+			BeforeEach(func() {
+				deployer.Orgs[0].Installer = nil
+			})
+
+			It("skips the installation", func() {
+				err := deployer.Deploy()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockCommitter.CommitCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the org has already approved the definition", func() {
+			BeforeEach(func() {
+				// override the per-call stubs of the outer BeforeEach, as
+				// counterfeiter prefers them over the catch-all return
+				mockApprovalsChecker.ApprovalsReturnsOnCall(0, map[string]bool{"Org1MSP": true}, nil)
+				mockApprovalsChecker.ApprovalsReturns(map[string]bool{"Org1MSP": true}, nil)
+			})
+
+			It("skips the approval and commits the definition", func() {
+				err := deployer.Deploy()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockApprover.ApproveCallCount()).To(Equal(0))
+				Expect(mockCommitter.CommitCallCount()).To(Equal(1))
+				Eventually(deployer.Writer).Should(gbytes.Say("Chaincode definition has already been approved by org Org1MSP"))
+			})
+		})
+
+		Context("when multiple orgs are deploying", func() {
+			var (
+				org2Installer *mock.PackageInstaller
+				org2Approver  *mock.DefinitionApprover
+			)
+
+			BeforeEach(func() {
+				org2Installer = &mock.PackageInstaller{}
+				org2Approver = &mock.DefinitionApprover{}
+				deployer.Orgs = append(deployer.Orgs, &chaincode.DeployOrg{
+					MSPID:     "Org2MSP",
+					Installer: org2Installer,
+					Approver:  org2Approver,
+				})
+				mockApprovalsChecker.ApprovalsReturnsOnCall(0, map[string]bool{"Org1MSP": true, "Org2MSP": false}, nil)
+				mockApprovalsChecker.ApprovalsReturnsOnCall(1, map[string]bool{"Org1MSP": true, "Org2MSP": true}, nil)
+			})
+
+			It("approves the definition only for the orgs which have not approved it", func() {
+				err := deployer.Deploy()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mockInstaller.InstallCallCount()).To(Equal(1))
+				Expect(org2Installer.InstallCallCount()).To(Equal(1))
+				Expect(mockApprover.ApproveCallCount()).To(Equal(0))
+				Expect(org2Approver.ApproveCallCount()).To(Equal(1))
+				Expect(mockCommitter.CommitCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the installation fails", func() {
+			BeforeEach(func() {
+				mockInstaller.InstallReturns(errors.New("latte"))
+			})
+
+			It("returns an error and does not approve the definition", func() {
+				err := deployer.Deploy()
+				Expect(err).To(MatchError("failed to install chaincode package for org Org1MSP: latte"))
+				Expect(mockApprover.ApproveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the readiness check fails", func() {
+			BeforeEach(func() {
+				mockApprovalsChecker.ApprovalsReturnsOnCall(0, nil, errors.New("macchiato"))
+			})
+
+			It("returns an error", func() {
+				err := deployer.Deploy()
+				Expect(err).To(MatchError("failed to check commit readiness: macchiato"))
+			})
+		})
+
+		Context("when the approval fails", func() {
+			BeforeEach(func() {
+				mockApprover.ApproveReturns(errors.New("mocha"))
+			})
+
+			It("returns an error and does not commit the definition", func() {
+				err := deployer.Deploy()
+				Expect(err).To(MatchError("failed to approve chaincode definition for org Org1MSP: mocha"))
+				Expect(mockCommitter.CommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when approvals from other orgs are still outstanding", func() {
+			BeforeEach(func() {
+				mockApprovalsChecker.ApprovalsReturnsOnCall(1, map[string]bool{"Org1MSP": true, "Org3MSP": false, "Org2MSP": false}, nil)
+			})
+
+			It("returns an error and does not commit the definition", func() {
+				err := deployer.Deploy()
+				Expect(err).To(MatchError("chaincode definition has not yet been approved by: Org2MSP, Org3MSP. Rerun the deployment once the remaining orgs have approved it to commit the definition"))
+				Expect(mockCommitter.CommitCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the commit fails", func() {
+			BeforeEach(func() {
+				mockCommitter.CommitReturns(errors.New("espresso"))
+			})
+
+			It("returns an error", func() {
+				err := deployer.Deploy()
+				Expect(err).To(MatchError("failed to commit chaincode definition: espresso"))
+			})
+		})
+	})
+
+	Describe("DeployCmd", func() {
+		var (
+			deployCmd *cobra.Command
+		)
+
+		BeforeEach(func() {
+			cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+			Expect(err).To(BeNil())
+			deployCmd = chaincode.DeployCmd(nil, cryptoProvider)
+			deployCmd.SetArgs([]string{
+				"testpkg",
+				"--channelID=testchannel",
+				"--name=testcc",
+				"--version=testversion",
+				"--sequence=1",
+				"--peerAddresses=deploypeer1",
+				"--tlsRootCertFiles=tls1",
+			})
+		})
+
+		AfterEach(func() {
+			chaincode.ResetFlags()
+		})
+
+		It("sets up the deployer and attempts the deployment", func() {
+			err := deployCmd.Execute()
+			Expect(err).To(MatchError(ContainSubstring("failed to retrieve endorser client")))
+		})
+
+		Context("when more than one argument is provided", func() {
+			BeforeEach(func() {
+				deployCmd.SetArgs([]string{
+					"testpkg",
+					"anotherpkg",
+					"--channelID=testchannel",
+					"--name=testcc",
+					"--version=testversion",
+					"--sequence=1",
+				})
+			})
+
+			It("returns an error", func() {
+				err := deployCmd.Execute()
+				Expect(err).To(MatchError("invalid number of args. expected at most the chaincode package file"))
+			})
+		})
+	})
+})
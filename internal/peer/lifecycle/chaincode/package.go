@@ -14,7 +14,9 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hyperledger/fabric/core/chaincode/eventschema"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/internal/peer/packaging"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -33,16 +35,20 @@ type Packager struct {
 	Command          *cobra.Command
 	Input            *PackageInput
 	PlatformRegistry PlatformRegistry
+	Reader           Reader
 	Writer           Writer
+	Signer           Signer
 }
 
 // PackageInput holds the input parameters for packaging a
 // ChaincodeInstallPackage
 type PackageInput struct {
-	OutputFile string
-	Path       string
-	Type       string
-	Label      string
+	OutputFile       string
+	Path             string
+	Type             string
+	Label            string
+	SignPackage      bool
+	EventSchemasFile string
 }
 
 // Validate checks for the required inputs
@@ -79,8 +85,17 @@ func PackageCmd(p *Packager) *cobra.Command {
 
 				p = &Packager{
 					PlatformRegistry: pr,
+					Reader:           &persistence.FilesystemIO{},
 					Writer:           &persistence.FilesystemIO{},
 				}
+
+				if signPackage {
+					signer, err := common.GetDefaultSigner()
+					if err != nil {
+						return err
+					}
+					p.Signer = signer
+				}
 			}
 			p.Command = cmd
 
@@ -94,6 +109,8 @@ func PackageCmd(p *Packager) *cobra.Command {
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
+		"sign",
+		"event-schemas",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
@@ -117,10 +134,12 @@ func (p *Packager) PackageChaincode(args []string) error {
 
 func (p *Packager) setInput(outputFile string) {
 	p.Input = &PackageInput{
-		OutputFile: outputFile,
-		Path:       chaincodePath,
-		Type:       chaincodeLang,
-		Label:      packageLabel,
+		OutputFile:       outputFile,
+		Path:             chaincodePath,
+		Type:             chaincodeLang,
+		Label:            packageLabel,
+		SignPackage:      signPackage,
+		EventSchemasFile: eventSchemasFile,
 	}
 }
 
@@ -132,6 +151,10 @@ func (p *Packager) Package() error {
 		return err
 	}
 
+	if p.Input.SignPackage && p.Signer == nil {
+		return errors.New("package signing was requested, but no signer is available")
+	}
+
 	pkgTarGzBytes, err := p.getTarGzBytes()
 	if err != nil {
 		return err
@@ -162,7 +185,11 @@ func (p *Packager) getTarGzBytes() ([]byte, error) {
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed to normalize chaincode path")
 	}
-	metadataBytes, err := toJSON(normalizedPath, p.Input.Type, p.Input.Label)
+	eventSchemas, err := p.loadEventSchemas()
+	if err != nil {
+		return nil, err
+	}
+	metadataBytes, err := toJSON(normalizedPath, p.Input.Type, p.Input.Label, eventSchemas)
 	if err != nil {
 		return nil, err
 	}
@@ -183,6 +210,18 @@ func (p *Packager) getTarGzBytes() ([]byte, error) {
 		return nil, errors.Wrap(err, "error writing package code bytes to tar")
 	}
 
+	if p.Input.SignPackage {
+		signatureBytes, err := p.signPackage(metadataBytes, codeBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeBytesToPackage(tw, persistence.SignatureFile, signatureBytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "error writing package signature to tar")
+		}
+	}
+
 	err = tw.Close()
 	if err == nil {
 		err = gw.Close()
@@ -194,6 +233,32 @@ func (p *Packager) getTarGzBytes() ([]byte, error) {
 	return payload.Bytes(), nil
 }
 
+// signPackage signs the metadata and code package entries with the
+// packager's identity and returns the json encoded signature file. The
+// embedded identity and signature allow peers to verify the provenance
+// of the package at install time.
+func (p *Packager) signPackage(metadataBytes, codeBytes []byte) ([]byte, error) {
+	identity, err := p.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error serializing signer identity")
+	}
+
+	signature, err := p.Signer.Sign(append(append([]byte{}, metadataBytes...), codeBytes...))
+	if err != nil {
+		return nil, errors.WithMessage(err, "error signing chaincode package")
+	}
+
+	signatureBytes, err := json.Marshal(&persistence.ChaincodePackageSignature{
+		Identity:  identity,
+		Signature: signature,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal chaincode package signature into JSON")
+	}
+
+	return signatureBytes, nil
+}
+
 func writeBytesToPackage(tw *tar.Writer, name string, payload []byte) error {
 	err := tw.WriteHeader(&tar.Header{
 		Name: name,
@@ -212,18 +277,47 @@ func writeBytesToPackage(tw *tar.Writer, name string, payload []byte) error {
 	return nil
 }
 
+// loadEventSchemas reads and validates the event payload schemas named by
+// the event schemas file. The file contains a JSON object mapping event
+// names to their schemas.
+func (p *Packager) loadEventSchemas() (map[string]json.RawMessage, error) {
+	if p.Input.EventSchemasFile == "" {
+		return nil, nil
+	}
+
+	schemasBytes, err := p.Reader.ReadFile(p.Input.EventSchemasFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read event schemas file at %s", p.Input.EventSchemasFile)
+	}
+
+	eventSchemas := map[string]json.RawMessage{}
+	if err := json.Unmarshal(schemasBytes, &eventSchemas); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal event schemas file at %s as a JSON object", p.Input.EventSchemasFile)
+	}
+
+	for eventName, schemaBytes := range eventSchemas {
+		if _, err := eventschema.Parse(schemaBytes); err != nil {
+			return nil, errors.WithMessagef(err, "invalid schema for event '%s'", eventName)
+		}
+	}
+
+	return eventSchemas, nil
+}
+
 // PackageMetadata holds the path and type for a chaincode package
 type PackageMetadata struct {
-	Path  string `json:"path"`
-	Type  string `json:"type"`
-	Label string `json:"label"`
+	Path         string                     `json:"path"`
+	Type         string                     `json:"type"`
+	Label        string                     `json:"label"`
+	EventSchemas map[string]json.RawMessage `json:"event_schemas,omitempty"`
 }
 
-func toJSON(path, ccType, label string) ([]byte, error) {
+func toJSON(path, ccType, label string, eventSchemas map[string]json.RawMessage) ([]byte, error) {
 	metadata := &PackageMetadata{
-		Path:  path,
-		Type:  ccType,
-		Label: label,
+		Path:         path,
+		Type:         ccType,
+		Label:        label,
+		EventSchemas: eventSchemas,
 	}
 
 	metadataBytes, err := json.Marshal(metadata)
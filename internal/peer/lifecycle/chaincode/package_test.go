@@ -10,11 +10,13 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
 	"github.com/pkg/errors"
@@ -167,6 +169,139 @@ var _ = Describe("Package", func() {
 				Expect(err).To(MatchError("error writing chaincode package to testDir/testPackage: espresso"))
 			})
 		})
+
+		Context("when event schemas are provided", func() {
+			var mockReader *mock.Reader
+
+			BeforeEach(func() {
+				mockReader = &mock.Reader{}
+				mockReader.ReadFileReturns([]byte(`{"transfer":{"type":"object"}}`), nil)
+
+				packager.Reader = mockReader
+				input.EventSchemasFile = "testSchemas.json"
+			})
+
+			It("embeds the schemas in the package metadata", func() {
+				err := packager.Package()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockReader.ReadFileCallCount()).To(Equal(1))
+				Expect(mockReader.ReadFileArgsForCall(0)).To(Equal("testSchemas.json"))
+
+				_, _, pkgTarGzBytes := mockWriter.WriteFileArgsForCall(0)
+				metadata, err := readMetadataFromBytes(pkgTarGzBytes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(metadata).To(MatchJSON(`{"path":"normalizedPath","type":"testType","label":"testLabel","event_schemas":{"transfer":{"type":"object"}}}`))
+			})
+
+			Context("when reading the event schemas file fails", func() {
+				BeforeEach(func() {
+					mockReader.ReadFileReturns(nil, errors.New("latte"))
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("failed to read event schemas file at testSchemas.json: latte"))
+				})
+			})
+
+			Context("when the file does not contain a JSON object", func() {
+				BeforeEach(func() {
+					mockReader.ReadFileReturns([]byte(`["transfer"]`), nil)
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError(ContainSubstring("could not unmarshal event schemas file at testSchemas.json as a JSON object")))
+				})
+			})
+
+			Context("when a schema is invalid", func() {
+				BeforeEach(func() {
+					mockReader.ReadFileReturns([]byte(`{"transfer":{"type":"object","minLength":3}}`), nil)
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("invalid schema for event 'transfer': unsupported schema keyword 'minLength'"))
+				})
+			})
+		})
+
+		Context("when signing is requested", func() {
+			var mockSigner *mock.Signer
+
+			BeforeEach(func() {
+				mockSigner = &mock.Signer{}
+				mockSigner.SerializeReturns([]byte("signer-identity"), nil)
+				mockSigner.SignReturns([]byte("package-signature"), nil)
+				mockPlatformRegistry.GetDeploymentPayloadReturns([]byte("code-bytes"), nil)
+
+				packager.Signer = mockSigner
+				input.SignPackage = true
+			})
+
+			It("embeds the signer's identity and signature in the package", func() {
+				err := packager.Package()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, pkgTarGzBytes := mockWriter.WriteFileArgsForCall(0)
+				signatureJSON, err := readFileFromBytes(pkgTarGzBytes, "signature.json")
+				Expect(err).NotTo(HaveOccurred())
+
+				signature := &persistence.ChaincodePackageSignature{}
+				err = json.Unmarshal(signatureJSON, signature)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(signature.Identity).To(Equal([]byte("signer-identity")))
+				Expect(signature.Signature).To(Equal([]byte("package-signature")))
+			})
+
+			It("signs the metadata and code package bytes", func() {
+				err := packager.Package()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, pkgTarGzBytes := mockWriter.WriteFileArgsForCall(0)
+				metadataBytes, err := readFileFromBytes(pkgTarGzBytes, "metadata.json")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockSigner.SignCallCount()).To(Equal(1))
+				signedBytes := mockSigner.SignArgsForCall(0)
+				Expect(signedBytes).To(Equal(append(append([]byte{}, metadataBytes...), []byte("code-bytes")...)))
+			})
+
+			Context("when no signer is available", func() {
+				BeforeEach(func() {
+					packager.Signer = nil
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("package signing was requested, but no signer is available"))
+				})
+			})
+
+			Context("when serializing the signer identity fails", func() {
+				BeforeEach(func() {
+					mockSigner.SerializeReturns(nil, errors.New("ristretto"))
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("error serializing signer identity: ristretto"))
+				})
+			})
+
+			Context("when signing fails", func() {
+				BeforeEach(func() {
+					mockSigner.SignReturns(nil, errors.New("macchiato"))
+				})
+
+				It("returns an error", func() {
+					err := packager.Package()
+					Expect(err).To(MatchError("error signing chaincode package: macchiato"))
+				})
+			})
+		})
 	})
 
 	Describe("PackageCmd", func() {
@@ -238,3 +373,24 @@ func readMetadataFromBytes(pkgTarGzBytes []byte) ([]byte, error) {
 	}
 	return nil, errors.New("metadata.json not found")
 }
+
+func readFileFromBytes(pkgTarGzBytes []byte, name string) ([]byte, error) {
+	buffer := bytes.NewBuffer(pkgTarGzBytes)
+	gzr, err := gzip.NewReader(buffer)
+	Expect(err).NotTo(HaveOccurred())
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Name == name {
+			return ioutil.ReadAll(tr)
+		}
+	}
+	return nil, errors.Errorf("%s not found", name)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/packaging"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// BuildVerifier holds the dependencies needed to rebuild
+// a chaincode package from source and verify that it
+// reproduces an installed package
+type BuildVerifier struct {
+	Command          *cobra.Command
+	Input            *VerifyBuildInput
+	PlatformRegistry PlatformRegistry
+	Writer           io.Writer
+}
+
+// VerifyBuildInput holds the input parameters for verifying
+// that a chaincode build is reproducible
+type VerifyBuildInput struct {
+	Path      string
+	Type      string
+	Label     string
+	PackageID string
+}
+
+// Validate checks for the required inputs
+func (v *VerifyBuildInput) Validate() error {
+	if v.Path == "" {
+		return errors.New("chaincode path must be specified")
+	}
+	if v.Type == "" {
+		return errors.New("chaincode language must be specified")
+	}
+	if v.Label == "" {
+		return errors.New("package label must be specified")
+	}
+	if err := persistence.ValidateLabel(v.Label); err != nil {
+		return err
+	}
+	if v.PackageID == "" {
+		return errors.New("The required parameter 'package-id' is empty. Rerun the command with --package-id flag")
+	}
+
+	return nil
+}
+
+// VerifyBuildCmd returns the cobra command for verifying that a chaincode
+// build reproduces an installed package
+func VerifyBuildCmd(v *BuildVerifier) *cobra.Command {
+	chaincodeVerifyBuildCmd := &cobra.Command{
+		Use:   "verifybuild",
+		Short: "Verify that a chaincode build reproduces an installed package.",
+		Long:  "Rebuild a chaincode package from source and verify that the resulting package ID matches the package ID of an installed package. Because packaging is deterministic, a match proves that the installed package was built from the same source.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if v == nil {
+				pr := packaging.NewRegistry(packaging.SupportedPlatforms...)
+
+				v = &BuildVerifier{
+					PlatformRegistry: pr,
+					Writer:           os.Stdout,
+				}
+			}
+			v.Command = cmd
+
+			return v.VerifyBuild(args)
+		},
+	}
+	flagList := []string{
+		"label",
+		"lang",
+		"path",
+		"package-id",
+	}
+	attachFlags(chaincodeVerifyBuildCmd, flagList)
+
+	return chaincodeVerifyBuildCmd
+}
+
+// VerifyBuild rebuilds a chaincode package and verifies that it
+// reproduces the supplied package ID.
+func (v *BuildVerifier) VerifyBuild(args []string) error {
+	if v.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		v.Command.SilenceUsage = true
+	}
+
+	if len(args) != 0 {
+		return errors.New("invalid number of args. expected none")
+	}
+	v.setInput()
+
+	return v.Verify()
+}
+
+func (v *BuildVerifier) setInput() {
+	v.Input = &VerifyBuildInput{
+		Path:      chaincodePath,
+		Type:      chaincodeLang,
+		Label:     packageLabel,
+		PackageID: packageID,
+	}
+}
+
+// Verify rebuilds the chaincode package from source and compares the
+// package ID of the result with the supplied package ID. The rebuilt
+// package is never signed, since a signature would alter the package
+// bytes and therefore the package ID.
+func (v *BuildVerifier) Verify() error {
+	err := v.Input.Validate()
+	if err != nil {
+		return err
+	}
+
+	packager := &Packager{
+		PlatformRegistry: v.PlatformRegistry,
+		Input: &PackageInput{
+			Path:  v.Input.Path,
+			Type:  v.Input.Type,
+			Label: v.Input.Label,
+		},
+	}
+
+	pkgTarGzBytes, err := packager.getTarGzBytes()
+	if err != nil {
+		return err
+	}
+
+	computedPackageID := persistence.PackageID(v.Input.Label, pkgTarGzBytes)
+	if computedPackageID != v.Input.PackageID {
+		return errors.Errorf("rebuilt package ID '%s' does not match the supplied package ID '%s'", computedPackageID, v.Input.PackageID)
+	}
+
+	fmt.Fprintf(v.Writer, "Rebuilt package ID '%s' matches the supplied package ID\n", computedPackageID)
+
+	return nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("VerifyBuild", func() {
+	Describe("BuildVerifier", func() {
+		var (
+			mockPlatformRegistry *mock.PlatformRegistry
+			input                *chaincode.VerifyBuildInput
+			verifier             *chaincode.BuildVerifier
+		)
+
+		BeforeEach(func() {
+			mockPlatformRegistry = &mock.PlatformRegistry{}
+			mockPlatformRegistry.NormalizePathReturns("normalizedPath", nil)
+			mockPlatformRegistry.GetDeploymentPayloadReturns([]byte("code-bytes"), nil)
+
+			expectedPkgBytes := buildPackageBytes(
+				[]byte(`{"path":"normalizedPath","type":"testType","label":"testLabel"}`),
+				[]byte("code-bytes"),
+			)
+
+			input = &chaincode.VerifyBuildInput{
+				Path:      "testPath",
+				Type:      "testType",
+				Label:     "testLabel",
+				PackageID: persistence.PackageID("testLabel", expectedPkgBytes),
+			}
+
+			verifier = &chaincode.BuildVerifier{
+				PlatformRegistry: mockPlatformRegistry,
+				Input:            input,
+				Writer:           gbytes.NewBuffer(),
+			}
+		})
+
+		It("verifies that the rebuilt package reproduces the package ID", func() {
+			err := verifier.Verify()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mockPlatformRegistry.NormalizePathCallCount()).To(Equal(1))
+			ccType, path := mockPlatformRegistry.NormalizePathArgsForCall(0)
+			Expect(ccType).To(Equal("TESTTYPE"))
+			Expect(path).To(Equal("testPath"))
+
+			Expect(mockPlatformRegistry.GetDeploymentPayloadCallCount()).To(Equal(1))
+
+			Eventually(verifier.Writer).Should(gbytes.Say("matches the supplied package ID"))
+		})
+
+		Context("when the rebuilt package does not match the package ID", func() {
+			BeforeEach(func() {
+				input.PackageID = "testLabel:0000000000000000000000000000000000000000000000000000000000000000"
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError(ContainSubstring("does not match the supplied package ID 'testLabel:0000000000000000000000000000000000000000000000000000000000000000'")))
+			})
+		})
+
+		Context("when the path is not provided", func() {
+			BeforeEach(func() {
+				input.Path = ""
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("chaincode path must be specified"))
+			})
+		})
+
+		Context("when the type is not provided", func() {
+			BeforeEach(func() {
+				input.Type = ""
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("chaincode language must be specified"))
+			})
+		})
+
+		Context("when the label is not provided", func() {
+			BeforeEach(func() {
+				input.Label = ""
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("package label must be specified"))
+			})
+		})
+
+		Context("when the label is invalid", func() {
+			BeforeEach(func() {
+				input.Label = "label with spaces"
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("invalid label 'label with spaces'. Label must be non-empty, can only consist of alphanumerics, symbols from '.+-_', and can only begin with alphanumerics"))
+			})
+		})
+
+		Context("when the package ID is not provided", func() {
+			BeforeEach(func() {
+				input.PackageID = ""
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("The required parameter 'package-id' is empty. Rerun the command with --package-id flag"))
+			})
+		})
+
+		Context("when the platform registry fails to get the deployment payload", func() {
+			BeforeEach(func() {
+				mockPlatformRegistry.GetDeploymentPayloadReturns(nil, errors.New("americano"))
+			})
+
+			It("returns an error", func() {
+				err := verifier.Verify()
+				Expect(err).To(MatchError("error getting chaincode bytes: americano"))
+			})
+		})
+	})
+
+	Describe("VerifyBuildCmd", func() {
+		var verifyBuildCmd *cobra.Command
+
+		BeforeEach(func() {
+			verifyBuildCmd = chaincode.VerifyBuildCmd(nil)
+			verifyBuildCmd.SetArgs([]string{
+				"--path=testPath",
+				"--lang=golang",
+				"--label=testLabel",
+				"--package-id=testLabel:fakehash",
+			})
+		})
+
+		It("sets up the build verifier and attempts to rebuild the chaincode", func() {
+			err := verifyBuildCmd.Execute()
+			Expect(err).To(MatchError(ContainSubstring("error getting chaincode bytes")))
+		})
+
+		Context("when an argument is provided", func() {
+			BeforeEach(func() {
+				verifyBuildCmd = chaincode.VerifyBuildCmd(nil)
+				verifyBuildCmd.SetArgs([]string{
+					"whatthe",
+				})
+			})
+
+			It("returns an error", func() {
+				err := verifyBuildCmd.Execute()
+				Expect(err).To(MatchError("invalid number of args. expected none"))
+			})
+		})
+	})
+})
+
+func buildPackageBytes(metadataBytes, codeBytes []byte) []byte {
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for _, file := range []struct {
+		name  string
+		bytes []byte
+	}{
+		{name: "metadata.json", bytes: metadataBytes},
+		{name: "code.tar.gz", bytes: codeBytes},
+	} {
+		err := tw.WriteHeader(&tar.Header{
+			Name: file.name,
+			Size: int64(len(file.bytes)),
+			Mode: 0100644,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tw.Write(file.bytes)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return payload.Bytes()
+}
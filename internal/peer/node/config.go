@@ -9,9 +9,12 @@ package node
 import (
 	"path/filepath"
 
+	"github.com/hyperledger/fabric/common/ledger/blockarchival"
+	"github.com/hyperledger/fabric/common/ledger/blockexport"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/hyperledger/fabric/core/ledger/util/postgres"
 	"github.com/spf13/viper"
 )
 
@@ -57,6 +60,11 @@ func ledgerConfig() *ledger.Config {
 		HistoryDBConfig: &ledger.HistoryDBConfig{
 			Enabled: viper.GetBool("ledger.history.enableHistoryDatabase"),
 		},
+		EncryptionConfig: &ledger.EncryptionConfig{
+			Enabled: viper.GetBool("ledger.encryption.enabled"),
+			KeyPath: viper.GetString("ledger.encryption.keyPath"),
+		},
+		ValidationWorkerPoolSize: viper.GetInt("ledger.validationWorkerPoolSize"),
 	}
 
 	if conf.StateDBConfig.StateDatabase == "CouchDB" {
@@ -73,6 +81,65 @@ func ledgerConfig() *ledger.Config {
 			CreateGlobalChangesDB:   viper.GetBool("ledger.state.couchDBConfig.createGlobalChangesDB"),
 			RedoLogPath:             filepath.Join(rootFSPath, "couchdbRedoLogs"),
 			UserCacheSizeMBs:        viper.GetInt("ledger.state.couchDBConfig.cacheSize"),
+			TLS: couchdb.TLSConfig{
+				Enabled:         viper.GetBool("ledger.state.couchDBConfig.tls.enabled"),
+				ClientCertFile:  coreconfig.GetPath("ledger.state.couchDBConfig.tls.clientCert.file"),
+				ClientKeyFile:   coreconfig.GetPath("ledger.state.couchDBConfig.tls.clientKey.file"),
+				RootCACertFiles: viper.GetStringSlice("ledger.state.couchDBConfig.tls.rootCAs.files"),
+			},
+		}
+	}
+
+	if retainBlocks := viper.GetInt("ledger.blockArchival.retainBlocks"); retainBlocks > 0 {
+		conf.BlockArchivalConfig = &ledger.BlockArchivalConfig{
+			RetainBlocks: uint64(retainBlocks),
+			Archiver:     viper.GetString("ledger.blockArchival.archiver"),
+			FileDir:      viper.GetString("ledger.blockArchival.fileDir"),
+		}
+		if conf.BlockArchivalConfig.Archiver == "s3" {
+			conf.BlockArchivalConfig.S3 = &blockarchival.S3Config{
+				Endpoint:        viper.GetString("ledger.blockArchival.s3.endpoint"),
+				Bucket:          viper.GetString("ledger.blockArchival.s3.bucket"),
+				Region:          viper.GetString("ledger.blockArchival.s3.region"),
+				AccessKeyID:     viper.GetString("ledger.blockArchival.s3.accessKeyID"),
+				SecretAccessKey: viper.GetString("ledger.blockArchival.s3.secretAccessKey"),
+				Prefix:          viper.GetString("ledger.blockArchival.s3.prefix"),
+			}
+		}
+	}
+
+	if sink := viper.GetString("ledger.blockExport.sink"); sink != "" {
+		conf.BlockExportConfig = &ledger.BlockExportConfig{
+			Sink:    sink,
+			Content: viper.GetString("ledger.blockExport.content"),
+		}
+		switch sink {
+		case "kafka":
+			conf.BlockExportConfig.Kafka = &blockexport.KafkaConfig{
+				Brokers: viper.GetStringSlice("ledger.blockExport.kafka.brokers"),
+				Topic:   viper.GetString("ledger.blockExport.kafka.topic"),
+			}
+		case "webhook":
+			conf.BlockExportConfig.Webhook = &blockexport.WebhookConfig{
+				URL:            viper.GetString("ledger.blockExport.webhook.url"),
+				RequestTimeout: viper.GetDuration("ledger.blockExport.webhook.requestTimeout"),
+			}
+		}
+	}
+
+	if conf.StateDBConfig.StateDatabase == "Postgres" {
+		postgresQueryLimit := 1000
+		if viper.IsSet("ledger.state.postgresConfig.internalQueryLimit") {
+			postgresQueryLimit = viper.GetInt("ledger.state.postgresConfig.internalQueryLimit")
+		}
+		conf.StateDBConfig.Postgres = &postgres.Config{
+			DataSourceName:     viper.GetString("ledger.state.postgresConfig.dataSourceName"),
+			Driver:             viper.GetString("ledger.state.postgresConfig.driver"),
+			DriverPlugin:       coreconfig.GetPath("ledger.state.postgresConfig.driverPlugin"),
+			MaxOpenConns:       viper.GetInt("ledger.state.postgresConfig.maxOpenConns"),
+			MaxIdleConns:       viper.GetInt("ledger.state.postgresConfig.maxIdleConns"),
+			ConnMaxLifetime:    viper.GetDuration("ledger.state.postgresConfig.connMaxLifetime"),
+			InternalQueryLimit: postgresQueryLimit,
 		}
 	}
 	return conf
@@ -43,6 +43,7 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				EncryptionConfig: &ledger.EncryptionConfig{},
 			},
 		},
 		{
@@ -86,6 +87,7 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: false,
 				},
+				EncryptionConfig: &ledger.EncryptionConfig{},
 			},
 		},
 		{
@@ -136,6 +138,7 @@ func TestLedgerConfig(t *testing.T) {
 				HistoryDBConfig: &ledger.HistoryDBConfig{
 					Enabled: true,
 				},
+				EncryptionConfig: &ledger.EncryptionConfig{},
 			},
 		},
 	}
@@ -8,6 +8,7 @@ package node
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -16,6 +17,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -27,11 +29,13 @@ import (
 	discprotos "github.com/hyperledger/fabric-protos-go/discovery"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/accessaudit"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/denylist"
 	"github.com/hyperledger/fabric/common/flogging"
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
@@ -44,6 +48,7 @@ import (
 	"github.com/hyperledger/fabric/core/cclifecycle"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/core/chaincode/eventschema"
 	"github.com/hyperledger/fabric/core/chaincode/extcc"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
@@ -54,12 +59,16 @@ import (
 	"github.com/hyperledger/fabric/core/common/privdata"
 	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/cclogs"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/kubecontroller"
+	"github.com/hyperledger/fabric/core/container/wasmruntime"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
+	authFilter "github.com/hyperledger/fabric/core/handlers/auth/filter"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
 	"github.com/hyperledger/fabric/core/handlers/library"
@@ -67,6 +76,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
@@ -89,9 +99,12 @@ import (
 	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/service"
 	gossipservice "github.com/hyperledger/fabric/gossip/service"
+	peercommon "github.com/hyperledger/fabric/internal/peer/common"
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
+	"github.com/hyperledger/fabric/internal/pkg/renewal"
 	"github.com/hyperledger/fabric/msp"
+	mspcache "github.com/hyperledger/fabric/msp/cache"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -175,9 +188,17 @@ func (e endorserChannelAdapter) Channel(channelID string) *endorser.Channel {
 type custodianLauncherAdapter struct {
 	launcher      chaincode.Launcher
 	streamHandler extcc.StreamHandler
+	// launchDisabled suppresses the proactive launches the custodian
+	// enqueues when chaincode definitions are committed; it is set in the
+	// on-demand execution mode, where chaincodes are only launched when
+	// their first invocation arrives.
+	launchDisabled bool
 }
 
 func (c custodianLauncherAdapter) Launch(ccid string) error {
+	if c.launchDisabled {
+		return nil
+	}
 	return c.launcher.Launch(ccid, c.streamHandler)
 }
 
@@ -226,6 +247,7 @@ func serve(args []string) error {
 	metricsProvider := opsSystem.Provider
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.SetObserver(logObserver)
+	mspcache.InitializeMetrics(metricsProvider)
 
 	mspID := coreConfig.LocalMSPID
 
@@ -309,14 +331,43 @@ func serve(args []string) error {
 	}
 
 	expirationLogger := flogging.MustGetLogger("certmonitor")
-	crypto.TrackExpiration(
-		serverConfig.SecOpts.UseTLS,
-		serverConfig.SecOpts.Certificate,
-		cs.GetClientCertificate().Certificate,
-		signingIdentityBytes,
-		expirationLogger.Warnf, // This can be used to piggyback a metric event in the future
-		time.Now(),
-		time.AfterFunc)
+	monitoredCerts := []crypto.MonitoredCertificate{
+		crypto.MonitoredIdentityCertificate("enrollment", signingIdentityBytes),
+	}
+	if serverConfig.SecOpts.UseTLS {
+		monitoredCerts = append(monitoredCerts, crypto.MonitoredCertificate{
+			Role:    "server TLS",
+			RawCert: serverConfig.SecOpts.Certificate,
+		})
+		if clientCertChain := cs.GetClientCertificate().Certificate; len(clientCertChain) > 0 {
+			monitoredCerts = append(monitoredCerts, crypto.MonitoredCertificate{
+				Role:    "client TLS",
+				RawCert: clientCertChain[0],
+			})
+		}
+	}
+	channelCertificates := func() []crypto.MonitoredCertificate {
+		var certs []crypto.MonitoredCertificate
+		for _, cInfo := range peerInstance.GetChannelsInfo() {
+			resources := peerInstance.GetChannelConfig(cInfo.ChannelId)
+			if resources == nil {
+				continue
+			}
+			certs = append(certs, crypto.ConfigCertificates(cInfo.ChannelId, resources.ConfigtxValidator().ConfigProto())...)
+		}
+		return certs
+	}
+	certMonitor := crypto.NewCertificateExpirationMonitor(
+		[]crypto.CertificateSource{
+			crypto.StaticCertificateSource(monitoredCerts...),
+			channelCertificates,
+		},
+		crypto.NewCertificateExpirationGauge(metricsProvider),
+		expirationLogger.Warnf,
+		time.Hour,
+	)
+	go certMonitor.Run()
+	defer certMonitor.Stop()
 
 	policyMgr := policies.PolicyManagerGetterFunc(peerInstance.GetPolicyManager)
 
@@ -437,6 +488,14 @@ func serve(args []string) error {
 		logger.Fatalf("Failed to create peer server (%s)", err)
 	}
 
+	if coreConfig.RenewalEnabled {
+		renewalAgent, err := startRenewalAgent(coreConfig, serverConfig, signingIdentityBytes, peerServer, peerInstance)
+		if err != nil {
+			return errors.WithMessage(err, "failed to initialize the certificate renewal agent")
+		}
+		defer renewalAgent.Stop()
+	}
+
 	// FIXME: Creating the gossip service has the side effect of starting a bunch
 	// of go routines and registration with the grpc server.
 	gossipService, err := initGossipService(
@@ -457,6 +516,25 @@ func serve(args []string) error {
 
 	peerInstance.GossipService = gossipService
 
+	// the runtime identity deny-list guards the endorser and deliver services
+	// and is managed through the operations server
+	identityDenyList := denylist.NewDenyList()
+
+	opsSystem.RegisterHandler("/reconciliation", gossipservice.NewReconciliationHandler(gossipService), true)
+	opsSystem.RegisterHandler("/gossip/membership", gossipservice.NewMembershipHandler(gossipService), true)
+	opsSystem.RegisterHandler("/gossip/leadership", gossipservice.NewLeadershipHandler(gossipService), true)
+	opsSystem.RegisterHandler("/state-cache", statedb.NewCacheHandler(peerInstance.LedgerMgr.StateCache()), true)
+	opsSystem.RegisterHandler("/block-index", ledgermgmt.NewBlockIndexHandler(peerInstance.LedgerMgr), true)
+	opsSystem.RegisterHandler("/audit/access", accessaudit.NewHandler(), true)
+	opsSystem.RegisterHandler("/denylist", denylist.NewHandler(identityDenyList), true)
+	opsSystem.RegisterHandler("/msp/reload", mgmt.NewReloadHandler(func() error {
+		return peercommon.InitCrypto(
+			coreconfig.GetPath("peer.mspConfigPath"),
+			coreConfig.LocalMSPID,
+			viper.GetString("peer.localMspType"),
+		)
+	}), true)
+
 	// Configure CC package storage
 	lsccInstallPath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "chaincodes")
 	ccprovider.SetChaincodesPath(lsccInstallPath)
@@ -477,6 +555,9 @@ func serve(args []string) error {
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	policyCheckerProvider := func(resourceName string) deliver.PolicyCheckerFunc {
 		return func(env *cb.Envelope, channelID string) error {
+			if err := identityDenyList.CheckEnvelope(env); err != nil {
+				return err
+			}
 			return aclProvider.CheckACL(resourceName, channelID, env)
 		}
 	}
@@ -517,12 +598,22 @@ func serve(args []string) error {
 		HandlerRegistry: chaincodeHandlerRegistry,
 	}
 
-	if coreConfig.VMEndpoint == "" && len(coreConfig.ExternalBuilders) == 0 {
-		logger.Panic("VMEndpoint not set and no ExternalBuilders defined")
+	if coreConfig.VMEndpoint == "" && len(coreConfig.ExternalBuilders) == 0 &&
+		!coreConfig.WasmRuntimeEnabled && !coreConfig.KubeChaincodeEnabled {
+		logger.Panic("VMEndpoint not set and no ExternalBuilders or alternative chaincode runtime defined")
 	}
 
 	chaincodeConfig := chaincode.GlobalConfig()
 
+	var ccLogBuffer *cclogs.Buffer
+	if chaincodeConfig.LogStreamEnabled {
+		ccLogBuffer = cclogs.NewBuffer(chaincodeConfig.LogStreamRetainedBytes)
+		opsSystem.RegisterHandler("/chaincodelogs", cclogs.NewHandler(ccLogBuffer), true)
+	}
+
+	eventSchemaRegistry := eventschema.NewRegistry(ccStore)
+	opsSystem.RegisterHandler("/eventschemas", eventschema.NewHandler(eventSchemaRegistry), true)
+
 	var dockerBuilder container.DockerBuilder
 	if coreConfig.VMEndpoint != "" {
 		client, err := createDockerClient(coreConfig)
@@ -551,7 +642,12 @@ func serve(args []string) error {
 				"CORE_CHAINCODE_LOGGING_SHIM=" + chaincodeConfig.ShimLogLevel,
 				"CORE_CHAINCODE_LOGGING_FORMAT=" + chaincodeConfig.LogFormat,
 			},
-			MSPID: mspID,
+			MSPID:                   mspID,
+			DefaultResourceLimits:   containerResourceLimits(chaincodeConfig.ResourceLimitsDefault),
+			ResourceLimitsOverrides: containerResourceLimitsOverrides(chaincodeConfig.ResourceLimitsOverrides),
+		}
+		if ccLogBuffer != nil {
+			dockerVM.LogObserver = ccLogBuffer
 		}
 		if err := opsSystem.RegisterChecker("docker", dockerVM); err != nil {
 			logger.Panicf("failed to register docker health check: %s", err)
@@ -569,10 +665,43 @@ func serve(args []string) error {
 		DurablePath: externalBuilderOutput,
 	}
 
+	var kubeBuilder container.KubeBuilder
+	if coreConfig.KubeChaincodeEnabled {
+		kubeClient, err := kubecontroller.NewInClusterClient()
+		if err != nil {
+			logger.Panicf("cannot create kubernetes client: %s", err)
+		}
+		if coreConfig.KubeChaincodeNamespace != "" {
+			kubeClient.Namespace = coreConfig.KubeChaincodeNamespace
+		}
+		kubeBuilder = &kubecontroller.KubeVM{
+			Client: kubeClient,
+			PeerID: coreConfig.PeerID,
+		}
+	}
+
+	var wasmBuilder container.WasmBuilder
+	if coreConfig.WasmRuntimeEnabled {
+		wasmOutput := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "wasmruntime", "modules")
+		if err := os.MkdirAll(wasmOutput, 0700); err != nil {
+			logger.Panicf("could not create wasm modules dir: %s", err)
+		}
+		wasmBuilder = &wasmruntime.Runtime{
+			Engine: &wasmruntime.ProcessEngine{Path: coreConfig.WasmEnginePath},
+			Limits: wasmruntime.Limits{
+				MaxMemoryPages:  coreConfig.WasmMaxMemoryPages,
+				MaxInstructions: coreConfig.WasmMaxInstructions,
+			},
+			DurablePath: wasmOutput,
+		}
+	}
+
 	buildRegistry := &container.BuildRegistry{}
 
 	containerRouter := &container.Router{
 		DockerBuilder:   dockerBuilder,
+		KubeBuilder:     kubeBuilder,
+		WasmBuilder:     wasmBuilder,
 		ExternalBuilder: externalVMAdapter{externalVM},
 		PackageProvider: &persistence.FallbackPackageLocator{
 			ChaincodePackageLocator: &persistence.ChaincodePackageLocator{
@@ -622,6 +751,9 @@ func serve(args []string) error {
 		InstalledChaincodesLister: lifecycleCache,
 		ChaincodeBuilder:          containerRouter,
 		BuildRegistry:             buildRegistry,
+		PackageSignatureValidator: &lifecycle.LocalPackageSignatureValidator{
+			Deserializer: mgmt.GetLocalMSP(factory.GetDefault()),
+		},
 	}
 
 	lifecycleSCC := &lifecycle.SCC{
@@ -637,14 +769,16 @@ func serve(args []string) error {
 	}
 
 	chaincodeLauncher := &chaincode.RuntimeLauncher{
-		Metrics:           chaincode.NewLaunchMetrics(opsSystem.Provider),
-		Registry:          chaincodeHandlerRegistry,
-		Runtime:           containerRuntime,
-		StartupTimeout:    chaincodeConfig.StartupTimeout,
-		CertGenerator:     authenticator,
-		CACert:            ca.CertBytes(),
-		PeerAddress:       ccEndpoint,
-		ConnectionHandler: &extcc.ExternalChaincodeRuntime{},
+		Metrics:        chaincode.NewLaunchMetrics(opsSystem.Provider),
+		Registry:       chaincodeHandlerRegistry,
+		Runtime:        containerRuntime,
+		StartupTimeout: chaincodeConfig.StartupTimeout,
+		CertGenerator:  authenticator,
+		CACert:         ca.CertBytes(),
+		PeerAddress:    ccEndpoint,
+		ConnectionHandler: &extcc.ExternalChaincodeRuntime{
+			Metrics: extcc.NewMetrics(opsSystem.Provider),
+		},
 	}
 
 	// Keep TestQueries working
@@ -652,27 +786,47 @@ func serve(args []string) error {
 		chaincodeLauncher.CertGenerator = nil
 	}
 
+	callTracer := chaincode.NewCallTracer(0)
+
+	var idleReaper *chaincode.IdleReaper
+	if chaincodeConfig.OnDemandEnabled {
+		idleReaper = chaincode.NewIdleReaper(chaincodeLauncher, chaincodeConfig.OnDemandIdleTimeout)
+	}
+
+	var validatingEventSchemas *eventschema.Registry
+	if chaincodeConfig.EventSchemaValidation {
+		validatingEventSchemas = eventSchemaRegistry
+	}
+
 	chaincodeSupport := &chaincode.ChaincodeSupport{
-		ACLProvider:            aclProvider,
-		AppConfig:              peerInstance,
-		DeployedCCInfoProvider: lifecycleValidatorCommitter,
-		ExecuteTimeout:         chaincodeConfig.ExecuteTimeout,
-		InstallTimeout:         chaincodeConfig.InstallTimeout,
-		HandlerRegistry:        chaincodeHandlerRegistry,
-		HandlerMetrics:         chaincode.NewHandlerMetrics(opsSystem.Provider),
-		Keepalive:              chaincodeConfig.Keepalive,
-		Launcher:               chaincodeLauncher,
-		Lifecycle:              chaincodeEndorsementInfo,
-		Peer:                   peerInstance,
-		Runtime:                containerRuntime,
-		BuiltinSCCs:            builtinSCCs,
-		TotalQueryLimit:        chaincodeConfig.TotalQueryLimit,
-		UserRunsCC:             userRunsCC,
+		ACLProvider:             aclProvider,
+		AppConfig:               peerInstance,
+		CallTracer:              callTracer,
+		DeployedCCInfoProvider:  lifecycleValidatorCommitter,
+		EventSchemas:            validatingEventSchemas,
+		ExecuteTimeout:          chaincodeConfig.ExecuteTimeout,
+		ExecuteTimeoutOverrides: chaincodeConfig.ExecuteTimeoutOverrides,
+		ExecutionLimiter:        chaincode.NewExecutionLimiter(chaincodeConfig),
+		InstallTimeout:          chaincodeConfig.InstallTimeout,
+		HandlerRegistry:         chaincodeHandlerRegistry,
+		HandlerMetrics:          chaincode.NewHandlerMetrics(opsSystem.Provider),
+		IdleReaper:              idleReaper,
+		Keepalive:               chaincodeConfig.Keepalive,
+		Launcher:                chaincodeLauncher,
+		Lifecycle:               chaincodeEndorsementInfo,
+		Peer:                    peerInstance,
+		Runtime:                 containerRuntime,
+		BuiltinSCCs:             builtinSCCs,
+		TotalQueryLimit:         chaincodeConfig.TotalQueryLimit,
+		QueryBatchLimit:         chaincodeConfig.QueryBatchLimit,
+		QueryBatchByteLimit:     chaincodeConfig.QueryBatchByteLimit,
+		UserRunsCC:              userRunsCC,
 	}
 
 	custodianLauncher := custodianLauncherAdapter{
-		launcher:      chaincodeLauncher,
-		streamHandler: chaincodeSupport,
+		launcher:       chaincodeLauncher,
+		streamHandler:  chaincodeSupport,
+		launchDisabled: chaincodeConfig.OnDemandEnabled,
 	}
 	go chaincodeCustodian.Work(buildRegistry, containerRouter, custodianLauncher)
 
@@ -690,7 +844,7 @@ func serve(args []string) error {
 		peerInstance,
 		factory.GetDefault(),
 	)
-	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
+	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance, callTracer))
 
 	pb.RegisterChaincodeSupportServer(ccSrv.Server(), ccSupSrv)
 
@@ -707,6 +861,7 @@ func serve(args []string) error {
 	reg := library.InitRegistry(libConf)
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
+	authFilters = append(authFilters, authFilter.NewDenyListFilter(identityDenyList))
 	endorserSupport := &endorser.SupportImpl{
 		SignerSerializer: signingIdentity,
 		Peer:             peerInstance,
@@ -1230,6 +1385,139 @@ func newOperationsSystem(coreConfig *peer.Config) *operations.System {
 	})
 }
 
+// startRenewalAgent launches the agent that re-enrolls the peer's
+// certificates against the configured Fabric CA before they expire. The
+// renewed enrollment certificate is persisted into the local MSP, which is
+// then reloaded, and a renewed TLS certificate is swapped into the running
+// gRPC server. Config update drafts for channels that embed the renewed
+// certificate are written under the renewal-drafts directory.
+func startRenewalAgent(
+	coreConfig *peer.Config,
+	serverConfig comm.ServerConfig,
+	signingIdentityBytes []byte,
+	peerServer *comm.GRPCServer,
+	peerInstance *peer.Peer,
+) (*renewal.Agent, error) {
+	var caTLSRootCert []byte
+	if coreConfig.RenewalCATLSRootCertFile != "" {
+		var err error
+		caTLSRootCert, err = ioutil.ReadFile(coreConfig.RenewalCATLSRootCertFile)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed reading the renewal CA TLS root certificate")
+		}
+	}
+
+	caClient, err := renewal.NewCAClient(
+		coreConfig.RenewalCAURL,
+		coreConfig.RenewalCAName,
+		coreConfig.RenewalEnrollmentID,
+		coreConfig.RenewalEnrollmentSecret,
+		caTLSRootCert,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollmentCert, err := renewal.IdentityCert(signingIdentityBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	identitySigner, err := renewal.SignerForCert(factory.GetDefault(), enrollmentCert)
+	if err != nil {
+		return nil, errors.WithMessage(err, "the key of the local signing identity is not available for renewal")
+	}
+
+	mspConfigPath := coreconfig.GetPath("peer.mspConfigPath")
+	localMSPType := viper.GetString("peer.localMspType")
+
+	renewalCerts := []*renewal.Certificate{
+		{
+			Role:    "enrollment",
+			CertPEM: enrollmentCert,
+			Signer:  identitySigner,
+			Swap: func(oldCertPEM, newCertPEM []byte) error {
+				if err := renewal.ReplaceCertFile(filepath.Join(mspConfigPath, "signcerts"), oldCertPEM, newCertPEM); err != nil {
+					return err
+				}
+				// reload the local MSP so the renewed certificate takes effect
+				return peercommon.InitCrypto(mspConfigPath, coreConfig.LocalMSPID, localMSPType)
+			},
+		},
+	}
+
+	if serverConfig.SecOpts.UseTLS {
+		tlsSigner, err := renewal.TLSSigner(serverConfig.SecOpts.Key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "the TLS key is not available for renewal")
+		}
+		renewalCerts = append(renewalCerts, &renewal.Certificate{
+			Role:    "server TLS",
+			CertPEM: serverConfig.SecOpts.Certificate,
+			Signer:  tlsSigner,
+			Swap: func(oldCertPEM, newCertPEM []byte) error {
+				tlsCert, err := tls.X509KeyPair(newCertPEM, serverConfig.SecOpts.Key)
+				if err != nil {
+					return err
+				}
+				peerServer.SetServerCertificate(tlsCert)
+				return nil
+			},
+		})
+	}
+
+	draftsDir := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "renewal-drafts")
+	configUpdateHook := func(role string, oldCertPEM, newCertPEM []byte) {
+		for _, cInfo := range peerInstance.GetChannelsInfo() {
+			resources := peerInstance.GetChannelConfig(cInfo.ChannelId)
+			if resources == nil {
+				continue
+			}
+			configUpdate, err := renewal.DraftMSPCertUpdate(
+				cInfo.ChannelId,
+				resources.ConfigtxValidator().ConfigProto(),
+				coreConfig.LocalMSPID,
+				oldCertPEM,
+				newCertPEM,
+			)
+			if err != nil {
+				logger.Debugf("Not drafting a config update for channel %s: %s", cInfo.ChannelId, err)
+				continue
+			}
+			name := fmt.Sprintf("%s-%s-config-update.pb", cInfo.ChannelId, strings.ReplaceAll(role, " ", "-"))
+			path, err := renewal.WriteConfigUpdateDraft(draftsDir, name, protoutil.MarshalOrPanic(configUpdate))
+			if err != nil {
+				logger.Warningf("Failed writing the config update draft for channel %s: %s", cInfo.ChannelId, err)
+				continue
+			}
+			logger.Infof("Wrote a config update draft replacing the renewed %s certificate of channel %s to %s; it still requires admin signatures and submission", role, cInfo.ChannelId, path)
+		}
+	}
+
+	agent, err := renewal.NewAgent(caClient, renewalCerts, coreConfig.RenewalWindow, coreConfig.RenewalCheckInterval, configUpdateHook)
+	if err != nil {
+		return nil, err
+	}
+
+	go agent.Run()
+	return agent, nil
+}
+
+func containerResourceLimits(limits chaincode.ResourceLimits) dockercontroller.ContainerResourceLimits {
+	return dockercontroller.ContainerResourceLimits{
+		CPUShares: limits.CPUShares,
+		Memory:    limits.Memory,
+	}
+}
+
+func containerResourceLimitsOverrides(overrides map[string]chaincode.ResourceLimits) map[string]dockercontroller.ContainerResourceLimits {
+	containerLimits := map[string]dockercontroller.ContainerResourceLimits{}
+	for name, limits := range overrides {
+		containerLimits[name] = containerResourceLimits(limits)
+	}
+	return containerLimits
+}
+
 func getDockerHostConfig() *docker.HostConfig {
 	dockerKey := func(key string) string { return "vm.docker.hostConfig." + key }
 	getInt64 := func(key string) int64 { return int64(viper.GetInt(dockerKey(key))) }
@@ -1340,7 +1628,7 @@ func resetLoop(
 	}
 }
 
-//implements the auth.Filter interface
+// implements the auth.Filter interface
 type reset struct {
 	sync.RWMutex
 	next   pb.EndorserServer
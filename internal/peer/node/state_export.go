@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	namespace    string
+	startKey     string
+	endKey       string
+	snapshotFile string
+)
+
+func exportStateCmd() *cobra.Command {
+	nodeExportStateCmd.ResetFlags()
+	flags := nodeExportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to export from.")
+	flags.StringVarP(&namespace, "namespace", "n", common.UndefinedParamValue, "Namespace (chaincode name) whose keys are exported.")
+	flags.StringVar(&startKey, "startKey", "", "First key of the range to export (inclusive). Defaults to the start of the namespace.")
+	flags.StringVar(&endKey, "endKey", "", "End key of the range to export (exclusive). Defaults to the end of the namespace.")
+	flags.StringVarP(&snapshotFile, "file", "f", common.UndefinedParamValue, "File to write the snapshot to.")
+
+	return nodeExportStateCmd
+}
+
+var nodeExportStateCmd = &cobra.Command{
+	Use:   "export-state",
+	Short: "Exports a range of a channel's state.",
+	Long:  `Exports a consistent snapshot of the keys of a namespace within a key range from a channel's state database to a portable file, which can be imported into another channel with the import-state command. When the command is executed, the peer must be offline. The command is supported only with the goleveldb state database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		if namespace == common.UndefinedParamValue {
+			return errors.New("Must supply namespace")
+		}
+		if snapshotFile == common.UndefinedParamValue {
+			return errors.New("Must supply snapshot file")
+		}
+
+		config := ledgerConfig()
+		info, err := kvledger.ExportStateRange(config, channelID, namespace, startKey, endKey, snapshotFile)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported %d keys of namespace %s from channel %s at block height %d\n",
+			info.NumKeys, info.Namespace, info.LedgerID, info.BlockHeight)
+		return nil
+	},
+}
+
+func importStateCmd() *cobra.Command {
+	nodeImportStateCmd.ResetFlags()
+	flags := nodeImportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to import into.")
+	flags.StringVarP(&snapshotFile, "file", "f", common.UndefinedParamValue, "File to read the snapshot from.")
+
+	return nodeImportStateCmd
+}
+
+var nodeImportStateCmd = &cobra.Command{
+	Use:   "import-state",
+	Short: "Imports an exported state range into a channel.",
+	Long:  `Imports the keys of a snapshot file, produced by the export-state command, into the state database of a channel. The channel must already exist on the peer. The imported keys carry no history - the history database and the block store of the channel are not updated. When the command is executed, the peer must be offline. The command is supported only with the goleveldb state database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		if snapshotFile == common.UndefinedParamValue {
+			return errors.New("Must supply snapshot file")
+		}
+
+		config := ledgerConfig()
+		info, err := kvledger.ImportStateRange(config, channelID, snapshotFile)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d keys of namespace %s into channel %s\n",
+			info.NumKeys, info.Namespace, info.LedgerID)
+		return nil
+	},
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ledgerVerificationReport is the report printed by the verify-ledger command.
+// When the peer's MSP configuration is available, the report is signed by the
+// peer's signing identity so that it can be handed over as an attestation
+type ledgerVerificationReport struct {
+	ChannelID       string `json:"channelID"`
+	NumBlocks       uint64 `json:"numBlocks"`
+	LastBlockNumber uint64 `json:"lastBlockNumber"`
+	LastBlockHash   string `json:"lastBlockHash"`
+	Signer          string `json:"signer,omitempty"`
+	Signature       string `json:"signature,omitempty"`
+}
+
+func verifyLedgerCmd() *cobra.Command {
+	nodeVerifyLedgerCmd.ResetFlags()
+	flags := nodeVerifyLedgerCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to verify.")
+
+	return nodeVerifyLedgerCmd
+}
+
+var nodeVerifyLedgerCmd = &cobra.Command{
+	Use:   "verify-ledger",
+	Short: "Verifies the integrity of a channel's ledger.",
+	Long:  `Verifies the integrity of a channel's ledger by recomputing the hash of every block, validating the hash chain across the block headers, and cross-checking the block index against the block files. When the command is executed, the peer must be offline. On success, a report is printed which, when the peer's MSP configuration is available, is signed by the peer's signing identity.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		config := ledgerConfig()
+		result, err := kvledger.VerifyKVLedger(config.RootFSPath, channelID)
+		if err != nil {
+			return err
+		}
+
+		report := &ledgerVerificationReport{
+			ChannelID:       channelID,
+			NumBlocks:       result.NumBlocks,
+			LastBlockNumber: result.LastBlockNumber,
+			LastBlockHash:   hex.EncodeToString(result.LastBlockHash),
+		}
+		if err := signVerificationReport(report); err != nil {
+			logger.Warningf("The verification report could not be signed: %s", err)
+		}
+
+		reportBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportBytes))
+		return nil
+	},
+}
+
+// signVerificationReport signs the report content with the peer's signing
+// identity and records the signature and the serialized identity in the report
+func signVerificationReport(report *ledgerVerificationReport) error {
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return err
+	}
+	content, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	signature, err := signer.Sign(content)
+	if err != nil {
+		return err
+	}
+	serializedSigner, err := signer.Serialize()
+	if err != nil {
+		return err
+	}
+	report.Signer = base64.StdEncoding.EncodeToString(serializedSigner)
+	report.Signature = base64.StdEncoding.EncodeToString(signature)
+	return nil
+}
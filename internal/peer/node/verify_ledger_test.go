@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyLedgerCmd(t *testing.T) {
+	t.Run("when the channelID is not supplied", func(t *testing.T) {
+		cmd := verifyLedgerCmd()
+		args := []string{}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		assert.Equal(t, "Must supply channel ID", err.Error())
+	})
+
+	t.Run("when the specified channelID does not exist", func(t *testing.T) {
+		cmd := verifyLedgerCmd()
+		args := []string{"-c", "ch1"}
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		expectedErr := "ledgerID [ch1] does not exist"
+		assert.Equal(t, expectedErr, err.Error())
+	})
+}
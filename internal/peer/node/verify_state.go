@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// stateVerificationReport is the report printed by the verify-state command
+type stateVerificationReport struct {
+	ChannelID        string                   `json:"channelID"`
+	BlockHeight      uint64                   `json:"blockHeight"`
+	NumKeysChecked   uint64                   `json:"numKeysChecked"`
+	LiveStateHash    string                   `json:"liveStateHash"`
+	RebuiltStateHash string                   `json:"rebuiltStateHash"`
+	InSync           bool                     `json:"inSync"`
+	NumDivergentKeys uint64                   `json:"numDivergentKeys"`
+	DivergentKeys    []*kvledger.DivergentKey `json:"divergentKeys,omitempty"`
+}
+
+func verifyStateCmd() *cobra.Command {
+	nodeVerifyStateCmd.ResetFlags()
+	flags := nodeVerifyStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to verify.")
+
+	return nodeVerifyStateCmd
+}
+
+var nodeVerifyStateCmd = &cobra.Command{
+	Use:   "verify-state",
+	Short: "Verifies a channel's state database against its blocks.",
+	Long:  `Verifies the integrity of a channel's state database by rebuilding the public state from the committed blocks in a throwaway database and comparing the outcome against the live state database. Divergent keys indicate corruption of the state database or nondeterminism in a past commit. When the command is executed, the peer must be offline. The command is supported only with the goleveldb state database.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+
+		config := ledgerConfig()
+		result, err := kvledger.VerifyState(config, channelID)
+		if err != nil {
+			return err
+		}
+
+		report := &stateVerificationReport{
+			ChannelID:        result.LedgerID,
+			BlockHeight:      result.BlockHeight,
+			NumKeysChecked:   result.NumKeysChecked,
+			LiveStateHash:    hex.EncodeToString(result.LiveStateHash),
+			RebuiltStateHash: hex.EncodeToString(result.RebuiltStateHash),
+			InSync:           result.InSync,
+			NumDivergentKeys: result.NumDivergentKeys,
+			DivergentKeys:    result.DivergentKeys,
+		}
+		reportBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(reportBytes))
+
+		if !result.InSync {
+			return errors.Errorf("the state database of channel %s diverges from the state rebuilt from its blocks", channelID)
+		}
+		return nil
+	},
+}
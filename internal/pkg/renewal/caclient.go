@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renewal
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// CAClient is an Enroller backed by the enrollment endpoint of a Fabric
+// CA. Enrollment requests authenticate with the configured enrollment ID
+// and secret.
+type CAClient struct {
+	url          string
+	caName       string
+	enrollmentID string
+	secret       string
+	client       *http.Client
+}
+
+// NewCAClient creates a CAClient for the Fabric CA reachable at caURL. If
+// tlsRootCertPEM is not empty it is used to authenticate the CA's TLS
+// certificate.
+func NewCAClient(caURL, caName, enrollmentID, secret string, tlsRootCertPEM []byte) (*CAClient, error) {
+	if caURL == "" {
+		return nil, errors.New("Invalid CA URL. It must be set")
+	}
+	if enrollmentID == "" {
+		return nil, errors.New("Invalid enrollment ID. It must be set")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if len(tlsRootCertPEM) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(tlsRootCertPEM) {
+			return nil, errors.New("failed adding the CA TLS root certificate to the cert pool")
+		}
+		client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: certPool},
+		}
+	}
+
+	return &CAClient{
+		url:          caURL,
+		caName:       caName,
+		enrollmentID: enrollmentID,
+		secret:       secret,
+		client:       client,
+	}, nil
+}
+
+type enrollmentRequest struct {
+	CertificateRequest string `json:"certificate_request"`
+	CAName             string `json:"caname,omitempty"`
+}
+
+type enrollmentResponse struct {
+	Success bool `json:"success"`
+	Result  struct {
+		Cert string `json:"Cert"`
+	} `json:"result"`
+	Errors []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Reenroll submits the given certificate signing request to the CA's
+// enrollment endpoint and returns the PEM encoded renewed certificate.
+func (c *CAClient) Reenroll(csrPEM []byte) ([]byte, error) {
+	reqBody, err := json.Marshal(&enrollmentRequest{
+		CertificateRequest: string(csrPEM),
+		CAName:             c.caName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.url+"/api/v1/enroll", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.enrollmentID, c.secret)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "enrollment request failed")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed reading enrollment response")
+	}
+
+	enrollmentResp := &enrollmentResponse{}
+	if err := json.Unmarshal(respBody, enrollmentResp); err != nil {
+		return nil, errors.Errorf("CA returned a malformed response with status %d", resp.StatusCode)
+	}
+
+	if !enrollmentResp.Success {
+		if len(enrollmentResp.Errors) > 0 {
+			return nil, errors.Errorf("CA refused enrollment: %s", enrollmentResp.Errors[0].Message)
+		}
+		return nil, errors.Errorf("CA refused enrollment with status %d", resp.StatusCode)
+	}
+
+	certPEM, err := base64.StdEncoding.DecodeString(enrollmentResp.Result.Cert)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed decoding the certificate returned by the CA")
+	}
+
+	return certPEM, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renewal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCAClientReenroll(t *testing.T) {
+	certPEM := []byte("-----BEGIN CERTIFICATE-----\nrenewed\n-----END CERTIFICATE-----\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/enroll", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		user, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "peer0", user)
+		assert.Equal(t, "peer0pw", secret)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request := &enrollmentRequest{}
+		assert.NoError(t, json.Unmarshal(body, request))
+		assert.Equal(t, "my csr", request.CertificateRequest)
+		assert.Equal(t, "ca-org1", request.CAName)
+
+		response := &enrollmentResponse{Success: true}
+		response.Result.Cert = base64.StdEncoding.EncodeToString(certPEM)
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client, err := NewCAClient(server.URL, "ca-org1", "peer0", "peer0pw", nil)
+	assert.NoError(t, err)
+
+	cert, err := client.Reenroll([]byte("my csr"))
+	assert.NoError(t, err)
+	assert.Equal(t, certPEM, cert)
+}
+
+func TestCAClientReenrollRefused(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"success":false,"errors":[{"code":20,"message":"authentication failure"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCAClient(server.URL, "", "peer0", "wrong", nil)
+	assert.NoError(t, err)
+
+	_, err = client.Reenroll([]byte("my csr"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CA refused enrollment: authentication failure")
+}
+
+func TestCAClientMalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("upstream gone"))
+	}))
+	defer server.Close()
+
+	client, err := NewCAClient(server.URL, "", "peer0", "peer0pw", nil)
+	assert.NoError(t, err)
+
+	_, err = client.Reenroll([]byte("my csr"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "CA returned a malformed response with status 502")
+}
+
+func TestNewCAClient(t *testing.T) {
+	_, err := NewCAClient("", "", "peer0", "pw", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid CA URL. It must be set")
+
+	_, err = NewCAClient("https://ca:7054", "", "", "pw", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid enrollment ID. It must be set")
+
+	_, err = NewCAClient("https://ca:7054", "", "peer0", "pw", []byte("not a cert"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed adding the CA TLS root certificate")
+}
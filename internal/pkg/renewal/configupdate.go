@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renewal
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+// DraftMSPCertUpdate computes a config update draft against the given
+// channel configuration that replaces oldCertPEM with newCertPEM wherever
+// it appears in the MSP definition of mspID. The draft still has to be
+// signed by the organization's admins and submitted for ordering.
+func DraftMSPCertUpdate(channelID string, config *cb.Config, mspID string, oldCertPEM, newCertPEM []byte) (*cb.ConfigUpdate, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.New("the channel configuration is empty")
+	}
+
+	updated := proto.Clone(config).(*cb.Config)
+	replaced, err := replaceCertInGroup(updated.ChannelGroup, mspID, oldCertPEM, newCertPEM)
+	if err != nil {
+		return nil, err
+	}
+	if !replaced {
+		return nil, errors.Errorf("the certificate does not appear in the MSP definition of %s", mspID)
+	}
+
+	configUpdate, err := update.Compute(config, updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing the config update")
+	}
+	configUpdate.ChannelId = channelID
+
+	return configUpdate, nil
+}
+
+func replaceCertInGroup(group *cb.ConfigGroup, mspID string, oldCertPEM, newCertPEM []byte) (bool, error) {
+	replaced := false
+
+	if mspValue, ok := group.Values["MSP"]; ok {
+		valueReplaced, err := replaceCertInMSPValue(mspValue, mspID, oldCertPEM, newCertPEM)
+		if err != nil {
+			return false, err
+		}
+		replaced = replaced || valueReplaced
+	}
+
+	for _, subGroup := range group.Groups {
+		groupReplaced, err := replaceCertInGroup(subGroup, mspID, oldCertPEM, newCertPEM)
+		if err != nil {
+			return false, err
+		}
+		replaced = replaced || groupReplaced
+	}
+
+	return replaced, nil
+}
+
+func replaceCertInMSPValue(mspValue *cb.ConfigValue, mspID string, oldCertPEM, newCertPEM []byte) (bool, error) {
+	mspConfig := &mspprotos.MSPConfig{}
+	if err := proto.Unmarshal(mspValue.Value, mspConfig); err != nil {
+		return false, err
+	}
+
+	if mspConfig.Type != int32(msp.FABRIC) {
+		return false, nil
+	}
+
+	fabricConfig := &mspprotos.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return false, err
+	}
+
+	if fabricConfig.Name != mspID {
+		return false, nil
+	}
+
+	replaced := false
+	replaceCert := func(rawCerts [][]byte) {
+		for i, rawCert := range rawCerts {
+			if bytes.Equal(bytes.TrimSpace(rawCert), bytes.TrimSpace(oldCertPEM)) {
+				rawCerts[i] = newCertPEM
+				replaced = true
+			}
+		}
+	}
+
+	replaceCert(fabricConfig.Admins)
+	replaceCert(fabricConfig.RootCerts)
+	replaceCert(fabricConfig.IntermediateCerts)
+	replaceCert(fabricConfig.TlsRootCerts)
+	replaceCert(fabricConfig.TlsIntermediateCerts)
+
+	if !replaced {
+		return false, nil
+	}
+
+	serializedFabricConfig, err := proto.Marshal(fabricConfig)
+	if err != nil {
+		return false, err
+	}
+	mspConfig.Config = serializedFabricConfig
+
+	serializedMSPConfig, err := proto.Marshal(mspConfig)
+	if err != nil {
+		return false, err
+	}
+	mspValue.Value = serializedMSPConfig
+
+	return true, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renewal
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func testChannelConfig(t *testing.T, adminCert []byte) *cb.Config {
+	fabricConfig := protoutil.MarshalOrPanic(&mspprotos.FabricMSPConfig{
+		Name:      "Org1MSP",
+		Admins:    [][]byte{adminCert},
+		RootCerts: [][]byte{[]byte("root CA cert")},
+	})
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Application": {
+					Groups: map[string]*cb.ConfigGroup{
+						"Org1MSP": {
+							Values: map[string]*cb.ConfigValue{
+								"MSP": {
+									Version: 1,
+									Value: protoutil.MarshalOrPanic(&mspprotos.MSPConfig{
+										Config: fabricConfig,
+									}),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDraftMSPCertUpdate(t *testing.T) {
+	oldCert := []byte("-----BEGIN CERTIFICATE-----\nold\n-----END CERTIFICATE-----\n")
+	newCert := []byte("-----BEGIN CERTIFICATE-----\nnew\n-----END CERTIFICATE-----\n")
+
+	config := testChannelConfig(t, oldCert)
+
+	configUpdate, err := DraftMSPCertUpdate("mychannel", config, "Org1MSP", oldCert, newCert)
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+
+	// The write set carries the updated MSP definition with a bumped version
+	org := configUpdate.WriteSet.Groups["Application"].Groups["Org1MSP"]
+	mspValue := org.Values["MSP"]
+	assert.Equal(t, uint64(2), mspValue.Version)
+
+	mspConfig := &mspprotos.MSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspValue.Value, mspConfig))
+	fabricConfig := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(mspConfig.Config, fabricConfig))
+	assert.Equal(t, [][]byte{newCert}, fabricConfig.Admins)
+	assert.Equal(t, [][]byte{[]byte("root CA cert")}, fabricConfig.RootCerts)
+
+	// The original configuration is left untouched
+	_, err = DraftMSPCertUpdate("mychannel", config, "Org1MSP", oldCert, newCert)
+	assert.NoError(t, err)
+}
+
+func TestDraftMSPCertUpdateCertNotFound(t *testing.T) {
+	config := testChannelConfig(t, []byte("some other cert"))
+
+	_, err := DraftMSPCertUpdate("mychannel", config, "Org1MSP", []byte("missing"), []byte("new"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the certificate does not appear in the MSP definition of Org1MSP")
+
+	_, err = DraftMSPCertUpdate("mychannel", nil, "Org1MSP", []byte("missing"), []byte("new"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the channel configuration is empty")
+}
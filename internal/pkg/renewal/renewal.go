@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package renewal implements an agent that re-enrolls the certificates of a
+// peer or orderer against a Fabric CA before they expire and hot-swaps the
+// renewed certificates into the running node.
+package renewal
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	mspprotos "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("renewal")
+
+// Enroller obtains a renewed certificate for the given PEM encoded
+// certificate signing request. CAClient is the stock implementation backed
+// by the Fabric CA enrollment API.
+type Enroller interface {
+	Reenroll(csrPEM []byte) ([]byte, error)
+}
+
+// SwapFunc hot-swaps a renewed certificate into the component serving it,
+// for example the local MSP or a TLS listener.
+type SwapFunc func(oldCertPEM, newCertPEM []byte) error
+
+// ConfigUpdateHook is invoked after a certificate that may also be
+// embedded in channel configurations has been renewed, so that config
+// update drafts replacing the old certificate can be emitted.
+type ConfigUpdateHook func(role string, oldCertPEM, newCertPEM []byte)
+
+// Certificate describes a single certificate managed by the Agent.
+type Certificate struct {
+	// Role names the certificate in logs, e.g. "enrollment" or "server TLS".
+	Role string
+	// CertPEM is the PEM encoding of the current certificate.
+	CertPEM []byte
+	// Signer signs the certificate signing request; re-enrollment reuses
+	// the existing private key.
+	Signer crypto.Signer
+	// Swap is invoked with the old and the renewed certificate once
+	// re-enrollment succeeded.
+	Swap SwapFunc
+}
+
+// Agent periodically inspects the managed certificates and re-enrolls the
+// ones that are about to expire.
+type Agent struct {
+	enroller         Enroller
+	certs            []*Certificate
+	renewalWindow    time.Duration
+	checkInterval    time.Duration
+	configUpdateHook ConfigUpdateHook
+	now              func() time.Time
+
+	stopOnce sync.Once
+	stopChan chan struct{}
+}
+
+// NewAgent creates an Agent that renews the given certificates through the
+// enroller once their remaining lifetime drops below renewalWindow,
+// checking every checkInterval. A renewalWindow of zero defaults to 30
+// days and a checkInterval of zero defaults to one hour.
+func NewAgent(enroller Enroller, certs []*Certificate, renewalWindow, checkInterval time.Duration, configUpdateHook ConfigUpdateHook) (*Agent, error) {
+	if enroller == nil {
+		return nil, errors.New("Invalid enroller. It must be different from nil")
+	}
+
+	if renewalWindow == 0 {
+		renewalWindow = 30 * 24 * time.Hour
+	}
+	if checkInterval == 0 {
+		checkInterval = time.Hour
+	}
+
+	return &Agent{
+		enroller:         enroller,
+		certs:            certs,
+		renewalWindow:    renewalWindow,
+		checkInterval:    checkInterval,
+		configUpdateHook: configUpdateHook,
+		now:              time.Now,
+		stopChan:         make(chan struct{}),
+	}, nil
+}
+
+// Run checks the managed certificates until Stop is invoked, and is meant
+// to be run on a dedicated goroutine.
+func (a *Agent) Run() {
+	a.RenewDueCertificates()
+
+	ticker := time.NewTicker(a.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.RenewDueCertificates()
+		case <-a.stopChan:
+			return
+		}
+	}
+}
+
+// Stop halts the agent.
+func (a *Agent) Stop() {
+	a.stopOnce.Do(func() {
+		close(a.stopChan)
+	})
+}
+
+// RenewDueCertificates re-enrolls every managed certificate whose
+// remaining lifetime is below the renewal window.
+func (a *Agent) RenewDueCertificates() {
+	now := a.now()
+	for _, cert := range a.certs {
+		current, err := parseCertPEM(cert.CertPEM)
+		if err != nil {
+			logger.Warningf("Cannot inspect the %s certificate for renewal: %s", cert.Role, err)
+			continue
+		}
+
+		if current.NotAfter.Sub(now) > a.renewalWindow {
+			continue
+		}
+
+		if err := a.renew(cert, current); err != nil {
+			logger.Warningf("Failed renewing the %s certificate: %s", cert.Role, err)
+		}
+	}
+}
+
+func (a *Agent) renew(cert *Certificate, current *x509.Certificate) error {
+	csrPEM, err := createCSR(current, cert.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating certificate signing request")
+	}
+
+	newCertPEM, err := a.enroller.Reenroll(csrPEM)
+	if err != nil {
+		return errors.WithMessage(err, "re-enrollment failed")
+	}
+
+	renewed, err := parseCertPEM(newCertPEM)
+	if err != nil {
+		return errors.WithMessage(err, "CA returned an unparseable certificate")
+	}
+
+	// Re-enrollment reuses the existing key, so the renewed certificate
+	// must certify the very same public key
+	oldPK, err := x509.MarshalPKIXPublicKey(cert.Signer.Public())
+	if err != nil {
+		return err
+	}
+	newPK, err := x509.MarshalPKIXPublicKey(renewed.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(oldPK, newPK) {
+		return errors.New("CA returned a certificate for a different public key")
+	}
+
+	oldCertPEM := cert.CertPEM
+	if cert.Swap != nil {
+		if err := cert.Swap(oldCertPEM, newCertPEM); err != nil {
+			return errors.WithMessage(err, "failed swapping in the renewed certificate")
+		}
+	}
+	cert.CertPEM = newCertPEM
+
+	logger.Infof("Renewed the %s certificate; the new certificate expires at %s", cert.Role, renewed.NotAfter)
+
+	if a.configUpdateHook != nil {
+		a.configUpdateHook(cert.Role, oldCertPEM, newCertPEM)
+	}
+
+	return nil
+}
+
+func createCSR(current *x509.Certificate, certSigner crypto.Signer) ([]byte, error) {
+	if certSigner == nil {
+		return nil, errors.New("no signer associated with the certificate")
+	}
+
+	template := &x509.CertificateRequest{
+		Subject:        current.Subject,
+		DNSNames:       current.DNSNames,
+		IPAddresses:    current.IPAddresses,
+		EmailAddresses: current.EmailAddresses,
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, template, certSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}), nil
+}
+
+func parseCertPEM(certPEM []byte) (*x509.Certificate, error) {
+	bl, _ := pem.Decode(certPEM)
+	if bl == nil {
+		return nil, errors.New("the certificate is not PEM encoded")
+	}
+	return x509.ParseCertificate(bl.Bytes)
+}
+
+// IdentityCert extracts the PEM encoded certificate from the given
+// serialized identity.
+func IdentityCert(sIDBytes []byte) ([]byte, error) {
+	sID := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(sIDBytes, sID); err != nil {
+		return nil, errors.WithMessage(err, "failed unmarshalling the serialized identity")
+	}
+	return sID.IdBytes, nil
+}
+
+// TLSSigner returns a crypto.Signer backed by the given PEM encoded TLS
+// private key.
+func TLSSigner(keyPEM []byte) (crypto.Signer, error) {
+	key, err := utils.PEMtoPrivateKey(keyPEM, nil)
+	if err != nil {
+		return nil, err
+	}
+	keySigner, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("the TLS private key cannot sign")
+	}
+	return keySigner, nil
+}
+
+// SignerForCert returns a crypto.Signer backed by the private key the
+// given BCCSP associates with the public key of the PEM encoded
+// certificate.
+func SignerForCert(csp bccsp.BCCSP, certPEM []byte) (crypto.Signer, error) {
+	cert, err := parseCertPEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed importing the certificate's public key")
+	}
+
+	privKey, err := csp.GetKey(pubKey.SKI())
+	if err != nil {
+		return nil, errors.WithMessage(err, "the private key of the certificate is not available")
+	}
+
+	return signer.New(csp, privKey)
+}
+
+// ReplaceCertFile overwrites the file in dir whose content equals
+// oldCertPEM with newCertPEM, preserving the file's permissions. It is
+// used to persist a renewed certificate into MSP material on disk.
+func ReplaceCertFile(dir string, oldCertPEM, newCertPEM []byte) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(bytes.TrimSpace(content), bytes.TrimSpace(oldCertPEM)) {
+			continue
+		}
+		return ioutil.WriteFile(path, newCertPEM, file.Mode().Perm())
+	}
+
+	return errors.Errorf("no file in %s holds the certificate being replaced", dir)
+}
+
+// WriteConfigUpdateDraft persists a marshaled config update draft under
+// dir, creating the directory if needed, and returns the path of the
+// written file.
+func WriteConfigUpdateDraft(dir, name string, draft []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, draft, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
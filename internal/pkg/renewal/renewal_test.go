@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renewal
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, pub interface{}, notAfter time.Time) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "peer0"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, pub, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// fakeEnroller issues renewed certificates from the test CA for whatever
+// key signed the certificate signing request.
+type fakeEnroller struct {
+	t        *testing.T
+	ca       *testCA
+	err      error
+	wrongKey bool
+	calls    int
+}
+
+func (e *fakeEnroller) Reenroll(csrPEM []byte) ([]byte, error) {
+	e.calls++
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	bl, _ := pem.Decode(csrPEM)
+	assert.NotNil(e.t, bl)
+	csr, err := x509.ParseCertificateRequest(bl.Bytes)
+	assert.NoError(e.t, err)
+	assert.NoError(e.t, csr.CheckSignature())
+
+	pub := csr.PublicKey
+	if e.wrongKey {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(e.t, err)
+		pub = &otherKey.PublicKey
+	}
+
+	return e.ca.issue(e.t, pub, time.Now().Add(365*24*time.Hour)), nil
+}
+
+func TestAgentRenewsDueCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	// The certificate expires in 10 days, within the 30 day renewal window
+	certPEM := ca.issue(t, &key.PublicKey, time.Now().Add(10*24*time.Hour))
+
+	var swappedOld, swappedNew []byte
+	var hookRole string
+	enroller := &fakeEnroller{t: t, ca: ca}
+
+	cert := &Certificate{
+		Role:    "enrollment",
+		CertPEM: certPEM,
+		Signer:  key,
+		Swap: func(oldCertPEM, newCertPEM []byte) error {
+			swappedOld = oldCertPEM
+			swappedNew = newCertPEM
+			return nil
+		},
+	}
+
+	agent, err := NewAgent(enroller, []*Certificate{cert}, 0, 0, func(role string, oldCertPEM, newCertPEM []byte) {
+		hookRole = role
+	})
+	assert.NoError(t, err)
+
+	agent.RenewDueCertificates()
+
+	assert.Equal(t, 1, enroller.calls)
+	assert.Equal(t, certPEM, swappedOld)
+	assert.Equal(t, swappedNew, cert.CertPEM)
+	assert.NotEqual(t, certPEM, cert.CertPEM)
+	assert.Equal(t, "enrollment", hookRole)
+
+	renewed, err := parseCertPEM(cert.CertPEM)
+	assert.NoError(t, err)
+	assert.True(t, renewed.NotAfter.After(time.Now().Add(300*24*time.Hour)))
+
+	// The renewed certificate is not due again
+	agent.RenewDueCertificates()
+	assert.Equal(t, 1, enroller.calls)
+}
+
+func TestAgentSkipsCertificateFarFromExpiry(t *testing.T) {
+	ca := newTestCA(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	certPEM := ca.issue(t, &key.PublicKey, time.Now().Add(365*24*time.Hour))
+	enroller := &fakeEnroller{t: t, ca: ca}
+
+	agent, err := NewAgent(enroller, []*Certificate{{Role: "enrollment", CertPEM: certPEM, Signer: key}}, 0, 0, nil)
+	assert.NoError(t, err)
+
+	agent.RenewDueCertificates()
+	assert.Equal(t, 0, enroller.calls)
+}
+
+func TestAgentRejectsCertificateForDifferentKey(t *testing.T) {
+	ca := newTestCA(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	certPEM := ca.issue(t, &key.PublicKey, time.Now().Add(24*time.Hour))
+	enroller := &fakeEnroller{t: t, ca: ca, wrongKey: true}
+
+	cert := &Certificate{Role: "enrollment", CertPEM: certPEM, Signer: key}
+	agent, err := NewAgent(enroller, []*Certificate{cert}, 0, 0, nil)
+	assert.NoError(t, err)
+
+	agent.RenewDueCertificates()
+	assert.Equal(t, 1, enroller.calls)
+	// The certificate is left untouched
+	assert.Equal(t, certPEM, cert.CertPEM)
+}
+
+func TestAgentToleratesEnrollerFailure(t *testing.T) {
+	ca := newTestCA(t)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	certPEM := ca.issue(t, &key.PublicKey, time.Now().Add(24*time.Hour))
+	enroller := &fakeEnroller{t: t, ca: ca, err: errors.New("CA is down")}
+
+	cert := &Certificate{Role: "enrollment", CertPEM: certPEM, Signer: key}
+	agent, err := NewAgent(enroller, []*Certificate{cert}, 0, 0, nil)
+	assert.NoError(t, err)
+
+	agent.RenewDueCertificates()
+	assert.Equal(t, certPEM, cert.CertPEM)
+
+	// The agent retries on the next check
+	agent.RenewDueCertificates()
+	assert.Equal(t, 2, enroller.calls)
+}
+
+func TestNewAgent(t *testing.T) {
+	_, err := NewAgent(nil, nil, 0, 0, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid enroller. It must be different from nil")
+}
+
+func TestReplaceCertFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "renewal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	oldCert := []byte("-----BEGIN CERTIFICATE-----\nold\n-----END CERTIFICATE-----\n")
+	newCert := []byte("-----BEGIN CERTIFICATE-----\nnew\n-----END CERTIFICATE-----\n")
+
+	path := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(path, oldCert, 0644))
+
+	assert.NoError(t, ReplaceCertFile(dir, oldCert, newCert))
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, newCert, content)
+
+	err = ReplaceCertFile(dir, oldCert, newCert)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no file in")
+}
+
+func TestWriteConfigUpdateDraft(t *testing.T) {
+	dir, err := ioutil.TempDir("", "renewal")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path, err := WriteConfigUpdateDraft(filepath.Join(dir, "drafts"), "mychannel-enrollment.pb", []byte{1, 2, 3})
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, content)
+}
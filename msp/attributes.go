@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// attributesOID is the ASN.1 object identifier of the non-critical
+// extension under which the Fabric CA records the enrollment attributes of
+// the certificates it issues.
+var attributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// the extension value is a JSON document of the form
+// {"attrs":{"name":"value", ...}}
+type certificateAttributesExtension struct {
+	Attrs map[string]string `json:"attrs"`
+}
+
+// certificateAttributes returns the enrollment attributes the given
+// certificate carries; a certificate without the attributes extension
+// yields a nil map.
+func certificateAttributes(cert *x509.Certificate) (map[string]string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(attributesOID) {
+			continue
+		}
+
+		attrs := &certificateAttributesExtension{}
+		if err := json.Unmarshal(ext.Value, attrs); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal the attributes extension of the certificate")
+		}
+		return attrs.Attrs, nil
+	}
+
+	return nil, nil
+}
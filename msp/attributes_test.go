@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	m "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/stretchr/testify/assert"
+)
+
+// attributeTestMSP returns an MSP of the given version together with the
+// serialized identity of a member whose certificate carries the given raw
+// attributes extension; an empty extension value yields a certificate
+// without the extension.
+func attributeTestMSP(t *testing.T, version MSPVersion, attrsExtension string) (MSP, []byte) {
+	now := time.Now()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "attr-test-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(24 * time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caRaw, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	assert.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caRaw)
+	assert.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "attr-holder"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if attrsExtension != "" {
+		leafTemplate.ExtraExtensions = []pkix.Extension{
+			{Id: attributesOID, Value: []byte(attrsExtension)},
+		}
+	}
+	leafRaw, err := x509.CreateCertificate(rand.Reader, leafTemplate, caCert, &leafKey.PublicKey, caKey)
+	assert.NoError(t, err)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caRaw})
+	leafPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafRaw})
+
+	mspConf, err := proto.Marshal(&m.FabricMSPConfig{
+		Name:      "AttrOrg",
+		RootCerts: [][]byte{caPEM},
+		Admins:    [][]byte{leafPEM},
+	})
+	assert.NoError(t, err)
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+	thisMSP, err := newBccspMsp(version, cryptoProvider)
+	assert.NoError(t, err)
+	err = thisMSP.Setup(&m.MSPConfig{Config: mspConf})
+	assert.NoError(t, err)
+
+	sID, err := proto.Marshal(&m.SerializedIdentity{Mspid: "AttrOrg", IdBytes: leafPEM})
+	assert.NoError(t, err)
+
+	return thisMSP, sID
+}
+
+func attributePrincipal(t *testing.T, mspID, name, value string) *m.MSPPrincipal {
+	raw, err := proto.Marshal(&m.MSPAttribute{
+		MspIdentifier:  mspID,
+		AttributeName:  name,
+		AttributeValue: value,
+	})
+	assert.NoError(t, err)
+
+	return &m.MSPPrincipal{
+		PrincipalClassification: m.MSPPrincipal_ATTRIBUTE,
+		Principal:               raw,
+	}
+}
+
+func TestAttributePrincipal(t *testing.T) {
+	thisMSP, sID := attributeTestMSP(t, MSPv3_0, `{"attrs":{"role":"auditor"}}`)
+	id, err := thisMSP.DeserializeIdentity(sID)
+	assert.NoError(t, err)
+
+	// matching name and value
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", "auditor"))
+	assert.NoError(t, err)
+
+	// presence-only principal
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", ""))
+	assert.NoError(t, err)
+
+	// restricted to the identity's MSP
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "AttrOrg", "role", "auditor"))
+	assert.NoError(t, err)
+
+	// restricted to a different MSP
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "OtherOrg", "role", "auditor"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the identity is a member of a different MSP")
+
+	// value mismatch
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", "approver"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not carry the required value")
+
+	// unknown attribute
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "clearance", ""))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "carries no attribute named [clearance]")
+
+	// the attribute name is mandatory
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "", ""))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the attribute name must be set")
+}
+
+func TestAttributePrincipalWithoutExtension(t *testing.T) {
+	thisMSP, sID := attributeTestMSP(t, MSPv3_0, "")
+	id, err := thisMSP.DeserializeIdentity(sID)
+	assert.NoError(t, err)
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", "auditor"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "carries no attribute named [role]")
+}
+
+func TestAttributePrincipalMalformedExtension(t *testing.T) {
+	thisMSP, sID := attributeTestMSP(t, MSPv3_0, "not a json document")
+	id, err := thisMSP.DeserializeIdentity(sID)
+	assert.NoError(t, err)
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", "auditor"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "could not unmarshal the attributes extension")
+}
+
+func TestAttributePrincipalPreV3(t *testing.T) {
+	thisMSP, sID := attributeTestMSP(t, MSPv1_4_3, `{"attrs":{"role":"auditor"}}`)
+	id, err := thisMSP.DeserializeIdentity(sID)
+	assert.NoError(t, err)
+
+	err = id.SatisfiesPrincipal(attributePrincipal(t, "", "role", "auditor"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid principal type")
+}
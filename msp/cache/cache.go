@@ -7,6 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package cache
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
 	pmsp "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/msp"
@@ -21,22 +26,60 @@ const (
 
 var mspLogger = flogging.MustGetLogger("msp")
 
+// Options customizes the cache layer created by NewWithOptions.
+type Options struct {
+	// ChannelID, when set, shares the caches with every other MSP instance
+	// created for the same MSP on the same channel, so that the caches stay
+	// warm across the config updates of the channel as long as the MSP
+	// definition itself is unchanged.
+	ChannelID string
+
+	// Cache sizes; a non-positive size falls back to the default of 100
+	// entries per cache.
+	DeserializeIdentityCacheSize int
+	ValidateIdentityCacheSize    int
+	SatisfiesPrincipalCacheSize  int
+}
+
+// New adds an unshared cache layer with default sizes on top of the given MSP.
 func New(o msp.MSP) (msp.MSP, error) {
+	return NewWithOptions(o, Options{})
+}
+
+// NewWithOptions adds a cache layer on top of the given MSP, customized
+// according to the given options.
+func NewWithOptions(o msp.MSP, opts Options) (msp.MSP, error) {
 	mspLogger.Debugf("Creating Cache-MSP instance")
 	if o == nil {
 		return nil, errors.Errorf("Invalid passed MSP. It must be different from nil.")
 	}
 
-	theMsp := &cachedMSP{MSP: o}
-	theMsp.deserializeIdentityCache = newSecondChanceCache(deserializeIdentityCacheSize)
-	theMsp.satisfiesPrincipalCache = newSecondChanceCache(satisfiesPrincipalCacheSize)
-	theMsp.validateIdentityCache = newSecondChanceCache(validateIdentityCacheSize)
+	if opts.DeserializeIdentityCacheSize <= 0 {
+		opts.DeserializeIdentityCacheSize = deserializeIdentityCacheSize
+	}
+	if opts.ValidateIdentityCacheSize <= 0 {
+		opts.ValidateIdentityCacheSize = validateIdentityCacheSize
+	}
+	if opts.SatisfiesPrincipalCacheSize <= 0 {
+		opts.SatisfiesPrincipalCacheSize = satisfiesPrincipalCacheSize
+	}
+
+	channelLabel := opts.ChannelID
+	if channelLabel == "" {
+		channelLabel = "local"
+	}
+
+	theMsp := &cachedMSP{MSP: o, opts: opts, channelLabel: channelLabel}
+	theMsp.mspCaches = newMSPCaches(nil, opts)
 
 	return theMsp, nil
 }
 
-type cachedMSP struct {
-	msp.MSP
+// mspCaches groups the caches of a single MSP so that they can be handed
+// over as a unit to the next MSP instance set up with the same definition.
+type mspCaches struct {
+	// hash of the MSP configuration the cached entries were produced under
+	configHash []byte
 
 	// cache for DeserializeIdentity.
 	deserializeIdentityCache *secondChanceCache
@@ -49,6 +92,64 @@ type cachedMSP struct {
 	satisfiesPrincipalCache *secondChanceCache
 }
 
+func newMSPCaches(configHash []byte, opts Options) *mspCaches {
+	return &mspCaches{
+		configHash:               configHash,
+		deserializeIdentityCache: newSecondChanceCache(opts.DeserializeIdentityCacheSize),
+		validateIdentityCache:    newSecondChanceCache(opts.ValidateIdentityCacheSize),
+		satisfiesPrincipalCache:  newSecondChanceCache(opts.SatisfiesPrincipalCacheSize),
+	}
+}
+
+// cacheRegistry hands out the caches shared by the successive instances of
+// a channel MSP. A channel's config updates recreate every MSP of the
+// channel, but in the vast majority of updates the MSP definitions are
+// untouched; keeping the caches across such updates spares the node from
+// re-verifying every identity and principal it already knows.
+type cacheRegistry struct {
+	mutex  sync.Mutex
+	caches map[string]*mspCaches
+}
+
+var sharedCaches = &cacheRegistry{caches: make(map[string]*mspCaches)}
+
+// cachesFor returns the caches shared by the MSP with the given identifier
+// on the given channel. The cached entries are reused only if the MSP
+// configuration is unchanged; otherwise fresh caches replace them.
+func (r *cacheRegistry) cachesFor(channelID, mspID string, configHash []byte, opts Options) *mspCaches {
+	key := channelID + "/" + mspID
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	existing, ok := r.caches[key]
+	if ok && len(configHash) > 0 && bytes.Equal(existing.configHash, configHash) {
+		return existing
+	}
+
+	caches := newMSPCaches(configHash, opts)
+	r.caches[key] = caches
+	return caches
+}
+
+func mspConfigHash(config *pmsp.MSPConfig) []byte {
+	raw, err := proto.Marshal(config)
+	if err != nil {
+		return nil
+	}
+	hash := sha256.Sum256(raw)
+	return hash[:]
+}
+
+type cachedMSP struct {
+	msp.MSP
+
+	opts         Options
+	channelLabel string
+
+	*mspCaches
+}
+
 type cachedIdentity struct {
 	msp.Identity
 	cache *cachedMSP
@@ -64,6 +165,7 @@ func (id *cachedIdentity) Validate() error {
 
 func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
 	id, ok := c.deserializeIdentityCache.get(string(serializedIdentity))
+	c.report("deserialize_identity", ok)
 	if ok {
 		return &cachedIdentity{
 			cache:    c,
@@ -85,7 +187,20 @@ func (c *cachedMSP) DeserializeIdentity(serializedIdentity []byte) (msp.Identity
 func (c *cachedMSP) Setup(config *pmsp.MSPConfig) error {
 	c.cleanCache()
 
-	return c.MSP.Setup(config)
+	if err := c.MSP.Setup(config); err != nil {
+		return err
+	}
+
+	if c.opts.ChannelID != "" {
+		// adopt the caches of the previous instance of this MSP on the
+		// channel; identities and principal evaluations cached under an
+		// identical configuration remain valid
+		if mspID, err := c.MSP.GetIdentifier(); err == nil {
+			c.mspCaches = sharedCaches.cachesFor(c.opts.ChannelID, mspID, mspConfigHash(config), c.opts)
+		}
+	}
+
+	return nil
 }
 
 func (c *cachedMSP) Validate(id msp.Identity) error {
@@ -93,6 +208,7 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 	key := string(identifier.Mspid + ":" + identifier.Id)
 
 	_, ok := c.validateIdentityCache.get(key)
+	c.report("validate_identity", ok)
 	if ok {
 		// cache only stores if the identity is valid.
 		return nil
@@ -113,6 +229,7 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	key := identityKey + principalKey
 
 	v, ok := c.satisfiesPrincipalCache.get(key)
+	c.report("satisfies_principal", ok)
 	if ok {
 		if v == nil {
 			return nil
@@ -127,10 +244,16 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	return err
 }
 
+func (c *cachedMSP) report(cacheName string, hit bool) {
+	if hit {
+		cacheMetrics.Hits.With("channel", c.channelLabel, "cache", cacheName).Add(1)
+		return
+	}
+	cacheMetrics.Misses.With("channel", c.channelLabel, "cache", cacheName).Add(1)
+}
+
 func (c *cachedMSP) cleanCache() error {
-	c.deserializeIdentityCache = newSecondChanceCache(deserializeIdentityCacheSize)
-	c.satisfiesPrincipalCache = newSecondChanceCache(satisfiesPrincipalCacheSize)
-	c.validateIdentityCache = newSecondChanceCache(validateIdentityCacheSize)
+	c.mspCaches = newMSPCaches(nil, c.opts)
 
 	return nil
 }
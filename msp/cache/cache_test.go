@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	msp2 "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mocks"
 	"github.com/pkg/errors"
@@ -321,3 +322,135 @@ func TestSatisfiesPrincipal(t *testing.T) {
 	assert.NotNil(t, v)
 	assert.Contains(t, "Invalid", v.(error).Error())
 }
+
+func TestNewWithOptions(t *testing.T) {
+	i, err := NewWithOptions(nil, Options{})
+	assert.Error(t, err)
+	assert.Nil(t, i)
+
+	i, err = NewWithOptions(&mocks.MockMSP{}, Options{
+		DeserializeIdentityCacheSize: 5,
+		ValidateIdentityCacheSize:    7,
+		SatisfiesPrincipalCacheSize:  9,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, i.(*cachedMSP).deserializeIdentityCache.items, 5)
+	assert.Len(t, i.(*cachedMSP).validateIdentityCache.items, 7)
+	assert.Len(t, i.(*cachedMSP).satisfiesPrincipalCache.items, 9)
+
+	// non-positive sizes fall back to the defaults
+	i, err = NewWithOptions(&mocks.MockMSP{}, Options{})
+	assert.NoError(t, err)
+	assert.Len(t, i.(*cachedMSP).deserializeIdentityCache.items, deserializeIdentityCacheSize)
+	assert.Len(t, i.(*cachedMSP).validateIdentityCache.items, validateIdentityCacheSize)
+	assert.Len(t, i.(*cachedMSP).satisfiesPrincipalCache.items, satisfiesPrincipalCacheSize)
+}
+
+func TestSharedCachesAcrossSetup(t *testing.T) {
+	configA := &msp2.MSPConfig{Config: []byte("A")}
+	configB := &msp2.MSPConfig{Config: []byte("B")}
+
+	mockMSP := &mocks.MockMSP{}
+	mockMSP.On("Setup", mock.Anything).Return(nil)
+	mockMSP.On("GetIdentifier").Return("SharedMSP", nil)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	serializedIdentity := []byte{1, 2, 3}
+	mockMSP.On("DeserializeIdentity", serializedIdentity).Return(mockIdentity, nil)
+
+	first, err := NewWithOptions(mockMSP, Options{ChannelID: "sharedchannel"})
+	assert.NoError(t, err)
+	assert.NoError(t, first.Setup(configA))
+	_, err = first.DeserializeIdentity(serializedIdentity)
+	assert.NoError(t, err)
+
+	// a new instance set up with the identical configuration adopts the
+	// warm caches of its predecessor
+	second, err := NewWithOptions(mockMSP, Options{ChannelID: "sharedchannel"})
+	assert.NoError(t, err)
+	assert.NoError(t, second.Setup(configA))
+	_, ok := second.(*cachedMSP).deserializeIdentityCache.get(string(serializedIdentity))
+	assert.True(t, ok)
+
+	// a configuration change discards the cached entries
+	third, err := NewWithOptions(mockMSP, Options{ChannelID: "sharedchannel"})
+	assert.NoError(t, err)
+	assert.NoError(t, third.Setup(configB))
+	_, ok = third.(*cachedMSP).deserializeIdentityCache.get(string(serializedIdentity))
+	assert.False(t, ok)
+
+	// the same MSP on another channel does not share
+	other, err := NewWithOptions(mockMSP, Options{ChannelID: "otherchannel"})
+	assert.NoError(t, err)
+	assert.NoError(t, other.Setup(configB))
+	_, ok = other.(*cachedMSP).deserializeIdentityCache.get(string(serializedIdentity))
+	assert.False(t, ok)
+}
+
+func TestCacheMetrics(t *testing.T) {
+	fakeHits := &metricsfakes.Counter{}
+	fakeHits.WithReturns(fakeHits)
+	fakeMisses := &metricsfakes.Counter{}
+	fakeMisses.WithReturns(fakeMisses)
+	defer func(m *Metrics) { cacheMetrics = m }(cacheMetrics)
+	cacheMetrics = &Metrics{Hits: fakeHits, Misses: fakeMisses}
+
+	mockMSP := &mocks.MockMSP{}
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	serializedIdentity := []byte{1, 2, 3}
+	mockMSP.On("DeserializeIdentity", serializedIdentity).Return(mockIdentity, nil)
+
+	i, err := New(mockMSP)
+	assert.NoError(t, err)
+
+	_, err = i.DeserializeIdentity(serializedIdentity)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, fakeHits.AddCallCount())
+	assert.Equal(t, 1, fakeMisses.AddCallCount())
+	assert.Equal(t, []string{"channel", "local", "cache", "deserialize_identity"}, fakeMisses.WithArgsForCall(0))
+
+	_, err = i.DeserializeIdentity(serializedIdentity)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fakeHits.AddCallCount())
+	assert.Equal(t, []string{"channel", "local", "cache", "deserialize_identity"}, fakeHits.WithArgsForCall(0))
+}
+
+func BenchmarkDeserializeIdentityHit(b *testing.B) {
+	mockMSP := &mocks.MockMSP{}
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	serializedIdentity := []byte{1, 2, 3}
+	mockMSP.On("DeserializeIdentity", serializedIdentity).Return(mockIdentity, nil)
+
+	i, err := New(mockMSP)
+	assert.NoError(b, err)
+	// warm the cache
+	_, err = i.DeserializeIdentity(serializedIdentity)
+	assert.NoError(b, err)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := i.DeserializeIdentity(serializedIdentity); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSatisfiesPrincipalHit(b *testing.B) {
+	mockMSP := &mocks.MockMSP{}
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	principal := &msp2.MSPPrincipal{PrincipalClassification: msp2.MSPPrincipal_IDENTITY, Principal: []byte{1, 2, 3}}
+	mockMSP.On("SatisfiesPrincipal", mockIdentity, principal).Return(nil)
+
+	i, err := New(mockMSP)
+	assert.NoError(b, err)
+	// warm the cache
+	assert.NoError(b, i.SatisfiesPrincipal(mockIdentity, principal))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if err := i.SatisfiesPrincipal(mockIdentity, principal); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
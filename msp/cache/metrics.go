@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+)
+
+var cacheHits = metrics.CounterOpts{
+	Namespace:    "msp",
+	Name:         "cache_hits",
+	Help:         "The number of lookups served from the MSP caches.",
+	LabelNames:   []string{"channel", "cache"},
+	StatsdFormat: "%{#fqname}.%{channel}.%{cache}",
+}
+
+var cacheMisses = metrics.CounterOpts{
+	Namespace:    "msp",
+	Name:         "cache_misses",
+	Help:         "The number of lookups the MSP caches could not serve.",
+	LabelNames:   []string{"channel", "cache"},
+	StatsdFormat: "%{#fqname}.%{channel}.%{cache}",
+}
+
+type Metrics struct {
+	Hits   metrics.Counter
+	Misses metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		Hits:   p.NewCounter(cacheHits),
+		Misses: p.NewCounter(cacheMisses),
+	}
+}
+
+var cacheMetrics = NewMetrics(&disabled.Provider{})
+
+// InitializeMetrics replaces the no-op metrics the MSP caches report to by
+// default. It is meant to be called once during node startup, before any
+// channel is created.
+func InitializeMetrics(p metrics.Provider) {
+	cacheMetrics = NewMetrics(p)
+}
@@ -11,6 +11,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/msp"
@@ -380,6 +382,7 @@ const (
 	IdemixConfigFileIssuerPublicKey     = "IssuerPublicKey"
 	IdemixConfigFileRevocationPublicKey = "RevocationPublicKey"
 	IdemixConfigFileSigner              = "SignerConfig"
+	IdemixConfigFileEpoch               = "Epoch"
 )
 
 // GetIdemixMspConfig returns the configuration for the Idemix MSP
@@ -400,6 +403,17 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 		RevocationPk: revocationPkBytes,
 	}
 
+	// The epoch file is optional; an MSP config without it operates
+	// in the initial revocation epoch 0.
+	epochBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileEpoch))
+	if err == nil {
+		epoch, err := strconv.ParseInt(strings.TrimSpace(string(epochBytes)), 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse revocation epoch file")
+		}
+		idemixConfig.Epoch = epoch
+	}
+
 	signerBytes, err := readFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
 	if err == nil {
 		signerConfig := &msp.IdemixMSPSignerConfig{}
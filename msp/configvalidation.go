@@ -0,0 +1,233 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/pkg/errors"
+)
+
+// Severities of a ValidationFinding
+const (
+	FindingError   = "error"
+	FindingWarning = "warning"
+)
+
+// ValidationFinding describes a single problem detected in an MSP
+// configuration, together with the element of the configuration it
+// refers to.
+type ValidationFinding struct {
+	// Severity is either FindingError or FindingWarning. An MSP with
+	// error findings will not work; warnings flag setups that work but
+	// are likely not what the operator intended.
+	Severity string
+	// Element names the part of the MSP the finding refers to,
+	// e.g. "root CA #1" or "NodeOUs.ClientOUIdentifier".
+	Element string
+	// Message describes the problem and how to fix it.
+	Message string
+}
+
+func (f *ValidationFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Element, f.Message)
+}
+
+// ValidateMSPDir checks the MSP folder at the given path for the classic
+// assembly mistakes: missing or non-CA root certificates, expired CAs,
+// admin certificates that do not chain to any declared CA, NodeOUs
+// classifications without certifiers, and CAs reused across the signing
+// and TLS roles. It returns one finding per problem detected; an error is
+// returned only when the folder cannot be read as an MSP configuration
+// at all.
+func ValidateMSPDir(dir string) ([]*ValidationFinding, error) {
+	conf, err := GetVerifyingMspConfig(dir, "MSPValidator", ProviderTypeToString(FABRIC))
+	if err != nil {
+		return nil, err
+	}
+	mspConf := &msp.FabricMSPConfig{}
+	if err := proto.Unmarshal(conf.Config, mspConf); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling fabric msp config")
+	}
+	return ValidateMSPConfig(mspConf), nil
+}
+
+// ValidateMSPConfig applies the same checks as ValidateMSPDir to an
+// already assembled FabricMSPConfig, e.g. one extracted from a channel
+// configuration.
+func ValidateMSPConfig(conf *msp.FabricMSPConfig) []*ValidationFinding {
+	v := &configValidator{now: time.Now()}
+	v.validate(conf)
+	return v.findings
+}
+
+type configValidator struct {
+	now      time.Time
+	findings []*ValidationFinding
+}
+
+func (v *configValidator) addf(severity, element, format string, args ...interface{}) {
+	v.findings = append(v.findings, &ValidationFinding{
+		Severity: severity,
+		Element:  element,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *configValidator) validate(conf *msp.FabricMSPConfig) {
+	if len(conf.RootCerts) == 0 {
+		v.addf(FindingError, "root CAs", "the MSP declares no root CA certificates; add at least one CA certificate under cacerts")
+	}
+
+	roots := v.checkCAs(conf.RootCerts, "root CA")
+	intermediates := v.checkCAs(conf.IntermediateCerts, "intermediate CA")
+	tlsRoots := v.checkCAs(conf.TlsRootCerts, "TLS root CA")
+	v.checkCAs(conf.TlsIntermediateCerts, "TLS intermediate CA")
+
+	v.checkAdmins(conf, roots, intermediates)
+	v.checkNodeOUs(conf)
+	v.checkMixedCAs(conf.RootCerts, tlsRoots)
+}
+
+// checkCAs parses the given PEM-encoded certificates and reports the ones
+// that are unparsable, are not CA certificates, or are outside their
+// validity period. It returns the certificates that parsed successfully.
+func (v *configValidator) checkCAs(certs [][]byte, role string) []*x509.Certificate {
+	var parsed []*x509.Certificate
+	for i, raw := range certs {
+		element := fmt.Sprintf("%s #%d", role, i+1)
+		cert := v.parseCert(raw, element)
+		if cert == nil {
+			continue
+		}
+		if !cert.IsCA {
+			v.addf(FindingError, element, "certificate [%s] is not a CA certificate; it lacks the CA basic constraint", cert.Subject)
+		}
+		v.checkValidity(cert, element)
+		parsed = append(parsed, cert)
+	}
+	return parsed
+}
+
+func (v *configValidator) checkAdmins(conf *msp.FabricMSPConfig, roots, intermediates []*x509.Certificate) {
+	rootPool := x509.NewCertPool()
+	for _, cert := range roots {
+		rootPool.AddCert(cert)
+	}
+	intermediatePool := x509.NewCertPool()
+	for _, cert := range intermediates {
+		intermediatePool.AddCert(cert)
+	}
+
+	for i, raw := range conf.Admins {
+		element := fmt.Sprintf("admin #%d", i+1)
+		cert := v.parseCert(raw, element)
+		if cert == nil {
+			continue
+		}
+		v.checkValidity(cert, element)
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         rootPool,
+			Intermediates: intermediatePool,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			CurrentTime:   v.now,
+		})
+		if err != nil {
+			v.addf(FindingError, element, "certificate [%s] does not chain to any of the MSP's CAs; it was likely issued by a different CA", cert.Subject)
+		}
+	}
+}
+
+func (v *configValidator) checkNodeOUs(conf *msp.FabricMSPConfig) {
+	nodeOUs := conf.FabricNodeOus
+	if nodeOUs == nil || !nodeOUs.Enable {
+		if len(conf.Admins) == 0 {
+			v.addf(FindingError, "admins", "the MSP declares no admin certificates and NodeOUs are disabled; no identity can administer this organization")
+		}
+		return
+	}
+
+	ous := []struct {
+		element string
+		ou      *msp.FabricOUIdentifier
+	}{
+		{"NodeOUs.ClientOUIdentifier", nodeOUs.ClientOuIdentifier},
+		{"NodeOUs.PeerOUIdentifier", nodeOUs.PeerOuIdentifier},
+		{"NodeOUs.AdminOUIdentifier", nodeOUs.AdminOuIdentifier},
+		{"NodeOUs.OrdererOUIdentifier", nodeOUs.OrdererOuIdentifier},
+	}
+	for _, entry := range ous {
+		element, ou := entry.element, entry.ou
+		if ou == nil {
+			continue
+		}
+		if ou.OrganizationalUnitIdentifier == "" {
+			v.addf(FindingError, element, "the OU identifier is empty; set OrganizationalUnitIdentifier in config.yaml")
+		}
+		if len(ou.Certificate) == 0 {
+			v.addf(FindingWarning, element, "no certifier certificate is set; identities carrying this OU will be accepted from any of the MSP's CAs")
+		} else {
+			v.parseCert(ou.Certificate, element)
+		}
+	}
+
+	if nodeOUs.AdminOuIdentifier == nil && len(conf.Admins) == 0 {
+		v.addf(FindingError, "admins", "NodeOUs are enabled without an AdminOUIdentifier and no admin certificates are declared; no identity can administer this organization")
+	}
+}
+
+// checkMixedCAs flags CA certificates that appear in both the signing and
+// the TLS role; reusing a CA across the two roles works but usually means
+// the folders were assembled from the wrong crypto material.
+func (v *configValidator) checkMixedCAs(rootCerts [][]byte, tlsRoots []*x509.Certificate) {
+	for i, raw := range rootCerts {
+		cert := parseCertPEM(raw)
+		if cert == nil {
+			continue
+		}
+		for _, tlsRoot := range tlsRoots {
+			if bytes.Equal(cert.Raw, tlsRoot.Raw) {
+				v.addf(FindingWarning, fmt.Sprintf("root CA #%d", i+1), "certificate [%s] is declared as both a signing CA and a TLS CA; the two roles normally use distinct CAs", cert.Subject)
+			}
+		}
+	}
+}
+
+func (v *configValidator) checkValidity(cert *x509.Certificate, element string) {
+	switch {
+	case v.now.After(cert.NotAfter):
+		v.addf(FindingError, element, "certificate [%s] expired on %s", cert.Subject, cert.NotAfter.Format(time.RFC3339))
+	case v.now.Before(cert.NotBefore):
+		v.addf(FindingError, element, "certificate [%s] is not valid before %s", cert.Subject, cert.NotBefore.Format(time.RFC3339))
+	}
+}
+
+func (v *configValidator) parseCert(raw []byte, element string) *x509.Certificate {
+	cert := parseCertPEM(raw)
+	if cert == nil {
+		v.addf(FindingError, element, "the certificate is not a parsable PEM-encoded X509 certificate")
+	}
+	return cert
+}
+
+func parseCertPEM(raw []byte) *x509.Certificate {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+	return cert
+}
@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/stretchr/testify/assert"
+)
+
+type validationCA struct {
+	certPEM []byte
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+}
+
+func newValidationCA(t *testing.T, cn string, notBefore, notAfter time.Time, isCA bool) *validationCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certDER)
+	assert.NoError(t, err)
+	return &validationCA{
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		cert:    cert,
+		key:     key,
+	}
+}
+
+func (ca *validationCA) issue(t *testing.T, cn string, notBefore, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+}
+
+func findingsByElement(findings []*ValidationFinding) map[string][]string {
+	byElement := map[string][]string{}
+	for _, finding := range findings {
+		byElement[finding.Element] = append(byElement[finding.Element], finding.Severity+": "+finding.Message)
+	}
+	return byElement
+}
+
+func TestValidateMSPConfigWellFormed(t *testing.T) {
+	notBefore := time.Now().Add(-1 * time.Hour)
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca := newValidationCA(t, "ca.example.com", notBefore, notAfter, true)
+	tlsCA := newValidationCA(t, "tlsca.example.com", notBefore, notAfter, true)
+
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{
+		Name:         "WellFormedMSP",
+		RootCerts:    [][]byte{ca.certPEM},
+		TlsRootCerts: [][]byte{tlsCA.certPEM},
+		Admins:       [][]byte{ca.issue(t, "admin.example.com", notBefore, notAfter)},
+	})
+	assert.Empty(t, findings)
+}
+
+func TestValidateMSPConfigMissingRootsAndAdmins(t *testing.T) {
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{Name: "EmptyMSP"})
+	byElement := findingsByElement(findings)
+	assert.Contains(t, byElement["root CAs"][0], "declares no root CA certificates")
+	assert.Contains(t, byElement["admins"][0], "declares no admin certificates")
+}
+
+func TestValidateMSPConfigBadRoots(t *testing.T) {
+	notBefore := time.Now().Add(-48 * time.Hour)
+	expired := newValidationCA(t, "expired.example.com", notBefore, time.Now().Add(-24*time.Hour), true)
+	nonCA := newValidationCA(t, "nonca.example.com", notBefore, time.Now().Add(24*time.Hour), false)
+
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{
+		Name:      "BadRootsMSP",
+		RootCerts: [][]byte{expired.certPEM, nonCA.certPEM, []byte("not a certificate")},
+	})
+	byElement := findingsByElement(findings)
+	assert.Contains(t, byElement["root CA #1"][0], "expired on")
+	assert.Contains(t, byElement["root CA #2"][0], "not a CA certificate")
+	assert.Contains(t, byElement["root CA #3"][0], "not a parsable PEM-encoded X509 certificate")
+}
+
+func TestValidateMSPConfigForeignAdmin(t *testing.T) {
+	notBefore := time.Now().Add(-1 * time.Hour)
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca := newValidationCA(t, "ca.example.com", notBefore, notAfter, true)
+	otherCA := newValidationCA(t, "other.example.com", notBefore, notAfter, true)
+
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{
+		Name:      "ForeignAdminMSP",
+		RootCerts: [][]byte{ca.certPEM},
+		Admins:    [][]byte{otherCA.issue(t, "admin.example.com", notBefore, notAfter)},
+	})
+	byElement := findingsByElement(findings)
+	assert.Contains(t, byElement["admin #1"][0], "does not chain to any of the MSP's CAs")
+}
+
+func TestValidateMSPConfigNodeOUs(t *testing.T) {
+	notBefore := time.Now().Add(-1 * time.Hour)
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca := newValidationCA(t, "ca.example.com", notBefore, notAfter, true)
+
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{
+		Name:      "NodeOUsMSP",
+		RootCerts: [][]byte{ca.certPEM},
+		FabricNodeOus: &msp.FabricNodeOUs{
+			Enable:             true,
+			ClientOuIdentifier: &msp.FabricOUIdentifier{},
+			PeerOuIdentifier: &msp.FabricOUIdentifier{
+				OrganizationalUnitIdentifier: "peer",
+				Certificate:                  ca.certPEM,
+			},
+		},
+	})
+	byElement := findingsByElement(findings)
+	assert.Contains(t, byElement["NodeOUs.ClientOUIdentifier"][0], "error: the OU identifier is empty")
+	assert.Contains(t, byElement["NodeOUs.ClientOUIdentifier"][1], "warning: no certifier certificate")
+	assert.NotContains(t, byElement, "NodeOUs.PeerOUIdentifier")
+	assert.Contains(t, byElement["admins"][0], "NodeOUs are enabled without an AdminOUIdentifier")
+}
+
+func TestValidateMSPConfigMixedCAs(t *testing.T) {
+	notBefore := time.Now().Add(-1 * time.Hour)
+	notAfter := time.Now().Add(24 * time.Hour)
+	ca := newValidationCA(t, "ca.example.com", notBefore, notAfter, true)
+
+	findings := ValidateMSPConfig(&msp.FabricMSPConfig{
+		Name:         "MixedCAsMSP",
+		RootCerts:    [][]byte{ca.certPEM},
+		TlsRootCerts: [][]byte{ca.certPEM},
+		Admins:       [][]byte{ca.issue(t, "admin.example.com", notBefore, notAfter)},
+	})
+	byElement := findingsByElement(findings)
+	assert.Contains(t, byElement["root CA #1"][0], "both a signing CA and a TLS CA")
+}
+
+func TestValidateMSPDir(t *testing.T) {
+	findings, err := ValidateMSPDir(configtest.GetDevMspDir())
+	assert.NoError(t, err)
+	assert.Empty(t, findings)
+
+	_, err = ValidateMSPDir("/tmp/this/does/not/exist")
+	assert.Error(t, err)
+}
@@ -140,6 +140,10 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	}
 	msp.revocationPK = RevocationPublicKey
 
+	// The epoch is the revocation period the MSP operates in; signatures
+	// must carry credential revocation information issued for this epoch.
+	msp.epoch = int(conf.Epoch)
+
 	if conf.Signer == nil {
 		// No credential in config, so we don't setup a default signer
 		mspLogger.Debug("idemix msp setup as verification only msp (no key material found)")
@@ -180,8 +184,22 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 
 	enrollmentId := conf.Signer.EnrollmentId
 
-	// Verify credential
+	// Verify that the credential revocation information was signed by the
+	// revocation authority and was issued for the epoch the MSP operates in;
+	// a signer with a stale CRI cannot produce valid signatures and is
+	// rejected at setup rather than at signing time.
 	valid, err := msp.csp.Verify(
+		msp.revocationPK,
+		conf.Signer.CredentialRevocationInformation,
+		nil,
+		&bccsp.IdemixCRISignerOpts{Epoch: msp.epoch},
+	)
+	if err != nil || !valid {
+		return errors.WithMessage(err, "Credential revocation information is not valid")
+	}
+
+	// Verify credential
+	valid, err = msp.csp.Verify(
 		UserKey,
 		conf.Signer.Cred,
 		nil,
@@ -552,8 +570,9 @@ func newIdemixIdentity(msp *idemixmsp, NymPublicKey bccsp.Key, role *m.MSPRole,
 }
 
 func (id *idemixidentity) ExpiresAt() time.Time {
-	// Idemix MSP currently does not use expiration dates or revocation,
-	// so we return the zero time to indicate this.
+	// Idemix credentials are revoked by advancing the revocation epoch
+	// rather than through expiration dates, so we return the zero time
+	// to indicate this.
 	return time.Time{}
 }
 
@@ -57,6 +57,11 @@ func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity,
 		mspIdentityLogger.Debugf("Creating identity instance for cert %s", certToPEM(cert))
 	}
 
+	// Ed25519 keys are only accepted once the channel operates at MSP v3.0 or later
+	if cert.PublicKeyAlgorithm == x509.Ed25519 && msp.version < MSPv3_0 {
+		return nil, errors.Errorf("Ed25519 keys are not supported at MSP version [%v]; the channel must support MSP v3.0 or later", msp.version)
+	}
+
 	// Sanitize first the certificate
 	cert, err := msp.sanitizeCert(cert)
 	if err != nil {
@@ -169,15 +174,19 @@ func NewSerializedIdentity(mspID string, certPEM []byte) ([]byte, error) {
 func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
-	if err != nil {
-		return errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return errors.WithMessage(err, "failed computing digest")
+	// Compute Hash. Ed25519 hashes internally as part of signing,
+	// so the signature is verified over the message itself.
+	digest := msg
+	if id.cert.PublicKeyAlgorithm != x509.Ed25519 {
+		hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+		if err != nil {
+			return errors.WithMessage(err, "failed getting hash function options")
+		}
+
+		digest, err = id.msp.bccsp.Hash(msg, hashOpt)
+		if err != nil {
+			return errors.WithMessage(err, "failed computing digest")
+		}
 	}
 
 	if mspIdentityLogger.IsEnabledFor(zapcore.DebugLevel) {
@@ -254,15 +263,19 @@ func newSigningIdentity(cert *x509.Certificate, pk bccsp.Key, signer crypto.Sign
 func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	//mspIdentityLogger.Infof("Signing message")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed computing digest")
+	// Compute Hash. Ed25519 hashes internally as part of signing,
+	// so the message itself is handed to the signer.
+	digest := msg
+	if id.cert.PublicKeyAlgorithm != x509.Ed25519 {
+		hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed getting hash function options")
+		}
+
+		digest, err = id.msp.bccsp.Hash(msg, hashOpt)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed computing digest")
+		}
 	}
 
 	if len(msg) < 32 {
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mgmt
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ReloadHandler exposes the local MSP reload over the operations server so
+// that refreshed CRLs, new admin certificates, or a renewed signing
+// certificate take effect without restarting the node. A reload is triggered
+// by a POST request; the reload function re-reads the MSP directory and
+// applies the configuration to the running local MSP.
+type ReloadHandler struct {
+	reload func() error
+}
+
+// NewReloadHandler creates an http.Handler that invokes the supplied reload
+// function. The peer and the orderer each bind the function to the local MSP
+// directory and identifier they were started with.
+func NewReloadHandler(reload func() error) *ReloadHandler {
+	return &ReloadHandler{reload: reload}
+}
+
+func (h *ReloadHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.reload(); err != nil {
+		mspLogger.Warningf("Failed reloading the local MSP: %s", err)
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(resp).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	mspLogger.Info("Reloaded the local MSP")
+	resp.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mgmt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadHandler(t *testing.T) {
+	reloaded := false
+	handler := NewReloadHandler(func() error {
+		reloaded = true
+		return nil
+	})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/msp/reload", nil))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.True(t, reloaded)
+}
+
+func TestReloadHandlerError(t *testing.T) {
+	handler := NewReloadHandler(func() error {
+		return errors.New("the CRL is malformed")
+	})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/msp/reload", nil))
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error": "the CRL is malformed"}`, resp.Body.String())
+}
+
+func TestReloadHandlerBadMethod(t *testing.T) {
+	handler := NewReloadHandler(func() error {
+		t.Fatal("a GET request must not trigger a reload")
+		return nil
+	})
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/msp/reload", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
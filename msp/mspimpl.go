@@ -133,6 +133,11 @@ func newBccspMsp(version MSPVersion, defaultBCCSP bccsp.BCCSP) (MSP, error) {
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV142
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV142
 		theMsp.internalSetupAdmin = theMsp.setupAdminsV142
+	case MSPv3_0:
+		theMsp.internalSetupFunc = theMsp.setupV142
+		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV142
+		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV3
+		theMsp.internalSetupAdmin = theMsp.setupAdminsV142
 	default:
 		return nil, errors.Errorf("Invalid MSP version [%v]", version)
 	}
@@ -670,6 +675,60 @@ func (msp *bccspmsp) satisfiesPrincipalInternalV142(id Identity, principal *m.MS
 	return msp.satisfiesPrincipalInternalV13(id, principal)
 }
 
+// satisfiesPrincipalInternalV3 takes as arguments the identity and the principal.
+// The function returns an error if one occurred.
+// The function implements the additional behavior expected of an MSP starting from v3.0.
+// For v2.0 functionality, the function calls the satisfiesPrincipalInternalV142.
+func (msp *bccspmsp) satisfiesPrincipalInternalV3(id Identity, principal *m.MSPPrincipal) error {
+	switch principal.PrincipalClassification {
+	case m.MSPPrincipal_ATTRIBUTE:
+		_, okay := id.(*identity)
+		if !okay {
+			return errors.New("invalid identity type, expected *identity")
+		}
+
+		// Principal contains the required certificate attribute
+		attr := &m.MSPAttribute{}
+		err := proto.Unmarshal(principal.Principal, attr)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal MSPAttribute from principal")
+		}
+
+		if attr.AttributeName == "" {
+			return errors.New("invalid attribute principal, the attribute name must be set")
+		}
+
+		// an attribute principal without an MSP identifier matches the
+		// attribute holders of every MSP of the channel
+		if attr.MspIdentifier != "" && attr.MspIdentifier != msp.name {
+			return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", attr.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		mspLogger.Debugf("Checking if identity carries the attribute [%s] for %s", attr.AttributeName, msp.name)
+		if err := msp.Validate(id); err != nil {
+			return errors.Wrapf(err, "The identity is not valid under this MSP [%s]", msp.name)
+		}
+
+		attrs, err := certificateAttributes(id.(*identity).cert)
+		if err != nil {
+			return err
+		}
+
+		value, inCert := attrs[attr.AttributeName]
+		if !inCert {
+			return errors.Errorf("the certificate carries no attribute named [%s]", attr.AttributeName)
+		}
+		if attr.AttributeValue != "" && value != attr.AttributeValue {
+			return errors.Errorf("the certificate attribute [%s] does not carry the required value", attr.AttributeName)
+		}
+
+		return nil
+	default:
+		// Use the v2.0 function to check other principal types
+		return msp.satisfiesPrincipalInternalV142(id, principal)
+	}
+}
+
 func (msp *bccspmsp) isInAdmins(id *identity) bool {
 	for _, admincert := range msp.admins {
 		if bytes.Equal(id.cert.Raw, admincert.(*identity).cert.Raw) {
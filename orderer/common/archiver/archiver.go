@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package archiver implements configurable retention for the orderer's block
+// ledger. Blocks that have aged out of a channel's retention window are
+// streamed to an archival sink, after which the contiguous archived prefix of
+// the chain may be removed from the local block store. The latest config
+// block is always retained locally, as it is required to bootstrap
+// reconnecting peers and ordering service nodes.
+package archiver
+
+import (
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("orderer.common.archiver")
+
+// Sink receives blocks that have aged out of the retention window. A Sink
+// must store blocks durably before returning, since a successfully archived
+// block becomes eligible for removal from the local block store.
+type Sink interface {
+	Put(channelID string, block *cb.Block) error
+}
+
+// Source serves previously archived blocks. It is the counterpart of Sink
+// implemented by the archival backends in common/ledger/blockarchival that
+// support retrieval.
+type Source interface {
+	Get(channelID string, number uint64) (*cb.Block, error)
+}
+
+// GetBlock returns the requested block of a channel, reading it from the
+// local ledger when present and falling back to the archival source for
+// blocks that have been pruned from the local block store.
+func GetBlock(channelID string, reader blockledger.Reader, source Source, number uint64) (*cb.Block, error) {
+	if number < reader.Height() {
+		if block := blockledger.GetBlock(reader, number); block != nil {
+			return block, nil
+		}
+	}
+	return source.Get(channelID, number)
+}
+
+// Archiver streams blocks of a single channel that are older than the
+// retention window to a Sink. It is not safe for concurrent use.
+type Archiver struct {
+	channelID    string
+	reader       blockledger.Reader
+	sink         Sink
+	retainBlocks uint64
+
+	nextBlock   uint64
+	configBlock uint64
+}
+
+// New creates an Archiver for the given channel ledger. retainBlocks is the
+// number of most recent blocks that are never archived; it must be at least
+// one so that the chain tip remains available locally.
+func New(channelID string, reader blockledger.Reader, sink Sink, retainBlocks uint64) (*Archiver, error) {
+	if retainBlocks == 0 {
+		return nil, errors.New("retain blocks must be at least 1")
+	}
+
+	return &Archiver{
+		channelID:    channelID,
+		reader:       reader,
+		sink:         sink,
+		retainBlocks: retainBlocks,
+	}, nil
+}
+
+// Archive copies every block that has aged out of the retention window and
+// has not yet been archived to the sink, in order. It returns the number of
+// blocks archived. On error, blocks archived before the failure are not
+// re-archived by subsequent calls.
+func (a *Archiver) Archive() (uint64, error) {
+	height := a.reader.Height()
+	if height <= a.retainBlocks {
+		return 0, nil
+	}
+
+	horizon := height - a.retainBlocks
+	if a.nextBlock >= horizon {
+		return 0, nil
+	}
+
+	iterator, _ := a.reader.Iterator(&ab.SeekPosition{
+		Type: &ab.SeekPosition_Specified{
+			Specified: &ab.SeekSpecified{Number: a.nextBlock},
+		},
+	})
+	defer iterator.Close()
+
+	var archived uint64
+	for a.nextBlock < horizon {
+		block, status := iterator.Next()
+		if status != cb.Status_SUCCESS {
+			return archived, errors.Errorf("failed to retrieve block %d of channel %s: %v", a.nextBlock, a.channelID, status)
+		}
+
+		if err := a.sink.Put(a.channelID, block); err != nil {
+			return archived, errors.WithMessagef(err, "failed to archive block %d of channel %s", a.nextBlock, a.channelID)
+		}
+
+		if protoutil.IsConfigBlock(block) {
+			a.configBlock = block.Header.Number
+		}
+
+		a.nextBlock++
+		archived++
+	}
+
+	logger.Infof("Archived %d block(s) of channel %s, next block to archive is %d", archived, a.channelID, a.nextBlock)
+	return archived, nil
+}
+
+// PrunableThrough returns the exclusive upper bound of the contiguous prefix
+// of blocks that may be removed from the local block store: every block below
+// the bound has been archived, and the latest config block observed so far is
+// always retained.
+func (a *Archiver) PrunableThrough() uint64 {
+	if a.configBlock < a.nextBlock {
+		return a.configBlock
+	}
+	return a.nextBlock
+}
@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package archiver
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockReader serves blocks from a slice and satisfies blockledger.Reader. If
+// height is set it overrides the real chain height.
+type mockReader struct {
+	blocks []*cb.Block
+	height uint64
+}
+
+func (mr *mockReader) Height() uint64 {
+	if mr.height != 0 {
+		return mr.height
+	}
+	return uint64(len(mr.blocks))
+}
+
+func (mr *mockReader) Iterator(startPosition *ab.SeekPosition) (blockledger.Iterator, uint64) {
+	start := startPosition.GetSpecified().Number
+	return &mockIterator{reader: mr, blockNumber: start}, start
+}
+
+type mockIterator struct {
+	reader      *mockReader
+	blockNumber uint64
+}
+
+func (mi *mockIterator) Next() (*cb.Block, cb.Status) {
+	if mi.blockNumber >= uint64(len(mi.reader.blocks)) {
+		return nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+	block := mi.reader.blocks[mi.blockNumber]
+	mi.blockNumber++
+	return block, cb.Status_SUCCESS
+}
+
+func (mi *mockIterator) Close() {}
+
+// mockSink records archived blocks and can be set to fail on a given block
+// number.
+type mockSink struct {
+	blocks      []*cb.Block
+	failOnBlock uint64
+	err         error
+}
+
+func (ms *mockSink) Put(channelID string, block *cb.Block) error {
+	if ms.err != nil && block.Header.Number == ms.failOnBlock {
+		return ms.err
+	}
+	ms.blocks = append(ms.blocks, block)
+	return nil
+}
+
+func newTestBlock(number uint64, headerType cb.HeaderType) *cb.Block {
+	block := protoutil.NewBlock(number, nil)
+	block.Data = &cb.BlockData{
+		Data: [][]byte{
+			protoutil.MarshalOrPanic(&cb.Envelope{
+				Payload: protoutil.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+							Type: int32(headerType),
+						}),
+					},
+				}),
+			}),
+		},
+	}
+	return block
+}
+
+func newTestChain(height uint64, configBlocks ...uint64) *mockReader {
+	isConfig := map[uint64]bool{}
+	for _, number := range configBlocks {
+		isConfig[number] = true
+	}
+
+	reader := &mockReader{}
+	for number := uint64(0); number < height; number++ {
+		headerType := cb.HeaderType_ENDORSER_TRANSACTION
+		if isConfig[number] {
+			headerType = cb.HeaderType_CONFIG
+		}
+		reader.blocks = append(reader.blocks, newTestBlock(number, headerType))
+	}
+	return reader
+}
+
+func TestNewInvalidRetention(t *testing.T) {
+	_, err := New("testchannel", &mockReader{}, &mockSink{}, 0)
+	assert.EqualError(t, err, "retain blocks must be at least 1")
+}
+
+func TestArchiveWithinRetention(t *testing.T) {
+	reader := newTestChain(3, 0)
+	sink := &mockSink{}
+	a, err := New("testchannel", reader, sink, 5)
+	assert.NoError(t, err)
+
+	archived, err := a.Archive()
+	assert.NoError(t, err)
+	assert.Zero(t, archived)
+	assert.Empty(t, sink.blocks)
+	assert.Zero(t, a.PrunableThrough())
+}
+
+func TestArchive(t *testing.T) {
+	reader := newTestChain(10, 0, 5)
+	sink := &mockSink{}
+	a, err := New("testchannel", reader, sink, 3)
+	assert.NoError(t, err)
+
+	archived, err := a.Archive()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), archived)
+	assert.Len(t, sink.blocks, 7)
+	for i, block := range sink.blocks {
+		assert.Equal(t, uint64(i), block.Header.Number)
+	}
+	// blocks 0 through 4 may be pruned; block 5 is the latest config block
+	assert.Equal(t, uint64(5), a.PrunableThrough())
+
+	// a second invocation has nothing left to archive
+	archived, err = a.Archive()
+	assert.NoError(t, err)
+	assert.Zero(t, archived)
+
+	// archiving resumes where it left off as the chain grows
+	for number := uint64(10); number < 13; number++ {
+		reader.blocks = append(reader.blocks, newTestBlock(number, cb.HeaderType_ENDORSER_TRANSACTION))
+	}
+	archived, err = a.Archive()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), archived)
+	assert.Len(t, sink.blocks, 10)
+}
+
+func TestArchiveSinkFailure(t *testing.T) {
+	reader := newTestChain(10, 0)
+	sink := &mockSink{failOnBlock: 2, err: fmt.Errorf("disk full")}
+	a, err := New("testchannel", reader, sink, 3)
+	assert.NoError(t, err)
+
+	archived, err := a.Archive()
+	assert.EqualError(t, err, "failed to archive block 2 of channel testchannel: disk full")
+	assert.Equal(t, uint64(2), archived)
+
+	// blocks archived before the failure are not re-archived
+	sink.err = nil
+	archived, err = a.Archive()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), archived)
+	assert.Len(t, sink.blocks, 7)
+}
+
+func TestArchiveRetrievalFailure(t *testing.T) {
+	// report a height beyond the blocks the reader can actually serve so
+	// retrieval fails partway through
+	reader := newTestChain(2, 0)
+	reader.height = 5
+	sink := &mockSink{}
+	a, err := New("testchannel", reader, sink, 1)
+	assert.NoError(t, err)
+
+	archived, err := a.Archive()
+	assert.EqualError(t, err, "failed to retrieve block 2 of channel testchannel: SERVICE_UNAVAILABLE")
+	assert.Equal(t, uint64(2), archived)
+	assert.Len(t, sink.blocks, 2)
+}
+
+// mapSource serves archived blocks from a map.
+type mapSource struct {
+	blocks map[uint64]*cb.Block
+}
+
+func (s *mapSource) Get(channelID string, number uint64) (*cb.Block, error) {
+	block, ok := s.blocks[number]
+	if !ok {
+		return nil, fmt.Errorf("block %d of channel %s is not archived", number, channelID)
+	}
+	return block, nil
+}
+
+func TestGetBlockReadThrough(t *testing.T) {
+	archivedBlock := newTestBlock(0, cb.HeaderType_CONFIG)
+	localBlock := newTestBlock(1, cb.HeaderType_ENDORSER_TRANSACTION)
+	reader := &mockReader{blocks: []*cb.Block{nil, localBlock}, height: 2}
+	source := &mapSource{blocks: map[uint64]*cb.Block{0: archivedBlock}}
+
+	block, err := GetBlock("testchannel", reader, source, 1)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(localBlock, block))
+
+	// blocks pruned from the local ledger fall back to the archival source
+	block, err = GetBlock("testchannel", reader, source, 0)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(archivedBlock, block))
+
+	_, err = GetBlock("testchannel", reader, source, 42)
+	assert.EqualError(t, err, "block 42 of channel testchannel is not archived")
+}
@@ -0,0 +1,256 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auditlog
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSigner signs by prefixing the message with "signed:".
+type fakeSigner struct {
+	err error
+}
+
+func (fs *fakeSigner) Sign(message []byte) ([]byte, error) {
+	if fs.err != nil {
+		return nil, fs.err
+	}
+	return append([]byte("signed:"), message...), nil
+}
+
+func newTestLog(t *testing.T) (*Log, string) {
+	baseDir, err := ioutil.TempDir("", "auditlog")
+	assert.NoError(t, err)
+
+	log, err := Open(filepath.Join(baseDir, "configtx.log"), &fakeSigner{})
+	assert.NoError(t, err)
+	return log, baseDir
+}
+
+func TestAppendAndQuery(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 1, ConfigSeq: 1}))
+	assert.NoError(t, log.Append(&Entry{Channel: "otherchannel", Block: 4, ConfigSeq: 2}))
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 7, ConfigSeq: 2}))
+
+	entries, err := log.Entries("")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, uint64(0), entries[0].Sequence)
+	assert.Equal(t, uint64(2), entries[2].Sequence)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+	assert.Equal(t, entries[1].Hash, entries[2].PrevHash)
+	assert.NotEmpty(t, entries[0].Signature)
+
+	entries, err = log.Entries("testchannel")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, uint64(7), entries[1].Block)
+
+	assert.NoError(t, log.Verify())
+	assert.NoError(t, log.Close())
+}
+
+func TestChainResumesAcrossReopen(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 1}))
+	assert.NoError(t, log.Close())
+
+	log, err := Open(log.path, &fakeSigner{})
+	assert.NoError(t, err)
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 2}))
+	defer log.Close()
+
+	entries, err := log.Entries("")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, uint64(1), entries[1].Sequence)
+	assert.Equal(t, entries[0].Hash, entries[1].PrevHash)
+	assert.NoError(t, log.Verify())
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+	defer log.Close()
+
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 1, ConfigSeq: 1}))
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 2, ConfigSeq: 2}))
+
+	contents, err := ioutil.ReadFile(log.path)
+	assert.NoError(t, err)
+	tampered := strings.Replace(string(contents), `"config_seq":1`, `"config_seq":3`, 1)
+	assert.NoError(t, ioutil.WriteFile(log.path, []byte(tampered), 0600))
+
+	assert.EqualError(t, log.Verify(), "audit log entry 0 has been altered")
+}
+
+func TestVerifyDetectsRemoval(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+	defer log.Close()
+
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 1}))
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 2}))
+
+	contents, err := ioutil.ReadFile(log.path)
+	assert.NoError(t, err)
+	lines := strings.SplitN(string(contents), "\n", 2)
+	assert.NoError(t, ioutil.WriteFile(log.path, []byte(lines[1]), 0600))
+
+	err = log.Verify()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected sequence")
+}
+
+func newConfigBlock(t *testing.T, channelID string, blockNum uint64) *cb.Block {
+	configUpdate := &cb.ConfigUpdate{
+		ChannelId: channelID,
+		WriteSet: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Orderer": {
+					Values: map[string]*cb.ConfigValue{
+						"BatchSize": {},
+					},
+				},
+			},
+		},
+	}
+
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "SampleOrg"})
+	assert.NoError(t, err)
+	signatureHeader, err := proto.Marshal(&cb.SignatureHeader{Creator: creator})
+	assert.NoError(t, err)
+
+	configUpdateEnvelope := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: protoutil.MarshalOrPanic(configUpdate),
+		Signatures: []*cb.ConfigSignature{
+			{SignatureHeader: signatureHeader},
+		},
+	}
+
+	lastUpdate := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_CONFIG_UPDATE),
+					ChannelId: channelID,
+				}),
+			},
+			Data: protoutil.MarshalOrPanic(configUpdateEnvelope),
+		}),
+	}
+
+	configEnvelope := &cb.ConfigEnvelope{
+		Config:     &cb.Config{Sequence: 3},
+		LastUpdate: lastUpdate,
+	}
+
+	envelope := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_CONFIG),
+					ChannelId: channelID,
+					TxId:      "config-tx-id",
+				}),
+			},
+			Data: protoutil.MarshalOrPanic(configEnvelope),
+		}),
+	}
+
+	block := protoutil.NewBlock(blockNum, nil)
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(envelope)}
+	return block
+}
+
+func TestRecorder(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+	defer log.Close()
+
+	recorder := NewRecorder(log)
+	assert.NoError(t, recorder.Record(newConfigBlock(t, "testchannel", 7)))
+
+	entries, err := log.Entries("testchannel")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, uint64(7), entries[0].Block)
+	assert.Equal(t, uint64(3), entries[0].ConfigSeq)
+	assert.Equal(t, "config-tx-id", entries[0].TxID)
+	assert.Equal(t, []string{"SampleOrg"}, entries[0].Submitters)
+	assert.Equal(t, []string{"Channel/Orderer/BatchSize"}, entries[0].Summary)
+}
+
+func TestRecorderRejectsNonConfigBlock(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+	defer log.Close()
+
+	envelope := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+					ChannelId: "testchannel",
+				}),
+			},
+		}),
+	}
+	block := protoutil.NewBlock(1, nil)
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(envelope)}
+
+	err := NewRecorder(log).Record(block)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected config transaction type")
+}
+
+func TestHandler(t *testing.T) {
+	log, baseDir := newTestLog(t)
+	defer os.RemoveAll(baseDir)
+	defer log.Close()
+
+	assert.NoError(t, log.Append(&Entry{Channel: "testchannel", Block: 1}))
+	assert.NoError(t, log.Append(&Entry{Channel: "otherchannel", Block: 2}))
+
+	handler := NewHandler(log)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest("GET", "/audit/configtx", nil))
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+	var entries []*Entry
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &entries))
+	assert.Len(t, entries, 2)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest("GET", "/audit/configtx?channel=testchannel", nil))
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &entries))
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "testchannel", entries[0].Channel)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest("POST", "/audit/configtx", nil))
+	assert.Equal(t, 405, resp.Code)
+}
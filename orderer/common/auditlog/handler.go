@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auditlog
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler serves the entries of an audit log over HTTP for the operations
+// API. Entries may be filtered by the "channel" query parameter.
+type Handler struct {
+	Log *Log
+}
+
+// NewHandler creates a Handler serving the given log.
+func NewHandler(log *Log) *Handler {
+	return &Handler{Log: log}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries, err := h.Log.Entries(req.URL.Query().Get("channel"))
+	if err != nil {
+		logger.Warningf("Failed to read audit log: %s", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if entries == nil {
+		entries = []*Entry{}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(entries); err != nil {
+		logger.Warningf("Failed to encode audit log entries: %s", err)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auditlog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Recorder decodes committed config blocks into audit log entries.
+type Recorder struct {
+	log *Log
+}
+
+// NewRecorder creates a Recorder appending to the given log.
+func NewRecorder(log *Log) *Recorder {
+	return &Recorder{log: log}
+}
+
+// Record appends an entry describing the config transaction contained in the
+// block. It is invoked by the block writer for every committed config block.
+func (r *Recorder) Record(block *cb.Block) error {
+	envelope, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract config transaction")
+	}
+
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal config transaction payload")
+	}
+	if payload.Header == nil {
+		return errors.New("config transaction payload header is missing")
+	}
+
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal channel header")
+	}
+
+	entry := &Entry{
+		Channel: chdr.ChannelId,
+		Block:   block.Header.Number,
+		TxID:    chdr.TxId,
+	}
+
+	switch chdr.Type {
+	case int32(cb.HeaderType_CONFIG):
+		configEnvelope := &cb.ConfigEnvelope{}
+		if err := proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+			return errors.WithMessage(err, "failed to unmarshal config envelope")
+		}
+		if configEnvelope.Config != nil {
+			entry.ConfigSeq = configEnvelope.Config.Sequence
+		}
+		if configEnvelope.LastUpdate != nil {
+			configUpdateEnvelope, err := protoutil.EnvelopeToConfigUpdate(configEnvelope.LastUpdate)
+			if err != nil {
+				return errors.WithMessage(err, "failed to unmarshal config update")
+			}
+			entry.Submitters = submitterOrgs(configUpdateEnvelope)
+
+			configUpdate := &cb.ConfigUpdate{}
+			if err := proto.Unmarshal(configUpdateEnvelope.ConfigUpdate, configUpdate); err != nil {
+				return errors.WithMessage(err, "failed to unmarshal config update")
+			}
+			entry.Summary = summarizeConfigUpdate(configUpdate)
+		}
+
+	case int32(cb.HeaderType_ORDERER_TRANSACTION):
+		// a new channel is being created through the system channel
+		newChannelEnvelope, err := protoutil.UnmarshalEnvelope(payload.Data)
+		if err != nil {
+			return errors.WithMessage(err, "failed to unmarshal new channel transaction")
+		}
+		newChannelID, err := protoutil.ChannelID(newChannelEnvelope)
+		if err != nil {
+			return errors.WithMessage(err, "failed to extract new channel ID")
+		}
+		entry.Summary = []string{fmt.Sprintf("created channel %s", newChannelID)}
+
+	default:
+		return errors.Errorf("unexpected config transaction type: %v", chdr.Type)
+	}
+
+	return r.log.Append(entry)
+}
+
+// submitterOrgs returns the sorted MSP IDs of the organizations that signed
+// the config update.
+func submitterOrgs(configUpdateEnvelope *cb.ConfigUpdateEnvelope) []string {
+	orgSet := map[string]struct{}{}
+	for _, configSignature := range configUpdateEnvelope.Signatures {
+		shdr, err := protoutil.UnmarshalSignatureHeader(configSignature.SignatureHeader)
+		if err != nil {
+			continue
+		}
+		sid, err := protoutil.UnmarshalSerializedIdentity(shdr.Creator)
+		if err != nil {
+			continue
+		}
+		orgSet[sid.Mspid] = struct{}{}
+	}
+
+	var orgs []string
+	for org := range orgSet {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+	return orgs
+}
+
+// summarizeConfigUpdate lists the paths of the groups and values the config
+// update writes, e.g. "Channel/Orderer/BatchSize".
+func summarizeConfigUpdate(configUpdate *cb.ConfigUpdate) []string {
+	var summary []string
+	summarizeConfigGroup("Channel", configUpdate.WriteSet, &summary)
+	sort.Strings(summary)
+	return summary
+}
+
+func summarizeConfigGroup(path string, group *cb.ConfigGroup, summary *[]string) {
+	if group == nil {
+		return
+	}
+	if len(group.Groups) == 0 && len(group.Values) == 0 && len(group.Policies) == 0 {
+		*summary = append(*summary, path)
+		return
+	}
+	for name, child := range group.Groups {
+		summarizeConfigGroup(path+"/"+name, child, summary)
+	}
+	for name := range group.Values {
+		*summary = append(*summary, path+"/"+name)
+	}
+	for name := range group.Policies {
+		*summary = append(*summary, path+"/"+name)
+	}
+}
@@ -10,11 +10,14 @@ import (
 	"io"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
 
@@ -56,10 +59,32 @@ type Consenter interface {
 	WaitReady() error
 }
 
+//go:generate counterfeiter -o mock/admitter.go --fake-name Admitter . Admitter
+
+// Admitter decides whether a message submitted by a client organization may
+// enter the ordering pipeline, e.g. based on per-organization rate limits.
+type Admitter interface {
+	Admit(mspID string, size int) error
+}
+
+//go:generate counterfeiter -o mock/deduper.go --fake-name Deduper . Deduper
+
+// Deduper remembers recently ordered transactions so that identical
+// resubmissions can be acknowledged without being ordered again.
+type Deduper interface {
+	Seen(channelID, txID string) bool
+	Record(channelID, txID string)
+}
+
 // Handler is designed to handle connections from Broadcast AB gRPC service
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Admission, when not nil, is consulted before a message is processed.
+	Admission Admitter
+	// Deduplication, when not nil, suppresses re-ordering of normal messages
+	// whose transaction ID was ordered recently.
+	Deduplication Deduper
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -147,6 +172,13 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	}()
 	tracker.BeginValidate()
 
+	if bh.Admission != nil {
+		if err := bh.Admission.Admit(submitterMSPID(msg), proto.Size(msg)); err != nil {
+			logger.Warningf("Rejecting broadcast from %s with SERVICE_UNAVAILABLE: %s", addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+		}
+	}
+
 	chdr, isConfig, processor, err := bh.SupportRegistrar.BroadcastChannelSupport(msg)
 	if chdr != nil {
 		tracker.ChannelID = chdr.ChannelId
@@ -160,6 +192,11 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	if !isConfig {
 		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
+		if bh.Deduplication != nil && chdr.TxId != "" && bh.Deduplication.Seen(chdr.ChannelId, chdr.TxId) {
+			logger.Debugf("[channel: %s] Suppressing duplicate broadcast of txid '%s' from %s", chdr.ChannelId, chdr.TxId, addr)
+			return &ab.BroadcastResponse{Status: cb.Status_SUCCESS, Info: "transaction was already ordered"}
+		}
+
 		configSeq, err := processor.ProcessNormalMsg(msg)
 		if err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
@@ -178,6 +215,10 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
+
+		if bh.Deduplication != nil && chdr.TxId != "" {
+			bh.Deduplication.Record(chdr.ChannelId, chdr.TxId)
+		}
 	} else { // isConfig
 		logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
 
@@ -206,6 +247,26 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
+// submitterMSPID extracts the MSP ID of the identity that created the
+// message. An empty string is returned when the creator cannot be decoded;
+// such messages share a single admission budget and are rejected during
+// validation.
+func submitterMSPID(msg *cb.Envelope) string {
+	payload, err := protoutil.UnmarshalPayload(msg.Payload)
+	if err != nil || payload.Header == nil {
+		return ""
+	}
+	shdr, err := protoutil.UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return ""
+	}
+	sid := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(shdr.Creator, sid); err != nil {
+		return ""
+	}
+	return sid.Mspid
+}
+
 // ClassifyError converts an error type into a status code.
 func ClassifyError(err error) cb.Status {
 	switch errors.Cause(err) {
@@ -128,6 +128,130 @@ var _ = Describe("Broadcast", func() {
 			Expect(proto.Equal(fakeABServer.SendArgsForCall(0), &ab.BroadcastResponse{Status: cb.Status_SUCCESS})).To(BeTrue())
 		})
 
+		Context("when admission control is configured", func() {
+			var fakeAdmitter *mock.Admitter
+
+			BeforeEach(func() {
+				fakeAdmitter = &mock.Admitter{}
+				handler.Admission = fakeAdmitter
+			})
+
+			It("consults the admitter and enqueues the message", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeAdmitter.AdmitCallCount()).To(Equal(1))
+				mspID, size := fakeAdmitter.AdmitArgsForCall(0)
+				Expect(mspID).To(Equal(""))
+				Expect(size).To(Equal(proto.Size(fakeMsg)))
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+			})
+
+			Context("when the admitter refuses the message", func() {
+				BeforeEach(func() {
+					fakeAdmitter.AdmitReturns(fmt.Errorf("over-budget"))
+				})
+
+				It("rejects the message with a service unavailable status", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupportRegistrar.BroadcastChannelSupportCallCount()).To(Equal(0))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "over-budget"}),
+					).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when deduplication is configured", func() {
+			var fakeDeduper *mock.Deduper
+
+			BeforeEach(func() {
+				fakeDeduper = &mock.Deduper{}
+				handler.Deduplication = fakeDeduper
+
+				fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
+					Type:      3,
+					ChannelId: "fake-channel",
+					TxId:      "fake-tx-id",
+				}, false, fakeSupport, nil)
+			})
+
+			It("records the transaction after it is ordered", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeDeduper.SeenCallCount()).To(Equal(1))
+				channelID, txID := fakeDeduper.SeenArgsForCall(0)
+				Expect(channelID).To(Equal("fake-channel"))
+				Expect(txID).To(Equal("fake-tx-id"))
+
+				Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+				Expect(fakeDeduper.RecordCallCount()).To(Equal(1))
+				channelID, txID = fakeDeduper.RecordArgsForCall(0)
+				Expect(channelID).To(Equal("fake-channel"))
+				Expect(txID).To(Equal("fake-tx-id"))
+			})
+
+			Context("when the transaction was ordered recently", func() {
+				BeforeEach(func() {
+					fakeDeduper.SeenReturns(true)
+				})
+
+				It("acknowledges the message without ordering it again", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeSupport.ProcessNormalMsgCallCount()).To(Equal(0))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+					Expect(fakeDeduper.RecordCallCount()).To(Equal(0))
+
+					Expect(fakeABServer.SendCallCount()).To(Equal(1))
+					Expect(proto.Equal(
+						fakeABServer.SendArgsForCall(0),
+						&ab.BroadcastResponse{Status: cb.Status_SUCCESS, Info: "transaction was already ordered"}),
+					).To(BeTrue())
+				})
+			})
+
+			Context("when the message has no transaction ID", func() {
+				BeforeEach(func() {
+					fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
+						Type:      3,
+						ChannelId: "fake-channel",
+					}, false, fakeSupport, nil)
+				})
+
+				It("orders the message without consulting the deduper", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeDeduper.SeenCallCount()).To(Equal(0))
+					Expect(fakeSupport.OrderCallCount()).To(Equal(1))
+					Expect(fakeDeduper.RecordCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the consenter rejects the message", func() {
+				BeforeEach(func() {
+					fakeSupport.OrderReturns(fmt.Errorf("consenter-error"))
+				})
+
+				It("does not record the transaction", func() {
+					err := handler.Handle(fakeABServer)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeDeduper.RecordCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the channel support cannot be retrieved", func() {
 			BeforeEach(func() {
 				fakeSupportRegistrar.BroadcastChannelSupportReturns(&cb.ChannelHeader{
@@ -121,6 +121,17 @@ func (dialer *PredicateDialer) UpdateRootCAs(serverRootCAs [][]byte) {
 	dialer.Config.SecOpts.ServerRootCAs = serverRootCAs
 }
 
+// UpdateClientCertificate changes the client TLS certificate and key used for
+// subsequently created connections, so a renewed certificate is picked up
+// without restarting the node. Existing connections keep the certificate they
+// were established with until they reconnect.
+func (dialer *PredicateDialer) UpdateClientCertificate(certPEM, keyPEM []byte) {
+	dialer.lock.Lock()
+	defer dialer.lock.Unlock()
+	dialer.Config.SecOpts.Certificate = certPEM
+	dialer.Config.SecOpts.Key = keyPEM
+}
+
 // Dial creates a new gRPC connection that can only be established, if the remote node's
 // certificate chain satisfy verifyFunc
 func (dialer *PredicateDialer) Dial(address string, verifyFunc RemoteVerifier) (*grpc.ClientConn, error) {
@@ -107,6 +107,18 @@ func TestDialerCustomKeepAliveOptions(t *testing.T) {
 	assert.Equal(t, time.Second*12345, timeout)
 }
 
+func TestPredicateDialerUpdateClientCertificate(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	keyPair, err := ca.NewClientCertKeyPair()
+	assert.NoError(t, err)
+
+	dialer := &cluster.PredicateDialer{}
+	dialer.UpdateClientCertificate(keyPair.Cert, keyPair.Key)
+	assert.Equal(t, keyPair.Cert, dialer.Config.SecOpts.Certificate)
+	assert.Equal(t, keyPair.Key, dialer.Config.SecOpts.Key)
+}
+
 func TestPredicateDialerUpdateRootCAs(t *testing.T) {
 	node1 := newTestNode(t)
 	defer node1.stop()
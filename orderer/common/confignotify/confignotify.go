@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package confignotify pushes committed config blocks to interested clients
+// ahead of regular Deliver traffic. Deliver streams replay the ledger in
+// order, so a client working through a backlog of normal blocks learns about
+// membership or endpoint changes only once it has caught up. The notifier
+// provides a dedicated long-poll stream over the operations API that surfaces
+// the latest committed config block of a channel as soon as it is written.
+package confignotify
+
+import (
+	"sync"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+var logger = flogging.MustGetLogger("orderer.common.confignotify")
+
+// Notifier retains the latest committed config block of every channel and
+// wakes up the clients waiting for one.
+type Notifier struct {
+	mutex   sync.Mutex
+	latest  map[string]*cb.Block
+	waiters map[string][]chan *cb.Block
+}
+
+// NewNotifier creates an empty Notifier.
+func NewNotifier() *Notifier {
+	return &Notifier{
+		latest:  make(map[string]*cb.Block),
+		waiters: make(map[string][]chan *cb.Block),
+	}
+}
+
+// Notify records block as the latest committed config block of the channel
+// and delivers it to all pending waiters.
+func (n *Notifier) Notify(channel string, block *cb.Block) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	n.latest[channel] = block
+	for _, waiter := range n.waiters[channel] {
+		waiter <- block
+	}
+	delete(n.waiters, channel)
+}
+
+// Latest returns the latest config block committed to the channel since
+// startup, or nil if none was committed.
+func (n *Notifier) Latest(channel string) *cb.Block {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	return n.latest[channel]
+}
+
+// wait returns a buffered channel that receives the next config block
+// committed to the channel.
+func (n *Notifier) wait(channel string) <-chan *cb.Block {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	waiter := make(chan *cb.Block, 1)
+	n.waiters[channel] = append(n.waiters[channel], waiter)
+	return waiter
+}
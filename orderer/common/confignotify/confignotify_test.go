@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confignotify
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifier(t *testing.T) {
+	notifier := NewNotifier()
+	assert.Nil(t, notifier.Latest("mychannel"))
+
+	waiter := notifier.wait("mychannel")
+	block := protoutil.NewBlock(7, nil)
+	notifier.Notify("mychannel", block)
+
+	assert.Equal(t, block, notifier.Latest("mychannel"))
+	assert.Nil(t, notifier.Latest("otherchannel"))
+	select {
+	case received := <-waiter:
+		assert.Equal(t, block, received)
+	default:
+		t.Fatal("waiter was not notified")
+	}
+}
+
+func TestHandlerReturnsLatest(t *testing.T) {
+	notifier := NewNotifier()
+	notifier.Notify("mychannel", protoutil.NewBlock(7, nil))
+	handler := NewHandler(notifier)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify?channel=mychannel", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	notification := &Notification{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), notification))
+	assert.Equal(t, "mychannel", notification.Channel)
+	assert.Equal(t, uint64(7), notification.Block)
+
+	raw, err := base64.StdEncoding.DecodeString(notification.Data)
+	require.NoError(t, err)
+	block := &cb.Block{}
+	require.NoError(t, proto.Unmarshal(raw, block))
+	assert.Equal(t, uint64(7), block.Header.Number)
+}
+
+func TestHandlerLongPoll(t *testing.T) {
+	notifier := NewNotifier()
+	notifier.Notify("mychannel", protoutil.NewBlock(7, nil))
+	handler := NewHandler(notifier)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		notifier.Notify("mychannel", protoutil.NewBlock(8, nil))
+	}()
+
+	// The latest block (7) is not newer than "after", so the request blocks
+	// until block 8 is committed.
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify?channel=mychannel&after=7&wait=10s", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	notification := &Notification{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), notification))
+	assert.Equal(t, uint64(8), notification.Block)
+}
+
+func TestHandlerTimeout(t *testing.T) {
+	handler := NewHandler(NewNotifier())
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify?channel=mychannel&wait=10ms", nil))
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+}
+
+func TestHandlerBadRequest(t *testing.T) {
+	handler := NewHandler(NewNotifier())
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify?channel=mychannel&after=x", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/confignotify?channel=mychannel&wait=x", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/confignotify?channel=mychannel", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package confignotify
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+const (
+	defaultWait = 30 * time.Second
+	maxWait     = 5 * time.Minute
+)
+
+// Notification is the JSON document returned for a committed config block.
+type Notification struct {
+	Channel string `json:"channel"`
+	Block   uint64 `json:"block"`
+	// Data is the base64 encoding of the marshaled config block.
+	Data string `json:"data"`
+}
+
+// Handler serves config block notifications over HTTP for the operations
+// API. A request selects a channel with the "channel" query parameter, and
+// long-polls until a config block newer than the "after" query parameter is
+// committed, up to the duration given by the "wait" query parameter.
+type Handler struct {
+	Notifier *Notifier
+}
+
+// NewHandler creates a Handler serving the given notifier.
+func NewHandler(notifier *Notifier) *Handler {
+	return &Handler{Notifier: notifier}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	channel := req.URL.Query().Get("channel")
+	if channel == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		resp.Write([]byte("channel is required\n"))
+		return
+	}
+
+	var after uint64
+	var haveAfter bool
+	if v := req.URL.Query().Get("after"); v != "" {
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			resp.WriteHeader(http.StatusBadRequest)
+			resp.Write([]byte("after must be a block number\n"))
+			return
+		}
+		after = parsed
+		haveAfter = true
+	}
+
+	wait := defaultWait
+	if v := req.URL.Query().Get("wait"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed < 0 {
+			resp.WriteHeader(http.StatusBadRequest)
+			resp.Write([]byte("wait must be a duration\n"))
+			return
+		}
+		if parsed > maxWait {
+			parsed = maxWait
+		}
+		wait = parsed
+	}
+
+	// Register the waiter before consulting the latest block, so a config
+	// block committed in between the two is not missed.
+	waiter := h.Notifier.wait(channel)
+	if block := h.Notifier.Latest(channel); block != nil && (!haveAfter || block.Header.Number > after) {
+		writeNotification(resp, channel, block)
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case block := <-waiter:
+			if haveAfter && block.Header.Number <= after {
+				waiter = h.Notifier.wait(channel)
+				continue
+			}
+			writeNotification(resp, channel, block)
+			return
+		case <-timer.C:
+			resp.WriteHeader(http.StatusNoContent)
+			return
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+func writeNotification(resp http.ResponseWriter, channel string, block *cb.Block) {
+	raw, err := proto.Marshal(block)
+	if err != nil {
+		logger.Warningf("Failed to marshal config block of channel %s: %s", channel, err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	notification := &Notification{
+		Channel: channel,
+		Block:   block.Header.Number,
+		Data:    base64.StdEncoding.EncodeToString(raw),
+	}
+	if err := json.NewEncoder(resp).Encode(notification); err != nil {
+		logger.Warningf("Failed to encode config block notification: %s", err)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package dedupe implements a suppression window for duplicate transaction
+// submissions on Broadcast. Clients that retry aggressively may submit the
+// same transaction several times; ordering every copy produces blocks full of
+// duplicates that validate as such on peers. The cache remembers the
+// transaction IDs of recently ordered messages, so that identical
+// resubmissions within the window can be acknowledged without being ordered
+// again.
+package dedupe
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache remembers the transaction IDs of recently ordered messages. It is
+// safe for concurrent use.
+type Cache struct {
+	window time.Duration
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	entries   map[string]time.Time
+	lastSweep time.Time
+}
+
+// New creates a Cache with the given suppression window.
+func New(window time.Duration) *Cache {
+	return &Cache{
+		window:  window,
+		now:     time.Now,
+		entries: map[string]time.Time{},
+	}
+}
+
+// SetWindow changes the suppression window at runtime. Entries recorded
+// earlier are aged against the new window; a zero window suppresses nothing.
+func (c *Cache) SetWindow(window time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.window = window
+}
+
+// Seen returns true if the transaction was recorded within the suppression
+// window.
+func (c *Cache) Seen(channelID, txID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	orderedAt, ok := c.entries[channelID+"/"+txID]
+	return ok && c.now().Sub(orderedAt) < c.window
+}
+
+// Record remembers that the transaction was ordered. Entries older than the
+// suppression window are swept out periodically so that the cache size stays
+// proportional to the recent transaction volume.
+func (c *Cache) Record(channelID, txID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	if now.Sub(c.lastSweep) >= c.window {
+		for key, orderedAt := range c.entries {
+			if now.Sub(orderedAt) >= c.window {
+				delete(c.entries, key)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	c.entries[channelID+"/"+txID] = now
+}
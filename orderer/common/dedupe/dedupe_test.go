@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dedupe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock provides a controllable time source for the cache.
+type fakeClock struct {
+	current time.Time
+}
+
+func (fc *fakeClock) now() time.Time {
+	return fc.current
+}
+
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.current = fc.current.Add(d)
+}
+
+func newTestCache(window time.Duration) (*Cache, *fakeClock) {
+	clock := &fakeClock{current: time.Now()}
+	cache := New(window)
+	cache.now = clock.now
+	return cache, clock
+}
+
+func TestSeenWithinWindow(t *testing.T) {
+	cache, clock := newTestCache(time.Minute)
+
+	assert.False(t, cache.Seen("testchannel", "tx-1"))
+
+	cache.Record("testchannel", "tx-1")
+	assert.True(t, cache.Seen("testchannel", "tx-1"))
+
+	clock.advance(59 * time.Second)
+	assert.True(t, cache.Seen("testchannel", "tx-1"))
+
+	clock.advance(time.Second)
+	assert.False(t, cache.Seen("testchannel", "tx-1"))
+}
+
+func TestSeenIsScopedToChannel(t *testing.T) {
+	cache, _ := newTestCache(time.Minute)
+
+	cache.Record("testchannel", "tx-1")
+	assert.True(t, cache.Seen("testchannel", "tx-1"))
+	assert.False(t, cache.Seen("otherchannel", "tx-1"))
+}
+
+func TestExpiredEntriesAreSwept(t *testing.T) {
+	cache, clock := newTestCache(time.Minute)
+
+	cache.Record("testchannel", "tx-1")
+	cache.Record("testchannel", "tx-2")
+	assert.Len(t, cache.entries, 2)
+
+	// recording after the window has elapsed sweeps out the expired entries
+	clock.advance(time.Minute)
+	cache.Record("testchannel", "tx-3")
+	assert.Len(t, cache.entries, 1)
+	assert.True(t, cache.Seen("testchannel", "tx-3"))
+}
+
+func TestSetWindow(t *testing.T) {
+	cache, clock := newTestCache(time.Minute)
+
+	cache.Record("testchannel", "tx-1")
+	assert.True(t, cache.Seen("testchannel", "tx-1"))
+
+	// shrinking the window ages out older entries
+	clock.advance(30 * time.Second)
+	cache.SetWindow(10 * time.Second)
+	assert.False(t, cache.Seen("testchannel", "tx-1"))
+
+	// a zero window suppresses nothing
+	cache.SetWindow(0)
+	cache.Record("testchannel", "tx-2")
+	assert.False(t, cache.Seen("testchannel", "tx-2"))
+}
@@ -0,0 +1,187 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package endpointpub maintains a discovery-compatible record of the orderer
+// endpoints and TLS root CAs of every channel this node services. The record
+// is refreshed on every committed config transaction and published through
+// the operations API, so gateways and peers can learn about added or removed
+// consenters right after a topology change, instead of broadcasting to stale
+// endpoint lists until their client-side config refresh catches up.
+package endpointpub
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("orderer.common.endpointpub")
+
+// Endpoint is a single orderer address, split into the host and port form
+// used by the discovery protocol.
+type Endpoint struct {
+	Host string `json:"host"`
+	Port uint32 `json:"port"`
+}
+
+// OrgOrderers holds the endpoints and TLS root CAs of a single orderer
+// organization.
+type OrgOrderers struct {
+	Endpoints []Endpoint `json:"endpoints"`
+	// TLSRootCAs are the PEM encoded TLS root and intermediate CA
+	// certificates a client needs to verify connections to the endpoints.
+	TLSRootCAs []string `json:"tls_root_cas,omitempty"`
+}
+
+// Record mirrors the orderer portion of the discovery protocol's
+// ConfigResult: orderer endpoints keyed by MSP ID, augmented with the TLS
+// CAs needed to connect to them and the config sequence they were derived
+// from.
+type Record struct {
+	Channel        string `json:"channel"`
+	ConfigSequence uint64 `json:"config_sequence"`
+	// GlobalEndpoints carries the legacy channel-level orderer addresses of
+	// channels that predate org-specific endpoints.
+	GlobalEndpoints []Endpoint              `json:"global_endpoints,omitempty"`
+	Orderers        map[string]*OrgOrderers `json:"orderers"`
+	LastUpdated     time.Time               `json:"last_updated"`
+}
+
+// Publisher derives the endpoint record of every channel from its config
+// bundle and keeps it current as config blocks commit. It is safe for
+// concurrent use.
+type Publisher struct {
+	now func() time.Time
+
+	mutex   sync.RWMutex
+	records map[string]*Record
+}
+
+// NewPublisher creates an empty Publisher.
+func NewPublisher() *Publisher {
+	return &Publisher{
+		now:     time.Now,
+		records: map[string]*Record{},
+	}
+}
+
+// Update publishes the record of the channel described by the given
+// resources. It is intended to be invoked from the config bundle callback,
+// so that every committed config transaction refreshes the record.
+func (p *Publisher) Update(res channelconfig.Resources) {
+	channelID := res.ConfigtxValidator().ChannelID()
+	record := &Record{
+		Channel:        channelID,
+		ConfigSequence: res.ConfigtxValidator().Sequence(),
+		Orderers:       map[string]*OrgOrderers{},
+		LastUpdated:    p.now(),
+	}
+
+	for _, address := range res.ChannelConfig().OrdererAddresses() {
+		endpoint, err := parseEndpoint(address)
+		if err != nil {
+			logger.Warningf("Skipping global orderer endpoint of channel %s: %s", channelID, err)
+			continue
+		}
+		record.GlobalEndpoints = append(record.GlobalEndpoints, endpoint)
+	}
+
+	oc, ok := res.OrdererConfig()
+	if !ok {
+		logger.Warningf("Channel %s has no orderer config, not publishing orderer endpoints", channelID)
+		return
+	}
+
+	tlsCAs := tlsRootCAsByMSPID(res)
+	for _, org := range oc.Organizations() {
+		mspID := org.MSPID()
+		orgOrderers, ok := record.Orderers[mspID]
+		if !ok {
+			orgOrderers = &OrgOrderers{TLSRootCAs: tlsCAs[mspID]}
+			record.Orderers[mspID] = orgOrderers
+		}
+		for _, address := range org.Endpoints() {
+			endpoint, err := parseEndpoint(address)
+			if err != nil {
+				logger.Warningf("Skipping orderer endpoint of org %s on channel %s: %s", mspID, channelID, err)
+				continue
+			}
+			orgOrderers.Endpoints = append(orgOrderers.Endpoints, endpoint)
+		}
+	}
+
+	p.mutex.Lock()
+	p.records[channelID] = record
+	p.mutex.Unlock()
+	logger.Debugf("Published orderer endpoints of channel %s at config sequence %d", channelID, record.ConfigSequence)
+}
+
+// Record returns the published record of a single channel, or nil if the
+// channel is unknown.
+func (p *Publisher) Record(channelID string) *Record {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.records[channelID]
+}
+
+// Records returns the published records of all channels, sorted by channel
+// name.
+func (p *Publisher) Records() []*Record {
+	p.mutex.RLock()
+	records := make([]*Record, 0, len(p.records))
+	for _, record := range p.records {
+		records = append(records, record)
+	}
+	p.mutex.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Channel < records[j].Channel
+	})
+	return records
+}
+
+// tlsRootCAsByMSPID collects the PEM encoded TLS root and intermediate CA
+// certificates of every MSP on the channel, keyed by MSP ID.
+func tlsRootCAsByMSPID(res channelconfig.Resources) map[string][]string {
+	msps, err := res.MSPManager().GetMSPs()
+	if err != nil {
+		logger.Warningf("Failed retrieving MSPs of channel %s: %s", res.ConfigtxValidator().ChannelID(), err)
+		return nil
+	}
+
+	cas := map[string][]string{}
+	for mspID, m := range msps {
+		if m.GetType() != msp.FABRIC {
+			continue
+		}
+		for _, root := range m.GetTLSRootCerts() {
+			cas[mspID] = append(cas[mspID], string(root))
+		}
+		for _, intermediate := range m.GetTLSIntermediateCerts() {
+			cas[mspID] = append(cas[mspID], string(intermediate))
+		}
+	}
+	return cas
+}
+
+func parseEndpoint(address string) (Endpoint, error) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return Endpoint{}, errors.Errorf("invalid endpoint %s: %s", address, err)
+	}
+	port, err := strconv.ParseUint(portString, 10, 32)
+	if err != nil {
+		return Endpoint{}, errors.Errorf("invalid port in endpoint %s", address)
+	}
+	return Endpoint{Host: host, Port: uint32(port)}, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endpointpub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBundle(t *testing.T, channelID string) *channelconfig.Bundle {
+	gConf := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile, configtest.GetDevConfigDir())
+	gConf.Orderer.Capabilities = map[string]bool{
+		capabilities.OrdererV2_0: true,
+	}
+	channelGroup, err := encoder.NewChannelGroup(gConf)
+	require.NoError(t, err)
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	bundle, err := channelconfig.NewBundle(channelID, &cb.Config{ChannelGroup: channelGroup}, cryptoProvider)
+	require.NoError(t, err)
+	return bundle
+}
+
+func TestPublisherUpdate(t *testing.T) {
+	publisher := NewPublisher()
+	publisher.Update(testBundle(t, "mychannel"))
+
+	record := publisher.Record("mychannel")
+	require.NotNil(t, record)
+	assert.Equal(t, "mychannel", record.Channel)
+	assert.False(t, record.LastUpdated.IsZero())
+
+	org := record.Orderers["SampleOrg"]
+	require.NotNil(t, org)
+	assert.Equal(t, []Endpoint{{Host: "127.0.0.1", Port: 7050}}, org.Endpoints)
+	assert.NotEmpty(t, org.TLSRootCAs)
+
+	assert.Nil(t, publisher.Record("unknown"))
+}
+
+func TestPublisherRecordsSorted(t *testing.T) {
+	publisher := NewPublisher()
+	publisher.Update(testBundle(t, "zebra"))
+	publisher.Update(testBundle(t, "aardvark"))
+
+	records := publisher.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, "aardvark", records[0].Channel)
+	assert.Equal(t, "zebra", records[1].Channel)
+}
+
+func TestParseEndpoint(t *testing.T) {
+	endpoint, err := parseEndpoint("orderer.example.com:7050")
+	assert.NoError(t, err)
+	assert.Equal(t, Endpoint{Host: "orderer.example.com", Port: 7050}, endpoint)
+
+	_, err = parseEndpoint("no-port")
+	assert.Contains(t, err.Error(), "invalid endpoint no-port")
+
+	_, err = parseEndpoint("host:not-a-port")
+	assert.EqualError(t, err, "invalid port in endpoint host:not-a-port")
+}
+
+func TestHandler(t *testing.T) {
+	publisher := NewPublisher()
+	publisher.Update(testBundle(t, "mychannel"))
+	handler := NewHandler(publisher)
+
+	resp := httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/discovery/orderers", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"channel":"mychannel"`)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/discovery/orderers?channel=mychannel", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"orderers"`)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/discovery/orderers?channel=unknown", nil))
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+
+	resp = httptest.NewRecorder()
+	handler.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/discovery/orderers", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+}
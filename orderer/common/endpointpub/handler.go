@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endpointpub
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Handler serves the published orderer endpoint records over HTTP for the
+// operations API. A single channel may be selected with the "channel" query
+// parameter.
+type Handler struct {
+	Publisher *Publisher
+}
+
+// NewHandler creates a Handler serving the records of the given publisher.
+func NewHandler(publisher *Publisher) *Handler {
+	return &Handler{Publisher: publisher}
+}
+
+func (h *Handler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body interface{}
+	if channelID := req.URL.Query().Get("channel"); channelID != "" {
+		record := h.Publisher.Record(channelID)
+		if record == nil {
+			resp.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body = record
+	} else {
+		body = h.Publisher.Records()
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(body); err != nil {
+		logger.Warningf("Failed to encode orderer endpoint records: %s", err)
+	}
+}
@@ -39,6 +39,16 @@ type TopLevel struct {
 	Metrics    Metrics
 }
 
+// Roles a node may assume on the channels it serves.
+const (
+	// RoleVoter nodes participate in consensus for channels they are
+	// consenters of.
+	RoleVoter = "voter"
+	// RoleReplica nodes follow channels and serve Deliver traffic, but never
+	// participate in consensus.
+	RoleReplica = "replica"
+)
+
 // General contains config which should be common among all orderer types.
 type General struct {
 	ListenAddress     string
@@ -51,11 +61,39 @@ type General struct {
 	GenesisFile       string // For compatibility only, will be replaced by BootstrapFile
 	BootstrapMethod   string
 	BootstrapFile     string
-	Profile           Profile
-	LocalMSPDir       string
-	LocalMSPID        string
-	BCCSP             *bccsp.FactoryOpts
-	Authentication    Authentication
+	// Role selects whether this node participates in consensus for the
+	// channels it serves ("voter", the default) or merely follows them and
+	// serves Deliver traffic ("replica").
+	Role        string
+	Profile     Profile
+	LocalMSPDir string
+	LocalMSPID  string
+	// AdditionalBlockSigners lists further local MSPs whose default signing
+	// identities co-sign every committed block, in addition to the local MSP.
+	AdditionalBlockSigners []BlockSigner
+	BCCSP                  *bccsp.FactoryOpts
+	Authentication         Authentication
+	// FIPS restricts BCCSP, TLS, and hashing to FIPS-approved algorithms
+	// and refuses non-compliant channel configurations. It requires a
+	// binary built with the fips build tag.
+	FIPS       bool
+	RateLimits RateLimits
+	Dedupe     Dedupe
+	Audit      Audit
+	Renewal    Renewal
+}
+
+// Renewal configures the agent that re-enrolls the orderer's certificates
+// against a Fabric CA before they expire.
+type Renewal struct {
+	Enabled          bool
+	CAURL            string
+	CAName           string
+	CATLSRootCert    string
+	EnrollmentID     string
+	EnrollmentSecret string
+	Window           time.Duration
+	CheckInterval    time.Duration
 }
 
 type Cluster struct {
@@ -68,6 +106,10 @@ type Cluster struct {
 	RootCAs                              []string
 	DialTimeout                          time.Duration
 	RPCTimeout                           time.Duration
+	ClientKeepaliveInterval              time.Duration
+	ClientKeepaliveTimeout               time.Duration
+	MaxRecvMsgSize                       int
+	MaxSendMsgSize                       int
 	ReplicationBufferSize                int
 	ReplicationPullTimeout               time.Duration
 	ReplicationRetryTimeout              time.Duration
@@ -89,6 +131,7 @@ type Keepalive struct {
 type TLS struct {
 	Enabled            bool
 	PrivateKey         string
+	PrivateKeySKI      string
 	Certificate        string
 	RootCAs            []string
 	ClientAuthRequired bool
@@ -109,6 +152,49 @@ type Authentication struct {
 	NoExpirationChecks bool
 }
 
+// RateLimits contains configuration for per-organization broadcast admission
+// control.
+type RateLimits struct {
+	Enabled               bool
+	DefaultTxPerSecond    int
+	DefaultBytesPerSecond int
+	Orgs                  map[string]OrgRateLimit
+}
+
+// OrgRateLimit overrides the default broadcast rate limits for a single
+// organization, keyed by MSP ID. A zero value means unlimited.
+type OrgRateLimit struct {
+	TxPerSecond    int
+	BytesPerSecond int
+}
+
+// BlockSigner identifies an additional local MSP whose default signing
+// identity co-signs every committed block, so that blocks carry multiple
+// signatures for external verifiers. The signature threshold a verifier
+// demands is governed by the channel's BlockValidation policy.
+type BlockSigner struct {
+	MSPDir string
+	MSPID  string
+}
+
+// Audit contains configuration for the tamper-evident audit log of committed
+// config transactions. The log is exposed for querying via the operations
+// API.
+type Audit struct {
+	Enabled bool
+	// Path is the file the audit log is appended to. It must be set when the
+	// audit log is enabled.
+	Path string
+}
+
+// Dedupe contains configuration for suppression of duplicate transaction
+// submissions on Broadcast. Resubmissions of a transaction ID that was
+// ordered within the window are acknowledged without being ordered again.
+type Dedupe struct {
+	Enabled bool
+	Window  time.Duration
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -212,6 +298,7 @@ var Defaults = TopLevel{
 		ListenPort:      7050,
 		BootstrapMethod: "file",
 		BootstrapFile:   "genesisblock",
+		Role:            RoleVoter,
 		Profile: Profile{
 			Enabled: false,
 			Address: "0.0.0.0:6060",
@@ -226,6 +313,10 @@ var Defaults = TopLevel{
 			ReplicationRetryTimeout:              time.Second * 5,
 			ReplicationPullTimeout:               time.Second * 5,
 			CertExpirationWarningThreshold:       time.Hour * 24 * 7,
+			ClientKeepaliveInterval:              time.Minute,
+			ClientKeepaliveTimeout:               time.Second * 20,
+			MaxRecvMsgSize:                       104857600, // 100 MiB
+			MaxSendMsgSize:                       104857600, // 100 MiB
 		},
 		LocalMSPDir: "msp",
 		LocalMSPID:  "SampleOrg",
@@ -233,6 +324,13 @@ var Defaults = TopLevel{
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
+		Dedupe: Dedupe{
+			Window: 2 * time.Minute,
+		},
+		Renewal: Renewal{
+			Window:        time.Hour * 24 * 30,
+			CheckInterval: time.Hour,
+		},
 	},
 	FileLedger: FileLedger{
 		Location: "/var/hyperledger/production/orderer",
@@ -288,6 +386,13 @@ func Load() (*TopLevel, error) {
 	return cache.load()
 }
 
+// Reload re-reads the orderer YAML file and environment, bypassing the
+// cache, so that edits made after startup are observed. The cache is
+// refreshed, so subsequent Load calls return the new configuration.
+func Reload() (*TopLevel, error) {
+	return cache.reload()
+}
+
 // configCache stores marshalled bytes of config structures that produced from
 // EnhancedExactUnmarshal. Cache key is the path of the configuration file that was used.
 type configCache struct {
@@ -297,6 +402,14 @@ type configCache struct {
 
 var cache = &configCache{}
 
+// reload drops the cached configuration and loads it anew.
+func (c *configCache) reload() (*TopLevel, error) {
+	c.mutex.Lock()
+	c.cache = nil
+	c.mutex.Unlock()
+	return c.load()
+}
+
 // Load will load the configuration and cache it on the first call; subsequent
 // calls will return a clone of the configuration that was previously loaded.
 func (c *configCache) load() (*TopLevel, error) {
@@ -359,6 +472,15 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		coreconfig.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.BootstrapFile)
 		coreconfig.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		for i := range c.General.AdditionalBlockSigners {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.AdditionalBlockSigners[i].MSPDir)
+		}
+		if c.General.Audit.Path != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.Audit.Path)
+		}
+		if c.General.Renewal.CATLSRootCert != "" {
+			coreconfig.TranslatePathInPlace(configDir, &c.General.Renewal.CATLSRootCert)
+		}
 		// Translate file ledger location
 		coreconfig.TranslatePathInPlace(configDir, &c.FileLedger.Location)
 	}()
@@ -387,6 +509,11 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			} else {
 				c.General.BootstrapFile = Defaults.General.BootstrapFile
 			}
+		case c.General.Role == "":
+			logger.Infof("General.Role unset, setting to %s", Defaults.General.Role)
+			c.General.Role = Defaults.General.Role
+		case c.General.Role != RoleVoter && c.General.Role != RoleReplica:
+			logger.Panicf("General.Role must be either %q or %q: %q", RoleVoter, RoleReplica, c.General.Role)
 		case c.General.Cluster.RPCTimeout == 0:
 			c.General.Cluster.RPCTimeout = Defaults.General.Cluster.RPCTimeout
 		case c.General.Cluster.DialTimeout == 0:
@@ -405,6 +532,14 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			c.General.Cluster.ReplicationBackgroundRefreshInterval = Defaults.General.Cluster.ReplicationBackgroundRefreshInterval
 		case c.General.Cluster.CertExpirationWarningThreshold == 0:
 			c.General.Cluster.CertExpirationWarningThreshold = Defaults.General.Cluster.CertExpirationWarningThreshold
+		case c.General.Cluster.ClientKeepaliveInterval == 0:
+			c.General.Cluster.ClientKeepaliveInterval = Defaults.General.Cluster.ClientKeepaliveInterval
+		case c.General.Cluster.ClientKeepaliveTimeout == 0:
+			c.General.Cluster.ClientKeepaliveTimeout = Defaults.General.Cluster.ClientKeepaliveTimeout
+		case c.General.Cluster.MaxRecvMsgSize == 0:
+			c.General.Cluster.MaxRecvMsgSize = Defaults.General.Cluster.MaxRecvMsgSize
+		case c.General.Cluster.MaxSendMsgSize == 0:
+			c.General.Cluster.MaxSendMsgSize = Defaults.General.Cluster.MaxSendMsgSize
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.PrivateKey == "":
@@ -432,6 +567,17 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("General.Authentication.TimeWindow unset, setting to %s", Defaults.General.Authentication.TimeWindow)
 			c.General.Authentication.TimeWindow = Defaults.General.Authentication.TimeWindow
 
+		case c.General.Dedupe.Enabled && c.General.Dedupe.Window == 0:
+			logger.Infof("General.Dedupe.Window unset, setting to %s", Defaults.General.Dedupe.Window)
+			c.General.Dedupe.Window = Defaults.General.Dedupe.Window
+
+		case c.General.Renewal.Enabled && c.General.Renewal.Window == 0:
+			logger.Infof("General.Renewal.Window unset, setting to %s", Defaults.General.Renewal.Window)
+			c.General.Renewal.Window = Defaults.General.Renewal.Window
+		case c.General.Renewal.Enabled && c.General.Renewal.CheckInterval == 0:
+			logger.Infof("General.Renewal.CheckInterval unset, setting to %s", Defaults.General.Renewal.CheckInterval)
+			c.General.Renewal.CheckInterval = Defaults.General.Renewal.CheckInterval
+
 		case c.FileLedger.Prefix == "":
 			logger.Infof("FileLedger.Prefix unset, setting to %s", Defaults.FileLedger.Prefix)
 			c.FileLedger.Prefix = Defaults.FileLedger.Prefix
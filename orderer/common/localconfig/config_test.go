@@ -181,6 +181,34 @@ func TestKafkaSASLPlain(t *testing.T) {
 	}
 }
 
+func TestRole(t *testing.T) {
+	testCases := []struct {
+		name        string
+		role        string
+		shouldPanic bool
+	}{
+		{"Default", "", false},
+		{"Voter", RoleVoter, false},
+		{"Replica", RoleReplica, false},
+		{"Unknown", "observer", true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uconf := &TopLevel{General: General{Role: tc.role}}
+			if tc.shouldPanic {
+				assert.Panics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should panic")
+			} else {
+				assert.NotPanics(t, func() { uconf.completeInitialization("/dummy/path") }, "Should not panic")
+				expected := tc.role
+				if expected == "" {
+					expected = Defaults.General.Role
+				}
+				assert.Equal(t, expected, uconf.General.Role)
+			}
+		})
+	}
+}
+
 func TestClusterDefaults(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -189,6 +217,10 @@ func TestClusterDefaults(t *testing.T) {
 	cfg, err := cc.load()
 	assert.NoError(t, err)
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
+	assert.Equal(t, cfg.General.Cluster.ClientKeepaliveInterval, Defaults.General.Cluster.ClientKeepaliveInterval)
+	assert.Equal(t, cfg.General.Cluster.ClientKeepaliveTimeout, Defaults.General.Cluster.ClientKeepaliveTimeout)
+	assert.Equal(t, cfg.General.Cluster.MaxRecvMsgSize, Defaults.General.Cluster.MaxRecvMsgSize)
+	assert.Equal(t, cfg.General.Cluster.MaxSendMsgSize, Defaults.General.Cluster.MaxSendMsgSize)
 }
 
 func TestConsensusConfig(t *testing.T) {
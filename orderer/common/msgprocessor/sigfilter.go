@@ -11,6 +11,7 @@ import (
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/accessaudit"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/protoutil"
@@ -75,7 +76,23 @@ func (sf *SigFilter) Apply(message *cb.Envelope) error {
 	err = policy.EvaluateSignedData(signedData)
 	if err != nil {
 		logger.Debugf("SigFilter evaluation failed: %s, policyName: %s, ConsensusState: %s", err.Error(), policyName, ordererConf.ConsensusState())
+		accessaudit.Record(accessaudit.Event{
+			Channel:    sigFilterChannelID(message),
+			Policy:     policyName,
+			Identities: accessaudit.DescribeSignedData(signedData),
+			Reason:     err.Error(),
+		})
 		return errors.Wrap(errors.WithStack(ErrPermissionDenied), err.Error())
 	}
 	return nil
 }
+
+// sigFilterChannelID extracts the channel of the message for the audit
+// trail; an unparsable header yields an empty channel.
+func sigFilterChannelID(message *cb.Envelope) string {
+	chdr, err := protoutil.ChannelHeader(message)
+	if err != nil {
+		return ""
+	}
+	return chdr.ChannelId
+}
@@ -35,6 +35,7 @@ type blockWriterSupport interface {
 type BlockWriter struct {
 	support            blockWriterSupport
 	registrar          *Registrar
+	extraSigners       []identity.SignerSerializer
 	lastConfigBlockNum uint64
 	lastConfigSeq      uint64
 	lastBlock          *cb.Block
@@ -48,6 +49,9 @@ func newBlockWriter(lastBlock *cb.Block, r *Registrar, support blockWriterSuppor
 		lastBlock:     lastBlock,
 		registrar:     r,
 	}
+	if r != nil {
+		bw.extraSigners = r.extraSigners
+	}
 
 	// If this is the genesis block, the lastconfig field may be empty, and, the last config block is necessarily block 0
 	// so no need to initialize lastConfig
@@ -156,7 +160,22 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 		logger.Panicf("Told to write a config block with unknown header type: %v", chdr.Type)
 	}
 
-	bw.WriteBlock(block, encodedMetadataValue)
+	if bw.registrar != nil && bw.registrar.auditor != nil {
+		if err := bw.registrar.auditor.Record(block); err != nil {
+			logger.Warningf("[channel: %s] Failed to record config transaction in audit log: %s", bw.support.ChannelID(), err)
+		}
+	}
+
+	var notifyCommit func()
+	if bw.registrar != nil && bw.registrar.configNotifier != nil {
+		notifier := bw.registrar.configNotifier
+		channel := bw.support.ChannelID()
+		notifyCommit = func() {
+			notifier.Notify(channel, block)
+		}
+	}
+
+	bw.writeBlock(block, encodedMetadataValue, notifyCommit)
 }
 
 // WriteBlock should be invoked for blocks which contain normal transactions.
@@ -166,12 +185,21 @@ func (bw *BlockWriter) WriteConfigBlock(block *cb.Block, encodedMetadataValue []
 // then release the lock.  This allows the calling thread to begin assembling the next block
 // before the commit phase is complete.
 func (bw *BlockWriter) WriteBlock(block *cb.Block, encodedMetadataValue []byte) {
+	bw.writeBlock(block, encodedMetadataValue, nil)
+}
+
+// writeBlock commits the block asynchronously, and invokes onCommit (if set)
+// once the block has been written to the ledger.
+func (bw *BlockWriter) writeBlock(block *cb.Block, encodedMetadataValue []byte, onCommit func()) {
 	bw.committingBlock.Lock()
 	bw.lastBlock = block
 
 	go func() {
 		defer bw.committingBlock.Unlock()
 		bw.commitBlock(encodedMetadataValue)
+		if onCommit != nil {
+			onCommit()
+		}
 	}()
 }
 
@@ -189,25 +217,30 @@ func (bw *BlockWriter) commitBlock(encodedMetadataValue []byte) {
 }
 
 func (bw *BlockWriter) addBlockSignature(block *cb.Block, consenterMetadata []byte) {
-	blockSignature := &cb.MetadataSignature{
-		SignatureHeader: protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(bw.support)),
-	}
-
 	blockSignatureValue := protoutil.MarshalOrPanic(&cb.OrdererBlockMetadata{
 		LastConfig:        &cb.LastConfig{Index: bw.lastConfigBlockNum},
 		ConsenterMetadata: protoutil.MarshalOrPanic(&cb.Metadata{Value: consenterMetadata}),
 	})
 
-	blockSignature.Signature = protoutil.SignOrPanic(
-		bw.support,
-		util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, protoutil.BlockHeaderBytes(block.Header)),
-	)
+	// The local signer always signs; any configured extra signers co-sign,
+	// so external verifiers can demand multiple signatures per block via the
+	// channel's block validation policy.
+	signers := append([]identity.SignerSerializer{bw.support}, bw.extraSigners...)
+	signatures := make([]*cb.MetadataSignature, 0, len(signers))
+	for _, signer := range signers {
+		blockSignature := &cb.MetadataSignature{
+			SignatureHeader: protoutil.MarshalOrPanic(protoutil.NewSignatureHeaderOrPanic(signer)),
+		}
+		blockSignature.Signature = protoutil.SignOrPanic(
+			signer,
+			util.ConcatenateBytes(blockSignatureValue, blockSignature.SignatureHeader, protoutil.BlockHeaderBytes(block.Header)),
+		)
+		signatures = append(signatures, blockSignature)
+	}
 
 	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&cb.Metadata{
-		Value: blockSignatureValue,
-		Signatures: []*cb.MetadataSignature{
-			blockSignature,
-		},
+		Value:      blockSignatureValue,
+		Signatures: signatures,
 	})
 }
 
@@ -116,6 +116,40 @@ func TestBlockSignature(t *testing.T) {
 	assert.NotNil(t, md.Signatures, "Should have signature")
 }
 
+func TestBlockMultiSignature(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-ledger")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	rlf, err := fileledger.New(dir, &disabled.Provider{})
+	require.NoError(t, err)
+
+	l, err := rlf.GetOrCreate("mychannel")
+	assert.NoError(t, err)
+	lastBlock := protoutil.NewBlock(0, nil)
+	l.Append(lastBlock)
+
+	bw := &BlockWriter{
+		lastConfigBlockNum: 42,
+		support: &mockBlockWriterSupport{
+			SignerSerializer:  mockCrypto(),
+			ConfigTXValidator: &mocks.ConfigTXValidator{},
+			ReadWriter:        l,
+		},
+		extraSigners: []identity.SignerSerializer{mockCrypto(), mockCrypto()},
+		lastBlock:    protoutil.NewBlock(1, protoutil.BlockHeaderHash(lastBlock.Header)),
+	}
+
+	bw.commitBlock([]byte("bar"))
+
+	it, _ := l.Iterator(&orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{}})
+	committedBlock, status := it.Next()
+	assert.Equal(t, cb.Status_SUCCESS, status)
+
+	md := protoutil.GetMetadataFromBlockOrPanic(committedBlock, cb.BlockMetadataIndex_SIGNATURES)
+	assert.Len(t, md.Signatures, 3, "local signer and both extra signers should have signed")
+}
+
 func TestBlockLastConfig(t *testing.T) {
 	lastConfigSeq := uint64(6)
 	newConfigSeq := lastConfigSeq + 1
@@ -257,6 +291,60 @@ func TestGoodWriteConfig(t *testing.T) {
 	assert.Equal(t, consenterMetadata, omd.Value)
 }
 
+type fakeConfigNotifier struct {
+	channels []string
+	blocks   []*cb.Block
+}
+
+func (f *fakeConfigNotifier) Notify(channel string, block *cb.Block) {
+	f.channels = append(f.channels, channel)
+	f.blocks = append(f.blocks, block)
+}
+
+func TestWriteConfigBlockNotifies(t *testing.T) {
+	confSys := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile, configtest.GetDevConfigDir())
+	genesisBlockSys := encoder.New(confSys).GenesisBlock()
+
+	tmpdir, err := ioutil.TempDir("", "file-ledger")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	_, l := newLedgerAndFactory(tmpdir, "testchannelid", genesisBlockSys)
+
+	fakeConfig := &mock.OrdererConfig{}
+	fakeConfig.ConsensusTypeReturns("solo")
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	mockValidator := &mocks.ConfigTXValidator{}
+	mockValidator.ChannelIDReturns("testchannelid")
+	notifier := &fakeConfigNotifier{}
+	bw := newBlockWriter(genesisBlockSys, &Registrar{configNotifier: notifier},
+		&mockBlockWriterSupport{
+			SignerSerializer:  mockCrypto(),
+			ReadWriter:        l,
+			ConfigTXValidator: mockValidator,
+			fakeConfig:        fakeConfig,
+			bccsp:             cryptoProvider,
+		},
+	)
+
+	ctx := makeConfigTxFull("testchannelid", 1)
+	block := protoutil.NewBlock(1, protoutil.BlockHeaderHash(genesisBlockSys.Header))
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(ctx)}
+	bw.WriteConfigBlock(block, nil)
+
+	// Wait for the commit to complete; the notifier fires before the
+	// committing lock is released.
+	bw.committingBlock.Lock()
+	bw.committingBlock.Unlock()
+
+	require.Len(t, notifier.blocks, 1)
+	assert.Equal(t, []string{"testchannelid"}, notifier.channels)
+	assert.Equal(t, uint64(1), notifier.blocks[0].Header.Number)
+}
+
 func TestMigrationWriteConfig(t *testing.T) {
 	confSys := genesisconfig.Load(genesisconfig.SampleInsecureSoloProfile, configtest.GetDevConfigDir())
 	genesisBlockSys := encoder.New(confSys).GenesisBlock()
@@ -18,6 +18,16 @@ type ChannelCapabilities struct {
 	consensusTypeMigrationReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	HybridPQCSignaturesStub        func() bool
+	hybridPQCSignaturesMutex       sync.RWMutex
+	hybridPQCSignaturesArgsForCall []struct {
+	}
+	hybridPQCSignaturesReturns struct {
+		result1 bool
+	}
+	hybridPQCSignaturesReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	MSPVersionStub        func() msp.MSPVersion
 	mSPVersionMutex       sync.RWMutex
 	mSPVersionArgsForCall []struct {
@@ -104,6 +114,58 @@ func (fake *ChannelCapabilities) ConsensusTypeMigrationReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ChannelCapabilities) HybridPQCSignatures() bool {
+	fake.hybridPQCSignaturesMutex.Lock()
+	ret, specificReturn := fake.hybridPQCSignaturesReturnsOnCall[len(fake.hybridPQCSignaturesArgsForCall)]
+	fake.hybridPQCSignaturesArgsForCall = append(fake.hybridPQCSignaturesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("HybridPQCSignatures", []interface{}{})
+	fake.hybridPQCSignaturesMutex.Unlock()
+	if fake.HybridPQCSignaturesStub != nil {
+		return fake.HybridPQCSignaturesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.hybridPQCSignaturesReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelCapabilities) HybridPQCSignaturesCallCount() int {
+	fake.hybridPQCSignaturesMutex.RLock()
+	defer fake.hybridPQCSignaturesMutex.RUnlock()
+	return len(fake.hybridPQCSignaturesArgsForCall)
+}
+
+func (fake *ChannelCapabilities) HybridPQCSignaturesCalls(stub func() bool) {
+	fake.hybridPQCSignaturesMutex.Lock()
+	defer fake.hybridPQCSignaturesMutex.Unlock()
+	fake.HybridPQCSignaturesStub = stub
+}
+
+func (fake *ChannelCapabilities) HybridPQCSignaturesReturns(result1 bool) {
+	fake.hybridPQCSignaturesMutex.Lock()
+	defer fake.hybridPQCSignaturesMutex.Unlock()
+	fake.HybridPQCSignaturesStub = nil
+	fake.hybridPQCSignaturesReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ChannelCapabilities) HybridPQCSignaturesReturnsOnCall(i int, result1 bool) {
+	fake.hybridPQCSignaturesMutex.Lock()
+	defer fake.hybridPQCSignaturesMutex.Unlock()
+	fake.HybridPQCSignaturesStub = nil
+	if fake.hybridPQCSignaturesReturnsOnCall == nil {
+		fake.hybridPQCSignaturesReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.hybridPQCSignaturesReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 	fake.mSPVersionMutex.Lock()
 	ret, specificReturn := fake.mSPVersionReturnsOnCall[len(fake.mSPVersionArgsForCall)]
@@ -122,6 +184,8 @@ func (fake *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 }
 
 func (fake *ChannelCapabilities) MSPVersionCallCount() int {
+	fake.hybridPQCSignaturesMutex.RLock()
+	defer fake.hybridPQCSignaturesMutex.RUnlock()
 	fake.mSPVersionMutex.RLock()
 	defer fake.mSPVersionMutex.RUnlock()
 	return len(fake.mSPVersionArgsForCall)
@@ -93,6 +93,17 @@ type ledgerResources struct {
 }
 
 // Registrar serves as a point of access and control for the individual channel resources.
+// ConfigTxAuditor records committed config transactions to an audit log.
+type ConfigTxAuditor interface {
+	Record(block *cb.Block) error
+}
+
+// ConfigBlockNotifier is told about every committed config block, so that it
+// can push the block to subscribed clients ahead of regular Deliver traffic.
+type ConfigBlockNotifier interface {
+	Notify(channel string, block *cb.Block)
+}
+
 type Registrar struct {
 	config localconfig.TopLevel
 	lock   sync.RWMutex
@@ -107,6 +118,28 @@ type Registrar struct {
 	templator          msgprocessor.ChannelConfigTemplator
 	callbacks          []channelconfig.BundleActor
 	bccsp              bccsp.BCCSP
+	auditor            ConfigTxAuditor
+	configNotifier     ConfigBlockNotifier
+	extraSigners       []identity.SignerSerializer
+}
+
+// SetAuditor sets the auditor that records committed config transactions. It
+// must be called before Initialize.
+func (r *Registrar) SetAuditor(auditor ConfigTxAuditor) {
+	r.auditor = auditor
+}
+
+// SetConfigBlockNotifier sets the notifier that is told about committed
+// config blocks. It must be called before Initialize.
+func (r *Registrar) SetConfigBlockNotifier(notifier ConfigBlockNotifier) {
+	r.configNotifier = notifier
+}
+
+// SetExtraBlockSigners sets additional signing identities that co-sign every
+// committed block, alongside the local signer. It must be called before
+// Initialize.
+func (r *Registrar) SetExtraBlockSigners(signers ...identity.SignerSerializer) {
+	r.extraSigners = signers
 }
 
 // ConfigBlock retrieves the last configuration block from the given ledger.
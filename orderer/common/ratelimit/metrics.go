@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimit
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var rejectedCount = metrics.CounterOpts{
+	Namespace:    "broadcast",
+	Name:         "rate_limited_count",
+	Help:         "The number of transactions rejected by admission control.",
+	LabelNames:   []string{"org", "reason"},
+	StatsdFormat: "%{#fqname}.%{org}.%{reason}",
+}
+
+type Metrics struct {
+	RejectedCount metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		RejectedCount: p.NewCounter(rejectedCount),
+	}
+}
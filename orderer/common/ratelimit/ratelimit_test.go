@@ -0,0 +1,123 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	current time.Time
+}
+
+func (fc *fakeClock) now() time.Time {
+	return fc.current
+}
+
+func (fc *fakeClock) advance(d time.Duration) {
+	fc.current = fc.current.Add(d)
+}
+
+func newTestLimiter(config Config) (*Limiter, *fakeClock, *metricsfakes.Counter) {
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	clock := &fakeClock{current: time.Now()}
+	limiter := New(config, &Metrics{RejectedCount: fakeCounter})
+	limiter.now = clock.now
+	return limiter, clock, fakeCounter
+}
+
+func TestAdmitUnlimited(t *testing.T) {
+	limiter, _, fakeCounter := newTestLimiter(Config{})
+
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, limiter.Admit("Org1MSP", 1024))
+	}
+	assert.Zero(t, fakeCounter.AddCallCount())
+}
+
+func TestAdmitTxRate(t *testing.T) {
+	limiter, clock, fakeCounter := newTestLimiter(Config{
+		DefaultLimit: Limit{TxPerSecond: 2},
+	})
+
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	err := limiter.Admit("Org1MSP", 10)
+	assert.EqualError(t, err, "transaction rate limit of 2 tx/s exceeded for org Org1MSP")
+
+	assert.Equal(t, 1, fakeCounter.AddCallCount())
+	assert.Equal(t, []string{"org", "Org1MSP", "reason", "tx_rate"}, fakeCounter.WithArgsForCall(0))
+
+	// the budget is replenished as time passes
+	clock.advance(500 * time.Millisecond)
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	err = limiter.Admit("Org1MSP", 10)
+	assert.EqualError(t, err, "transaction rate limit of 2 tx/s exceeded for org Org1MSP")
+
+	// other organizations are not affected
+	assert.NoError(t, limiter.Admit("Org2MSP", 10))
+}
+
+func TestAdmitByteBudget(t *testing.T) {
+	limiter, clock, fakeCounter := newTestLimiter(Config{
+		DefaultLimit: Limit{BytesPerSecond: 10},
+	})
+
+	assert.NoError(t, limiter.Admit("Org1MSP", 6))
+	err := limiter.Admit("Org1MSP", 6)
+	assert.EqualError(t, err, "message size budget of 10 bytes/s exceeded for org Org1MSP")
+
+	assert.Equal(t, 1, fakeCounter.AddCallCount())
+	assert.Equal(t, []string{"org", "Org1MSP", "reason", "byte_rate"}, fakeCounter.WithArgsForCall(0))
+
+	clock.advance(500 * time.Millisecond)
+	assert.NoError(t, limiter.Admit("Org1MSP", 6))
+}
+
+func TestAdmitOrgOverride(t *testing.T) {
+	limiter, _, _ := newTestLimiter(Config{
+		DefaultLimit: Limit{TxPerSecond: 100},
+		OrgLimits: map[string]Limit{
+			"Org1MSP": {TxPerSecond: 1},
+		},
+	})
+
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.EqualError(t, limiter.Admit("Org1MSP", 10), "transaction rate limit of 1 tx/s exceeded for org Org1MSP")
+
+	// organizations without an override use the default limit
+	assert.NoError(t, limiter.Admit("Org2MSP", 10))
+	assert.NoError(t, limiter.Admit("Org2MSP", 10))
+}
+
+func TestUpdate(t *testing.T) {
+	limiter, _, _ := newTestLimiter(Config{
+		DefaultLimit: Limit{TxPerSecond: 1},
+	})
+
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.Error(t, limiter.Admit("Org1MSP", 10))
+
+	// raising the limit takes effect immediately, the exhausted bucket is
+	// discarded
+	limiter.Update(Config{DefaultLimit: Limit{TxPerSecond: 3}})
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.EqualError(t, limiter.Admit("Org1MSP", 10), "transaction rate limit of 3 tx/s exceeded for org Org1MSP")
+
+	// an empty configuration removes all limits
+	limiter.Update(Config{})
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, limiter.Admit("Org1MSP", 1024))
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// channelDataPath describes one on-disk location holding data of a channel.
+type channelDataPath struct {
+	path string
+	// size is the total size in bytes of the files under path.
+	size int64
+}
+
+// channelDataPaths returns the on-disk locations holding data of the given
+// channel that exist on this node: the channel's block files, and for Raft
+// channels its WAL and snapshot directories.
+func channelDataPaths(conf *localconfig.TopLevel, channelID string) ([]channelDataPath, error) {
+	candidates := []string{
+		filepath.Join(conf.FileLedger.Location, "chains", channelID),
+	}
+
+	var raftConfig etcdraft.Config
+	if err := mapstructure.Decode(conf.Consensus, &raftConfig); err == nil {
+		if raftConfig.WALDir != "" {
+			candidates = append(candidates, filepath.Join(raftConfig.WALDir, channelID))
+		}
+		if raftConfig.SnapDir != "" {
+			candidates = append(candidates, filepath.Join(raftConfig.SnapDir, channelID))
+		}
+	}
+
+	var paths []channelDataPath
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			continue
+		}
+
+		var size int64
+		err := filepath.Walk(candidate, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to inspect %s", candidate)
+		}
+
+		paths = append(paths, channelDataPath{path: candidate, size: size})
+	}
+
+	return paths, nil
+}
+
+// removeChannelData deletes all on-disk data of the given channel: its block
+// files, and for Raft channels its WAL and snapshot directories. When dryRun
+// is set, nothing is deleted. The paths that were removed, or would have been
+// removed, are returned. The orderer must not be running, since an active
+// chain would recreate the data and may crash when its ledger disappears.
+func removeChannelData(conf *localconfig.TopLevel, channelID string, dryRun bool) ([]channelDataPath, error) {
+	paths, err := channelDataPaths(conf, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("no data found for channel %s", channelID)
+	}
+
+	if dryRun {
+		return paths, nil
+	}
+
+	for _, p := range paths {
+		if err := os.RemoveAll(p.path); err != nil {
+			return nil, errors.WithMessagef(err, "failed to remove %s", p.path)
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCleanupTestConfig(t *testing.T) (*localconfig.TopLevel, string) {
+	baseDir, err := ioutil.TempDir("", "cleanup-test")
+	assert.NoError(t, err)
+
+	conf := &localconfig.TopLevel{
+		FileLedger: localconfig.FileLedger{
+			Location: filepath.Join(baseDir, "ledger"),
+		},
+		Consensus: map[string]interface{}{
+			"WALDir":  filepath.Join(baseDir, "etcdraft", "wal"),
+			"SnapDir": filepath.Join(baseDir, "etcdraft", "snapshot"),
+		},
+	}
+
+	for _, channelID := range []string{"mychannel", "otherchannel"} {
+		for _, dir := range []string{
+			filepath.Join(baseDir, "ledger", "chains", channelID),
+			filepath.Join(baseDir, "etcdraft", "wal", channelID),
+			filepath.Join(baseDir, "etcdraft", "snapshot", channelID),
+		} {
+			assert.NoError(t, os.MkdirAll(dir, 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "data"), []byte("0123456789"), 0644))
+		}
+	}
+
+	return conf, baseDir
+}
+
+func TestRemoveChannelDataDryRun(t *testing.T) {
+	conf, baseDir := newCleanupTestConfig(t)
+	defer os.RemoveAll(baseDir)
+
+	paths, err := removeChannelData(conf, "mychannel", true)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 3)
+	for _, p := range paths {
+		assert.Equal(t, int64(10), p.size)
+		// nothing was deleted
+		_, err := os.Stat(p.path)
+		assert.NoError(t, err)
+	}
+}
+
+func TestRemoveChannelData(t *testing.T) {
+	conf, baseDir := newCleanupTestConfig(t)
+	defer os.RemoveAll(baseDir)
+
+	paths, err := removeChannelData(conf, "mychannel", false)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 3)
+	for _, p := range paths {
+		_, err := os.Stat(p.path)
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	// data of other channels is untouched
+	_, err = os.Stat(filepath.Join(baseDir, "ledger", "chains", "otherchannel"))
+	assert.NoError(t, err)
+
+	// a second removal finds nothing left to delete
+	_, err = removeChannelData(conf, "mychannel", false)
+	assert.EqualError(t, err, "no data found for channel mychannel")
+}
+
+func TestRemoveChannelDataNotFound(t *testing.T) {
+	conf, baseDir := newCleanupTestConfig(t)
+	defer os.RemoveAll(baseDir)
+
+	_, err := removeChannelData(conf, "unknown", false)
+	assert.EqualError(t, err, "no data found for channel unknown")
+}
+
+func TestRemoveChannelDataWithoutRaftDirs(t *testing.T) {
+	conf, baseDir := newCleanupTestConfig(t)
+	defer os.RemoveAll(baseDir)
+
+	// a non-Raft configuration only removes the block files
+	conf.Consensus = nil
+	paths, err := removeChannelData(conf, "mychannel", false)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 1)
+	assert.Equal(t, filepath.Join(baseDir, "ledger", "chains", "mychannel"), paths[0].path)
+}
@@ -9,6 +9,7 @@ package server
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -16,6 +17,7 @@ import (
 	_ "net/http/pprof" // This is essentially the main package for the orderer
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -26,8 +28,11 @@ import (
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/common/accessaudit"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/crypto"
+	"github.com/hyperledger/fabric/common/fips"
 	"github.com/hyperledger/fabric/common/flogging"
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
@@ -39,17 +44,26 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/internal/pkg/renewal"
 	"github.com/hyperledger/fabric/msp"
+	mspcache "github.com/hyperledger/fabric/msp/cache"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/auditlog"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/confignotify"
+	"github.com/hyperledger/fabric/orderer/common/dedupe"
+	"github.com/hyperledger/fabric/orderer/common/endpointpub"
 	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/metadata"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
 	"github.com/hyperledger/fabric/orderer/consensus"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/kafka"
 	"github.com/hyperledger/fabric/orderer/consensus/solo"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
 	"google.golang.org/grpc"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -64,6 +78,10 @@ var (
 	_       = app.Command("start", "Start the orderer node").Default() // preserved for cli compatibility
 	version = app.Command("version", "Show version information")
 
+	removeChannel       = app.Command("remove-channel", "Remove a channel's block files and Raft WAL/snapshot data from this node. The orderer must not be running.")
+	removeChannelID     = removeChannel.Arg("channelID", "The ID of the channel to remove.").Required().String()
+	removeChannelDryRun = removeChannel.Flag("dryRun", "Report what would be removed without deleting anything.").Bool()
+
 	clusterTypes = map[string]struct{}{"etcdraft": {}}
 )
 
@@ -84,11 +102,36 @@ func Main() {
 	}
 	initializeLogging()
 
+	// "remove-channel" command
+	if fullCmd == removeChannel.FullCommand() {
+		removed, err := removeChannelData(conf, *removeChannelID, *removeChannelDryRun)
+		if err != nil {
+			logger.Error("failed to remove channel data: ", err)
+			os.Exit(1)
+		}
+		for _, p := range removed {
+			if *removeChannelDryRun {
+				fmt.Printf("would remove %s (%d bytes)\n", p.path, p.size)
+			} else {
+				fmt.Printf("removed %s (%d bytes)\n", p.path, p.size)
+			}
+		}
+		return
+	}
+
 	prettyPrintStruct(conf)
 
+	// Enable FIPS mode before any cryptographic provider is created
+	if conf.General.FIPS {
+		if err := fips.Enable(); err != nil {
+			logger.Panicf("Failed to enable FIPS mode: %s", err)
+		}
+	}
+
 	cryptoProvider := factory.GetDefault()
 
-	signer, signErr := loadLocalMSP(conf).GetDefaultSigningIdentity()
+	localMSP := loadLocalMSP(conf)
+	signer, signErr := localMSP.GetDefaultSigningIdentity()
 	if signErr != nil {
 		logger.Panicf("Failed to get local MSP identity: %s", signErr)
 	}
@@ -101,6 +144,7 @@ func Main() {
 	metricsProvider := opsSystem.Provider
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.SetObserver(logObserver)
+	mspcache.InitializeMetrics(metricsProvider)
 
 	serverConfig := initializeServerConfig(conf, metricsProvider)
 	grpcServer := initializeGrpcServer(conf, serverConfig)
@@ -164,20 +208,50 @@ func Main() {
 
 	}
 
+	if conf.General.Role == localconfig.RoleReplica {
+		if !clusterType {
+			logger.Panicf("General.Role %q is only supported with a cluster-type consensus implementation", localconfig.RoleReplica)
+		}
+		logger.Info("Orderer is configured as a deliver-only replica; it will follow channels and serve Deliver traffic without participating in consensus")
+	}
+
 	identityBytes, err := signer.Serialize()
 	if err != nil {
 		logger.Panicf("Failed serializing signing identity: %v", err)
 	}
 
 	expirationLogger := flogging.MustGetLogger("certmonitor")
-	crypto.TrackExpiration(
-		serverConfig.SecOpts.UseTLS,
-		serverConfig.SecOpts.Certificate,
-		[][]byte{clusterClientConfig.SecOpts.Certificate},
-		identityBytes,
-		expirationLogger.Warnf, // This can be used to piggyback a metric event in the future
-		time.Now(),
-		time.AfterFunc)
+	monitoredCerts := []crypto.MonitoredCertificate{
+		crypto.MonitoredIdentityCertificate("enrollment", identityBytes),
+	}
+	if serverConfig.SecOpts.UseTLS {
+		monitoredCerts = append(monitoredCerts, crypto.MonitoredCertificate{
+			Role:    "server TLS",
+			RawCert: serverConfig.SecOpts.Certificate,
+		})
+	}
+	if len(clusterClientConfig.SecOpts.Certificate) > 0 {
+		monitoredCerts = append(monitoredCerts, crypto.MonitoredCertificate{
+			Role:    "cluster client TLS",
+			RawCert: clusterClientConfig.SecOpts.Certificate,
+		})
+	}
+	certMonitor := crypto.NewCertificateExpirationMonitor(
+		[]crypto.CertificateSource{crypto.StaticCertificateSource(monitoredCerts...)},
+		crypto.NewCertificateExpirationGauge(metricsProvider),
+		expirationLogger.Warnf,
+		time.Hour,
+	)
+	go certMonitor.Run()
+	defer certMonitor.Stop()
+
+	if conf.General.Renewal.Enabled {
+		renewalAgent, err := startRenewalAgent(conf, serverConfig, identityBytes, cryptoProvider, grpcServer)
+		if err != nil {
+			logger.Panicf("Failed to initialize the certificate renewal agent: %s", err)
+		}
+		defer renewalAgent.Stop()
+	}
 
 	// if cluster is reusing client-facing server, then it is already
 	// appended to serversToUpdate at this point.
@@ -185,6 +259,9 @@ func Main() {
 		serversToUpdate = append(serversToUpdate, grpcServer)
 	}
 
+	endpointPublisher := endpointpub.NewPublisher()
+	opsSystem.RegisterHandler("/discovery/orderers", endpointpub.NewHandler(endpointPublisher), false)
+
 	tlsCallback := func(bundle *channelconfig.Bundle) {
 		logger.Debug("Executing callback to update root CAs")
 		caMgr.updateTrustedRoots(bundle, serversToUpdate...)
@@ -194,8 +271,26 @@ func Main() {
 				clusterClientConfig.SecOpts.ServerRootCAs,
 			)
 		}
+		endpointPublisher.Update(bundle)
+	}
+
+	var auditor multichannel.ConfigTxAuditor
+	if conf.General.Audit.Enabled {
+		if conf.General.Audit.Path == "" {
+			logger.Panicf("General.Audit.Path must be set when the config transaction audit log is enabled")
+		}
+		auditLog, err := auditlog.Open(conf.General.Audit.Path, signer)
+		if err != nil {
+			logger.Panicf("Failed to open config transaction audit log: %s", err)
+		}
+		defer auditLog.Close()
+		auditor = auditlog.NewRecorder(auditLog)
+		opsSystem.RegisterHandler("/audit/configtx", auditlog.NewHandler(auditLog), conf.Operations.TLS.Enabled)
 	}
 
+	configNotifier := confignotify.NewNotifier()
+	opsSystem.RegisterHandler("/confignotify", confignotify.NewHandler(configNotifier), conf.Operations.TLS.Enabled)
+
 	manager := initializeMultichannelRegistrar(
 		clusterBootBlock,
 		r,
@@ -208,9 +303,29 @@ func Main() {
 		opsSystem,
 		lf,
 		cryptoProvider,
+		auditor,
+		configNotifier,
 		tlsCallback,
 	)
 
+	var admission *ratelimit.Limiter
+	if conf.General.RateLimits.Enabled {
+		admission = ratelimit.New(rateLimitConfig(conf.General.RateLimits), ratelimit.NewMetrics(metricsProvider))
+	}
+	var deduplication *dedupe.Cache
+	if conf.General.Dedupe.Enabled {
+		deduplication = dedupe.New(conf.General.Dedupe.Window)
+	}
+	opsSystem.RegisterHandler("/config/reload", &configReloader{
+		load:        localconfig.Reload,
+		limiter:     admission,
+		dedupeCache: deduplication,
+	}, conf.Operations.TLS.Enabled)
+	opsSystem.RegisterHandler("/audit/access", accessaudit.NewHandler(), conf.Operations.TLS.Enabled)
+	opsSystem.RegisterHandler("/msp/reload", mspmgmt.NewReloadHandler(func() error {
+		return reloadMSP(localMSP, conf.General.LocalMSPDir, conf.General.LocalMSPID, conf.General.BCCSP)
+	}), conf.Operations.TLS.Enabled)
+
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
 	server := NewServer(
 		manager,
@@ -219,8 +334,20 @@ func Main() {
 		conf.General.Authentication.TimeWindow,
 		mutualTLS,
 		conf.General.Authentication.NoExpirationChecks,
+		admission,
+		deduplication,
 	)
 
+	reloader := &certReloader{
+		conf:          conf,
+		loadPEM:       ioutil.ReadFile,
+		grpcServer:    grpcServer,
+		clusterDialer: clusterDialer,
+	}
+	if clusterType && !reuseGrpcListener {
+		reloader.clusterGRPCServer = clusterGRPCServer
+	}
+
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	handleSignals(addPlatformSignals(map[os.Signal]func(){
 		syscall.SIGTERM: func() {
@@ -229,6 +356,7 @@ func Main() {
 				clusterGRPCServer.Stop()
 			}
 		},
+		syscall.SIGHUP: reloader.reload,
 	}))
 
 	if !reuseGrpcListener && clusterType {
@@ -431,6 +559,8 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 		ServerStatsHandler: generalConf.ServerStatsHandler,
 		Logger:             generalConf.Logger,
 		KaOpts:             generalConf.KaOpts,
+		MaxRecvMsgSize:     clusterConf.MaxRecvMsgSize,
+		MaxSendMsgSize:     clusterConf.MaxSendMsgSize,
 		SecOpts: comm.SecureOptions{
 			TimeShift:         conf.General.Cluster.TLSHandshakeTimeShift,
 			CipherSuites:      comm.DefaultTLSCipherSuites,
@@ -451,11 +581,21 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 }
 
 func initializeClusterClientConfig(conf *localconfig.TopLevel) comm.ClientConfig {
+	kaOpts := comm.DefaultKeepaliveOptions
+	if conf.General.Cluster.ClientKeepaliveInterval > 0 {
+		kaOpts.ClientInterval = conf.General.Cluster.ClientKeepaliveInterval
+	}
+	if conf.General.Cluster.ClientKeepaliveTimeout > 0 {
+		kaOpts.ClientTimeout = conf.General.Cluster.ClientKeepaliveTimeout
+	}
+
 	cc := comm.ClientConfig{
-		AsyncConnect: true,
-		KaOpts:       comm.DefaultKeepaliveOptions,
-		Timeout:      conf.General.Cluster.DialTimeout,
-		SecOpts:      comm.SecureOptions{},
+		AsyncConnect:   true,
+		KaOpts:         kaOpts,
+		Timeout:        conf.General.Cluster.DialTimeout,
+		MaxRecvMsgSize: conf.General.Cluster.MaxRecvMsgSize,
+		MaxSendMsgSize: conf.General.Cluster.MaxSendMsgSize,
+		SecOpts:        comm.SecureOptions{},
 	}
 
 	if conf.General.Cluster.ClientCertificate == "" {
@@ -511,10 +651,22 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 			logger.Fatalf("Failed to load server Certificate file '%s' (%s)",
 				conf.General.TLS.Certificate, err)
 		}
-		serverKey, err := ioutil.ReadFile(conf.General.TLS.PrivateKey)
-		if err != nil {
-			logger.Fatalf("Failed to load PrivateKey file '%s' (%s)",
-				conf.General.TLS.PrivateKey, err)
+		if conf.General.TLS.PrivateKeySKI != "" {
+			// the key lives in the default BCCSP (e.g. an HSM) and is
+			// referenced by SKI rather than read from the file system
+			tlsSigner, err := signer.NewFromSKI(factory.GetDefault(), conf.General.TLS.PrivateKeySKI)
+			if err != nil {
+				logger.Fatalf("Failed to resolve TLS key with SKI '%s' (%s)",
+					conf.General.TLS.PrivateKeySKI, err)
+			}
+			secureOpts.Signer = tlsSigner
+		} else {
+			serverKey, err := ioutil.ReadFile(conf.General.TLS.PrivateKey)
+			if err != nil {
+				logger.Fatalf("Failed to load PrivateKey file '%s' (%s)",
+					conf.General.TLS.PrivateKey, err)
+			}
+			secureOpts.Key = serverKey
 		}
 		var serverRootCAs, clientRootCAs [][]byte
 		for _, serverRoot := range conf.General.TLS.RootCAs {
@@ -536,7 +688,6 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 			}
 			msg = "mutual TLS"
 		}
-		secureOpts.Key = serverKey
 		secureOpts.Certificate = serverCertificate
 		secureOpts.ServerRootCAs = serverRootCAs
 		secureOpts.ClientRootCAs = clientRootCAs
@@ -656,10 +807,101 @@ func initializeGrpcServer(conf *localconfig.TopLevel, serverConfig comm.ServerCo
 	return grpcServer
 }
 
+// startRenewalAgent launches the agent that re-enrolls the orderer's
+// certificates against the configured Fabric CA before they expire. A
+// renewed server TLS certificate is hot-swapped into the gRPC server and
+// persisted; a renewed enrollment certificate is persisted into the local
+// MSP and takes effect on the next restart.
+func startRenewalAgent(
+	conf *localconfig.TopLevel,
+	serverConfig comm.ServerConfig,
+	identityBytes []byte,
+	cryptoProvider bccsp.BCCSP,
+	grpcServer *comm.GRPCServer,
+) (*renewal.Agent, error) {
+	renewalConf := conf.General.Renewal
+
+	var caTLSRootCert []byte
+	if renewalConf.CATLSRootCert != "" {
+		var err error
+		caTLSRootCert, err = ioutil.ReadFile(renewalConf.CATLSRootCert)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed reading the CA TLS root certificate")
+		}
+	}
+
+	caClient, err := renewal.NewCAClient(
+		renewalConf.CAURL,
+		renewalConf.CAName,
+		renewalConf.EnrollmentID,
+		renewalConf.EnrollmentSecret,
+		caTLSRootCert,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollmentCert, err := renewal.IdentityCert(identityBytes)
+	if err != nil {
+		return nil, err
+	}
+	identitySigner, err := renewal.SignerForCert(cryptoProvider, enrollmentCert)
+	if err != nil {
+		return nil, err
+	}
+
+	renewalCerts := []*renewal.Certificate{
+		{
+			Role:    "enrollment",
+			CertPEM: enrollmentCert,
+			Signer:  identitySigner,
+			Swap: func(oldCertPEM, newCertPEM []byte) error {
+				// the renewed enrollment certificate takes effect once the
+				// orderer restarts with the updated MSP material
+				return renewal.ReplaceCertFile(filepath.Join(conf.General.LocalMSPDir, "signcerts"), oldCertPEM, newCertPEM)
+			},
+		},
+	}
+
+	if serverConfig.SecOpts.UseTLS {
+		tlsSigner, err := renewal.TLSSigner(serverConfig.SecOpts.Key)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed loading the server TLS key")
+		}
+		renewalCerts = append(renewalCerts, &renewal.Certificate{
+			Role:    "server TLS",
+			CertPEM: serverConfig.SecOpts.Certificate,
+			Signer:  tlsSigner,
+			Swap: func(oldCertPEM, newCertPEM []byte) error {
+				tlsCert, err := tls.X509KeyPair(newCertPEM, serverConfig.SecOpts.Key)
+				if err != nil {
+					return err
+				}
+				grpcServer.SetServerCertificate(tlsCert)
+				return ioutil.WriteFile(conf.General.TLS.Certificate, newCertPEM, 0644)
+			},
+		})
+	}
+
+	// The registrar offers no way to enumerate the channels an orderer
+	// serves, so channel config update drafts for renewed certificates are
+	// not emitted here and remain an administrator's task.
+	agent, err := renewal.NewAgent(caClient, renewalCerts, renewalConf.Window, renewalConf.CheckInterval, nil)
+	if err != nil {
+		return nil, err
+	}
+	go agent.Run()
+	return agent, nil
+}
+
 func loadLocalMSP(conf *localconfig.TopLevel) msp.MSP {
+	return loadMSP(conf.General.LocalMSPDir, conf.General.LocalMSPID, conf.General.BCCSP)
+}
+
+func loadMSP(mspDir, mspID string, bccspConfig *factory.FactoryOpts) msp.MSP {
 	// MUST call GetLocalMspConfig first, so that default BCCSP is properly
 	// initialized prior to LoadByType.
-	mspConfig, err := msp.GetLocalMspConfig(conf.General.LocalMSPDir, conf.General.BCCSP, conf.General.LocalMSPID)
+	mspConfig, err := msp.GetLocalMspConfig(mspDir, bccspConfig, mspID)
 	if err != nil {
 		logger.Panicf("Failed to get local msp config: %v", err)
 	}
@@ -682,6 +924,31 @@ func loadLocalMSP(conf *localconfig.TopLevel) msp.MSP {
 	return localmsp
 }
 
+// reloadMSP re-reads the local MSP directory and applies it to the running
+// local MSP, so that refreshed CRLs or new admin certificates take effect
+// without a restart.
+func reloadMSP(localMSP msp.MSP, mspDir, mspID string, bccspConfig *factory.FactoryOpts) error {
+	mspConfig, err := msp.GetLocalMspConfig(mspDir, bccspConfig, mspID)
+	if err != nil {
+		return err
+	}
+	return localMSP.Setup(mspConfig)
+}
+
+// loadAdditionalBlockSigners loads the signing identities of the configured
+// additional block signer MSPs, which co-sign every committed block.
+func loadAdditionalBlockSigners(conf *localconfig.TopLevel) []identity.SignerSerializer {
+	var signers []identity.SignerSerializer
+	for _, blockSigner := range conf.General.AdditionalBlockSigners {
+		signer, err := loadMSP(blockSigner.MSPDir, blockSigner.MSPID, conf.General.BCCSP).GetDefaultSigningIdentity()
+		if err != nil {
+			logger.Panicf("Failed to get signing identity of additional block signer %s: %v", blockSigner.MSPID, err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
 //go:generate counterfeiter -o mocks/health_checker.go -fake-name HealthChecker . healthChecker
 
 // HealthChecker defines the contract for health checker
@@ -701,16 +968,27 @@ func initializeMultichannelRegistrar(
 	healthChecker healthChecker,
 	lf blockledger.Factory,
 	bccsp bccsp.BCCSP,
+	auditor multichannel.ConfigTxAuditor,
+	configNotifier multichannel.ConfigBlockNotifier,
 	callbacks ...channelconfig.BundleActor,
 ) *multichannel.Registrar {
 
 	registrar := multichannel.NewRegistrar(*conf, lf, signer, metricsProvider, bccsp, callbacks...)
+	if auditor != nil {
+		registrar.SetAuditor(auditor)
+	}
+	if configNotifier != nil {
+		registrar.SetConfigBlockNotifier(configNotifier)
+	}
+	if len(conf.General.AdditionalBlockSigners) > 0 {
+		registrar.SetExtraBlockSigners(loadAdditionalBlockSigners(conf)...)
+	}
 
 	consenters := map[string]consensus.Consenter{}
 
 	var icr etcdraft.InactiveChainRegistry
 	if conf.General.BootstrapMethod == "file" && isClusterType(bootstrapBlock, bccsp) {
-		etcdConsenter := initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider, bccsp)
+		etcdConsenter := initializeEtcdraftConsenter(consenters, conf, lf, clusterDialer, bootstrapBlock, ri, srvConf, srv, registrar, metricsProvider, healthChecker, bccsp)
 		icr = etcdConsenter.InactiveChainRegistry
 	}
 
@@ -735,6 +1013,7 @@ func initializeEtcdraftConsenter(
 	srv *comm.GRPCServer,
 	registrar *multichannel.Registrar,
 	metricsProvider metrics.Provider,
+	healthChecker healthChecker,
 	bccsp bccsp.BCCSP,
 ) *etcdraft.Consenter {
 	replicationRefreshInterval := conf.General.Cluster.ReplicationBackgroundRefreshInterval
@@ -774,7 +1053,7 @@ func initializeEtcdraftConsenter(
 	ri.channelLister = icr
 
 	go icr.run()
-	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider, bccsp)
+	raftConsenter := etcdraft.New(clusterDialer, conf, srvConf, srv, registrar, icr, metricsProvider, healthChecker, bccsp)
 	consenters["etcdraft"] = raftConsenter
 	return raftConsenter
 }
@@ -197,6 +197,26 @@ func TestInitializeServerConfig(t *testing.T) {
 	}
 }
 
+func TestInitializeClusterClientConfig(t *testing.T) {
+	conf := &localconfig.TopLevel{
+		General: localconfig.General{
+			Cluster: localconfig.Cluster{
+				DialTimeout:             7 * time.Second,
+				ClientKeepaliveInterval: 30 * time.Second,
+				ClientKeepaliveTimeout:  10 * time.Second,
+				MaxRecvMsgSize:          1024,
+				MaxSendMsgSize:          2048,
+			},
+		},
+	}
+	cc := initializeClusterClientConfig(conf)
+	assert.Equal(t, 7*time.Second, cc.Timeout)
+	assert.Equal(t, 30*time.Second, cc.KaOpts.ClientInterval)
+	assert.Equal(t, 10*time.Second, cc.KaOpts.ClientTimeout)
+	assert.Equal(t, 1024, cc.MaxRecvMsgSize)
+	assert.Equal(t, 2048, cc.MaxSendMsgSize)
+}
+
 func TestInitializeBootstrapChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -379,6 +399,34 @@ func TestLoadLocalMSP(t *testing.T) {
 	})
 }
 
+func TestLoadAdditionalBlockSigners(t *testing.T) {
+	conf := &localconfig.TopLevel{
+		General: localconfig.General{
+			AdditionalBlockSigners: []localconfig.BlockSigner{
+				{MSPDir: configtest.GetDevMspDir(), MSPID: "SampleOrg"},
+			},
+			BCCSP: &factory.FactoryOpts{
+				ProviderName: "SW",
+				SwOpts: &factory.SwOpts{
+					HashFamily: "SHA2",
+					SecLevel:   256,
+					Ephemeral:  true,
+				},
+			},
+		},
+	}
+
+	signers := loadAdditionalBlockSigners(conf)
+	require.Len(t, signers, 1)
+	_, err := signers[0].Serialize()
+	require.NoError(t, err)
+
+	conf.General.AdditionalBlockSigners[0].MSPDir = "does-not-exist"
+	assert.Panics(t, func() {
+		loadAdditionalBlockSigners(conf)
+	})
+}
+
 func TestInitializeMultichannelRegistrar(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -408,6 +456,8 @@ func TestInitializeMultichannelRegistrar(t *testing.T) {
 			&server_mocks.HealthChecker{},
 			lf,
 			cryptoProvider,
+			nil,
+			nil,
 		)
 		assert.NotNil(t, registrar)
 		assert.Equal(t, "testchannelid", registrar.SystemChannelID())
@@ -430,6 +480,8 @@ func TestInitializeMultichannelRegistrar(t *testing.T) {
 			&server_mocks.HealthChecker{},
 			lf,
 			cryptoProvider,
+			nil,
+			nil,
 		)
 		assert.NotNil(t, registrar)
 		assert.Empty(t, registrar.SystemChannelID())
@@ -541,6 +593,8 @@ func TestUpdateTrustedRoots(t *testing.T) {
 		&server_mocks.HealthChecker{},
 		lf,
 		cryptoProvider,
+		nil,
+		nil,
 		callback,
 	)
 	t.Logf("# app CAs: %d", len(caMgr.appRootCAsByChain["testchannelid"]))
@@ -592,6 +646,8 @@ func TestUpdateTrustedRoots(t *testing.T) {
 		&server_mocks.HealthChecker{},
 		lf,
 		cryptoProvider,
+		nil,
+		nil,
 		callback,
 	)
 	t.Logf("# app CAs: %d", len(caMgr.appRootCAsByChain["testchannelid"]))
@@ -857,6 +913,7 @@ func TestInitializeEtcdraftConsenter(t *testing.T) {
 		srv,
 		&multichannel.Registrar{},
 		&disabled.Provider{},
+		&server_mocks.HealthChecker{},
 		cryptoProvider,
 	)
 	assert.NotNil(t, consenters["etcdraft"])
@@ -88,6 +88,15 @@ func (ri *replicationInitiator) createReplicator(bootstrapBlock *common.Block, f
 		replicator.ChannelLister = ri.channelLister
 	}
 
+	if ri.conf.General.Role == localconfig.RoleReplica {
+		// A deliver-only replica follows every channel it is permitted to
+		// read from, regardless of whether its certificate is in the
+		// consenter set.
+		replicator.AmIPartOfChannel = func(configBlock *common.Block) error {
+			return nil
+		}
+	}
+
 	return replicator
 }
 
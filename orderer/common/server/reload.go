@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"crypto/tls"
+
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
+)
+
+// certReloader picks up renewed TLS certificates from disk and applies them
+// to the running orderer, so certificate renewal does not require a restart.
+// It is triggered by SIGHUP. On any failure the previous certificates remain
+// in effect.
+type certReloader struct {
+	conf    *localconfig.TopLevel
+	loadPEM loadPEMFunc
+
+	// grpcServer is the client-facing gRPC server.
+	grpcServer *comm.GRPCServer
+	// clusterGRPCServer is the intra-cluster gRPC server, nil when the
+	// cluster reuses the client-facing listener.
+	clusterGRPCServer *comm.GRPCServer
+	// clusterDialer creates intra-cluster connections, nil for non-cluster
+	// orderer types.
+	clusterDialer *cluster.PredicateDialer
+}
+
+// reload re-reads every configured TLS certificate and key from disk and
+// swaps them into the respective servers and the cluster dialer.
+func (cr *certReloader) reload() {
+	logger.Info("Reloading TLS certificates from disk")
+
+	if cr.conf.General.TLS.Enabled {
+		cr.reloadServerCertificate(cr.grpcServer, cr.conf.General.TLS.Certificate, cr.conf.General.TLS.PrivateKey, "server")
+	}
+
+	if cr.clusterGRPCServer != nil {
+		cr.reloadServerCertificate(cr.clusterGRPCServer, cr.conf.General.Cluster.ServerCertificate, cr.conf.General.Cluster.ServerPrivateKey, "cluster")
+	}
+
+	if cr.clusterDialer != nil {
+		cr.reloadClusterClientCertificate()
+	}
+}
+
+func (cr *certReloader) reloadServerCertificate(srv *comm.GRPCServer, certPath, keyPath, role string) {
+	certPEM, keyPEM, err := cr.loadKeyPair(certPath, keyPath)
+	if err != nil {
+		logger.Warningf("Keeping previous %s TLS certificate: %s", role, err)
+		return
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		logger.Warningf("Keeping previous %s TLS certificate, failed to load key pair: %s", role, err)
+		return
+	}
+
+	srv.SetServerCertificate(cert)
+	logger.Infof("Updated %s TLS certificate from %s", role, certPath)
+}
+
+func (cr *certReloader) reloadClusterClientCertificate() {
+	certPath := cr.conf.General.Cluster.ClientCertificate
+	keyPath := cr.conf.General.Cluster.ClientPrivateKey
+
+	certPEM, keyPEM, err := cr.loadKeyPair(certPath, keyPath)
+	if err != nil {
+		logger.Warningf("Keeping previous cluster client TLS certificate: %s", err)
+		return
+	}
+
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		logger.Warningf("Keeping previous cluster client TLS certificate, failed to load key pair: %s", err)
+		return
+	}
+
+	cr.clusterDialer.UpdateClientCertificate(certPEM, keyPEM)
+	logger.Infof("Updated cluster client TLS certificate from %s, new intra-cluster connections will use it", certPath)
+}
+
+func (cr *certReloader) loadKeyPair(certPath, keyPath string) ([]byte, []byte, error) {
+	certPEM, err := cr.loadPEM(certPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := cr.loadPEM(keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certPEM, keyPEM, nil
+}
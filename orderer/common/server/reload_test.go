@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/orderer/common/cluster"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertReloader(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	initialKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+	renewedKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "cert-reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	certPath := filepath.Join(tempDir, "server.crt")
+	keyPath := filepath.Join(tempDir, "server.key")
+	assert.NoError(t, ioutil.WriteFile(certPath, initialKeyPair.Cert, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyPath, initialKeyPair.Key, 0600))
+
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{
+		SecOpts: comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: initialKeyPair.Cert,
+			Key:         initialKeyPair.Key,
+		},
+	})
+	assert.NoError(t, err)
+	defer srv.Stop()
+
+	dialer := &cluster.PredicateDialer{}
+
+	reloader := &certReloader{
+		conf: &localconfig.TopLevel{
+			General: localconfig.General{
+				TLS: localconfig.TLS{
+					Enabled:     true,
+					Certificate: certPath,
+					PrivateKey:  keyPath,
+				},
+				Cluster: localconfig.Cluster{
+					ClientCertificate: certPath,
+					ClientPrivateKey:  keyPath,
+				},
+			},
+		},
+		loadPEM:       ioutil.ReadFile,
+		grpcServer:    srv,
+		clusterDialer: dialer,
+	}
+
+	// renew the certificate on disk and reload
+	assert.NoError(t, ioutil.WriteFile(certPath, renewedKeyPair.Cert, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyPath, renewedKeyPair.Key, 0600))
+	reloader.reload()
+
+	renewedCert, err := tls.X509KeyPair(renewedKeyPair.Cert, renewedKeyPair.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, renewedCert.Certificate, srv.ServerCertificate().Certificate)
+	assert.Equal(t, renewedKeyPair.Cert, dialer.Config.SecOpts.Certificate)
+	assert.Equal(t, renewedKeyPair.Key, dialer.Config.SecOpts.Key)
+}
+
+func TestCertReloaderKeepsPreviousCertificateOnFailure(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	assert.NoError(t, err)
+	initialKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	assert.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "cert-reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	certPath := filepath.Join(tempDir, "server.crt")
+	keyPath := filepath.Join(tempDir, "server.key")
+	assert.NoError(t, ioutil.WriteFile(certPath, initialKeyPair.Cert, 0600))
+	assert.NoError(t, ioutil.WriteFile(keyPath, initialKeyPair.Key, 0600))
+
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{
+		SecOpts: comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: initialKeyPair.Cert,
+			Key:         initialKeyPair.Key,
+		},
+	})
+	assert.NoError(t, err)
+	defer srv.Stop()
+
+	reloader := &certReloader{
+		conf: &localconfig.TopLevel{
+			General: localconfig.General{
+				TLS: localconfig.TLS{
+					Enabled:     true,
+					Certificate: certPath,
+					PrivateKey:  keyPath,
+				},
+			},
+		},
+		loadPEM:    ioutil.ReadFile,
+		grpcServer: srv,
+	}
+
+	initialCert, err := tls.X509KeyPair(initialKeyPair.Cert, initialKeyPair.Key)
+	assert.NoError(t, err)
+
+	// a corrupt key pair on disk leaves the previous certificate in effect
+	assert.NoError(t, ioutil.WriteFile(keyPath, []byte("not a key"), 0600))
+	reloader.reload()
+	assert.Equal(t, initialCert.Certificate, srv.ServerCertificate().Certificate)
+
+	// so does a missing file
+	assert.NoError(t, os.Remove(certPath))
+	reloader.reload()
+	assert.Equal(t, initialCert.Certificate, srv.ServerCertificate().Certificate)
+}
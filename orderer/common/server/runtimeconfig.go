@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger/fabric/orderer/common/dedupe"
+	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
+)
+
+// configReloader re-reads orderer.yaml and applies the settings that can
+// change while the process is running, so that tuning them does not require
+// a restart that disturbs Raft leadership. It is exposed on the operations
+// server and triggered by a POST request. Logging specs are already
+// adjustable at runtime through the /logspec endpoint.
+//
+// Settings whose components were not enabled at startup cannot be enabled by
+// a reload; they are reported in the response as requiring a restart.
+type configReloader struct {
+	load func() (*localconfig.TopLevel, error)
+
+	// limiter is the broadcast admission limiter, nil when admission control
+	// was disabled at startup.
+	limiter *ratelimit.Limiter
+	// dedupeCache is the duplicate-transaction suppression cache, nil when
+	// suppression was disabled at startup.
+	dedupeCache *dedupe.Cache
+}
+
+// reloadResult is the JSON body returned to the caller, naming the settings
+// that were applied and those that need a restart to take effect.
+type reloadResult struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required,omitempty"`
+}
+
+func (cr *configReloader) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	conf, err := cr.load()
+	if err != nil {
+		logger.Warningf("Failed reloading configuration: %s", err)
+		resp.WriteHeader(http.StatusInternalServerError)
+		resp.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(resp).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	result := cr.apply(conf)
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(result); err != nil {
+		logger.Warningf("Failed to encode config reload result: %s", err)
+	}
+}
+
+func (cr *configReloader) apply(conf *localconfig.TopLevel) *reloadResult {
+	result := &reloadResult{Applied: []string{}}
+
+	switch {
+	case cr.limiter != nil:
+		limits := localconfig.RateLimits{}
+		if conf.General.RateLimits.Enabled {
+			limits = conf.General.RateLimits
+		}
+		// With disabled or all-zero limits every organization is unlimited,
+		// so disabling admission control by reload works as expected.
+		cr.limiter.Update(rateLimitConfig(limits))
+		result.Applied = append(result.Applied, "General.RateLimits")
+		logger.Info("Reloaded broadcast rate limits")
+	case conf.General.RateLimits.Enabled:
+		result.RestartRequired = append(result.RestartRequired, "General.RateLimits")
+	}
+
+	switch {
+	case cr.dedupeCache != nil:
+		window := time.Duration(0)
+		if conf.General.Dedupe.Enabled {
+			window = conf.General.Dedupe.Window
+		}
+		cr.dedupeCache.SetWindow(window)
+		result.Applied = append(result.Applied, "General.Dedupe")
+		logger.Info("Reloaded duplicate-transaction suppression window")
+	case conf.General.Dedupe.Enabled:
+		result.RestartRequired = append(result.RestartRequired, "General.Dedupe")
+	}
+
+	return result
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/orderer/common/dedupe"
+	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func reloadRequest(t *testing.T, cr *configReloader, method string) (*httptest.ResponseRecorder, *reloadResult) {
+	req := httptest.NewRequest(method, "/config/reload", nil)
+	rec := httptest.NewRecorder()
+	cr.ServeHTTP(rec, req)
+
+	result := &reloadResult{}
+	if rec.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), result))
+	}
+	return rec, result
+}
+
+func TestConfigReloaderAppliesSettings(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Config{
+		DefaultLimit: ratelimit.Limit{TxPerSecond: 1},
+	}, ratelimit.NewMetrics(&disabled.Provider{}))
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	assert.Error(t, limiter.Admit("Org1MSP", 10))
+
+	dedupeCache := dedupe.New(time.Minute)
+	dedupeCache.Record("testchannel", "tx-1")
+
+	cr := &configReloader{
+		load: func() (*localconfig.TopLevel, error) {
+			return &localconfig.TopLevel{
+				General: localconfig.General{
+					RateLimits: localconfig.RateLimits{
+						Enabled:            true,
+						DefaultTxPerSecond: 100,
+					},
+					Dedupe: localconfig.Dedupe{
+						Enabled: false,
+					},
+				},
+			}, nil
+		},
+		limiter:     limiter,
+		dedupeCache: dedupeCache,
+	}
+
+	rec, result := reloadRequest(t, cr, http.MethodPost)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"General.RateLimits", "General.Dedupe"}, result.Applied)
+	assert.Empty(t, result.RestartRequired)
+
+	// the raised rate limit is in effect
+	assert.NoError(t, limiter.Admit("Org1MSP", 10))
+	// disabling dedupe cleared the suppression window
+	assert.False(t, dedupeCache.Seen("testchannel", "tx-1"))
+}
+
+func TestConfigReloaderRestartRequired(t *testing.T) {
+	cr := &configReloader{
+		load: func() (*localconfig.TopLevel, error) {
+			return &localconfig.TopLevel{
+				General: localconfig.General{
+					RateLimits: localconfig.RateLimits{Enabled: true},
+					Dedupe:     localconfig.Dedupe{Enabled: true, Window: time.Minute},
+				},
+			}, nil
+		},
+	}
+
+	rec, result := reloadRequest(t, cr, http.MethodPost)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []string{"General.RateLimits", "General.Dedupe"}, result.RestartRequired)
+}
+
+func TestConfigReloaderLoadFailure(t *testing.T) {
+	cr := &configReloader{
+		load: func() (*localconfig.TopLevel, error) {
+			return nil, errors.New("bad yaml")
+		},
+	}
+
+	rec, _ := reloadRequest(t, cr, http.MethodPost)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "bad yaml")
+}
+
+func TestConfigReloaderMethodNotAllowed(t *testing.T) {
+	cr := &configReloader{}
+	rec, _ := reloadRequest(t, cr, http.MethodGet)
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
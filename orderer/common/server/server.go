@@ -20,9 +20,11 @@ import (
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/dedupe"
 	localconfig "github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/orderer/common/ratelimit"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 )
@@ -90,6 +92,8 @@ func NewServer(
 	timeWindow time.Duration,
 	mutualTLS bool,
 	expirationCheckDisabled bool,
+	admission *ratelimit.Limiter,
+	deduplication *dedupe.Cache,
 ) ab.AtomicBroadcastServer {
 	s := &server{
 		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider), expirationCheckDisabled),
@@ -100,9 +104,36 @@ func NewServer(
 		debug:     debug,
 		Registrar: r,
 	}
+	// The concrete types are only assigned to the interface fields when they
+	// exist, so that the handler's nil checks keep working.
+	if admission != nil {
+		s.bh.Admission = admission
+	}
+	if deduplication != nil {
+		s.bh.Deduplication = deduplication
+	}
 	return s
 }
 
+func rateLimitConfig(rateLimits localconfig.RateLimits) ratelimit.Config {
+	config := ratelimit.Config{
+		DefaultLimit: ratelimit.Limit{
+			TxPerSecond:    rateLimits.DefaultTxPerSecond,
+			BytesPerSecond: rateLimits.DefaultBytesPerSecond,
+		},
+	}
+	for mspID, limit := range rateLimits.Orgs {
+		if config.OrgLimits == nil {
+			config.OrgLimits = map[string]ratelimit.Limit{}
+		}
+		config.OrgLimits[mspID] = ratelimit.Limit{
+			TxPerSecond:    limit.TxPerSecond,
+			BytesPerSecond: limit.BytesPerSecond,
+		}
+	}
+	return config
+}
+
 type msgTracer struct {
 	function string
 	debug    *localconfig.Debug
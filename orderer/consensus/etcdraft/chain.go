@@ -98,6 +98,12 @@ type Options struct {
 	SnapDir              string
 	SnapshotIntervalSize uint32
 
+	// Compression is the scheme applied to block payloads before they are
+	// proposed to Raft, and consequently to the WAL entries and snapshots
+	// derived from them. Payloads are decoded by inspection, so mixing
+	// compressed and uncompressed payloads in existing files is safe.
+	Compression string
+
 	// This is configurable mainly for testing purpose. Users are not
 	// expected to alter this. Instead, DefaultSnapshotCatchUpEntries is used.
 	SnapshotCatchUpEntries uint64
@@ -233,7 +239,10 @@ func NewChain(
 	var snapBlkNum uint64
 	var cc raftpb.ConfState
 	if s := storage.Snapshot(); !raft.IsEmptySnap(s) {
-		b := protoutil.UnmarshalBlockOrPanic(s.Data)
+		b, err := blockFromPayload(s.Data)
+		if err != nil {
+			return nil, errors.Errorf("failed to unmarshal snapshot data to block: %s", err)
+		}
 		snapBlkNum = b.Header.Number
 		cc = s.Metadata.ConfState
 	}
@@ -273,6 +282,10 @@ func NewChain(
 			ActiveNodes:             opts.Metrics.ActiveNodes.With("channel", support.ChannelID()),
 			CommittedBlockNumber:    opts.Metrics.CommittedBlockNumber.With("channel", support.ChannelID()),
 			SnapshotBlockNumber:     opts.Metrics.SnapshotBlockNumber.With("channel", support.ChannelID()),
+			RaftTerm:                opts.Metrics.RaftTerm.With("channel", support.ChannelID()),
+			LeaderID:                opts.Metrics.LeaderID.With("channel", support.ChannelID()),
+			ConsenterCommitLag:      opts.Metrics.ConsenterCommitLag.With("channel", support.ChannelID()),
+			BlocksSinceSnapshot:     opts.Metrics.BlocksSinceSnapshot.With("channel", support.ChannelID()),
 			LeaderChanges:           opts.Metrics.LeaderChanges.With("channel", support.ChannelID()),
 			ProposalFailures:        opts.Metrics.ProposalFailures.With("channel", support.ChannelID()),
 			DataPersistDuration:     opts.Metrics.DataPersistDuration.With("channel", support.ChannelID()),
@@ -290,6 +303,7 @@ func NewChain(
 	c.Metrics.ActiveNodes.Set(float64(0))
 	c.Metrics.CommittedBlockNumber.Set(float64(c.lastBlock.Header.Number))
 	c.Metrics.SnapshotBlockNumber.Set(float64(c.lastSnapBlockNum))
+	c.Metrics.BlocksSinceSnapshot.Set(float64(c.lastBlock.Header.Number - c.lastSnapBlockNum))
 
 	// DO NOT use Applied option in config, see https://github.com/etcd-io/etcd/issues/10217
 	// We guard against replay of written blocks with `appliedIndex` instead.
@@ -324,11 +338,12 @@ func NewChain(
 		clock:        c.clock,
 		metadata:     c.opts.BlockMetadata,
 		tracker: &Tracker{
-			id:     c.raftID,
-			sender: disseminator,
-			gauge:  c.Metrics.ActiveNodes,
-			active: &c.ActiveNodes,
-			logger: c.logger,
+			id:      c.raftID,
+			sender:  disseminator,
+			gauge:   c.Metrics.ActiveNodes,
+			metrics: c.Metrics,
+			active:  &c.ActiveNodes,
+			logger:  c.logger,
 		},
 	}
 
@@ -432,6 +447,68 @@ func (c *Chain) Halt() {
 	}
 }
 
+// TransferLeadership asks the Raft leader of the chain to hand leadership
+// over to the consenter with the given raft ID and waits for the transfer to
+// take effect, so the leader node can be taken down for maintenance without
+// waiting for an election to disrupt the cluster. It must be invoked on the
+// current leader.
+func (c *Chain) TransferLeadership(transferee uint64) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	c.raftMetadataLock.RLock()
+	_, exists := c.opts.Consenters[transferee]
+	c.raftMetadataLock.RUnlock()
+
+	if !exists {
+		return errors.Errorf("consenter with raft id %d is not part of the channel", transferee)
+	}
+
+	status := c.Node.Status()
+	if status.RaftState != raft.StateLeader {
+		return errors.Errorf("leadership can only be transferred from the leader, this node is a %s", status.RaftState)
+	}
+
+	if transferee == status.ID {
+		c.logger.Infof("This node is already the leader, nothing to transfer")
+		return nil
+	}
+
+	return c.Node.transferLeadershipTo(status.ID, transferee)
+}
+
+// HealthCheck checks the health of the chain for the operations health
+// endpoint. The chain is unhealthy when it has no view of a Raft leader, or,
+// on the leader, when less than a quorum of consenters is active.
+func (c *Chain) HealthCheck(ctx context.Context) error {
+	if err := c.isRunning(); err != nil {
+		return err
+	}
+
+	status := c.Node.Status()
+	if status.Lead == raft.None {
+		return errors.New("no Raft leader")
+	}
+
+	// only the leader has a view of which consenters are active
+	if status.RaftState != raft.StateLeader {
+		return nil
+	}
+
+	active := len(c.ActiveNodes.Load().([]uint64))
+	c.raftMetadataLock.RLock()
+	total := len(c.opts.Consenters)
+	c.raftMetadataLock.RUnlock()
+
+	quorum := total/2 + 1
+	if active < quorum {
+		return errors.Errorf("%d out of %d consenters are active, quorum is %d", active, total, quorum)
+	}
+
+	return nil
+}
+
 func (c *Chain) isRunning() error {
 	select {
 	case <-c.startC:
@@ -584,7 +661,7 @@ func (c *Chain) run() {
 			for {
 				select {
 				case b := <-ch:
-					data := protoutil.MarshalOrPanic(b)
+					data := compressPayload(c.opts.Compression, protoutil.MarshalOrPanic(b))
 					if err := c.Node.Propose(ctx, data); err != nil {
 						c.logger.Errorf("Failed to propose block [%d] to raft and discard %d blocks in queue: %s", b.Header.Number, len(ch), err)
 						return
@@ -809,9 +886,11 @@ func (c *Chain) writeBlock(block *common.Block, index uint64) {
 
 // Orders the envelope in the `msg` content. SubmitRequest.
 // Returns
-//   -- batches [][]*common.Envelope; the batches cut,
-//   -- pending bool; if there are envelopes pending to be ordered,
-//   -- err error; the error encountered, if any.
+//
+//	-- batches [][]*common.Envelope; the batches cut,
+//	-- pending bool; if there are envelopes pending to be ordered,
+//	-- err error; the error encountered, if any.
+//
 // It takes care of config messages as well as the revalidation of messages if the config sequence has advanced.
 func (c *Chain) ordered(msg *orderer.SubmitRequest) (batches [][]*common.Envelope, pending bool, err error) {
 	seq := c.support.Sequence()
@@ -871,7 +950,7 @@ func (c *Chain) propose(ch chan<- *common.Block, bc *blockCreator, batches ...[]
 }
 
 func (c *Chain) catchUp(snap *raftpb.Snapshot) error {
-	b, err := protoutil.UnmarshalBlock(snap.Data)
+	b, err := blockFromPayload(snap.Data)
 	if err != nil {
 		return errors.Errorf("failed to unmarshal snapshot data to block: %s", err)
 	}
@@ -981,9 +1060,10 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 				break
 			}
 
-			block := protoutil.UnmarshalBlockOrPanic(ents[i].Data)
+			block := blockFromPayloadOrPanic(ents[i].Data)
 			c.writeBlock(block, ents[i].Index)
 			c.Metrics.CommittedBlockNumber.Set(float64(block.Header.Number))
+			c.Metrics.BlocksSinceSnapshot.Set(float64(block.Header.Number - c.lastSnapBlockNum))
 
 		case raftpb.EntryConfChange:
 			var cc raftpb.ConfChange
@@ -1048,7 +1128,7 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 	}
 
 	if c.accDataSize >= c.sizeLimit {
-		b := protoutil.UnmarshalBlockOrPanic(ents[position].Data)
+		b := blockFromPayloadOrPanic(ents[position].Data)
 
 		select {
 		case c.gcC <- &gc{index: c.appliedIndex, state: c.confState, data: ents[position].Data}:
@@ -1058,6 +1138,7 @@ func (c *Chain) apply(ents []raftpb.Entry) {
 			c.accDataSize = 0
 			c.lastSnapBlockNum = b.Header.Number
 			c.Metrics.SnapshotBlockNumber.Set(float64(b.Header.Number))
+			c.Metrics.BlocksSinceSnapshot.Set(0)
 		default:
 			c.logger.Warnf("Snapshotting is in progress, it is very likely that SnapshotIntervalSize is too small")
 		}
@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"os/user"
 	"path"
@@ -258,6 +259,11 @@ var _ = Describe("Chain", func() {
 		})
 
 		Context("when no Raft leader is elected", func() {
+			It("fails to transfer leadership", func() {
+				err := chain.TransferLeadership(1)
+				Expect(err).To(MatchError("leadership can only be transferred from the leader, this node is a StateFollower"))
+			})
+
 			It("fails to order envelope", func() {
 				err := chain.Order(env, 0)
 				Expect(err).To(MatchError("no Raft leader"))
@@ -291,6 +297,50 @@ var _ = Describe("Chain", func() {
 				Expect(fakeFields.fakeLeaderChanges.AddArgsForCall(0)).To(Equal(float64(1)))
 			})
 
+			Context("when snappy compression is enabled", func() {
+				BeforeEach(func() {
+					opts.Compression = etcdraft.CompressionSnappy
+				})
+
+				It("compresses proposed payloads and still commits blocks", func() {
+					close(cutter.Block)
+					cutter.CutNext = true
+
+					Expect(chain.Order(env, 0)).To(Succeed())
+					Eventually(support.WriteBlockCallCount, LongEventualTimeout).Should(Equal(1))
+					b, _ := support.WriteBlockArgsForCall(0)
+					Expect(b.Header.Number).To(Equal(uint64(1)))
+
+					firstIndex, err := storage.FirstIndex()
+					Expect(err).NotTo(HaveOccurred())
+					lastIndex, err := storage.LastIndex()
+					Expect(err).NotTo(HaveOccurred())
+					entries, err := storage.Entries(firstIndex, lastIndex+1, math.MaxUint64)
+					Expect(err).NotTo(HaveOccurred())
+
+					// A marshaled block never begins with a zero byte, so a leading
+					// zero indicates the compressed payload framing.
+					var compressed int
+					for _, e := range entries {
+						if e.Type == raftpb.EntryNormal && len(e.Data) > 0 {
+							Expect(e.Data[0]).To(Equal(byte(0)))
+							compressed++
+						}
+					}
+					Expect(compressed).To(Equal(1))
+				})
+			})
+
+			It("refuses to transfer leadership to an unknown consenter", func() {
+				err := chain.TransferLeadership(2)
+				Expect(err).To(MatchError("consenter with raft id 2 is not part of the channel"))
+			})
+
+			It("does nothing when asked to transfer leadership to itself", func() {
+				err := chain.TransferLeadership(1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
 			It("fails to order envelope if chain is halted", func() {
 				chain.Halt()
 				err := chain.Order(env, 0)
@@ -3651,9 +3701,9 @@ func (n *network) exec(f func(c *chain), ids ...uint64) {
 //
 // expectLeaderChange controls whether leader change should
 // be observed on newly joined node.
-// - it should be true if newly joined node was leader
-// - it should be false if newly joined node was follower, and
-//   already knows the leader.
+//   - it should be true if newly joined node was leader
+//   - it should be false if newly joined node was follower, and
+//     already knows the leader.
 func (n *network) join(id uint64, expectLeaderChange bool) {
 	n.connect(id)
 
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"bytes"
+
+	"github.com/golang/snappy"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Compression schemes supported for Raft entry payloads and snapshot data.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+)
+
+// compressedPayloadPrefix marks a snappy-compressed payload. An uncompressed
+// payload is a marshaled block, which always begins with a valid protobuf tag
+// byte and therefore never with a zero byte, so payloads written before
+// compression was enabled - or after it was disabled again - are decoded
+// unambiguously. This is what allows compressed and uncompressed payloads to
+// coexist in the same WAL and snapshot files.
+var compressedPayloadPrefix = []byte{0x00, 's', 'n', 'p', 'y'}
+
+// parseCompression validates the configured compression scheme, treating an
+// empty value as CompressionNone.
+func parseCompression(scheme string) (string, error) {
+	switch scheme {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionSnappy:
+		return CompressionSnappy, nil
+	default:
+		return "", errors.Errorf("unsupported compression scheme '%s', expected '%s' or '%s'", scheme, CompressionNone, CompressionSnappy)
+	}
+}
+
+// compressPayload encodes data according to the given scheme. With
+// CompressionNone the data is returned as is.
+func compressPayload(scheme string, data []byte) []byte {
+	if scheme != CompressionSnappy {
+		return data
+	}
+
+	encoded := snappy.Encode(nil, data)
+	payload := make([]byte, 0, len(compressedPayloadPrefix)+len(encoded))
+	payload = append(payload, compressedPayloadPrefix...)
+	return append(payload, encoded...)
+}
+
+// decompressPayload decodes a payload regardless of how it was written, so a
+// node can replay entries and snapshots recorded under a different
+// compression setting than the one it currently runs with.
+func decompressPayload(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, compressedPayloadPrefix) {
+		return data, nil
+	}
+
+	decoded, err := snappy.Decode(nil, data[len(compressedPayloadPrefix):])
+	if err != nil {
+		return nil, errors.Errorf("failed to decompress payload: %s", err)
+	}
+	return decoded, nil
+}
+
+// blockFromPayload unmarshals a Raft entry or snapshot payload into a block,
+// transparently decompressing it when necessary.
+func blockFromPayload(data []byte) (*common.Block, error) {
+	decoded, err := decompressPayload(data)
+	if err != nil {
+		return nil, err
+	}
+	return protoutil.UnmarshalBlock(decoded)
+}
+
+// blockFromPayloadOrPanic unmarshals a Raft entry or snapshot payload into a
+// block, and panics if the payload cannot be decoded.
+func blockFromPayloadOrPanic(data []byte) *common.Block {
+	block, err := blockFromPayload(data)
+	if err != nil {
+		panic(err)
+	}
+	return block
+}
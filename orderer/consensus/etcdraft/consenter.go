@@ -14,6 +14,7 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-lib-go/healthz"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
@@ -40,6 +41,12 @@ type InactiveChainRegistry interface {
 	TrackChain(chainName string, genesisBlock *common.Block, createChain func())
 }
 
+// HealthChecker registers per-channel health checkers with the operations
+// health endpoint.
+type HealthChecker interface {
+	RegisterChecker(component string, checker healthz.HealthChecker) error
+}
+
 //go:generate mockery -dir . -name ChainGetter -case underscore -output mocks
 
 // ChainGetter obtains instances of ChainSupport for the given channel
@@ -55,6 +62,7 @@ type Config struct {
 	WALDir            string // WAL data of <my-channel> is stored in WALDir/<my-channel>
 	SnapDir           string // Snapshots of <my-channel> are stored in SnapDir/<my-channel>
 	EvictionSuspicion string // Duration threshold that the node samples in order to suspect its eviction from the channel.
+	Compression       string // Compression scheme for Raft entry payloads and snapshots, either "none" (default) or "snappy".
 }
 
 // Consenter implements etcdraft consenter
@@ -71,6 +79,7 @@ type Consenter struct {
 	Cert           []byte
 	Metrics        *Metrics
 	BCCSP          bccsp.BCCSP
+	HealthChecker  HealthChecker
 }
 
 // TargetChannel extracts the channel from the given proto.Message.
@@ -156,13 +165,24 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 
 	consenters := CreateConsentersMap(blockMetadata, m)
 
+	if c.OrdererConfig.General.Role == localconfig.RoleReplica {
+		c.Logger.Infof("This node is a deliver-only replica; following channel %s without joining consensus", support.ChannelID())
+		c.Metrics.IsVoter.With("channel", support.ChannelID()).Set(0)
+		c.InactiveChainRegistry.TrackChain(support.ChannelID(), support.Block(0), func() {
+			c.CreateChain(support.ChannelID())
+		})
+		return &inactive.Chain{Err: errors.Errorf("channel %s is served by a deliver-only replica", support.ChannelID())}, nil
+	}
+
 	id, err := c.detectSelfID(consenters)
 	if err != nil {
+		c.Metrics.IsVoter.With("channel", support.ChannelID()).Set(0)
 		c.InactiveChainRegistry.TrackChain(support.ChannelID(), support.Block(0), func() {
 			c.CreateChain(support.ChannelID())
 		})
 		return &inactive.Chain{Err: errors.Errorf("channel %s is not serviced by me", support.ChannelID())}, nil
 	}
+	c.Metrics.IsVoter.With("channel", support.ChannelID()).Set(1)
 
 	var evictionSuspicion time.Duration
 	if c.EtcdRaftConfig.EvictionSuspicion == "" {
@@ -175,6 +195,11 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		}
 	}
 
+	compression, err := parseCompression(c.EtcdRaftConfig.Compression)
+	if err != nil {
+		c.Logger.Panicf("Failed parsing Consensus.Compression: %v", err)
+	}
+
 	tickInterval, err := time.ParseDuration(m.Options.TickInterval)
 	if err != nil {
 		return nil, errors.Errorf("failed to parse TickInterval (%s) to time duration", m.Options.TickInterval)
@@ -201,6 +226,7 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		WALDir:            path.Join(c.EtcdRaftConfig.WALDir, support.ChannelID()),
 		SnapDir:           path.Join(c.EtcdRaftConfig.SnapDir, support.ChannelID()),
 		EvictionSuspicion: evictionSuspicion,
+		Compression:       compression,
 		Cert:              c.Cert,
 		Metrics:           c.Metrics,
 	}
@@ -212,7 +238,7 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		Comm:          c.Communication,
 		StreamsByType: cluster.NewStreamsByType(),
 	}
-	return NewChain(
+	chain, err := NewChain(
 		support,
 		opts,
 		c.Communication,
@@ -226,6 +252,17 @@ func (c *Consenter) HandleChain(support consensus.ConsenterSupport, metadata *co
 		},
 		nil,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.HealthChecker != nil {
+		if err := c.HealthChecker.RegisterChecker(support.ChannelID(), chain); err != nil {
+			c.Logger.Warningf("Failed to register health checker for channel %s: %s", support.ChannelID(), err)
+		}
+	}
+
+	return chain, nil
 }
 
 // ReadBlockMetadata attempts to read raft metadata from block metadata, if available.
@@ -261,6 +298,7 @@ func New(
 	r *multichannel.Registrar,
 	icr InactiveChainRegistry,
 	metricsProvider metrics.Provider,
+	healthChecker HealthChecker,
 	bccsp bccsp.BCCSP,
 ) *Consenter {
 	logger := flogging.MustGetLogger("orderer.consensus.etcdraft")
@@ -282,6 +320,7 @@ func New(
 		Metrics:               NewMetrics(metricsProvider),
 		InactiveChainRegistry: icr,
 		BCCSP:                 bccsp,
+		HealthChecker:         healthChecker,
 	}
 	consenter.Dispatcher = &Dispatcher{
 		Logger:        logger,
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/orderer/common/cluster"
 	clustermocks "github.com/hyperledger/fabric/orderer/common/cluster/mocks"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
 	"github.com/hyperledger/fabric/orderer/common/multichannel"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
 	"github.com/hyperledger/fabric/orderer/consensus/etcdraft/mocks"
@@ -237,6 +238,42 @@ var _ = Describe("Consenter", func() {
 		consenter.icr.AssertNumberOfCalls(testingInstance, "TrackChain", 1)
 	})
 
+	It("returns an inactive chain when the node is a deliver-only replica", func() {
+		m := &etcdraftproto.ConfigMetadata{
+			Consenters: []*etcdraftproto.Consenter{
+				{ServerTlsCert: certAsPEM},
+			},
+			Options: &etcdraftproto.Options{
+				TickInterval:      "500ms",
+				ElectionTick:      10,
+				HeartbeatTick:     1,
+				MaxInflightBlocks: 5,
+			},
+		}
+		metadata := protoutil.MarshalOrPanic(m)
+		support := &consensusmocks.FakeConsenterSupport{}
+		mockOrderer := &mocks.OrdererConfig{}
+		mockOrderer.ConsensusMetadataReturns(metadata)
+		mockOrderer.BatchSizeReturns(
+			&orderer.BatchSize{
+				PreferredMaxBytes: 2 * 1024 * 1024,
+			},
+		)
+		support.SharedConfigReturns(mockOrderer)
+		support.ChannelIDReturns("foo")
+
+		consenter := newConsenter(chainGetter)
+		consenter.OrdererConfig.General.Role = localconfig.RoleReplica
+
+		// Even though our certificate is in the consenter set, the replica
+		// role must keep us out of consensus.
+		chain, err := consenter.HandleChain(support, &common.Metadata{})
+		Expect(chain).To(Not(BeNil()))
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(chain.Order(nil, 0).Error()).To(Equal("channel foo is served by a deliver-only replica"))
+		consenter.icr.AssertNumberOfCalls(testingInstance, "TrackChain", 1)
+	})
+
 	It("fails to handle chain if etcdraft options have not been provided", func() {
 		m := &etcdraftproto.ConfigMetadata{
 			Consenters: []*etcdraftproto.Consenter{
@@ -312,6 +349,7 @@ func newConsenter(chainGetter *mocks.ChainGetter) *consenter {
 		InactiveChainRegistry: icr,
 		Communication:         communicator,
 		Cert:                  certAsPEM,
+		Metrics:               newFakeMetrics(newFakeMetricsFields()),
 		Logger:                flogging.MustGetLogger("test"),
 		Chains:                chainGetter,
 		Dispatcher: &etcdraft.Dispatcher{
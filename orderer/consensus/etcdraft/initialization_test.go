@@ -36,6 +36,7 @@ func TestNewEtcdRaftConsenter(t *testing.T) {
 		}, srv, &multichannel.Registrar{},
 		&mocks.InactiveChainRegistry{},
 		&disabled.Provider{},
+		nil,
 		cryptoProvider,
 	)
 
@@ -81,6 +81,46 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	raftTermOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "raft_term",
+		Help:         "The current Raft term of this channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	leaderIDOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "leader_id",
+		Help:         "The Raft ID of the current leader of this channel, 0 if there is no leader.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	consenterCommitLagOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "consenter_commit_lag",
+		Help:         "The number of Raft entries the consenter trails the leader's commit index by, observed on the leader.",
+		LabelNames:   []string{"channel", "consenter_id"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{consenter_id}",
+	}
+	blocksSinceSnapshotOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "blocks_since_snapshot",
+		Help:         "The number of blocks committed since the latest snapshot was taken.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	isVoterOpts = metrics.GaugeOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "is_voter",
+		Help:         "Whether this node participates in consensus for this channel: 1 if it is a voter, 0 if it is a deliver-only replica.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 	configProposalsReceivedOpts = metrics.CounterOpts{
 		Namespace:    "consensus",
 		Subsystem:    "etcdraft",
@@ -94,9 +134,14 @@ var (
 type Metrics struct {
 	ClusterSize             metrics.Gauge
 	IsLeader                metrics.Gauge
+	IsVoter                 metrics.Gauge
 	ActiveNodes             metrics.Gauge
 	CommittedBlockNumber    metrics.Gauge
 	SnapshotBlockNumber     metrics.Gauge
+	RaftTerm                metrics.Gauge
+	LeaderID                metrics.Gauge
+	ConsenterCommitLag      metrics.Gauge
+	BlocksSinceSnapshot     metrics.Gauge
 	LeaderChanges           metrics.Counter
 	ProposalFailures        metrics.Counter
 	DataPersistDuration     metrics.Histogram
@@ -108,9 +153,14 @@ func NewMetrics(p metrics.Provider) *Metrics {
 	return &Metrics{
 		ClusterSize:             p.NewGauge(clusterSizeOpts),
 		IsLeader:                p.NewGauge(isLeaderOpts),
+		IsVoter:                 p.NewGauge(isVoterOpts),
 		ActiveNodes:             p.NewGauge(ActiveNodesOpts),
 		CommittedBlockNumber:    p.NewGauge(committedBlockNumberOpts),
 		SnapshotBlockNumber:     p.NewGauge(snapshotBlockNumberOpts),
+		RaftTerm:                p.NewGauge(raftTermOpts),
+		LeaderID:                p.NewGauge(leaderIDOpts),
+		ConsenterCommitLag:      p.NewGauge(consenterCommitLagOpts),
+		BlocksSinceSnapshot:     p.NewGauge(blocksSinceSnapshotOpts),
 		LeaderChanges:           p.NewCounter(leaderChangesOpts),
 		ProposalFailures:        p.NewCounter(proposalFailuresOpts),
 		DataPersistDuration:     p.NewHistogram(dataPersistDurationOpts),
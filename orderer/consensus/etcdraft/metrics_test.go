@@ -37,14 +37,19 @@ var _ = Describe("Metrics", func() {
 			metrics := etcdraft.NewMetrics(fakeProvider)
 
 			Expect(metrics).NotTo(BeNil())
-			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(5))
+			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(10))
 			Expect(fakeProvider.NewCounterCallCount()).To(Equal(4))
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
 
 			Expect(metrics.ClusterSize).To(Equal(fakeGauge))
 			Expect(metrics.IsLeader).To(Equal(fakeGauge))
+			Expect(metrics.IsVoter).To(Equal(fakeGauge))
 			Expect(metrics.CommittedBlockNumber).To(Equal(fakeGauge))
 			Expect(metrics.SnapshotBlockNumber).To(Equal(fakeGauge))
+			Expect(metrics.RaftTerm).To(Equal(fakeGauge))
+			Expect(metrics.LeaderID).To(Equal(fakeGauge))
+			Expect(metrics.ConsenterCommitLag).To(Equal(fakeGauge))
+			Expect(metrics.BlocksSinceSnapshot).To(Equal(fakeGauge))
 			Expect(metrics.LeaderChanges).To(Equal(fakeCounter))
 			Expect(metrics.ProposalFailures).To(Equal(fakeCounter))
 			Expect(metrics.DataPersistDuration).To(Equal(fakeHistogram))
@@ -58,9 +63,14 @@ func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 	return &etcdraft.Metrics{
 		ClusterSize:             fakeFields.fakeClusterSize,
 		IsLeader:                fakeFields.fakeIsLeader,
+		IsVoter:                 fakeFields.fakeIsVoter,
 		ActiveNodes:             fakeFields.fakeActiveNodes,
 		CommittedBlockNumber:    fakeFields.fakeCommittedBlockNumber,
 		SnapshotBlockNumber:     fakeFields.fakeSnapshotBlockNumber,
+		RaftTerm:                fakeFields.fakeRaftTerm,
+		LeaderID:                fakeFields.fakeLeaderID,
+		ConsenterCommitLag:      fakeFields.fakeConsenterCommitLag,
+		BlocksSinceSnapshot:     fakeFields.fakeBlocksSinceSnapshot,
 		LeaderChanges:           fakeFields.fakeLeaderChanges,
 		ProposalFailures:        fakeFields.fakeProposalFailures,
 		DataPersistDuration:     fakeFields.fakeDataPersistDuration,
@@ -72,9 +82,14 @@ func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 type fakeMetricsFields struct {
 	fakeClusterSize             *metricsfakes.Gauge
 	fakeIsLeader                *metricsfakes.Gauge
+	fakeIsVoter                 *metricsfakes.Gauge
 	fakeActiveNodes             *metricsfakes.Gauge
 	fakeCommittedBlockNumber    *metricsfakes.Gauge
 	fakeSnapshotBlockNumber     *metricsfakes.Gauge
+	fakeRaftTerm                *metricsfakes.Gauge
+	fakeLeaderID                *metricsfakes.Gauge
+	fakeConsenterCommitLag      *metricsfakes.Gauge
+	fakeBlocksSinceSnapshot     *metricsfakes.Gauge
 	fakeLeaderChanges           *metricsfakes.Counter
 	fakeProposalFailures        *metricsfakes.Counter
 	fakeDataPersistDuration     *metricsfakes.Histogram
@@ -86,9 +101,14 @@ func newFakeMetricsFields() *fakeMetricsFields {
 	return &fakeMetricsFields{
 		fakeClusterSize:             newFakeGauge(),
 		fakeIsLeader:                newFakeGauge(),
+		fakeIsVoter:                 newFakeGauge(),
 		fakeActiveNodes:             newFakeGauge(),
 		fakeCommittedBlockNumber:    newFakeGauge(),
 		fakeSnapshotBlockNumber:     newFakeGauge(),
+		fakeRaftTerm:                newFakeGauge(),
+		fakeLeaderID:                newFakeGauge(),
+		fakeConsenterCommitLag:      newFakeGauge(),
+		fakeBlocksSinceSnapshot:     newFakeGauge(),
 		fakeLeaderChanges:           newFakeCounter(),
 		fakeProposalFailures:        newFakeCounter(),
 		fakeDataPersistDuration:     newFakeHistogram(),
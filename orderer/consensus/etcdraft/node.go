@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"go.etcd.io/etcd/raft"
 	"go.etcd.io/etcd/raft/raftpb"
 )
@@ -276,6 +277,38 @@ func (n *node) abdicateLeader(currentLead uint64) {
 	}
 }
 
+// transferLeadershipTo initiates a leadership transfer from the current
+// leader to the given transferee and waits for the leader change to be
+// observed, up to an election timeout.
+func (n *node) transferLeadershipTo(currentLead, transferee uint64) error {
+	// register a leader subscriberC
+	notifyc := make(chan uint64, 1)
+	select {
+	case n.subscriberC <- notifyc:
+	case <-n.chain.doneC:
+		return errors.New("chain is stopped")
+	}
+
+	n.logger.Infof("Transferring leadership to %d", transferee)
+	n.TransferLeadership(context.TODO(), currentLead, transferee)
+
+	timer := n.clock.NewTimer(time.Duration(n.config.ElectionTick) * n.tickInterval)
+	defer timer.Stop() // prevent timer leak
+
+	select {
+	case <-timer.C():
+		return errors.Errorf("timed out waiting for leadership to transfer to %d", transferee)
+	case l := <-notifyc:
+		if l != transferee {
+			return errors.Errorf("leadership was transferred to %d instead of %d", l, transferee)
+		}
+		n.logger.Infof("Leader has been transferred from %d to %d", currentLead, l)
+		return nil
+	case <-n.chain.doneC:
+		return nil
+	}
+}
+
 func (n *node) logSendFailure(dest uint64, err error) {
 	if _, ok := n.unreachable[dest]; ok {
 		n.logger.Debugf("Failed to send StepRequest to %d, because: %s", dest, err)
@@ -7,6 +7,7 @@
 package etcdraft
 
 import (
+	"strconv"
 	"sync/atomic"
 
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
@@ -19,10 +20,11 @@ import (
 // Tracker periodically poll Raft Status, and update disseminator
 // so that status is populated to followers.
 type Tracker struct {
-	id     uint64
-	sender *Disseminator
-	gauge  metrics.Gauge
-	active *atomic.Value
+	id      uint64
+	sender  *Disseminator
+	gauge   metrics.Gauge
+	metrics *Metrics
+	active  *atomic.Value
 
 	counter int
 
@@ -30,6 +32,9 @@ type Tracker struct {
 }
 
 func (t *Tracker) Check(status *raft.Status) {
+	t.metrics.RaftTerm.Set(float64(status.Term))
+	t.metrics.LeaderID.Set(float64(status.Lead)) // raft.None is reported as 0
+
 	// leaderless
 	if status.Lead == raft.None {
 		t.gauge.Set(0)
@@ -42,7 +47,15 @@ func (t *Tracker) Check(status *raft.Status) {
 		return
 	}
 
-	// leader
+	// leader; only the leader knows how far each consenter trails its commit index
+	for id, progress := range status.Progress {
+		var lag uint64
+		if progress.Match < status.Commit {
+			lag = status.Commit - progress.Match
+		}
+		t.metrics.ConsenterCommitLag.With("consenter_id", strconv.FormatUint(id, 10)).Set(float64(lag))
+	}
+
 	current := []uint64{t.id}
 	for id, progress := range status.Progress {
 
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -17,16 +18,31 @@ import (
 // Application is a copy of the orderer configuration with the addition of an anchor peers
 // list in the organization definition.
 type Application struct {
-	Organizations []Organization
-	Capabilities  map[string]bool
-	Policies      map[string]Policy
-	ACLs          map[string]string
+	Organizations []Organization    `json:"organizations,omitempty" yaml:"organizations,omitempty"`
+	Capabilities  map[string]bool   `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	Policies      map[string]Policy `json:"policies,omitempty" yaml:"policies,omitempty"`
+	ACLs          map[string]string `json:"acls,omitempty" yaml:"acls,omitempty"`
 }
 
 // AnchorPeer defines the endpoint of peers for each application organization.
 type AnchorPeer struct {
-	Host string
-	Port int
+	Host string `json:"host" yaml:"host"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+// AddApplicationOrg adds an organization to an existing config's Application configuration.
+// Will not error if organization already exists.
+func AddApplicationOrg(config *cb.Config, org Organization) error {
+	appGroup := config.ChannelGroup.Groups[ApplicationGroupKey]
+
+	orgGroup, err := newOrgConfigGroup(org)
+	if err != nil {
+		return fmt.Errorf("failed to create application org '%s': %v", org.Name, err)
+	}
+
+	appGroup.Groups[org.Name] = orgGroup
+
+	return nil
 }
 
 // AddAnchorPeer adds an anchor peer to an existing channel config transaction.
@@ -173,6 +189,22 @@ func newApplicationGroup(application Application) (*cb.ConfigGroup, error) {
 	return applicationGroup, nil
 }
 
+// SetACLs sets an application's resources based ACL definitions, replacing
+// any existing ACL configuration.
+func SetACLs(config *cb.Config, acls map[string]string) error {
+	applicationGroup, ok := config.ChannelGroup.Groups[ApplicationGroupKey]
+	if !ok {
+		return errors.New("application group does not exist in channel config")
+	}
+
+	err := addValue(applicationGroup, aclValues(acls), AdminsPolicyKey)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // aclValues returns the config definition for an application's resources based ACL definitions.
 // It is a value for the /Channel/Application/.
 func aclValues(acls map[string]string) *standardConfigValue {
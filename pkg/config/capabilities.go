@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+var capabilityRegex = regexp.MustCompile(`^V([0-9]+)_([0-9]+)$`)
+
+// CapabilityUpdate describes a single capability change emitted by
+// PlanCapabilityMigration.
+type CapabilityUpdate struct {
+	// GroupPath is the config group the capability is enabled in, one of
+	// Channel, Channel/Orderer, or Channel/Application.
+	GroupPath string
+	// Capability is the capability level to enable, e.g. "V2_0".
+	Capability string
+}
+
+// CapabilityMigrationPlan is the ordered set of config updates required to
+// raise a channel to a target capability level, together with warnings about
+// operational prerequisites the config itself cannot verify.
+type CapabilityMigrationPlan struct {
+	Updates  []CapabilityUpdate
+	Warnings []string
+}
+
+// PlanCapabilityMigration computes the config updates required to enable the
+// target capability in every group present in the config. Updates are ordered
+// the way they should be submitted: the Orderer group first, then the Channel
+// group, and the Application group last, since application capabilities must
+// not be enabled before all peers have been upgraded. Groups already at or
+// above the target level require no update. A target below a group's current
+// capability level is rejected, as capabilities cannot be downgraded.
+func PlanCapabilityMigration(config *cb.Config, target string) (CapabilityMigrationPlan, error) {
+	targetMajor, targetMinor, err := parseCapability(target)
+	if err != nil {
+		return CapabilityMigrationPlan{}, err
+	}
+
+	plan := CapabilityMigrationPlan{}
+
+	groups := []struct {
+		path  string
+		group *cb.ConfigGroup
+	}{
+		{ChannelGroupKey + "/" + OrdererGroupKey, config.ChannelGroup.Groups[OrdererGroupKey]},
+		{ChannelGroupKey, config.ChannelGroup},
+		{ChannelGroupKey + "/" + ApplicationGroupKey, config.ChannelGroup.Groups[ApplicationGroupKey]},
+	}
+
+	for _, g := range groups {
+		if g.group == nil {
+			continue
+		}
+
+		capabilities, err := capabilitiesFromGroup(g.group)
+		if err != nil {
+			return CapabilityMigrationPlan{}, fmt.Errorf("reading capabilities of group %s: %v", g.path, err)
+		}
+
+		atTarget := false
+		for capability := range capabilities {
+			major, minor, err := parseCapability(capability)
+			if err != nil {
+				return CapabilityMigrationPlan{}, fmt.Errorf("group %s: %v", g.path, err)
+			}
+
+			if major > targetMajor || (major == targetMajor && minor > targetMinor) {
+				return CapabilityMigrationPlan{}, fmt.Errorf("group %s already has capability %s, capabilities cannot be downgraded to %s", g.path, capability, target)
+			}
+
+			if major == targetMajor && minor == targetMinor {
+				atTarget = true
+			}
+		}
+
+		if atTarget {
+			continue
+		}
+
+		plan.Updates = append(plan.Updates, CapabilityUpdate{GroupPath: g.path, Capability: target})
+
+		switch g.path {
+		case ChannelGroupKey + "/" + OrdererGroupKey:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("all ordering nodes must run a version supporting %s before it is enabled in the Orderer group", target))
+		case ChannelGroupKey:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the Channel capability %s applies to both orderers and peers, all nodes must support it", target))
+		case ChannelGroupKey + "/" + ApplicationGroupKey:
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("all peers must run a version supporting %s before it is enabled in the Application group", target))
+		}
+	}
+
+	// the V2_0 application capability activates the new chaincode lifecycle,
+	// which requires a LifecycleEndorsement policy to be defined
+	if applicationGroup, ok := config.ChannelGroup.Groups[ApplicationGroupKey]; ok && targetMajor >= 2 {
+		if _, ok := applicationGroup.Policies[LifecycleEndorsementPolicyKey]; !ok {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("the Application group does not define a %s policy, required by the %s chaincode lifecycle", LifecycleEndorsementPolicyKey, target))
+		}
+	}
+
+	return plan, nil
+}
+
+// capabilitiesFromGroup returns the capabilities enabled in a config group,
+// or nil when the group carries no capabilities value.
+func capabilitiesFromGroup(group *cb.ConfigGroup) (map[string]bool, error) {
+	valueAtKey, ok := group.Values[CapabilitiesKey]
+	if !ok {
+		return nil, nil
+	}
+
+	capabilitiesProto := &cb.Capabilities{}
+	err := proto.Unmarshal(valueAtKey.Value, capabilitiesProto)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling %s: %v", CapabilitiesKey, err)
+	}
+
+	capabilities := map[string]bool{}
+	for capability := range capabilitiesProto.Capabilities {
+		capabilities[capability] = true
+	}
+
+	return capabilities, nil
+}
+
+// parseCapability splits a capability name of the form V<major>_<minor> into
+// its version components.
+func parseCapability(capability string) (int, int, error) {
+	m := capabilityRegex.FindStringSubmatch(capability)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid capability name '%s', expected a name like V2_0", capability)
+	}
+
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+
+	return major, minor, nil
+}
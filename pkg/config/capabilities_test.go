@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPlanCapabilityMigration(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseCapabilityConfig(t)
+
+	plan, err := PlanCapabilityMigration(config, "V2_0")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	gt.Expect(plan.Updates).To(Equal([]CapabilityUpdate{
+		{GroupPath: "Channel/Orderer", Capability: "V2_0"},
+		{GroupPath: "Channel", Capability: "V2_0"},
+		{GroupPath: "Channel/Application", Capability: "V2_0"},
+	}))
+	gt.Expect(plan.Warnings).To(Equal([]string{
+		"all ordering nodes must run a version supporting V2_0 before it is enabled in the Orderer group",
+		"the Channel capability V2_0 applies to both orderers and peers, all nodes must support it",
+		"all peers must run a version supporting V2_0 before it is enabled in the Application group",
+		"the Application group does not define a LifecycleEndorsement policy, required by the V2_0 chaincode lifecycle",
+	}))
+}
+
+func TestPlanCapabilityMigrationAlreadyAtTarget(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseCapabilityConfig(t)
+
+	plan, err := PlanCapabilityMigration(config, "V1_3")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	// the orderer and application groups are already at V1_3, only the
+	// channel group needs the update
+	gt.Expect(plan.Updates).To(Equal([]CapabilityUpdate{
+		{GroupPath: "Channel", Capability: "V1_3"},
+	}))
+}
+
+func TestPlanCapabilityMigrationFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		target      string
+		expectedErr string
+	}{
+		{
+			testName:    "when the target is not a capability name",
+			target:      "2.0",
+			expectedErr: "invalid capability name '2.0', expected a name like V2_0",
+		},
+		{
+			testName:    "when the target is below a current capability",
+			target:      "V1_1",
+			expectedErr: "group Channel/Orderer already has capability V1_3, capabilities cannot be downgraded to V1_1",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			config := baseCapabilityConfig(t)
+
+			_, err := PlanCapabilityMigration(config, test.target)
+			gt.Expect(err).To(MatchError(test.expectedErr))
+		})
+	}
+}
+
+// baseCapabilityConfig returns a config with orderer and application groups
+// at capability V1_3 and no channel level capabilities.
+func baseCapabilityConfig(t *testing.T) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	applicationGroup, err := newApplicationGroup(baseApplication())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	channelGroup := newConfigGroup()
+	channelGroup.Groups[OrdererGroupKey] = ordererGroup
+	channelGroup.Groups[ApplicationGroupKey] = applicationGroup
+
+	return &cb.Config{
+		ChannelGroup: channelGroup,
+	}
+}
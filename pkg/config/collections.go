@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+)
+
+// Collection is a private data collection definition for a chaincode. The
+// member orgs and endorsement policies use the same signature policy DSL as
+// the rest of the channel configuration.
+type Collection struct {
+	// Name is the name of the collection inside the chaincode.
+	Name string `json:"name" yaml:"name"`
+	// MemberOrgsPolicy is the signature policy rule defining which orgs have
+	// access to the collection's private data.
+	MemberOrgsPolicy string `json:"member_orgs_policy" yaml:"member_orgs_policy"`
+	// RequiredPeerCount is the minimum number of peers private data is sent
+	// to upon endorsement.
+	RequiredPeerCount int32 `json:"required_peer_count" yaml:"required_peer_count"`
+	// MaximumPeerCount is the maximum number of peers private data is sent
+	// to upon endorsement.
+	MaximumPeerCount int32 `json:"maximum_peer_count" yaml:"maximum_peer_count"`
+	// BlockToLive is the number of blocks after which the collection data
+	// expires. A zero value means the data never expires.
+	BlockToLive uint64 `json:"block_to_live,omitempty" yaml:"block_to_live,omitempty"`
+	// MemberOnlyRead restricts reads of the private data to collection
+	// member clients.
+	MemberOnlyRead bool `json:"member_only_read,omitempty" yaml:"member_only_read,omitempty"`
+	// MemberOnlyWrite restricts writes of the private data to collection
+	// member clients.
+	MemberOnlyWrite bool `json:"member_only_write,omitempty" yaml:"member_only_write,omitempty"`
+	// EndorsementPolicy is the optional signature policy rule overriding the
+	// chaincode level endorsement policy for keys in this collection.
+	EndorsementPolicy string `json:"endorsement_policy,omitempty" yaml:"endorsement_policy,omitempty"`
+}
+
+// NewCollectionConfigPackage builds a collection config package from the
+// given collection definitions. Each definition is validated before it is
+// converted.
+func NewCollectionConfigPackage(collections []Collection) (*pb.CollectionConfigPackage, error) {
+	configs := []*pb.CollectionConfig{}
+
+	for _, collection := range collections {
+		config, err := newCollectionConfig(collection)
+		if err != nil {
+			return nil, fmt.Errorf("collection %s: %v", collection.Name, err)
+		}
+
+		configs = append(configs, config)
+	}
+
+	return &pb.CollectionConfigPackage{Config: configs}, nil
+}
+
+// ValidateCollection checks a collection definition for the constraints the
+// peer enforces at chaincode definition time: a name, a parseable member
+// orgs policy, consistent peer counts, and, when set, a parseable
+// endorsement policy.
+func ValidateCollection(collection Collection) error {
+	if collection.Name == "" {
+		return errors.New("collection name is required")
+	}
+
+	if collection.MemberOrgsPolicy == "" {
+		return errors.New("member orgs policy is required")
+	}
+
+	_, err := policydsl.FromString(collection.MemberOrgsPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid member orgs policy rule: '%s': %v", collection.MemberOrgsPolicy, err)
+	}
+
+	if collection.RequiredPeerCount < 0 {
+		return fmt.Errorf("required peer count %d is negative", collection.RequiredPeerCount)
+	}
+
+	if collection.MaximumPeerCount < collection.RequiredPeerCount {
+		return fmt.Errorf("maximum peer count %d is less than required peer count %d", collection.MaximumPeerCount, collection.RequiredPeerCount)
+	}
+
+	if collection.EndorsementPolicy != "" {
+		_, err = policydsl.FromString(collection.EndorsementPolicy)
+		if err != nil {
+			return fmt.Errorf("invalid endorsement policy rule: '%s': %v", collection.EndorsementPolicy, err)
+		}
+	}
+
+	return nil
+}
+
+// newCollectionConfig converts a validated collection definition into its
+// proto representation.
+func newCollectionConfig(collection Collection) (*pb.CollectionConfig, error) {
+	err := ValidateCollection(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	memberOrgsPolicy, err := policydsl.FromString(collection.MemberOrgsPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid member orgs policy rule: '%s': %v", collection.MemberOrgsPolicy, err)
+	}
+
+	staticConfig := &pb.StaticCollectionConfig{
+		Name: collection.Name,
+		MemberOrgsPolicy: &pb.CollectionPolicyConfig{
+			Payload: &pb.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: memberOrgsPolicy,
+			},
+		},
+		RequiredPeerCount: collection.RequiredPeerCount,
+		MaximumPeerCount:  collection.MaximumPeerCount,
+		BlockToLive:       collection.BlockToLive,
+		MemberOnlyRead:    collection.MemberOnlyRead,
+		MemberOnlyWrite:   collection.MemberOnlyWrite,
+	}
+
+	if collection.EndorsementPolicy != "" {
+		endorsementPolicy, err := policydsl.FromString(collection.EndorsementPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid endorsement policy rule: '%s': %v", collection.EndorsementPolicy, err)
+		}
+
+		staticConfig.EndorsementPolicy = &pb.ApplicationPolicy{
+			Type: &pb.ApplicationPolicy_SignaturePolicy{
+				SignaturePolicy: endorsementPolicy,
+			},
+		}
+	}
+
+	return &pb.CollectionConfig{
+		Payload: &pb.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: staticConfig,
+		},
+	}, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewCollectionConfigPackage(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	collectionConfigPackage, err := NewCollectionConfigPackage([]Collection{
+		{
+			Name:              "collection1",
+			MemberOrgsPolicy:  "OR('Org1.member', 'Org2.member')",
+			RequiredPeerCount: 1,
+			MaximumPeerCount:  3,
+			BlockToLive:       100,
+			MemberOnlyRead:    true,
+			MemberOnlyWrite:   true,
+			EndorsementPolicy: "AND('Org1.peer', 'Org2.peer')",
+		},
+		{
+			Name:              "collection2",
+			MemberOrgsPolicy:  "OR('Org1.member')",
+			RequiredPeerCount: 0,
+			MaximumPeerCount:  1,
+		},
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(collectionConfigPackage.Config).To(HaveLen(2))
+
+	staticConfig := collectionConfigPackage.Config[0].GetStaticCollectionConfig()
+	gt.Expect(staticConfig.Name).To(Equal("collection1"))
+	gt.Expect(staticConfig.RequiredPeerCount).To(Equal(int32(1)))
+	gt.Expect(staticConfig.MaximumPeerCount).To(Equal(int32(3)))
+	gt.Expect(staticConfig.BlockToLive).To(Equal(uint64(100)))
+	gt.Expect(staticConfig.MemberOnlyRead).To(BeTrue())
+	gt.Expect(staticConfig.MemberOnlyWrite).To(BeTrue())
+
+	expectedMemberOrgsPolicy, err := policydsl.FromString("OR('Org1.member', 'Org2.member')")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(staticConfig.MemberOrgsPolicy.GetSignaturePolicy()).To(Equal(expectedMemberOrgsPolicy))
+
+	expectedEndorsementPolicy, err := policydsl.FromString("AND('Org1.peer', 'Org2.peer')")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(staticConfig.EndorsementPolicy.GetSignaturePolicy()).To(Equal(expectedEndorsementPolicy))
+
+	staticConfig = collectionConfigPackage.Config[1].GetStaticCollectionConfig()
+	gt.Expect(staticConfig.Name).To(Equal("collection2"))
+	gt.Expect(staticConfig.EndorsementPolicy).To(BeNil())
+}
+
+func TestNewCollectionConfigPackageFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	_, err := NewCollectionConfigPackage([]Collection{
+		{
+			Name:             "badcollection",
+			MemberOrgsPolicy: "garbage",
+			MaximumPeerCount: 1,
+		},
+	})
+	gt.Expect(err).To(MatchError("collection badcollection: invalid member orgs policy rule: 'garbage': " +
+		"unrecognized token 'garbage' in policy string at line 1, col 1: did you mean 'garbage.member'?"))
+}
+
+func TestValidateCollection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		collection  Collection
+		expectedErr string
+	}{
+		{
+			testName: "valid collection",
+			collection: Collection{
+				Name:              "collection1",
+				MemberOrgsPolicy:  "OR('Org1.member')",
+				RequiredPeerCount: 0,
+				MaximumPeerCount:  1,
+			},
+		},
+		{
+			testName: "missing name",
+			collection: Collection{
+				MemberOrgsPolicy: "OR('Org1.member')",
+			},
+			expectedErr: "collection name is required",
+		},
+		{
+			testName: "missing member orgs policy",
+			collection: Collection{
+				Name: "collection1",
+			},
+			expectedErr: "member orgs policy is required",
+		},
+		{
+			testName: "negative required peer count",
+			collection: Collection{
+				Name:              "collection1",
+				MemberOrgsPolicy:  "OR('Org1.member')",
+				RequiredPeerCount: -1,
+			},
+			expectedErr: "required peer count -1 is negative",
+		},
+		{
+			testName: "maximum less than required",
+			collection: Collection{
+				Name:              "collection1",
+				MemberOrgsPolicy:  "OR('Org1.member')",
+				RequiredPeerCount: 2,
+				MaximumPeerCount:  1,
+			},
+			expectedErr: "maximum peer count 1 is less than required peer count 2",
+		},
+		{
+			testName: "invalid endorsement policy",
+			collection: Collection{
+				Name:              "collection1",
+				MemberOrgsPolicy:  "OR('Org1.member')",
+				MaximumPeerCount:  1,
+				EndorsementPolicy: "ANY Readers",
+			},
+			expectedErr: "invalid endorsement policy rule: 'ANY Readers': " +
+				"Cannot transition token types from VARIABLE [ANY] to VARIABLE [Readers]",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			err := ValidateCollection(test.collection)
+			if test.expectedErr == "" {
+				gt.Expect(err).NotTo(HaveOccurred())
+			} else {
+				gt.Expect(err).To(MatchError(test.expectedErr))
+			}
+		})
+	}
+}
+
+// ensure the generated package round trips through the proto getters used by
+// the peer
+func TestCollectionConfigPackageRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	collectionConfigPackage, err := NewCollectionConfigPackage([]Collection{
+		{
+			Name:              "collection1",
+			MemberOrgsPolicy:  "OR('Org1.member')",
+			RequiredPeerCount: 1,
+			MaximumPeerCount:  2,
+		},
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	var config *pb.StaticCollectionConfig
+	for _, c := range collectionConfigPackage.Config {
+		config = c.GetStaticCollectionConfig()
+	}
+	gt.Expect(config).NotTo(BeNil())
+	gt.Expect(config.GetMemberOrgsPolicy().GetSignaturePolicy()).NotTo(BeNil())
+}
@@ -25,29 +25,31 @@ import (
 
 // Channel is a channel configuration.
 type Channel struct {
-	Consortium   string
-	Application  Application
-	Orderer      Orderer
-	Consortiums  []Consortium
-	Capabilities map[string]bool
-	Policies     map[string]Policy
-	ChannelID    string
+	Consortium   string            `json:"consortium,omitempty" yaml:"consortium,omitempty"`
+	Application  Application       `json:"application,omitempty" yaml:"application,omitempty"`
+	Orderer      Orderer           `json:"orderer,omitempty" yaml:"orderer,omitempty"`
+	Consortiums  []Consortium      `json:"consortiums,omitempty" yaml:"consortiums,omitempty"`
+	Capabilities map[string]bool   `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	Policies     map[string]Policy `json:"policies,omitempty" yaml:"policies,omitempty"`
+	ChannelID    string            `json:"channel_id" yaml:"channel_id"`
 }
 
 // Policy is an expression used to define rules for access to channels, chaincodes, etc.
 type Policy struct {
-	Type string
-	Rule string
+	Type string `json:"type" yaml:"type"`
+	Rule string `json:"rule" yaml:"rule"`
 }
 
 // Organization is an organization in the channel configuration.
+// The MSP crypto material is not serializable and is expected to be
+// loaded from an MSP directory layout via LoadOrganizationFromDir.
 type Organization struct {
-	Name     string
-	Policies map[string]Policy
-	MSP      MSP
+	Name     string            `json:"name" yaml:"name"`
+	Policies map[string]Policy `json:"policies,omitempty" yaml:"policies,omitempty"`
+	MSP      MSP               `json:"-" yaml:"-"`
 
-	AnchorPeers      []AnchorPeer
-	OrdererEndpoints []string
+	AnchorPeers      []AnchorPeer `json:"anchor_peers,omitempty" yaml:"anchor_peers,omitempty"`
+	OrdererEndpoints []string     `json:"orderer_endpoints,omitempty" yaml:"orderer_endpoints,omitempty"`
 }
 
 type standardConfigValue struct {
@@ -190,6 +192,68 @@ func newNonce() ([]byte, error) {
 	return nonce, nil
 }
 
+// NewChannelGroup returns a fully populated channel config group for the
+// given channel configuration, including the application, orderer, and
+// consortiums groups when they are defined. It is primarily intended for
+// tools and tests that need a complete config tree rather than the config
+// update transaction produced by NewCreateChannelTx.
+func NewChannelGroup(channelConfig Channel) (*cb.ConfigGroup, error) {
+	var err error
+
+	channelGroup := newConfigGroup()
+
+	if len(channelConfig.Policies) > 0 {
+		err = addPolicies(channelGroup, channelConfig.Policies, AdminsPolicyKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add channel policies: %v", err)
+		}
+	}
+
+	if channelConfig.Consortium != "" {
+		err = addValue(channelGroup, consortiumValue(channelConfig.Consortium), AdminsPolicyKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(channelConfig.Capabilities) > 0 {
+		err = addValue(channelGroup, capabilitiesValue(channelConfig.Capabilities), AdminsPolicyKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(channelConfig.Orderer.Addresses) > 0 {
+		err = addValue(channelGroup, ordererAddressesValue(channelConfig.Orderer.Addresses), ordererAdminsPolicyName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if channelConfig.Orderer.OrdererType != "" {
+		channelGroup.Groups[OrdererGroupKey], err = newOrdererGroup(channelConfig.Orderer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create orderer group: %v", err)
+		}
+	}
+
+	if len(channelConfig.Application.Organizations) > 0 {
+		channelGroup.Groups[ApplicationGroupKey], err = newApplicationGroup(channelConfig.Application)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create application group: %v", err)
+		}
+	}
+
+	if len(channelConfig.Consortiums) > 0 {
+		channelGroup.Groups[ConsortiumsGroupKey], err = newConsortiumsGroup(channelConfig.Consortiums)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create consortiums group: %v", err)
+		}
+	}
+
+	return channelGroup, nil
+}
+
 // newChannelGroup defines the root of the channel configuration.
 func newChannelGroup(channelConfig Channel) (*cb.ConfigGroup, error) {
 	var err error
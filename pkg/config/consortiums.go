@@ -13,12 +13,13 @@ import (
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/policydsl"
 )
 
 // Consortium is a group of non-orderer organizations used in channel transactions.
 type Consortium struct {
-	Name          string
-	Organizations []Organization
+	Name          string         `json:"name" yaml:"name"`
+	Organizations []Organization `json:"organizations,omitempty" yaml:"organizations,omitempty"`
 }
 
 // AddOrgToConsortium adds an org definition to a named consortium in a given
@@ -45,6 +46,72 @@ func AddOrgToConsortium(config *cb.Config, org Organization, consortium string)
 	return nil
 }
 
+// GetChannelCreationPolicy returns the ChannelCreationPolicy value of an
+// existing consortium.
+func GetChannelCreationPolicy(config *cb.Config, consortiumName string) (Policy, error) {
+	consortiumGroup, ok := config.ChannelGroup.Groups[ConsortiumsGroupKey].Groups[consortiumName]
+	if !ok {
+		return Policy{}, fmt.Errorf("consortium %s does not exist in channel config", consortiumName)
+	}
+
+	policyProto := &cb.Policy{}
+	err := unmarshalConfigValueAtKey(consortiumGroup, ChannelCreationPolicyKey, policyProto)
+	if err != nil {
+		return Policy{}, err
+	}
+
+	return policyFromProto(policyProto)
+}
+
+// SetChannelCreationPolicy sets the ChannelCreationPolicy value of an
+// existing consortium, which gates creation of channels from that
+// consortium. An implicit meta policy is only accepted when every member
+// org of the consortium defines the referenced sub-policy, since channel
+// creation would otherwise be rejected by the orderer.
+func SetChannelCreationPolicy(config *cb.Config, consortiumName string, policy Policy) error {
+	consortiumGroup, ok := config.ChannelGroup.Groups[ConsortiumsGroupKey].Groups[consortiumName]
+	if !ok {
+		return fmt.Errorf("consortium %s does not exist in channel config", consortiumName)
+	}
+
+	var policyProto *cb.Policy
+
+	switch policy.Type {
+	case ImplicitMetaPolicyType:
+		imp, err := implicitMetaFromString(policy.Rule)
+		if err != nil {
+			return fmt.Errorf("invalid implicit meta policy rule: '%s': %v", policy.Rule, err)
+		}
+
+		for orgName, orgGroup := range consortiumGroup.Groups {
+			if _, ok := orgGroup.Policies[imp.SubPolicy]; !ok {
+				return fmt.Errorf("sub-policy '%s' is not defined for consortium org %s", imp.SubPolicy, orgName)
+			}
+		}
+
+		policyProto, err = implicitMetaPolicy(imp.SubPolicy, imp.Rule)
+		if err != nil {
+			return err
+		}
+	case SignaturePolicyType:
+		sp, err := policydsl.FromString(policy.Rule)
+		if err != nil {
+			return fmt.Errorf("invalid signature policy rule: '%s': %v", policy.Rule, err)
+		}
+
+		signaturePolicy, err := signaturePolicy(ChannelCreationPolicyKey, sp)
+		if err != nil {
+			return err
+		}
+
+		policyProto = signaturePolicy.value
+	default:
+		return fmt.Errorf("unknown policy type: %s", policy.Type)
+	}
+
+	return addValue(consortiumGroup, channelCreationPolicyValue(policyProto), ordererAdminsPolicyName)
+}
+
 // newConsortiumsGroup returns the consortiums component of the channel configuration. This element is only defined for
 // the ordering system channel.
 // It sets the mod_policy for all elements to "/Channel/Orderer/Admins".
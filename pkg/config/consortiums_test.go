@@ -481,3 +481,135 @@ func baseConsortiums() []Consortium {
 		},
 	}
 }
+
+func TestGetChannelCreationPolicy(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	consortiumsGroup, err := newConsortiumsGroup(baseConsortiums())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ConsortiumsGroupKey: consortiumsGroup,
+			},
+		},
+	}
+
+	policy, err := GetChannelCreationPolicy(config, "Consortium1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(policy).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "ANY Admins",
+	}))
+
+	_, err = GetChannelCreationPolicy(config, "BadConsortium")
+	gt.Expect(err).To(MatchError("consortium BadConsortium does not exist in channel config"))
+}
+
+func TestSetChannelCreationPolicy(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	consortiumsGroup, err := newConsortiumsGroup(baseConsortiums())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ConsortiumsGroupKey: consortiumsGroup,
+			},
+		},
+	}
+
+	err = SetChannelCreationPolicy(config, "Consortium1", Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "MAJORITY Admins",
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	policy, err := GetChannelCreationPolicy(config, "Consortium1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(policy).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "MAJORITY Admins",
+	}))
+
+	err = SetChannelCreationPolicy(config, "Consortium1", Policy{
+		Type: SignaturePolicyType,
+		Rule: "OutOf(2, 'Org1.admin', 'Org2.admin')",
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	policy, err = GetChannelCreationPolicy(config, "Consortium1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(policy.Type).To(Equal(SignaturePolicyType))
+}
+
+func TestSetChannelCreationPolicyFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		consortium  string
+		policy      Policy
+		expectedErr string
+	}{
+		{
+			testName:    "when the consortium does not exist",
+			consortium:  "BadConsortium",
+			policy:      Policy{Type: ImplicitMetaPolicyType, Rule: "ANY Admins"},
+			expectedErr: "consortium BadConsortium does not exist in channel config",
+		},
+		{
+			testName:    "when the implicit meta rule is malformed",
+			consortium:  "Consortium1",
+			policy:      Policy{Type: ImplicitMetaPolicyType, Rule: "ANY"},
+			expectedErr: "invalid implicit meta policy rule: 'ANY': expected two space separated tokens, but got 1",
+		},
+		{
+			testName:    "when a member org does not define the sub-policy",
+			consortium:  "Consortium1",
+			policy:      Policy{Type: ImplicitMetaPolicyType, Rule: "MAJORITY Curators"},
+			expectedErr: "sub-policy 'Curators' is not defined for consortium org ",
+		},
+		{
+			testName:    "when the signature policy rule is invalid",
+			consortium:  "Consortium1",
+			policy:      Policy{Type: SignaturePolicyType, Rule: "garbage"},
+			expectedErr: "invalid signature policy rule: 'garbage': unrecognized token 'garbage' in policy string at line 1, col 1: did you mean 'garbage.member'?",
+		},
+		{
+			testName:    "when the policy type is unknown",
+			consortium:  "Consortium1",
+			policy:      Policy{Type: "Bogus"},
+			expectedErr: "unknown policy type: Bogus",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			consortiumsGroup, err := newConsortiumsGroup(baseConsortiums())
+			gt.Expect(err).NotTo(HaveOccurred())
+
+			config := &cb.Config{
+				ChannelGroup: &cb.ConfigGroup{
+					Groups: map[string]*cb.ConfigGroup{
+						ConsortiumsGroupKey: consortiumsGroup,
+					},
+				},
+			}
+
+			err = SetChannelCreationPolicy(config, test.consortium, test.policy)
+			gt.Expect(err.Error()).To(HavePrefix(test.expectedErr))
+		})
+	}
+}
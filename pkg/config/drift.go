@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// Drift element names.
+const (
+	DriftElementGroup  = "group"
+	DriftElementValue  = "value"
+	DriftElementPolicy = "policy"
+)
+
+// Drift change names.
+const (
+	// DriftChangeAdded means the element is declared in the desired channel
+	// definition but missing from the live config.
+	DriftChangeAdded = "added"
+	// DriftChangeRemoved means the element exists in the live config but is
+	// not declared in the desired channel definition.
+	DriftChangeRemoved = "removed"
+	// DriftChangeChanged means the element exists in both but differs.
+	DriftChangeChanged = "changed"
+)
+
+// Drift describes one difference between a desired channel definition and a
+// live channel config.
+type Drift struct {
+	// Path is the slash separated path of the drifted element, rooted at
+	// the channel group, for example "Channel/Application/Org1".
+	Path string
+	// Element is the kind of config element that drifted: group, value,
+	// or policy.
+	Element string
+	// Change describes how the element drifted: added, removed, or changed.
+	Change string
+}
+
+// ComputeDrift compares a live channel config against a desired channel
+// definition, typically unmarshaled from a JSON or YAML declaration, and
+// returns the differences sorted by path. An empty result means the live
+// config matches the desired definition.
+func ComputeDrift(liveConfig *cb.Config, desired Channel) ([]Drift, error) {
+	if liveConfig.ChannelGroup == nil {
+		return nil, fmt.Errorf("no channel group included for live config")
+	}
+
+	desiredConfig, err := newDesiredConfig(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []Drift{}
+	compareGroups(ChannelGroupKey, liveConfig.ChannelGroup, desiredConfig.ChannelGroup, &drifts)
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Path != drifts[j].Path {
+			return drifts[i].Path < drifts[j].Path
+		}
+		return drifts[i].Element < drifts[j].Element
+	})
+
+	return drifts, nil
+}
+
+// ConvergeConfigUpdate returns the config update that would converge a live
+// channel config to a desired channel definition. An error is returned when
+// no differences are detected.
+func ConvergeConfigUpdate(liveConfig *cb.Config, desired Channel) (*cb.ConfigUpdate, error) {
+	desiredConfig, err := newDesiredConfig(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	configUpdate, err := computeConfigUpdate(liveConfig, desiredConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	configUpdate.ChannelId = desired.ChannelID
+
+	return configUpdate, nil
+}
+
+// newDesiredConfig builds the full config tree for a desired channel
+// definition.
+func newDesiredConfig(desired Channel) (*cb.Config, error) {
+	channelGroup, err := NewChannelGroup(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create desired channel group: %v", err)
+	}
+
+	desiredConfig := &cb.Config{
+		ChannelGroup: channelGroup,
+	}
+
+	// NewChannelGroup leaves the application org groups empty, as it does
+	// for channel creation transactions, so the full org definitions are
+	// added before comparison
+	for _, org := range desired.Application.Organizations {
+		err = AddApplicationOrg(desiredConfig, org)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create desired application org %s: %v", org.Name, err)
+		}
+	}
+
+	return desiredConfig, nil
+}
+
+// compareGroups records the differences between a live and a desired config
+// group in drifts, recursing into sub-groups present on both sides.
+func compareGroups(path string, live, desired *cb.ConfigGroup, drifts *[]Drift) {
+	for valueName, liveValue := range live.Values {
+		desiredValue, ok := desired.Values[valueName]
+		if !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + valueName, Element: DriftElementValue, Change: DriftChangeRemoved})
+			continue
+		}
+
+		if liveValue.ModPolicy != desiredValue.ModPolicy || !bytes.Equal(liveValue.Value, desiredValue.Value) {
+			*drifts = append(*drifts, Drift{Path: path + "/" + valueName, Element: DriftElementValue, Change: DriftChangeChanged})
+		}
+	}
+
+	for valueName := range desired.Values {
+		if _, ok := live.Values[valueName]; !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + valueName, Element: DriftElementValue, Change: DriftChangeAdded})
+		}
+	}
+
+	for policyName, livePolicy := range live.Policies {
+		desiredPolicy, ok := desired.Policies[policyName]
+		if !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + policyName, Element: DriftElementPolicy, Change: DriftChangeRemoved})
+			continue
+		}
+
+		if livePolicy.ModPolicy != desiredPolicy.ModPolicy || !proto.Equal(livePolicy.Policy, desiredPolicy.Policy) {
+			*drifts = append(*drifts, Drift{Path: path + "/" + policyName, Element: DriftElementPolicy, Change: DriftChangeChanged})
+		}
+	}
+
+	for policyName := range desired.Policies {
+		if _, ok := live.Policies[policyName]; !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + policyName, Element: DriftElementPolicy, Change: DriftChangeAdded})
+		}
+	}
+
+	for groupName, liveGroup := range live.Groups {
+		desiredGroup, ok := desired.Groups[groupName]
+		if !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + groupName, Element: DriftElementGroup, Change: DriftChangeRemoved})
+			continue
+		}
+
+		compareGroups(path+"/"+groupName, liveGroup, desiredGroup, drifts)
+	}
+
+	for groupName := range desired.Groups {
+		if _, ok := live.Groups[groupName]; !ok {
+			*drifts = append(*drifts, Drift{Path: path + "/" + groupName, Element: DriftElementGroup, Change: DriftChangeAdded})
+		}
+	}
+}
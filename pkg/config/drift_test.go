@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	. "github.com/onsi/gomega"
+)
+
+func TestComputeDriftNoDrift(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	liveConfig, err := newDesiredConfig(baseChannel())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	drifts, err := ComputeDrift(liveConfig, baseChannel())
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(drifts).To(BeEmpty())
+}
+
+func TestComputeDrift(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	liveConfig, err := newDesiredConfig(baseChannel())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	desired := baseChannel()
+	desired.Capabilities["V2_0"] = true
+	desired.Application.Organizations = desired.Application.Organizations[:1]
+	desired.Application.Policies["TestPolicy"] = Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "ANY Readers",
+	}
+	desired.Orderer.BatchSize.MaxMessageCount = 10000
+
+	drifts, err := ComputeDrift(liveConfig, desired)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(drifts).To(Equal([]Drift{
+		{Path: "Channel/Application/Org2", Element: DriftElementGroup, Change: DriftChangeRemoved},
+		{Path: "Channel/Application/TestPolicy", Element: DriftElementPolicy, Change: DriftChangeAdded},
+		{Path: "Channel/Capabilities", Element: DriftElementValue, Change: DriftChangeChanged},
+		{Path: "Channel/Orderer/BatchSize", Element: DriftElementValue, Change: DriftChangeChanged},
+	}))
+}
+
+func TestComputeDriftFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	_, err := ComputeDrift(&cb.Config{}, baseChannel())
+	gt.Expect(err).To(MatchError("no channel group included for live config"))
+}
+
+func TestConvergeConfigUpdate(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	liveConfig, err := newDesiredConfig(baseChannel())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	desired := baseChannel()
+	desired.Capabilities["V2_0"] = true
+
+	configUpdate, err := ConvergeConfigUpdate(liveConfig, desired)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(configUpdate.ChannelId).To(Equal("testchannel"))
+	gt.Expect(configUpdate.WriteSet.Values).To(HaveKey(CapabilitiesKey))
+}
+
+func TestConvergeConfigUpdateNoDifferences(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	liveConfig, err := newDesiredConfig(baseChannel())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	_, err = ConvergeConfigUpdate(liveConfig, baseChannel())
+	gt.Expect(err).To(MatchError("no differences detected between original and updated config"))
+}
+
+// baseChannel returns a desired channel definition matching the base
+// application and orderer fixtures.
+func baseChannel() Channel {
+	application := baseApplication()
+	for i := range application.Organizations {
+		application.Organizations[i].Policies = applicationOrgStandardPolicies()
+	}
+
+	return Channel{
+		ChannelID:    "testchannel",
+		Capabilities: map[string]bool{"V1_3": true},
+		Policies:     standardPolicies(),
+		Orderer:      baseOrderer(),
+		Application:  application,
+	}
+}
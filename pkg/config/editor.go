@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// ConfigEditor records a sequence of mutations to a channel configuration
+// and applies them atomically. The mutations are applied in order to a deep
+// copy of the config passed to NewConfigEditor. If any mutation fails, the
+// copy is discarded and the original config remains untouched.
+type ConfigEditor struct {
+	original  *cb.Config
+	mutations []func(config *cb.Config) error
+}
+
+// NewConfigEditor creates a ConfigEditor for the given channel config.
+// The config is not modified until Apply succeeds.
+func NewConfigEditor(config *cb.Config) *ConfigEditor {
+	return &ConfigEditor{
+		original: config,
+	}
+}
+
+// Edit queues an arbitrary mutation of the channel config. The mutation is
+// not executed until Apply is called.
+func (e *ConfigEditor) Edit(mutation func(config *cb.Config) error) {
+	e.mutations = append(e.mutations, mutation)
+}
+
+// AddApplicationOrg queues the addition of an application organization.
+func (e *ConfigEditor) AddApplicationOrg(org Organization) {
+	e.Edit(func(config *cb.Config) error {
+		return AddApplicationOrg(config, org)
+	})
+}
+
+// AddOrdererOrg queues the addition of an orderer organization.
+func (e *ConfigEditor) AddOrdererOrg(org Organization) {
+	e.Edit(func(config *cb.Config) error {
+		return AddOrdererOrg(config, org)
+	})
+}
+
+// AddOrgToConsortium queues the addition of an organization to a named consortium.
+func (e *ConfigEditor) AddOrgToConsortium(org Organization, consortium string) {
+	e.Edit(func(config *cb.Config) error {
+		return AddOrgToConsortium(config, org, consortium)
+	})
+}
+
+// AddAnchorPeer queues the addition of an anchor peer to an application organization.
+func (e *ConfigEditor) AddAnchorPeer(orgName string, anchorPeer AnchorPeer) {
+	e.Edit(func(config *cb.Config) error {
+		return AddAnchorPeer(config, orgName, anchorPeer)
+	})
+}
+
+// RemoveAnchorPeer queues the removal of an anchor peer from an application organization.
+func (e *ConfigEditor) RemoveAnchorPeer(orgName string, anchorPeer AnchorPeer) {
+	e.Edit(func(config *cb.Config) error {
+		return RemoveAnchorPeer(config, orgName, anchorPeer)
+	})
+}
+
+// SetACLs queues the replacement of the application's ACL definitions.
+func (e *ConfigEditor) SetACLs(acls map[string]string) {
+	e.Edit(func(config *cb.Config) error {
+		return SetACLs(config, acls)
+	})
+}
+
+// AddApplicationPolicy queues the addition of an application policy.
+func (e *ConfigEditor) AddApplicationPolicy(modPolicy, policyName string, policy Policy) {
+	e.Edit(func(config *cb.Config) error {
+		return AddApplicationPolicy(config, modPolicy, policyName, policy)
+	})
+}
+
+// RemoveApplicationPolicy queues the removal of an application policy.
+func (e *ConfigEditor) RemoveApplicationPolicy(policyName string) {
+	e.Edit(func(config *cb.Config) error {
+		return RemoveApplicationPolicy(config, policyName)
+	})
+}
+
+// UpdateOrdererConfiguration queues an update of the orderer configuration values.
+func (e *ConfigEditor) UpdateOrdererConfiguration(o Orderer) {
+	e.Edit(func(config *cb.Config) error {
+		return UpdateOrdererConfiguration(config, o)
+	})
+}
+
+// Apply applies all queued mutations in order to a deep copy of the config
+// and returns the updated copy. If any mutation fails, no config is returned
+// and the original config is left untouched. The queued mutations are
+// retained so a failed sequence can be corrected and reapplied.
+func (e *ConfigEditor) Apply() (*cb.Config, error) {
+	updatedConfig := proto.Clone(e.original).(*cb.Config)
+
+	err := e.applyTo(updatedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedConfig, nil
+}
+
+// applyTo applies all queued mutations in order to the given config.
+func (e *ConfigEditor) applyTo(config *cb.Config) error {
+	for i, mutate := range e.mutations {
+		err := mutate(config)
+		if err != nil {
+			return fmt.Errorf("applying mutation %d of %d: %v", i+1, len(e.mutations), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	. "github.com/onsi/gomega"
+)
+
+func TestConfigEditorApply(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	applicationGroup, err := newApplicationGroup(baseApplication())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: applicationGroup,
+			},
+		},
+	}
+	originalConfig := proto.Clone(config).(*cb.Config)
+
+	editor := NewConfigEditor(config)
+	editor.AddApplicationOrg(baseApplicationOrg())
+	editor.SetACLs(map[string]string{"acl1": "Readers"})
+	editor.AddApplicationPolicy(AdminsPolicyKey, "TestPolicy", Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "ANY Readers",
+	})
+
+	updatedConfig, err := editor.Apply()
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	// The original config is untouched by a successful apply.
+	gt.Expect(proto.Equal(config, originalConfig)).To(BeTrue())
+
+	updatedApplicationGroup := updatedConfig.ChannelGroup.Groups[ApplicationGroupKey]
+	gt.Expect(updatedApplicationGroup.Groups["Org1"].Values).To(HaveKey(MSPKey))
+	gt.Expect(updatedApplicationGroup.Values).To(HaveKey(ACLsKey))
+	gt.Expect(updatedApplicationGroup.Policies).To(HaveKey("TestPolicy"))
+}
+
+func TestConfigEditorApplyFailure(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	applicationGroup, err := newApplicationGroup(baseApplication())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: applicationGroup,
+			},
+		},
+	}
+	originalConfig := proto.Clone(config).(*cb.Config)
+
+	editor := NewConfigEditor(config)
+	editor.SetACLs(map[string]string{"acl1": "Readers"})
+	editor.RemoveApplicationPolicy("DoesNotExist")
+
+	updatedConfig, err := editor.Apply()
+	gt.Expect(updatedConfig).To(BeNil())
+	gt.Expect(err).To(MatchError("applying mutation 2 of 2: could not find policy 'DoesNotExist'"))
+
+	// A failed apply leaves the original config untouched, including
+	// mutations queued before the failing one.
+	gt.Expect(proto.Equal(config, originalConfig)).To(BeTrue())
+}
+
+func TestSetACLs(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{},
+		},
+	}
+
+	err := SetACLs(config, map[string]string{"acl1": "Readers"})
+	gt.Expect(err).To(MatchError("application group does not exist in channel config"))
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// defaultOrdererPort is assumed when an orderer endpoint omits its port.
+const defaultOrdererPort = "7050"
+
+// GetOrdererEndpoints returns the endpoints of an existing orderer org.
+func GetOrdererEndpoints(config *cb.Config, orgName string) ([]string, error) {
+	orgGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey].Groups[orgName]
+	if !ok {
+		return nil, fmt.Errorf("orderer org %s does not exist in channel config", orgName)
+	}
+
+	endpointsProto := &cb.OrdererAddresses{}
+	err := unmarshalConfigValueAtKey(orgGroup, EndpointsKey, endpointsProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return endpointsProto.Addresses, nil
+}
+
+// AddOrdererEndpoint adds an endpoint to an existing orderer org. Endpoints
+// are compared after normalization, so an address already present under a
+// different case or with an explicit default port is rejected as a duplicate.
+func AddOrdererEndpoint(config *cb.Config, orgName, endpoint string) error {
+	endpoints, err := GetOrdererEndpoints(config, orgName)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range endpoints {
+		if normalizeOrdererEndpoint(existing) == normalizeOrdererEndpoint(endpoint) {
+			return fmt.Errorf("endpoint '%s' already exists in orderer org %s", endpoint, orgName)
+		}
+	}
+
+	orgGroup := config.ChannelGroup.Groups[OrdererGroupKey].Groups[orgName]
+
+	return addValue(orgGroup, endpointsValue(append(endpoints, endpoint)), AdminsPolicyKey)
+}
+
+// RemoveOrdererEndpoint removes an endpoint from an existing orderer org.
+// The address is matched after normalization, so decommissioned addresses
+// recorded under a different case or with an explicit default port are
+// still found.
+func RemoveOrdererEndpoint(config *cb.Config, orgName, endpoint string) error {
+	endpoints, err := GetOrdererEndpoints(config, orgName)
+	if err != nil {
+		return err
+	}
+
+	normalized := normalizeOrdererEndpoint(endpoint)
+	remaining := []string{}
+	for _, existing := range endpoints {
+		if normalizeOrdererEndpoint(existing) != normalized {
+			remaining = append(remaining, existing)
+		}
+	}
+
+	if len(remaining) == len(endpoints) {
+		return fmt.Errorf("could not find endpoint '%s' in orderer org %s", endpoint, orgName)
+	}
+
+	orgGroup := config.ChannelGroup.Groups[OrdererGroupKey].Groups[orgName]
+	if len(remaining) == 0 {
+		delete(orgGroup.Values, EndpointsKey)
+		return nil
+	}
+
+	return addValue(orgGroup, endpointsValue(remaining), AdminsPolicyKey)
+}
+
+// normalizeOrdererEndpoint lowercases an endpoint's host and applies the
+// default orderer port when none is given, so that equivalent addresses
+// compare equal.
+func normalizeOrdererEndpoint(endpoint string) string {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host, port = endpoint, defaultOrdererPort
+	}
+
+	if port == "" {
+		port = defaultOrdererPort
+	}
+
+	return strings.ToLower(host) + ":" + port
+}
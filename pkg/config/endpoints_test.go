@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGetOrdererEndpoints(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	endpoints, err := GetOrdererEndpoints(config, "OrdererOrg")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(endpoints).To(Equal([]string{"localhost:123"}))
+
+	_, err = GetOrdererEndpoints(config, "BadOrg")
+	gt.Expect(err).To(MatchError("orderer org BadOrg does not exist in channel config"))
+}
+
+func TestAddOrdererEndpoint(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	err := AddOrdererEndpoint(config, "OrdererOrg", "node-2.example.com:7050")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	endpoints, err := GetOrdererEndpoints(config, "OrdererOrg")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(endpoints).To(Equal([]string{"localhost:123", "node-2.example.com:7050"}))
+
+	// the same address under a different case and an implied default port is
+	// a duplicate
+	err = AddOrdererEndpoint(config, "OrdererOrg", "Node-2.Example.Com")
+	gt.Expect(err).To(MatchError("endpoint 'Node-2.Example.Com' already exists in orderer org OrdererOrg"))
+}
+
+func TestRemoveOrdererEndpoint(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	err := AddOrdererEndpoint(config, "OrdererOrg", "node-2.example.com:7050")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = RemoveOrdererEndpoint(config, "OrdererOrg", "NODE-2.example.com")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	endpoints, err := GetOrdererEndpoints(config, "OrdererOrg")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(endpoints).To(Equal([]string{"localhost:123"}))
+
+	err = RemoveOrdererEndpoint(config, "OrdererOrg", "node-2.example.com:7050")
+	gt.Expect(err).To(MatchError("could not find endpoint 'node-2.example.com:7050' in orderer org OrdererOrg"))
+}
+
+func TestRemoveOrdererEndpointRemovesEmptyValue(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	err := RemoveOrdererEndpoint(config, "OrdererOrg", "LOCALHOST:123")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	orgGroup := config.ChannelGroup.Groups[OrdererGroupKey].Groups["OrdererOrg"]
+	gt.Expect(orgGroup.Values).NotTo(HaveKey(EndpointsKey))
+}
+
+// baseOrdererConfig returns a config with only the orderer group populated.
+func baseOrdererConfig(t *testing.T) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				OrdererGroupKey: ordererGroup,
+			},
+		},
+	}
+}
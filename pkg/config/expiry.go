@@ -0,0 +1,211 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+)
+
+// Certificate usages reported by CertificateExpirations.
+const (
+	CertificateUsageRootCA             = "root CA"
+	CertificateUsageIntermediateCA     = "intermediate CA"
+	CertificateUsageAdmin              = "admin"
+	CertificateUsageTLSRootCA          = "TLS root CA"
+	CertificateUsageTLSIntermediateCA  = "TLS intermediate CA"
+	CertificateUsageSigningIdentity    = "signing identity"
+	CertificateUsageConsenterClientTLS = "consenter client TLS"
+	CertificateUsageConsenterServerTLS = "consenter server TLS"
+)
+
+// CertificateExpiration describes a certificate embedded in a channel
+// configuration and when it expires.
+type CertificateExpiration struct {
+	// Path locates the certificate in the channel config,
+	// e.g. "Application/Org1".
+	Path string
+	// Usage describes the role the certificate plays in the config.
+	Usage string
+	// Subject is the distinguished name of the certificate's subject.
+	Subject string
+	// NotAfter is the time at which the certificate expires.
+	NotAfter time.Time
+}
+
+// CertificateExpirations walks every X.509 certificate embedded in the
+// config - MSP root, intermediate, and admin certs, TLS CAs, signing
+// identities, and etcdraft consenter TLS certs - and returns their
+// subjects, usages, and expiry dates sorted by soonest expiry first.
+// MSPs that are not X.509 based (such as idemix, whose issuer keys carry
+// no expiry) contribute no entries.
+func CertificateExpirations(config *cb.Config) ([]CertificateExpiration, error) {
+	expirations := []CertificateExpiration{}
+
+	for _, groupKey := range []string{ApplicationGroupKey, OrdererGroupKey} {
+		group, ok := config.ChannelGroup.Groups[groupKey]
+		if !ok {
+			continue
+		}
+
+		for orgName, orgGroup := range group.Groups {
+			orgExpirations, err := mspCertificateExpirations(orgGroup, groupKey+"/"+orgName)
+			if err != nil {
+				return nil, fmt.Errorf("gathering certificate expirations for org %s: %v", orgName, err)
+			}
+
+			expirations = append(expirations, orgExpirations...)
+		}
+	}
+
+	if consortiumsGroup, ok := config.ChannelGroup.Groups[ConsortiumsGroupKey]; ok {
+		for consortiumName, consortiumGroup := range consortiumsGroup.Groups {
+			for orgName, orgGroup := range consortiumGroup.Groups {
+				orgExpirations, err := mspCertificateExpirations(orgGroup, ConsortiumsGroupKey+"/"+consortiumName+"/"+orgName)
+				if err != nil {
+					return nil, fmt.Errorf("gathering certificate expirations for consortium org %s: %v", orgName, err)
+				}
+
+				expirations = append(expirations, orgExpirations...)
+			}
+		}
+	}
+
+	consenterExpirations, err := consenterCertificateExpirations(config)
+	if err != nil {
+		return nil, fmt.Errorf("gathering consenter certificate expirations: %v", err)
+	}
+	expirations = append(expirations, consenterExpirations...)
+
+	sort.Slice(expirations, func(i, j int) bool {
+		if !expirations[i].NotAfter.Equal(expirations[j].NotAfter) {
+			return expirations[i].NotAfter.Before(expirations[j].NotAfter)
+		}
+		if expirations[i].Path != expirations[j].Path {
+			return expirations[i].Path < expirations[j].Path
+		}
+
+		return expirations[i].Usage < expirations[j].Usage
+	})
+
+	return expirations, nil
+}
+
+// mspCertificateExpirations gathers the expirations of the certificates
+// embedded in an org config group's MSP value.
+func mspCertificateExpirations(orgGroup *cb.ConfigGroup, path string) ([]CertificateExpiration, error) {
+	mspValueProto := &mb.MSPConfig{}
+	err := unmarshalConfigValueAtKey(orgGroup, MSPKey, mspValueProto)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the X.509 based Fabric MSP (type 0) embeds certificates
+	if mspValueProto.Type != 0 {
+		return nil, nil
+	}
+
+	fabricMSPConfig := &mb.FabricMSPConfig{}
+	err = proto.Unmarshal(mspValueProto.Config, fabricMSPConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling fabric msp config: %v", err)
+	}
+
+	expirations := []CertificateExpiration{}
+
+	certLists := []struct {
+		usage string
+		certs [][]byte
+	}{
+		{CertificateUsageRootCA, fabricMSPConfig.RootCerts},
+		{CertificateUsageIntermediateCA, fabricMSPConfig.IntermediateCerts},
+		{CertificateUsageAdmin, fabricMSPConfig.Admins},
+		{CertificateUsageTLSRootCA, fabricMSPConfig.TlsRootCerts},
+		{CertificateUsageTLSIntermediateCA, fabricMSPConfig.TlsIntermediateCerts},
+		{CertificateUsageSigningIdentity, [][]byte{fabricMSPConfig.GetSigningIdentity().GetPublicSigner()}},
+	}
+
+	for _, certList := range certLists {
+		for _, cert := range certList.certs {
+			if len(cert) == 0 {
+				continue
+			}
+
+			certificate, err := parseCertificateFromBytes(cert)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s cert: %v", certList.usage, err)
+			}
+
+			expirations = append(expirations, certificateExpiration(certificate, path, certList.usage))
+		}
+	}
+
+	return expirations, nil
+}
+
+// consenterCertificateExpirations gathers the expirations of the TLS
+// certificates of the etcdraft consenters in the orderer config group, if
+// one is present.
+func consenterCertificateExpirations(config *cb.Config) ([]CertificateExpiration, error) {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return nil, nil
+	}
+
+	consensusTypeProto := &ob.ConsensusType{}
+	err := unmarshalConfigValueAtKey(ordererGroup, ConsensusTypeKey, consensusTypeProto)
+	if err != nil {
+		return nil, err
+	}
+
+	if consensusTypeProto.Type != ConsensusTypeEtcdRaft {
+		return nil, nil
+	}
+
+	configMetadata := &eb.ConfigMetadata{}
+	err = proto.Unmarshal(consensusTypeProto.Metadata, configMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling etcdraft metadata: %v", err)
+	}
+
+	expirations := []CertificateExpiration{}
+
+	for _, consenter := range configMetadata.Consenters {
+		path := fmt.Sprintf("%s/%s/%s:%d", OrdererGroupKey, ConsensusTypeKey, consenter.Host, consenter.Port)
+
+		clientCert, err := parseCertificateFromBytes(consenter.ClientTlsCert)
+		if err != nil {
+			return nil, fmt.Errorf("parsing client tls cert for consenter %s:%d: %v", consenter.Host, consenter.Port, err)
+		}
+		expirations = append(expirations, certificateExpiration(clientCert, path, CertificateUsageConsenterClientTLS))
+
+		serverCert, err := parseCertificateFromBytes(consenter.ServerTlsCert)
+		if err != nil {
+			return nil, fmt.Errorf("parsing server tls cert for consenter %s:%d: %v", consenter.Host, consenter.Port, err)
+		}
+		expirations = append(expirations, certificateExpiration(serverCert, path, CertificateUsageConsenterServerTLS))
+	}
+
+	return expirations, nil
+}
+
+func certificateExpiration(cert x509.Certificate, path, usage string) CertificateExpiration {
+	return CertificateExpiration{
+		Path:     path,
+		Usage:    usage,
+		Subject:  cert.Subject.String(),
+		NotAfter: cert.NotAfter,
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCertificateExpirations(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	// replace the solo consensus type with etcdraft so consenter certs are
+	// part of the report
+	etcdRaftMetadata, err := proto.Marshal(&eb.ConfigMetadata{
+		Consenters: []*eb.Consenter{
+			{
+				Host:          "node-1.example.com",
+				Port:          7050,
+				ClientTlsCert: []byte(dummyCert),
+				ServerTlsCert: []byte(dummyCert),
+			},
+		},
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	consensusTypeProto := &ob.ConsensusType{
+		Type:     ConsensusTypeEtcdRaft,
+		Metadata: etcdRaftMetadata,
+	}
+	consensusType, err := proto.Marshal(consensusTypeProto)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	ordererGroup.Values[ConsensusTypeKey].Value = consensusType
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				OrdererGroupKey: ordererGroup,
+			},
+		},
+	}
+
+	expirations, err := CertificateExpirations(config)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	// dummyCert's subject and expiry
+	subject := "O=Acme Co"
+	notAfter := time.Date(2017, time.August, 17, 20, 36, 7, 0, time.UTC)
+
+	consenterPath := "Orderer/ConsensusType/node-1.example.com:7050"
+	orgPath := "Orderer/OrdererOrg"
+
+	gt.Expect(expirations).To(Equal([]CertificateExpiration{
+		{Path: consenterPath, Usage: CertificateUsageConsenterClientTLS, Subject: subject, NotAfter: notAfter},
+		{Path: consenterPath, Usage: CertificateUsageConsenterServerTLS, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageTLSIntermediateCA, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageTLSRootCA, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageAdmin, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageIntermediateCA, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageRootCA, Subject: subject, NotAfter: notAfter},
+		{Path: orgPath, Usage: CertificateUsageSigningIdentity, Subject: subject, NotAfter: notAfter},
+	}))
+}
+
+func TestCertificateExpirationsForConsortiumOrg(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	consortiumsGroup, err := newConsortiumsGroup(baseConsortiums())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ConsortiumsGroupKey: consortiumsGroup,
+			},
+		},
+	}
+
+	expirations, err := CertificateExpirations(config)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(expirations).To(HaveLen(12))
+	gt.Expect(expirations[0].Path).To(Equal("Consortiums/Consortium1/Org1"))
+	gt.Expect(expirations[0].NotAfter).To(Equal(time.Date(2017, time.August, 17, 20, 36, 7, 0, time.UTC)))
+}
+
+func TestCertificateExpirationsFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: {
+					Groups: map[string]*cb.ConfigGroup{
+						"Org1": newConfigGroup(),
+					},
+				},
+			},
+		},
+	}
+
+	expirations, err := CertificateExpirations(config)
+	gt.Expect(err).To(MatchError("gathering certificate expirations for org Org1: config does not contain value for MSP"))
+	gt.Expect(expirations).To(BeNil())
+}
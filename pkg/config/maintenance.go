@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+)
+
+// BroadcastStream is the subset of the AtomicBroadcast broadcast client used
+// to submit config updates. The caller is responsible for establishing the
+// stream.
+type BroadcastStream interface {
+	Send(*cb.Envelope) error
+	Recv() (*ob.BroadcastResponse, error)
+}
+
+// GetConsensusState returns the consensus state of the channel's ordering
+// service.
+func GetConsensusState(config *cb.Config) (ConsensusState, error) {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return "", fmt.Errorf("config does not contain orderer group")
+	}
+
+	consensusTypeProto := &ob.ConsensusType{}
+	err := unmarshalConfigValueAtKey(ordererGroup, ConsensusTypeKey, consensusTypeProto)
+	if err != nil {
+		return "", err
+	}
+
+	return ConsensusState(consensusTypeProto.State.String()), nil
+}
+
+// SetConsensusState sets the operational state of the channel's ordering
+// service, leaving the consensus type and metadata untouched. The state
+// change must still satisfy the orderer's maintenance filter rules when
+// submitted, e.g. the consensus type can only be changed while the channel is
+// in maintenance.
+func SetConsensusState(config *cb.Config, state ConsensusState) error {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return fmt.Errorf("config does not contain orderer group")
+	}
+
+	consensusTypeProto := &ob.ConsensusType{}
+	err := unmarshalConfigValueAtKey(ordererGroup, ConsensusTypeKey, consensusTypeProto)
+	if err != nil {
+		return err
+	}
+
+	consensusState, ok := ob.ConsensusType_State_value[string(state)]
+	if !ok {
+		return fmt.Errorf("unknown consensus state '%s'", state)
+	}
+
+	return addValue(ordererGroup, consensusTypeValue(consensusTypeProto.Type, consensusTypeProto.Metadata, consensusState), AdminsPolicyKey)
+}
+
+// MaintenanceConfigUpdate computes the config update that moves the channel's
+// ordering service into the given consensus state. It returns an error if the
+// channel is already in that state.
+func MaintenanceConfigUpdate(baseConfig *cb.Config, channelID string, state ConsensusState) (*cb.ConfigUpdate, error) {
+	currentState, err := GetConsensusState(baseConfig)
+	if err != nil {
+		return nil, err
+	}
+	if currentState == state {
+		return nil, fmt.Errorf("channel %s is already in state %s", channelID, state)
+	}
+
+	updatedConfig := proto.Clone(baseConfig).(*cb.Config)
+	if err := SetConsensusState(updatedConfig, state); err != nil {
+		return nil, err
+	}
+
+	return ComputeUpdate(baseConfig, updatedConfig, channelID)
+}
+
+// SubmitMaintenanceUpdate generates, signs, and submits the config update
+// that drives the channel's ordering service into the given consensus state.
+// Each signing identity contributes a config signature; the first identity
+// also signs the submission envelope. Once the update commits, the orderer's
+// maintenance filter rejects normal transactions on the channel, so in-flight
+// transactions are either ordered before the state change or bounced back to
+// their submitters.
+func SubmitMaintenanceUpdate(stream BroadcastStream, baseConfig *cb.Config, channelID string, state ConsensusState, signingIdentities []SigningIdentity) error {
+	if len(signingIdentities) == 0 {
+		return fmt.Errorf("at least one signing identity is required")
+	}
+
+	configUpdate, err := MaintenanceConfigUpdate(baseConfig, channelID, state)
+	if err != nil {
+		return err
+	}
+
+	var signatures []*cb.ConfigSignature
+	for _, signingIdentity := range signingIdentities {
+		signature, err := SignConfigUpdate(configUpdate, signingIdentity)
+		if err != nil {
+			return err
+		}
+		signatures = append(signatures, signature)
+	}
+
+	envelope, err := CreateSignedConfigUpdateEnvelope(configUpdate, signingIdentities[0], signatures...)
+	if err != nil {
+		return err
+	}
+
+	if err := stream.Send(envelope); err != nil {
+		return fmt.Errorf("failed to submit config update for channel %s: %v", channelID, err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return fmt.Errorf("failed to receive broadcast response for channel %s: %v", channelID, err)
+	}
+	if resp.Status != cb.Status_SUCCESS {
+		return fmt.Errorf("config update for channel %s was rejected with status %v: %s", channelID, resp.Status, resp.Info)
+	}
+
+	return nil
+}
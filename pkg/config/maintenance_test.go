@@ -0,0 +1,194 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeBroadcastStream records submitted envelopes and replays canned
+// responses.
+type fakeBroadcastStream struct {
+	sent     []*cb.Envelope
+	sendErr  error
+	response *ob.BroadcastResponse
+	recvErr  error
+}
+
+func (f *fakeBroadcastStream) Send(env *cb.Envelope) error {
+	if f.sendErr != nil {
+		return f.sendErr
+	}
+	f.sent = append(f.sent, env)
+	return nil
+}
+
+func (f *fakeBroadcastStream) Recv() (*ob.BroadcastResponse, error) {
+	return f.response, f.recvErr
+}
+
+func baseMaintenanceConfig(t *testing.T) *cb.Config {
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	if err != nil {
+		t.Fatalf("failed to create orderer group: %v", err)
+	}
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				OrdererGroupKey: ordererGroup,
+			},
+		},
+	}
+}
+
+func TestGetConsensusState(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	state, err := GetConsensusState(config)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(state).To(Equal(ConsensusStateNormal))
+}
+
+func TestSetConsensusState(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	err := SetConsensusState(config, ConsensusStateMaintenance)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	state, err := GetConsensusState(config)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(state).To(Equal(ConsensusStateMaintenance))
+
+	// the consensus type and metadata are untouched
+	consensusTypeProto := &ob.ConsensusType{}
+	err = unmarshalConfigValueAtKey(config.ChannelGroup.Groups[OrdererGroupKey], ConsensusTypeKey, consensusTypeProto)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(consensusTypeProto.Type).To(Equal(ConsensusTypeSolo))
+}
+
+func TestSetConsensusStateFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+	err := SetConsensusState(config, ConsensusState("STATE_BOGUS"))
+	gt.Expect(err).To(MatchError("unknown consensus state 'STATE_BOGUS'"))
+
+	err = SetConsensusState(&cb.Config{ChannelGroup: &cb.ConfigGroup{}}, ConsensusStateMaintenance)
+	gt.Expect(err).To(MatchError("config does not contain orderer group"))
+}
+
+func TestMaintenanceConfigUpdate(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	configUpdate, err := MaintenanceConfigUpdate(config, "testchannel", ConsensusStateMaintenance)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(configUpdate.ChannelId).To(Equal("testchannel"))
+
+	writeSet := configUpdate.WriteSet.Groups[OrdererGroupKey]
+	consensusTypeProto := &ob.ConsensusType{}
+	err = proto.Unmarshal(writeSet.Values[ConsensusTypeKey].Value, consensusTypeProto)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(consensusTypeProto.State).To(Equal(ob.ConsensusType_STATE_MAINTENANCE))
+
+	// the base config is not mutated
+	state, err := GetConsensusState(config)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(state).To(Equal(ConsensusStateNormal))
+}
+
+func TestMaintenanceConfigUpdateAlreadyInState(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	_, err := MaintenanceConfigUpdate(config, "testchannel", ConsensusStateNormal)
+	gt.Expect(err).To(MatchError("channel testchannel is already in state STATE_NORMAL"))
+}
+
+func TestSubmitMaintenanceUpdate(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	cert, privateKey := generateCertAndPrivateKey()
+	signingIdentity := SigningIdentity{
+		Certificate: cert,
+		PrivateKey:  privateKey,
+		MSPID:       "test-msp",
+	}
+
+	stream := &fakeBroadcastStream{
+		response: &ob.BroadcastResponse{Status: cb.Status_SUCCESS},
+	}
+
+	err := SubmitMaintenanceUpdate(stream, config, "testchannel", ConsensusStateMaintenance, []SigningIdentity{signingIdentity, signingIdentity})
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(stream.sent).To(HaveLen(1))
+
+	// the submitted envelope carries a config signature per identity
+	payload := &cb.Payload{}
+	err = proto.Unmarshal(stream.sent[0].Payload, payload)
+	gt.Expect(err).NotTo(HaveOccurred())
+	configUpdateEnvelope := &cb.ConfigUpdateEnvelope{}
+	err = proto.Unmarshal(payload.Data, configUpdateEnvelope)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(configUpdateEnvelope.Signatures).To(HaveLen(2))
+}
+
+func TestSubmitMaintenanceUpdateFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseMaintenanceConfig(t)
+
+	cert, privateKey := generateCertAndPrivateKey()
+	signingIdentity := SigningIdentity{
+		Certificate: cert,
+		PrivateKey:  privateKey,
+		MSPID:       "test-msp",
+	}
+
+	err := SubmitMaintenanceUpdate(&fakeBroadcastStream{}, config, "testchannel", ConsensusStateMaintenance, nil)
+	gt.Expect(err).To(MatchError("at least one signing identity is required"))
+
+	stream := &fakeBroadcastStream{sendErr: errors.New("connection refused")}
+	err = SubmitMaintenanceUpdate(stream, config, "testchannel", ConsensusStateMaintenance, []SigningIdentity{signingIdentity})
+	gt.Expect(err).To(MatchError("failed to submit config update for channel testchannel: connection refused"))
+
+	stream = &fakeBroadcastStream{
+		response: &ob.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "next config: not in maintenance mode"},
+	}
+	err = SubmitMaintenanceUpdate(stream, config, "testchannel", ConsensusStateMaintenance, []SigningIdentity{signingIdentity})
+	gt.Expect(err).To(MatchError("config update for channel testchannel was rejected with status BAD_REQUEST: next config: not in maintenance mode"))
+}
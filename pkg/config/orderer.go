@@ -25,39 +25,39 @@ type ConsensusState string
 type Orderer struct {
 	// OrdererType is the type of orderer
 	// Options: `Solo`, `Kafka` or `Raft`
-	OrdererType string
+	OrdererType string `json:"orderer_type" yaml:"orderer_type"`
 	// Addresses is the list of orderer addresses.
-	Addresses []string
+	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
 	// BatchTimeout is the wait time between transactions.
-	BatchTimeout  time.Duration
-	BatchSize     BatchSize
-	Kafka         Kafka
-	EtcdRaft      eb.ConfigMetadata
-	Organizations []Organization
+	BatchTimeout  time.Duration     `json:"batch_timeout,omitempty" yaml:"batch_timeout,omitempty"`
+	BatchSize     BatchSize         `json:"batch_size,omitempty" yaml:"batch_size,omitempty"`
+	Kafka         Kafka             `json:"kafka,omitempty" yaml:"kafka,omitempty"`
+	EtcdRaft      eb.ConfigMetadata `json:"-" yaml:"-"`
+	Organizations []Organization    `json:"organizations,omitempty" yaml:"organizations,omitempty"`
 	// MaxChannels is the maximum count of channels an orderer supports.
-	MaxChannels uint64
+	MaxChannels uint64 `json:"max_channels,omitempty" yaml:"max_channels,omitempty"`
 	// Capabilities is a map of the capabilities the orderer supports.
-	Capabilities map[string]bool
-	Policies     map[string]Policy
+	Capabilities map[string]bool   `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+	Policies     map[string]Policy `json:"policies,omitempty" yaml:"policies,omitempty"`
 	// Options: `ConsensusStateNormal` and `ConsensusStateMaintenance`
-	State ConsensusState
+	State ConsensusState `json:"state,omitempty" yaml:"state,omitempty"`
 }
 
 // BatchSize is the configuration affecting the size of batches.
 type BatchSize struct {
 	// MaxMessageCount is the max message count.
-	MaxMessageCount uint32
+	MaxMessageCount uint32 `json:"max_message_count,omitempty" yaml:"max_message_count,omitempty"`
 	// AbsoluteMaxBytes is the max block size (not including headers).
-	AbsoluteMaxBytes uint32
+	AbsoluteMaxBytes uint32 `json:"absolute_max_bytes,omitempty" yaml:"absolute_max_bytes,omitempty"`
 	// PreferredMaxBytes is the preferred size of blocks.
-	PreferredMaxBytes uint32
+	PreferredMaxBytes uint32 `json:"preferred_max_bytes,omitempty" yaml:"preferred_max_bytes,omitempty"`
 }
 
 // Kafka is a list of Kafka broker endpoints.
 type Kafka struct {
 	// Brokers contains the addresses of *at least two* kafka brokers
 	// Must be in `IP:port` notation
-	Brokers []string
+	Brokers []string `json:"brokers,omitempty" yaml:"brokers,omitempty"`
 }
 
 // UpdateOrdererConfiguration modifies an existing config tx's Orderer configuration
@@ -9,12 +9,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	"github.com/hyperledger/fabric/common/policydsl"
 )
 
@@ -177,8 +180,14 @@ func RemoveConsortiumOrgPolicy(config *cb.Config, consortiumName, orgName, polic
 }
 
 // AddOrdererPolicy modifies an existing orderer policy configuration.
-// When the policy exists it will overwrite the existing policy.
+// When the policy exists it will overwrite the existing policy. The
+// BlockValidation policy cannot be modified with this function, use
+// SetOrdererBlockValidationPolicy instead.
 func AddOrdererPolicy(config *cb.Config, modPolicy, policyName string, policy Policy) error {
+	if policyName == BlockValidationPolicyKey {
+		return errors.New("BlockValidation policy must be set via SetOrdererBlockValidationPolicy")
+	}
+
 	err := addPolicy(config.ChannelGroup.Groups[OrdererGroupKey], modPolicy, policyName, policy)
 	if err != nil {
 		return fmt.Errorf("failed to add policy '%s': %v", policyName, err)
@@ -187,6 +196,108 @@ func AddOrdererPolicy(config *cb.Config, modPolicy, policyName string, policy Po
 	return nil
 }
 
+// SetOrdererBlockValidationPolicy replaces the orderer BlockValidation policy
+// with a signature policy, as required for BFT-style block verification where
+// blocks carry signatures of multiple ordering nodes. When the channel uses
+// etcdraft, the policy's top level threshold is checked against the BFT
+// quorum of the consenter set, so that a policy too weak to guarantee safety
+// or impossible to satisfy is rejected before it is submitted.
+func SetOrdererBlockValidationPolicy(config *cb.Config, policy Policy) error {
+	if policy.Type != SignaturePolicyType {
+		return fmt.Errorf("BlockValidation policy must be of type %s", SignaturePolicyType)
+	}
+
+	signaturePolicy, err := policydsl.FromString(policy.Rule)
+	if err != nil {
+		return fmt.Errorf("invalid signature policy rule: '%s': %v", policy.Rule, err)
+	}
+
+	err = validateBlockValidationQuorum(config, signaturePolicy)
+	if err != nil {
+		return err
+	}
+
+	err = addPolicy(config.ChannelGroup.Groups[OrdererGroupKey], AdminsPolicyKey, BlockValidationPolicyKey, policy)
+	if err != nil {
+		return fmt.Errorf("failed to add policy '%s': %v", BlockValidationPolicyKey, err)
+	}
+
+	return nil
+}
+
+// validateBlockValidationQuorum checks a prospective BlockValidation policy's
+// threshold against the channel's consenter set. For n consenters tolerating
+// f = (n-1)/3 byzantine faults, the policy must require at least the BFT
+// quorum of ceil((n+f+1)/2) signatures and must remain satisfiable by the
+// rules it lists. Channels without an etcdraft consenter set have no quorum
+// to validate against.
+func validateBlockValidationQuorum(config *cb.Config, policy *cb.SignaturePolicyEnvelope) error {
+	n, err := ordererConsenterCount(config)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	threshold, ruleCount := 1, 1
+	if nOutOf := policy.Rule.GetNOutOf(); nOutOf != nil {
+		threshold, ruleCount = int(nOutOf.N), len(nOutOf.Rules)
+	}
+
+	f := (n - 1) / 3
+	quorum := (n+f)/2 + 1
+
+	if threshold < quorum {
+		return fmt.Errorf("BlockValidation policy requires %d signatures, BFT quorum for %d consenters is %d", threshold, n, quorum)
+	}
+
+	if threshold > ruleCount {
+		return fmt.Errorf("BlockValidation policy requires %d signatures but only lists %d rules", threshold, ruleCount)
+	}
+
+	return nil
+}
+
+// ordererConsenterCount returns the number of etcdraft consenters in the
+// orderer config group, or zero when the channel does not use etcdraft.
+func ordererConsenterCount(config *cb.Config) (int, error) {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return 0, errors.New("orderer missing from config")
+	}
+
+	consenters, err := ordererConsenters(ordererGroup)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(consenters), nil
+}
+
+// ordererConsenters returns the etcdraft consenters of an orderer group, or
+// no consenters when a different consensus type is configured.
+func ordererConsenters(ordererGroup *cb.ConfigGroup) ([]*eb.Consenter, error) {
+	consensusTypeProto := &ob.ConsensusType{}
+	err := unmarshalConfigValueAtKey(ordererGroup, ConsensusTypeKey, consensusTypeProto)
+	if err != nil {
+		return nil, err
+	}
+
+	if consensusTypeProto.Type != ConsensusTypeEtcdRaft {
+		return nil, nil
+	}
+
+	configMetadata := &eb.ConfigMetadata{}
+	err = proto.Unmarshal(consensusTypeProto.Metadata, configMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling etcdraft metadata: %v", err)
+	}
+
+	return configMetadata.Consenters, nil
+}
+
 // RemoveOrdererPolicy removes an existing orderer policy configuration.
 // The policy must exist in the config.
 func RemoveOrdererPolicy(config *cb.Config, policyName string) error {
@@ -219,50 +330,121 @@ func RemoveOrdererOrgPolicy(config *cb.Config, orgName, policyName string) error
 	return removePolicy(config.ChannelGroup.Groups[OrdererGroupKey].Groups[orgName], policyName, policies)
 }
 
+// ImplicitMetaAnyPolicy returns an ImplicitMeta policy that is satisfied
+// when any sub-group's policy of the given name is satisfied.
+func ImplicitMetaAnyPolicy(subPolicy string) Policy {
+	return Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: cb.ImplicitMetaPolicy_ANY.String() + " " + subPolicy,
+	}
+}
+
+// ImplicitMetaAllPolicy returns an ImplicitMeta policy that is satisfied
+// only when every sub-group's policy of the given name is satisfied.
+func ImplicitMetaAllPolicy(subPolicy string) Policy {
+	return Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: cb.ImplicitMetaPolicy_ALL.String() + " " + subPolicy,
+	}
+}
+
+// ImplicitMetaMajorityPolicy returns an ImplicitMeta policy that is satisfied
+// when a majority of the sub-groups' policies of the given name are satisfied.
+func ImplicitMetaMajorityPolicy(subPolicy string) Policy {
+	return Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: cb.ImplicitMetaPolicy_MAJORITY.String() + " " + subPolicy,
+	}
+}
+
+// ParseImplicitMetaPolicy parses a "MAJORITY Admins"-style rule and checks
+// that the named sub-policy is defined in at least one sub-group of the
+// group the policy is destined for. A rule referencing a sub-policy no
+// sub-group defines parses cleanly but can never be satisfied, and the
+// orderer will reject the resulting config.
+func ParseImplicitMetaPolicy(rule string, group *cb.ConfigGroup) (Policy, error) {
+	imp, err := implicitMetaFromString(rule)
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid implicit meta policy rule: '%s': %v", rule, err)
+	}
+
+	subPolicyNames := map[string]struct{}{}
+	for _, subGroup := range group.Groups {
+		for policyName := range subGroup.Policies {
+			subPolicyNames[policyName] = struct{}{}
+		}
+	}
+
+	if _, ok := subPolicyNames[imp.SubPolicy]; !ok {
+		known := make([]string, 0, len(subPolicyNames))
+		for policyName := range subPolicyNames {
+			known = append(known, policyName)
+		}
+		sort.Strings(known)
+
+		return Policy{}, fmt.Errorf("sub-policy '%s' is not defined in any sub-group, defined sub-policies are %v", imp.SubPolicy, known)
+	}
+
+	return Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: rule,
+	}, nil
+}
+
 // getPolicies returns a map of Policy from given map of ConfigPolicy in organization config group.
 func getPolicies(policies map[string]*cb.ConfigPolicy) (map[string]Policy, error) {
 	p := map[string]Policy{}
 
 	for name, policy := range policies {
-		switch cb.Policy_PolicyType(policy.Policy.Type) {
-		case cb.Policy_IMPLICIT_META:
-			imp := &cb.ImplicitMetaPolicy{}
-			err := proto.Unmarshal(policy.Policy.Value, imp)
-			if err != nil {
-				return nil, err
-			}
+		converted, err := policyFromProto(policy.Policy)
+		if err != nil {
+			return nil, err
+		}
 
-			rule, err := implicitMetaToString(imp)
-			if err != nil {
-				return nil, err
-			}
+		p[name] = converted
+	}
 
-			p[name] = Policy{
-				Type: ImplicitMetaPolicyType,
-				Rule: rule,
-			}
-		case cb.Policy_SIGNATURE:
-			sp := &cb.SignaturePolicyEnvelope{}
-			err := proto.Unmarshal(policy.Policy.Value, sp)
-			if err != nil {
-				return nil, err
-			}
+	return p, nil
+}
 
-			rule, err := signatureMetaToString(sp)
-			if err != nil {
-				return nil, err
-			}
+// policyFromProto converts a *cb.Policy to its Policy representation.
+func policyFromProto(policy *cb.Policy) (Policy, error) {
+	switch cb.Policy_PolicyType(policy.Type) {
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		err := proto.Unmarshal(policy.Value, imp)
+		if err != nil {
+			return Policy{}, err
+		}
 
-			p[name] = Policy{
-				Type: SignaturePolicyType,
-				Rule: rule,
-			}
-		default:
-			return nil, fmt.Errorf("unknown policy type: %v", policy.Policy.Type)
+		rule, err := implicitMetaToString(imp)
+		if err != nil {
+			return Policy{}, err
 		}
-	}
 
-	return p, nil
+		return Policy{
+			Type: ImplicitMetaPolicyType,
+			Rule: rule,
+		}, nil
+	case cb.Policy_SIGNATURE:
+		sp := &cb.SignaturePolicyEnvelope{}
+		err := proto.Unmarshal(policy.Value, sp)
+		if err != nil {
+			return Policy{}, err
+		}
+
+		rule, err := signatureMetaToString(sp)
+		if err != nil {
+			return Policy{}, err
+		}
+
+		return Policy{
+			Type: SignaturePolicyType,
+			Rule: rule,
+		}, nil
+	default:
+		return Policy{}, fmt.Errorf("unknown policy type: %v", policy.Type)
+	}
 }
 
 // implicitMetaToString converts a *cb.ImplicitMetaPolicy to a string representation.
@@ -332,6 +514,8 @@ func mspPrincipalToString(principal *mb.MSPPrincipal) (string, error) {
 		return "", nil
 	case mb.MSPPrincipal_COMBINED:
 		return "", nil
+	case mb.MSPPrincipal_ATTRIBUTE:
+		return "", nil
 	default:
 		return "", fmt.Errorf("unknown MSP principal classiciation %v", principal.PrincipalClassification)
 	}
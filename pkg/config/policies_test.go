@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package config
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
 	. "github.com/onsi/gomega"
 )
 
@@ -698,3 +702,202 @@ func TestRemoveOrdererOrgPolicyFailures(t *testing.T) {
 	err = RemoveOrdererOrgPolicy(config, "bad-org", "TestPolicy")
 	gt.Expect(err).To(MatchError("orderer org bad-org does not exist in channel config"))
 }
+
+func TestSetOrdererBlockValidationPolicy(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseEtcdRaftConfig(t, 4)
+	ordererConfigGroup := config.ChannelGroup.Groups[OrdererGroupKey]
+
+	// 4 consenters tolerate f=1 faults, BFT quorum is 3
+	err := SetOrdererBlockValidationPolicy(config, Policy{
+		Type: SignaturePolicyType,
+		Rule: "OutOf(3, 'A.member', 'B.member', 'C.member', 'D.member')",
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	policy := ordererConfigGroup.Policies[BlockValidationPolicyKey]
+	gt.Expect(policy).NotTo(BeNil())
+	gt.Expect(policy.Policy.Type).To(Equal(int32(cb.Policy_SIGNATURE)))
+}
+
+func TestSetOrdererBlockValidationPolicyFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		policy      Policy
+		expectedErr string
+	}{
+		{
+			testName:    "when the policy is not a signature policy",
+			policy:      Policy{Type: ImplicitMetaPolicyType, Rule: "MAJORITY Admins"},
+			expectedErr: "BlockValidation policy must be of type Signature",
+		},
+		{
+			testName:    "when the policy rule is invalid",
+			policy:      Policy{Type: SignaturePolicyType, Rule: "garbage"},
+			expectedErr: "invalid signature policy rule: 'garbage': unrecognized token 'garbage' in policy string at line 1, col 1: did you mean 'garbage.member'?",
+		},
+		{
+			testName:    "when the threshold is below the BFT quorum",
+			policy:      Policy{Type: SignaturePolicyType, Rule: "OutOf(2, 'A.member', 'B.member', 'C.member', 'D.member')"},
+			expectedErr: "BlockValidation policy requires 2 signatures, BFT quorum for 4 consenters is 3",
+		},
+		{
+			testName:    "when the threshold exceeds the listed rules",
+			policy:      Policy{Type: SignaturePolicyType, Rule: "OutOf(3, 'A.member', 'B.member')"},
+			expectedErr: "BlockValidation policy requires 3 signatures but only lists 2 rules",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			config := baseEtcdRaftConfig(t, 4)
+
+			err := SetOrdererBlockValidationPolicy(config, test.policy)
+			gt.Expect(err).To(MatchError(test.expectedErr))
+		})
+	}
+}
+
+func TestAddOrdererPolicyRefusesBlockValidation(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				OrdererGroupKey: ordererGroup,
+			},
+		},
+	}
+
+	err = AddOrdererPolicy(config, AdminsPolicyKey, BlockValidationPolicyKey, Policy{Type: SignaturePolicyType, Rule: "ANY Admins"})
+	gt.Expect(err).To(MatchError("BlockValidation policy must be set via SetOrdererBlockValidationPolicy"))
+}
+
+// baseEtcdRaftConfig returns a config whose orderer group carries an
+// etcdraft consenter set of the given size.
+func baseEtcdRaftConfig(t *testing.T, consenterCount int) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	consenters := []*eb.Consenter{}
+	for i := 0; i < consenterCount; i++ {
+		consenters = append(consenters, &eb.Consenter{
+			Host:          fmt.Sprintf("node-%d.example.com", i+1),
+			Port:          7050,
+			ClientTlsCert: []byte(dummyCert),
+			ServerTlsCert: []byte(dummyCert),
+		})
+	}
+
+	etcdRaftMetadata, err := proto.Marshal(&eb.ConfigMetadata{Consenters: consenters})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	consensusType, err := proto.Marshal(&ob.ConsensusType{
+		Type:     ConsensusTypeEtcdRaft,
+		Metadata: etcdRaftMetadata,
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	ordererGroup.Values[ConsensusTypeKey].Value = consensusType
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				OrdererGroupKey: ordererGroup,
+			},
+		},
+	}
+}
+
+func TestImplicitMetaPolicyConstructors(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	gt.Expect(ImplicitMetaAnyPolicy("Readers")).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "ANY Readers",
+	}))
+	gt.Expect(ImplicitMetaAllPolicy("Writers")).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "ALL Writers",
+	}))
+	gt.Expect(ImplicitMetaMajorityPolicy("Admins")).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "MAJORITY Admins",
+	}))
+}
+
+func TestParseImplicitMetaPolicy(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	ordererGroup, err := newOrdererGroup(baseOrderer())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	policy, err := ParseImplicitMetaPolicy("MAJORITY Admins", ordererGroup)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(policy).To(Equal(Policy{
+		Type: ImplicitMetaPolicyType,
+		Rule: "MAJORITY Admins",
+	}))
+}
+
+func TestParseImplicitMetaPolicyFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		rule        string
+		expectedErr string
+	}{
+		{
+			testName:    "when the rule is malformed",
+			rule:        "MAJORITY",
+			expectedErr: "invalid implicit meta policy rule: 'MAJORITY': expected two space separated tokens, but got 1",
+		},
+		{
+			testName:    "when the rule type is unknown",
+			rule:        "MOST Admins",
+			expectedErr: "invalid implicit meta policy rule: 'MOST Admins': unknown rule type 'MOST', expected ALL, ANY, or MAJORITY",
+		},
+		{
+			testName:    "when the sub-policy is not defined in any sub-group",
+			rule:        "MAJORITY Curators",
+			expectedErr: "sub-policy 'Curators' is not defined in any sub-group, defined sub-policies are [Admins Endorsement Readers Writers]",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			ordererGroup, err := newOrdererGroup(baseOrderer())
+			gt.Expect(err).NotTo(HaveOccurred())
+
+			_, err = ParseImplicitMetaPolicy(test.rule, ordererGroup)
+			gt.Expect(err).To(MatchError(test.expectedErr))
+		})
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// Phases of a root CA rotation, in the order they are traversed.
+const (
+	// RotationPhaseAddRoot: the new root still has to be added to the
+	// organization's MSP in the channel config.
+	RotationPhaseAddRoot = "add-root"
+	// RotationPhaseReissue: both roots are trusted; node and admin
+	// certificates have to be reissued under the new root.
+	RotationPhaseReissue = "reissue"
+	// RotationPhaseRemoveRoot: the reissued certificates are verified;
+	// the old root still has to be removed from the channel config.
+	RotationPhaseRemoveRoot = "remove-root"
+	// RotationPhaseComplete: the rotation is finished.
+	RotationPhaseComplete = "complete"
+)
+
+// RootRotation tracks the rotation of an organization's root CA through
+// its phases: first the new root is added next to the old one so that both
+// are trusted, then the organization's node and admin certificates are
+// reissued under the new root and verified, and only then is the old root
+// removed. Each transition verifies the preconditions of the next phase,
+// so an operator cannot remove the old root while identities still depend
+// on it.
+type RootRotation struct {
+	// OrgName is the application organization whose root is rotated.
+	OrgName string
+	// OldRoot is the root CA certificate being retired.
+	OldRoot x509.Certificate
+	// NewRoot is the root CA certificate taking its place.
+	NewRoot x509.Certificate
+	// Phase is the current phase of the rotation, one of the
+	// RotationPhase constants above.
+	Phase string
+}
+
+// NewRootRotation starts tracking a root CA rotation for the given
+// application organization. The new root must be a CA certificate distinct
+// from the old one.
+func NewRootRotation(orgName string, oldRoot, newRoot x509.Certificate) (*RootRotation, error) {
+	if !newRoot.IsCA {
+		return nil, fmt.Errorf("new root for org %s is not a CA certificate", orgName)
+	}
+	if bytes.Equal(oldRoot.Raw, newRoot.Raw) {
+		return nil, fmt.Errorf("old and new root for org %s are the same certificate", orgName)
+	}
+
+	return &RootRotation{
+		OrgName: orgName,
+		OldRoot: oldRoot,
+		NewRoot: newRoot,
+		Phase:   RotationPhaseAddRoot,
+	}, nil
+}
+
+// AddRoot adds the new root to the organization's MSP in the channel
+// config, next to the old one, and advances the rotation to the reissue
+// phase. The old root must currently be trusted by the MSP.
+func (r *RootRotation) AddRoot(config *cb.Config) error {
+	if r.Phase != RotationPhaseAddRoot {
+		return fmt.Errorf("cannot add the new root in phase %q", r.Phase)
+	}
+
+	err := r.updateMSP(config, func(m *MSP) error {
+		if !containsCert(m.RootCerts, r.OldRoot) {
+			return fmt.Errorf("org %s does not trust the root being rotated out", r.OrgName)
+		}
+		if !containsCert(m.RootCerts, r.NewRoot) {
+			m.RootCerts = append(m.RootCerts, r.NewRoot)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.Phase = RotationPhaseReissue
+	return nil
+}
+
+// VerifyReissue checks that the given reissued node and admin certificates
+// chain to the new root and advances the rotation to the remove-root
+// phase. It should be called with every certificate the organization
+// operates once they have been reissued.
+func (r *RootRotation) VerifyReissue(certs ...x509.Certificate) error {
+	if r.Phase != RotationPhaseReissue {
+		return fmt.Errorf("cannot verify reissued certificates in phase %q", r.Phase)
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("no reissued certificates to verify for org %s", r.OrgName)
+	}
+
+	for _, cert := range certs {
+		if err := r.chainsToNewRoot(cert); err != nil {
+			return err
+		}
+	}
+
+	r.Phase = RotationPhaseRemoveRoot
+	return nil
+}
+
+// RemoveRoot removes the old root from the organization's MSP in the
+// channel config and completes the rotation. It refuses to remove the old
+// root while any admin certificate in the config still depends on it.
+func (r *RootRotation) RemoveRoot(config *cb.Config) error {
+	if r.Phase != RotationPhaseRemoveRoot {
+		return fmt.Errorf("cannot remove the old root in phase %q", r.Phase)
+	}
+
+	err := r.updateMSP(config, func(m *MSP) error {
+		for _, admin := range m.Admins {
+			if err := r.chainsToNewRoot(admin); err != nil {
+				return fmt.Errorf("admin of org %s still depends on the old root: %v", r.OrgName, err)
+			}
+		}
+		var remaining []x509.Certificate
+		for _, root := range m.RootCerts {
+			if !bytes.Equal(root.Raw, r.OldRoot.Raw) {
+				remaining = append(remaining, root)
+			}
+		}
+		m.RootCerts = remaining
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.Phase = RotationPhaseComplete
+	return nil
+}
+
+// VerifyConfig checks that the organization's MSP in the channel config
+// matches the expectations of the current phase: before AddRoot only the
+// old root is required, while reissuing both roots must be trusted, and
+// once complete the old root must be gone.
+func (r *RootRotation) VerifyConfig(config *cb.Config) error {
+	m, err := GetMSPConfigurationForApplicationOrg(config, r.OrgName)
+	if err != nil {
+		return err
+	}
+
+	switch r.Phase {
+	case RotationPhaseAddRoot:
+		if !containsCert(m.RootCerts, r.OldRoot) {
+			return fmt.Errorf("org %s does not trust the root being rotated out", r.OrgName)
+		}
+	case RotationPhaseReissue, RotationPhaseRemoveRoot:
+		if !containsCert(m.RootCerts, r.OldRoot) || !containsCert(m.RootCerts, r.NewRoot) {
+			return fmt.Errorf("org %s must trust both the old and the new root in phase %q", r.OrgName, r.Phase)
+		}
+	case RotationPhaseComplete:
+		if containsCert(m.RootCerts, r.OldRoot) {
+			return fmt.Errorf("org %s still trusts the old root after the rotation completed", r.OrgName)
+		}
+		if !containsCert(m.RootCerts, r.NewRoot) {
+			return fmt.Errorf("org %s does not trust the new root after the rotation completed", r.OrgName)
+		}
+	default:
+		return fmt.Errorf("unknown rotation phase %q", r.Phase)
+	}
+
+	return nil
+}
+
+// VerifyLocalMSPDir checks that a node's local MSP folder keeps up with
+// the rotation: from the reissue phase on its cacerts must include the new
+// root, and once the rotation is complete the old root must be gone.
+func (r *RootRotation) VerifyLocalMSPDir(dir string) error {
+	caCerts, err := readLocalCACerts(dir)
+	if err != nil {
+		return err
+	}
+
+	switch r.Phase {
+	case RotationPhaseAddRoot:
+		if !containsCert(caCerts, r.OldRoot) {
+			return fmt.Errorf("local MSP at %s does not trust the root being rotated out", dir)
+		}
+	case RotationPhaseReissue, RotationPhaseRemoveRoot:
+		if !containsCert(caCerts, r.NewRoot) {
+			return fmt.Errorf("local MSP at %s does not trust the new root yet", dir)
+		}
+	case RotationPhaseComplete:
+		if !containsCert(caCerts, r.NewRoot) {
+			return fmt.Errorf("local MSP at %s does not trust the new root", dir)
+		}
+		if containsCert(caCerts, r.OldRoot) {
+			return fmt.Errorf("local MSP at %s still trusts the old root after the rotation completed", dir)
+		}
+	default:
+		return fmt.Errorf("unknown rotation phase %q", r.Phase)
+	}
+
+	return nil
+}
+
+// updateMSP applies the given update to the organization's MSP and writes
+// the result back into the channel config.
+func (r *RootRotation) updateMSP(config *cb.Config, update func(m *MSP) error) error {
+	orgGroup, ok := config.ChannelGroup.Groups[ApplicationGroupKey].Groups[r.OrgName]
+	if !ok {
+		return fmt.Errorf("application org %s does not exist in config", r.OrgName)
+	}
+
+	m, err := getMSPConfigForOrg(orgGroup, r.OrgName)
+	if err != nil {
+		return err
+	}
+
+	if err := update(&m); err != nil {
+		return err
+	}
+
+	fabricMSPConfig, err := m.toProto()
+	if err != nil {
+		return fmt.Errorf("converting fabric msp config to proto: %v", err)
+	}
+
+	conf, err := proto.Marshal(fabricMSPConfig)
+	if err != nil {
+		return fmt.Errorf("marshaling msp config: %v", err)
+	}
+
+	return addValue(orgGroup, mspValue(&mb.MSPConfig{Config: conf}), AdminsPolicyKey)
+}
+
+// chainsToNewRoot verifies that the given certificate chains to the new
+// root of the rotation.
+func (r *RootRotation) chainsToNewRoot(cert x509.Certificate) error {
+	roots := x509.NewCertPool()
+	newRoot := r.NewRoot
+	roots.AddCert(&newRoot)
+
+	_, err := cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("certificate [%s] does not chain to the new root: %v", cert.Subject, err)
+	}
+	return nil
+}
+
+// readLocalCACerts parses the CA certificates of a local MSP folder.
+func readLocalCACerts(dir string) ([]x509.Certificate, error) {
+	files, err := ioutil.ReadDir(filepath.Join(dir, "cacerts"))
+	if err != nil {
+		return nil, fmt.Errorf("reading cacerts of local MSP at %s: %v", dir, err)
+	}
+
+	var caCerts []x509.Certificate
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(filepath.Join(dir, "cacerts", file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate of local MSP at %s: %v", dir, err)
+		}
+		cert, err := parseCertificateFromBytes(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CA certificate %s of local MSP at %s: %v", file.Name(), dir, err)
+		}
+		caCerts = append(caCerts, cert)
+	}
+	return caCerts, nil
+}
+
+// containsCert reports whether certs contains the given certificate.
+func containsCert(certs []x509.Certificate, cert x509.Certificate) bool {
+	for _, candidate := range certs {
+		if bytes.Equal(candidate.Raw, cert.Raw) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	. "github.com/onsi/gomega"
+)
+
+func rotationCA(gt *GomegaWithT, cn string) (x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gt.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	gt.Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDER)
+	gt.Expect(err).NotTo(HaveOccurred())
+	return *cert, key
+}
+
+func rotationIssue(gt *GomegaWithT, ca x509.Certificate, caKey *ecdsa.PrivateKey, cn string) x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gt.Expect(err).NotTo(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, &ca, &key.PublicKey, caKey)
+	gt.Expect(err).NotTo(HaveOccurred())
+	cert, err := x509.ParseCertificate(certDER)
+	gt.Expect(err).NotTo(HaveOccurred())
+	return *cert
+}
+
+func rotationMSP(root, admin x509.Certificate) MSP {
+	return MSP{
+		Name:      "Org1MSP",
+		RootCerts: []x509.Certificate{root},
+		Admins:    []x509.Certificate{admin},
+		SigningIdentity: SigningIdentityInfo{
+			PublicSigner: admin,
+		},
+		NodeOus: NodeOUs{
+			ClientOUIdentifier:  OUIdentifier{Certificate: root},
+			PeerOUIdentifier:    OUIdentifier{Certificate: root},
+			AdminOUIdentifier:   OUIdentifier{Certificate: root},
+			OrdererOUIdentifier: OUIdentifier{Certificate: root},
+		},
+	}
+}
+
+func rotationConfig(gt *GomegaWithT, orgName string, m MSP) *cb.Config {
+	fabricMSPConfig, err := m.toProto()
+	gt.Expect(err).NotTo(HaveOccurred())
+	conf, err := proto.Marshal(fabricMSPConfig)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	orgGroup := newConfigGroup()
+	err = addValue(orgGroup, mspValue(&mb.MSPConfig{Config: conf}), AdminsPolicyKey)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: {
+					Groups: map[string]*cb.ConfigGroup{
+						orgName: orgGroup,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRootRotation(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	oldCA, oldCAKey := rotationCA(gt, "old-ca.example.com")
+	newCA, newCAKey := rotationCA(gt, "new-ca.example.com")
+	oldAdmin := rotationIssue(gt, oldCA, oldCAKey, "admin.example.com")
+	config := rotationConfig(gt, "Org1", rotationMSP(oldCA, oldAdmin))
+
+	rotation, err := NewRootRotation("Org1", oldCA, newCA)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseAddRoot))
+	gt.Expect(rotation.VerifyConfig(config)).To(Succeed())
+
+	// phase transitions are enforced in order
+	err = rotation.VerifyReissue(oldAdmin)
+	gt.Expect(err).To(MatchError(`cannot verify reissued certificates in phase "add-root"`))
+	err = rotation.RemoveRoot(config)
+	gt.Expect(err).To(MatchError(`cannot remove the old root in phase "add-root"`))
+
+	// phase 1: both roots become trusted
+	gt.Expect(rotation.AddRoot(config)).To(Succeed())
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseReissue))
+	gt.Expect(rotation.VerifyConfig(config)).To(Succeed())
+
+	updatedMSP, err := GetMSPConfigurationForApplicationOrg(config, "Org1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(updatedMSP.RootCerts).To(HaveLen(2))
+
+	// certificates still issued under the old root do not pass verification
+	err = rotation.VerifyReissue(oldAdmin)
+	gt.Expect(err.Error()).To(ContainSubstring("does not chain to the new root"))
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseReissue))
+
+	// reissue the admin under the new root and record it in the config
+	newAdmin := rotationIssue(gt, newCA, newCAKey, "admin.example.com")
+	err = rotation.updateMSP(config, func(m *MSP) error {
+		m.Admins = []x509.Certificate{newAdmin}
+		return nil
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(rotation.VerifyReissue(newAdmin)).To(Succeed())
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseRemoveRoot))
+
+	// phase 2: the old root is removed
+	gt.Expect(rotation.RemoveRoot(config)).To(Succeed())
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseComplete))
+	gt.Expect(rotation.VerifyConfig(config)).To(Succeed())
+
+	updatedMSP, err = GetMSPConfigurationForApplicationOrg(config, "Org1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(updatedMSP.RootCerts).To(HaveLen(1))
+	gt.Expect(updatedMSP.RootCerts[0].Raw).To(Equal(newCA.Raw))
+}
+
+func TestRootRotationRefusesDanglingAdmins(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	oldCA, oldCAKey := rotationCA(gt, "old-ca.example.com")
+	newCA, newCAKey := rotationCA(gt, "new-ca.example.com")
+	oldAdmin := rotationIssue(gt, oldCA, oldCAKey, "admin.example.com")
+	config := rotationConfig(gt, "Org1", rotationMSP(oldCA, oldAdmin))
+
+	rotation, err := NewRootRotation("Org1", oldCA, newCA)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(rotation.AddRoot(config)).To(Succeed())
+
+	// the reissued certificate checks out, but the config still lists the
+	// admin issued under the old root
+	newAdmin := rotationIssue(gt, newCA, newCAKey, "admin.example.com")
+	gt.Expect(rotation.VerifyReissue(newAdmin)).To(Succeed())
+
+	err = rotation.RemoveRoot(config)
+	gt.Expect(err.Error()).To(ContainSubstring("admin of org Org1 still depends on the old root"))
+	gt.Expect(rotation.Phase).To(Equal(RotationPhaseRemoveRoot))
+}
+
+func TestNewRootRotationFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	oldCA, oldCAKey := rotationCA(gt, "old-ca.example.com")
+	newCA, _ := rotationCA(gt, "new-ca.example.com")
+	nonCA := rotationIssue(gt, oldCA, oldCAKey, "leaf.example.com")
+
+	_, err := NewRootRotation("Org1", oldCA, nonCA)
+	gt.Expect(err).To(MatchError("new root for org Org1 is not a CA certificate"))
+
+	_, err = NewRootRotation("Org1", newCA, newCA)
+	gt.Expect(err).To(MatchError("old and new root for org Org1 are the same certificate"))
+
+	rotation, err := NewRootRotation("Org1", oldCA, newCA)
+	gt.Expect(err).NotTo(HaveOccurred())
+	err = rotation.AddRoot(&cb.Config{ChannelGroup: &cb.ConfigGroup{Groups: map[string]*cb.ConfigGroup{
+		ApplicationGroupKey: {Groups: map[string]*cb.ConfigGroup{}},
+	}}})
+	gt.Expect(err).To(MatchError("application org Org1 does not exist in config"))
+}
+
+func TestRootRotationVerifyLocalMSPDir(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	oldCA, _ := rotationCA(gt, "old-ca.example.com")
+	newCA, _ := rotationCA(gt, "new-ca.example.com")
+
+	dir, err := ioutil.TempDir("", "rootrotation")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+	caCertsDir := filepath.Join(dir, "cacerts")
+	gt.Expect(os.Mkdir(caCertsDir, 0755)).To(Succeed())
+	err = ioutil.WriteFile(filepath.Join(caCertsDir, "old-ca.pem"), pemEncodeX509Certificate(oldCA), 0644)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	rotation, err := NewRootRotation("Org1", oldCA, newCA)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(rotation.VerifyLocalMSPDir(dir)).To(Succeed())
+
+	// until the new root is distributed, the local MSP lags behind
+	rotation.Phase = RotationPhaseReissue
+	err = rotation.VerifyLocalMSPDir(dir)
+	gt.Expect(err.Error()).To(ContainSubstring("does not trust the new root yet"))
+
+	err = ioutil.WriteFile(filepath.Join(caCertsDir, "new-ca.pem"), pemEncodeX509Certificate(newCA), 0644)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(rotation.VerifyLocalMSPDir(dir)).To(Succeed())
+
+	// once complete, the old root has to be removed from the folder
+	rotation.Phase = RotationPhaseComplete
+	err = rotation.VerifyLocalMSPDir(dir)
+	gt.Expect(err.Error()).To(ContainSubstring("still trusts the old root"))
+
+	gt.Expect(os.Remove(filepath.Join(caCertsDir, "old-ca.pem"))).To(Succeed())
+	gt.Expect(rotation.VerifyLocalMSPDir(dir)).To(Succeed())
+}
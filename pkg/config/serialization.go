@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Standard MSP directory layout folder names.
+const (
+	cacertsDir              = "cacerts"
+	intermediatecertsDir    = "intermediatecerts"
+	admincertsDir           = "admincerts"
+	tlscacertsDir           = "tlscacerts"
+	tlsintermediatecertsDir = "tlsintermediatecerts"
+	crlsDir                 = "crls"
+)
+
+// LoadOrganizationFromDir creates an Organization whose MSP crypto material
+// is read from an MSP directory layout (cacerts, intermediatecerts, admincerts,
+// tlscacerts, tlsintermediatecerts, and crls folders). All folders except
+// cacerts are optional. The returned Organization carries no policies; they
+// are expected to be set by the caller, for example by unmarshaling a JSON or
+// YAML declaration of the organization, before the organization is added to a
+// channel config via AddApplicationOrg, AddOrdererOrg, or AddOrgToConsortium.
+func LoadOrganizationFromDir(orgName, mspID, mspDir string) (Organization, error) {
+	msp, err := LoadMSPFromDir(mspID, mspDir)
+	if err != nil {
+		return Organization{}, err
+	}
+
+	return Organization{
+		Name: orgName,
+		MSP:  msp,
+	}, nil
+}
+
+// LoadMSPFromDir reads the crypto material in an MSP directory layout into
+// an MSP with the given MSP ID. The cacerts folder is required, all other
+// folders are optional.
+func LoadMSPFromDir(mspID, mspDir string) (MSP, error) {
+	rootCerts, err := loadCertificatesFromDir(filepath.Join(mspDir, cacertsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading root ca certs: %v", err)
+	}
+	if len(rootCerts) == 0 {
+		return MSP{}, fmt.Errorf("no root ca certs found in %s", filepath.Join(mspDir, cacertsDir))
+	}
+
+	intermediateCerts, err := loadOptionalCertificatesFromDir(filepath.Join(mspDir, intermediatecertsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading intermediate ca certs: %v", err)
+	}
+
+	adminCerts, err := loadOptionalCertificatesFromDir(filepath.Join(mspDir, admincertsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading admin certs: %v", err)
+	}
+
+	tlsRootCerts, err := loadOptionalCertificatesFromDir(filepath.Join(mspDir, tlscacertsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading tls root ca certs: %v", err)
+	}
+
+	tlsIntermediateCerts, err := loadOptionalCertificatesFromDir(filepath.Join(mspDir, tlsintermediatecertsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading tls intermediate ca certs: %v", err)
+	}
+
+	revocationList, err := loadCRLsFromDir(filepath.Join(mspDir, crlsDir))
+	if err != nil {
+		return MSP{}, fmt.Errorf("loading crls: %v", err)
+	}
+
+	return MSP{
+		Name:                 mspID,
+		RootCerts:            rootCerts,
+		IntermediateCerts:    intermediateCerts,
+		Admins:               adminCerts,
+		TLSRootCerts:         tlsRootCerts,
+		TLSIntermediateCerts: tlsIntermediateCerts,
+		RevocationList:       revocationList,
+	}, nil
+}
+
+// loadCertificatesFromDir parses every file in dir as a list of PEM encoded
+// X.509 certificates.
+func loadCertificatesFromDir(dir string) ([]x509.Certificate, error) {
+	certs := []x509.Certificate{}
+
+	fileBytes, err := readFilesInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for fileName, pemBytes := range fileBytes {
+		certificates, err := parseCertificateListFromBytes(splitPEMBlocks(pemBytes))
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificates in file %s: %v", fileName, err)
+		}
+
+		certs = append(certs, certificates...)
+	}
+
+	return certs, nil
+}
+
+// loadOptionalCertificatesFromDir behaves as loadCertificatesFromDir but
+// returns no certificates when the directory does not exist.
+func loadOptionalCertificatesFromDir(dir string) ([]x509.Certificate, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return []x509.Certificate{}, nil
+	}
+
+	return loadCertificatesFromDir(dir)
+}
+
+// loadCRLsFromDir parses every file in dir as a list of PEM encoded
+// certificate revocation lists. A missing directory yields an empty list.
+func loadCRLsFromDir(dir string) ([]pkix.CertificateList, error) {
+	crls := []pkix.CertificateList{}
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return crls, nil
+	}
+
+	fileBytes, err := readFilesInDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for fileName, pemBytes := range fileBytes {
+		certificateLists, err := parseCRL(splitPEMBlocks(pemBytes))
+		if err != nil {
+			return nil, fmt.Errorf("parsing crls in file %s: %v", fileName, err)
+		}
+
+		crls = append(crls, certificateLists...)
+	}
+
+	return crls, nil
+}
+
+// readFilesInDir returns the contents of every regular file in dir keyed
+// by file name. Subdirectories are skipped.
+func readFilesInDir(dir string) (map[string][]byte, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory %s: %v", dir, err)
+	}
+
+	fileBytes := map[string][]byte{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() {
+			continue
+		}
+
+		fileName := filepath.Join(dir, fileInfo.Name())
+		contents, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("reading file %s: %v", fileName, err)
+		}
+
+		fileBytes[fileName] = contents
+	}
+
+	return fileBytes, nil
+}
+
+// splitPEMBlocks splits a file's contents into individual PEM blocks so
+// that files holding multiple certificates or crls can be parsed with the
+// single block parsers.
+func splitPEMBlocks(pemBytes []byte) [][]byte {
+	blocks := [][]byte{}
+
+	for {
+		block, rest := pem.Decode(pemBytes)
+		if block == nil {
+			break
+		}
+
+		blocks = append(blocks, pem.EncodeToMemory(block))
+		pemBytes = rest
+	}
+
+	return blocks
+}
@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestLoadOrganizationFromDir(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	mspDir, cleanup := createMSPDirLayout(t)
+	defer cleanup()
+
+	org, err := LoadOrganizationFromDir("Org1", "Org1MSP", mspDir)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	gt.Expect(org.Name).To(Equal("Org1"))
+	gt.Expect(org.MSP.Name).To(Equal("Org1MSP"))
+	gt.Expect(org.MSP.RootCerts).To(HaveLen(1))
+	gt.Expect(org.MSP.IntermediateCerts).To(HaveLen(1))
+	gt.Expect(org.MSP.Admins).To(HaveLen(1))
+	gt.Expect(org.MSP.TLSRootCerts).To(HaveLen(1))
+	gt.Expect(org.MSP.TLSIntermediateCerts).To(BeEmpty())
+	gt.Expect(org.MSP.RevocationList).To(HaveLen(1))
+}
+
+func TestLoadOrganizationFromDirFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		corruptDir  func(mspDir string) error
+		expectedErr string
+	}{
+		{
+			testName: "missing cacerts folder",
+			corruptDir: func(mspDir string) error {
+				return os.RemoveAll(filepath.Join(mspDir, "cacerts"))
+			},
+			expectedErr: "loading root ca certs: reading directory",
+		},
+		{
+			testName: "empty cacerts folder",
+			corruptDir: func(mspDir string) error {
+				return os.Remove(filepath.Join(mspDir, "cacerts", "cacert.pem"))
+			},
+			expectedErr: "no root ca certs found in",
+		},
+		{
+			testName: "malformed cert",
+			corruptDir: func(mspDir string) error {
+				return ioutil.WriteFile(filepath.Join(mspDir, "cacerts", "cacert.pem"),
+					[]byte("-----BEGIN CERTIFICATE-----\naGVsbG8=\n-----END CERTIFICATE-----\n"), 0644)
+			},
+			expectedErr: "loading root ca certs: parsing certificates in file",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.testName, func(t *testing.T) {
+			t.Parallel()
+			gt := NewGomegaWithT(t)
+
+			mspDir, cleanup := createMSPDirLayout(t)
+			defer cleanup()
+
+			err := tt.corruptDir(mspDir)
+			gt.Expect(err).NotTo(HaveOccurred())
+
+			_, err = LoadOrganizationFromDir("Org1", "Org1MSP", mspDir)
+			gt.Expect(err.Error()).To(ContainSubstring(tt.expectedErr))
+		})
+	}
+}
+
+func TestOrganizationSerialization(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	expectedOrg := Organization{
+		Name:     "Org1",
+		Policies: standardPolicies(),
+		AnchorPeers: []AnchorPeer{
+			{Host: "host3", Port: 123},
+		},
+		OrdererEndpoints: []string{"localhost:123"},
+	}
+
+	yamlBytes, err := yaml.Marshal(expectedOrg)
+	gt.Expect(err).NotTo(HaveOccurred())
+	var orgFromYAML Organization
+	err = yaml.Unmarshal(yamlBytes, &orgFromYAML)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(orgFromYAML).To(Equal(expectedOrg))
+
+	jsonBytes, err := json.Marshal(expectedOrg)
+	gt.Expect(err).NotTo(HaveOccurred())
+	var orgFromJSON Organization
+	err = json.Unmarshal(jsonBytes, &orgFromJSON)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(orgFromJSON).To(Equal(expectedOrg))
+}
+
+// createMSPDirLayout writes a standard MSP directory layout populated with
+// the dummy crypto material used throughout this package's tests.
+func createMSPDirLayout(t *testing.T) (string, func()) {
+	gt := NewGomegaWithT(t)
+
+	mspDir, err := ioutil.TempDir("", "config-msp-dir")
+	gt.Expect(err).NotTo(HaveOccurred())
+	cleanup := func() {
+		os.RemoveAll(mspDir)
+	}
+
+	for folder, contents := range map[string]string{
+		"cacerts":           dummyCert,
+		"intermediatecerts": dummyCert,
+		"admincerts":        dummyCert,
+		"tlscacerts":        dummyCert,
+		"crls":              dummyCRL,
+	} {
+		err = os.MkdirAll(filepath.Join(mspDir, folder), 0755)
+		gt.Expect(err).NotTo(HaveOccurred())
+
+		fileName := "cacert.pem"
+		if folder == "crls" {
+			fileName = "crl.pem"
+		}
+		err = ioutil.WriteFile(filepath.Join(mspDir, folder, fileName), []byte(contents), 0644)
+		gt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	return mspDir, cleanup
+}
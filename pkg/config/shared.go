@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+)
+
+// SharedConfig wraps a channel configuration for safe concurrent use. The
+// functions in this package mutate the config they are given and are not
+// safe for concurrent use on their own; SharedConfig provides copy-on-write
+// semantics on top of them, so any number of goroutines can read the config
+// while another performs an update. Readers always observe either the config
+// from before an update or the config from after it, never a partially
+// mutated one.
+type SharedConfig struct {
+	// updateMu serializes updates so concurrent updates cannot overwrite
+	// each other's changes.
+	updateMu sync.Mutex
+
+	mu     sync.RWMutex
+	config *cb.Config
+}
+
+// NewSharedConfig wraps the given channel config for concurrent use. The
+// caller must not retain or modify the config after handing it over.
+func NewSharedConfig(config *cb.Config) *SharedConfig {
+	return &SharedConfig{
+		config: config,
+	}
+}
+
+// Read invokes the given function with the current config under a read lock.
+// The function must not modify the config or retain a reference to it beyond
+// the call.
+func (s *SharedConfig) Read(read func(config *cb.Config) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return read(s.config)
+}
+
+// Config returns a deep copy of the current config, which the caller may
+// modify freely.
+func (s *SharedConfig) Config() *cb.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return proto.Clone(s.config).(*cb.Config)
+}
+
+// Update applies the given mutation to a deep copy of the current config
+// and, if the mutation succeeds, replaces the shared config with the updated
+// copy. If the mutation fails, the shared config is left untouched. Readers
+// are only blocked for the swap of the updated config, not for the duration
+// of the mutation.
+func (s *SharedConfig) Update(mutation func(config *cb.Config) error) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	updatedConfig := s.Config()
+
+	err := mutation(updatedConfig)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.config = updatedConfig
+
+	return nil
+}
+
+// Apply applies all mutations queued on the given editor to a deep copy of
+// the current config and, if they all succeed, replaces the shared config
+// with the updated copy. The editor's own original config is ignored.
+func (s *SharedConfig) Apply(editor *ConfigEditor) error {
+	return s.Update(editor.applyTo)
+}
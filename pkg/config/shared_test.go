@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"sync"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	. "github.com/onsi/gomega"
+)
+
+func TestSharedConfigUpdate(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	shared := NewSharedConfig(baseSharedConfig(t))
+
+	err := shared.Update(func(config *cb.Config) error {
+		return AddApplicationOrg(config, baseApplicationOrg())
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = shared.Read(func(config *cb.Config) error {
+		applicationGroup := config.ChannelGroup.Groups[ApplicationGroupKey]
+		gt.Expect(applicationGroup.Groups["Org1"].Values).To(HaveKey(MSPKey))
+		return nil
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestSharedConfigUpdateFailure(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	shared := NewSharedConfig(baseSharedConfig(t))
+
+	err := shared.Update(func(config *cb.Config) error {
+		err := AddApplicationPolicy(config, AdminsPolicyKey, "TestPolicy", Policy{
+			Type: ImplicitMetaPolicyType,
+			Rule: "ANY Readers",
+		})
+		if err != nil {
+			return err
+		}
+
+		return RemoveApplicationPolicy(config, "DoesNotExist")
+	})
+	gt.Expect(err).To(MatchError("could not find policy 'DoesNotExist'"))
+
+	// a failed update leaves the shared config untouched, including
+	// mutations applied before the failing one
+	err = shared.Read(func(config *cb.Config) error {
+		applicationGroup := config.ChannelGroup.Groups[ApplicationGroupKey]
+		gt.Expect(applicationGroup.Policies).NotTo(HaveKey("TestPolicy"))
+		return nil
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestSharedConfigApplyEditor(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	shared := NewSharedConfig(baseSharedConfig(t))
+
+	editor := NewConfigEditor(nil)
+	editor.AddApplicationOrg(baseApplicationOrg())
+	editor.SetACLs(map[string]string{"acl1": "Readers"})
+
+	err := shared.Apply(editor)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	updatedApplicationGroup := shared.Config().ChannelGroup.Groups[ApplicationGroupKey]
+	gt.Expect(updatedApplicationGroup.Groups["Org1"].Values).To(HaveKey(MSPKey))
+	gt.Expect(updatedApplicationGroup.Values).To(HaveKey(ACLsKey))
+}
+
+func TestSharedConfigConcurrentReadersAndWriter(t *testing.T) {
+	t.Parallel()
+	gt := NewGomegaWithT(t)
+
+	shared := NewSharedConfig(baseSharedConfig(t))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				_ = shared.Read(func(config *cb.Config) error {
+					_, err := getPolicies(config.ChannelGroup.Groups[ApplicationGroupKey].Policies)
+					return err
+				})
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		err := shared.Update(func(config *cb.Config) error {
+			return SetACLs(config, map[string]string{"acl1": "Readers"})
+		})
+		gt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	wg.Wait()
+}
+
+// baseSharedConfig returns a config with only the application group populated.
+func baseSharedConfig(t *testing.T) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	applicationGroup, err := newApplicationGroup(baseApplication())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	return &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: applicationGroup,
+			},
+		},
+	}
+}
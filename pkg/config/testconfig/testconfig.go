@@ -0,0 +1,319 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package testconfig generates realistic channel configurations for use in
+// unit tests of tools built on top of pkg/config. The generated configs
+// carry valid self-signed certificates, so MSP parsing and certificate
+// reports behave the same way they do against real channel configs.
+package testconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/pkg/config"
+)
+
+// Options controls the shape of the generated channel config. The zero value
+// produces a config named "testchannel" with two application orgs, one
+// orderer org, and three raft consenters.
+type Options struct {
+	// ChannelID is the name of the channel.
+	ChannelID string
+	// ApplicationOrgs is the number of application orgs, named Org1..OrgN.
+	ApplicationOrgs int
+	// OrdererOrgs is the number of orderer orgs, named OrdererOrg1..OrdererOrgM.
+	OrdererOrgs int
+	// Consenters is the number of etcdraft consenters.
+	Consenters int
+}
+
+func (o *Options) applyDefaults() {
+	if o.ChannelID == "" {
+		o.ChannelID = "testchannel"
+	}
+	if o.ApplicationOrgs == 0 {
+		o.ApplicationOrgs = 2
+	}
+	if o.OrdererOrgs == 0 {
+		o.OrdererOrgs = 1
+	}
+	if o.Consenters == 0 {
+		o.Consenters = 3
+	}
+}
+
+// NewConfig generates a channel config tree with the requested number of
+// application orgs, orderer orgs, and etcdraft consenters. Each org carries
+// an MSP built from a freshly generated self-signed CA certificate.
+func NewConfig(opts Options) (*cb.Config, error) {
+	opts.applyDefaults()
+
+	applicationOrgs := []config.Organization{}
+	for i := 1; i <= opts.ApplicationOrgs; i++ {
+		org, err := newOrganization(fmt.Sprintf("Org%d", i), nil)
+		if err != nil {
+			return nil, err
+		}
+		applicationOrgs = append(applicationOrgs, org)
+	}
+
+	ordererOrgs := []config.Organization{}
+	for i := 1; i <= opts.OrdererOrgs; i++ {
+		org, err := newOrganization(
+			fmt.Sprintf("OrdererOrg%d", i),
+			[]string{fmt.Sprintf("orderer%d.example.com:7050", i)},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ordererOrgs = append(ordererOrgs, org)
+	}
+
+	channel := config.Channel{
+		ChannelID:    opts.ChannelID,
+		Capabilities: map[string]bool{"V2_0": true},
+		Policies:     standardPolicies(),
+		Application: config.Application{
+			Policies:      standardPolicies(),
+			Organizations: applicationOrgs,
+			Capabilities:  map[string]bool{"V2_0": true},
+		},
+		Orderer: config.Orderer{
+			// the consensus type value is replaced with etcdraft metadata
+			// below, since the raft metadata constructors expect certificate
+			// file paths rather than in-memory certs
+			OrdererType:   config.ConsensusTypeSolo,
+			Policies:      ordererPolicies(),
+			Organizations: ordererOrgs,
+			Capabilities:  map[string]bool{"V2_0": true},
+			BatchSize: config.BatchSize{
+				MaxMessageCount:   500,
+				AbsoluteMaxBytes:  10 * 1024 * 1024,
+				PreferredMaxBytes: 2 * 1024 * 1024,
+			},
+			BatchTimeout: 2 * time.Second,
+			State:        config.ConsensusStateNormal,
+		},
+	}
+
+	channelGroup, err := config.NewChannelGroup(channel)
+	if err != nil {
+		return nil, fmt.Errorf("creating channel group: %v", err)
+	}
+
+	c := &cb.Config{
+		Sequence:     0,
+		ChannelGroup: channelGroup,
+	}
+
+	// the channel group constructor leaves application org groups empty, as
+	// it does for channel creation transactions, so the full org definitions
+	// are added afterwards
+	for _, org := range applicationOrgs {
+		err = config.AddApplicationOrg(c, org)
+		if err != nil {
+			return nil, fmt.Errorf("adding application org %s: %v", org.Name, err)
+		}
+	}
+
+	err = setEtcdRaftConsensus(channelGroup.Groups[config.OrdererGroupKey], opts.Consenters)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// newOrganization returns an org definition whose MSP is built from a
+// generated self-signed CA certificate.
+func newOrganization(name string, ordererEndpoints []string) (config.Organization, error) {
+	caCert, err := newSelfSignedCACertificate(name + ".example.com")
+	if err != nil {
+		return config.Organization{}, fmt.Errorf("generating CA certificate for org %s: %v", name, err)
+	}
+
+	return config.Organization{
+		Name:             name,
+		Policies:         orgPolicies(),
+		OrdererEndpoints: ordererEndpoints,
+		MSP: config.MSP{
+			Name:              name + "MSP",
+			RootCerts:         []x509.Certificate{caCert},
+			IntermediateCerts: []x509.Certificate{},
+			Admins:            []x509.Certificate{caCert},
+			RevocationList:    []pkix.CertificateList{},
+			SigningIdentity: config.SigningIdentityInfo{
+				PublicSigner: caCert,
+			},
+			OrganizationalUnitIdentifiers: []config.OUIdentifier{},
+			CryptoConfig: config.CryptoConfig{
+				SignatureHashFamily:            "SHA2",
+				IdentityIdentifierHashFunction: "SHA256",
+			},
+			TLSRootCerts:         []x509.Certificate{caCert},
+			TLSIntermediateCerts: []x509.Certificate{},
+			NodeOus: config.NodeOUs{
+				Enable: true,
+				ClientOUIdentifier: config.OUIdentifier{
+					Certificate:                  caCert,
+					OrganizationalUnitIdentifier: "client",
+				},
+				PeerOUIdentifier: config.OUIdentifier{
+					Certificate:                  caCert,
+					OrganizationalUnitIdentifier: "peer",
+				},
+				AdminOUIdentifier: config.OUIdentifier{
+					Certificate:                  caCert,
+					OrganizationalUnitIdentifier: "admin",
+				},
+				OrdererOUIdentifier: config.OUIdentifier{
+					Certificate:                  caCert,
+					OrganizationalUnitIdentifier: "orderer",
+				},
+			},
+		},
+	}, nil
+}
+
+// setEtcdRaftConsensus replaces the orderer group's consensus type value
+// with etcdraft metadata carrying the requested number of consenters, each
+// with a generated self-signed TLS certificate.
+func setEtcdRaftConsensus(ordererGroup *cb.ConfigGroup, consenterCount int) error {
+	consenters := []*eb.Consenter{}
+	for i := 1; i <= consenterCount; i++ {
+		host := fmt.Sprintf("consenter%d.example.com", i)
+
+		tlsCert, err := newSelfSignedCACertificate(host)
+		if err != nil {
+			return fmt.Errorf("generating TLS certificate for consenter %s: %v", host, err)
+		}
+
+		pemCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: tlsCert.Raw})
+		consenters = append(consenters, &eb.Consenter{
+			Host:          host,
+			Port:          7050,
+			ClientTlsCert: pemCert,
+			ServerTlsCert: pemCert,
+		})
+	}
+
+	etcdRaftMetadata, err := proto.Marshal(&eb.ConfigMetadata{
+		Consenters: consenters,
+		Options: &eb.Options{
+			TickInterval:         "500ms",
+			ElectionTick:         10,
+			HeartbeatTick:        1,
+			MaxInflightBlocks:    5,
+			SnapshotIntervalSize: 16 * 1024 * 1024,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling etcdraft metadata: %v", err)
+	}
+
+	consensusType, err := proto.Marshal(&ob.ConsensusType{
+		Type:     config.ConsensusTypeEtcdRaft,
+		Metadata: etcdRaftMetadata,
+		State:    ob.ConsensusType_STATE_NORMAL,
+	})
+	if err != nil {
+		return fmt.Errorf("marshaling consensus type: %v", err)
+	}
+
+	ordererGroup.Values[config.ConsensusTypeKey].Value = consensusType
+
+	return nil
+}
+
+// newSelfSignedCACertificate generates an ECDSA P-256 self-signed CA
+// certificate valid for ten years.
+func newSelfSignedCACertificate(commonName string) (x509.Certificate, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return x509.Certificate{}, fmt.Errorf("generating private key: %v", err)
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return x509.Certificate{}, fmt.Errorf("generating serial number: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{commonName},
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return x509.Certificate{}, fmt.Errorf("creating certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return x509.Certificate{}, fmt.Errorf("parsing certificate: %v", err)
+	}
+
+	return *cert, nil
+}
+
+func standardPolicies() map[string]config.Policy {
+	return map[string]config.Policy{
+		config.ReadersPolicyKey: {
+			Type: config.ImplicitMetaPolicyType,
+			Rule: "ANY Readers",
+		},
+		config.WritersPolicyKey: {
+			Type: config.ImplicitMetaPolicyType,
+			Rule: "ANY Writers",
+		},
+		config.AdminsPolicyKey: {
+			Type: config.ImplicitMetaPolicyType,
+			Rule: "MAJORITY Admins",
+		},
+	}
+}
+
+func ordererPolicies() map[string]config.Policy {
+	policies := standardPolicies()
+
+	policies[config.BlockValidationPolicyKey] = config.Policy{
+		Type: config.ImplicitMetaPolicyType,
+		Rule: "ANY Writers",
+	}
+
+	return policies
+}
+
+func orgPolicies() map[string]config.Policy {
+	policies := standardPolicies()
+
+	policies[config.EndorsementPolicyKey] = config.Policy{
+		Type: config.ImplicitMetaPolicyType,
+		Rule: "MAJORITY Endorsement",
+	}
+
+	return policies
+}
@@ -0,0 +1,125 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package testconfig
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/pkg/config"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewConfigDefaults(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	c, err := NewConfig(Options{})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	applicationGroup := c.ChannelGroup.Groups[config.ApplicationGroupKey]
+	gt.Expect(applicationGroup.Groups).To(HaveLen(2))
+	gt.Expect(applicationGroup.Groups).To(HaveKey("Org1"))
+	gt.Expect(applicationGroup.Groups).To(HaveKey("Org2"))
+
+	ordererGroup := c.ChannelGroup.Groups[config.OrdererGroupKey]
+	gt.Expect(ordererGroup.Groups).To(HaveLen(1))
+	gt.Expect(ordererGroup.Groups).To(HaveKey("OrdererOrg1"))
+
+	endpoints, err := config.GetOrdererEndpoints(c, "OrdererOrg1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(endpoints).To(Equal([]string{"orderer1.example.com:7050"}))
+}
+
+func TestNewConfigOptions(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	c, err := NewConfig(Options{
+		ChannelID:       "mychannel",
+		ApplicationOrgs: 3,
+		OrdererOrgs:     2,
+		Consenters:      5,
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	applicationGroup := c.ChannelGroup.Groups[config.ApplicationGroupKey]
+	gt.Expect(applicationGroup.Groups).To(HaveLen(3))
+
+	ordererGroup := c.ChannelGroup.Groups[config.OrdererGroupKey]
+	gt.Expect(ordererGroup.Groups).To(HaveLen(2))
+}
+
+func TestNewConfigMSPRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	c, err := NewConfig(Options{})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	msp, err := config.GetMSPConfigurationForApplicationOrg(c, "Org1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(msp.Name).To(Equal("Org1MSP"))
+	gt.Expect(msp.RootCerts).To(HaveLen(1))
+	gt.Expect(msp.RootCerts[0].Subject.CommonName).To(Equal("Org1.example.com"))
+	gt.Expect(msp.RootCerts[0].IsCA).To(BeTrue())
+	gt.Expect(msp.TLSRootCerts).To(HaveLen(1))
+	gt.Expect(msp.NodeOus.Enable).To(BeTrue())
+	gt.Expect(msp.NodeOus.ClientOUIdentifier.OrganizationalUnitIdentifier).To(Equal("client"))
+
+	ordererMSP, err := config.GetMSPConfigurationForOrdererOrg(c, "OrdererOrg1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(ordererMSP.Name).To(Equal("OrdererOrg1MSP"))
+}
+
+func TestNewConfigEtcdRaftMetadata(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	c, err := NewConfig(Options{Consenters: 4})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	ordererGroup := c.ChannelGroup.Groups[config.OrdererGroupKey]
+
+	consensusType := &ob.ConsensusType{}
+	err = proto.Unmarshal(ordererGroup.Values[config.ConsensusTypeKey].Value, consensusType)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(consensusType.Type).To(Equal(config.ConsensusTypeEtcdRaft))
+
+	etcdRaftMetadata := &eb.ConfigMetadata{}
+	err = proto.Unmarshal(consensusType.Metadata, etcdRaftMetadata)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(etcdRaftMetadata.Consenters).To(HaveLen(4))
+
+	for i, consenter := range etcdRaftMetadata.Consenters {
+		gt.Expect(consenter.Host).To(Equal(fmt.Sprintf("consenter%d.example.com", i+1)))
+		gt.Expect(consenter.Port).To(Equal(uint32(7050)))
+		gt.Expect(consenter.ClientTlsCert).NotTo(BeEmpty())
+		gt.Expect(consenter.ServerTlsCert).NotTo(BeEmpty())
+	}
+}
+
+func TestNewConfigCertificateExpirations(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	c, err := NewConfig(Options{})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	expirations, err := config.CertificateExpirations(c)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(expirations).NotTo(BeEmpty())
+}
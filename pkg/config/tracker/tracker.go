@@ -0,0 +1,137 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package tracker follows the config blocks delivered on a channel. It reads
+// blocks from an orderer or peer Deliver stream, skips normal transaction
+// blocks, and surfaces each config block with the channel configuration and
+// the config update that produced it already decoded. Config-watching
+// daemons can build on it instead of reimplementing the deliver loop.
+package tracker
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// DeliverStream is the subset of the AtomicBroadcast deliver client the
+// tracker reads from. The caller is responsible for establishing the stream
+// and sending the seek request before handing it over.
+type DeliverStream interface {
+	Recv() (*ab.DeliverResponse, error)
+}
+
+// ConfigBlock is a config block received from a deliver stream with its
+// contents decoded.
+type ConfigBlock struct {
+	// BlockNumber is the number of the config block.
+	BlockNumber uint64
+	// Config is the full channel configuration carried by the block.
+	Config *cb.Config
+	// ConfigUpdate is the delta that produced Config. It is nil for blocks
+	// that carry no last update, such as a system channel genesis block.
+	ConfigUpdate *cb.ConfigUpdate
+}
+
+// Follow reads blocks from the given deliver stream and invokes onConfig for
+// each config block. Non-config blocks are skipped. Follow returns nil when
+// the stream terminates cleanly with io.EOF or a SUCCESS status, and
+// otherwise returns the stream, decode, or callback error.
+func Follow(stream DeliverStream, onConfig func(ConfigBlock) error) error {
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("receiving from deliver stream: %v", err)
+		}
+
+		switch t := resp.Type.(type) {
+		case *ab.DeliverResponse_Status:
+			if t.Status == cb.Status_SUCCESS {
+				return nil
+			}
+
+			return fmt.Errorf("deliver stream terminated with status %v", t.Status)
+		case *ab.DeliverResponse_Block:
+			if !protoutil.IsConfigBlock(t.Block) {
+				continue
+			}
+
+			configBlock, err := decodeConfigBlock(t.Block)
+			if err != nil {
+				return fmt.Errorf("decoding config block %d: %v", t.Block.Header.Number, err)
+			}
+
+			err = onConfig(configBlock)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("deliver response error: unknown type %T", t)
+		}
+	}
+}
+
+// decodeConfigBlock decodes the config envelope carried by a config block.
+func decodeConfigBlock(block *cb.Block) (ConfigBlock, error) {
+	envelope, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return ConfigBlock{}, err
+	}
+
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return ConfigBlock{}, err
+	}
+
+	configEnvelope := &cb.ConfigEnvelope{}
+	err = proto.Unmarshal(payload.Data, configEnvelope)
+	if err != nil {
+		return ConfigBlock{}, fmt.Errorf("unmarshaling config envelope: %v", err)
+	}
+
+	configBlock := ConfigBlock{
+		BlockNumber: block.Header.Number,
+		Config:      configEnvelope.Config,
+	}
+
+	if configEnvelope.LastUpdate != nil {
+		configBlock.ConfigUpdate, err = decodeConfigUpdate(configEnvelope.LastUpdate)
+		if err != nil {
+			return ConfigBlock{}, fmt.Errorf("decoding last update: %v", err)
+		}
+	}
+
+	return configBlock, nil
+}
+
+// decodeConfigUpdate unwraps the config update from a config update envelope.
+func decodeConfigUpdate(envelope *cb.Envelope) (*cb.ConfigUpdate, error) {
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	configUpdateEnvelope := &cb.ConfigUpdateEnvelope{}
+	err = proto.Unmarshal(payload.Data, configUpdateEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling config update envelope: %v", err)
+	}
+
+	configUpdate := &cb.ConfigUpdate{}
+	err = proto.Unmarshal(configUpdateEnvelope.ConfigUpdate, configUpdate)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling config update: %v", err)
+	}
+
+	return configUpdate, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracker
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/protoutil"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeStream replays a fixed sequence of deliver responses.
+type fakeStream struct {
+	responses []*ab.DeliverResponse
+	errs      []error
+}
+
+func (f *fakeStream) Recv() (*ab.DeliverResponse, error) {
+	if len(f.responses) == 0 {
+		if len(f.errs) > 0 {
+			err := f.errs[0]
+			f.errs = f.errs[1:]
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	resp := f.responses[0]
+	f.responses = f.responses[1:]
+	return resp, nil
+}
+
+func TestFollow(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	stream := &fakeStream{
+		responses: []*ab.DeliverResponse{
+			blockResponse(newTxBlock(0)),
+			blockResponse(newConfigBlock(1, 1, true)),
+			blockResponse(newTxBlock(2)),
+			blockResponse(newConfigBlock(3, 2, false)),
+			statusResponse(cb.Status_SUCCESS),
+		},
+	}
+
+	received := []ConfigBlock{}
+	err := Follow(stream, func(configBlock ConfigBlock) error {
+		received = append(received, configBlock)
+		return nil
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	gt.Expect(received).To(HaveLen(2))
+	gt.Expect(received[0].BlockNumber).To(Equal(uint64(1)))
+	gt.Expect(received[0].Config.Sequence).To(Equal(uint64(1)))
+	gt.Expect(received[0].ConfigUpdate.ChannelId).To(Equal("testchannel"))
+	gt.Expect(received[1].BlockNumber).To(Equal(uint64(3)))
+	gt.Expect(received[1].Config.Sequence).To(Equal(uint64(2)))
+	gt.Expect(received[1].ConfigUpdate).To(BeNil())
+}
+
+func TestFollowEOF(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	stream := &fakeStream{
+		responses: []*ab.DeliverResponse{
+			blockResponse(newConfigBlock(0, 1, false)),
+		},
+	}
+
+	received := []ConfigBlock{}
+	err := Follow(stream, func(configBlock ConfigBlock) error {
+		received = append(received, configBlock)
+		return nil
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(received).To(HaveLen(1))
+}
+
+func TestFollowFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		testName    string
+		stream      *fakeStream
+		onConfig    func(ConfigBlock) error
+		expectedErr string
+	}{
+		{
+			testName: "stream error",
+			stream: &fakeStream{
+				errs: []error{errors.New("connection reset")},
+			},
+			expectedErr: "receiving from deliver stream: connection reset",
+		},
+		{
+			testName: "terminal status",
+			stream: &fakeStream{
+				responses: []*ab.DeliverResponse{
+					statusResponse(cb.Status_SERVICE_UNAVAILABLE),
+				},
+			},
+			expectedErr: "deliver stream terminated with status SERVICE_UNAVAILABLE",
+		},
+		{
+			testName: "callback error",
+			stream: &fakeStream{
+				responses: []*ab.DeliverResponse{
+					blockResponse(newConfigBlock(0, 1, false)),
+				},
+			},
+			onConfig: func(ConfigBlock) error {
+				return errors.New("callback failed")
+			},
+			expectedErr: "callback failed",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			gt := NewGomegaWithT(t)
+
+			onConfig := test.onConfig
+			if onConfig == nil {
+				onConfig = func(ConfigBlock) error { return nil }
+			}
+
+			err := Follow(test.stream, onConfig)
+			gt.Expect(err).To(MatchError(test.expectedErr))
+		})
+	}
+}
+
+func blockResponse(block *cb.Block) *ab.DeliverResponse {
+	return &ab.DeliverResponse{
+		Type: &ab.DeliverResponse_Block{Block: block},
+	}
+}
+
+func statusResponse(status cb.Status) *ab.DeliverResponse {
+	return &ab.DeliverResponse{
+		Type: &ab.DeliverResponse_Status{Status: status},
+	}
+}
+
+// newConfigBlock returns a block carrying a config envelope with the given
+// sequence and, optionally, a last update for channel "testchannel".
+func newConfigBlock(blockNumber, sequence uint64, withLastUpdate bool) *cb.Block {
+	configEnvelope := &cb.ConfigEnvelope{
+		Config: &cb.Config{Sequence: sequence},
+	}
+
+	if withLastUpdate {
+		configUpdate := protoutil.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: "testchannel"})
+		configEnvelope.LastUpdate = &cb.Envelope{
+			Payload: protoutil.MarshalOrPanic(&cb.Payload{
+				Data: protoutil.MarshalOrPanic(&cb.ConfigUpdateEnvelope{ConfigUpdate: configUpdate}),
+			}),
+		}
+	}
+
+	return newBlock(blockNumber, cb.HeaderType_CONFIG, protoutil.MarshalOrPanic(configEnvelope))
+}
+
+// newTxBlock returns a block carrying a normal endorser transaction.
+func newTxBlock(blockNumber uint64) *cb.Block {
+	return newBlock(blockNumber, cb.HeaderType_ENDORSER_TRANSACTION, nil)
+}
+
+func newBlock(blockNumber uint64, headerType cb.HeaderType, data []byte) *cb.Block {
+	envelope := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+					Type: int32(headerType),
+				}),
+			},
+			Data: data,
+		}),
+	}
+
+	return &cb.Block{
+		Header: &cb.BlockHeader{Number: blockNumber},
+		Data: &cb.BlockData{
+			Data: [][]byte{protoutil.MarshalOrPanic(envelope)},
+		},
+	}
+}
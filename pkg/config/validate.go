@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ValidationOptions controls the optional address checks performed by the
+// validation helpers.
+type ValidationOptions struct {
+	// ResolveAddresses, when true, additionally verifies that each host
+	// name resolves in DNS. Literal IP addresses are not resolved.
+	ResolveAddresses bool
+}
+
+// ValidateAnchorPeers checks that every anchor peer declared by an
+// application org is a syntactically valid address.
+func ValidateAnchorPeers(config *cb.Config, options ValidationOptions) error {
+	applicationGroup, ok := config.ChannelGroup.Groups[ApplicationGroupKey]
+	if !ok {
+		return errors.New("application group does not exist in channel config")
+	}
+
+	for orgName, orgGroup := range applicationGroup.Groups {
+		if _, ok := orgGroup.Values[AnchorPeersKey]; !ok {
+			continue
+		}
+
+		anchorProtos := &pb.AnchorPeers{}
+		err := unmarshalConfigValueAtKey(orgGroup, AnchorPeersKey, anchorProtos)
+		if err != nil {
+			return err
+		}
+
+		for _, anchorPeer := range anchorProtos.AnchorPeers {
+			err = validateAddress(anchorPeer.Host, int(anchorPeer.Port), options)
+			if err != nil {
+				return fmt.Errorf("anchor peer %s:%d for org %s: %v", anchorPeer.Host, anchorPeer.Port, orgName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateOrdererEndpoints checks that every endpoint declared by an orderer
+// org is a syntactically valid host:port address.
+func ValidateOrdererEndpoints(config *cb.Config, options ValidationOptions) error {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return errors.New("orderer group does not exist in channel config")
+	}
+
+	for orgName, orgGroup := range ordererGroup.Groups {
+		if _, ok := orgGroup.Values[EndpointsKey]; !ok {
+			continue
+		}
+
+		endpointsProto := &cb.OrdererAddresses{}
+		err := unmarshalConfigValueAtKey(orgGroup, EndpointsKey, endpointsProto)
+		if err != nil {
+			return err
+		}
+
+		for _, endpoint := range endpointsProto.Addresses {
+			host, port, err := splitEndpoint(endpoint)
+			if err != nil {
+				return fmt.Errorf("endpoint '%s' for orderer org %s: %v", endpoint, orgName, err)
+			}
+
+			err = validateAddress(host, port, options)
+			if err != nil {
+				return fmt.Errorf("endpoint '%s' for orderer org %s: %v", endpoint, orgName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateConsenters checks that every etcdraft consenter has a
+// syntactically valid address and that its client and server TLS certs match
+// the consenter host. Nothing is validated for non-raft consensus types.
+func ValidateConsenters(config *cb.Config, options ValidationOptions) error {
+	ordererGroup, ok := config.ChannelGroup.Groups[OrdererGroupKey]
+	if !ok {
+		return errors.New("orderer group does not exist in channel config")
+	}
+
+	consenters, err := ordererConsenters(ordererGroup)
+	if err != nil {
+		return err
+	}
+
+	for _, consenter := range consenters {
+		err = validateAddress(consenter.Host, int(consenter.Port), options)
+		if err != nil {
+			return fmt.Errorf("consenter %s:%d: %v", consenter.Host, consenter.Port, err)
+		}
+
+		err = validateTLSCertForHost(consenter.ClientTlsCert, consenter.Host)
+		if err != nil {
+			return fmt.Errorf("client TLS cert for consenter %s:%d: %v", consenter.Host, consenter.Port, err)
+		}
+
+		err = validateTLSCertForHost(consenter.ServerTlsCert, consenter.Host)
+		if err != nil {
+			return fmt.Errorf("server TLS cert for consenter %s:%d: %v", consenter.Host, consenter.Port, err)
+		}
+	}
+
+	return nil
+}
+
+// splitEndpoint splits a host:port endpoint and parses the port.
+func splitEndpoint(endpoint string) (string, int, error) {
+	host, portString, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", 0, fmt.Errorf("parsing address: %v", err)
+	}
+
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return "", 0, fmt.Errorf("parsing port '%s': %v", portString, err)
+	}
+
+	return host, port, nil
+}
+
+// validateAddress checks a host and port pair for syntactic validity and,
+// when requested, that the host resolves in DNS.
+func validateAddress(host string, port int, options ValidationOptions) error {
+	if host == "" {
+		return errors.New("host is empty")
+	}
+
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("port %d is outside the valid range", port)
+	}
+
+	if options.ResolveAddresses && net.ParseIP(host) == nil {
+		_, err := net.LookupHost(host)
+		if err != nil {
+			return fmt.Errorf("host '%s' does not resolve: %v", host, err)
+		}
+	}
+
+	return nil
+}
+
+// validateTLSCertForHost checks that a PEM encoded TLS cert is parseable and
+// carries a SAN matching the given host.
+func validateTLSCertForHost(pemCert []byte, host string) error {
+	block, _ := pem.Decode(pemCert)
+	if block == nil {
+		return errors.New("no PEM data found in cert")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parsing cert: %v", err)
+	}
+
+	err = cert.VerifyHostname(host)
+	if err != nil {
+		return fmt.Errorf("cert does not match host: %v", err)
+	}
+
+	return nil
+}
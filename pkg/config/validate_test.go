@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ob "github.com/hyperledger/fabric-protos-go/orderer"
+	eb "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateAnchorPeers(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseValidateApplicationConfig(t)
+
+	err := AddAnchorPeer(config, "Org1", AnchorPeer{Host: "peer0.org1.example.com", Port: 7051})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = ValidateAnchorPeers(config, ValidationOptions{})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = AddAnchorPeer(config, "Org2", AnchorPeer{Host: "peer0.org2.example.com", Port: 0})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = ValidateAnchorPeers(config, ValidationOptions{})
+	gt.Expect(err).To(MatchError("anchor peer peer0.org2.example.com:0 for org Org2: port 0 is outside the valid range"))
+}
+
+func TestValidateAnchorPeersMissingApplicationGroup(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{},
+		},
+	}
+
+	err := ValidateAnchorPeers(config, ValidationOptions{})
+	gt.Expect(err).To(MatchError("application group does not exist in channel config"))
+}
+
+func TestValidateOrdererEndpoints(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	err := ValidateOrdererEndpoints(config, ValidationOptions{})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	// localhost resolves via the hosts file, so resolution can be verified
+	// without the network
+	err = ValidateOrdererEndpoints(config, ValidationOptions{ResolveAddresses: true})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	orgGroup := config.ChannelGroup.Groups[OrdererGroupKey].Groups["OrdererOrg"]
+	err = addValue(orgGroup, endpointsValue([]string{"no-port-here"}), AdminsPolicyKey)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	err = ValidateOrdererEndpoints(config, ValidationOptions{})
+	gt.Expect(err).To(MatchError("endpoint 'no-port-here' for orderer org OrdererOrg: parsing address: address no-port-here: missing port in address"))
+}
+
+func TestValidateConsenters(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseValidateConsenterConfig(t, map[string][]byte{
+		"node-1.example.com": selfSignedTLSCertPEM(t, "node-1.example.com"),
+		"node-2.example.com": selfSignedTLSCertPEM(t, "node-2.example.com"),
+	})
+
+	err := ValidateConsenters(config, ValidationOptions{})
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestValidateConsentersFailures(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	config := baseValidateConsenterConfig(t, map[string][]byte{
+		"node-1.example.com": []byte("not a cert"),
+	})
+
+	err := ValidateConsenters(config, ValidationOptions{})
+	gt.Expect(err).To(MatchError("client TLS cert for consenter node-1.example.com:7050: no PEM data found in cert"))
+
+	config = baseValidateConsenterConfig(t, map[string][]byte{
+		"node-1.example.com": selfSignedTLSCertPEM(t, "node-2.example.com"),
+	})
+
+	err = ValidateConsenters(config, ValidationOptions{})
+	gt.Expect(err).To(MatchError("client TLS cert for consenter node-1.example.com:7050: cert does not match host: x509: certificate is valid for node-2.example.com, not node-1.example.com"))
+}
+
+func TestValidateConsentersNonRaft(t *testing.T) {
+	t.Parallel()
+
+	gt := NewGomegaWithT(t)
+
+	// the base orderer config uses the solo consensus type, which carries no
+	// consenters to validate
+	err := ValidateConsenters(baseOrdererConfig(t), ValidationOptions{})
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+// baseValidateApplicationConfig returns a config with fully populated
+// application orgs.
+func baseValidateApplicationConfig(t *testing.T) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	applicationGroup, err := newApplicationGroup(baseApplication())
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config := &cb.Config{
+		ChannelGroup: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				ApplicationGroupKey: applicationGroup,
+			},
+		},
+	}
+
+	for _, org := range baseApplication().Organizations {
+		org.Policies = applicationOrgStandardPolicies()
+		err = AddApplicationOrg(config, org)
+		gt.Expect(err).NotTo(HaveOccurred())
+	}
+
+	return config
+}
+
+// baseValidateConsenterConfig returns an etcdraft config with one consenter
+// per entry in certsByHost, listening on port 7050.
+func baseValidateConsenterConfig(t *testing.T, certsByHost map[string][]byte) *cb.Config {
+	gt := NewGomegaWithT(t)
+
+	config := baseOrdererConfig(t)
+
+	consenters := []*eb.Consenter{}
+	for host, cert := range certsByHost {
+		consenters = append(consenters, &eb.Consenter{
+			Host:          host,
+			Port:          7050,
+			ClientTlsCert: cert,
+			ServerTlsCert: cert,
+		})
+	}
+
+	etcdRaftMetadata, err := proto.Marshal(&eb.ConfigMetadata{Consenters: consenters})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	consensusType, err := proto.Marshal(&ob.ConsensusType{
+		Type:     ConsensusTypeEtcdRaft,
+		Metadata: etcdRaftMetadata,
+	})
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	config.ChannelGroup.Groups[OrdererGroupKey].Values[ConsensusTypeKey].Value = consensusType
+
+	return config
+}
+
+// selfSignedTLSCertPEM generates a PEM encoded self-signed certificate with
+// a SAN for the given host.
+func selfSignedTLSCertPEM(t *testing.T, host string) []byte {
+	gt := NewGomegaWithT(t)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{host},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}